@@ -48,6 +48,9 @@ type Config struct {
 	// "SingleDimensionRollupOnly" - Enable single dimension rollup
 	// "NoDimensionRollup" - No dimension rollup (only keep original metrics which contain all dimensions)
 	DimensionRollupOption string `mapstructure:"dimension_rollup_option"`
+	// DimensionRollupRules is the list of rules overriding DimensionRollupOption for the metrics
+	// matching their namespace and metric name selectors. The first matching rule is applied.
+	DimensionRollupRules []*DimensionRollupRule `mapstructure:"dimension_rollup_rules"`
 	// ParseJSONEncodedAttributeValues is an array of attribute keys whose corresponding values are JSON-encoded as strings.
 	// Those strings will be decoded to its original json structure.
 	ParseJSONEncodedAttributeValues []string `mapstructure:"parse_json_encoded_attr_values"`
@@ -88,8 +91,19 @@ type MetricDescriptor struct {
 	overwrite bool `mapstructure:"overwrite"`
 }
 
-// Validate filters out invalid metricDeclarations and metricDescriptors
+// Validate filters out invalid dimensionRollupRules, metricDeclarations and metricDescriptors
 func (config *Config) Validate() error {
+	validRollupRules := []*DimensionRollupRule{}
+	for _, rule := range config.DimensionRollupRules {
+		err := rule.init()
+		if err != nil {
+			config.logger.Warn("Dropped dimension rollup rule.", zap.Error(err))
+		} else {
+			validRollupRules = append(validRollupRules, rule)
+		}
+	}
+	config.DimensionRollupRules = validRollupRules
+
 	validDeclarations := []*MetricDeclaration{}
 	for _, declaration := range config.MetricDeclarations {
 		err := declaration.init(config.logger)
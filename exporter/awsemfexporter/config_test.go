@@ -65,6 +65,7 @@ func TestLoadConfig(t *testing.T) {
 			DimensionRollupOption:           "ZeroAndSingleDimensionRollup",
 			OutputDestination:               "cloudwatch",
 			ParseJSONEncodedAttributeValues: make([]string, 0),
+			DimensionRollupRules:            []*DimensionRollupRule{},
 			MetricDeclarations:              []*MetricDeclaration{},
 			MetricDescriptors:               []MetricDescriptor{},
 		}, r1)
@@ -90,6 +91,7 @@ func TestLoadConfig(t *testing.T) {
 			OutputDestination:               "cloudwatch",
 			ResourceToTelemetrySettings:     exporterhelper.ResourceToTelemetrySettings{Enabled: true},
 			ParseJSONEncodedAttributeValues: make([]string, 0),
+			DimensionRollupRules:            []*DimensionRollupRule{},
 			MetricDeclarations:              []*MetricDeclaration{},
 			MetricDescriptors:               []MetricDescriptor{},
 		})
@@ -111,10 +113,17 @@ func TestConfigValidate(t *testing.T) {
 		DimensionRollupOption:       "ZeroAndSingleDimensionRollup",
 		ResourceToTelemetrySettings: exporterhelper.ResourceToTelemetrySettings{Enabled: true},
 		MetricDescriptors:           incorrectDescriptor,
-		logger:                      zap.NewNop(),
+		DimensionRollupRules: []*DimensionRollupRule{
+			{DimensionRollupOption: "NoDimensionRollup"},
+			{DimensionRollupOption: "INVALID"},
+		},
+		logger: zap.NewNop(),
 	}
 	assert.NoError(t, cfg.Validate())
 
+	assert.Equal(t, 1, len(cfg.DimensionRollupRules))
+	assert.Equal(t, "NoDimensionRollup", cfg.DimensionRollupRules[0].DimensionRollupOption)
+
 	assert.Equal(t, 2, len(cfg.MetricDescriptors))
 	assert.Equal(t, []MetricDescriptor{
 		{unit: "Count", metricName: "apiserver_total", overwrite: true},
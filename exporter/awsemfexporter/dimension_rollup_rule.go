@@ -0,0 +1,98 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsemfexporter
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+const noDimensionRollup = "NoDimensionRollup"
+
+// DimensionRollupRule overrides the dimension rollup option for the metrics
+// matching its namespace and metric name selectors.
+type DimensionRollupRule struct {
+	// NamespaceSelectors is a list of regex strings to be matched against metric
+	// namespaces. A rule without namespace selectors matches every namespace.
+	NamespaceSelectors []string `mapstructure:"namespace_selectors"`
+	// MetricNameSelectors is a list of regex strings to be matched against metric
+	// names. A rule without metric name selectors matches every metric.
+	MetricNameSelectors []string `mapstructure:"metric_name_selectors"`
+	// DimensionRollupOption is the rollup option applied to matching metrics, one of
+	// "ZeroAndSingleDimensionRollup", "SingleDimensionRollupOnly" or "NoDimensionRollup".
+	DimensionRollupOption string `mapstructure:"dimension_rollup_option"`
+
+	namespaceRegexList  []*regexp.Regexp
+	metricNameRegexList []*regexp.Regexp
+}
+
+// init validates the rule and compiles its regex strings.
+func (r *DimensionRollupRule) init() (err error) {
+	switch r.DimensionRollupOption {
+	case zeroAndSingleDimensionRollup, singleDimensionRollupOnly, noDimensionRollup:
+	case "":
+		return errors.New("invalid dimension rollup rule: no dimension rollup option defined")
+	default:
+		return fmt.Errorf("invalid dimension rollup rule: unknown dimension rollup option %q", r.DimensionRollupOption)
+	}
+
+	if r.namespaceRegexList, err = compileSelectors(r.NamespaceSelectors); err != nil {
+		return err
+	}
+	r.metricNameRegexList, err = compileSelectors(r.MetricNameSelectors)
+	return err
+}
+
+// Matches returns true if the given namespace and metric name match the rule.
+func (r *DimensionRollupRule) Matches(namespace, metricName string) bool {
+	return matchesAny(r.namespaceRegexList, namespace) && matchesAny(r.metricNameRegexList, metricName)
+}
+
+func compileSelectors(selectors []string) ([]*regexp.Regexp, error) {
+	regexList := make([]*regexp.Regexp, len(selectors))
+	for i, selector := range selectors {
+		regex, err := regexp.Compile(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dimension rollup rule: %w", err)
+		}
+		regexList[i] = regex
+	}
+	return regexList, nil
+}
+
+// matchesAny returns true if s matches any of the regexes, or if there are none.
+func matchesAny(regexList []*regexp.Regexp, s string) bool {
+	if len(regexList) == 0 {
+		return true
+	}
+	for _, regex := range regexList {
+		if regex.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// dimensionRollupOption returns the dimension rollup option of the first rule
+// matching the given namespace and metric name, or the global option if none does.
+func (config *Config) dimensionRollupOption(namespace, metricName string) string {
+	for _, rule := range config.DimensionRollupRules {
+		if rule.Matches(namespace, metricName) {
+			return rule.DimensionRollupOption
+		}
+	}
+	return config.DimensionRollupOption
+}
@@ -0,0 +1,84 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsemfexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDimensionRollupRuleInit(t *testing.T) {
+	rule := &DimensionRollupRule{
+		NamespaceSelectors:    []string{"^ContainerInsights$"},
+		MetricNameSelectors:   []string{"^pod_.*", "^node_.*"},
+		DimensionRollupOption: noDimensionRollup,
+	}
+	assert.NoError(t, rule.init())
+	assert.Len(t, rule.namespaceRegexList, 1)
+	assert.Len(t, rule.metricNameRegexList, 2)
+
+	rule = &DimensionRollupRule{}
+	assert.EqualError(t, rule.init(), "invalid dimension rollup rule: no dimension rollup option defined")
+
+	rule = &DimensionRollupRule{DimensionRollupOption: "AllDimensionRollup"}
+	assert.EqualError(t, rule.init(), `invalid dimension rollup rule: unknown dimension rollup option "AllDimensionRollup"`)
+
+	rule = &DimensionRollupRule{
+		MetricNameSelectors:   []string{"("},
+		DimensionRollupOption: singleDimensionRollupOnly,
+	}
+	assert.Error(t, rule.init())
+}
+
+func TestDimensionRollupRuleMatches(t *testing.T) {
+	rule := &DimensionRollupRule{
+		NamespaceSelectors:    []string{"^ContainerInsights$"},
+		MetricNameSelectors:   []string{"^pod_.*"},
+		DimensionRollupOption: noDimensionRollup,
+	}
+	assert.NoError(t, rule.init())
+	assert.True(t, rule.Matches("ContainerInsights", "pod_cpu_utilization"))
+	assert.False(t, rule.Matches("ContainerInsights", "node_cpu_utilization"))
+	assert.False(t, rule.Matches("ECS/ContainerInsights", "pod_cpu_utilization"))
+
+	rule = &DimensionRollupRule{DimensionRollupOption: noDimensionRollup}
+	assert.NoError(t, rule.init())
+	assert.True(t, rule.Matches("ContainerInsights", "pod_cpu_utilization"))
+}
+
+func TestConfigDimensionRollupOption(t *testing.T) {
+	config := &Config{
+		DimensionRollupOption: zeroAndSingleDimensionRollup,
+		DimensionRollupRules: []*DimensionRollupRule{
+			{
+				NamespaceSelectors:    []string{"^ContainerInsights$"},
+				MetricNameSelectors:   []string{"^pod_.*"},
+				DimensionRollupOption: singleDimensionRollupOnly,
+			},
+			{
+				NamespaceSelectors:    []string{"^ContainerInsights$"},
+				DimensionRollupOption: noDimensionRollup,
+			},
+		},
+	}
+	for _, rule := range config.DimensionRollupRules {
+		assert.NoError(t, rule.init())
+	}
+
+	assert.Equal(t, singleDimensionRollupOnly, config.dimensionRollupOption("ContainerInsights", "pod_cpu_utilization"))
+	assert.Equal(t, noDimensionRollup, config.dimensionRollupOption("ContainerInsights", "node_cpu_utilization"))
+	assert.Equal(t, zeroAndSingleDimensionRollup, config.dimensionRollupOption("default", "pod_cpu_utilization"))
+}
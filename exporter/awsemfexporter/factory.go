@@ -47,6 +47,7 @@ func createDefaultConfig() config.Exporter {
 		Namespace:                       "",
 		DimensionRollupOption:           "ZeroAndSingleDimensionRollup",
 		ParseJSONEncodedAttributeValues: make([]string, 0),
+		DimensionRollupRules:            make([]*DimensionRollupRule, 0),
 		MetricDeclarations:              make([]*MetricDeclaration, 0),
 		MetricDescriptors:               make([]MetricDescriptor, 0),
 		OutputDestination:               "cloudwatch",
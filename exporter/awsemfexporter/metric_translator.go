@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"time"
 
 	"go.opentelemetry.io/collector/model/pdata"
@@ -165,9 +166,10 @@ func translateGroupedMetricToCWMetric(groupedMetric *groupedMetric, config *Conf
 	var cWMeasurements []cWMeasurement
 	if len(config.MetricDeclarations) == 0 {
 		// If there are no metric declarations defined, translate grouped metric
-		// into the corresponding CW Measurement
-		cwm := groupedMetricToCWMeasurement(groupedMetric, config)
-		cWMeasurements = []cWMeasurement{cwm}
+		// into the corresponding CW Measurement, one per dimension rollup option
+		for _, group := range splitGroupedMetricByRollupOption(groupedMetric, config) {
+			cWMeasurements = append(cWMeasurements, groupedMetricToCWMeasurement(group, config))
+		}
 	} else {
 		// If metric declarations are defined, filter grouped metric's metrics using
 		// metric declarations and translate into the corresponding list of CW Measurements
@@ -181,10 +183,55 @@ func translateGroupedMetricToCWMetric(groupedMetric *groupedMetric, config *Conf
 	}
 }
 
+// splitGroupedMetricByRollupOption splits a grouped metric into grouped metrics whose metrics
+// share the same dimension rollup option.
+func splitGroupedMetricByRollupOption(gm *groupedMetric, config *Config) []*groupedMetric {
+	if len(config.DimensionRollupRules) == 0 {
+		return []*groupedMetric{gm}
+	}
+
+	groups := make(map[string]*groupedMetric)
+	for metricName, info := range gm.metrics {
+		option := config.dimensionRollupOption(gm.metadata.namespace, metricName)
+		group, ok := groups[option]
+		if !ok {
+			group = &groupedMetric{
+				labels:   gm.labels,
+				metrics:  make(map[string]*metricInfo),
+				metadata: gm.metadata,
+			}
+			groups[option] = group
+		}
+		group.metrics[metricName] = info
+	}
+
+	// Sort the groups by rollup option so that measurements are always emitted in the same order
+	options := make([]string, 0, len(groups))
+	for option := range groups {
+		options = append(options, option)
+	}
+	sort.Strings(options)
+	result := make([]*groupedMetric, 0, len(groups))
+	for _, option := range options {
+		result = append(result, groups[option])
+	}
+	return result
+}
+
+// groupedMetricRollupOption returns the dimension rollup option of a grouped metric
+// whose metrics share the same dimension rollup option.
+func groupedMetricRollupOption(groupedMetric *groupedMetric, config *Config) string {
+	for metricName := range groupedMetric.metrics {
+		return config.dimensionRollupOption(groupedMetric.metadata.namespace, metricName)
+	}
+	return config.DimensionRollupOption
+}
+
 // groupedMetricToCWMeasurement creates a single CW Measurement from a grouped metric.
+// All the metrics of the grouped metric must share the same dimension rollup option.
 func groupedMetricToCWMeasurement(groupedMetric *groupedMetric, config *Config) cWMeasurement {
 	labels := groupedMetric.labels
-	dimensionRollupOption := config.DimensionRollupOption
+	dimensionRollupOption := groupedMetricRollupOption(groupedMetric, config)
 
 	// Create a dimension set containing list of label names
 	dimSet := make([]string, len(labels))
@@ -262,8 +309,9 @@ func groupedMetricToCWMeasurementsWithFilters(groupedMetric *groupedMetric, conf
 
 	// Group metrics by matched metric declarations
 	type metricDeclarationGroup struct {
-		metricDeclIdxList []int
-		metrics           []map[string]string
+		metricDeclIdxList     []int
+		dimensionRollupOption string
+		metrics               []map[string]string
 	}
 
 	metricDeclGroups := make(map[string]*metricDeclarationGroup)
@@ -290,13 +338,15 @@ func groupedMetricToCWMeasurementsWithFilters(groupedMetric *groupedMetric, conf
 		if metricInfo.unit != "" {
 			metric["Unit"] = metricInfo.unit
 		}
-		metricDeclKey := fmt.Sprint(metricDeclIdx)
+		dimensionRollupOption := config.dimensionRollupOption(groupedMetric.metadata.namespace, metricName)
+		metricDeclKey := fmt.Sprint(metricDeclIdx, dimensionRollupOption)
 		if group, ok := metricDeclGroups[metricDeclKey]; ok {
 			group.metrics = append(group.metrics, metric)
 		} else {
 			metricDeclGroups[metricDeclKey] = &metricDeclarationGroup{
-				metricDeclIdxList: metricDeclIdx,
-				metrics:           []map[string]string{metric},
+				metricDeclIdxList:     metricDeclIdx,
+				dimensionRollupOption: dimensionRollupOption,
+				metrics:               []map[string]string{metric},
 			}
 		}
 	}
@@ -305,12 +355,12 @@ func groupedMetricToCWMeasurementsWithFilters(groupedMetric *groupedMetric, conf
 		return
 	}
 
-	// Apply single/zero dimension rollup to labels
-	rollupDimensionArray := dimensionRollup(config.DimensionRollupOption, labels)
-
 	// Translate each group into a CW Measurement
 	cWMeasurements = make([]cWMeasurement, 0, len(metricDeclGroups))
 	for _, group := range metricDeclGroups {
+		// Apply single/zero dimension rollup to labels
+		rollupDimensionArray := dimensionRollup(group.dimensionRollupOption, labels)
+
 		var dimensions [][]string
 		// Extract dimensions from matched metric declarations
 		for _, metricDeclIdx := range group.metricDeclIdxList {
@@ -28,6 +28,7 @@ import (
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/model/pdata"
 	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
 	"go.opentelemetry.io/collector/translator/internaldata"
@@ -1248,6 +1249,82 @@ func TestGroupedMetricToCWMeasurement(t *testing.T) {
 	}
 }
 
+func TestGroupedMetricToCWMeasurementWithRollupRules(t *testing.T) {
+	groupedMetric := &groupedMetric{
+		labels: map[string]string{
+			"a": "A",
+			"b": "B",
+		},
+		metrics: map[string]*metricInfo{
+			"metric1": {
+				value: 1,
+				unit:  "Count",
+			},
+			"metric2": {
+				value: 2,
+				unit:  "Count",
+			},
+		},
+		metadata: cWMetricMetadata{
+			groupedMetricMetadata: groupedMetricMetadata{
+				namespace:   "Namespace",
+				timestampMs: int64(1596151098037),
+			},
+		},
+	}
+	config := &Config{
+		DimensionRollupOption: zeroAndSingleDimensionRollup,
+		DimensionRollupRules: []*DimensionRollupRule{
+			{
+				NamespaceSelectors:    []string{"^Namespace$"},
+				MetricNameSelectors:   []string{"^metric2$"},
+				DimensionRollupOption: noDimensionRollup,
+			},
+		},
+		logger: zap.NewNop(),
+	}
+	for _, rule := range config.DimensionRollupRules {
+		assert.NoError(t, rule.init())
+	}
+
+	cWMetric := translateGroupedMetricToCWMetric(groupedMetric, config)
+	require.Len(t, cWMetric.measurements, 2)
+	// Measurements are ordered by rollup option
+	assert.Equal(t, "metric2", cWMetric.measurements[0].Metrics[0]["Name"])
+	assert.Equal(t, "metric1", cWMetric.measurements[1].Metrics[0]["Name"])
+
+	expectedDims := map[string][][]string{
+		"metric1": {{"a", "b"}, {}, {"a"}, {"b"}},
+		"metric2": {{"a", "b"}},
+	}
+	for _, cwm := range cWMetric.measurements {
+		require.Len(t, cwm.Metrics, 1)
+		assertDimsEqual(t, expectedDims[cwm.Metrics[0]["Name"]], cwm.Dimensions)
+	}
+
+	// Metric declarations apply the rollup option of each metric as well
+	config.MetricDeclarations = []*MetricDeclaration{
+		{
+			Dimensions:          [][]string{{"a"}},
+			MetricNameSelectors: []string{"metric.*"},
+		},
+	}
+	for _, decl := range config.MetricDeclarations {
+		assert.NoError(t, decl.init(zap.NewNop()))
+	}
+	cWMeasurements := groupedMetricToCWMeasurementsWithFilters(groupedMetric, config)
+	require.Len(t, cWMeasurements, 2)
+
+	expectedDims = map[string][][]string{
+		"metric1": {{"a"}, {}, {"b"}},
+		"metric2": {{"a"}},
+	}
+	for _, cwm := range cWMeasurements {
+		require.Len(t, cwm.Metrics, 1)
+		assertDimsEqual(t, expectedDims[cwm.Metrics[0]["Name"]], cwm.Dimensions)
+	}
+}
+
 func TestGroupedMetricToCWMeasurementsWithFilters(t *testing.T) {
 	timestamp := int64(1596151098037)
 	namespace := "Namespace"
@@ -60,7 +60,7 @@ func newTracesExporter(
 					spans := rspans.InstrumentationLibrarySpans().At(j).Spans()
 					for k := 0; k < spans.Len(); k++ {
 						document, localErr := translator.MakeSegmentDocumentString(spans.At(k), resource,
-							config.(*Config).IndexedAttributes, config.(*Config).IndexAllAttributes)
+							config.(*Config).IndexedAttributes, config.(*Config).IndexAllAttributes, config.(*Config).TraceIDPassThrough)
 						if localErr != nil {
 							logger.Debug("Error translating span.", zap.Error(localErr))
 							continue
@@ -33,4 +33,9 @@ type Config struct {
 	// Set to true to convert all OpenTelemetry attributes to X-Ray annotation (indexed) ignoring the IndexedAttributes option.
 	// Default value: false
 	IndexAllAttributes bool `mapstructure:"index_all_attributes"`
+	// Set to true to export spans whose trace ID doesn't start with a recent epoch, such as W3C random
+	// trace IDs, instead of dropping them. Such trace IDs are deterministically translated to X-Ray trace IDs
+	// and the original OpenTelemetry trace ID is kept in the otel_trace_id annotation of every segment.
+	// Default value: false
+	TraceIDPassThrough bool `mapstructure:"trace_id_passthrough"`
 }
@@ -59,13 +59,17 @@ const (
 	identifierOffset = 11 // offset of identifier within traceID
 )
 
+// OTelTraceIDAnnotation is the annotation holding the original OpenTelemetry trace ID
+// when trace ID pass-through is enabled.
+const OTelTraceIDAnnotation = "otel_trace_id"
+
 var (
 	writers = newWriterPool(2048)
 )
 
 // MakeSegmentDocumentString converts an OpenTelemetry Span to an X-Ray Segment and then serialzies to JSON
-func MakeSegmentDocumentString(span pdata.Span, resource pdata.Resource, indexedAttrs []string, indexAllAttrs bool) (string, error) {
-	segment, err := MakeSegment(span, resource, indexedAttrs, indexAllAttrs)
+func MakeSegmentDocumentString(span pdata.Span, resource pdata.Resource, indexedAttrs []string, indexAllAttrs bool, traceIDPassThrough bool) (string, error) {
+	segment, err := MakeSegment(span, resource, indexedAttrs, indexAllAttrs, traceIDPassThrough)
 	if err != nil {
 		return "", err
 	}
@@ -78,8 +82,12 @@ func MakeSegmentDocumentString(span pdata.Span, resource pdata.Resource, indexed
 	return jsonStr, nil
 }
 
-// MakeSegment converts an OpenTelemetry Span to an X-Ray Segment
-func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string, indexAllAttrs bool) (*awsxray.Segment, error) {
+// MakeSegment converts an OpenTelemetry Span to an X-Ray Segment.
+//
+// When traceIDPassThrough is set, trace IDs that can't be converted to the X-Ray format,
+// such as W3C random trace IDs, are translated deterministically instead of being rejected,
+// and the original trace ID is kept in the otel_trace_id annotation.
+func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string, indexAllAttrs bool, traceIDPassThrough bool) (*awsxray.Segment, error) {
 	var segmentType string
 
 	storeResource := true
@@ -93,7 +101,10 @@ func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string
 	// convert trace id
 	traceID, err := convertToAmazonTraceID(span.TraceID())
 	if err != nil {
-		return nil, err
+		if !traceIDPassThrough {
+			return nil, err
+		}
+		traceID = generateAmazonTraceID(span.TraceID(), time.Now())
 	}
 
 	var (
@@ -176,6 +187,13 @@ func MakeSegment(span pdata.Span, resource pdata.Resource, indexedAttrs []string
 		namespace = "remote"
 	}
 
+	if traceIDPassThrough {
+		if annotations == nil {
+			annotations = map[string]interface{}{}
+		}
+		annotations[OTelTraceIDAnnotation] = span.TraceID().HexString()
+	}
+
 	return &awsxray.Segment{
 		ID:          awsxray.String(span.SpanID().HexString()),
 		TraceID:     awsxray.String(traceID),
@@ -303,6 +321,37 @@ func convertToAmazonTraceID(traceID pdata.TraceID) (string, error) {
 	return string(content[0:traceIDLength]), nil
 }
 
+// passThroughEpochPeriod is the period the epoch of the X-Ray trace IDs generated from other
+// trace IDs is rounded down to, well within the 30 days X-Ray accepts trace IDs for.
+const passThroughEpochPeriod = 7 * 24 * time.Hour
+
+// generateAmazonTraceID derives an X-Ray trace ID from a trace ID that doesn't start with a
+// recent epoch, such as a W3C random trace ID.
+//
+// X-Ray rejects trace IDs whose epoch is out of its 30 day window, so the epoch is the current
+// time rounded down to passThroughEpochPeriod, and the last 12 bytes of the trace ID are the
+// identifier. All spans of a trace exported within the same period map to the same X-Ray trace ID,
+// no matter when they started.
+func generateAmazonTraceID(traceID pdata.TraceID, now time.Time) string {
+	var (
+		content      = [traceIDLength]byte{}
+		traceIDBytes = traceID.Bytes()
+		epoch        = now.Unix()
+		b            = [4]byte{}
+	)
+
+	epoch -= epoch % int64(passThroughEpochPeriod/time.Second)
+	binary.BigEndian.PutUint32(b[0:4], uint32(epoch))
+
+	content[0] = '1'
+	content[1] = '-'
+	hex.Encode(content[2:10], b[0:4])
+	content[10] = '-'
+	hex.Encode(content[identifierOffset:], traceIDBytes[4:16])
+
+	return string(content[0:traceIDLength])
+}
+
 func timestampToFloatSeconds(ts pdata.Timestamp) float64 {
 	return float64(ts) / float64(time.Second)
 }
@@ -18,11 +18,13 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math/rand"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/model/pdata"
 	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
 
@@ -58,12 +60,12 @@ func TestClientSpanWithAwsSdkClient(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, 0, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, nil, false)
+	segment, _ := MakeSegment(span, resource, nil, false, false)
 	assert.Equal(t, "DynamoDB", *segment.Name)
 	assert.Equal(t, "aws", *segment.Namespace)
 	assert.Equal(t, "subsegment", *segment.Type)
 
-	jsonStr, err := MakeSegmentDocumentString(span, resource, nil, false)
+	jsonStr, err := MakeSegmentDocumentString(span, resource, nil, false, false)
 
 	assert.NotNil(t, jsonStr)
 	assert.Nil(t, err)
@@ -88,7 +90,7 @@ func TestClientSpanWithPeerService(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, 0, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, nil, false)
+	segment, _ := MakeSegment(span, resource, nil, false, false)
 	assert.Equal(t, "cats-table", *segment.Name)
 }
 
@@ -111,7 +113,7 @@ func TestServerSpanWithInternalServerError(t *testing.T) {
 	timeEvents := constructTimedEventsWithSentMessageEvent(span.StartTimestamp())
 	timeEvents.CopyTo(span.Events())
 
-	segment, _ := MakeSegment(span, resource, nil, false)
+	segment, _ := MakeSegment(span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.NotNil(t, segment.Cause)
@@ -138,7 +140,7 @@ func TestServerSpanWithThrottle(t *testing.T) {
 	timeEvents := constructTimedEventsWithSentMessageEvent(span.StartTimestamp())
 	timeEvents.CopyTo(span.Events())
 
-	segment, _ := MakeSegment(span, resource, nil, false)
+	segment, _ := MakeSegment(span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.NotNil(t, segment.Cause)
@@ -154,7 +156,7 @@ func TestServerSpanNoParentId(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeOk, "OK", nil)
 
-	segment, _ := MakeSegment(span, resource, nil, false)
+	segment, _ := MakeSegment(span, resource, nil, false, false)
 
 	assert.Empty(t, segment.ParentID)
 }
@@ -169,7 +171,7 @@ func TestSpanNoParentId(t *testing.T) {
 	span.SetStartTimestamp(pdata.TimestampFromTime(time.Now()))
 	span.SetEndTimestamp(pdata.TimestampFromTime(time.Now().Add(10)))
 	resource := pdata.NewResource()
-	segment, _ := MakeSegment(span, resource, nil, false)
+	segment, _ := MakeSegment(span, resource, nil, false, false)
 
 	assert.Empty(t, segment.ParentID)
 	assert.Nil(t, segment.Type)
@@ -185,7 +187,7 @@ func TestSpanWithNoStatus(t *testing.T) {
 	span.SetEndTimestamp(pdata.TimestampFromTime(time.Now().Add(10)))
 
 	resource := pdata.NewResource()
-	segment, _ := MakeSegment(span, resource, nil, false)
+	segment, _ := MakeSegment(span, resource, nil, false, false)
 	assert.NotNil(t, segment)
 }
 
@@ -205,7 +207,7 @@ func TestClientSpanWithDbComponent(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, pdata.StatusCodeUnset, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, nil, false)
+	segment, _ := MakeSegment(span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.NotNil(t, segment.SQL)
@@ -246,7 +248,7 @@ func TestClientSpanWithHttpHost(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, pdata.StatusCodeUnset, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, nil, false)
+	segment, _ := MakeSegment(span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, "foo.com", *segment.Name)
@@ -265,7 +267,7 @@ func TestClientSpanWithoutHttpHost(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, pdata.StatusCodeUnset, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, nil, false)
+	segment, _ := MakeSegment(span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, "bar.com", *segment.Name)
@@ -285,7 +287,7 @@ func TestClientSpanWithRpcHost(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, pdata.StatusCodeUnset, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, nil, false)
+	segment, _ := MakeSegment(span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, "com.foo.AnimalService", *segment.Name)
@@ -307,11 +309,87 @@ func TestSpanWithInvalidTraceId(t *testing.T) {
 	traceID[0] = 0x11
 	span.SetTraceID(pdata.NewTraceID(traceID))
 
-	_, err := MakeSegmentDocumentString(span, resource, nil, false)
+	_, err := MakeSegmentDocumentString(span, resource, nil, false, false)
 
 	assert.NotNil(t, err)
 }
 
+func TestSpanWithInvalidTraceIdPassThrough(t *testing.T) {
+	spanName := "platformapi.widgets.searchWidgets"
+	attributes := make(map[string]interface{})
+	resource := constructDefaultResource()
+	span := constructClientSpan(pdata.InvalidSpanID(), spanName, pdata.StatusCodeUnset, "OK", attributes)
+	traceID := span.TraceID().Bytes()
+	traceID[0] = 0x11
+	span.SetTraceID(pdata.NewTraceID(traceID))
+
+	segment, err := MakeSegment(span, resource, nil, false, true)
+	require.NoError(t, err)
+	assert.Equal(t, span.TraceID().HexString(), segment.Annotations[OTelTraceIDAnnotation])
+
+	// The X-Ray trace ID has a recent epoch, so that X-Ray accepts it.
+	hexTraceID := span.TraceID().HexString()
+	assert.Equal(t, hexTraceID[8:32], (*segment.TraceID)[identifierOffset:])
+	epoch, err := strconv.ParseInt((*segment.TraceID)[2:10], 16, 64)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, epoch, time.Now().Unix())
+	assert.Greater(t, epoch, time.Now().Add(-passThroughEpochPeriod).Unix())
+
+	// The translation is deterministic, other spans of the trace get the same X-Ray trace ID.
+	other := constructServerSpan(newSegmentID(), "other", pdata.StatusCodeUnset, "OK", attributes)
+	other.SetTraceID(span.TraceID())
+	other.SetStartTimestamp(span.StartTimestamp())
+	otherSegment, err := MakeSegment(other, resource, nil, false, true)
+	require.NoError(t, err)
+	assert.Equal(t, *segment.TraceID, *otherSegment.TraceID)
+}
+
+func TestSpansAcrossMidnightWithInvalidTraceIdPassThrough(t *testing.T) {
+	attributes := make(map[string]interface{})
+	resource := constructDefaultResource()
+	midnight := time.Date(2021, 8, 10, 0, 0, 0, 0, time.UTC)
+
+	before := constructServerSpan(newSegmentID(), "before", pdata.StatusCodeUnset, "OK", attributes)
+	traceID := before.TraceID().Bytes()
+	traceID[0] = 0x11
+	before.SetTraceID(pdata.NewTraceID(traceID))
+	before.SetStartTimestamp(pdata.TimestampFromTime(midnight.Add(-time.Second)))
+	before.SetEndTimestamp(pdata.TimestampFromTime(midnight.Add(time.Second)))
+
+	after := constructClientSpan(before.SpanID(), "after", pdata.StatusCodeUnset, "OK", attributes)
+	after.SetTraceID(before.TraceID())
+	after.SetStartTimestamp(pdata.TimestampFromTime(midnight.Add(100 * time.Millisecond)))
+	after.SetEndTimestamp(pdata.TimestampFromTime(midnight.Add(500 * time.Millisecond)))
+
+	beforeSegment, err := MakeSegment(before, resource, nil, false, true)
+	require.NoError(t, err)
+	afterSegment, err := MakeSegment(after, resource, nil, false, true)
+	require.NoError(t, err)
+	assert.Equal(t, *beforeSegment.TraceID, *afterSegment.TraceID)
+}
+
+func TestGenerateAmazonTraceID(t *testing.T) {
+	traceID := pdata.NewTraceID([16]byte{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff, 0x00})
+	now := time.Date(2021, 8, 10, 12, 0, 0, 0, time.UTC)
+	// 2021-08-05 00:00:00 UTC, the start of the 7 day period since the Unix epoch holding now.
+	assert.Equal(t, "1-610b2a00-5566778899aabbccddeeff00", generateAmazonTraceID(traceID, now))
+	assert.Equal(t, generateAmazonTraceID(traceID, now), generateAmazonTraceID(traceID, now.Add(24*time.Hour)))
+}
+
+func TestSpanWithValidTraceIdPassThrough(t *testing.T) {
+	spanName := "platformapi.widgets.searchWidgets"
+	attributes := make(map[string]interface{})
+	resource := constructDefaultResource()
+	span := constructClientSpan(pdata.InvalidSpanID(), spanName, pdata.StatusCodeUnset, "OK", attributes)
+
+	segment, err := MakeSegment(span, resource, nil, false, true)
+	require.NoError(t, err)
+	expectedTraceID, err := convertToAmazonTraceID(span.TraceID())
+	require.NoError(t, err)
+	assert.Equal(t, expectedTraceID, *segment.TraceID)
+	assert.Equal(t, span.TraceID().HexString(), segment.Annotations[OTelTraceIDAnnotation])
+}
+
 func TestSpanWithExpiredTraceId(t *testing.T) {
 	// First Build expired TraceId
 	const maxAge = 60 * 60 * 24 * 30
@@ -355,7 +433,7 @@ func TestServerSpanWithNilAttributes(t *testing.T) {
 	timeEvents.CopyTo(span.Events())
 	pdata.NewAttributeMap().CopyTo(span.Attributes())
 
-	segment, _ := MakeSegment(span, resource, nil, false)
+	segment, _ := MakeSegment(span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.NotNil(t, segment.Cause)
@@ -372,7 +450,7 @@ func TestSpanWithAttributesDefaultNotIndexed(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, nil, false)
+	segment, _ := MakeSegment(span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, 0, len(segment.Annotations))
@@ -399,7 +477,7 @@ func TestSpanWithResourceNotStoredIfSubsegment(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, pdata.StatusCodeError, "ERROR", attributes)
 
-	segment, _ := MakeSegment(span, resource, nil, false)
+	segment, _ := MakeSegment(span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, 0, len(segment.Annotations))
@@ -422,7 +500,7 @@ func TestSpanWithAttributesPartlyIndexed(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, []string{"attr1@1", "not_exist"}, false)
+	segment, _ := MakeSegment(span, resource, []string{"attr1@1", "not_exist"}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, 1, len(segment.Annotations))
@@ -439,7 +517,7 @@ func TestSpanWithAttributesAllIndexed(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeOk, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, []string{"attr1@1", "not_exist"}, true)
+	segment, _ := MakeSegment(span, resource, []string{"attr1@1", "not_exist"}, true, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, "val1", segment.Annotations["attr1_1"])
@@ -460,7 +538,7 @@ func TestResourceAttributesCanBeIndexed(t *testing.T) {
 		"otel.resource.bool.key",
 		"otel.resource.map.key",
 		"otel.resource.array.key",
-	}, false)
+	}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, 4, len(segment.Annotations))
@@ -492,7 +570,7 @@ func TestResourceAttributesNotIndexedIfSubsegment(t *testing.T) {
 		"otel.resource.bool.key",
 		"otel.resource.map.key",
 		"otel.resource.array.key",
-	}, false)
+	}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Empty(t, segment.Annotations)
@@ -510,7 +588,7 @@ func TestOriginNotAws(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, []string{}, false)
+	segment, _ := MakeSegment(span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Nil(t, segment.Origin)
@@ -528,7 +606,7 @@ func TestOriginEc2(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, []string{}, false)
+	segment, _ := MakeSegment(span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, OriginEC2, *segment.Origin)
@@ -547,7 +625,7 @@ func TestOriginEcs(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, []string{}, false)
+	segment, _ := MakeSegment(span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, OriginECS, *segment.Origin)
@@ -567,7 +645,7 @@ func TestOriginEcsEc2(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, []string{}, false)
+	segment, _ := MakeSegment(span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, OriginECSEC2, *segment.Origin)
@@ -587,7 +665,7 @@ func TestOriginEcsFargate(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, []string{}, false)
+	segment, _ := MakeSegment(span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, OriginECSFargate, *segment.Origin)
@@ -607,7 +685,7 @@ func TestOriginEb(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, []string{}, false)
+	segment, _ := MakeSegment(span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, OriginEB, *segment.Origin)
@@ -639,7 +717,7 @@ func TestOriginEks(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, []string{}, false)
+	segment, _ := MakeSegment(span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, OriginEKS, *segment.Origin)
@@ -655,7 +733,7 @@ func TestOriginBlank(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, []string{}, false)
+	segment, _ := MakeSegment(span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Nil(t, segment.Origin)
@@ -676,7 +754,7 @@ func TestOriginPrefersInfraService(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(span, resource, []string{}, false)
+	segment, _ := MakeSegment(span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, OriginEC2, *segment.Origin)
@@ -709,7 +787,7 @@ func TestFilteredAttributesMetadata(t *testing.T) {
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 	attrs.CopyTo(span.Attributes())
 
-	segment, _ := MakeSegment(span, resource, []string{}, false)
+	segment, _ := MakeSegment(span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Nil(t, segment.Metadata["default"]["null_value"])
@@ -0,0 +1,158 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblobexporter
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+const (
+	// storageAPIVersion is the version of the Blob service REST API used for requests.
+	storageAPIVersion = "2020-04-08"
+	blockBlobType     = "BlockBlob"
+)
+
+// blobClient uploads blobs to a container with the Blob service REST API.
+type blobClient struct {
+	client       *http.Client
+	containerURL *url.URL
+	sasToken     string
+	accountName  string
+	accountKey   []byte
+	now          func() time.Time
+}
+
+func newBlobClient(cfg *Config, client *http.Client) (*blobClient, error) {
+	containerURL, err := url.Parse(cfg.ContainerURL)
+	if err != nil {
+		return nil, err
+	}
+	containerURL.Path = strings.TrimRight(containerURL.Path, "/")
+
+	c := &blobClient{
+		client:       client,
+		containerURL: containerURL,
+		sasToken:     strings.TrimPrefix(cfg.Auth.SASToken, "?"),
+		now:          time.Now,
+	}
+	if cfg.Auth.AccountKey != "" {
+		c.accountName = cfg.accountName()
+		if c.accountKey, err = base64.StdEncoding.DecodeString(cfg.Auth.AccountKey); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// upload writes data to the block blob with the given name in the container.
+func (c *blobClient) upload(ctx context.Context, name string, contentType string, contentEncoding string, data []byte) error {
+	blobURL := *c.containerURL
+	blobURL.Path += "/" + name
+	blobURL.RawQuery = c.sasToken
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, blobURL.String(), bytes.NewReader(data))
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+	req.Header.Set("x-ms-blob-type", blockBlobType)
+	req.Header.Set("x-ms-date", c.now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", storageAPIVersion)
+	if c.accountKey != nil {
+		req.Header.Set("Authorization", "SharedKey "+c.accountName+":"+c.signature(req))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	// Response body needs to both be read to EOF and closed to avoid leaks
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode == http.StatusCreated {
+		return nil
+	}
+	err = fmt.Errorf("failed to upload blob %q: %s (error code %q)", name, resp.Status, resp.Header.Get("x-ms-error-code"))
+	// Other client errors are caused by the configuration or the data and won't succeed when retried.
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return consumererror.Permanent(err)
+	}
+	return err
+}
+
+// signature returns the Shared Key signature of the request.
+func (c *blobClient) signature(req *http.Request) string {
+	mac := hmac.New(sha256.New, c.accountKey)
+	mac.Write([]byte(c.stringToSign(req)))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// stringToSign returns the string signed for Shared Key authorization, see
+// https://docs.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key.
+func (c *blobClient) stringToSign(req *http.Request) string {
+	var msHeaders []string
+	for name := range req.Header {
+		if name = strings.ToLower(name); strings.HasPrefix(name, "x-ms-") {
+			msHeaders = append(msHeaders, name)
+		}
+	}
+	sort.Strings(msHeaders)
+
+	var canonicalized strings.Builder
+	for _, name := range msHeaders {
+		canonicalized.WriteString(name + ":" + strings.TrimSpace(req.Header.Get(name)) + "\n")
+	}
+	canonicalized.WriteString("/" + c.accountName + req.URL.EscapedPath())
+
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+	return strings.Join([]string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		req.Header.Get("Date"),
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+		canonicalized.String(),
+	}, "\n")
+}
@@ -0,0 +1,117 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblobexporter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestUploadWithSASToken(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	cfg := &Config{
+		ContainerURL: server.URL + "/telemetry/",
+		Auth:         AuthConfig{SASToken: "?sv=2020-04-08&sig=c2ln"},
+	}
+	client, err := newBlobClient(cfg, server.Client())
+	require.NoError(t, err)
+
+	require.NoError(t, client.upload(context.Background(), "traces/1.pb.gz", "application/x-protobuf", "gzip", []byte("data")))
+	assert.Equal(t, http.MethodPut, req.Method)
+	assert.Equal(t, "/telemetry/traces/1.pb.gz", req.URL.Path)
+	assert.Equal(t, "sv=2020-04-08&sig=c2ln", req.URL.RawQuery)
+	assert.Equal(t, "BlockBlob", req.Header.Get("x-ms-blob-type"))
+	assert.Equal(t, storageAPIVersion, req.Header.Get("x-ms-version"))
+	assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+	assert.Equal(t, "gzip", req.Header.Get("Content-Encoding"))
+	assert.Empty(t, req.Header.Get("Authorization"))
+	assert.Equal(t, "data", string(body))
+}
+
+func TestUploadWithAccountKey(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	cfg := &Config{
+		ContainerURL: server.URL + "/telemetry",
+		Auth:         AuthConfig{AccountKey: "c2VjcmV0", AccountName: "otelarchive"},
+	}
+	client, err := newBlobClient(cfg, server.Client())
+	require.NoError(t, err)
+
+	require.NoError(t, client.upload(context.Background(), "logs/1.json", "application/json", "", []byte("{}")))
+	assert.Regexp(t, "^SharedKey otelarchive:[A-Za-z0-9+/]+=*$", authorization)
+}
+
+func TestStringToSign(t *testing.T) {
+	client := &blobClient{accountName: "otelarchive"}
+	req, err := http.NewRequest(http.MethodPut, "https://otelarchive.blob.core.windows.net/telemetry/logs/service%20a/1.json", nil)
+	require.NoError(t, err)
+	req.ContentLength = 42
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("x-ms-version", storageAPIVersion)
+	req.Header.Set("x-ms-date", time.Date(2021, 8, 5, 7, 9, 30, 0, time.UTC).Format(http.TimeFormat))
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+
+	assert.Equal(t, "PUT\ngzip\n\n42\n\napplication/json\n\n\n\n\n\n\n"+
+		"x-ms-blob-type:BlockBlob\nx-ms-date:Thu, 05 Aug 2021 07:09:30 GMT\nx-ms-version:2020-04-08\n"+
+		"/otelarchive/telemetry/logs/service%20a/1.json", client.stringToSign(req))
+}
+
+func TestUploadErrors(t *testing.T) {
+	for status, permanent := range map[int]bool{
+		http.StatusForbidden:           true,
+		http.StatusNotFound:            true,
+		http.StatusRequestTimeout:      false,
+		http.StatusTooManyRequests:     false,
+		http.StatusInternalServerError: false,
+		http.StatusServiceUnavailable:  false,
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("x-ms-error-code", "SomeError")
+			w.WriteHeader(status)
+		}))
+
+		client, err := newBlobClient(&Config{ContainerURL: server.URL + "/telemetry", Auth: AuthConfig{SASToken: "sig=c2ln"}}, server.Client())
+		require.NoError(t, err)
+
+		err = client.upload(context.Background(), "traces/1.pb", "application/x-protobuf", "", []byte("data"))
+		require.Error(t, err, status)
+		assert.Contains(t, err.Error(), "SomeError")
+		assert.Equal(t, permanent, consumererror.IsPermanent(err), status)
+		server.Close()
+	}
+}
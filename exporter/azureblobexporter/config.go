@@ -0,0 +1,138 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblobexporter
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	formatOTLPProto = "otlp_proto"
+	formatOTLPJSON  = "otlp_json"
+	// formatParquet isn't supported yet, it is only recognized to reject it with a clear error.
+	formatParquet = "parquet"
+
+	compressionNone = "none"
+	compressionGzip = "gzip"
+)
+
+// Config defines configuration for the Azure Blob Storage exporter.
+type Config struct {
+	config.ExporterSettings        `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+
+	// ContainerURL is the URL of the blob container the telemetry is written to,
+	// e.g. https://<account>.blob.core.windows.net/<container>.
+	ContainerURL string `mapstructure:"container_url"`
+
+	// Auth configures how requests to the storage account are authorized.
+	Auth AuthConfig `mapstructure:"auth"`
+
+	// Format is the serialization of the blobs, either otlp_proto or otlp_json.
+	Format string `mapstructure:"format"`
+
+	// Compression is the compression of the blobs, either none or gzip.
+	Compression string `mapstructure:"compression"`
+
+	// PathFormat is the format of the virtual directory the blobs are written to. It can contain
+	// the {signal}, {year}, {month}, {day}, {hour} and {minute} placeholders, and
+	// {resource:<attribute>} placeholders that partition the data by resource attribute.
+	PathFormat string `mapstructure:"path_format"`
+}
+
+// AuthConfig defines how requests to the storage account are authorized. Exactly one of
+// SASToken and AccountKey has to be set.
+type AuthConfig struct {
+	// SASToken is a shared access signature granting create and write permissions on the container.
+	SASToken string `mapstructure:"sas_token"`
+
+	// AccountKey is the storage account key used to sign requests with Shared Key authorization.
+	AccountKey string `mapstructure:"account_key"`
+
+	// AccountName is the name of the storage account the AccountKey belongs to. It defaults to the
+	// first label of the container URL host.
+	AccountName string `mapstructure:"account_name"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if err := cfg.ExporterSettings.Validate(); err != nil {
+		return err
+	}
+
+	containerURL, err := url.Parse(cfg.ContainerURL)
+	if err != nil {
+		return fmt.Errorf("invalid \"container_url\": %w", err)
+	}
+	if containerURL.Scheme != "http" && containerURL.Scheme != "https" {
+		return errors.New("\"container_url\" must be an http or https URL")
+	}
+	if strings.Trim(containerURL.Path, "/") == "" {
+		return errors.New("\"container_url\" must include the container name")
+	}
+
+	switch {
+	case cfg.Auth.SASToken == "" && cfg.Auth.AccountKey == "":
+		return errors.New("either \"auth.sas_token\" or \"auth.account_key\" must be specified")
+	case cfg.Auth.SASToken != "" && cfg.Auth.AccountKey != "":
+		return errors.New("only one of \"auth.sas_token\" and \"auth.account_key\" can be specified")
+	case cfg.Auth.AccountKey != "":
+		if _, err := base64.StdEncoding.DecodeString(cfg.Auth.AccountKey); err != nil {
+			return errors.New("\"auth.account_key\" must be base64 encoded")
+		}
+	}
+
+	switch cfg.Format {
+	case formatOTLPProto, formatOTLPJSON:
+	case formatParquet:
+		return fmt.Errorf("format %q is not supported yet, use %q or %q", cfg.Format, formatOTLPProto, formatOTLPJSON)
+	default:
+		return fmt.Errorf("unsupported format %q, must be one of %q or %q", cfg.Format, formatOTLPProto, formatOTLPJSON)
+	}
+
+	switch cfg.Compression {
+	case compressionNone, compressionGzip:
+	default:
+		return fmt.Errorf("unsupported compression %q, must be one of %q or %q", cfg.Compression, compressionNone, compressionGzip)
+	}
+
+	if _, err := parsePathFormat(cfg.PathFormat); err != nil {
+		return fmt.Errorf("invalid \"path_format\": %w", err)
+	}
+	return nil
+}
+
+// accountName returns the name of the storage account used for Shared Key authorization.
+func (cfg *Config) accountName() string {
+	if cfg.Auth.AccountName != "" {
+		return cfg.Auth.AccountName
+	}
+	containerURL, err := url.Parse(cfg.ContainerURL)
+	if err != nil {
+		return ""
+	}
+	return strings.SplitN(containerURL.Hostname(), ".", 2)[0]
+}
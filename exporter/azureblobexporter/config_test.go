@@ -0,0 +1,157 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblobexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Len(t, cfg.Exporters, 2)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.ContainerURL = "https://otelarchive.blob.core.windows.net/telemetry"
+	defaultCfg.Auth.SASToken = "sv=2020-04-08&ss=b&srt=co&sp=cw&se=2030-01-01T00:00:00Z&sig=c2lnbmF0dXJl"
+	assert.Equal(t, defaultCfg, cfg.Exporters[config.NewID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewIDWithName(typeStr, "allsettings")),
+		TimeoutSettings: exporterhelper.TimeoutSettings{
+			Timeout: 20 * time.Second,
+		},
+		QueueSettings: exporterhelper.QueueSettings{
+			Enabled:      true,
+			NumConsumers: 2,
+			QueueSize:    10,
+		},
+		RetrySettings: exporterhelper.RetrySettings{
+			Enabled:         true,
+			InitialInterval: 10 * time.Second,
+			MaxInterval:     1 * time.Minute,
+			MaxElapsedTime:  10 * time.Minute,
+		},
+		ContainerURL: "https://otelarchive.blob.core.windows.net/telemetry",
+		Auth: AuthConfig{
+			AccountKey:  "c2VjcmV0LWFjY291bnQta2V5",
+			AccountName: "otelarchive",
+		},
+		Format:      formatOTLPJSON,
+		Compression: compressionNone,
+		PathFormat:  "{signal}/{resource:service.name}/{year}/{month}/{day}",
+	}, cfg.Exporters[config.NewIDWithName(typeStr, "allsettings")])
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:   "missing container url",
+			modify: func(cfg *Config) { cfg.ContainerURL = "" },
+			err:    "\"container_url\" must be an http or https URL",
+		},
+		{
+			name:   "missing container name",
+			modify: func(cfg *Config) { cfg.ContainerURL = "https://otelarchive.blob.core.windows.net/" },
+			err:    "\"container_url\" must include the container name",
+		},
+		{
+			name:   "missing auth",
+			modify: func(cfg *Config) { cfg.Auth.SASToken = "" },
+			err:    "either \"auth.sas_token\" or \"auth.account_key\" must be specified",
+		},
+		{
+			name:   "both sas token and account key",
+			modify: func(cfg *Config) { cfg.Auth.AccountKey = "c2VjcmV0" },
+			err:    "only one of \"auth.sas_token\" and \"auth.account_key\" can be specified",
+		},
+		{
+			name: "invalid account key",
+			modify: func(cfg *Config) {
+				cfg.Auth.SASToken = ""
+				cfg.Auth.AccountKey = "not base64!"
+			},
+			err: "\"auth.account_key\" must be base64 encoded",
+		},
+		{
+			name:   "unsupported format",
+			modify: func(cfg *Config) { cfg.Format = "avro" },
+			err:    "unsupported format \"avro\", must be one of \"otlp_proto\" or \"otlp_json\"",
+		},
+		{
+			name:   "parquet format",
+			modify: func(cfg *Config) { cfg.Format = "parquet" },
+			err:    "format \"parquet\" is not supported yet, use \"otlp_proto\" or \"otlp_json\"",
+		},
+		{
+			name:   "unsupported compression",
+			modify: func(cfg *Config) { cfg.Compression = "zstd" },
+			err:    "unsupported compression \"zstd\", must be one of \"none\" or \"gzip\"",
+		},
+		{
+			name:   "unknown placeholder",
+			modify: func(cfg *Config) { cfg.PathFormat = "{signal}/{week}" },
+			err:    "invalid \"path_format\": unknown placeholder {week}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.ContainerURL = "https://otelarchive.blob.core.windows.net/telemetry"
+			cfg.Auth.SASToken = "sig=c2lnbmF0dXJl"
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestAccountName(t *testing.T) {
+	cfg := &Config{ContainerURL: "https://otelarchive.blob.core.windows.net/telemetry"}
+	assert.Equal(t, "otelarchive", cfg.accountName())
+
+	cfg.Auth.AccountName = "other"
+	assert.Equal(t, "other", cfg.accountName())
+}
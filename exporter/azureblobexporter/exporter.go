@@ -0,0 +1,257 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblobexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	signalTraces  = "traces"
+	signalMetrics = "metrics"
+	signalLogs    = "logs"
+)
+
+type blobExporter struct {
+	cfg    *Config
+	logger *zap.Logger
+	path   *pathTemplate
+	client *blobClient
+	now    func() time.Time
+
+	tracesMarshaler  pdata.TracesMarshaler
+	metricsMarshaler pdata.MetricsMarshaler
+	logsMarshaler    pdata.LogsMarshaler
+	contentType      string
+	extension        string
+}
+
+func newBlobExporter(cfg *Config, logger *zap.Logger) (*blobExporter, error) {
+	path, err := parsePathFormat(cfg.PathFormat)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newBlobClient(cfg, &http.Client{})
+	if err != nil {
+		return nil, err
+	}
+
+	e := &blobExporter{
+		cfg:    cfg,
+		logger: logger,
+		path:   path,
+		client: client,
+		now:    time.Now,
+	}
+	switch cfg.Format {
+	case formatOTLPJSON:
+		e.tracesMarshaler = otlp.NewJSONTracesMarshaler()
+		e.metricsMarshaler = otlp.NewJSONMetricsMarshaler()
+		e.logsMarshaler = otlp.NewJSONLogsMarshaler()
+		e.contentType = "application/json"
+		e.extension = ".json"
+	default:
+		e.tracesMarshaler = otlp.NewProtobufTracesMarshaler()
+		e.metricsMarshaler = otlp.NewProtobufMetricsMarshaler()
+		e.logsMarshaler = otlp.NewProtobufLogsMarshaler()
+		e.contentType = "application/x-protobuf"
+		e.extension = ".pb"
+	}
+	if cfg.Compression == compressionGzip {
+		e.extension += ".gz"
+	}
+	return e, nil
+}
+
+func (e *blobExporter) start(context.Context, component.Host) error {
+	return nil
+}
+
+func (e *blobExporter) shutdown(context.Context) error {
+	e.client.client.CloseIdleConnections()
+	return nil
+}
+
+func (e *blobExporter) consumeTraces(ctx context.Context, td pdata.Traces) error {
+	now := e.now()
+	partitions := map[string]pdata.Traces{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		path := e.path.render(signalTraces, now, rs.Resource().Attributes())
+		partition, ok := partitions[path]
+		if !ok {
+			partition = pdata.NewTraces()
+			partitions[path] = partition
+		}
+		rs.CopyTo(partition.ResourceSpans().AppendEmpty())
+	}
+
+	failed := pdata.NewTraces()
+	var errs, permanentErrs []error
+	for path, partition := range partitions {
+		data, err := e.tracesMarshaler.MarshalTraces(partition)
+		if err != nil {
+			err = consumererror.Permanent(err)
+		} else {
+			err = e.write(ctx, path, now, data)
+		}
+		if err == nil {
+			continue
+		}
+		if consumererror.IsPermanent(err) {
+			e.logger.Error("Dropping traces", zap.String("path", path), zap.Error(err))
+			permanentErrs = append(permanentErrs, err)
+			continue
+		}
+		errs = append(errs, err)
+		partition.ResourceSpans().MoveAndAppendTo(failed.ResourceSpans())
+	}
+
+	if len(errs) == 0 {
+		return consumererror.Combine(permanentErrs)
+	}
+	return consumererror.NewTraces(consumererror.Combine(errs), failed)
+}
+
+func (e *blobExporter) consumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	now := e.now()
+	partitions := map[string]pdata.Metrics{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		path := e.path.render(signalMetrics, now, rm.Resource().Attributes())
+		partition, ok := partitions[path]
+		if !ok {
+			partition = pdata.NewMetrics()
+			partitions[path] = partition
+		}
+		rm.CopyTo(partition.ResourceMetrics().AppendEmpty())
+	}
+
+	failed := pdata.NewMetrics()
+	var errs, permanentErrs []error
+	for path, partition := range partitions {
+		data, err := e.metricsMarshaler.MarshalMetrics(partition)
+		if err != nil {
+			err = consumererror.Permanent(err)
+		} else {
+			err = e.write(ctx, path, now, data)
+		}
+		if err == nil {
+			continue
+		}
+		if consumererror.IsPermanent(err) {
+			e.logger.Error("Dropping metrics", zap.String("path", path), zap.Error(err))
+			permanentErrs = append(permanentErrs, err)
+			continue
+		}
+		errs = append(errs, err)
+		partition.ResourceMetrics().MoveAndAppendTo(failed.ResourceMetrics())
+	}
+
+	if len(errs) == 0 {
+		return consumererror.Combine(permanentErrs)
+	}
+	return consumererror.NewMetrics(consumererror.Combine(errs), failed)
+}
+
+func (e *blobExporter) consumeLogs(ctx context.Context, ld pdata.Logs) error {
+	now := e.now()
+	partitions := map[string]pdata.Logs{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		path := e.path.render(signalLogs, now, rl.Resource().Attributes())
+		partition, ok := partitions[path]
+		if !ok {
+			partition = pdata.NewLogs()
+			partitions[path] = partition
+		}
+		rl.CopyTo(partition.ResourceLogs().AppendEmpty())
+	}
+
+	failed := pdata.NewLogs()
+	var errs, permanentErrs []error
+	for path, partition := range partitions {
+		data, err := e.logsMarshaler.MarshalLogs(partition)
+		if err != nil {
+			err = consumererror.Permanent(err)
+		} else {
+			err = e.write(ctx, path, now, data)
+		}
+		if err == nil {
+			continue
+		}
+		if consumererror.IsPermanent(err) {
+			e.logger.Error("Dropping logs", zap.String("path", path), zap.Error(err))
+			permanentErrs = append(permanentErrs, err)
+			continue
+		}
+		errs = append(errs, err)
+		partition.ResourceLogs().MoveAndAppendTo(failed.ResourceLogs())
+	}
+
+	if len(errs) == 0 {
+		return consumererror.Combine(permanentErrs)
+	}
+	return consumererror.NewLogs(consumererror.Combine(errs), failed)
+}
+
+// write uploads data as a new blob in the virtual directory path.
+func (e *blobExporter) write(ctx context.Context, path string, now time.Time, data []byte) error {
+	contentEncoding := ""
+	if e.cfg.Compression == compressionGzip {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		if _, err := gz.Write(data); err != nil {
+			return consumererror.Permanent(err)
+		}
+		if err := gz.Close(); err != nil {
+			return consumererror.Permanent(err)
+		}
+		data = buf.Bytes()
+		contentEncoding = compressionGzip
+	}
+
+	name, err := blobName(path, now, e.extension)
+	if err != nil {
+		return err
+	}
+	return e.client.upload(ctx, name, e.contentType, contentEncoding, data)
+}
+
+// blobName returns a unique name for a blob in the virtual directory path. Names start with the
+// time they were written at, so listing a directory returns the blobs in the order they were written.
+func blobName(path string, now time.Time, extension string) (string, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%d-%s%s", path, now.UnixNano(), hex.EncodeToString(suffix), extension), nil
+}
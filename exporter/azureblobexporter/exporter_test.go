@@ -0,0 +1,189 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblobexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// blobStore is a fake Blob service keeping the uploaded blobs in memory.
+type blobStore struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+	// status is the response status for blobs whose name contains the key.
+	status map[string]int
+}
+
+func newBlobStore() *blobStore {
+	return &blobStore{blobs: map[string][]byte{}, status: map[string]int{}}
+}
+
+func (s *blobStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, status := range s.status {
+		if strings.Contains(r.URL.Path, key) {
+			w.WriteHeader(status)
+			return
+		}
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	s.blobs[r.URL.Path] = body
+	w.WriteHeader(http.StatusCreated)
+}
+
+func newTestExporter(t *testing.T, url string, modify func(cfg *Config)) *blobExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ContainerURL = url + "/telemetry"
+	cfg.Auth.SASToken = "sig=c2ln"
+	cfg.PathFormat = "{signal}/{resource:service.name}/{year}"
+	modify(cfg)
+	require.NoError(t, cfg.Validate())
+
+	exp, err := newBlobExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	exp.now = func() time.Time { return time.Date(2021, 8, 5, 7, 9, 30, 0, time.UTC) }
+	return exp
+}
+
+func newTestTraces(services ...string) pdata.Traces {
+	td := pdata.NewTraces()
+	for _, service := range services {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString("service.name", service)
+		rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	}
+	return td
+}
+
+func TestConsumeTracesPartitioned(t *testing.T) {
+	store := newBlobStore()
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL, func(cfg *Config) {})
+	require.NoError(t, exp.consumeTraces(context.Background(), newTestTraces("checkout", "cart", "checkout")))
+
+	require.Len(t, store.blobs, 2)
+	spans := map[string]int{}
+	for name, blob := range store.blobs {
+		assert.True(t, strings.HasSuffix(name, ".pb.gz"), name)
+
+		gz, err := gzip.NewReader(bytes.NewReader(blob))
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(gz)
+		require.NoError(t, err)
+		td, err := otlp.NewProtobufTracesUnmarshaler().UnmarshalTraces(data)
+		require.NoError(t, err)
+
+		dir := name[:strings.LastIndex(name, "/")]
+		spans[dir] = td.SpanCount()
+	}
+	assert.Equal(t, map[string]int{
+		"/telemetry/traces/checkout/2021": 2,
+		"/telemetry/traces/cart/2021":     1,
+	}, spans)
+}
+
+func TestConsumeLogsJSON(t *testing.T) {
+	store := newBlobStore()
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL, func(cfg *Config) {
+		cfg.Format = formatOTLPJSON
+		cfg.Compression = compressionNone
+	})
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty().Body().SetStringVal("hello")
+	require.NoError(t, exp.consumeLogs(context.Background(), ld))
+
+	require.Len(t, store.blobs, 1)
+	for name, blob := range store.blobs {
+		assert.True(t, strings.HasPrefix(name, "/telemetry/logs/unknown/2021/"), name)
+		assert.True(t, strings.HasSuffix(name, ".json"), name)
+		logs, err := otlp.NewJSONLogsUnmarshaler().UnmarshalLogs(blob)
+		require.NoError(t, err)
+		assert.Equal(t, 1, logs.LogRecordCount())
+	}
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	store := newBlobStore()
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL, func(cfg *Config) {})
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "cart")
+	rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("requests")
+	require.NoError(t, exp.consumeMetrics(context.Background(), md))
+
+	require.Len(t, store.blobs, 1)
+	for name := range store.blobs {
+		assert.True(t, strings.HasPrefix(name, "/telemetry/metrics/cart/2021/"), name)
+	}
+}
+
+func TestConsumeTracesPartialFailure(t *testing.T) {
+	store := newBlobStore()
+	store.status["/cart/"] = http.StatusServiceUnavailable
+	store.status["/payment/"] = http.StatusForbidden
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL, func(cfg *Config) {})
+	err := exp.consumeTraces(context.Background(), newTestTraces("checkout", "cart", "payment"))
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+	assert.Len(t, store.blobs, 1)
+
+	// Only the partition that failed with a retryable error is retried.
+	var tracesErr consumererror.Traces
+	require.True(t, consumererror.AsTraces(err, &tracesErr))
+	failed := tracesErr.GetTraces()
+	require.Equal(t, 1, failed.ResourceSpans().Len())
+	service, _ := failed.ResourceSpans().At(0).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "cart", service.StringVal())
+}
+
+func TestConsumeTracesPermanentFailure(t *testing.T) {
+	store := newBlobStore()
+	store.status["/traces/"] = http.StatusForbidden
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL, func(cfg *Config) {})
+	err := exp.consumeTraces(context.Background(), newTestTraces("checkout"))
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+}
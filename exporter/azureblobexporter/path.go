@@ -0,0 +1,114 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblobexporter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	resourcePlaceholderPrefix = "resource:"
+
+	// unknownAttributeValue replaces resource attributes missing from a resource in the blob path.
+	unknownAttributeValue = "unknown"
+)
+
+// pathPart is either a literal part of the path format or a placeholder.
+type pathPart struct {
+	literal     string
+	placeholder string
+	attribute   string
+}
+
+// pathTemplate renders the path format into the virtual directory blobs are written to.
+type pathTemplate struct {
+	parts []pathPart
+}
+
+func parsePathFormat(format string) (*pathTemplate, error) {
+	if strings.Trim(format, "/") == "" {
+		return nil, errors.New("path format must not be empty")
+	}
+
+	t := &pathTemplate{}
+	for rest := format; rest != ""; {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			t.parts = append(t.parts, pathPart{literal: rest})
+			break
+		}
+		if start > 0 {
+			t.parts = append(t.parts, pathPart{literal: rest[:start]})
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated placeholder in %q", format)
+		}
+		placeholder := rest[start+1 : start+end]
+		rest = rest[start+end+1:]
+
+		switch {
+		case placeholder == "signal", placeholder == "year", placeholder == "month",
+			placeholder == "day", placeholder == "hour", placeholder == "minute":
+			t.parts = append(t.parts, pathPart{placeholder: placeholder})
+		case strings.HasPrefix(placeholder, resourcePlaceholderPrefix) && len(placeholder) > len(resourcePlaceholderPrefix):
+			t.parts = append(t.parts, pathPart{attribute: strings.TrimPrefix(placeholder, resourcePlaceholderPrefix)})
+		default:
+			return nil, fmt.Errorf("unknown placeholder {%s}", placeholder)
+		}
+	}
+	return t, nil
+}
+
+// render returns the path for data of the given signal written at time now by a resource with
+// the given attributes.
+func (t *pathTemplate) render(signal string, now time.Time, attrs pdata.AttributeMap) string {
+	now = now.UTC()
+	var sb strings.Builder
+	for _, part := range t.parts {
+		switch {
+		case part.attribute != "":
+			value := unknownAttributeValue
+			if v, ok := attrs.Get(part.attribute); ok {
+				if s := tracetranslator.AttributeValueToString(v); s != "" {
+					value = s
+				}
+			}
+			// Attribute values must not add levels to the virtual directory.
+			sb.WriteString(strings.ReplaceAll(value, "/", "_"))
+		case part.placeholder == "signal":
+			sb.WriteString(signal)
+		case part.placeholder == "year":
+			fmt.Fprintf(&sb, "%04d", now.Year())
+		case part.placeholder == "month":
+			fmt.Fprintf(&sb, "%02d", now.Month())
+		case part.placeholder == "day":
+			fmt.Fprintf(&sb, "%02d", now.Day())
+		case part.placeholder == "hour":
+			fmt.Fprintf(&sb, "%02d", now.Hour())
+		case part.placeholder == "minute":
+			fmt.Fprintf(&sb, "%02d", now.Minute())
+		default:
+			sb.WriteString(part.literal)
+		}
+	}
+	return strings.Trim(sb.String(), "/")
+}
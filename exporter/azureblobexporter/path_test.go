@@ -0,0 +1,56 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblobexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestParsePathFormatErrors(t *testing.T) {
+	for format, expected := range map[string]string{
+		"":                     "path format must not be empty",
+		"/":                    "path format must not be empty",
+		"{signal}/{year":       "unterminated placeholder in \"{signal}/{year\"",
+		"{signal}/{week}":      "unknown placeholder {week}",
+		"{signal}/{resource:}": "unknown placeholder {resource:}",
+	} {
+		_, err := parsePathFormat(format)
+		assert.EqualError(t, err, expected, format)
+	}
+}
+
+func TestPathTemplateRender(t *testing.T) {
+	now := time.Date(2021, 8, 5, 7, 9, 30, 0, time.FixedZone("CEST", 2*60*60))
+	attrs := pdata.NewAttributeMap()
+	attrs.InsertString("service.name", "checkout")
+	attrs.InsertString("k8s.namespace.name", "shop/prod")
+	attrs.InsertInt("shard", 3)
+
+	for format, expected := range map[string]string{
+		defaultPathFormat: "traces/year=2021/month=08/day=05/hour=05",
+		"{signal}/{resource:service.name}/{year}{month}{day}{hour}{minute}": "traces/checkout/202108050509",
+		"/archive/{resource:k8s.namespace.name}/{resource:shard}/":          "archive/shop_prod/3",
+		"{resource:missing}/{signal}":                                       "unknown/traces",
+	} {
+		tmpl, err := parsePathFormat(format)
+		require.NoError(t, err)
+		assert.Equal(t, expected, tmpl.render(signalTraces, now, attrs), format)
+	}
+}
@@ -194,6 +194,8 @@ type Config struct {
 
 	// UseResourceMetadata defines whether to use resource attributes
 	// for completing host metadata (such as the hostname or host tags).
+	// The operating system, architecture and cloud provider reported by
+	// the resource detection processor are sent as host tags.
 	//
 	// By default this is true: the first resource attribute getting to
 	// the exporter will be used for host metadata.
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"runtime"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -55,14 +56,32 @@ type HostMetadata struct {
 
 	// Tags includes the host tags
 	Tags *HostTags `json:"host-tags"`
+
+	// OS is the operating system the host runs
+	OS string `json:"os,omitempty"`
+
+	// SystemStats includes information about the host platform
+	SystemStats *SystemStats `json:"systemStats,omitempty"`
+}
+
+// SystemStats includes information about the host platform.
+type SystemStats struct {
+	// Machine is the host architecture
+	Machine string `json:"machine,omitempty"`
+
+	// Platform is the operating system type
+	Platform string `json:"platform,omitempty"`
 }
 
 // HostTags are the host tags.
-// Currently only system (configuration) tags are considered.
 type HostTags struct {
 	// OTel are host tags set in the configuration
 	OTel []string `json:"otel,omitempty"`
 
+	// System are host tags derived from the cloud provider and architecture
+	// reported by resource detection
+	System []string `json:"system,omitempty"`
+
 	// GCP are Google Cloud Platform tags
 	GCP []string `json:"google cloud platform,omitempty"`
 }
@@ -114,12 +133,48 @@ func metadataFromAttributes(attrs pdata.AttributeMap) *HostMetadata {
 		hm.Meta.HostAliases = append(hm.Meta.HostAliases, azureHostInfo.HostAliases...)
 	}
 
+	hm.Tags.System = systemTagsFromAttributes(attrs)
+
+	osType, hasOSType := attrs.Get(conventions.AttributeOSType)
+	hostArch, hasHostArch := attrs.Get(conventions.AttributeHostArch)
+	if hasOSType {
+		hm.OS = osType.StringVal()
+	}
+	if hasOSType || hasHostArch {
+		hm.SystemStats = &SystemStats{
+			Machine:  hostArch.StringVal(),
+			Platform: osType.StringVal(),
+		}
+	}
+
 	return hm
 }
 
+// systemTagsFromAttributes gets the cloud provider and architecture host tags
+// from attributes following OpenTelemetry semantic conventions
+func systemTagsFromAttributes(attrs pdata.AttributeMap) []string {
+	var tags []string
+	for _, attr := range []struct {
+		key    string
+		tagKey string
+	}{
+		{conventions.AttributeCloudProvider, "cloud_provider"},
+		{conventions.AttributeCloudPlatform, "cloud_platform"},
+		{conventions.AttributeCloudRegion, "region"},
+		{conventions.AttributeCloudAvailabilityZone, "availability-zone"},
+		{conventions.AttributeHostArch, "arch"},
+	} {
+		if val, ok := attrs.Get(attr.key); ok && val.StringVal() != "" {
+			tags = append(tags, fmt.Sprintf("%s:%s", attr.tagKey, val.StringVal()))
+		}
+	}
+	return tags
+}
+
 func fillHostMetadata(params component.ExporterCreateSettings, cfg *config.Config, hm *HostMetadata) {
-	// Could not get hostname from attributes
-	if hm.InternalHostname == "" {
+	// Could not get hostname from attributes, the metadata is about the collector's own host
+	ownHost := hm.InternalHostname == ""
+	if ownHost {
 		hostname := GetHost(params.Logger, cfg)
 		hm.InternalHostname = hostname
 		hm.Meta.Hostname = hostname
@@ -144,6 +199,13 @@ func fillHostMetadata(params component.ExporterCreateSettings, cfg *config.Confi
 		hm.Meta.SocketHostname = systemHostInfo.OS
 		hm.Meta.SocketFqdn = systemHostInfo.FQDN
 	}
+
+	// Platform data was not set from attributes. The collector's platform
+	// only describes the collector's own host, not a host reported remotely.
+	if ownHost && hm.SystemStats == nil {
+		hm.OS = runtime.GOOS
+		hm.SystemStats = &SystemStats{Machine: runtime.GOARCH, Platform: runtime.GOOS}
+	}
 }
 
 func pushMetadata(cfg *config.Config, buildInfo component.BuildInfo, metadata *HostMetadata) error {
@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -75,11 +76,15 @@ func TestFillHostMetadata(t *testing.T) {
 	assert.Equal(t, metadata.Version, "1.0")
 	assert.Equal(t, metadata.Meta.Hostname, "hostname")
 	assert.ElementsMatch(t, metadata.Tags.OTel, []string{"key1:tag1", "key2:tag2", "env:prod"})
+	assert.Equal(t, metadata.OS, runtime.GOOS)
+	assert.Equal(t, metadata.SystemStats, &SystemStats{Machine: runtime.GOARCH, Platform: runtime.GOOS})
 
 	metadataWithVals := &HostMetadata{
 		InternalHostname: "my-custom-hostname",
 		Meta:             &Meta{Hostname: "my-custom-hostname"},
 		Tags:             &HostTags{},
+		OS:               "windows",
+		SystemStats:      &SystemStats{Machine: "arm64", Platform: "windows"},
 	}
 
 	fillHostMetadata(params, cfg, metadataWithVals)
@@ -88,6 +93,19 @@ func TestFillHostMetadata(t *testing.T) {
 	assert.Equal(t, metadataWithVals.Version, "1.0")
 	assert.Equal(t, metadataWithVals.Meta.Hostname, "my-custom-hostname")
 	assert.ElementsMatch(t, metadataWithVals.Tags.OTel, []string{"key1:tag1", "key2:tag2", "env:prod"})
+	assert.Equal(t, metadataWithVals.OS, "windows")
+	assert.Equal(t, metadataWithVals.SystemStats, &SystemStats{Machine: "arm64", Platform: "windows"})
+
+	// The collector's platform is not reported for a host named by its attributes
+	metadataRemote := &HostMetadata{
+		InternalHostname: "remote-hostname",
+		Meta:             &Meta{Hostname: "remote-hostname"},
+		Tags:             &HostTags{},
+	}
+
+	fillHostMetadata(params, cfg, metadataRemote)
+	assert.Empty(t, metadataRemote.OS)
+	assert.Nil(t, metadataRemote.SystemStats)
 }
 
 func TestMetadataFromAttributes(t *testing.T) {
@@ -108,6 +126,7 @@ func TestMetadataFromAttributes(t *testing.T) {
 			EC2Hostname: "ec2amaz-host-name",
 		})
 	assert.ElementsMatch(t, metadataAWS.Tags.OTel, []string{"tag1:val1", "tag2:val2"})
+	assert.ElementsMatch(t, metadataAWS.Tags.System, []string{"cloud_provider:aws"})
 
 	// GCP
 	attrsGCP := testutils.NewAttributeMap(map[string]string{
@@ -146,7 +165,31 @@ func TestMetadataFromAttributes(t *testing.T) {
 	assert.Equal(t, metadataOther.InternalHostname, "custom-name")
 	assert.Equal(t, metadataOther.Meta, &Meta{Hostname: "custom-name"})
 	assert.Equal(t, metadataOther.Tags, &HostTags{})
+	assert.Empty(t, metadataOther.OS)
+	assert.Nil(t, metadataOther.SystemStats)
+
+}
 
+func TestMetadataFromAttributesPlatform(t *testing.T) {
+	attrs := testutils.NewAttributeMap(map[string]string{
+		conventions.AttributeCloudProvider:         conventions.AttributeCloudProviderAWS,
+		conventions.AttributeCloudPlatform:         conventions.AttributeCloudPlatformAWSEC2,
+		conventions.AttributeCloudRegion:           "us-east-1",
+		conventions.AttributeCloudAvailabilityZone: "us-east-1c",
+		conventions.AttributeHostID:                "host-id",
+		conventions.AttributeOSType:                "linux",
+		conventions.AttributeHostArch:              conventions.AttributeHostArchAMD64,
+	})
+	metadata := metadataFromAttributes(attrs)
+	assert.Equal(t, metadata.OS, "linux")
+	assert.Equal(t, metadata.SystemStats, &SystemStats{Machine: "amd64", Platform: "linux"})
+	assert.ElementsMatch(t, metadata.Tags.System, []string{
+		"cloud_provider:aws",
+		"cloud_platform:aws_ec2",
+		"region:us-east-1",
+		"availability-zone:us-east-1c",
+		"arch:amd64",
+	})
 }
 
 func TestPushMetadata(t *testing.T) {
@@ -0,0 +1,133 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbexporter
+
+import (
+	"strconv"
+	"strings"
+
+	// Register the database/sql drivers supported by the exporter.
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+)
+
+const (
+	driverPostgres = "postgres"
+	driverMySQL    = "mysql"
+)
+
+var (
+	logsColumns = []string{
+		"timestamp", "trace_id", "span_id", "severity_number", "severity_text", "name", "body", "attributes", "resource",
+	}
+	spansColumns = []string{
+		"trace_id", "span_id", "parent_span_id", "trace_state", "name", "kind", "start_time", "end_time",
+		"status_code", "status_message", "attributes", "resource",
+	}
+)
+
+// dialect holds the SQL that differs between the supported databases.
+type dialect struct {
+	// placeholder returns the bind parameter for the n-th argument, starting at 1.
+	placeholder func(n int) string
+	// logsDDL and tracesDDL are formatted with the table name.
+	logsDDL   string
+	tracesDDL string
+}
+
+var dialects = map[string]dialect{
+	driverPostgres: {
+		placeholder: func(n int) string { return "$" + strconv.Itoa(n) },
+		logsDDL: `CREATE TABLE IF NOT EXISTS %s (
+	timestamp TIMESTAMP WITH TIME ZONE,
+	trace_id VARCHAR(32) NOT NULL,
+	span_id VARCHAR(16) NOT NULL,
+	severity_number INTEGER NOT NULL,
+	severity_text TEXT NOT NULL,
+	name TEXT NOT NULL,
+	body TEXT NOT NULL,
+	attributes JSONB NOT NULL,
+	resource JSONB NOT NULL
+)`,
+		tracesDDL: `CREATE TABLE IF NOT EXISTS %s (
+	trace_id VARCHAR(32) NOT NULL,
+	span_id VARCHAR(16) NOT NULL,
+	parent_span_id VARCHAR(16) NOT NULL,
+	trace_state TEXT NOT NULL,
+	name TEXT NOT NULL,
+	kind VARCHAR(32) NOT NULL,
+	start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+	end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+	status_code VARCHAR(32) NOT NULL,
+	status_message TEXT NOT NULL,
+	attributes JSONB NOT NULL,
+	resource JSONB NOT NULL
+)`,
+	},
+	driverMySQL: {
+		placeholder: func(int) string { return "?" },
+		logsDDL: `CREATE TABLE IF NOT EXISTS %s (
+	timestamp DATETIME(6) NULL,
+	trace_id VARCHAR(32) NOT NULL,
+	span_id VARCHAR(16) NOT NULL,
+	severity_number INTEGER NOT NULL,
+	severity_text TEXT NOT NULL,
+	name TEXT NOT NULL,
+	body TEXT NOT NULL,
+	attributes JSON NOT NULL,
+	resource JSON NOT NULL
+)`,
+		tracesDDL: `CREATE TABLE IF NOT EXISTS %s (
+	trace_id VARCHAR(32) NOT NULL,
+	span_id VARCHAR(16) NOT NULL,
+	parent_span_id VARCHAR(16) NOT NULL,
+	trace_state TEXT NOT NULL,
+	name TEXT NOT NULL,
+	kind VARCHAR(32) NOT NULL,
+	start_time DATETIME(6) NOT NULL,
+	end_time DATETIME(6) NOT NULL,
+	status_code VARCHAR(32) NOT NULL,
+	status_message TEXT NOT NULL,
+	attributes JSON NOT NULL,
+	resource JSON NOT NULL
+)`,
+	},
+}
+
+// insertStatement returns a statement inserting rows rows of the columns into table.
+func (d dialect) insertStatement(table string, columns []string, rows int) string {
+	var sb strings.Builder
+	sb.WriteString("INSERT INTO ")
+	sb.WriteString(table)
+	sb.WriteString(" (")
+	sb.WriteString(strings.Join(columns, ", "))
+	sb.WriteString(") VALUES ")
+	n := 0
+	for i := 0; i < rows; i++ {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("(")
+		for j := range columns {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			n++
+			sb.WriteString(d.placeholder(n))
+		}
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
@@ -0,0 +1,192 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbexporter
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+)
+
+type dbExporter struct {
+	cfg     *Config
+	logger  *zap.Logger
+	dialect dialect
+	// ddl is the statement creating the table of the signal, run on start if
+	// CreateSchema is enabled.
+	ddl string
+
+	open func(driverName, dataSourceName string) (*sql.DB, error)
+	db   *sql.DB
+}
+
+func newDBExporter(cfg *Config, logger *zap.Logger, ddl string) *dbExporter {
+	return &dbExporter{
+		cfg:     cfg,
+		logger:  logger,
+		dialect: dialects[cfg.Driver],
+		ddl:     ddl,
+		open:    sql.Open,
+	}
+}
+
+func (e *dbExporter) start(ctx context.Context, _ component.Host) error {
+	db, err := e.open(e.cfg.Driver, e.cfg.DataSource)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	e.db = db
+
+	if e.cfg.CreateSchema {
+		if _, err := e.db.ExecContext(ctx, e.ddl); err != nil {
+			return fmt.Errorf("failed to create table: %w", err)
+		}
+	}
+	return nil
+}
+
+func (e *dbExporter) shutdown(context.Context) error {
+	if e.db == nil {
+		return nil
+	}
+	return e.db.Close()
+}
+
+func (e *dbExporter) pushLogs(ctx context.Context, ld pdata.Logs) error {
+	var rows [][]interface{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resource, err := attributesToJSON(rl.Resource().Attributes())
+		if err != nil {
+			return consumererror.Permanent(err)
+		}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				attributes, err := attributesToJSON(lr.Attributes())
+				if err != nil {
+					return consumererror.Permanent(err)
+				}
+				var timestamp interface{}
+				if lr.Timestamp() != 0 {
+					timestamp = lr.Timestamp().AsTime().UTC()
+				}
+				rows = append(rows, []interface{}{
+					timestamp,
+					lr.TraceID().HexString(),
+					lr.SpanID().HexString(),
+					int64(lr.SeverityNumber()),
+					lr.SeverityText(),
+					lr.Name(),
+					tracetranslator.AttributeValueToString(lr.Body()),
+					attributes,
+					resource,
+				})
+			}
+		}
+	}
+	return e.insert(ctx, e.cfg.LogsTable, logsColumns, rows)
+}
+
+func (e *dbExporter) pushTraces(ctx context.Context, td pdata.Traces) error {
+	var rows [][]interface{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resource, err := attributesToJSON(rs.Resource().Attributes())
+		if err != nil {
+			return consumererror.Permanent(err)
+		}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				attributes, err := attributesToJSON(span.Attributes())
+				if err != nil {
+					return consumererror.Permanent(err)
+				}
+				rows = append(rows, []interface{}{
+					span.TraceID().HexString(),
+					span.SpanID().HexString(),
+					span.ParentSpanID().HexString(),
+					string(span.TraceState()),
+					span.Name(),
+					span.Kind().String(),
+					span.StartTimestamp().AsTime().UTC(),
+					span.EndTimestamp().AsTime().UTC(),
+					span.Status().Code().String(),
+					span.Status().Message(),
+					attributes,
+					resource,
+				})
+			}
+		}
+	}
+	return e.insert(ctx, e.cfg.TracesTable, spansColumns, rows)
+}
+
+// insert inserts the rows into table in batches of at most BatchSize rows. All
+// batches are inserted in a single transaction, so a failed request can be
+// retried without duplicating rows.
+func (e *dbExporter) insert(ctx context.Context, table string, columns []string, rows [][]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	for start := 0; start < len(rows); start += e.cfg.BatchSize {
+		end := start + e.cfg.BatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		args := make([]interface{}, 0, (end-start)*len(columns))
+		for _, row := range rows[start:end] {
+			args = append(args, row...)
+		}
+		if _, err := tx.ExecContext(ctx, e.dialect.insertStatement(table, columns, end-start), args...); err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				e.logger.Debug("Failed to roll back transaction", zap.Error(rollbackErr))
+			}
+			return fmt.Errorf("failed to insert into %s: %w", table, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// attributesToJSON returns the attributes as a JSON object.
+func attributesToJSON(attributes pdata.AttributeMap) (string, error) {
+	b, err := json.Marshal(tracetranslator.AttributeMapToMap(attributes))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal attributes: %w", err)
+	}
+	return string(b), nil
+}
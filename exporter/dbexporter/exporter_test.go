@@ -0,0 +1,199 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbexporter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+var testTime = time.Date(2021, 8, 20, 10, 30, 0, 0, time.UTC)
+
+func newTestExporter(t *testing.T, cfg *Config, ddl string) (*dbExporter, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	exp := newDBExporter(cfg, zap.NewNop(), ddl)
+	exp.open = func(driverName, dataSourceName string) (*sql.DB, error) {
+		assert.Equal(t, cfg.Driver, driverName)
+		assert.Equal(t, cfg.DataSource, dataSourceName)
+		return db, nil
+	}
+	return exp, mock
+}
+
+func testConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DataSource = "postgres://localhost/otel"
+	return cfg
+}
+
+func testLogs(count int) pdata.Logs {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("service.name", "checkout")
+	logs := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for i := 0; i < count; i++ {
+		lr := logs.AppendEmpty()
+		lr.SetTimestamp(pdata.TimestampFromTime(testTime))
+		lr.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+		lr.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+		lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+		lr.SetSeverityText("INFO")
+		lr.SetName("order")
+		lr.Body().SetStringVal("order placed")
+		lr.Attributes().InsertInt("order.items", 3)
+	}
+	return ld
+}
+
+func TestStartCreatesSchema(t *testing.T) {
+	cfg := testConfig()
+	exp, mock := newTestExporter(t, cfg, cfg.logsDDL())
+	mock.ExpectExec(cfg.logsDDL()).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectClose()
+
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.shutdown(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStartSkipsSchema(t *testing.T) {
+	cfg := testConfig()
+	cfg.CreateSchema = false
+	exp, mock := newTestExporter(t, cfg, cfg.logsDDL())
+
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStartSchemaError(t *testing.T) {
+	cfg := testConfig()
+	exp, mock := newTestExporter(t, cfg, cfg.logsDDL())
+	mock.ExpectExec(cfg.logsDDL()).WillReturnError(errors.New("permission denied"))
+
+	assert.EqualError(t, exp.start(context.Background(), componenttest.NewNopHost()), "failed to create table: permission denied")
+}
+
+func TestPushLogs(t *testing.T) {
+	cfg := testConfig()
+	cfg.CreateSchema = false
+	exp, mock := newTestExporter(t, cfg, cfg.logsDDL())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO otel_logs (timestamp, trace_id, span_id, severity_number, severity_text, name, body, attributes, resource) "+
+		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)").
+		WithArgs(
+			testTime,
+			"0102030405060708090a0b0c0d0e0f10",
+			"0102030405060708",
+			int64(pdata.SeverityNumberINFO),
+			"INFO",
+			"order",
+			"order placed",
+			`{"order.items":3}`,
+			`{"service.name":"checkout"}`,
+		).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs(1)))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPushLogsBatches(t *testing.T) {
+	cfg := testConfig()
+	cfg.Driver = driverMySQL
+	cfg.CreateSchema = false
+	cfg.BatchSize = 2
+	exp, mock := newTestExporter(t, cfg, cfg.logsDDL())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	columns := "INSERT INTO otel_logs (timestamp, trace_id, span_id, severity_number, severity_text, name, body, attributes, resource) VALUES "
+	mock.ExpectBegin()
+	mock.ExpectExec(columns + "(?, ?, ?, ?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?, ?, ?, ?)").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(columns + "(?, ?, ?, ?, ?, ?, ?, ?, ?)").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs(3)))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPushTracesRollsBack(t *testing.T) {
+	cfg := testConfig()
+	cfg.CreateSchema = false
+	exp, mock := newTestExporter(t, cfg, cfg.tracesDDL())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	span.SetName("GET /orders")
+	span.SetKind(pdata.SpanKindServer)
+	span.SetStartTimestamp(pdata.TimestampFromTime(testTime))
+	span.SetEndTimestamp(pdata.TimestampFromTime(testTime.Add(time.Second)))
+	span.Status().SetCode(pdata.StatusCodeError)
+	span.Status().SetMessage("timeout")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO otel_spans (trace_id, span_id, parent_span_id, trace_state, name, kind, start_time, end_time, "+
+		"status_code, status_message, attributes, resource) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)").
+		WithArgs(
+			"0102030405060708090a0b0c0d0e0f10",
+			"0102030405060708",
+			"",
+			"",
+			"GET /orders",
+			"SPAN_KIND_SERVER",
+			testTime,
+			testTime.Add(time.Second),
+			"STATUS_CODE_ERROR",
+			"timeout",
+			"{}",
+			`{"service.name":"checkout"}`,
+		).
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	err := exp.pushTraces(context.Background(), td)
+	assert.EqualError(t, err, "failed to insert into otel_spans: connection reset")
+	assert.False(t, consumererror.IsPermanent(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPushLogsEmpty(t *testing.T) {
+	cfg := testConfig()
+	cfg.CreateSchema = false
+	exp, mock := newTestExporter(t, cfg, cfg.logsDDL())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, exp.pushLogs(context.Background(), pdata.NewLogs()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,13 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/exporter/dbexporter
+
+go 1.16
+
+require (
+	github.com/DATA-DOG/go-sqlmock v1.5.0
+	github.com/go-sql-driver/mysql v1.6.0
+	github.com/lib/pq v1.10.2
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/collector v0.31.1-0.20210810171211-8038673eba9e
+	go.opentelemetry.io/collector/model v0.31.1-0.20210810171211-8038673eba9e
+	go.uber.org/zap v1.19.0
+)
@@ -59,10 +59,11 @@ type Config struct {
 	Pipeline string `mapstructure:"pipeline"`
 
 	HTTPClientSettings `mapstructure:",squash"`
-	Discovery          DiscoverySettings `mapstructure:"discover"`
-	Retry              RetrySettings     `mapstructure:"retry"`
-	Flush              FlushSettings     `mapstructure:"flush"`
-	Mapping            MappingsSettings  `mapstructure:"mapping"`
+	Discovery          DiscoverySettings  `mapstructure:"discover"`
+	Retry              RetrySettings      `mapstructure:"retry"`
+	Flush              FlushSettings      `mapstructure:"flush"`
+	Mapping            MappingsSettings   `mapstructure:"mapping"`
+	DeadLetter         DeadLetterSettings `mapstructure:"dead_letter"`
 }
 
 type HTTPClientSettings struct {
@@ -144,6 +145,15 @@ type RetrySettings struct {
 	MaxInterval time.Duration `mapstructure:"max_interval"`
 }
 
+// DeadLetterSettings defines settings for keeping the events that could not be
+// indexed in the Elasticsearch exporter.
+type DeadLetterSettings struct {
+	// File configures the file permanently rejected events are appended to, one
+	// JSON object per line, so they can be replayed later. Rejected events are
+	// dropped if File is not set.
+	File string `mapstructure:"file"`
+}
+
 type MappingsSettings struct {
 	// Mode configures the field mappings.
 	Mode string `mapstructure:"mode"`
@@ -0,0 +1,67 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// deadLetterEntry is a document that was permanently rejected, written to the
+// dead-letter file as a single line of JSON.
+type deadLetterEntry struct {
+	Timestamp time.Time       `json:"@timestamp"`
+	Index     string          `json:"index"`
+	Status    int             `json:"status,omitempty"`
+	Reason    string          `json:"reason,omitempty"`
+	Document  json.RawMessage `json:"document"`
+}
+
+// deadLetterWriter appends permanently rejected documents to a file so they
+// can be replayed later.
+type deadLetterWriter struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newDeadLetterWriter(path string) (*deadLetterWriter, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dead-letter file: %w", err)
+	}
+	return &deadLetterWriter{file: file}, nil
+}
+
+func (w *deadLetterWriter) Write(entry deadLetterEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err = w.file.Write(line)
+	return err
+}
+
+func (w *deadLetterWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
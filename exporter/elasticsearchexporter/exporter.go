@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -44,10 +45,20 @@ type elasticsearchExporter struct {
 
 	index       string
 	maxAttempts int
+	retry       RetrySettings
 
 	client      *esClientCurrent
 	bulkIndexer esBulkIndexerCurrent
+	deadLetter  *deadLetterWriter
 	model       mappingModel
+
+	// Rejected items are retried on their own goroutines, which are
+	// cancelled and awaited on shutdown.
+	retryCtx    context.Context
+	cancelRetry context.CancelFunc
+	retryMu     sync.Mutex
+	retryClosed bool
+	retryWg     sync.WaitGroup
 }
 
 var retryOnStatus = []int{500, 502, 503, 504, 429}
@@ -74,22 +85,50 @@ func newExporter(logger *zap.Logger, cfg *Config) (*elasticsearchExporter, error
 		maxAttempts = cfg.Retry.MaxRequests
 	}
 
+	var deadLetter *deadLetterWriter
+	if cfg.DeadLetter.File != "" {
+		deadLetter, err = newDeadLetterWriter(cfg.DeadLetter.File)
+		if err != nil {
+			bulkIndexer.Close(context.Background())
+			return nil, err
+		}
+	}
+
 	// TODO: Apply encoding and field mapping settings.
 	model := &encodeModel{dedup: true, dedot: false}
 
+	retryCtx, cancelRetry := context.WithCancel(context.Background())
+
 	return &elasticsearchExporter{
 		logger:      logger,
 		client:      client,
 		bulkIndexer: bulkIndexer,
+		deadLetter:  deadLetter,
 
 		index:       cfg.Index,
 		maxAttempts: maxAttempts,
+		retry:       cfg.Retry,
 		model:       model,
+
+		retryCtx:    retryCtx,
+		cancelRetry: cancelRetry,
 	}, nil
 }
 
 func (e *elasticsearchExporter) Shutdown(ctx context.Context) error {
-	return e.bulkIndexer.Close(ctx)
+	// Stop the pending retries before closing the bulk indexer, the items
+	// failing while it flushes on close are not retried anymore.
+	e.retryMu.Lock()
+	e.retryClosed = true
+	e.retryMu.Unlock()
+	e.cancelRetry()
+	e.retryWg.Wait()
+
+	err := e.bulkIndexer.Close(ctx)
+	if e.deadLetter != nil {
+		err = multierr.Append(err, e.deadLetter.Close())
+	}
+	return err
 }
 
 func (e *elasticsearchExporter) pushLogsData(ctx context.Context, ld pdata.Logs) error {
@@ -132,7 +171,7 @@ func (e *elasticsearchExporter) pushEvent(ctx context.Context, document []byte)
 
 	// Setup error handler. The handler handles the per item response status based on the
 	// selective ACKing in the bulk response.
-	item.OnFailure = func(ctx context.Context, item esBulkIndexerItem, resp esBulkIndexerResponseItem, err error) {
+	item.OnFailure = func(_ context.Context, item esBulkIndexerItem, resp esBulkIndexerResponseItem, err error) {
 		switch {
 		case attempts < e.maxAttempts && shouldRetryEvent(resp.Status):
 			e.logger.Debug("Retrying to index event",
@@ -140,31 +179,93 @@ func (e *elasticsearchExporter) pushEvent(ctx context.Context, document []byte)
 				zap.Int("status", resp.Status),
 				zap.NamedError("reason", err))
 
+			backoff := eventRetryBackoff(&e.retry, attempts)
 			attempts++
 			body.Seek(0, io.SeekStart)
-			e.bulkIndexer.Add(ctx, item)
+			e.scheduleRetry(item, document, backoff, resp.Status)
 
 		case resp.Status == 0 && err != nil:
 			// Encoding error. We didn't even attempt to send the event
 			e.logger.Error("Drop event: failed to add event to the bulk request buffer.",
 				zap.NamedError("reason", err))
+			e.writeDeadLetter(document, resp.Status, err.Error())
 
 		case err != nil:
 			e.logger.Error("Drop event: failed to index event",
 				zap.Int("attempt", attempts),
 				zap.Int("status", resp.Status),
 				zap.NamedError("reason", err))
+			e.writeDeadLetter(document, resp.Status, err.Error())
 
 		default:
 			e.logger.Error(fmt.Sprintf("Drop event: failed to index event: %#v", resp.Error),
 				zap.Int("attempt", attempts),
 				zap.Int("status", resp.Status))
+			e.writeDeadLetter(document, resp.Status, resp.Error.Reason)
 		}
 	}
 
 	return e.bulkIndexer.Add(ctx, item)
 }
 
+// scheduleRetry adds a rejected item back to the bulk indexer once the backoff
+// has elapsed. The wait and the Add happen on their own goroutine, so that the
+// flush worker reporting the failure is not stalled, nor blocked adding to the
+// queue it is supposed to drain.
+func (e *elasticsearchExporter) scheduleRetry(item esBulkIndexerItem, document []byte, backoff time.Duration, status int) {
+	e.retryMu.Lock()
+	defer e.retryMu.Unlock()
+
+	if e.retryClosed {
+		e.logger.Error("Drop event: exporter shut down before retrying to index the event",
+			zap.Int("status", status))
+		e.writeDeadLetter(document, status, "exporter shut down before retrying")
+		return
+	}
+
+	e.retryWg.Add(1)
+	go func() {
+		defer e.retryWg.Done()
+
+		// Back off before retrying, so that an overloaded cluster
+		// rejecting events with 429 gets a chance to recover.
+		timer := time.NewTimer(backoff)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			err := e.bulkIndexer.Add(e.retryCtx, item)
+			if err == nil {
+				return
+			}
+		case <-e.retryCtx.Done():
+		}
+
+		e.logger.Error("Drop event: exporter shut down before retrying to index the event",
+			zap.Int("status", status))
+		e.writeDeadLetter(document, status, "exporter shut down before retrying")
+	}()
+}
+
+// writeDeadLetter appends a permanently rejected document to the dead-letter
+// file, if one is configured.
+func (e *elasticsearchExporter) writeDeadLetter(document []byte, status int, reason string) {
+	if e.deadLetter == nil {
+		return
+	}
+
+	err := e.deadLetter.Write(deadLetterEntry{
+		Timestamp: time.Now().UTC(),
+		Index:     e.index,
+		Status:    status,
+		Reason:    reason,
+		Document:  document,
+	})
+	if err != nil {
+		e.logger.Error("Failed to write event to the dead-letter file", zap.NamedError("reason", err))
+	}
+}
+
 // clientLogger implements the estransport.Logger interface
 // that is required by the Elasticsearch client for logging.
 type clientLogger zap.Logger
@@ -308,6 +409,19 @@ func createElasticsearchBackoffFunc(config *RetrySettings) func(int) time.Durati
 	}
 }
 
+// eventRetryBackoff returns the time to wait before retrying to index an event
+// that was rejected attempts times, growing exponentially up to MaxInterval.
+func eventRetryBackoff(config *RetrySettings, attempts int) time.Duration {
+	backoff := config.InitialInterval
+	for i := 1; i < attempts && (config.MaxInterval <= 0 || backoff < config.MaxInterval); i++ {
+		backoff *= 2
+	}
+	if config.MaxInterval > 0 && backoff > config.MaxInterval {
+		backoff = config.MaxInterval
+	}
+	return backoff
+}
+
 func shouldRetryEvent(status int) bool {
 	for _, retryable := range retryOnStatus {
 		if status == retryable {
@@ -18,8 +18,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -306,6 +310,118 @@ func TestExporter_PushEvent(t *testing.T) {
 
 		assert.Equal(t, [3]int{1, 2, 1}, attempts)
 	})
+
+	t.Run("retry items while the indexer is saturated", func(t *testing.T) {
+		const numItems = 100
+
+		var mu sync.Mutex
+		rejected := make(map[int]bool)
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			var accepted []itemRequest
+			resp := make([]itemResponse, len(docs))
+			for i, doc := range docs {
+				var idxInfo struct{ Idx int }
+				if err := json.Unmarshal(doc.Document, &idxInfo); err != nil {
+					panic(err)
+				}
+
+				// Reject the first attempt of every item.
+				resp[i].Status = http.StatusOK
+				if !rejected[idxInfo.Idx] {
+					rejected[idxInfo.Idx] = true
+					resp[i].Status = http.StatusTooManyRequests
+					continue
+				}
+				accepted = append(accepted, doc)
+			}
+			rec.Record(accepted)
+			return resp, nil
+		})
+
+		exporter := newTestExporter(t, server.URL, func(cfg *Config) {
+			cfg.Retry.InitialInterval = 1 * time.Millisecond
+			cfg.Retry.MaxInterval = 10 * time.Millisecond
+		})
+
+		// Keep the queue of the single flush worker full while the rejected
+		// items are retried.
+		go func() {
+			for i := 0; i < numItems; i++ {
+				document := fmt.Sprintf(`{"message": "test", "idx": %d}`, i)
+				assert.NoError(t, exporter.pushEvent(context.TODO(), []byte(document)))
+			}
+		}()
+
+		require.Eventually(t, func() bool {
+			return rec.NumItems() == numItems
+		}, 10*time.Second, 10*time.Millisecond, "retried items not indexed")
+	})
+
+	t.Run("write rejected items to dead-letter file", func(t *testing.T) {
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			return itemsReportStatus(docs, http.StatusBadRequest)
+		})
+
+		deadLetterFile := filepath.Join(t.TempDir(), "dead_letter.ndjson")
+		exporter := newTestExporter(t, server.URL, func(cfg *Config) {
+			cfg.DeadLetter.File = deadLetterFile
+		})
+		mustSend(t, exporter, `{"message":"test1"}`)
+
+		var lines []string
+		require.Eventually(t, func() bool {
+			content, err := ioutil.ReadFile(deadLetterFile)
+			require.NoError(t, err)
+			lines = strings.Split(strings.TrimSpace(string(content)), "\n")
+			return len(content) > 0
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.Len(t, lines, 1)
+		var entry deadLetterEntry
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+		assert.Equal(t, http.StatusBadRequest, entry.Status)
+		assert.Equal(t, exporter.index, entry.Index)
+		assert.JSONEq(t, `{"message":"test1"}`, string(entry.Document))
+	})
+
+	t.Run("write items to dead-letter file once retries are exhausted", func(t *testing.T) {
+		var attempts int64
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			atomic.AddInt64(&attempts, 1)
+			return itemsReportStatus(docs, http.StatusTooManyRequests)
+		})
+
+		deadLetterFile := filepath.Join(t.TempDir(), "dead_letter.ndjson")
+		exporter := newTestExporter(t, server.URL, func(cfg *Config) {
+			cfg.Retry.InitialInterval = 1 * time.Millisecond
+			cfg.Retry.MaxInterval = 10 * time.Millisecond
+			cfg.DeadLetter.File = deadLetterFile
+		})
+		mustSend(t, exporter, `{"message":"test1"}`)
+
+		require.Eventually(t, func() bool {
+			content, err := ioutil.ReadFile(deadLetterFile)
+			require.NoError(t, err)
+			return len(content) > 0
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, int64(3), atomic.LoadInt64(&attempts))
+	})
+}
+
+func TestEventRetryBackoff(t *testing.T) {
+	config := &RetrySettings{
+		InitialInterval: 100 * time.Millisecond,
+		MaxInterval:     time.Second,
+	}
+	assert.Equal(t, 100*time.Millisecond, eventRetryBackoff(config, 1))
+	assert.Equal(t, 200*time.Millisecond, eventRetryBackoff(config, 2))
+	assert.Equal(t, 400*time.Millisecond, eventRetryBackoff(config, 3))
+	assert.Equal(t, time.Second, eventRetryBackoff(config, 5))
+	assert.Equal(t, time.Second, eventRetryBackoff(config, 100))
 }
 
 func newTestExporter(t *testing.T, url string, fns ...func(*Config)) *elasticsearchExporter {
@@ -0,0 +1,114 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package honeycombmarkerexporter
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+// Config defines configuration for the Honeycomb marker exporter.
+type Config struct {
+	config.ExporterSettings       `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+
+	// APIKey is the Honeycomb API key used to create markers.
+	APIKey string `mapstructure:"api_key"`
+
+	// Markers defines the markers created from log records. A log record matching
+	// the rules of several markers creates all of them.
+	Markers []Marker `mapstructure:"markers"`
+}
+
+// Marker defines a marker created for each log record matching its rules.
+type Marker struct {
+	// Type is the type of the marker, e.g. deploy. Markers of the same type share
+	// their color in the Honeycomb UI.
+	Type string `mapstructure:"type"`
+
+	// Dataset is the dataset the marker is created in. It defaults to __all__,
+	// which creates an environment wide marker.
+	Dataset string `mapstructure:"dataset"`
+
+	// MessageKey is the log record attribute holding the message of the marker.
+	// The body of the log record is used if it is empty or the attribute is missing.
+	MessageKey string `mapstructure:"message_key"`
+
+	// URLKey is the log record attribute holding the URL the marker links to.
+	URLKey string `mapstructure:"url_key"`
+
+	// Rules are the conditions a log record has to match for the marker to be created.
+	Rules Rules `mapstructure:"rules"`
+}
+
+// Rules are conditions on a log record. All of them have to match, and at least one
+// has to be set.
+type Rules struct {
+	// ResourceConditions are conditions on the resource attributes of the log record.
+	ResourceConditions []Condition `mapstructure:"resource_conditions"`
+
+	// LogConditions are conditions on the attributes of the log record.
+	LogConditions []Condition `mapstructure:"log_conditions"`
+
+	// Body is a regular expression the body of the log record has to match.
+	Body string `mapstructure:"body"`
+}
+
+// Condition matches an attribute whose value matches a regular expression.
+type Condition struct {
+	// Key is the name of the attribute.
+	Key string `mapstructure:"key"`
+
+	// Value is a regular expression the whole string representation of the
+	// attribute value has to match.
+	Value string `mapstructure:"value"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if err := cfg.ExporterSettings.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.APIKey == "" {
+		return errors.New("\"api_key\" must be specified")
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return fmt.Errorf("\"endpoint\" must be an http or https URL, got %q", cfg.Endpoint)
+	}
+
+	if len(cfg.Markers) == 0 {
+		return errors.New("at least one marker must be specified")
+	}
+	for i, marker := range cfg.Markers {
+		if marker.Type == "" {
+			return fmt.Errorf("\"type\" of marker %d must be specified", i)
+		}
+		if _, err := compileMarker(marker); err != nil {
+			return fmt.Errorf("invalid rules of marker %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package honeycombmarkerexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Len(t, cfg.Exporters, 2)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.APIKey = "testkey"
+	defaultCfg.Markers = []Marker{
+		{
+			Type: "deploy",
+			Rules: Rules{
+				LogConditions: []Condition{{Key: "event.name", Value: "deploy"}},
+			},
+		},
+	}
+	assert.Equal(t, defaultCfg, cfg.Exporters[config.NewID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewIDWithName(typeStr, "allsettings")),
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: "https://api.eu1.honeycomb.io",
+			Timeout:  10 * time.Second,
+		},
+		QueueSettings: exporterhelper.QueueSettings{
+			Enabled:      true,
+			NumConsumers: 2,
+			QueueSize:    10,
+		},
+		RetrySettings: exporterhelper.RetrySettings{
+			Enabled:         true,
+			InitialInterval: 10 * time.Second,
+			MaxInterval:     1 * time.Minute,
+			MaxElapsedTime:  10 * time.Minute,
+		},
+		APIKey: "testkey",
+		Markers: []Marker{
+			{
+				Type:       "deploy",
+				Dataset:    "checkout",
+				MessageKey: "deploy.version",
+				URLKey:     "deploy.url",
+				Rules: Rules{
+					ResourceConditions: []Condition{{Key: "service.name", Value: "checkout"}},
+					LogConditions:      []Condition{{Key: "event.name", Value: "deploy"}},
+				},
+			},
+			{
+				Type: "feature-flag",
+				Rules: Rules{
+					Body: "flag .* (enabled|disabled)",
+				},
+			},
+		},
+	}, cfg.Exporters[config.NewIDWithName(typeStr, "allsettings")])
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:   "missing api key",
+			modify: func(cfg *Config) { cfg.APIKey = "" },
+			err:    "\"api_key\" must be specified",
+		},
+		{
+			name:   "invalid endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "api.honeycomb.io" },
+			err:    "\"endpoint\" must be an http or https URL, got \"api.honeycomb.io\"",
+		},
+		{
+			name:   "no markers",
+			modify: func(cfg *Config) { cfg.Markers = nil },
+			err:    "at least one marker must be specified",
+		},
+		{
+			name:   "missing type",
+			modify: func(cfg *Config) { cfg.Markers[0].Type = "" },
+			err:    "\"type\" of marker 0 must be specified",
+		},
+		{
+			name:   "no rules",
+			modify: func(cfg *Config) { cfg.Markers[0].Rules = Rules{} },
+			err: "invalid rules of marker 0: at least one of \"resource_conditions\", \"log_conditions\" " +
+				"and \"body\" must be specified",
+		},
+		{
+			name: "missing condition key",
+			modify: func(cfg *Config) {
+				cfg.Markers[0].Rules.ResourceConditions = []Condition{{Value: "checkout"}}
+			},
+			err: "invalid rules of marker 0: \"key\" of a condition must be specified",
+		},
+		{
+			name:   "invalid body regexp",
+			modify: func(cfg *Config) { cfg.Markers[0].Rules.Body = "(" },
+			err:    "invalid rules of marker 0: invalid \"body\": error parsing regexp: missing closing ): `^(?:()$`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.APIKey = "testkey"
+			cfg.Markers = []Marker{
+				{
+					Type: "deploy",
+					Rules: Rules{
+						LogConditions: []Condition{{Key: "event.name", Value: "deploy"}},
+					},
+				},
+			}
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package honeycombmarkerexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// allDatasets is the dataset slug of environment wide markers.
+	allDatasets = "__all__"
+	// honeycombTeamHeader is the header holding the API key.
+	honeycombTeamHeader = "X-Honeycomb-Team"
+	// pendingMarkersAttribute is set on the log records returned to be retried to the
+	// indexes of their markers that failed, so that their other markers aren't created again.
+	pendingMarkersAttribute = "honeycombmarker.pending_markers"
+)
+
+// marker is the body of a create marker request, see
+// https://docs.honeycomb.io/api/markers/.
+type marker struct {
+	StartTime int64  `json:"start_time"`
+	Message   string `json:"message,omitempty"`
+	Type      string `json:"type"`
+	URL       string `json:"url,omitempty"`
+}
+
+type markerExporter struct {
+	cfg     *Config
+	logger  *zap.Logger
+	markers []*markerRules
+	client  *http.Client
+	now     func() time.Time
+}
+
+func newMarkerExporter(cfg *Config, logger *zap.Logger) (*markerExporter, error) {
+	markers := make([]*markerRules, 0, len(cfg.Markers))
+	for _, m := range cfg.Markers {
+		compiled, err := compileMarker(m)
+		if err != nil {
+			return nil, err
+		}
+		markers = append(markers, compiled)
+	}
+
+	return &markerExporter{
+		cfg:     cfg,
+		logger:  logger,
+		markers: markers,
+		now:     time.Now,
+	}, nil
+}
+
+func (e *markerExporter) start(_ context.Context, host component.Host) error {
+	client, err := e.cfg.HTTPClientSettings.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	e.client = client
+	return nil
+}
+
+// pushLogs creates the markers of the log records matching their rules. Log records
+// with a marker that failed with a retryable error are returned to be retried, and
+// only the markers that failed are created when they are.
+func (e *markerExporter) pushLogs(ctx context.Context, ld pdata.Logs) error {
+	failed := pdata.NewLogs()
+	var errs, permanentErrs []error
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		failedRL := pdata.NewResourceLogs()
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			failedILL := pdata.NewInstrumentationLibraryLogs()
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				pending, isRetry := pendingMarkers(lr)
+				var failedMarkers []string
+				for idx, m := range e.markers {
+					if isRetry {
+						if !pending[idx] {
+							continue
+						}
+					} else if !m.matches(rl.Resource(), lr) {
+						continue
+					}
+					err := e.createMarker(ctx, m.Dataset, e.newMarker(m, lr))
+					switch {
+					case err == nil:
+					case consumererror.IsPermanent(err):
+						e.logger.Error("Dropping marker", zap.String("type", m.Type), zap.Error(err))
+						permanentErrs = append(permanentErrs, err)
+					default:
+						errs = append(errs, err)
+						failedMarkers = append(failedMarkers, strconv.Itoa(idx))
+					}
+				}
+				if len(failedMarkers) > 0 {
+					failedLR := failedILL.Logs().AppendEmpty()
+					lr.CopyTo(failedLR)
+					failedLR.Attributes().UpsertString(pendingMarkersAttribute, strings.Join(failedMarkers, ","))
+				}
+			}
+			if failedILL.Logs().Len() > 0 {
+				ill.InstrumentationLibrary().CopyTo(failedILL.InstrumentationLibrary())
+				failedILL.CopyTo(failedRL.InstrumentationLibraryLogs().AppendEmpty())
+			}
+		}
+		if failedRL.InstrumentationLibraryLogs().Len() > 0 {
+			rl.Resource().CopyTo(failedRL.Resource())
+			failedRL.CopyTo(failed.ResourceLogs().AppendEmpty())
+		}
+	}
+
+	if len(errs) == 0 {
+		return consumererror.Combine(permanentErrs)
+	}
+	return consumererror.NewLogs(consumererror.Combine(errs), failed)
+}
+
+// pendingMarkers returns the indexes of the markers left to create for a log record
+// returned to be retried.
+func pendingMarkers(lr pdata.LogRecord) (map[int]bool, bool) {
+	value, ok := lr.Attributes().Get(pendingMarkersAttribute)
+	if !ok {
+		return nil, false
+	}
+	pending := make(map[int]bool)
+	for _, idx := range strings.Split(value.StringVal(), ",") {
+		if i, err := strconv.Atoi(idx); err == nil {
+			pending[i] = true
+		}
+	}
+	return pending, true
+}
+
+func (e *markerExporter) newMarker(m *markerRules, lr pdata.LogRecord) marker {
+	startTime := e.now()
+	if lr.Timestamp() != 0 {
+		startTime = lr.Timestamp().AsTime()
+	}
+	out := marker{
+		StartTime: startTime.Unix(),
+		Message:   tracetranslator.AttributeValueToString(lr.Body()),
+		Type:      m.Type,
+	}
+	if m.MessageKey != "" {
+		if value, ok := lr.Attributes().Get(m.MessageKey); ok {
+			out.Message = tracetranslator.AttributeValueToString(value)
+		}
+	}
+	if m.URLKey != "" {
+		if value, ok := lr.Attributes().Get(m.URLKey); ok {
+			out.URL = tracetranslator.AttributeValueToString(value)
+		}
+	}
+	return out
+}
+
+// createMarker sends a create marker request for the dataset.
+func (e *markerExporter) createMarker(ctx context.Context, dataset string, m marker) error {
+	body, err := json.Marshal(m)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	if dataset == "" {
+		dataset = allDatasets
+	}
+	endpoint := strings.TrimRight(e.cfg.Endpoint, "/") + "/1/markers/" + url.PathEscape(dataset)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(honeycombTeamHeader, e.cfg.APIKey)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	// Response body needs to both be read to EOF and closed to avoid leaks
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		e.logger.Debug("Created marker", zap.String("dataset", dataset), zap.String("type", m.Type))
+		return nil
+	}
+	err = fmt.Errorf("failed to create %q marker in dataset %q: %s", m.Type, dataset, resp.Status)
+	// Other client errors are caused by the configuration or the data and won't succeed when retried.
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return consumererror.Permanent(err)
+	}
+	return err
+}
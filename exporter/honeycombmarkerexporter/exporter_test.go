@@ -0,0 +1,215 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package honeycombmarkerexporter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+type markerRequest struct {
+	path   string
+	apiKey string
+	marker marker
+}
+
+// markerServer records the create marker requests it receives and responds with status,
+// or the status set for the request path in pathStatus.
+type markerServer struct {
+	*httptest.Server
+	mu         sync.Mutex
+	requests   []markerRequest
+	status     int
+	pathStatus map[string]int
+}
+
+func newMarkerServer(t *testing.T, status int) *markerServer {
+	s := &markerServer{status: status}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var m marker
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&m))
+		s.mu.Lock()
+		s.requests = append(s.requests, markerRequest{path: r.URL.Path, apiKey: r.Header.Get(honeycombTeamHeader), marker: m})
+		status, ok := s.pathStatus[r.URL.Path]
+		if !ok {
+			status = s.status
+		}
+		s.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTestExporter(t *testing.T, endpoint string, markers []Marker) *markerExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.APIKey = "testkey"
+	cfg.Markers = markers
+	require.NoError(t, cfg.Validate())
+
+	exp, err := newMarkerExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
+
+func testLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("service.name", "checkout")
+	logs := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+
+	deploy := logs.AppendEmpty()
+	deploy.SetTimestamp(pdata.TimestampFromTime(time.Unix(1629455400, 0)))
+	deploy.Body().SetStringVal("deployed checkout")
+	deploy.Attributes().InsertString("event.name", "deploy")
+	deploy.Attributes().InsertString("deploy.version", "v1.2.3")
+	deploy.Attributes().InsertString("deploy.url", "https://ci.example.com/builds/42")
+
+	other := logs.AppendEmpty()
+	other.Body().SetStringVal("order placed")
+	other.Attributes().InsertString("event.name", "order")
+	return ld
+}
+
+func TestPushLogs(t *testing.T) {
+	server := newMarkerServer(t, http.StatusCreated)
+	exp := newTestExporter(t, server.URL, []Marker{
+		{
+			Type:       "deploy",
+			Dataset:    "checkout",
+			MessageKey: "deploy.version",
+			URLKey:     "deploy.url",
+			Rules: Rules{
+				ResourceConditions: []Condition{{Key: "service.name", Value: "checkout"}},
+				LogConditions:      []Condition{{Key: "event.name", Value: "deploy"}},
+			},
+		},
+		{
+			Type:  "release",
+			Rules: Rules{Body: "deployed .*"},
+		},
+		{
+			Type:  "never",
+			Rules: Rules{ResourceConditions: []Condition{{Key: "service.name", Value: "frontend"}}},
+		},
+	})
+
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs()))
+	assert.Equal(t, []markerRequest{
+		{
+			path:   "/1/markers/checkout",
+			apiKey: "testkey",
+			marker: marker{
+				StartTime: 1629455400,
+				Message:   "v1.2.3",
+				Type:      "deploy",
+				URL:       "https://ci.example.com/builds/42",
+			},
+		},
+		{
+			path:   "/1/markers/__all__",
+			apiKey: "testkey",
+			marker: marker{
+				StartTime: 1629455400,
+				Message:   "deployed checkout",
+				Type:      "release",
+			},
+		},
+	}, server.requests)
+}
+
+func TestPushLogsRetryableError(t *testing.T) {
+	server := newMarkerServer(t, http.StatusServiceUnavailable)
+	exp := newTestExporter(t, server.URL, []Marker{
+		{Type: "deploy", Rules: Rules{LogConditions: []Condition{{Key: "event.name", Value: "deploy"}}}},
+	})
+
+	err := exp.pushLogs(context.Background(), testLogs())
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	var logsErr consumererror.Logs
+	require.True(t, consumererror.AsLogs(err, &logsErr))
+	failed := logsErr.GetLogs()
+	require.Equal(t, 1, failed.LogRecordCount())
+	rl := failed.ResourceLogs().At(0)
+	serviceName, _ := rl.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "checkout", serviceName.StringVal())
+	assert.Equal(t, "deployed checkout", rl.InstrumentationLibraryLogs().At(0).Logs().At(0).Body().StringVal())
+}
+
+func TestPushLogsRetriesOnlyFailedMarkers(t *testing.T) {
+	server := newMarkerServer(t, http.StatusCreated)
+	server.pathStatus = map[string]int{"/1/markers/checkout": http.StatusServiceUnavailable}
+	exp := newTestExporter(t, server.URL, []Marker{
+		{Type: "deploy", Dataset: "checkout", Rules: Rules{LogConditions: []Condition{{Key: "event.name", Value: "deploy"}}}},
+		{Type: "release", Rules: Rules{Body: "deployed .*"}},
+	})
+
+	err := exp.pushLogs(context.Background(), testLogs())
+	var logsErr consumererror.Logs
+	require.True(t, consumererror.AsLogs(err, &logsErr))
+	require.Len(t, server.requests, 2)
+
+	server.mu.Lock()
+	server.pathStatus = nil
+	server.requests = nil
+	server.mu.Unlock()
+	require.NoError(t, exp.pushLogs(context.Background(), logsErr.GetLogs()))
+	require.Len(t, server.requests, 1)
+	assert.Equal(t, "/1/markers/checkout", server.requests[0].path)
+	assert.Equal(t, "deploy", server.requests[0].marker.Type)
+}
+
+func TestPushLogsPermanentError(t *testing.T) {
+	server := newMarkerServer(t, http.StatusUnauthorized)
+	exp := newTestExporter(t, server.URL, []Marker{
+		{Type: "deploy", Rules: Rules{LogConditions: []Condition{{Key: "event.name", Value: "deploy"}}}},
+	})
+
+	err := exp.pushLogs(context.Background(), testLogs())
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+}
+
+func TestNewMarkerDefaultsToNow(t *testing.T) {
+	exp, err := newMarkerExporter(&Config{}, zap.NewNop())
+	require.NoError(t, err)
+	exp.now = func() time.Time { return time.Unix(1629455400, 0) }
+
+	lr := pdata.NewLogRecord()
+	lr.Body().SetStringVal("deployed")
+	lr.Attributes().InsertString("deploy.version", "v1.2.3")
+
+	assert.Equal(t, marker{
+		StartTime: 1629455400,
+		Message:   "deployed",
+		Type:      "deploy",
+	}, exp.newMarker(&markerRules{Marker: Marker{Type: "deploy", MessageKey: "missing"}}, lr))
+}
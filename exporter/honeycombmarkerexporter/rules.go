@@ -0,0 +1,100 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package honeycombmarkerexporter
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// markerRules is a Marker with its rules compiled.
+type markerRules struct {
+	Marker
+	resourceConditions []condition
+	logConditions      []condition
+	body               *regexp.Regexp
+}
+
+type condition struct {
+	key   string
+	value *regexp.Regexp
+}
+
+func compileMarker(marker Marker) (*markerRules, error) {
+	rules := marker.Rules
+	if len(rules.ResourceConditions) == 0 && len(rules.LogConditions) == 0 && rules.Body == "" {
+		return nil, errors.New("at least one of \"resource_conditions\", \"log_conditions\" and \"body\" must be specified")
+	}
+
+	m := &markerRules{Marker: marker}
+	var err error
+	if m.resourceConditions, err = compileConditions(rules.ResourceConditions); err != nil {
+		return nil, err
+	}
+	if m.logConditions, err = compileConditions(rules.LogConditions); err != nil {
+		return nil, err
+	}
+	if rules.Body != "" {
+		if m.body, err = compileRegexp(rules.Body); err != nil {
+			return nil, fmt.Errorf("invalid \"body\": %w", err)
+		}
+	}
+	return m, nil
+}
+
+func compileConditions(conditions []Condition) ([]condition, error) {
+	compiled := make([]condition, 0, len(conditions))
+	for _, c := range conditions {
+		if c.Key == "" {
+			return nil, errors.New("\"key\" of a condition must be specified")
+		}
+		value, err := compileRegexp(c.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of condition on %q: %w", c.Key, err)
+		}
+		compiled = append(compiled, condition{key: c.Key, value: value})
+	}
+	return compiled, nil
+}
+
+// compileRegexp compiles a regular expression that has to match the whole string.
+func compileRegexp(expr string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + expr + ")$")
+}
+
+// matches returns whether the log record of the resource matches all rules.
+func (m *markerRules) matches(resource pdata.Resource, lr pdata.LogRecord) bool {
+	if !matchConditions(m.resourceConditions, resource.Attributes()) {
+		return false
+	}
+	if !matchConditions(m.logConditions, lr.Attributes()) {
+		return false
+	}
+	return m.body == nil || m.body.MatchString(tracetranslator.AttributeValueToString(lr.Body()))
+}
+
+func matchConditions(conditions []condition, attributes pdata.AttributeMap) bool {
+	for _, c := range conditions {
+		value, ok := attributes.Get(c.key)
+		if !ok || !c.value.MatchString(tracetranslator.AttributeValueToString(value)) {
+			return false
+		}
+	}
+	return true
+}
@@ -44,4 +44,27 @@ type Config struct {
 	// - telegraf-prometheus-v1
 	// - telegraf-prometheus-v2
 	MetricsSchema string `mapstructure:"metrics_schema"`
+
+	// HistogramEncoding indicates how histogram and summary points are written.
+	// The default is the encoding of the metrics schema.
+	// Options:
+	// - fields: one point per histogram or summary, with a field per bucket or quantile
+	// - series: one point per bucket or quantile, tagged with "le" or "quantile"
+	HistogramEncoding string `mapstructure:"histogram_encoding"`
+
+	// BucketRouting defines how points are routed to buckets other than Bucket.
+	BucketRouting BucketRoutingConfig `mapstructure:"bucket_routing"`
+}
+
+// BucketRoutingConfig defines the bucket and retention policy points are written to,
+// based on their tags. Resource attributes are written as tags, so they can be used
+// to route the telemetry of different tenants.
+type BucketRoutingConfig struct {
+	// BucketTag is the tag holding the name of the bucket a point is written to.
+	// Points without the tag are written to Bucket.
+	BucketTag string `mapstructure:"bucket_tag"`
+	// RetentionPolicyTag is the tag holding the retention policy a point is written with.
+	// It is appended to the bucket name as "<bucket>/<retention policy>", as expected by
+	// the InfluxDB 1.8 compatibility API.
+	RetentionPolicyTag string `mapstructure:"retention_policy_tag"`
 }
@@ -65,5 +65,9 @@ func TestLoadConfig(t *testing.T) {
 		Bucket:        "my-bucket",
 		Token:         "my-token",
 		MetricsSchema: "telegraf-prometheus-v2",
+		BucketRouting: BucketRoutingConfig{
+			BucketTag:          "tenant",
+			RetentionPolicyTag: "retention_policy",
+		},
 	})
 }
@@ -77,6 +77,11 @@ var metricsSchemata = map[string]common.MetricsSchema{
 	"telegraf-prometheus-v2": common.MetricsSchemaTelegrafPrometheusV2,
 }
 
+const (
+	histogramEncodingFields = "fields"
+	histogramEncodingSeries = "series"
+)
+
 func newMetricsExporter(config *Config, params component.ExporterCreateSettings) (*metricsExporter, error) {
 	logger := newZapInfluxLogger(params.Logger)
 	schema, found := metricsSchemata[config.MetricsSchema]
@@ -84,6 +89,17 @@ func newMetricsExporter(config *Config, params component.ExporterCreateSettings)
 		return nil, fmt.Errorf("schema '%s' not recognized", config.MetricsSchema)
 	}
 
+	switch config.HistogramEncoding {
+	case "", histogramEncodingSeries:
+	case histogramEncodingFields:
+		// telegraf-prometheus-v2 writes every bucket and quantile as a separate point.
+		if schema != common.MetricsSchemaTelegrafPrometheusV1 {
+			return nil, fmt.Errorf("histogram encoding '%s' is not supported by schema '%s'", config.HistogramEncoding, config.MetricsSchema)
+		}
+	default:
+		return nil, fmt.Errorf("histogram encoding '%s' not recognized", config.HistogramEncoding)
+	}
+
 	converter, err := otel2influx.NewOtelMetricsToLineProtocol(logger, schema)
 	if err != nil {
 		return nil, err
@@ -34,7 +34,11 @@ import (
 type influxHTTPWriter struct {
 	encoderPool sync.Pool
 	httpClient  *http.Client
-	writeURL    string
+	writeURL    *url.URL
+	bucket      string
+	routing     BucketRoutingConfig
+	// histogramSeries is set when histogram and summary points are written as one point per bucket or quantile.
+	histogramSeries bool
 
 	logger common.Logger
 }
@@ -52,7 +56,6 @@ func newInfluxHTTPWriter(logger common.Logger, config *Config, host component.Ho
 	}
 	queryValues := writeURL.Query()
 	queryValues.Set("org", config.Org)
-	queryValues.Set("bucket", config.Bucket)
 	queryValues.Set("precision", "ns")
 	writeURL.RawQuery = queryValues.Encode()
 
@@ -74,48 +77,168 @@ func newInfluxHTTPWriter(logger common.Logger, config *Config, host component.Ho
 				return e
 			},
 		},
-		httpClient: httpClient,
-		writeURL:   writeURL.String(),
-		logger:     logger,
+		httpClient:      httpClient,
+		writeURL:        writeURL,
+		bucket:          config.Bucket,
+		routing:         config.BucketRouting,
+		histogramSeries: config.MetricsSchema == "telegraf-prometheus-v1" && config.HistogramEncoding == histogramEncodingSeries,
+		logger:          logger,
 	}, nil
 }
 
+// bucketWriteURL returns the write URL of the given bucket.
+func (w *influxHTTPWriter) bucketWriteURL(bucket string) string {
+	writeURL := *w.writeURL
+	queryValues := writeURL.Query()
+	queryValues.Set("bucket", bucket)
+	writeURL.RawQuery = queryValues.Encode()
+	return writeURL.String()
+}
+
+// pointBucket returns the bucket a point with the given tags is written to.
+func (w *influxHTTPWriter) pointBucket(tags map[string]string) string {
+	bucket := w.bucket
+	if w.routing.BucketTag != "" {
+		if v := tags[w.routing.BucketTag]; v != "" {
+			bucket = v
+		}
+	}
+	if w.routing.RetentionPolicyTag != "" {
+		if v := tags[w.routing.RetentionPolicyTag]; v != "" {
+			bucket += "/" + v
+		}
+	}
+	return bucket
+}
+
 func (w *influxHTTPWriter) newBatch() *influxHTTPWriterBatch {
 	return &influxHTTPWriterBatch{
-		w:       w,
-		encoder: w.encoderPool.Get().(*lineprotocol.Encoder),
-		logger:  w.logger,
+		w:        w,
+		encoders: map[string]*lineprotocol.Encoder{},
+		logger:   w.logger,
 	}
 }
 
 type influxHTTPWriterBatch struct {
-	w       *influxHTTPWriter
-	encoder *lineprotocol.Encoder
-	logger  common.Logger
+	w *influxHTTPWriter
+	// encoders holds the line protocol buffer of every bucket written to.
+	encoders map[string]*lineprotocol.Encoder
+	logger   common.Logger
 }
 
+const (
+	histogramSumFieldKey   = "sum"
+	histogramCountFieldKey = "count"
+	histogramBoundTagKey   = "le"
+	histogramBucketField   = "bucket"
+	summaryQuantileTagKey  = "quantile"
+	summaryQuantileField   = "value"
+)
+
 // WritePoint emits a set of line protocol attributes (metrics, tags, fields, timestamp)
 // to the internal line protocol buffer. This method implements otel2influx.InfluxWriter.
-func (b *influxHTTPWriterBatch) WritePoint(_ context.Context, measurement string, tags map[string]string, fields map[string]interface{}, ts time.Time, _ common.InfluxMetricValueType) error {
-	b.encoder.StartLine(measurement)
-	for _, tag := range b.sortTags(tags) {
-		b.encoder.AddTag(tag.k, tag.v)
+func (b *influxHTTPWriterBatch) WritePoint(_ context.Context, measurement string, tags map[string]string, fields map[string]interface{}, ts time.Time, vType common.InfluxMetricValueType) error {
+	encoder := b.encoder(b.w.pointBucket(tags))
+
+	if b.w.histogramSeries {
+		switch vType {
+		case common.InfluxMetricValueTypeHistogram:
+			return b.writeSeries(encoder, measurement, tags, fields, ts, histogramBoundTagKey, histogramBucketField)
+		case common.InfluxMetricValueTypeSummary:
+			return b.writeSeries(encoder, measurement, tags, fields, ts, summaryQuantileTagKey, summaryQuantileField)
+		}
+	}
+
+	return b.writeLine(encoder, measurement, b.sortTags(tags), fields, ts)
+}
+
+// writeSeries writes a histogram or summary point as one point per bucket or quantile,
+// tagged with its bound or quantile, and a point holding the sum and count fields.
+func (b *influxHTTPWriterBatch) writeSeries(encoder *lineprotocol.Encoder, measurement string, tags map[string]string, fields map[string]interface{}, ts time.Time, tagKey, fieldKey string) error {
+	sortedTags := b.sortTags(tags)
+	totals := make(map[string]interface{}, 2)
+	keys := make([]string, 0, len(fields))
+	for k, v := range fields {
+		if k == histogramSumFieldKey || k == histogramCountFieldKey {
+			totals[k] = v
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		seriesTags := append(append(make([]tag, 0, len(sortedTags)+1), sortedTags...), tag{tagKey, k})
+		sort.Slice(seriesTags, func(i, j int) bool {
+			return seriesTags[i].k < seriesTags[j].k
+		})
+		if err := b.writeLine(encoder, measurement, seriesTags, map[string]interface{}{fieldKey: fields[k]}, ts); err != nil {
+			return err
+		}
+	}
+	if len(totals) == 0 {
+		return nil
+	}
+	return b.writeLine(encoder, measurement, sortedTags, totals, ts)
+}
+
+func (b *influxHTTPWriterBatch) writeLine(encoder *lineprotocol.Encoder, measurement string, tags []tag, fields map[string]interface{}, ts time.Time) error {
+	encoder.StartLine(measurement)
+	for _, tag := range tags {
+		encoder.AddTag(tag.k, tag.v)
 	}
 	for k, v := range b.convertFields(fields) {
-		b.encoder.AddField(k, v)
+		encoder.AddField(k, v)
 	}
-	b.encoder.EndLine(ts)
+	encoder.EndLine(ts)
 
-	if err := b.encoder.Err(); err != nil {
-		defer b.encoder.ClearErr()
+	if err := encoder.Err(); err != nil {
+		defer encoder.ClearErr()
 		return consumererror.Permanent(fmt.Errorf("failed to encode point: %w", err))
 	}
 
 	return nil
 }
 
+// encoder returns the line protocol buffer of the given bucket.
+func (b *influxHTTPWriterBatch) encoder(bucket string) *lineprotocol.Encoder {
+	encoder, ok := b.encoders[bucket]
+	if !ok {
+		encoder = b.w.encoderPool.Get().(*lineprotocol.Encoder)
+		b.encoders[bucket] = encoder
+	}
+	return encoder
+}
+
+// flushAndClose writes the buffer of every bucket. InfluxDB writes are idempotent, so when
+// writing to one of the buckets fails, the whole batch can be retried.
 func (b *influxHTTPWriterBatch) flushAndClose(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.w.writeURL, bytes.NewReader(b.encoder.Bytes()))
+	buckets := make([]string, 0, len(b.encoders))
+	for bucket := range b.encoders {
+		buckets = append(buckets, bucket)
+	}
+	sort.Strings(buckets)
+
+	for _, bucket := range buckets {
+		if err := b.flush(ctx, bucket); err != nil {
+			return err
+		}
+	}
+
+	for _, encoder := range b.encoders {
+		encoder.Reset()
+		b.w.encoderPool.Put(encoder)
+	}
+
+	// Caller has a reference to this batch; don't let the caller keep references to its members.
+	b.encoders = nil
+	b.logger = nil
+	b.w = nil
+	return nil
+}
+
+func (b *influxHTTPWriterBatch) flush(ctx context.Context, bucket string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.w.bucketWriteURL(bucket), bytes.NewReader(b.encoders[bucket].Bytes()))
 	if err != nil {
 		return consumererror.Permanent(err)
 	}
@@ -129,22 +252,13 @@ func (b *influxHTTPWriterBatch) flushAndClose(ctx context.Context) error {
 	} else {
 		switch res.StatusCode / 100 {
 		case 2: // Success
-			break
+			return nil
 		case 5: // Retryable error
 			return fmt.Errorf("line protocol write returned %q %q", res.Status, string(body))
 		default: // Terminal error
 			return consumererror.Permanent(fmt.Errorf("line protocol write returned %q %q", res.Status, string(body)))
 		}
 	}
-
-	b.encoder.Reset()
-	b.w.encoderPool.Put(b.encoder)
-
-	// Caller has a reference to this batch; don't let the caller keep references to its members.
-	b.encoder = nil
-	b.logger = nil
-	b.w = nil
-	return nil
 }
 
 type tag struct {
@@ -0,0 +1,121 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb-observability/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+// newTestWriter starts a server recording the line protocol written per bucket and returns a writer sending to it.
+func newTestWriter(t *testing.T, cfg *Config) (*influxHTTPWriter, map[string][]string) {
+	var mu sync.Mutex
+	written := map[string][]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "my-org", r.URL.Query().Get("org"))
+
+		mu.Lock()
+		defer mu.Unlock()
+		bucket := r.URL.Query().Get("bucket")
+		written[bucket] = append(written[bucket], strings.Split(strings.TrimSpace(string(body)), "\n")...)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg.Endpoint = server.URL
+	cfg.Org = "my-org"
+	writer, err := newInfluxHTTPWriter(newZapInfluxLogger(zap.NewNop()), cfg, componenttest.NewNopHost())
+	require.NoError(t, err)
+	return writer, written
+}
+
+func TestWriterBucketRouting(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Bucket = "default"
+	cfg.BucketRouting = BucketRoutingConfig{
+		BucketTag:          "tenant",
+		RetentionPolicyTag: "retention",
+	}
+	writer, written := newTestWriter(t, cfg)
+
+	ts := time.Unix(0, 1000)
+	batch := writer.newBatch()
+	require.NoError(t, batch.WritePoint(context.Background(), "cpu", map[string]string{"host": "a"}, map[string]interface{}{"gauge": 1.5}, ts, common.InfluxMetricValueTypeGauge))
+	require.NoError(t, batch.WritePoint(context.Background(), "cpu", map[string]string{"tenant": "acme"}, map[string]interface{}{"gauge": 2.5}, ts, common.InfluxMetricValueTypeGauge))
+	require.NoError(t, batch.WritePoint(context.Background(), "cpu", map[string]string{"tenant": "acme", "retention": "short"}, map[string]interface{}{"gauge": 3.5}, ts, common.InfluxMetricValueTypeGauge))
+	require.NoError(t, batch.flushAndClose(context.Background()))
+
+	assert.Equal(t, map[string][]string{
+		"default":    {"cpu,host=a gauge=1.5 1000"},
+		"acme":       {"cpu,tenant=acme gauge=2.5 1000"},
+		"acme/short": {"cpu,retention=short,tenant=acme gauge=3.5 1000"},
+	}, written)
+}
+
+func TestWriterHistogramSeries(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Bucket = "my-bucket"
+	cfg.HistogramEncoding = histogramEncodingSeries
+	writer, written := newTestWriter(t, cfg)
+
+	ts := time.Unix(0, 1000)
+	batch := writer.newBatch()
+	require.NoError(t, batch.WritePoint(context.Background(), "http_request_duration_seconds", map[string]string{"method": "post"},
+		map[string]interface{}{"0.1": 3.0, "1": 5.0, "sum": 2.5, "count": 5.0}, ts, common.InfluxMetricValueTypeHistogram))
+	require.NoError(t, batch.WritePoint(context.Background(), "rpc_duration_seconds", map[string]string{},
+		map[string]interface{}{"0.5": 0.2, "sum": 4.0, "count": 10.0}, ts, common.InfluxMetricValueTypeSummary))
+	require.NoError(t, batch.flushAndClose(context.Background()))
+
+	lines := written["my-bucket"]
+	require.Len(t, lines, 5)
+	assert.Equal(t, "http_request_duration_seconds,le=0.1,method=post bucket=3 1000", lines[0])
+	assert.Equal(t, "http_request_duration_seconds,le=1,method=post bucket=5 1000", lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "http_request_duration_seconds,method=post "))
+	assert.Contains(t, lines[2], "sum=2.5")
+	assert.Contains(t, lines[2], "count=5")
+	assert.Equal(t, "rpc_duration_seconds,quantile=0.5 value=0.2 1000", lines[3])
+	assert.Contains(t, lines[4], "count=10")
+}
+
+func TestNewMetricsExporterHistogramEncoding(t *testing.T) {
+	params := componenttest.NewNopExporterCreateSettings()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.HistogramEncoding = histogramEncodingFields
+	_, err := newMetricsExporter(cfg, params)
+	assert.NoError(t, err)
+
+	cfg.MetricsSchema = "telegraf-prometheus-v2"
+	_, err = newMetricsExporter(cfg, params)
+	assert.EqualError(t, err, "histogram encoding 'fields' is not supported by schema 'telegraf-prometheus-v2'")
+
+	cfg.HistogramEncoding = "buckets"
+	_, err = newMetricsExporter(cfg, params)
+	assert.EqualError(t, err, "histogram encoding 'buckets' not recognized")
+}
@@ -15,6 +15,8 @@
 package loadbalancingexporter
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
 )
@@ -24,6 +26,21 @@ type Config struct {
 	config.ExporterSettings `mapstructure:",squash"`
 	Protocol                Protocol         `mapstructure:"protocol"`
 	Resolver                ResolverSettings `mapstructure:"resolver"`
+
+	// HealthCheck enables active health checking of the backends when set
+	HealthCheck *HealthCheckSettings `mapstructure:"health_check"`
+}
+
+// HealthCheckSettings defines the configuration for the active health checking of the backends
+type HealthCheckSettings struct {
+	// Interval between two health checks of the backends
+	Interval time.Duration `mapstructure:"interval"`
+	// Timeout for the health check of a single backend
+	Timeout time.Duration `mapstructure:"timeout"`
+	// UnhealthyThreshold is the number of consecutive failed health checks after which a backend is ejected
+	UnhealthyThreshold int `mapstructure:"unhealthy_threshold"`
+	// HealthyThreshold is the number of consecutive successful health checks after which an ejected backend is restored
+	HealthyThreshold int `mapstructure:"healthy_threshold"`
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
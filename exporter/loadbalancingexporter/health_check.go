@@ -0,0 +1,262 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultHealthCheckInterval           = 10 * time.Second
+	defaultHealthCheckTimeout            = time.Second
+	defaultHealthCheckUnhealthyThreshold = 3
+	defaultHealthCheckHealthyThreshold   = 2
+)
+
+// probeFunc checks whether the backend at the given endpoint is able to accept connections
+type probeFunc func(ctx context.Context, endpoint string) error
+
+// healthChecker periodically probes the backends, ejecting a backend once it failed
+// unhealthyThreshold consecutive probes and restoring it once it succeeded
+// healthyThreshold consecutive probes.
+type healthChecker struct {
+	logger *zap.Logger
+
+	probe              probeFunc
+	interval           time.Duration
+	timeout            time.Duration
+	unhealthyThreshold int
+	healthyThreshold   int
+
+	backends          map[string]*backendHealth
+	onChangeCallbacks []func()
+
+	stopCh             chan (struct{})
+	updateLock         sync.RWMutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+}
+
+type backendHealth struct {
+	healthy bool
+	// consecutive counts the probes in a row whose outcome differs from the current state
+	consecutive int
+}
+
+func newHealthChecker(logger *zap.Logger, cfg HealthCheckSettings) *healthChecker {
+	hc := &healthChecker{
+		logger:             logger,
+		probe:              dialProbe,
+		interval:           cfg.Interval,
+		timeout:            cfg.Timeout,
+		unhealthyThreshold: cfg.UnhealthyThreshold,
+		healthyThreshold:   cfg.HealthyThreshold,
+		backends:           map[string]*backendHealth{},
+		stopCh:             make(chan struct{}),
+	}
+	if hc.interval <= 0 {
+		hc.interval = defaultHealthCheckInterval
+	}
+	if hc.timeout <= 0 {
+		hc.timeout = defaultHealthCheckTimeout
+	}
+	if hc.unhealthyThreshold <= 0 {
+		hc.unhealthyThreshold = defaultHealthCheckUnhealthyThreshold
+	}
+	if hc.healthyThreshold <= 0 {
+		hc.healthyThreshold = defaultHealthCheckHealthyThreshold
+	}
+	return hc
+}
+
+// dialProbe considers a backend healthy when a TCP connection can be established to it
+func dialProbe(ctx context.Context, endpoint string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", endpointWithPort(endpoint))
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func (hc *healthChecker) start() {
+	hc.shutdownWg.Add(1)
+	go hc.periodicallyCheck()
+}
+
+func (hc *healthChecker) shutdown() {
+	hc.changeCallbackLock.Lock()
+	hc.onChangeCallbacks = nil
+	hc.changeCallbackLock.Unlock()
+
+	close(hc.stopCh)
+	hc.shutdownWg.Wait()
+}
+
+func (hc *healthChecker) onChange(f func()) {
+	hc.changeCallbackLock.Lock()
+	defer hc.changeCallbackLock.Unlock()
+	hc.onChangeCallbacks = append(hc.onChangeCallbacks, f)
+}
+
+// setEndpoints sets the backends to check. New backends are considered healthy
+// until proven otherwise.
+func (hc *healthChecker) setEndpoints(endpoints []string) {
+	hc.updateLock.Lock()
+	defer hc.updateLock.Unlock()
+
+	backends := make(map[string]*backendHealth, len(endpoints))
+	for _, endpoint := range endpoints {
+		if existing, ok := hc.backends[endpoint]; ok {
+			backends[endpoint] = existing
+		} else {
+			backends[endpoint] = &backendHealth{healthy: true}
+		}
+	}
+	hc.backends = backends
+}
+
+// healthy returns the given endpoints that are currently healthy
+func (hc *healthChecker) healthy(endpoints []string) []string {
+	hc.updateLock.RLock()
+	defer hc.updateLock.RUnlock()
+
+	var healthy []string
+	for _, endpoint := range endpoints {
+		if backend, ok := hc.backends[endpoint]; !ok || backend.healthy {
+			healthy = append(healthy, endpoint)
+		}
+	}
+	return healthy
+}
+
+func (hc *healthChecker) periodicallyCheck() {
+	defer hc.shutdownWg.Done()
+
+	ticker := time.NewTicker(hc.interval)
+	defer ticker.Stop()
+
+	// the probes in flight are cancelled on shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-hc.stopCh
+		cancel()
+	}()
+
+	for {
+		select {
+		case <-ticker.C:
+			// don't start a new check if the shutdown began while waiting
+			select {
+			case <-hc.stopCh:
+				return
+			default:
+			}
+			hc.check(ctx)
+		case <-hc.stopCh:
+			return
+		}
+	}
+}
+
+// check probes all the backends, and propagates the change if any backend was
+// ejected or restored
+func (hc *healthChecker) check(ctx context.Context) {
+	hc.updateLock.RLock()
+	endpoints := make([]string, 0, len(hc.backends))
+	for endpoint := range hc.backends {
+		endpoints = append(endpoints, endpoint)
+	}
+	hc.updateLock.RUnlock()
+
+	results := make([]error, len(endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpoint string) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, hc.timeout)
+			defer cancel()
+			results[i] = hc.probe(probeCtx, endpoint)
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	changed := false
+	hc.updateLock.Lock()
+	for i, endpoint := range endpoints {
+		backend, ok := hc.backends[endpoint]
+		if !ok {
+			// the backend was removed while it was being probed
+			continue
+		}
+		if hc.record(endpoint, backend, results[i]) {
+			changed = true
+		}
+	}
+	hc.updateLock.Unlock()
+
+	if !changed {
+		return
+	}
+
+	// propagate the change
+	hc.changeCallbackLock.RLock()
+	for _, callback := range hc.onChangeCallbacks {
+		callback()
+	}
+	hc.changeCallbackLock.RUnlock()
+}
+
+// record updates the backend with the outcome of a probe, returning true if the
+// backend was ejected or restored. It must be called with updateLock held.
+func (hc *healthChecker) record(endpoint string, backend *backendHealth, probeErr error) bool {
+	if (probeErr == nil) == backend.healthy {
+		backend.consecutive = 0
+		return false
+	}
+
+	backend.consecutive++
+	threshold := hc.unhealthyThreshold
+	if !backend.healthy {
+		threshold = hc.healthyThreshold
+	}
+	if backend.consecutive < threshold {
+		return false
+	}
+
+	backend.healthy = !backend.healthy
+	backend.consecutive = 0
+	if backend.healthy {
+		hc.logger.Info("backend restored", zap.String("endpoint", endpoint))
+	} else {
+		hc.logger.Warn("backend ejected after failed health checks", zap.String("endpoint", endpoint), zap.Error(probeErr))
+	}
+
+	mCtx, _ := tag.New(context.Background(),
+		tag.Upsert(tag.MustNewKey("endpoint"), endpoint),
+		tag.Upsert(tag.MustNewKey("healthy"), strconv.FormatBool(backend.healthy)))
+	stats.Record(mCtx, mBackendHealthChanges.M(1))
+	return true
+}
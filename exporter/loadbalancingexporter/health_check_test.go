@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+func TestNewHealthCheckerDefaults(t *testing.T) {
+	hc := newHealthChecker(zap.NewNop(), HealthCheckSettings{})
+	assert.Equal(t, defaultHealthCheckInterval, hc.interval)
+	assert.Equal(t, defaultHealthCheckTimeout, hc.timeout)
+	assert.Equal(t, defaultHealthCheckUnhealthyThreshold, hc.unhealthyThreshold)
+	assert.Equal(t, defaultHealthCheckHealthyThreshold, hc.healthyThreshold)
+}
+
+func TestHealthCheckerEjectAndRestore(t *testing.T) {
+	// prepare
+	hc := newHealthChecker(zap.NewNop(), HealthCheckSettings{UnhealthyThreshold: 2, HealthyThreshold: 2})
+	failing := map[string]bool{}
+	var failingLock sync.Mutex
+	hc.probe = func(ctx context.Context, endpoint string) error {
+		failingLock.Lock()
+		defer failingLock.Unlock()
+		if failing[endpoint] {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	changes := 0
+	hc.onChange(func() {
+		changes++
+	})
+
+	endpoints := []string{"endpoint-1", "endpoint-2"}
+	hc.setEndpoints(endpoints)
+	failingLock.Lock()
+	failing["endpoint-2"] = true
+	failingLock.Unlock()
+
+	// test
+	hc.check(context.Background())
+	assert.Equal(t, endpoints, hc.healthy(endpoints), "a single failure should not eject the backend")
+	hc.check(context.Background())
+
+	// verify
+	assert.Equal(t, []string{"endpoint-1"}, hc.healthy(endpoints))
+	assert.Equal(t, 1, changes)
+
+	// test
+	failingLock.Lock()
+	failing["endpoint-2"] = false
+	failingLock.Unlock()
+	hc.check(context.Background())
+	assert.Equal(t, []string{"endpoint-1"}, hc.healthy(endpoints), "a single success should not restore the backend")
+	hc.check(context.Background())
+
+	// verify
+	assert.Equal(t, endpoints, hc.healthy(endpoints))
+	assert.Equal(t, 2, changes)
+}
+
+func TestHealthCheckerSetEndpoints(t *testing.T) {
+	// prepare
+	hc := newHealthChecker(zap.NewNop(), HealthCheckSettings{UnhealthyThreshold: 1})
+	hc.probe = func(ctx context.Context, endpoint string) error {
+		return errors.New("connection refused")
+	}
+	hc.setEndpoints([]string{"endpoint-1"})
+	hc.check(context.Background())
+	require.Empty(t, hc.healthy([]string{"endpoint-1"}))
+
+	// test
+	hc.setEndpoints([]string{"endpoint-1", "endpoint-2"})
+
+	// verify
+	assert.Equal(t, []string{"endpoint-2"}, hc.healthy([]string{"endpoint-1", "endpoint-2"}))
+}
+
+func TestHealthCheckerShutdownWaitsForChecks(t *testing.T) {
+	// prepare
+	hc := newHealthChecker(zap.NewNop(), HealthCheckSettings{Interval: time.Millisecond})
+	var probes int64
+	hc.probe = func(ctx context.Context, endpoint string) error {
+		atomic.AddInt64(&probes, 1)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	hc.setEndpoints([]string{"endpoint-1"})
+	hc.start()
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&probes) > 0
+	}, time.Second, time.Millisecond)
+
+	// test
+	hc.shutdown()
+
+	// verify
+	done := atomic.LoadInt64(&probes)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, done, atomic.LoadInt64(&probes), "no check should run after the shutdown")
+}
+
+func TestDialProbe(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	endpoint := ln.Addr().String()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, dialProbe(ctx, endpoint))
+
+	require.NoError(t, ln.Close())
+	assert.Error(t, dialProbe(ctx, endpoint))
+}
+
+func TestLoadBalancerEjectsUnhealthyBackends(t *testing.T) {
+	// prepare
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			Static: &StaticResolver{Hostnames: []string{"endpoint-1", "endpoint-2"}},
+		},
+		HealthCheck: &HealthCheckSettings{
+			Interval:           time.Hour,
+			UnhealthyThreshold: 1,
+			HealthyThreshold:   1,
+		},
+	}
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	unhealthy := map[string]bool{"endpoint-2": true}
+	p.healthChecker.probe = func(ctx context.Context, endpoint string) error {
+		if unhealthy[endpoint] {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer p.Shutdown(context.Background())
+	require.Len(t, p.ring.items, 2*defaultWeight)
+
+	// test
+	p.healthChecker.check(context.Background())
+
+	// verify
+	assert.Len(t, p.ring.items, defaultWeight)
+	assert.Equal(t, "endpoint-1", p.Endpoint(pdata.NewTraceID([16]byte{128, 128, 0, 0})))
+	assert.Len(t, p.exporters, 2, "the exporter of the ejected backend should be kept")
+
+	// test
+	unhealthy["endpoint-1"] = true
+	p.healthChecker.check(context.Background())
+
+	// verify
+	assert.Len(t, p.ring.items, 2*defaultWeight, "all backends should be used when none is healthy")
+
+	// test
+	unhealthy = map[string]bool{}
+	p.healthChecker.check(context.Background())
+
+	// verify
+	assert.Len(t, p.ring.items, 2*defaultWeight)
+}
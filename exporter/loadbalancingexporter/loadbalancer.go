@@ -21,6 +21,7 @@ import (
 	"strings"
 	"sync"
 
+	"go.opencensus.io/stats"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/model/pdata"
@@ -50,8 +51,10 @@ type loadBalancerImp struct {
 	logger *zap.Logger
 	host   component.Host
 
-	res  resolver
-	ring *hashRing
+	res           resolver
+	healthChecker *healthChecker
+	resolved      []string
+	ring          *hashRing
 
 	componentFactory componentFactory
 	exporters        map[string]component.Exporter
@@ -90,9 +93,15 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporte
 		return nil, errNoResolver
 	}
 
+	var hc *healthChecker
+	if oCfg.HealthCheck != nil {
+		hc = newHealthChecker(params.Logger.With(zap.String("component", "health_check")), *oCfg.HealthCheck)
+	}
+
 	return &loadBalancerImp{
 		logger:           params.Logger,
 		res:              res,
+		healthChecker:    hc,
 		componentFactory: factory,
 		exporters:        map[string]component.Exporter{},
 	}, nil
@@ -101,24 +110,63 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporte
 func (lb *loadBalancerImp) Start(ctx context.Context, host component.Host) error {
 	lb.res.onChange(lb.onBackendChanges)
 	lb.host = host
-	return lb.res.start(ctx)
+	if err := lb.res.start(ctx); err != nil {
+		return err
+	}
+	if lb.healthChecker != nil {
+		lb.healthChecker.onChange(lb.onHealthChanges)
+		lb.healthChecker.start()
+	}
+	return nil
 }
 
 func (lb *loadBalancerImp) onBackendChanges(resolved []string) {
-	newRing := newHashRing(resolved)
+	if lb.healthChecker != nil {
+		lb.healthChecker.setEndpoints(resolved)
+	}
 
-	if !newRing.equal(lb.ring) {
-		lb.updateLock.Lock()
-		defer lb.updateLock.Unlock()
+	lb.updateLock.Lock()
+	defer lb.updateLock.Unlock()
 
-		lb.ring = newRing
+	lb.resolved = resolved
+	lb.updateRing()
 
-		// TODO: set a timeout?
-		ctx := context.Background()
+	// TODO: set a timeout?
+	ctx := context.Background()
+
+	// add the missing exporters first, including the ones for ejected backends
+	lb.addMissingExporters(ctx, resolved)
+	lb.removeExtraExporters(ctx, resolved)
+}
 
-		// add the missing exporters first
-		lb.addMissingExporters(ctx, resolved)
-		lb.removeExtraExporters(ctx, resolved)
+// onHealthChanges rebalances the ring when backends were ejected or restored.
+// The exporters of ejected backends are kept, so that they can be used again
+// as soon as the backends are restored.
+func (lb *loadBalancerImp) onHealthChanges() {
+	lb.updateLock.Lock()
+	defer lb.updateLock.Unlock()
+
+	lb.updateRing()
+}
+
+// updateRing rebuilds the ring out of the healthy resolved backends.
+// It must be called with updateLock held.
+func (lb *loadBalancerImp) updateRing() {
+	endpoints := lb.resolved
+	if lb.healthChecker != nil {
+		healthy := lb.healthChecker.healthy(endpoints)
+		if len(healthy) == 0 && len(endpoints) > 0 {
+			// sending to unhealthy backends is still better than dropping everything
+			lb.logger.Warn("all backends are unhealthy, using all of them")
+		} else {
+			endpoints = healthy
+		}
+	}
+
+	newRing := newHashRing(endpoints)
+	if !newRing.equal(lb.ring) {
+		lb.ring = newRing
+		stats.Record(context.Background(), mNumRingUpdates.M(1))
 	}
 }
 
@@ -158,9 +206,11 @@ func (lb *loadBalancerImp) removeExtraExporters(ctx context.Context, endpoints [
 	}
 }
 
+// endpointFound returns whether the endpoint, an exporter key with a port, is
+// one of the given endpoints, compared with their default port as well.
 func endpointFound(endpoint string, endpoints []string) bool {
 	for _, candidate := range endpoints {
-		if candidate == endpoint {
+		if endpointWithPort(candidate) == endpointWithPort(endpoint) {
 			return true
 		}
 	}
@@ -169,6 +219,9 @@ func endpointFound(endpoint string, endpoints []string) bool {
 }
 
 func (lb *loadBalancerImp) Shutdown(context.Context) error {
+	if lb.healthChecker != nil && !lb.stopped {
+		lb.healthChecker.shutdown()
+	}
 	lb.stopped = true
 	return nil
 }
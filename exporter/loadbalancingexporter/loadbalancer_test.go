@@ -291,6 +291,16 @@ func TestEndpointFound(t *testing.T) {
 			[]string{"endpoint-1", "endpoint-2"},
 			false,
 		},
+		{
+			"endpoint-1:4317",
+			[]string{"endpoint-1", "endpoint-2"},
+			true,
+		},
+		{
+			"endpoint-1:55690",
+			[]string{"endpoint-1", "endpoint-2"},
+			false,
+		},
 	} {
 		assert.Equal(t, tt.expected, endpointFound(tt.endpoint, tt.endpoints))
 	}
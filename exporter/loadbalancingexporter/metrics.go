@@ -24,6 +24,9 @@ var (
 	mNumResolutions = stats.Int64("loadbalancer_num_resolutions", "Number of times the resolver triggered a new resolutions", stats.UnitDimensionless)
 	mNumBackends    = stats.Int64("loadbalancer_num_backends", "Current number of backends in use", stats.UnitDimensionless)
 	mBackendLatency = stats.Int64("loadbalancer_backend_latency", "Response latency in ms for the backends", stats.UnitMilliseconds)
+
+	mBackendHealthChanges = stats.Int64("loadbalancer_backend_health_changes", "Number of times a backend was ejected from or restored to the ring", stats.UnitDimensionless)
+	mNumRingUpdates       = stats.Int64("loadbalancer_num_ring_updates", "Number of times the ring was rebalanced", stats.UnitDimensionless)
 )
 
 // MetricViews return the metrics views according to given telemetry level.
@@ -76,5 +79,21 @@ func MetricViews() []*view.View {
 			},
 			Aggregation: view.Count(),
 		},
+		{
+			Name:        mBackendHealthChanges.Name(),
+			Measure:     mBackendHealthChanges,
+			Description: mBackendHealthChanges.Description(),
+			TagKeys: []tag.Key{
+				tag.MustNewKey("endpoint"),
+				tag.MustNewKey("healthy"),
+			},
+			Aggregation: view.Count(),
+		},
+		{
+			Name:        mNumRingUpdates.Name(),
+			Measure:     mNumRingUpdates,
+			Description: mNumRingUpdates.Description(),
+			Aggregation: view.Count(),
+		},
 	}
 }
@@ -26,6 +26,9 @@ func TestProcessorMetrics(t *testing.T) {
 		"loadbalancer_num_backends",
 		"loadbalancer_num_backend_updates",
 		"loadbalancer_backend_latency",
+		"loadbalancer_backend_outcome",
+		"loadbalancer_backend_health_changes",
+		"loadbalancer_num_ring_updates",
 	}
 
 	views := MetricViews()
@@ -17,6 +17,7 @@ package lokiexporter
 import (
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/prometheus/common/model"
 	"go.opentelemetry.io/collector/config"
@@ -36,6 +37,13 @@ type Config struct {
 
 	// Labels defines how labels should be applied to log streams sent to Loki.
 	Labels LabelsConfig `mapstructure:"labels"`
+
+	// SplitByStream sends every log stream in its own push request, so that Loki rejecting one
+	// stream doesn't fail the entries of the other streams in the batch.
+	SplitByStream bool `mapstructure:"split_by_stream"`
+
+	// TimestampAdjustment defines how entry timestamps are adjusted to be accepted by Loki.
+	TimestampAdjustment TimestampAdjustmentConfig `mapstructure:"timestamp_adjustment"`
 }
 
 func (c *Config) validate() error {
@@ -43,9 +51,41 @@ func (c *Config) validate() error {
 		return fmt.Errorf("\"endpoint\" must be a valid URL")
 	}
 
+	if err := c.TimestampAdjustment.validate(); err != nil {
+		return err
+	}
+
 	return c.Labels.validate()
 }
 
+// TimestampAdjustmentConfig defines the timestamp adjustment related configuration.
+type TimestampAdjustmentConfig struct {
+	// Enabled turns on sorting the entries of each stream by timestamp and adjusting the
+	// timestamps that Loki would reject.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MaxAge is the maximum age of an entry accepted by Loki. Older entries are set to the
+	// time they are exported. It should match Loki's reject_old_samples_max_age.
+	MaxAge time.Duration `mapstructure:"max_age"`
+
+	// MaxFutureSkew is how far in the future an entry timestamp may be. Entries further ahead
+	// are set to the time they are exported. It should match Loki's creation_grace_period.
+	MaxFutureSkew time.Duration `mapstructure:"max_future_skew"`
+}
+
+func (c *TimestampAdjustmentConfig) validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.MaxAge <= 0 {
+		return fmt.Errorf("\"timestamp_adjustment.max_age\" must be positive")
+	}
+	if c.MaxFutureSkew < 0 {
+		return fmt.Errorf("\"timestamp_adjustment.max_future_skew\" must not be negative")
+	}
+	return nil
+}
+
 // LabelsConfig defines the labels-related configuration
 type LabelsConfig struct {
 	// Attributes are the log record attributes that are allowed to be added as labels on a log stream.
@@ -87,6 +87,12 @@ func TestLoadConfig(t *testing.T) {
 				"severity":      "severity",
 			},
 		},
+		SplitByStream: true,
+		TimestampAdjustment: TimestampAdjustmentConfig{
+			Enabled:       true,
+			MaxAge:        24 * time.Hour,
+			MaxFutureSkew: 5 * time.Minute,
+		},
 	}
 	require.Equal(t, &expectedCfg, actualCfg)
 }
@@ -107,6 +113,8 @@ func TestConfig_validate(t *testing.T) {
 		CredentialFile string
 		Audience       string
 		Labels         LabelsConfig
+
+		TimestampAdjustment TimestampAdjustmentConfig
 	}
 	tests := []struct {
 		name         string
@@ -168,6 +176,18 @@ func TestConfig_validate(t *testing.T) {
 			},
 			shouldError: false,
 		},
+		{
+			name: "with invalid `timestamp_adjustment.max_age`",
+			fields: fields{
+				Endpoint: validEndpoint,
+				Labels:   validAttribLabelsConfig,
+				TimestampAdjustment: TimestampAdjustmentConfig{
+					Enabled: true,
+				},
+			},
+			errorMessage: "\"timestamp_adjustment.max_age\" must be positive",
+			shouldError:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -177,6 +197,7 @@ func TestConfig_validate(t *testing.T) {
 			cfg.ExporterSettings = config.NewExporterSettings(config.NewID(typeStr))
 			cfg.Endpoint = tt.fields.Endpoint
 			cfg.Labels = tt.fields.Labels
+			cfg.TimestampAdjustment = tt.fields.TimestampAdjustment
 
 			err := cfg.validate()
 			if (err != nil) != tt.shouldError {
@@ -21,6 +21,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 
@@ -32,7 +33,7 @@ import (
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/zap"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter/internal/third_party/loki/logproto"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/loki/third_party/logproto"
 )
 
 type lokiExporter struct {
@@ -40,12 +41,30 @@ type lokiExporter struct {
 	logger *zap.Logger
 	client *http.Client
 	wg     sync.WaitGroup
+	now    func() time.Time
+
+	// lastTimestamps holds the newest timestamp pushed per stream, so that entries of later
+	// pushes can be kept in order when timestamp adjustment is enabled.
+	lastTimestampsMu sync.Mutex
+	lastTimestamps   map[string]time.Time
+}
+
+// lokiStream is a Loki log stream along with the log records it was created from. The log
+// records are only kept when streams are pushed separately, to retry the failed ones.
+type lokiStream struct {
+	stream  logproto.Stream
+	logs    pdata.Logs
+	records pdata.LogSlice
+	// source is the index of the resource and instrumentation library the last record was read from.
+	source [2]int
 }
 
 func newExporter(config *Config, logger *zap.Logger) *lokiExporter {
 	return &lokiExporter{
-		config: config,
-		logger: logger,
+		config:         config,
+		logger:         logger,
+		now:            time.Now,
+		lastTimestamps: map[string]time.Time{},
 	}
 }
 
@@ -53,11 +72,50 @@ func (l *lokiExporter) pushLogData(ctx context.Context, ld pdata.Logs) error {
 	l.wg.Add(1)
 	defer l.wg.Done()
 
-	pushReq, _ := l.logDataToLoki(ld)
-	if len(pushReq.Streams) == 0 {
+	streams, _ := l.logDataToLokiStreams(ld, l.config.SplitByStream)
+	if len(streams) == 0 {
 		return consumererror.Permanent(fmt.Errorf("failed to transform logs into Loki log streams"))
 	}
 
+	if l.config.TimestampAdjustment.Enabled {
+		l.adjustTimestamps(streams)
+	}
+
+	if !l.config.SplitByStream {
+		if err := l.push(ctx, newPushRequest(streams)); err != nil {
+			if consumererror.IsPermanent(err) {
+				return err
+			}
+			return consumererror.NewLogs(err, ld)
+		}
+		l.recordTimestamps(streams)
+		return nil
+	}
+
+	var errs []error
+	failed := pdata.NewLogs()
+	for _, stream := range streams {
+		err := l.push(ctx, newPushRequest([]*lokiStream{stream}))
+		if err == nil {
+			l.recordTimestamps([]*lokiStream{stream})
+			continue
+		}
+		if consumererror.IsPermanent(err) {
+			l.logger.Error("Dropping log stream", zap.String("stream", stream.stream.Labels), zap.Error(err))
+			continue
+		}
+		errs = append(errs, fmt.Errorf("stream %s: %w", stream.stream.Labels, err))
+		stream.logs.ResourceLogs().MoveAndAppendTo(failed.ResourceLogs())
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return consumererror.NewLogs(consumererror.Combine(errs), failed)
+}
+
+// push sends a single push request to Loki.
+func (l *lokiExporter) push(ctx context.Context, pushReq *logproto.PushRequest) error {
 	buf, err := encode(pushReq)
 	if err != nil {
 		return consumererror.Permanent(err)
@@ -79,7 +137,7 @@ func (l *lokiExporter) pushLogData(ctx context.Context, ld pdata.Logs) error {
 
 	resp, err := l.client.Do(req)
 	if err != nil {
-		return consumererror.NewLogs(err, ld)
+		return err
 	}
 
 	_, _ = io.Copy(ioutil.Discard, resp.Body)
@@ -87,12 +145,91 @@ func (l *lokiExporter) pushLogData(ctx context.Context, ld pdata.Logs) error {
 
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
 		err = fmt.Errorf("HTTP %d %q", resp.StatusCode, http.StatusText(resp.StatusCode))
-		return consumererror.NewLogs(err, ld)
+		// Loki rejects invalid pushes with a 4xx status, which retrying won't fix. It only
+		// asks clients to retry with 429 when they hit its rate limits.
+		if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError &&
+			resp.StatusCode != http.StatusTooManyRequests {
+			return consumererror.Permanent(err)
+		}
+		return err
 	}
 
 	return nil
 }
 
+// adjustTimestamps sorts the entries of every stream by timestamp and moves the timestamps
+// Loki would reject into its accepted window: entries that are too old or too far in the
+// future are set to the current time, and entries older than the newest one already pushed
+// for their stream are set to that timestamp.
+func (l *lokiExporter) adjustTimestamps(streams []*lokiStream) {
+	now := l.now()
+	oldest := now.Add(-l.config.TimestampAdjustment.MaxAge)
+	newest := now.Add(l.config.TimestampAdjustment.MaxFutureSkew)
+
+	l.lastTimestampsMu.Lock()
+	defer l.lastTimestampsMu.Unlock()
+
+	for labels, last := range l.lastTimestamps {
+		if last.Before(oldest) {
+			delete(l.lastTimestamps, labels)
+		}
+	}
+
+	for _, stream := range streams {
+		entries := stream.stream.Entries
+		for i := range entries {
+			if entries[i].Timestamp.Before(oldest) || entries[i].Timestamp.After(newest) {
+				entries[i].Timestamp = now
+			}
+		}
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Timestamp.Before(entries[j].Timestamp)
+		})
+
+		last, ok := l.lastTimestamps[stream.stream.Labels]
+		if !ok {
+			continue
+		}
+		for i := range entries {
+			if !entries[i].Timestamp.Before(last) {
+				break
+			}
+			entries[i].Timestamp = last
+		}
+	}
+}
+
+// recordTimestamps remembers the newest timestamp of every successfully pushed stream.
+func (l *lokiExporter) recordTimestamps(streams []*lokiStream) {
+	if !l.config.TimestampAdjustment.Enabled {
+		return
+	}
+
+	l.lastTimestampsMu.Lock()
+	defer l.lastTimestampsMu.Unlock()
+
+	for _, stream := range streams {
+		entries := stream.stream.Entries
+		if len(entries) == 0 {
+			continue
+		}
+		newest := entries[len(entries)-1].Timestamp
+		if last, ok := l.lastTimestamps[stream.stream.Labels]; !ok || newest.After(last) {
+			l.lastTimestamps[stream.stream.Labels] = newest
+		}
+	}
+}
+
+func newPushRequest(streams []*lokiStream) *logproto.PushRequest {
+	pr := &logproto.PushRequest{
+		Streams: make([]logproto.Stream, len(streams)),
+	}
+	for i, stream := range streams {
+		pr.Streams[i] = stream.stream
+	}
+	return pr
+}
+
 func encode(pb proto.Message) ([]byte, error) {
 	buf, err := proto.Marshal(pb)
 	if err != nil {
@@ -119,7 +256,14 @@ func (l *lokiExporter) stop(context.Context) (err error) {
 }
 
 func (l *lokiExporter) logDataToLoki(ld pdata.Logs) (pr *logproto.PushRequest, numDroppedLogs int) {
-	streams := make(map[string]*logproto.Stream)
+	streams, numDroppedLogs := l.logDataToLokiStreams(ld, false)
+	return newPushRequest(streams), numDroppedLogs
+}
+
+// logDataToLokiStreams groups the log records into Loki log streams, in the order the streams
+// are first seen. When keepLogs is set, every stream also holds a copy of its log records.
+func (l *lokiExporter) logDataToLokiStreams(ld pdata.Logs, keepLogs bool) (streams []*lokiStream, numDroppedLogs int) {
+	streamsByLabels := make(map[string]*lokiStream)
 	rls := ld.ResourceLogs()
 	for i := 0; i < rls.Len(); i++ {
 		ills := rls.At(i).InstrumentationLibraryLogs()
@@ -137,30 +281,37 @@ func (l *lokiExporter) logDataToLoki(ld pdata.Logs) (pr *logproto.PushRequest, n
 				labels := mergedLabels.String()
 				entry := convertLogToLokiEntry(log)
 
-				if stream, ok := streams[labels]; ok {
-					stream.Entries = append(stream.Entries, *entry)
-					continue
+				stream, ok := streamsByLabels[labels]
+				if !ok {
+					stream = &lokiStream{
+						stream: logproto.Stream{Labels: labels},
+						source: [2]int{-1, -1},
+					}
+					if keepLogs {
+						stream.logs = pdata.NewLogs()
+					}
+					streamsByLabels[labels] = stream
+					streams = append(streams, stream)
 				}
+				stream.stream.Entries = append(stream.stream.Entries, *entry)
 
-				streams[labels] = &logproto.Stream{
-					Labels:  labels,
-					Entries: []logproto.Entry{*entry},
+				if !keepLogs {
+					continue
 				}
+				if stream.source != [2]int{i, j} {
+					rl := stream.logs.ResourceLogs().AppendEmpty()
+					resource.CopyTo(rl.Resource())
+					ill := rl.InstrumentationLibraryLogs().AppendEmpty()
+					ills.At(j).InstrumentationLibrary().CopyTo(ill.InstrumentationLibrary())
+					stream.records = ill.Logs()
+					stream.source = [2]int{i, j}
+				}
+				log.CopyTo(stream.records.AppendEmpty())
 			}
 		}
 	}
 
-	pr = &logproto.PushRequest{
-		Streams: make([]logproto.Stream, len(streams)),
-	}
-
-	i := 0
-	for _, stream := range streams {
-		pr.Streams[i] = *stream
-		i++
-	}
-
-	return pr, numDroppedLogs
+	return streams, numDroppedLogs
 }
 
 func (l *lokiExporter) convertAttributesAndMerge(logAttrs pdata.AttributeMap, resourceAttrs pdata.AttributeMap) (mergedAttributes model.LabelSet, dropped bool) {
@@ -24,6 +24,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
@@ -35,7 +36,7 @@ import (
 	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
 	"go.uber.org/zap"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter/internal/third_party/loki/logproto"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/loki/third_party/logproto"
 )
 
 const (
@@ -165,6 +166,31 @@ func TestExporter_pushLogData(t *testing.T) {
 				require.Equal(t, 10, e.GetLogs().LogRecordCount())
 			},
 		},
+		{
+			name:             "bad request",
+			reqTestFunc:      genericReqTestFunc,
+			config:           genericConfig,
+			httpResponseCode: http.StatusBadRequest,
+			testServer:       true,
+			genLogsFunc:      genericGenLogsFunc,
+			errFunc: func(err error) {
+				require.True(t, consumererror.IsPermanent(err))
+			},
+		},
+		{
+			name:             "rate limited",
+			reqTestFunc:      genericReqTestFunc,
+			config:           genericConfig,
+			httpResponseCode: http.StatusTooManyRequests,
+			testServer:       true,
+			genLogsFunc:      genericGenLogsFunc,
+			errFunc: func(err error) {
+				require.False(t, consumererror.IsPermanent(err))
+				var e consumererror.Logs
+				require.True(t, consumererror.AsLogs(err, &e))
+				require.Equal(t, 10, e.GetLogs().LogRecordCount())
+			},
+		},
 		{
 			name:             "server unavailable",
 			reqTestFunc:      genericReqTestFunc,
@@ -396,6 +422,120 @@ func TestExporter_logDataToLoki(t *testing.T) {
 
 }
 
+func TestExporter_pushLogDataSplitByStream(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		buf, err := snappy.Decode(nil, body)
+		require.NoError(t, err)
+		pr := &logproto.PushRequest{}
+		require.NoError(t, proto.Unmarshal(buf, pr))
+		require.Len(t, pr.Streams, 1)
+
+		switch pr.Streams[0].Labels {
+		case `{severity="error"}`:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case `{severity="warn"}`:
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	config := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: server.URL,
+		},
+		Labels: LabelsConfig{
+			Attributes: map[string]string{
+				"severity": "",
+			},
+		},
+		SplitByStream: true,
+	}
+	exp := newExporter(config, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	logs := pdata.NewLogs()
+	for _, severity := range []string{"debug", "error", "info", "warn"} {
+		createLogData(3,
+			pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+				"severity": pdata.NewAttributeValueString(severity),
+			})).ResourceLogs().MoveAndAppendTo(logs.ResourceLogs())
+	}
+
+	err := exp.pushLogData(context.Background(), logs)
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+	assert.Equal(t, 4, requests)
+
+	// The stream Loki rejected with a 400 is dropped instead of being retried.
+
+	var e consumererror.Logs
+	require.True(t, consumererror.AsLogs(err, &e))
+	failed := e.GetLogs()
+	require.Equal(t, 3, failed.LogRecordCount())
+	records := failed.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	for i := 0; i < records.Len(); i++ {
+		severity, ok := records.At(i).Attributes().Get("severity")
+		require.True(t, ok)
+		assert.Equal(t, "error", severity.StringVal())
+	}
+}
+
+func TestExporter_adjustTimestamps(t *testing.T) {
+	now := time.Date(2021, 8, 1, 12, 0, 0, 0, time.UTC)
+	config := &Config{
+		TimestampAdjustment: TimestampAdjustmentConfig{
+			Enabled:       true,
+			MaxAge:        time.Hour,
+			MaxFutureSkew: time.Minute,
+		},
+	}
+	exp := newExporter(config, zap.NewNop())
+	exp.now = func() time.Time { return now }
+
+	newStream := func(timestamps ...time.Time) *lokiStream {
+		stream := &lokiStream{stream: logproto.Stream{Labels: `{severity="info"}`}}
+		for _, ts := range timestamps {
+			stream.stream.Entries = append(stream.stream.Entries, logproto.Entry{Timestamp: ts})
+		}
+		return stream
+	}
+	timestamps := func(stream *lokiStream) []time.Time {
+		var out []time.Time
+		for _, entry := range stream.stream.Entries {
+			out = append(out, entry.Timestamp)
+		}
+		return out
+	}
+
+	stream := newStream(
+		now.Add(-time.Minute),
+		now.Add(-2*time.Hour),
+		now.Add(-3*time.Minute),
+		now.Add(time.Hour),
+	)
+	exp.adjustTimestamps([]*lokiStream{stream})
+	assert.Equal(t, []time.Time{now.Add(-3 * time.Minute), now.Add(-time.Minute), now, now}, timestamps(stream))
+
+	exp.recordTimestamps([]*lokiStream{stream})
+	assert.Equal(t, now, exp.lastTimestamps[`{severity="info"}`])
+
+	// Entries older than the last pushed one are moved up to it.
+	stream = newStream(now.Add(-5*time.Minute), now.Add(30*time.Second))
+	exp.adjustTimestamps([]*lokiStream{stream})
+	assert.Equal(t, []time.Time{now, now.Add(30 * time.Second)}, timestamps(stream))
+
+	// Streams that haven't been pushed within max_age are forgotten.
+	now = now.Add(2 * time.Hour)
+	exp.adjustTimestamps(nil)
+	assert.Empty(t, exp.lastTimestamps)
+}
+
 func TestExporter_convertAttributesToLabels(t *testing.T) {
 	config := &Config{
 		HTTPClientSettings: confighttp.HTTPClientSettings{
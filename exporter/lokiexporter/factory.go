@@ -24,7 +24,13 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
 
-const typeStr = "loki"
+const (
+	typeStr = "loki"
+
+	// Loki's defaults for reject_old_samples_max_age and creation_grace_period.
+	defaultTimestampMaxAge        = 168 * time.Hour
+	defaultTimestampMaxFutureSkew = 10 * time.Minute
+)
 
 // NewFactory creates a factory for Loki exporter.
 func NewFactory() component.ExporterFactory {
@@ -52,6 +58,10 @@ func createDefaultConfig() config.Exporter {
 			Attributes:         map[string]string{},
 			ResourceAttributes: map[string]string{},
 		},
+		TimestampAdjustment: TimestampAdjustmentConfig{
+			MaxAge:        defaultTimestampMaxAge,
+			MaxFutureSkew: defaultTimestampMaxFutureSkew,
+		},
 	}
 }
 
@@ -45,6 +45,10 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 	assert.Equal(t, true, ocfg.QueueSettings.Enabled, "default sending queue is enabled")
 	assert.Equal(t, "", ocfg.TenantID)
 	assert.Equal(t, map[string]string{}, ocfg.Labels.Attributes)
+	assert.False(t, ocfg.SplitByStream)
+	assert.False(t, ocfg.TimestampAdjustment.Enabled)
+	assert.Equal(t, 168*time.Hour, ocfg.TimestampAdjustment.MaxAge)
+	assert.Equal(t, 10*time.Minute, ocfg.TimestampAdjustment.MaxFutureSkew)
 }
 
 func TestFactory_CreateLogExporter(t *testing.T) {
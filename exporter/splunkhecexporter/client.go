@@ -43,6 +43,7 @@ type client struct {
 	zippers sync.Pool
 	wg      sync.WaitGroup
 	headers map[string]string
+	monitor *hecMonitor
 }
 
 // bufferState encapsulates intermediate buffer state when pushing log data
@@ -71,33 +72,7 @@ func (c *client) pushMetricsData(
 		return nil
 	}
 
-	body, compressed, err := encodeBodyEvents(&c.zippers, splunkDataPoints, c.config.DisableCompression)
-	if err != nil {
-		return consumererror.Permanent(err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "POST", c.url.String(), body)
-	if err != nil {
-		return consumererror.Permanent(err)
-	}
-
-	for k, v := range c.headers {
-		req.Header.Set(k, v)
-	}
-
-	if compressed {
-		req.Header.Set("Content-Encoding", "gzip")
-	}
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return err
-	}
-
-	io.Copy(ioutil.Discard, resp.Body)
-	resp.Body.Close()
-
-	return splunk.HandleHTTPCode(resp)
+	return c.sendSplunkEvents(ctx, splunkDataPoints)
 }
 
 func (c *client) pushTraceData(
@@ -120,7 +95,22 @@ func (c *client) sendSplunkEvents(ctx context.Context, splunkEvents []*splunk.Ev
 	if err != nil {
 		return consumererror.Permanent(err)
 	}
-	return c.postEvents(ctx, body, nil, compressed)
+	if err = c.postEvents(ctx, body, nil, compressed); err != nil {
+		return err
+	}
+	c.monitor.recordEvents(len(splunkEvents))
+	return nil
+}
+
+// sendMonitoringEvents sends the heartbeat and telemetry events, which are not
+// accounted for in the telemetry of the exporter.
+func (c *client) sendMonitoringEvents(ctx context.Context, events []*splunk.Event) error {
+	body, compressed, err := encodeBodyEvents(&c.zippers, events, c.config.DisableCompression)
+	if err != nil {
+		return err
+	}
+	_, err = c.post(ctx, body, nil, compressed)
+	return err
 }
 
 func (c *client) pushLogData(ctx context.Context, ld pdata.Logs) error {
@@ -136,6 +126,9 @@ func (c *client) pushLogData(ctx context.Context, ld pdata.Logs) error {
 	// Callback when each batch is to be sent.
 	send := func(ctx context.Context, buf *bytes.Buffer, headers map[string]string) (err error) {
 		shouldCompress := buf.Len() >= minCompressionLen && !c.config.DisableCompression
+		// Each JSON encoded event is terminated by a newline.
+		events := bytes.Count(buf.Bytes(), []byte{'\n'})
+		body := buf
 
 		if shouldCompress {
 			gzipBuffer.Reset()
@@ -149,10 +142,14 @@ func (c *client) pushLogData(ctx context.Context, ld pdata.Logs) error {
 				return fmt.Errorf("failed flushing compressed data to gzip writer: %v", err)
 			}
 
-			return c.postEvents(ctx, gzipBuffer, headers, shouldCompress)
+			body = gzipBuffer
 		}
 
-		return c.postEvents(ctx, buf, headers, shouldCompress)
+		if err = c.postEvents(ctx, body, headers, shouldCompress); err != nil {
+			return err
+		}
+		c.monitor.recordEvents(events)
+		return nil
 	}
 
 	return c.pushLogDataInBatches(ctx, ld, send)
@@ -306,9 +303,16 @@ func (c *client) pushLogRecords(ctx context.Context, lds pdata.ResourceLogsSlice
 }
 
 func (c *client) postEvents(ctx context.Context, events io.Reader, headers map[string]string, compressed bool) error {
+	size, err := c.post(ctx, events, headers, compressed)
+	c.monitor.recordRequest(size, err)
+	return err
+}
+
+// post sends the events to the HEC endpoint and returns the size of the request body.
+func (c *client) post(ctx context.Context, events io.Reader, headers map[string]string, compressed bool) (int64, error) {
 	req, err := http.NewRequestWithContext(ctx, "POST", c.url.String(), events)
 	if err != nil {
-		return consumererror.Permanent(err)
+		return 0, consumererror.Permanent(err)
 	}
 
 	// Set the headers configured for the client
@@ -327,7 +331,7 @@ func (c *client) postEvents(ctx context.Context, events io.Reader, headers map[s
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return err
+		return req.ContentLength, err
 	}
 	defer resp.Body.Close()
 
@@ -335,7 +339,7 @@ func (c *client) postEvents(ctx context.Context, events io.Reader, headers map[s
 
 	io.Copy(ioutil.Discard, resp.Body)
 
-	return err
+	return req.ContentLength, err
 }
 
 // subLogs returns a subset of `ld` starting from `profilingBufFront` for profiling data
@@ -431,10 +435,11 @@ func getReader(zippers *sync.Pool, b *bytes.Buffer, disableCompression bool) (io
 }
 
 func (c *client) stop(context.Context) error {
+	c.monitor.stop()
 	c.wg.Wait()
 	return nil
 }
 
-func (c *client) start(context.Context, component.Host) (err error) {
-	return nil
+func (c *client) start(ctx context.Context, _ component.Host) (err error) {
+	return c.monitor.start(ctx)
 }
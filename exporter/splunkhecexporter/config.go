@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"time"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtls"
@@ -71,6 +72,33 @@ type Config struct {
 
 	// App version is used to track telemetry information for Splunk App's using HEC by App version. Defaults to the current OpenTelemetry Collector Contrib build version.
 	SplunkAppVersion string `mapstructure:"splunk_app_version"`
+
+	// Heartbeat configures the heartbeat events sent to monitor the liveness of the exporter.
+	Heartbeat HecHeartbeat `mapstructure:"heartbeat"`
+
+	// Telemetry configures the events reporting the data sent by the exporter.
+	Telemetry HecTelemetry `mapstructure:"telemetry"`
+
+	// Splunk index of the heartbeat and telemetry events. Defaults to the index of the data.
+	MonitoringIndex string `mapstructure:"monitoring_index"`
+}
+
+// HecHeartbeat defines the heartbeat events of the exporter.
+type HecHeartbeat struct {
+	// Interval between two heartbeat events. Periodic heartbeat events are disabled when 0, the default.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Startup sends a heartbeat event when the exporter starts, failing the start if it cannot be sent. Defaults to false.
+	Startup bool `mapstructure:"startup"`
+}
+
+// HecTelemetry defines the self-telemetry events of the exporter.
+type HecTelemetry struct {
+	// Enabled sends the events reporting the events, requests and bytes sent by the exporter. Defaults to false.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Interval between two telemetry events, each one reporting the data sent since the previous one. Defaults to 60s.
+	Interval time.Duration `mapstructure:"interval"`
 }
 
 func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
@@ -102,6 +130,14 @@ func (cfg *Config) validateConfig() error {
 		return fmt.Errorf(`requires "max_content_length_logs" <= %d`, maxContentLengthLogsLimit)
 	}
 
+	if cfg.Heartbeat.Interval < 0 {
+		return errors.New(`requires "heartbeat.interval" >= 0`)
+	}
+
+	if cfg.Telemetry.Enabled && cfg.Telemetry.Interval <= 0 {
+		return errors.New(`requires "telemetry.interval" > 0 when "telemetry.enabled" is true`)
+	}
+
 	return nil
 }
 
@@ -83,6 +83,15 @@ func TestLoadConfig(t *testing.T) {
 			},
 			InsecureSkipVerify: false,
 		},
+		Heartbeat: HecHeartbeat{
+			Interval: 30 * time.Second,
+			Startup:  true,
+		},
+		Telemetry: HecTelemetry{
+			Enabled:  true,
+			Interval: 5 * time.Minute,
+		},
+		MonitoringIndex: "_internal_otel",
 	}
 	assert.Equal(t, &expectedCfg, e1)
 
@@ -100,6 +109,8 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 		SourceType           string
 		Index                string
 		MaxContentLengthLogs uint
+		Heartbeat            HecHeartbeat
+		Telemetry            HecTelemetry
 	}
 	tests := []struct {
 		name    string
@@ -154,6 +165,26 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Test negative heartbeat interval",
+			fields: fields{
+				Token:     "1234",
+				Endpoint:  "https://example.com:8000",
+				Heartbeat: HecHeartbeat{Interval: -time.Second},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Test telemetry enabled without interval",
+			fields: fields{
+				Token:     "1234",
+				Endpoint:  "https://example.com:8000",
+				Telemetry: HecTelemetry{Enabled: true},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -164,6 +195,8 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 				SourceType:           tt.fields.SourceType,
 				Index:                tt.fields.Index,
 				MaxContentLengthLogs: tt.fields.MaxContentLengthLogs,
+				Heartbeat:            tt.fields.Heartbeat,
+				Telemetry:            tt.fields.Telemetry,
 			}
 			got, err := cfg.getOptionsFromConfig()
 			if (err != nil) != tt.wantErr {
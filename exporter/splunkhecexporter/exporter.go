@@ -53,9 +53,10 @@ type exporterOptions struct {
 	token string
 }
 
-// createExporter returns a new Splunk exporter.
+// createExporter returns a new Splunk exporter of the given signal type.
 func createExporter(
 	config *Config,
+	signalType string,
 	logger *zap.Logger,
 	buildinfo *component.BuildInfo,
 ) (*splunkExporter, error) {
@@ -81,6 +82,7 @@ func createExporter(
 	if err != nil {
 		return nil, err
 	}
+	client.monitor = newHecMonitor(config, signalType, logger, client.sendMonitoringEvents)
 
 	return &splunkExporter{
 		pushMetricsData: client.pushMetricsData,
@@ -48,7 +48,7 @@ import (
 
 func TestNew(t *testing.T) {
 	buildInfo := component.DefaultBuildInfo()
-	got, err := createExporter(nil, zap.NewNop(), &buildInfo)
+	got, err := createExporter(nil, "metrics", zap.NewNop(), &buildInfo)
 	assert.EqualError(t, err, "nil config")
 	assert.Nil(t, got)
 
@@ -57,7 +57,7 @@ func TestNew(t *testing.T) {
 		Endpoint:        "https://example.com:8088",
 		TimeoutSettings: exporterhelper.TimeoutSettings{Timeout: 1 * time.Second},
 	}
-	got, err = createExporter(config, zap.NewNop(), &buildInfo)
+	got, err = createExporter(config, "metrics", zap.NewNop(), &buildInfo)
 	assert.NoError(t, err)
 	require.NotNil(t, got)
 
@@ -74,7 +74,7 @@ func TestNew(t *testing.T) {
 			InsecureSkipVerify: false,
 		},
 	}
-	got, err = createExporter(config, zap.NewNop(), &buildInfo)
+	got, err = createExporter(config, "metrics", zap.NewNop(), &buildInfo)
 	assert.Error(t, err)
 	require.Nil(t, got)
 }
@@ -338,7 +338,7 @@ func TestExporterStartAlwaysReturnsNil(t *testing.T) {
 		Endpoint: "https://example.com:8088",
 		Token:    "abc",
 	}
-	e, err := createExporter(config, zap.NewNop(), &buildInfo)
+	e, err := createExporter(config, "metrics", zap.NewNop(), &buildInfo)
 	assert.NoError(t, err)
 	assert.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
 }
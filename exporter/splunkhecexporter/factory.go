@@ -29,6 +29,8 @@ const (
 	typeStr            = "splunk_hec"
 	defaultMaxIdleCons = 100
 	defaultHTTPTimeout = 10 * time.Second
+
+	defaultTelemetryInterval = 60 * time.Second
 )
 
 // NewFactory creates a factory for Splunk HEC exporter.
@@ -52,6 +54,9 @@ func createDefaultConfig() config.Exporter {
 		DisableCompression:   false,
 		MaxConnections:       defaultMaxIdleCons,
 		MaxContentLengthLogs: maxContentLengthLogsLimit,
+		Telemetry: HecTelemetry{
+			Interval: defaultTelemetryInterval,
+		},
 	}
 }
 
@@ -65,7 +70,7 @@ func createTracesExporter(
 	}
 	expCfg := config.(*Config)
 
-	exp, err := createExporter(expCfg, set.Logger, &set.BuildInfo)
+	exp, err := createExporter(expCfg, "traces", set.Logger, &set.BuildInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -92,7 +97,7 @@ func createMetricsExporter(
 	}
 	expCfg := config.(*Config)
 
-	exp, err := createExporter(expCfg, set.Logger, &set.BuildInfo)
+	exp, err := createExporter(expCfg, "metrics", set.Logger, &set.BuildInfo)
 
 	if err != nil {
 		return nil, err
@@ -120,7 +125,7 @@ func createLogsExporter(
 	}
 	expCfg := config.(*Config)
 
-	exp, err := createExporter(expCfg, set.Logger, &set.BuildInfo)
+	exp, err := createExporter(expCfg, "logs", set.Logger, &set.BuildInfo)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,206 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecexporter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+)
+
+const (
+	heartbeatEventName   = "HeartbeatEvent"
+	telemetryEventName   = "TelemetryEvent"
+	monitoringSource     = "otelcol"
+	monitoringSourceType = "otelcol:monitoring"
+)
+
+// hecMonitor periodically sends the heartbeat and telemetry events of an exporter,
+// so that its liveness and throughput can be monitored from Splunk.
+type hecMonitor struct {
+	// The data sent since the last telemetry event, accessed atomically.
+	eventsSent     int64
+	requestsSent   int64
+	requestsFailed int64
+	bytesSent      int64
+
+	config     *Config
+	signalType string
+	hostname   string
+	logger     *zap.Logger
+	send       func(ctx context.Context, events []*splunk.Event) error
+
+	lastTelemetry time.Time
+	done          chan struct{}
+	wg            sync.WaitGroup
+}
+
+// newHecMonitor returns the monitor of an exporter of the given signal type, or nil
+// when neither the heartbeat nor the telemetry events are enabled.
+func newHecMonitor(config *Config, signalType string, logger *zap.Logger, send func(context.Context, []*splunk.Event) error) *hecMonitor {
+	if config.Heartbeat.Interval <= 0 && !config.Heartbeat.Startup && !config.Telemetry.Enabled {
+		return nil
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		logger.Warn("Failed to get the hostname for the monitoring events", zap.Error(err))
+	}
+
+	return &hecMonitor{
+		config:     config,
+		signalType: signalType,
+		hostname:   hostname,
+		logger:     logger,
+		send:       send,
+		done:       make(chan struct{}),
+	}
+}
+
+func (m *hecMonitor) start(ctx context.Context) error {
+	if m == nil {
+		return nil
+	}
+
+	if m.config.Heartbeat.Startup {
+		if err := m.sendHeartbeat(ctx); err != nil {
+			return fmt.Errorf("failed to send the startup heartbeat event: %w", err)
+		}
+	}
+
+	if m.config.Heartbeat.Interval > 0 {
+		m.wg.Add(1)
+		go m.run(m.config.Heartbeat.Interval, m.sendHeartbeat)
+	}
+
+	if m.config.Telemetry.Enabled {
+		m.lastTelemetry = time.Now()
+		m.wg.Add(1)
+		go m.run(m.config.Telemetry.Interval, m.sendTelemetry)
+	}
+
+	return nil
+}
+
+func (m *hecMonitor) stop() {
+	if m == nil {
+		return
+	}
+	close(m.done)
+	m.wg.Wait()
+}
+
+func (m *hecMonitor) run(interval time.Duration, send func(context.Context) error) {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+			if err := send(context.Background()); err != nil {
+				m.logger.Warn("Failed to send the monitoring event to Splunk", zap.Error(err))
+			}
+		}
+	}
+}
+
+// recordRequest counts a request posted to the HEC endpoint with a body of the given size.
+func (m *hecMonitor) recordRequest(size int64, err error) {
+	if m == nil {
+		return
+	}
+	atomic.AddInt64(&m.requestsSent, 1)
+	if err != nil {
+		atomic.AddInt64(&m.requestsFailed, 1)
+		return
+	}
+	if size > 0 {
+		atomic.AddInt64(&m.bytesSent, size)
+	}
+}
+
+// recordEvents counts the events successfully sent to the HEC endpoint.
+func (m *hecMonitor) recordEvents(count int) {
+	if m == nil {
+		return
+	}
+	atomic.AddInt64(&m.eventsSent, int64(count))
+}
+
+func (m *hecMonitor) sendHeartbeat(ctx context.Context) error {
+	event := m.newEvent(heartbeatEventName)
+	event.Fields["os.type"] = runtime.GOOS
+	return m.send(ctx, []*splunk.Event{event})
+}
+
+func (m *hecMonitor) sendTelemetry(ctx context.Context) error {
+	now := time.Now()
+	eventsSent := atomic.SwapInt64(&m.eventsSent, 0)
+	requestsSent := atomic.SwapInt64(&m.requestsSent, 0)
+	requestsFailed := atomic.SwapInt64(&m.requestsFailed, 0)
+	bytesSent := atomic.SwapInt64(&m.bytesSent, 0)
+
+	event := m.newEvent(telemetryEventName)
+	event.Fields["interval"] = now.Sub(m.lastTelemetry).Seconds()
+	event.Fields["events_sent"] = eventsSent
+	event.Fields["requests_sent"] = requestsSent
+	event.Fields["requests_failed"] = requestsFailed
+	event.Fields["bytes_sent"] = bytesSent
+
+	if err := m.send(ctx, []*splunk.Event{event}); err != nil {
+		// Report the data in the next telemetry event instead.
+		atomic.AddInt64(&m.eventsSent, eventsSent)
+		atomic.AddInt64(&m.requestsSent, requestsSent)
+		atomic.AddInt64(&m.requestsFailed, requestsFailed)
+		atomic.AddInt64(&m.bytesSent, bytesSent)
+		return err
+	}
+	m.lastTelemetry = now
+	return nil
+}
+
+func (m *hecMonitor) newEvent(name string) *splunk.Event {
+	index := m.config.MonitoringIndex
+	if index == "" {
+		index = m.config.Index
+	}
+	now := float64(time.Now().UnixNano()) / 1e9
+	return &splunk.Event{
+		Time:       &now,
+		Host:       m.hostname,
+		Source:     monitoringSource,
+		SourceType: monitoringSourceType,
+		Index:      index,
+		Event:      name,
+		Fields: map[string]interface{}{
+			"exporter":           m.config.ID().String(),
+			"signal_type":        m.signalType,
+			"splunk_app_name":    m.config.SplunkAppName,
+			"splunk_app_version": m.config.SplunkAppVersion,
+		},
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecexporter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+)
+
+// eventsServer is a HEC endpoint recording the events it receives.
+type eventsServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests int
+	events   []splunk.Event
+}
+
+// newEventsServer returns an eventsServer answering the requests with the given
+// status codes in turn, and then with 200.
+func newEventsServer(t *testing.T, statusCodes ...int) *eventsServer {
+	s := &eventsServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		statusCode := http.StatusOK
+		if s.requests < len(statusCodes) {
+			statusCode = statusCodes[s.requests]
+		}
+		s.requests++
+		s.mu.Unlock()
+		if statusCode != http.StatusOK {
+			w.WriteHeader(statusCode)
+			return
+		}
+
+		decoder := json.NewDecoder(r.Body)
+		for decoder.More() {
+			var event splunk.Event
+			if !assert.NoError(t, decoder.Decode(&event)) {
+				break
+			}
+			s.mu.Lock()
+			s.events = append(s.events, event)
+			s.mu.Unlock()
+		}
+	}))
+	return s
+}
+
+// eventsNamed returns the received events with the given event name.
+func (s *eventsServer) eventsNamed(name string) []splunk.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []splunk.Event
+	for _, event := range s.events {
+		if event.Event == name {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func newMonitoredExporter(t *testing.T, cfg *Config, signalType string) *splunkExporter {
+	buildInfo := component.BuildInfo{Version: "1.2.3"}
+	exp, err := createExporter(cfg, signalType, zap.NewNop(), &buildInfo)
+	require.NoError(t, err)
+	return exp
+}
+
+func newMonitoringConfig(endpoint string) *Config {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Token = "1234-1234"
+	cfg.Index = "metrics"
+	cfg.DisableCompression = true
+	return cfg
+}
+
+func TestMonitorDisabled(t *testing.T) {
+	cfg := newMonitoringConfig("https://example.com:8088")
+	exp := newMonitoredExporter(t, cfg, "metrics")
+	assert.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, exp.stop(context.Background()))
+
+	assert.Nil(t, newHecMonitor(cfg, "metrics", zap.NewNop(), nil))
+}
+
+func TestHeartbeat(t *testing.T) {
+	server := newEventsServer(t)
+	defer server.Close()
+
+	cfg := newMonitoringConfig(server.URL)
+	cfg.ExporterSettings = config.NewExporterSettings(config.NewIDWithName(typeStr, "monitored"))
+	cfg.Heartbeat = HecHeartbeat{Interval: 10 * time.Millisecond, Startup: true}
+	cfg.MonitoringIndex = "_internal_otel"
+
+	exp := newMonitoredExporter(t, cfg, "traces")
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	// The startup heartbeat is sent before the start returns.
+	require.NotEmpty(t, server.eventsNamed(heartbeatEventName))
+
+	require.Eventually(t, func() bool {
+		return len(server.eventsNamed(heartbeatEventName)) > 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, exp.stop(context.Background()))
+
+	event := server.eventsNamed(heartbeatEventName)[0]
+	assert.NotNil(t, event.Time)
+	assert.Equal(t, monitoringSource, event.Source)
+	assert.Equal(t, monitoringSourceType, event.SourceType)
+	assert.Equal(t, "_internal_otel", event.Index)
+	assert.Equal(t, "splunk_hec/monitored", event.Fields["exporter"])
+	assert.Equal(t, "traces", event.Fields["signal_type"])
+	assert.Equal(t, defaultSplunkAppName, event.Fields["splunk_app_name"])
+	assert.Equal(t, "1.2.3", event.Fields["splunk_app_version"])
+}
+
+func TestStartupHeartbeatFailure(t *testing.T) {
+	server := newEventsServer(t, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	cfg := newMonitoringConfig(server.URL)
+	cfg.Heartbeat.Startup = true
+
+	exp := newMonitoredExporter(t, cfg, "logs")
+	err := exp.start(context.Background(), componenttest.NewNopHost())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send the startup heartbeat event")
+}
+
+func TestTelemetry(t *testing.T) {
+	server := newEventsServer(t)
+	defer server.Close()
+
+	cfg := newMonitoringConfig(server.URL)
+	cfg.Telemetry = HecTelemetry{Enabled: true, Interval: 50 * time.Millisecond}
+
+	exp := newMonitoredExporter(t, cfg, "metrics")
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.pushMetricsData(context.Background(), createMetricsData(3)))
+
+	var telemetry splunk.Event
+	require.Eventually(t, func() bool {
+		for _, event := range server.eventsNamed(telemetryEventName) {
+			if event.Fields["events_sent"] != float64(0) {
+				telemetry = event
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, exp.stop(context.Background()))
+
+	assert.Equal(t, "metrics", telemetry.Index)
+	assert.Equal(t, "metrics", telemetry.Fields["signal_type"])
+	assert.Equal(t, float64(3), telemetry.Fields["events_sent"])
+	assert.Equal(t, float64(1), telemetry.Fields["requests_sent"])
+	assert.Equal(t, float64(0), telemetry.Fields["requests_failed"])
+	assert.Greater(t, telemetry.Fields["bytes_sent"], float64(0))
+	assert.Greater(t, telemetry.Fields["interval"], float64(0))
+}
+
+func TestTelemetryRecordsFailures(t *testing.T) {
+	// The request carrying the logs fails.
+	server := newEventsServer(t, http.StatusBadRequest)
+	defer server.Close()
+
+	cfg := newMonitoringConfig(server.URL)
+	cfg.Telemetry = HecTelemetry{Enabled: true, Interval: 50 * time.Millisecond}
+
+	exp := newMonitoredExporter(t, cfg, "logs")
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	assert.Error(t, exp.pushLogData(context.Background(), createLogData(1, 1, 2)))
+
+	var telemetry splunk.Event
+	require.Eventually(t, func() bool {
+		for _, event := range server.eventsNamed(telemetryEventName) {
+			if event.Fields["requests_failed"] != float64(0) {
+				telemetry = event
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, exp.stop(context.Background()))
+
+	assert.Equal(t, float64(0), telemetry.Fields["events_sent"])
+	assert.Equal(t, float64(1), telemetry.Fields["requests_sent"])
+	assert.Equal(t, float64(1), telemetry.Fields["requests_failed"])
+	assert.Equal(t, float64(0), telemetry.Fields["bytes_sent"])
+}
@@ -47,4 +47,11 @@ type Config struct {
 	// AWSEndpoint is the X-Ray service endpoint which the local
 	// TCP server forwards requests to.
 	AWSEndpoint string `mapstructure:"aws_endpoint"`
+
+	// IMDSv2Only determines whether the region is only fetched from the
+	// EC2 instance metadata endpoint with an IMDSv2 session token.
+	IMDSv2Only bool `mapstructure:"imdsv2_only"`
+
+	// STSEndpoint is the STS endpoint used to assume RoleARN.
+	STSEndpoint string `mapstructure:"sts_endpoint"`
 }
@@ -56,6 +56,8 @@ func TestLoadConfig(t *testing.T) {
 			Region:      "us-west-1",
 			RoleARN:     "arn:aws:iam::123456789012:role/awesome_role",
 			AWSEndpoint: "https://another.aws.endpoint.com",
+			IMDSv2Only:  true,
+			STSEndpoint: "https://sts.us-gov-west-1.amazonaws.com",
 		},
 		ext1)
 
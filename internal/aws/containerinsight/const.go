@@ -36,6 +36,8 @@ const (
 	EbsVolumeID             = "ebs_volume_id" //used by kubernetes cluster as persistent volume
 	HostEbsVolumeID         = "EBSVolumeId"   //used by host filesystem
 	FSType                  = "fstype"
+	GpuDevice               = "GpuDevice"
+	EfaDevice               = "EfaDevice"
 	MetricType              = "Type"
 	SourcesKey              = "Sources"
 	Timestamp               = "Timestamp"
@@ -86,6 +88,19 @@ const (
 	FSInodesfree  = "filesystem_inodes_free"
 	FSUtilization = "filesystem_utilization"
 
+	GPUUtilization        = "gpu_utilization"
+	GPUMemUsed            = "gpu_memory_used"
+	GPUMemTotal           = "gpu_memory_total"
+	GPUMemUtilization     = "gpu_memory_utilization"
+	GPUTemperature        = "gpu_temperature"
+	GPUPowerDraw          = "gpu_power_draw"
+	EfaRxBytes            = "efa_rx_bytes"
+	EfaTxBytes            = "efa_tx_bytes"
+	EfaRxDropped          = "efa_rx_dropped"
+	EfaRdmaReadBytes      = "efa_rdma_read_bytes"
+	EfaRdmaWriteBytes     = "efa_rdma_write_bytes"
+	EfaRdmaWriteRecvBytes = "efa_rdma_write_recv_bytes"
+
 	RunningPodCount       = "number_of_running_pods"
 	RunningContainerCount = "number_of_running_containers"
 	ContainerCount        = "number_of_containers"
@@ -115,11 +130,15 @@ const (
 	TypeNodeNet          = "NodeNet"
 	TypeInstanceDiskIO   = "InstanceDiskIO"
 	TypeNodeDiskIO       = "NodeDiskIO"
+	TypeNodeGPU          = "NodeGPU"
+	TypeNodeEFA          = "NodeEFA"
 	TypePod              = "Pod"
 	TypePodNet           = "PodNet"
 	TypeContainer        = "Container"
 	TypeContainerFS      = "ContainerFS"
 	TypeContainerDiskIO  = "ContainerDiskIO"
+	TypeContainerGPU     = "ContainerGPU"
+	TypeTask             = "Task" //mean ECS Task on Fargate
 
 	//unit
 	UnitBytes       = "Bytes"
@@ -130,6 +149,8 @@ const (
 	UnitCountPerSec = "Count/Second"
 	UnitVCPU        = "vCPU"
 	UnitPercent     = "Percent"
+	UnitWatts       = "Watts"
+	UnitCelsius     = "Celsius"
 )
 
 var metricToUnitMap map[string]string
@@ -198,6 +219,22 @@ func init() {
 		FSInodesfree:  UnitCount,
 		FSUtilization: UnitPercent,
 
+		//gpu metrics
+		GPUUtilization:    UnitPercent,
+		GPUMemUsed:        UnitBytes,
+		GPUMemTotal:       UnitBytes,
+		GPUMemUtilization: UnitPercent,
+		GPUTemperature:    UnitCelsius,
+		GPUPowerDraw:      UnitWatts,
+
+		//efa metrics
+		EfaRxBytes:            UnitBytesPerSec,
+		EfaTxBytes:            UnitBytesPerSec,
+		EfaRxDropped:          UnitCountPerSec,
+		EfaRdmaReadBytes:      UnitBytesPerSec,
+		EfaRdmaWriteBytes:     UnitBytesPerSec,
+		EfaRdmaWriteRecvBytes: UnitBytesPerSec,
+
 		//cluster metrics
 		NodeCount:       UnitCount,
 		FailedNodeCount: UnitCount,
@@ -15,6 +15,10 @@ package containerinsight
 
 //define constants that are used for ECS Container Insights only
 const (
-	ContainerInstanceIDKey = "ContainerInstanceId"
-	ECS                    = "ecs"
+	ContainerInstanceIDKey    = "ContainerInstanceId"
+	TaskIDKey                 = "TaskId"
+	TaskDefinitionFamilyKey   = "TaskDefinitionFamily"
+	TaskDefinitionRevisionKey = "TaskDefinitionRevision"
+	ECS                       = "ecs"
+	ECSFargate                = "ecs_fargate"
 )
@@ -60,7 +60,7 @@ func SumFields(fields []map[string]interface{}) map[string]float64 {
 // IsNode checks if a type belongs to node level metrics (for EKS)
 func IsNode(mType string) bool {
 	switch mType {
-	case TypeNode, TypeNodeNet, TypeNodeFS, TypeNodeDiskIO:
+	case TypeNode, TypeNodeNet, TypeNodeFS, TypeNodeDiskIO, TypeNodeGPU, TypeNodeEFA:
 		return true
 	}
 	return false
@@ -78,7 +78,7 @@ func IsInstance(mType string) bool {
 // IsContainer checks if a type belongs to container level metrics
 func IsContainer(mType string) bool {
 	switch mType {
-	case TypeContainer, TypeContainerDiskIO, TypeContainerFS:
+	case TypeContainer, TypeContainerDiskIO, TypeContainerFS, TypeContainerGPU:
 		return true
 	}
 	return false
@@ -105,6 +105,7 @@ func getPrefixByMetricType(mType string) string {
 	service := "service_"
 	cluster := "cluster_"
 	namespace := "namespace_"
+	taskPrefix := "task_"
 
 	switch mType {
 	case TypeInstance:
@@ -123,6 +124,10 @@ func getPrefixByMetricType(mType string) string {
 		prefix = nodePrefix
 	case TypeNodeNet:
 		prefix = nodeNetPrefix
+	case TypeNodeGPU:
+		prefix = nodePrefix
+	case TypeNodeEFA:
+		prefix = nodePrefix
 	case TypePod:
 		prefix = podPrefix
 	case TypePodNet:
@@ -133,6 +138,10 @@ func getPrefixByMetricType(mType string) string {
 		prefix = containerPrefix
 	case TypeContainerFS:
 		prefix = containerPrefix
+	case TypeContainerGPU:
+		prefix = containerPrefix
+	case TypeTask:
+		prefix = taskPrefix
 	case TypeService:
 		prefix = service
 	case TypeCluster:
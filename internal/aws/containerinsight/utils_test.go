@@ -75,6 +75,10 @@ func TestMetricName(t *testing.T) {
 	assert.Equal(t, "service_number_of_running_pods", MetricName(TypeService, "number_of_running_pods"))
 	assert.Equal(t, "namespace_number_of_running_pods", MetricName(TypeClusterNamespace, "number_of_running_pods"))
 	assert.Equal(t, "container_diskio_io_service_bytes_total", MetricName(TypeContainerDiskIO, "diskio_io_service_bytes_total"))
+	assert.Equal(t, "node_gpu_utilization", MetricName(TypeNodeGPU, "gpu_utilization"))
+	assert.Equal(t, "container_gpu_memory_used", MetricName(TypeContainerGPU, "gpu_memory_used"))
+	assert.Equal(t, "node_efa_rx_bytes", MetricName(TypeNodeEFA, "efa_rx_bytes"))
+	assert.Equal(t, "task_cpu_utilization", MetricName(TypeTask, "cpu_utilization"))
 	assert.Equal(t, "unknown_metrics", MetricName("unknown_type", "unknown_metrics"))
 }
 
@@ -83,6 +87,8 @@ func TestIsNode(t *testing.T) {
 	assert.Equal(t, true, IsNode(TypeNodeNet))
 	assert.Equal(t, true, IsNode(TypeNodeFS))
 	assert.Equal(t, true, IsNode(TypeNodeDiskIO))
+	assert.Equal(t, true, IsNode(TypeNodeGPU))
+	assert.Equal(t, true, IsNode(TypeNodeEFA))
 	assert.Equal(t, false, IsNode(TypePod))
 }
 
@@ -98,6 +104,7 @@ func TestIsContainer(t *testing.T) {
 	assert.Equal(t, true, IsContainer(TypeContainer))
 	assert.Equal(t, true, IsContainer(TypeContainerDiskIO))
 	assert.Equal(t, true, IsContainer(TypeContainerFS))
+	assert.Equal(t, true, IsContainer(TypeContainerGPU))
 	assert.Equal(t, false, IsContainer(TypePod))
 }
 
@@ -49,6 +49,15 @@ type Config struct {
 	// will be called or not. Set to `true` to skip EC2 instance
 	// metadata check.
 	LocalMode bool `mapstructure:"local_mode"`
+
+	// IMDSv2Only determines whether the region is only fetched from the
+	// EC2 instance metadata endpoint with an IMDSv2 session token. Set to
+	// `true` to fail instead of falling back to IMDSv1.
+	IMDSv2Only bool `mapstructure:"imdsv2_only"`
+
+	// STSEndpoint is the STS endpoint used to assume RoleARN. By default
+	// the regional STS endpoint of the region's partition is used.
+	STSEndpoint string `mapstructure:"sts_endpoint"`
 }
 
 func DefaultConfig() *Config {
@@ -49,13 +49,28 @@ const (
 
 	httpsProxyEnvVar = "HTTPS_PROXY"
 
+	ec2MetadataTokenPath      = "/latest/api/token"
+	ec2MetadataRegionPath     = "/latest/meta-data/placement/region"
+	ec2MetadataTokenTTLHeader = "X-aws-ec2-metadata-token-ttl-seconds"
+	ec2MetadataTokenHeader    = "X-aws-ec2-metadata-token"
+	ec2MetadataTokenTTL       = "60"
+	ec2MetadataTimeout        = 5 * time.Second
+
 	stsEndpointPrefix         = "https://sts."
 	stsEndpointSuffix         = ".amazonaws.com"
 	stsAwsCnPartitionIDSuffix = ".amazonaws.com.cn" // AWS China partition.
 )
 
-var newAWSSession = func(roleArn string, region string, log *zap.Logger) (*session.Session, error) {
-	sts := &stsCalls{log: log, getSTSCredsFromRegionEndpoint: getSTSCredsFromRegionEndpoint}
+// ec2MetadataEndpoint is the endpoint of the EC2 instance metadata service.
+var ec2MetadataEndpoint = "http://169.254.169.254"
+
+var newAWSSession = func(roleArn string, region string, stsEndpoint string, log *zap.Logger) (*session.Session, error) {
+	sts := &stsCalls{
+		log:                           log,
+		stsEndpoint:                   stsEndpoint,
+		getSTSCredsFromRegionEndpoint: getSTSCredsFromRegionEndpoint,
+		getSTSCredsFromEndpoint:       getSTSCredsFromEndpoint,
+	}
 
 	if roleArn == "" {
 		sess, err := session.NewSession()
@@ -83,6 +98,51 @@ var getEC2Region = func(s *session.Session) (string, error) {
 	return ec2metadata.New(s).Region()
 }
 
+// getEC2RegionIMDSv2 fetches the region from the EC2 instance metadata service at endpoint
+// with an IMDSv2 session token. Unlike the SDK, it doesn't fall back to IMDSv1 if fetching
+// the token fails.
+func getEC2RegionIMDSv2(endpoint string) (string, error) {
+	client := &http.Client{Timeout: ec2MetadataTimeout}
+
+	req, err := http.NewRequest(http.MethodPut, endpoint+ec2MetadataTokenPath, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set(ec2MetadataTokenTTLHeader, ec2MetadataTokenTTL)
+	token, err := doEC2MetadataRequest(client, req)
+	if err != nil {
+		return "", fmt.Errorf("unable to fetch IMDSv2 session token: %w", err)
+	}
+
+	req, err = http.NewRequest(http.MethodGet, endpoint+ec2MetadataRegionPath, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set(ec2MetadataTokenHeader, token)
+	region, err := doEC2MetadataRequest(client, req)
+	if err != nil {
+		return "", fmt.Errorf("unable to fetch region with IMDSv2: %w", err)
+	}
+	return region, nil
+}
+
+func doEC2MetadataRequest(client *http.Client, req *http.Request) (string, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return string(body), nil
+}
+
 func getAWSConfigSession(c *Config, logger *zap.Logger) (*aws.Config, *session.Session, error) {
 	var (
 		awsRegion string
@@ -103,14 +163,23 @@ func getAWSConfigSession(c *Config, logger *zap.Logger) (*aws.Config, *session.S
 		awsRegion, err = getRegionFromECSMetadata()
 		if err != nil {
 			logger.Debug("Unable to fetch region from ECS metadata", zap.Error(err))
-			var sess *session.Session
-			sess, err = session.NewSession()
-			if err == nil {
-				awsRegion, err = getEC2Region(sess)
+			if c.IMDSv2Only {
+				awsRegion, err = getEC2RegionIMDSv2(ec2MetadataEndpoint)
 				if err != nil {
-					logger.Debug("Unable to fetch region from EC2 metadata", zap.Error(err))
+					logger.Debug("Unable to fetch region from EC2 metadata with IMDSv2", zap.Error(err))
 				} else {
-					logger.Debug("Fetched region from EC2 metadata", zap.String("region", awsRegion))
+					logger.Debug("Fetched region from EC2 metadata with IMDSv2", zap.String("region", awsRegion))
+				}
+			} else {
+				var sess *session.Session
+				sess, err = session.NewSession()
+				if err == nil {
+					awsRegion, err = getEC2Region(sess)
+					if err != nil {
+						logger.Debug("Unable to fetch region from EC2 metadata", zap.Error(err))
+					} else {
+						logger.Debug("Fetched region from EC2 metadata", zap.String("region", awsRegion))
+					}
 				}
 			}
 		} else {
@@ -122,7 +191,7 @@ func getAWSConfigSession(c *Config, logger *zap.Logger) (*aws.Config, *session.S
 		return nil, nil, fmt.Errorf("could not fetch region from config file, environment variables, ecs metadata, or ec2 metadata: %w", err)
 	}
 
-	sess, err := newAWSSession(c.RoleARN, awsRegion, logger)
+	sess, err := newAWSSession(c.RoleARN, awsRegion, c.STSEndpoint, logger)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -213,10 +282,13 @@ func proxyServerTransport(config *Config) (*http.Transport, error) {
 
 type stsCalls struct {
 	log                           *zap.Logger
+	stsEndpoint                   string
 	getSTSCredsFromRegionEndpoint func(log *zap.Logger, sess *session.Session, region, roleArn string) *credentials.Credentials
+	getSTSCredsFromEndpoint       func(log *zap.Logger, sess *session.Session, region, endpoint, roleArn string) *credentials.Credentials
 }
 
-// getSTSCreds gets STS credentials first from the regional endpoint, then from the primary
+// getSTSCreds gets STS credentials from the configured STS endpoint if there is one.
+// Otherwise they are fetched first from the regional endpoint, then from the primary
 // region in the respective AWS partition if the regional endpoint is disabled.
 func (s *stsCalls) getCreds(region string, roleArn string) (*credentials.Credentials, error) {
 	sess, err := session.NewSession()
@@ -224,6 +296,15 @@ func (s *stsCalls) getCreds(region string, roleArn string) (*credentials.Credent
 		return nil, err
 	}
 
+	if s.stsEndpoint != "" {
+		stsCred := s.getSTSCredsFromEndpoint(s.log, sess, region, s.stsEndpoint, roleArn)
+		// Make explicit call to fetch credentials.
+		if _, err = stsCred.Get(); err != nil {
+			return nil, fmt.Errorf("unable to fetch credentials from STS endpoint %s: %w", s.stsEndpoint, err)
+		}
+		return stsCred, nil
+	}
+
 	stsCred := s.getSTSCredsFromRegionEndpoint(s.log, sess, region, roleArn)
 	// Make explicit call to fetch credentials.
 	_, err = stsCred.Get()
@@ -250,7 +331,12 @@ var getSTSCredsFromRegionEndpoint = func(log *zap.Logger, sess *session.Session,
 	// if regionalEndpoint is "", the STS endpoint is Global endpoint for classic regions except ap-east-1 - (HKG)
 	// for other opt-in regions, region value will create STS regional endpoint.
 	// This will only be the case if the provided region is not present in aws_regions.go
-	c := &aws.Config{Region: aws.String(region), Endpoint: &regionalEndpoint}
+	return getSTSCredsFromEndpoint(log, sess, region, regionalEndpoint, roleArn)
+}
+
+// getSTSCredsFromEndpoint fetches STS credentials for provided roleARN from the given endpoint.
+func getSTSCredsFromEndpoint(log *zap.Logger, sess *session.Session, region, endpoint, roleArn string) *credentials.Credentials {
+	c := &aws.Config{Region: aws.String(region), Endpoint: &endpoint}
 	st := sts.New(sess, c)
 	log.Info("STS endpoint to use", zap.String("endpoint", st.Endpoint))
 	return stscreds.NewCredentialsWithClient(st, roleArn)
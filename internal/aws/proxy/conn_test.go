@@ -17,6 +17,8 @@ package proxy
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -45,7 +47,7 @@ func (m *mock) getEC2Region(s *session.Session) (string, error) {
 	return ec2Region, nil
 }
 
-func (m *mock) newAWSSession(roleArn string, region string, logger *zap.Logger) (*session.Session, error) {
+func (m *mock) newAWSSession(roleArn string, region string, stsEndpoint string, logger *zap.Logger) (*session.Session, error) {
 	return m.sn, nil
 }
 
@@ -71,7 +73,7 @@ func restoreEnv(env []string) {
 }
 
 func setupMock(sess *session.Session) (f1 func(s *session.Session) (string, error),
-	f2 func(roleArn string, region string, logger *zap.Logger) (*session.Session, error)) {
+	f2 func(roleArn string, region string, stsEndpoint string, logger *zap.Logger) (*session.Session, error)) {
 	f1 = getEC2Region
 	f2 = newAWSSession
 	m := mock{sn: sess}
@@ -82,7 +84,7 @@ func setupMock(sess *session.Session) (f1 func(s *session.Session) (string, erro
 
 func tearDownMock(
 	f1 func(s *session.Session) (string, error),
-	f2 func(roleArn string, region string, logger *zap.Logger) (*session.Session, error),
+	f2 func(roleArn string, region string, stsEndpoint string, logger *zap.Logger) (*session.Session, error),
 ) {
 	getEC2Region = f1
 	newAWSSession = f2
@@ -237,6 +239,56 @@ func TestNoRegion(t *testing.T) {
 		m.getEC2RegionErr.Error(), "expected error")
 }
 
+// fetch region value from ec2 meta data service with IMDSv2 only
+func TestRegionFromEC2IMDSv2Only(t *testing.T) {
+	logger, recordedLogs := logSetup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == ec2MetadataTokenPath:
+			assert.Equal(t, ec2MetadataTokenTTL, r.Header.Get(ec2MetadataTokenTTLHeader))
+			_, _ = w.Write([]byte("token"))
+		case r.Method == http.MethodGet && r.URL.Path == ec2MetadataRegionPath && r.Header.Get(ec2MetadataTokenHeader) == "token":
+			_, _ = w.Write([]byte(ec2Region))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+	endpoint := ec2MetadataEndpoint
+	ec2MetadataEndpoint = server.URL
+	defer func() { ec2MetadataEndpoint = endpoint }()
+
+	expectedSession, err := session.NewSession()
+	assert.NoError(t, err, "expectedSession should be created")
+	f1, f2 := setupMock(expectedSession)
+	defer tearDownMock(f1, f2)
+
+	cfg := DefaultConfig()
+	cfg.IMDSv2Only = true
+	awsCfg, _, err := getAWSConfigSession(cfg, logger)
+	assert.NoError(t, err, "getAWSConfigSession should not error out")
+	assert.Equal(t, ec2Region, *awsCfg.Region, "region value fetched from ec2-metadata service")
+
+	logs := recordedLogs.All()
+	lastEntry := logs[len(logs)-1]
+	assert.Contains(t, lastEntry.Message, "Fetched region from EC2 metadata with IMDSv2", "expected log message")
+}
+
+// IMDSv2 only doesn't fall back to IMDSv1 if fetching the token fails
+func TestRegionFromEC2IMDSv2OnlyTokenFailed(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	_, err := getEC2RegionIMDSv2(server.URL)
+	assert.EqualError(t, err, "unable to fetch IMDSv2 session token: unexpected status code 403")
+	assert.Equal(t, 1, requests, "the region must not be requested without a token")
+}
+
 // getRegionFromECSMetadata() returns an error if ECS metadata related env is not set
 func TestNoECSMetadata(t *testing.T) {
 	env := stashEnv()
@@ -296,13 +348,13 @@ func TestLoadEnvConfigCreds(t *testing.T) {
 	for k, v := range cases.Env {
 		os.Setenv(k, v)
 	}
-	cfg, err := newAWSSession("", "", zap.NewNop())
+	cfg, err := newAWSSession("", "", "", zap.NewNop())
 	assert.NoError(t, err, "Expect no error")
 	value, err := cfg.Config.Credentials.Get()
 	assert.NoError(t, err, "Expect no error")
 	assert.Equal(t, cases.Val, value, "Expect the credentials value to match")
 
-	_, err = newAWSSession("ROLEARN", "TEST", zap.NewNop())
+	_, err = newAWSSession("ROLEARN", "TEST", "", zap.NewNop())
 	assert.Error(t, err, "expected error")
 	assert.Contains(t, err.Error(), "unable to handle AWS error", "expected error message")
 }
@@ -382,7 +434,7 @@ func TestNewSessionCreationFailed(t *testing.T) {
 	os.Setenv("AWS_SDK_LOAD_CONFIG", "true")
 	os.Setenv("AWS_STS_REGIONAL_ENDPOINTS", "invalid")
 
-	_, err := newAWSSession("", "dontCare", zap.NewNop())
+	_, err := newAWSSession("", "dontCare", "", zap.NewNop())
 	assert.Error(t, err, "expected failure")
 }
 
@@ -394,7 +446,7 @@ func TestGetSTSCredsFailed(t *testing.T) {
 	os.Setenv("AWS_SDK_LOAD_CONFIG", "true")
 	os.Setenv("AWS_STS_REGIONAL_ENDPOINTS", "invalid")
 
-	_, err := newAWSSession("ROLEARN", "us-west-2", zap.NewNop())
+	_, err := newAWSSession("ROLEARN", "us-west-2", "", zap.NewNop())
 	assert.Error(t, err, "expected failure")
 }
 
@@ -530,3 +582,50 @@ func TestSTSRegionalEndpointDisabled(t *testing.T) {
 		lastEntry.Context[1].Interface.(error),
 		expectedErr.Error(), "expected error")
 }
+
+func TestSTSCustomEndpoint(t *testing.T) {
+	const (
+		expectedRoleARN  = "a role ARN"
+		expectedEndpoint = "https://sts.example.com"
+	)
+	called := false
+	fake := &stsCalls{
+		log:         zap.NewNop(),
+		stsEndpoint: expectedEndpoint,
+		getSTSCredsFromRegionEndpoint: func(_ *zap.Logger, _ *session.Session, region, roleArn string) *credentials.Credentials {
+			assert.Fail(t, "regional endpoint should not be used")
+			return nil
+		},
+		getSTSCredsFromEndpoint: func(_ *zap.Logger, _ *session.Session, region, endpoint, roleArn string) *credentials.Credentials {
+			assert.Equal(t, "us-gov-west-1", region, "expected region differs")
+			assert.Equal(t, expectedEndpoint, endpoint, "expected endpoint differs")
+			assert.Equal(t, expectedRoleARN, roleArn, "expected role ARN differs")
+			called = true
+			return credentials.NewCredentials(&mockProvider{})
+		},
+	}
+	_, err := fake.getCreds("us-gov-west-1", expectedRoleARN)
+	assert.True(t, called, "getSTSCredsFromEndpoint should be called")
+	assert.NoError(t, err, "no expected error")
+
+	// Errors of the custom endpoint are returned without falling back to another endpoint.
+	fake.getSTSCredsFromEndpoint = func(_ *zap.Logger, _ *session.Session, region, endpoint, roleArn string) *credentials.Credentials {
+		return credentials.NewCredentials(&mockProvider{&mockAWSErr{}})
+	}
+	_, err = fake.getCreds("us-gov-west-1", expectedRoleARN)
+	assert.EqualError(t, err, "unable to fetch credentials from STS endpoint https://sts.example.com: mockAWSErr")
+}
+
+func TestGetSTSCredsFromEndpoint(t *testing.T) {
+	sess, err := session.NewSession()
+	assert.NoError(t, err, "session should be created")
+
+	logger, recordedLogs := logSetup()
+	creds := getSTSCredsFromEndpoint(logger, sess, "cn-north-1", "https://sts.example.com", "a role ARN")
+	assert.NotNil(t, creds)
+
+	logs := recordedLogs.All()
+	lastEntry := logs[len(logs)-1]
+	assert.Equal(t, "STS endpoint to use", lastEntry.Message)
+	assert.Equal(t, "https://sts.example.com", lastEntry.Context[0].String)
+}
@@ -41,6 +41,9 @@ const (
 	// AWSXRayTracedAttribute is the `traced` field in an X-Ray subsegment
 	AWSXRayTracedAttribute = "aws.xray.traced"
 
+	// AWSXRayInferredAttribute is the `inferred` flag in an X-Ray segment
+	AWSXRayInferredAttribute = "aws.xray.inferred"
+
 	// AWSXraySegmentMetadataAttributePrefix is the prefix of the attribute that
 	// will be treated by the X-Ray exporter as metadata. The key of a metadata
 	// will be AWSXraySegmentMetadataAttributePrefix + <metadata_key>.
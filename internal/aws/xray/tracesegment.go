@@ -73,6 +73,10 @@ type Segment struct {
 	PrecursorIDs []string `json:"precursor_ids,omitempty"`
 	Traced       *bool    `json:"traced,omitempty"`
 	SQL          *SQLData `json:"sql,omitempty"`
+
+	// Inferred is set on the segments X-Ray infers for the downstream services
+	// that aren't instrumented, from the subsegments calling them.
+	Inferred *bool `json:"inferred,omitempty"`
 }
 
 // Validate checks whether the segment is valid or not
@@ -591,6 +591,33 @@ func TestTraceBodyUnMarshalling(t *testing.T) {
 				}, actualSeg, testCase+": unmarshalled segment is different from the expected")
 			},
 		},
+		{
+			testCase:   "TestTraceBodyInferredUnmarshalled",
+			samplePath: path.Join("testdata", "inferredSegment.txt"),
+			verification: func(testCase string, actualSeg Segment, err error) {
+				assert.NoError(t, err, testCase+": JSON Unmarshalling should've succeeded")
+
+				assert.Equal(t, Segment{
+					Name:      String("DynamoDB"),
+					ID:        String("4d5e6f7a8b9c0d1e"),
+					StartTime: aws.Float64(1595437651.7),
+					EndTime:   aws.Float64(1595437651.9),
+					TraceID:   String("1-5f187253-6a106696d56b1f4ef9eba2ed"),
+					ParentID:  String("2b3c4d5e6f7a8b9c"),
+					Origin:    String("AWS::DynamoDB::Table"),
+					Inferred:  aws.Bool(true),
+					HTTP: &HTTPData{
+						Response: &ResponseData{
+							Status: aws.Int64(200),
+						},
+					},
+					AWS: &AWSData{
+						Operation: String("GetItem"),
+						TableName: String("users"),
+					},
+				}, actualSeg, testCase+": unmarshalled segment is different from the expected")
+			},
+		},
 		{
 			testCase:   "TestTraceBodyCauseIsExceptionIdUnmarshalled",
 			samplePath: path.Join("testdata", "minCauseIsExceptionId.txt"),
@@ -20,7 +20,9 @@ import (
 	"net/http"
 	"os"
 
+	configclientset "github.com/openshift/client-go/config/clientset/versioned"
 	quotaclientset "github.com/openshift/client-go/quota/clientset/versioned"
+	"k8s.io/client-go/dynamic"
 	k8s "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -157,3 +159,43 @@ func MakeOpenShiftQuotaClient(apiConf APIConfig) (quotaclientset.Interface, erro
 
 	return client, nil
 }
+
+// MakeOpenShiftConfigClient can take configuration if needed for other types of auth
+// and return an OpenShift config API client
+func MakeOpenShiftConfigClient(apiConf APIConfig) (configclientset.Interface, error) {
+	if err := apiConf.Validate(); err != nil {
+		return nil, err
+	}
+
+	authConf, err := createRestConfig(apiConf)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := configclientset.NewForConfig(authConf)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// MakeDynamicClient can take configuration if needed for other types of auth
+// and return a dynamic client for arbitrary resources such as custom resources
+func MakeDynamicClient(apiConf APIConfig) (dynamic.Interface, error) {
+	if err := apiConf.Validate(); err != nil {
+		return nil, err
+	}
+
+	authConf, err := createRestConfig(apiConf)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := dynamic.NewForConfig(authConf)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
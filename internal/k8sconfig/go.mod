@@ -7,5 +7,8 @@ require (
 	github.com/google/gofuzz v1.2.0 // indirect
 	github.com/imdario/mergo v0.3.11 // indirect
 	github.com/openshift/client-go v0.0.0-20210521082421-73d9475a9142
+	github.com/stretchr/testify v1.7.0
+	go.uber.org/zap v1.19.0
+	k8s.io/apimachinery v0.22.0
 	k8s.io/client-go v0.22.0
 )
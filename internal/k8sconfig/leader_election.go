@@ -0,0 +1,172 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const (
+	// DefaultLeaseDuration is the default LeaderElectionConfig.LeaseDuration.
+	DefaultLeaseDuration = 15 * time.Second
+	// DefaultRenewDeadline is the default LeaderElectionConfig.RenewDeadline.
+	DefaultRenewDeadline = 10 * time.Second
+	// DefaultRetryPeriod is the default LeaderElectionConfig.RetryPeriod.
+	DefaultRetryPeriod = 2 * time.Second
+
+	// serviceAccountNamespaceFile holds the namespace of the pod the collector runs in.
+	serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// LeaderElectionConfig configures the election of the replica emitting data, using
+// a Lease object of the Kubernetes API, when several replicas of a receiver run.
+type LeaderElectionConfig struct {
+	// Enabled turns on the leader election. Only the elected replica emits
+	// data, the others stand by with their caches synced.
+	Enabled bool `mapstructure:"enabled"`
+	// LeaseName is the name of the Lease object used as the lock.
+	LeaseName string `mapstructure:"lease_name"`
+	// LeaseNamespace is the namespace of the Lease object. Defaults to the
+	// namespace of the collector's service account.
+	LeaseNamespace string `mapstructure:"lease_namespace"`
+	// Identity of the replica in the Lease. Defaults to the hostname, that is
+	// the pod name.
+	Identity string `mapstructure:"identity"`
+	// LeaseDuration is how long the other replicas wait before taking over
+	// a lease that wasn't renewed.
+	LeaseDuration time.Duration `mapstructure:"lease_duration"`
+	// RenewDeadline is how long the leader retries renewing the lease before
+	// giving up the leadership.
+	RenewDeadline time.Duration `mapstructure:"renew_deadline"`
+	// RetryPeriod is the interval between attempts to acquire or renew the lease.
+	RetryPeriod time.Duration `mapstructure:"retry_period"`
+}
+
+// Validate checks the leader election settings when the election is enabled.
+func (cfg *LeaderElectionConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.LeaseName == "" {
+		return errors.New("leader_election: lease_name must be set")
+	}
+	if cfg.RetryPeriod <= 0 {
+		return errors.New("leader_election: retry_period must be positive")
+	}
+	if cfg.RenewDeadline <= cfg.RetryPeriod {
+		return errors.New("leader_election: renew_deadline must be greater than retry_period")
+	}
+	if cfg.LeaseDuration <= cfg.RenewDeadline {
+		return errors.New("leader_election: lease_duration must be greater than renew_deadline")
+	}
+	return nil
+}
+
+// identity returns the configured identity or the hostname.
+func (cfg *LeaderElectionConfig) identity() (string, error) {
+	if cfg.Identity != "" {
+		return cfg.Identity, nil
+	}
+	return os.Hostname()
+}
+
+// leaseNamespace returns the configured namespace or the one of the service account.
+func (cfg *LeaderElectionConfig) leaseNamespace() (string, error) {
+	if cfg.LeaseNamespace != "" {
+		return cfg.LeaseNamespace, nil
+	}
+	ns, err := ioutil.ReadFile(serviceAccountNamespaceFile)
+	if err != nil {
+		return "", fmt.Errorf("lease_namespace is not set and the service account namespace cannot be read: %w", err)
+	}
+	return strings.TrimSpace(string(ns)), nil
+}
+
+// NewLeaderElector creates an elector for the lease calling onStartedLeading when this
+// replica acquires it and onStoppedLeading when it loses it.
+func NewLeaderElector(
+	logger *zap.Logger, cfg LeaderElectionConfig, client kubernetes.Interface,
+	name string, onStartedLeading func(), onStoppedLeading func()) (*leaderelection.LeaderElector, error) {
+	identity, err := cfg.identity()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the leader election identity: %w", err)
+	}
+	namespace, err := cfg.leaseNamespace()
+	if err != nil {
+		return nil, err
+	}
+
+	logger = logger.With(
+		zap.String("lease", namespace+"/"+cfg.LeaseName),
+		zap.String("identity", identity),
+	)
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      cfg.LeaseName,
+			Namespace: namespace,
+		},
+		Client: client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+
+	return leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   cfg.LeaseDuration,
+		RenewDeadline:   cfg.RenewDeadline,
+		RetryPeriod:     cfg.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            name,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) {
+				logger.Info("Started leading, emitting data.")
+				onStartedLeading()
+			},
+			OnStoppedLeading: func() {
+				logger.Info("Stopped leading, standing by.")
+				onStoppedLeading()
+			},
+			OnNewLeader: func(leader string) {
+				if leader != identity {
+					logger.Info("New leader elected.", zap.String("leader", leader))
+				}
+			},
+		},
+	})
+}
+
+// RunLeaderElection campaigns for the lease until the context is cancelled. The
+// elector returns when the leadership is lost, in which case it campaigns again.
+func RunLeaderElection(ctx context.Context, elector *leaderelection.LeaderElector) {
+	for {
+		elector.Run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderElectionConfigValidate(t *testing.T) {
+	valid := LeaderElectionConfig{
+		Enabled:       true,
+		LeaseName:     "otel-test-receiver",
+		LeaseDuration: DefaultLeaseDuration,
+		RenewDeadline: DefaultRenewDeadline,
+		RetryPeriod:   DefaultRetryPeriod,
+	}
+	tests := []struct {
+		name    string
+		modify  func(cfg *LeaderElectionConfig)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *LeaderElectionConfig) {},
+		},
+		{
+			name: "disabled",
+			modify: func(cfg *LeaderElectionConfig) {
+				*cfg = LeaderElectionConfig{}
+			},
+		},
+		{
+			name:    "missing lease name",
+			modify:  func(cfg *LeaderElectionConfig) { cfg.LeaseName = "" },
+			wantErr: "leader_election: lease_name must be set",
+		},
+		{
+			name:    "no retry period",
+			modify:  func(cfg *LeaderElectionConfig) { cfg.RetryPeriod = 0 },
+			wantErr: "leader_election: retry_period must be positive",
+		},
+		{
+			name:    "renew deadline not greater than retry period",
+			modify:  func(cfg *LeaderElectionConfig) { cfg.RenewDeadline = cfg.RetryPeriod },
+			wantErr: "leader_election: renew_deadline must be greater than retry_period",
+		},
+		{
+			name:    "lease duration not greater than renew deadline",
+			modify:  func(cfg *LeaderElectionConfig) { cfg.LeaseDuration = 5 * time.Second },
+			wantErr: "leader_election: lease_duration must be greater than renew_deadline",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/internal/loki
+
+go 1.16
+
+require (
+	github.com/gogo/protobuf v1.3.2
+	github.com/prometheus/prometheus v1.8.2-0.20210621150501-ff58416a0b02
+	github.com/stretchr/testify v1.7.0
+	google.golang.org/grpc v1.39.1
+)
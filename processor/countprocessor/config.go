@@ -0,0 +1,137 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package countprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	defaultSpanCountName      = "trace.span.count"
+	defaultLogCountName       = "log.record.count"
+	defaultDataPointCountName = "metric.datapoint.count"
+)
+
+// Config defines the configuration options for the count processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MetricsExporter is the name of the metrics exporter the counts are sent to.
+	MetricsExporter string `mapstructure:"metrics_exporter"`
+
+	// Spans defines the counts of spans. All spans are counted as trace.span.count if it is empty.
+	Spans []MetricInfo `mapstructure:"spans"`
+
+	// Logs defines the counts of log records. All log records are counted as
+	// log.record.count if it is empty.
+	Logs []MetricInfo `mapstructure:"logs"`
+
+	// DataPoints defines the counts of metric data points. All data points are
+	// counted as metric.datapoint.count if it is empty.
+	DataPoints []MetricInfo `mapstructure:"datapoints"`
+}
+
+// MetricInfo defines a count metric.
+type MetricInfo struct {
+	// Name is the name of the metric.
+	Name string `mapstructure:"name"`
+
+	// Description is the description of the metric.
+	Description string `mapstructure:"description"`
+
+	// ResourceConditions are conditions on the resource attributes of the counted item.
+	ResourceConditions []Condition `mapstructure:"resource_conditions"`
+
+	// Conditions are conditions on the attributes of the counted item, or the labels
+	// of a data point. An item is only counted if it matches all conditions.
+	Conditions []Condition `mapstructure:"conditions"`
+
+	// Attributes are the attributes the count is grouped by. Each of them becomes a
+	// label of the count.
+	Attributes []Attribute `mapstructure:"attributes"`
+}
+
+// Condition matches an attribute whose value matches a regular expression.
+type Condition struct {
+	// Key is the name of the attribute.
+	Key string `mapstructure:"key"`
+
+	// Value is a regular expression the whole string representation of the
+	// attribute value has to match.
+	Value string `mapstructure:"value"`
+}
+
+// Attribute defines an attribute the count is grouped by and the optional default
+// value used if neither the item nor its resource has the attribute.
+type Attribute struct {
+	Key     string  `mapstructure:"key"`
+	Default *string `mapstructure:"default"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if err := cfg.ProcessorSettings.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.MetricsExporter == "" {
+		return errors.New("\"metrics_exporter\" must be specified")
+	}
+
+	names := make(map[string]struct{})
+	for _, infos := range [][]MetricInfo{cfg.Spans, cfg.Logs, cfg.DataPoints} {
+		for _, info := range infos {
+			if info.Name == "" {
+				return errors.New("\"name\" of a count must be specified")
+			}
+			if _, ok := names[info.Name]; ok {
+				return fmt.Errorf("duplicate count name %q", info.Name)
+			}
+			names[info.Name] = struct{}{}
+
+			if _, err := newCounter(info); err != nil {
+				return fmt.Errorf("invalid count %q: %w", info.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// spanInfos returns the configured span counts, or the default count of all spans.
+func (cfg *Config) spanInfos() []MetricInfo {
+	return withDefault(cfg.Spans, defaultSpanCountName, "The number of spans observed.")
+}
+
+// logInfos returns the configured log record counts, or the default count of all log records.
+func (cfg *Config) logInfos() []MetricInfo {
+	return withDefault(cfg.Logs, defaultLogCountName, "The number of log records observed.")
+}
+
+// dataPointInfos returns the configured data point counts, or the default count of all data points.
+func (cfg *Config) dataPointInfos() []MetricInfo {
+	return withDefault(cfg.DataPoints, defaultDataPointCountName, "The number of data points observed.")
+}
+
+func withDefault(infos []MetricInfo, name, description string) []MetricInfo {
+	if len(infos) > 0 {
+		return infos
+	}
+	return []MetricInfo{{Name: name, Description: description}}
+}
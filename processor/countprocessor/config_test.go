@@ -0,0 +1,147 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package countprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Len(t, cfg.Processors, 2)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.MetricsExporter = "nop"
+	assert.Equal(t, defaultCfg, cfg.Processors[config.NewID(typeStr)])
+
+	unknown := "unknown"
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "custom")),
+		MetricsExporter:   "nop",
+		Spans: []MetricInfo{
+			{
+				Name:               "checkout.errors",
+				Description:        "The number of failed checkout spans.",
+				ResourceConditions: []Condition{{Key: "service.name", Value: "checkout"}},
+				Conditions:         []Condition{{Key: "http.status_code", Value: "5.."}},
+				Attributes: []Attribute{
+					{Key: "http.route"},
+					{Key: "deployment.environment", Default: &unknown},
+				},
+			},
+		},
+		Logs: []MetricInfo{
+			{
+				Name:       "log.errors",
+				Conditions: []Condition{{Key: "severity", Value: "ERROR|FATAL"}},
+			},
+		},
+		DataPoints: []MetricInfo{
+			{
+				Name:       "datapoints.by_service",
+				Attributes: []Attribute{{Key: "service.name"}},
+			},
+		},
+	}, cfg.Processors[config.NewIDWithName(typeStr, "custom")])
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:   "missing metrics exporter",
+			modify: func(cfg *Config) { cfg.MetricsExporter = "" },
+			err:    "\"metrics_exporter\" must be specified",
+		},
+		{
+			name:   "missing name",
+			modify: func(cfg *Config) { cfg.Logs = []MetricInfo{{}} },
+			err:    "\"name\" of a count must be specified",
+		},
+		{
+			name:   "duplicate name",
+			modify: func(cfg *Config) { cfg.Logs = []MetricInfo{{Name: "span.errors"}} },
+			err:    "duplicate count name \"span.errors\"",
+		},
+		{
+			name:   "missing condition key",
+			modify: func(cfg *Config) { cfg.Spans[0].Conditions = []Condition{{Value: "5.."}} },
+			err:    "invalid count \"span.errors\": \"key\" of a condition must be specified",
+		},
+		{
+			name: "invalid condition value",
+			modify: func(cfg *Config) {
+				cfg.Spans[0].ResourceConditions = []Condition{{Key: "service.name", Value: "("}}
+			},
+			err: "invalid count \"span.errors\": invalid value of condition on \"service.name\": " +
+				"error parsing regexp: missing closing ): `^(?:()$`",
+		},
+		{
+			name:   "missing attribute key",
+			modify: func(cfg *Config) { cfg.Spans[0].Attributes = []Attribute{{}} },
+			err:    "invalid count \"span.errors\": \"key\" of an attribute must be specified",
+		},
+		{
+			name: "duplicate attribute",
+			modify: func(cfg *Config) {
+				cfg.Spans[0].Attributes = []Attribute{{Key: "http.route"}, {Key: "http.route"}}
+			},
+			err: "invalid count \"span.errors\": duplicate attribute \"http.route\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.MetricsExporter = "otlp"
+			cfg.Spans = []MetricInfo{
+				{
+					Name:       "span.errors",
+					Conditions: []Condition{{Key: "http.status_code", Value: "5.."}},
+				},
+			}
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
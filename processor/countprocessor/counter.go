@@ -0,0 +1,188 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package countprocessor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	metricKeySeparator = string(byte(0))
+	// missingValue marks an attribute without a value in a metric key, so it isn't
+	// counted together with an attribute set to the empty string.
+	missingValue = string(byte(1))
+)
+
+type metricKey string
+
+// lookup returns the string representation of the value of an attribute.
+type lookup func(key string) (string, bool)
+
+func attributeLookup(attrs pdata.AttributeMap) lookup {
+	return func(key string) (string, bool) {
+		value, ok := attrs.Get(key)
+		if !ok {
+			return "", false
+		}
+		return tracetranslator.AttributeValueToString(value), true
+	}
+}
+
+func labelLookup(labels pdata.StringMap) lookup {
+	return labels.Get
+}
+
+type condition struct {
+	key   string
+	value *regexp.Regexp
+}
+
+// counter keeps the cumulative counts of a MetricInfo per set of attribute values.
+type counter struct {
+	info               MetricInfo
+	resourceConditions []condition
+	conditions         []condition
+
+	counts map[metricKey]int64
+	// A cache of the labels of each metric key, built on the first count of the key.
+	keyToLabels map[metricKey]map[string]string
+}
+
+func newCounter(info MetricInfo) (*counter, error) {
+	c := &counter{
+		info:        info,
+		counts:      make(map[metricKey]int64),
+		keyToLabels: make(map[metricKey]map[string]string),
+	}
+	var err error
+	if c.resourceConditions, err = compileConditions(info.ResourceConditions); err != nil {
+		return nil, err
+	}
+	if c.conditions, err = compileConditions(info.Conditions); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]struct{})
+	for _, attr := range info.Attributes {
+		if attr.Key == "" {
+			return nil, errors.New("\"key\" of an attribute must be specified")
+		}
+		if _, ok := keys[attr.Key]; ok {
+			return nil, fmt.Errorf("duplicate attribute %q", attr.Key)
+		}
+		keys[attr.Key] = struct{}{}
+	}
+	return c, nil
+}
+
+func compileConditions(conditions []Condition) ([]condition, error) {
+	compiled := make([]condition, 0, len(conditions))
+	for _, c := range conditions {
+		if c.Key == "" {
+			return nil, errors.New("\"key\" of a condition must be specified")
+		}
+		// The value has to match the whole string.
+		value, err := regexp.Compile("^(?:" + c.Value + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of condition on %q: %w", c.Key, err)
+		}
+		compiled = append(compiled, condition{key: c.Key, value: value})
+	}
+	return compiled, nil
+}
+
+func matchConditions(conditions []condition, attrs lookup) bool {
+	for _, c := range conditions {
+		value, ok := attrs(c.key)
+		if !ok || !c.value.MatchString(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// count counts an item with the attributes of the item and its resource if it
+// matches all conditions. The attributes of the item take precedence over the
+// attributes of the resource when the count is grouped.
+func (c *counter) count(resource, item lookup) {
+	if !matchConditions(c.resourceConditions, resource) || !matchConditions(c.conditions, item) {
+		return
+	}
+
+	values := make([]string, len(c.info.Attributes))
+	var keyBuilder strings.Builder
+	for i, attr := range c.info.Attributes {
+		value, ok := item(attr.Key)
+		if !ok {
+			value, ok = resource(attr.Key)
+		}
+		if !ok && attr.Default != nil {
+			value, ok = *attr.Default, true
+		}
+		if ok {
+			values[i] = value
+		} else {
+			values[i] = missingValue
+		}
+		if i > 0 {
+			keyBuilder.WriteString(metricKeySeparator)
+		}
+		keyBuilder.WriteString(values[i])
+	}
+
+	key := metricKey(keyBuilder.String())
+	if _, ok := c.keyToLabels[key]; !ok {
+		labels := make(map[string]string, len(values))
+		for i, attr := range c.info.Attributes {
+			if values[i] != missingValue {
+				labels[attr.Key] = values[i]
+			}
+		}
+		c.keyToLabels[key] = labels
+	}
+	c.counts[key]++
+}
+
+// appendMetric appends the cumulative counts as a monotonic sum with a data point per
+// set of attribute values. Nothing is appended if no item was counted yet.
+func (c *counter) appendMetric(metrics pdata.MetricSlice, startTime, now time.Time) {
+	if len(c.counts) == 0 {
+		return
+	}
+
+	m := metrics.AppendEmpty()
+	m.SetName(c.info.Name)
+	m.SetDescription(c.info.Description)
+	m.SetUnit("1")
+	m.SetDataType(pdata.MetricDataTypeSum)
+	m.Sum().SetIsMonotonic(true)
+	m.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+	dps := m.Sum().DataPoints()
+	for key, count := range c.counts {
+		dp := dps.AppendEmpty()
+		dp.SetStartTimestamp(pdata.TimestampFromTime(startTime))
+		dp.SetTimestamp(pdata.TimestampFromTime(now))
+		dp.SetIntVal(count)
+		dp.LabelsMap().InitFromMap(c.keyToLabels[key])
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package countprocessor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// processorImp counts the items passing through it and sends the counts to the
+// configured metrics exporter. The items are forwarded to the next consumer unmodified.
+type processorImp struct {
+	lock   sync.Mutex
+	logger *zap.Logger
+	config Config
+
+	metricsExporter consumer.Metrics
+
+	// Only the next consumer of the pipeline the processor was created for is set.
+	nextTraces  consumer.Traces
+	nextLogs    consumer.Logs
+	nextMetrics consumer.Metrics
+
+	// The counters of the items of the pipeline the processor was created for.
+	counters []*counter
+
+	// The starting time of the data points.
+	startTime time.Time
+}
+
+func newProcessor(logger *zap.Logger, cfg *Config, infos []MetricInfo) (*processorImp, error) {
+	counters := make([]*counter, 0, len(infos))
+	for _, info := range infos {
+		c, err := newCounter(info)
+		if err != nil {
+			return nil, fmt.Errorf("invalid count %q: %w", info.Name, err)
+		}
+		counters = append(counters, c)
+	}
+
+	return &processorImp{
+		logger:    logger,
+		config:    *cfg,
+		counters:  counters,
+		startTime: time.Now(),
+	}, nil
+}
+
+func newTracesProcessor(logger *zap.Logger, cfg *Config, nextConsumer consumer.Traces) (*processorImp, error) {
+	p, err := newProcessor(logger, cfg, cfg.spanInfos())
+	if err != nil {
+		return nil, err
+	}
+	p.nextTraces = nextConsumer
+	return p, nil
+}
+
+func newLogsProcessor(logger *zap.Logger, cfg *Config, nextConsumer consumer.Logs) (*processorImp, error) {
+	p, err := newProcessor(logger, cfg, cfg.logInfos())
+	if err != nil {
+		return nil, err
+	}
+	p.nextLogs = nextConsumer
+	return p, nil
+}
+
+func newMetricsProcessor(logger *zap.Logger, cfg *Config, nextConsumer consumer.Metrics) (*processorImp, error) {
+	p, err := newProcessor(logger, cfg, cfg.dataPointInfos())
+	if err != nil {
+		return nil, err
+	}
+	p.nextMetrics = nextConsumer
+	return p, nil
+}
+
+// Start implements the component.Component interface.
+func (p *processorImp) Start(_ context.Context, host component.Host) error {
+	var availableMetricsExporters []string
+
+	// The available list of exporters come from any configured metrics pipelines' exporters.
+	for k, exp := range host.GetExporters()[config.MetricsDataType] {
+		metricsExp, ok := exp.(component.MetricsExporter)
+		if !ok {
+			return fmt.Errorf("the exporter %q isn't a metrics exporter", k.String())
+		}
+		availableMetricsExporters = append(availableMetricsExporters, k.String())
+
+		if k.String() == p.config.MetricsExporter {
+			p.metricsExporter = metricsExp
+			p.logger.Info("Found exporter", zap.String("metrics-exporter", p.config.MetricsExporter))
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to find metrics exporter: '%s'; please configure metrics_exporter from one of: %+v",
+		p.config.MetricsExporter, availableMetricsExporters)
+}
+
+// Shutdown implements the component.Component interface.
+func (p *processorImp) Shutdown(context.Context) error {
+	return nil
+}
+
+// Capabilities implements the consumer interface.
+func (p *processorImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces counts the spans and forwards them to the next consumer.
+func (p *processorImp) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	p.lock.Lock()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resource := attributeLookup(rs.Resource().Attributes())
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				p.count(resource, attributeLookup(spans.At(k).Attributes()))
+			}
+		}
+	}
+	p.lock.Unlock()
+
+	// Firstly, export the counts to avoid being impacted by downstream errors/latency.
+	if err := p.exportCounts(ctx); err != nil {
+		return err
+	}
+	return p.nextTraces.ConsumeTraces(ctx, td)
+}
+
+// ConsumeLogs counts the log records and forwards them to the next consumer.
+func (p *processorImp) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	p.lock.Lock()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resource := attributeLookup(rl.Resource().Attributes())
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				p.count(resource, attributeLookup(logs.At(k).Attributes()))
+			}
+		}
+	}
+	p.lock.Unlock()
+
+	if err := p.exportCounts(ctx); err != nil {
+		return err
+	}
+	return p.nextLogs.ConsumeLogs(ctx, ld)
+}
+
+// ConsumeMetrics counts the data points and forwards them to the next consumer.
+func (p *processorImp) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	p.lock.Lock()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resource := attributeLookup(rm.Resource().Attributes())
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				forEachDataPointLabels(metrics.At(k), func(labels pdata.StringMap) {
+					p.count(resource, labelLookup(labels))
+				})
+			}
+		}
+	}
+	p.lock.Unlock()
+
+	if err := p.exportCounts(ctx); err != nil {
+		return err
+	}
+	return p.nextMetrics.ConsumeMetrics(ctx, md)
+}
+
+func (p *processorImp) count(resource, item lookup) {
+	for _, c := range p.counters {
+		c.count(resource, item)
+	}
+}
+
+// exportCounts sends the cumulative counts to the metrics exporter.
+func (p *processorImp) exportCounts(ctx context.Context) error {
+	md := pdata.NewMetrics()
+	ilm := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName("countprocessor")
+
+	p.lock.Lock()
+	now := time.Now()
+	for _, c := range p.counters {
+		c.appendMetric(ilm.Metrics(), p.startTime, now)
+	}
+	p.lock.Unlock()
+
+	if ilm.Metrics().Len() == 0 {
+		return nil
+	}
+	return p.metricsExporter.ConsumeMetrics(ctx, md)
+}
+
+func forEachDataPointLabels(m pdata.Metric, fn func(labels pdata.StringMap)) {
+	switch m.DataType() {
+	case pdata.MetricDataTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).LabelsMap())
+		}
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package countprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// exportersHost is a host with the given exporters.
+type exportersHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *exportersHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
+func TestProcessorStart(t *testing.T) {
+	exporterFactory := componenttest.NewNopExporterFactory()
+	exp, err := exporterFactory.CreateMetricsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(),
+		exporterFactory.CreateDefaultConfig())
+	require.NoError(t, err)
+	host := &exportersHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.MetricsDataType: {config.NewID("nop"): exp},
+		},
+	}
+
+	for _, tc := range []struct {
+		name            string
+		metricsExporter string
+		wantErrorMsg    string
+	}{
+		{"export to active metrics exporter", "nop", ""},
+		{"unable to find configured exporter in active exporter list", "prometheus", "failed to find metrics exporter: 'prometheus'; please configure metrics_exporter from one of: [nop]"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.MetricsExporter = tc.metricsExporter
+			p, err := newTracesProcessor(zap.NewNop(), cfg, consumertest.NewNop())
+			require.NoError(t, err)
+
+			err = p.Start(context.Background(), host)
+			if tc.wantErrorMsg != "" {
+				assert.EqualError(t, err, tc.wantErrorMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, exp, p.metricsExporter)
+			}
+		})
+	}
+}
+
+func testTraces() pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	rs.Resource().Attributes().InsertString("deployment.environment", "prod")
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for _, status := range []int64{200, 500, 503} {
+		span := spans.AppendEmpty()
+		span.Attributes().InsertInt("http.status_code", status)
+		span.Attributes().InsertString("http.route", "/cart")
+	}
+	span := spans.AppendEmpty()
+	span.Attributes().InsertInt("http.status_code", 500)
+	span.Attributes().InsertString("deployment.environment", "canary")
+
+	rs = td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "frontend")
+	span = rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().InsertInt("http.status_code", 500)
+	return td
+}
+
+// sumValues returns the values of the data points of the sum metric with the
+// given name, keyed by their sorted labels.
+func sumValues(t *testing.T, md pdata.Metrics, name string) map[string]int64 {
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	ilm := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0)
+	assert.Equal(t, "countprocessor", ilm.InstrumentationLibrary().Name())
+
+	for i := 0; i < ilm.Metrics().Len(); i++ {
+		m := ilm.Metrics().At(i)
+		if m.Name() != name {
+			continue
+		}
+		require.Equal(t, pdata.MetricDataTypeSum, m.DataType())
+		assert.True(t, m.Sum().IsMonotonic())
+		assert.Equal(t, pdata.AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+
+		values := make(map[string]int64)
+		dps := m.Sum().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			dp := dps.At(j)
+			assert.NotZero(t, dp.StartTimestamp())
+			assert.NotZero(t, dp.Timestamp())
+			var labels []string
+			dp.LabelsMap().Range(func(k string, v string) bool {
+				labels = append(labels, fmt.Sprintf("%s=%s", k, v))
+				return true
+			})
+			sort.Strings(labels)
+			values[strings.Join(labels, ",")] = dp.IntVal()
+		}
+		return values
+	}
+	t.Fatalf("metric %q not found", name)
+	return nil
+}
+
+func TestConsumeTraces(t *testing.T) {
+	unknown := "unknown"
+	cfg := createDefaultConfig().(*Config)
+	cfg.Spans = []MetricInfo{
+		{
+			Name:               "checkout.errors",
+			ResourceConditions: []Condition{{Key: "service.name", Value: "checkout"}},
+			Conditions:         []Condition{{Key: "http.status_code", Value: "5.."}},
+			Attributes: []Attribute{
+				{Key: "http.route"},
+				{Key: "deployment.environment", Default: &unknown},
+			},
+		},
+		{
+			Name:       "spans",
+			Attributes: []Attribute{{Key: "service.name"}},
+		},
+	}
+	next := new(consumertest.TracesSink)
+	p, err := newTracesProcessor(zap.NewNop(), cfg, next)
+	require.NoError(t, err)
+	exporter := new(consumertest.MetricsSink)
+	p.metricsExporter = exporter
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), testTraces()))
+	require.NoError(t, p.ConsumeTraces(context.Background(), testTraces()))
+
+	assert.Len(t, next.AllTraces(), 2)
+	require.Len(t, exporter.AllMetrics(), 2)
+	first := exporter.AllMetrics()[0]
+	assert.Equal(t, map[string]int64{
+		"deployment.environment=prod,http.route=/cart": 2,
+		"deployment.environment=canary":                1,
+	}, sumValues(t, first, "checkout.errors"))
+
+	last := exporter.AllMetrics()[1]
+	assert.Equal(t, map[string]int64{
+		"deployment.environment=prod,http.route=/cart": 4,
+		"deployment.environment=canary":                2,
+	}, sumValues(t, last, "checkout.errors"))
+	assert.Equal(t, map[string]int64{
+		"service.name=checkout": 8,
+		"service.name=frontend": 2,
+	}, sumValues(t, last, "spans"))
+}
+
+func TestConsumeTracesExportError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	next := new(consumertest.TracesSink)
+	p, err := newTracesProcessor(zap.NewNop(), cfg, next)
+	require.NoError(t, err)
+	p.metricsExporter = consumertest.NewErr(errors.New("export failed"))
+
+	assert.EqualError(t, p.ConsumeTraces(context.Background(), testTraces()), "export failed")
+	assert.Empty(t, next.AllTraces())
+}
+
+func TestConsumeTracesWithoutMatches(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Spans = []MetricInfo{
+		{Name: "never", Conditions: []Condition{{Key: "http.status_code", Value: "418"}}},
+	}
+	next := new(consumertest.TracesSink)
+	p, err := newTracesProcessor(zap.NewNop(), cfg, next)
+	require.NoError(t, err)
+	exporter := new(consumertest.MetricsSink)
+	p.metricsExporter = exporter
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), testTraces()))
+	assert.Len(t, next.AllTraces(), 1)
+	assert.Empty(t, exporter.AllMetrics())
+}
+
+func TestConsumeLogs(t *testing.T) {
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for _, severity := range []string{"INFO", "ERROR", "FATAL"} {
+		logs.AppendEmpty().Attributes().InsertString("severity", severity)
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	next := new(consumertest.LogsSink)
+	p, err := newLogsProcessor(zap.NewNop(), cfg, next)
+	require.NoError(t, err)
+	exporter := new(consumertest.MetricsSink)
+	p.metricsExporter = exporter
+
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+	assert.Len(t, next.AllLogs(), 1)
+	require.Len(t, exporter.AllMetrics(), 1)
+	assert.Equal(t, map[string]int64{"": 3}, sumValues(t, exporter.AllMetrics()[0], defaultLogCountName))
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("host.name", "host-1")
+	metrics := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetDataType(pdata.MetricDataTypeGauge)
+	gauge.Gauge().DataPoints().AppendEmpty().LabelsMap().Insert("host.name", "host-2")
+	gauge.Gauge().DataPoints().AppendEmpty()
+
+	sum := metrics.AppendEmpty()
+	sum.SetDataType(pdata.MetricDataTypeSum)
+	sum.Sum().DataPoints().AppendEmpty()
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	histogram.Histogram().DataPoints().AppendEmpty()
+
+	summary := metrics.AppendEmpty()
+	summary.SetDataType(pdata.MetricDataTypeSummary)
+	summary.Summary().DataPoints().AppendEmpty()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.DataPoints = []MetricInfo{
+		{Name: "datapoints", Attributes: []Attribute{{Key: "host.name"}}},
+	}
+	next := new(consumertest.MetricsSink)
+	p, err := newMetricsProcessor(zap.NewNop(), cfg, next)
+	require.NoError(t, err)
+	exporter := new(consumertest.MetricsSink)
+	p.metricsExporter = exporter
+
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+	assert.Len(t, next.AllMetrics(), 1)
+	require.Len(t, exporter.AllMetrics(), 1)
+	assert.Equal(t, map[string]int64{
+		"host.name=host-1": 4,
+		"host.name=host-2": 1,
+	}, sumValues(t, exporter.AllMetrics()[0], "datapoints"))
+}
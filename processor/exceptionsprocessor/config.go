@@ -0,0 +1,63 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exceptionsprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines the configuration options for the exceptions processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MetricsExporter is the name of the metrics exporter the exception counts are sent to.
+	MetricsExporter string `mapstructure:"metrics_exporter"`
+
+	// LogsExporter is the name of the logs exporter the exception log records are sent to.
+	LogsExporter string `mapstructure:"logs_exporter"`
+
+	// Dimensions defines the list of additional span attributes the exception counts are
+	// grouped by, on top of the service name, span name, exception type and fingerprint.
+	// They are also added to the exception log records.
+	Dimensions []string `mapstructure:"dimensions"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if err := cfg.ProcessorSettings.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.MetricsExporter == "" && cfg.LogsExporter == "" {
+		return errors.New("at least one of \"metrics_exporter\" and \"logs_exporter\" must be specified")
+	}
+
+	labels := make(map[string]struct{})
+	for _, key := range []string{serviceNameKey, spanNameKey, exceptionTypeKey, fingerprintKey} {
+		labels[key] = struct{}{}
+	}
+	for _, dim := range cfg.Dimensions {
+		if _, ok := labels[dim]; ok {
+			return fmt.Errorf("duplicate dimension name %q", dim)
+		}
+		labels[dim] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exceptionsprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Len(t, cfg.Processors, 2)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.MetricsExporter = "nop"
+	assert.Equal(t, defaultCfg, cfg.Processors[config.NewID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "custom")),
+		MetricsExporter:   "nop",
+		LogsExporter:      "nop",
+		Dimensions:        []string{"http.route"},
+	}, cfg.Processors[config.NewIDWithName(typeStr, "custom")])
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:   "only logs exporter",
+			modify: func(cfg *Config) { cfg.MetricsExporter, cfg.LogsExporter = "", "otlp" },
+		},
+		{
+			name:   "missing exporters",
+			modify: func(cfg *Config) { cfg.MetricsExporter = "" },
+			err:    "at least one of \"metrics_exporter\" and \"logs_exporter\" must be specified",
+		},
+		{
+			name:   "reserved dimension",
+			modify: func(cfg *Config) { cfg.Dimensions = []string{"exception.type"} },
+			err:    "duplicate dimension name \"exception.type\"",
+		},
+		{
+			name:   "duplicate dimension",
+			modify: func(cfg *Config) { cfg.Dimensions = []string{"http.route", "http.route"} },
+			err:    "duplicate dimension name \"http.route\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.MetricsExporter = "otlp"
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exceptionsprocessor
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+)
+
+var (
+	// The variable parts of exception messages, from the most to the least specific.
+	uuidRegexp   = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	hexRegexp    = regexp.MustCompile(`\b0[xX][0-9a-fA-F]+\b`)
+	numberRegexp = regexp.MustCompile(`\d+(\.\d+)?`)
+)
+
+// normalizeMessage replaces the parts of an exception message that usually vary
+// between occurrences of the same error, like IDs, addresses and numbers, with
+// placeholders.
+func normalizeMessage(message string) string {
+	message = uuidRegexp.ReplaceAllString(message, "<uuid>")
+	message = hexRegexp.ReplaceAllString(message, "<hex>")
+	return numberRegexp.ReplaceAllString(message, "<num>")
+}
+
+// fingerprint returns an identifier of an exception, which is the same for
+// exceptions of the same type whose messages only differ in their variable parts.
+func fingerprint(exceptionType, message string) string {
+	h := fnv.New64a()
+	h.Write([]byte(exceptionType))
+	h.Write([]byte{0})
+	h.Write([]byte(normalizeMessage(message)))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
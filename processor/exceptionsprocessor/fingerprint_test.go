@@ -0,0 +1,48 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exceptionsprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeMessage(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"connection refused", "connection refused"},
+		{"user 42 not found", "user <num> not found"},
+		{"timeout after 1.5s", "timeout after <num>s"},
+		{"order 123e4567-e89b-12d3-a456-426614174000 is locked", "order <uuid> is locked"},
+		{"invalid pointer 0x7ffd5fbff8ac", "invalid pointer <hex>"},
+		{"dial tcp 10.0.0.1:5432: i/o timeout", "dial tcp <num>.<num>:<num>: i/o timeout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizeMessage(tt.message))
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	fp := fingerprint("NotFoundError", "user 42 not found")
+	assert.Len(t, fp, 16)
+	assert.Equal(t, fp, fingerprint("NotFoundError", "user 7 not found"))
+	assert.NotEqual(t, fp, fingerprint("KeyError", "user 42 not found"))
+	assert.NotEqual(t, fp, fingerprint("NotFoundError", "order 42 not found"))
+}
@@ -0,0 +1,330 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exceptionsprocessor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	exceptionEventName = "exception"
+
+	serviceNameKey     = conventions.AttributeServiceName
+	spanNameKey        = "span.name"
+	exceptionTypeKey   = conventions.AttributeExceptionType
+	fingerprintKey     = "exception.fingerprint"
+	metricKeySeparator = string(byte(0))
+)
+
+type metricKey string
+
+// processorImp extracts the exception events of spans, counts them per exception
+// type and fingerprint and turns them into log records, which are sent to the
+// configured exporters. The spans are forwarded to the next consumer unmodified.
+type processorImp struct {
+	lock   sync.Mutex
+	logger *zap.Logger
+	config Config
+
+	metricsExporter consumer.Metrics
+	logsExporter    consumer.Logs
+	nextConsumer    consumer.Traces
+
+	// The starting time of the data points.
+	startTime time.Time
+
+	exceptionCount map[metricKey]int64
+	// A cache of the labels of each metric key, built on the first exception of the key.
+	metricKeyToLabels map[metricKey]map[string]string
+}
+
+func newProcessor(logger *zap.Logger, cfg *Config, nextConsumer consumer.Traces) *processorImp {
+	return &processorImp{
+		logger:            logger,
+		config:            *cfg,
+		nextConsumer:      nextConsumer,
+		startTime:         time.Now(),
+		exceptionCount:    make(map[metricKey]int64),
+		metricKeyToLabels: make(map[metricKey]map[string]string),
+	}
+}
+
+// Start implements the component.Component interface.
+func (p *processorImp) Start(_ context.Context, host component.Host) error {
+	exporters := host.GetExporters()
+	if p.config.MetricsExporter != "" {
+		exp, err := findExporter(exporters, config.MetricsDataType, p.config.MetricsExporter)
+		if err != nil {
+			return err
+		}
+		metricsExp, ok := exp.(component.MetricsExporter)
+		if !ok {
+			return fmt.Errorf("the exporter %q isn't a metrics exporter", p.config.MetricsExporter)
+		}
+		p.metricsExporter = metricsExp
+	}
+	if p.config.LogsExporter != "" {
+		exp, err := findExporter(exporters, config.LogsDataType, p.config.LogsExporter)
+		if err != nil {
+			return err
+		}
+		logsExp, ok := exp.(component.LogsExporter)
+		if !ok {
+			return fmt.Errorf("the exporter %q isn't a logs exporter", p.config.LogsExporter)
+		}
+		p.logsExporter = logsExp
+	}
+	return nil
+}
+
+// findExporter returns the exporter with the given name from the exporters of the
+// pipelines of the given data type.
+func findExporter(exporters map[config.DataType]map[config.ComponentID]component.Exporter, dataType config.DataType, name string) (component.Exporter, error) {
+	var available []string
+	for k, exp := range exporters[dataType] {
+		if k.String() == name {
+			return exp, nil
+		}
+		available = append(available, k.String())
+	}
+	return nil, fmt.Errorf("failed to find %s exporter: '%s'; please configure %s_exporter from one of: %+v",
+		dataType, name, dataType, available)
+}
+
+// Shutdown implements the component.Component interface.
+func (p *processorImp) Shutdown(context.Context) error {
+	return nil
+}
+
+// Capabilities implements the consumer interface.
+func (p *processorImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces counts the exceptions of the spans, sends the exception counts and log
+// records to the exporters and forwards the spans to the next consumer.
+func (p *processorImp) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	ld := pdata.NewLogs()
+
+	p.lock.Lock()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		p.aggregateResourceSpans(rss.At(i), ld)
+	}
+	p.lock.Unlock()
+
+	// Firstly, export the exceptions to avoid being impacted by downstream errors/latency.
+	if err := p.exportMetrics(ctx); err != nil {
+		return err
+	}
+	if p.logsExporter != nil && ld.LogRecordCount() > 0 {
+		if err := p.logsExporter.ConsumeLogs(ctx, ld); err != nil {
+			return err
+		}
+	}
+	return p.nextConsumer.ConsumeTraces(ctx, td)
+}
+
+// aggregateResourceSpans counts the exceptions of the spans of a resource and appends
+// their log records to ld, under a copy of the resource.
+func (p *processorImp) aggregateResourceSpans(rs pdata.ResourceSpans, ld pdata.Logs) {
+	var serviceName string
+	if attr, ok := rs.Resource().Attributes().Get(conventions.AttributeServiceName); ok {
+		serviceName = attr.StringVal()
+	}
+
+	var logs pdata.LogSlice
+	hasLogs := false
+	ilss := rs.InstrumentationLibrarySpans()
+	for i := 0; i < ilss.Len(); i++ {
+		spans := ilss.At(i).Spans()
+		for j := 0; j < spans.Len(); j++ {
+			span := spans.At(j)
+			events := span.Events()
+			for k := 0; k < events.Len(); k++ {
+				event := events.At(k)
+				if event.Name() != exceptionEventName {
+					continue
+				}
+
+				e := p.newException(serviceName, span, event)
+				p.updateMetrics(e)
+				if p.logsExporter == nil {
+					continue
+				}
+				if !hasLogs {
+					rl := ld.ResourceLogs().AppendEmpty()
+					rs.Resource().CopyTo(rl.Resource())
+					ill := rl.InstrumentationLibraryLogs().AppendEmpty()
+					ill.InstrumentationLibrary().SetName("exceptionsprocessor")
+					logs = ill.Logs()
+					hasLogs = true
+				}
+				e.appendLogRecord(logs, span, event)
+			}
+		}
+	}
+}
+
+// exception holds the details of an exception event.
+type exception struct {
+	serviceName   string
+	spanName      string
+	exceptionType string
+	message       string
+	stacktrace    string
+	fingerprint   string
+	// The values of the configured dimensions the span has.
+	dimensions map[string]string
+}
+
+func (p *processorImp) newException(serviceName string, span pdata.Span, event pdata.SpanEvent) *exception {
+	e := &exception{
+		serviceName: serviceName,
+		spanName:    span.Name(),
+		dimensions:  make(map[string]string, len(p.config.Dimensions)),
+	}
+	attrs := event.Attributes()
+	if attr, ok := attrs.Get(conventions.AttributeExceptionType); ok {
+		e.exceptionType = attr.StringVal()
+	}
+	if attr, ok := attrs.Get(conventions.AttributeExceptionMessage); ok {
+		e.message = attr.StringVal()
+	}
+	if attr, ok := attrs.Get(conventions.AttributeExceptionStacktrace); ok {
+		e.stacktrace = attr.StringVal()
+	}
+	e.fingerprint = fingerprint(e.exceptionType, e.message)
+
+	spanAttrs := span.Attributes()
+	for _, dim := range p.config.Dimensions {
+		if attr, ok := spanAttrs.Get(dim); ok {
+			e.dimensions[dim] = tracetranslator.AttributeValueToString(attr)
+		}
+	}
+	return e
+}
+
+// labels returns the labels of the exception count of e.
+func (e *exception) labels() map[string]string {
+	labels := map[string]string{
+		serviceNameKey:   e.serviceName,
+		spanNameKey:      e.spanName,
+		exceptionTypeKey: e.exceptionType,
+		fingerprintKey:   e.fingerprint,
+	}
+	for k, v := range e.dimensions {
+		labels[k] = v
+	}
+	return labels
+}
+
+// appendLogRecord appends a log record of the exception, linked to the span it
+// occurred in.
+func (e *exception) appendLogRecord(logs pdata.LogSlice, span pdata.Span, event pdata.SpanEvent) {
+	lr := logs.AppendEmpty()
+	lr.SetTimestamp(event.Timestamp())
+	lr.SetSeverityNumber(pdata.SeverityNumberERROR)
+	lr.SetSeverityText("ERROR")
+	lr.SetTraceID(span.TraceID())
+	lr.SetSpanID(span.SpanID())
+	lr.Body().SetStringVal(e.message)
+
+	attrs := lr.Attributes()
+	attrs.InsertString(spanNameKey, e.spanName)
+	attrs.InsertString(conventions.AttributeExceptionType, e.exceptionType)
+	attrs.InsertString(conventions.AttributeExceptionMessage, e.message)
+	if e.stacktrace != "" {
+		attrs.InsertString(conventions.AttributeExceptionStacktrace, e.stacktrace)
+	}
+	attrs.InsertString(fingerprintKey, e.fingerprint)
+	for k, v := range e.dimensions {
+		attrs.InsertString(k, v)
+	}
+}
+
+// updateMetrics counts an exception.
+func (p *processorImp) updateMetrics(e *exception) {
+	if p.metricsExporter == nil {
+		return
+	}
+
+	var keyBuilder strings.Builder
+	for i, value := range []string{e.serviceName, e.spanName, e.exceptionType, e.fingerprint} {
+		if i > 0 {
+			keyBuilder.WriteString(metricKeySeparator)
+		}
+		keyBuilder.WriteString(value)
+	}
+	for _, dim := range p.config.Dimensions {
+		keyBuilder.WriteString(metricKeySeparator)
+		keyBuilder.WriteString(e.dimensions[dim])
+	}
+
+	key := metricKey(keyBuilder.String())
+	if _, ok := p.metricKeyToLabels[key]; !ok {
+		p.metricKeyToLabels[key] = e.labels()
+	}
+	p.exceptionCount[key]++
+}
+
+// exportMetrics sends the cumulative exception counts to the metrics exporter.
+func (p *processorImp) exportMetrics(ctx context.Context) error {
+	if p.metricsExporter == nil {
+		return nil
+	}
+
+	md := pdata.NewMetrics()
+	ilm := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName("exceptionsprocessor")
+
+	p.lock.Lock()
+	if len(p.exceptionCount) == 0 {
+		p.lock.Unlock()
+		return nil
+	}
+	m := ilm.Metrics().AppendEmpty()
+	m.SetName("exceptions_total")
+	m.SetDescription("The number of exceptions recorded in spans.")
+	m.SetUnit("1")
+	m.SetDataType(pdata.MetricDataTypeSum)
+	m.Sum().SetIsMonotonic(true)
+	m.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+	now := time.Now()
+	dps := m.Sum().DataPoints()
+	for key, count := range p.exceptionCount {
+		dp := dps.AppendEmpty()
+		dp.SetStartTimestamp(pdata.TimestampFromTime(p.startTime))
+		dp.SetTimestamp(pdata.TimestampFromTime(now))
+		dp.SetIntVal(count)
+		dp.LabelsMap().InitFromMap(p.metricKeyToLabels[key])
+	}
+	p.lock.Unlock()
+
+	return p.metricsExporter.ConsumeMetrics(ctx, md)
+}
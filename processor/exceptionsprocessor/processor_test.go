@@ -0,0 +1,229 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exceptionsprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// exportersHost is a host with the given exporters.
+type exportersHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *exportersHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
+func TestProcessorStart(t *testing.T) {
+	exporterFactory := componenttest.NewNopExporterFactory()
+	metricsExp, err := exporterFactory.CreateMetricsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(),
+		exporterFactory.CreateDefaultConfig())
+	require.NoError(t, err)
+	logsExp, err := exporterFactory.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(),
+		exporterFactory.CreateDefaultConfig())
+	require.NoError(t, err)
+	host := &exportersHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.MetricsDataType: {config.NewID("nop"): metricsExp},
+			config.LogsDataType:    {config.NewID("nop"): logsExp},
+		},
+	}
+
+	for _, tc := range []struct {
+		name            string
+		metricsExporter string
+		logsExporter    string
+		wantErrorMsg    string
+	}{
+		{"export to active metrics exporter", "nop", "", ""},
+		{"export to active metrics and logs exporters", "nop", "nop", ""},
+		{"unable to find configured metrics exporter", "prometheus", "", "failed to find metrics exporter: 'prometheus'; please configure metrics_exporter from one of: [nop]"},
+		{"unable to find configured logs exporter", "", "loki", "failed to find logs exporter: 'loki'; please configure logs_exporter from one of: [nop]"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.MetricsExporter = tc.metricsExporter
+			cfg.LogsExporter = tc.logsExporter
+			p := newProcessor(zap.NewNop(), cfg, consumertest.NewNop())
+
+			err := p.Start(context.Background(), host)
+			if tc.wantErrorMsg != "" {
+				assert.EqualError(t, err, tc.wantErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.metricsExporter != "" {
+				assert.Equal(t, metricsExp, p.metricsExporter)
+			}
+			if tc.logsExporter != "" {
+				assert.Equal(t, logsExp, p.logsExporter)
+			} else {
+				assert.Nil(t, p.logsExporter)
+			}
+		})
+	}
+}
+
+func appendException(span pdata.Span, exceptionType, message string) {
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.SetTimestamp(span.StartTimestamp())
+	event.Attributes().InsertString("exception.type", exceptionType)
+	event.Attributes().InsertString("exception.message", message)
+	event.Attributes().InsertString("exception.stacktrace", "at main.go:42")
+}
+
+func testTraces() pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+
+	span := spans.AppendEmpty()
+	span.SetName("GET /cart")
+	span.SetTraceID(pdata.NewTraceID([16]byte{1}))
+	span.SetSpanID(pdata.NewSpanID([8]byte{1}))
+	span.Attributes().InsertString("http.route", "/cart")
+	appendException(span, "NotFoundError", "cart 1 not found")
+	appendException(span, "NotFoundError", "cart 2 not found")
+	span.Events().AppendEmpty().SetName("retry")
+
+	// Spans without exceptions don't produce anything.
+	spans.AppendEmpty().SetName("GET /health")
+
+	span = spans.AppendEmpty()
+	span.SetName("POST /pay")
+	appendException(span, "TimeoutError", "payment timed out")
+
+	// Resources without exceptions don't produce anything.
+	td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	return td
+}
+
+func TestConsumeTraces(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Dimensions = []string{"http.route"}
+	next := new(consumertest.TracesSink)
+	p := newProcessor(zap.NewNop(), cfg, next)
+	metricsSink := new(consumertest.MetricsSink)
+	p.metricsExporter = metricsSink
+	logsSink := new(consumertest.LogsSink)
+	p.logsExporter = logsSink
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), testTraces()))
+	require.NoError(t, p.ConsumeTraces(context.Background(), testTraces()))
+	assert.Len(t, next.AllTraces(), 2)
+
+	require.Len(t, metricsSink.AllMetrics(), 2)
+	md := metricsSink.AllMetrics()[1]
+	require.Equal(t, 1, md.MetricCount())
+	m := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "exceptions_total", m.Name())
+	require.Equal(t, pdata.MetricDataTypeSum, m.DataType())
+	assert.True(t, m.Sum().IsMonotonic())
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+
+	counts := make(map[string]int64)
+	dps := m.Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		exceptionType, _ := dp.LabelsMap().Get("exception.type")
+		counts[exceptionType] = dp.IntVal()
+
+		if exceptionType == "NotFoundError" {
+			labels := make(map[string]string)
+			dp.LabelsMap().Range(func(k string, v string) bool {
+				labels[k] = v
+				return true
+			})
+			assert.Equal(t, map[string]string{
+				"service.name":          "checkout",
+				"span.name":             "GET /cart",
+				"exception.type":        "NotFoundError",
+				"exception.fingerprint": fingerprint("NotFoundError", "cart 1 not found"),
+				"http.route":            "/cart",
+			}, labels)
+		}
+	}
+	assert.Equal(t, map[string]int64{"NotFoundError": 4, "TimeoutError": 2}, counts)
+
+	require.Len(t, logsSink.AllLogs(), 2)
+	ld := logsSink.AllLogs()[0]
+	require.Equal(t, 1, ld.ResourceLogs().Len())
+	rl := ld.ResourceLogs().At(0)
+	serviceName, _ := rl.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "checkout", serviceName.StringVal())
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	require.Equal(t, 3, logs.Len())
+
+	lr := logs.At(0)
+	assert.Equal(t, pdata.SeverityNumberERROR, lr.SeverityNumber())
+	assert.Equal(t, "cart 1 not found", lr.Body().StringVal())
+	assert.Equal(t, pdata.NewTraceID([16]byte{1}), lr.TraceID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{1}), lr.SpanID())
+	for key, want := range map[string]string{
+		"span.name":             "GET /cart",
+		"exception.type":        "NotFoundError",
+		"exception.message":     "cart 1 not found",
+		"exception.stacktrace":  "at main.go:42",
+		"exception.fingerprint": fingerprint("NotFoundError", "cart 1 not found"),
+		"http.route":            "/cart",
+	} {
+		attr, ok := lr.Attributes().Get(key)
+		require.True(t, ok, key)
+		assert.Equal(t, want, attr.StringVal(), key)
+	}
+}
+
+func TestConsumeTracesWithoutExceptions(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	next := new(consumertest.TracesSink)
+	p := newProcessor(zap.NewNop(), cfg, next)
+	metricsSink := new(consumertest.MetricsSink)
+	p.metricsExporter = metricsSink
+	logsSink := new(consumertest.LogsSink)
+	p.logsExporter = logsSink
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	require.NoError(t, p.ConsumeTraces(context.Background(), td))
+	assert.Len(t, next.AllTraces(), 1)
+	assert.Empty(t, metricsSink.AllMetrics())
+	assert.Empty(t, logsSink.AllLogs())
+}
+
+func TestConsumeTracesExportError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	next := new(consumertest.TracesSink)
+	p := newProcessor(zap.NewNop(), cfg, next)
+	p.logsExporter = consumertest.NewErr(errors.New("export failed"))
+
+	assert.EqualError(t, p.ConsumeTraces(context.Background(), testTraces()), "export failed")
+	assert.Empty(t, next.AllTraces())
+}
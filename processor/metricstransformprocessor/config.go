@@ -54,6 +54,9 @@ const (
 
 	// SubmatchCaseFieldName is the mapstructure field name for SubmatchCase field
 	SubmatchCaseFieldName = "submatch_case"
+
+	// MatchDataPointsFieldName is the mapstructure field name for MatchDataPoints field
+	MatchDataPointsFieldName = "experimental_match_datapoints"
 )
 
 // Config defines configuration for Resource processor.
@@ -117,6 +120,29 @@ type FilterConfig struct {
 	// MatchLabels specifies the label set against which the metric filter will work.
 	// This field is optional.
 	MatchLabels map[string]string `mapstructure:"experimental_match_labels"`
+
+	// MatchDataPoints specifies the condition the data points of the metric(s) must match.
+	// Only the matching data points are operated on. When the action is update and NewName
+	// is set, the matching data points are moved to a metric with the new name.
+	// This field is optional.
+	MatchDataPoints *DataPointCondition `mapstructure:"experimental_match_datapoints"`
+}
+
+// DataPointCondition matches data points by their label values and value.
+type DataPointCondition struct {
+	// MatchLabels specifies regular expressions the label values of the data point must match.
+	// A missing label matches if the regular expression matches the empty string.
+	MatchLabels map[string]string `mapstructure:"match_labels"`
+
+	// ValueLabel specifies a label whose value is parsed as a number and compared against
+	// MinValue and MaxValue instead of the value of the data point.
+	ValueLabel string `mapstructure:"value_label"`
+
+	// MinValue is the inclusive lower bound of the value.
+	MinValue *float64 `mapstructure:"min_value"`
+
+	// MaxValue is the exclusive upper bound of the value.
+	MaxValue *float64 `mapstructure:"max_value"`
 }
 
 // Operation defines the specific operation performed on the selected metrics.
@@ -151,6 +177,10 @@ type Operation struct {
 
 	// LabelValue identifies the exact label value to operate on
 	LabelValue string `mapstructure:"label_value"`
+
+	// MatchDataPoints restricts the operation to the data points matching the condition.
+	// Only supported when the action is `add_label`, `update_label` or `experimental_scale_value`.
+	MatchDataPoints *DataPointCondition `mapstructure:"experimental_match_datapoints"`
 }
 
 // ValueAction renames label values.
@@ -221,6 +251,9 @@ const (
 
 var operationActions = []OperationAction{AddLabel, UpdateLabel, DeleteLabelValue, ToggleScalarDataType, ScaleValue, AggregateLabels, AggregateLabelValues}
 
+// conditionalOperationActions are the operation actions that support data point conditions.
+var conditionalOperationActions = []OperationAction{AddLabel, UpdateLabel, ScaleValue}
+
 func (oa OperationAction) isValid() bool {
 	for _, operationAction := range operationActions {
 		if oa == operationAction {
@@ -231,6 +264,16 @@ func (oa OperationAction) isValid() bool {
 	return false
 }
 
+func (oa OperationAction) supportsCondition() bool {
+	for _, operationAction := range conditionalOperationActions {
+		if oa == operationAction {
+			return true
+		}
+	}
+
+	return false
+}
+
 // AggregationType is the enum to capture the three types of aggregation for the aggregation operation.
 type AggregationType string
 
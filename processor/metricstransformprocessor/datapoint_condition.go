@@ -0,0 +1,192 @@
+// Copyright 2021 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// internalDataPointCondition is the compiled DataPointCondition.
+type internalDataPointCondition struct {
+	matchLabels map[string]*regexp.Regexp
+	valueLabel  string
+	minValue    *float64
+	maxValue    *float64
+}
+
+// validateDataPointCondition validates the condition, returning an error prefixed with the field name.
+func validateDataPointCondition(condition *DataPointCondition) error {
+	if condition == nil {
+		return nil
+	}
+
+	for label, expr := range condition.MatchLabels {
+		if _, err := regexp.Compile(expr); err != nil {
+			return fmt.Errorf("%q: label %q, %w", MatchDataPointsFieldName, label, err)
+		}
+	}
+	if condition.ValueLabel != "" && condition.MinValue == nil && condition.MaxValue == nil {
+		return fmt.Errorf("%q: value_label requires min_value or max_value", MatchDataPointsFieldName)
+	}
+	if condition.MinValue != nil && condition.MaxValue != nil && *condition.MinValue >= *condition.MaxValue {
+		return fmt.Errorf("%q: min_value must be less than max_value", MatchDataPointsFieldName)
+	}
+	return nil
+}
+
+func createDataPointCondition(condition *DataPointCondition) (*internalDataPointCondition, error) {
+	if condition == nil {
+		return nil, nil
+	}
+
+	matchLabels := make(map[string]*regexp.Regexp, len(condition.MatchLabels))
+	for label, expr := range condition.MatchLabels {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, err
+		}
+		matchLabels[label] = re
+	}
+	return &internalDataPointCondition{
+		matchLabels: matchLabels,
+		valueLabel:  condition.ValueLabel,
+		minValue:    condition.MinValue,
+		maxValue:    condition.MaxValue,
+	}, nil
+}
+
+// matches returns whether the data points of the time series match the condition.
+func (c *internalDataPointCondition) matches(descriptor *metricspb.MetricDescriptor, ts *metricspb.TimeSeries) bool {
+	for label, re := range c.matchLabels {
+		value, _ := labelValue(descriptor, ts, label)
+		if !re.MatchString(value) {
+			return false
+		}
+	}
+
+	if c.minValue == nil && c.maxValue == nil {
+		return true
+	}
+
+	if c.valueLabel != "" {
+		value, ok := labelValue(descriptor, ts, c.valueLabel)
+		if !ok {
+			return false
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return false
+		}
+		return c.inRange(f)
+	}
+
+	if len(ts.Points) == 0 {
+		return false
+	}
+	for _, point := range ts.Points {
+		var value float64
+		switch v := point.Value.(type) {
+		case *metricspb.Point_Int64Value:
+			value = float64(v.Int64Value)
+		case *metricspb.Point_DoubleValue:
+			value = v.DoubleValue
+		default:
+			// Distributions and summaries don't have a single value to compare.
+			return false
+		}
+		if !c.inRange(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *internalDataPointCondition) inRange(value float64) bool {
+	if c.minValue != nil && value < *c.minValue {
+		return false
+	}
+	if c.maxValue != nil && value >= *c.maxValue {
+		return false
+	}
+	return true
+}
+
+// labelValue returns the value of the label in the time series, and whether it has one.
+func labelValue(descriptor *metricspb.MetricDescriptor, ts *metricspb.TimeSeries, label string) (string, bool) {
+	for idx, key := range descriptor.LabelKeys {
+		if key.Key == label && idx < len(ts.LabelValues) && ts.LabelValues[idx].HasValue {
+			return ts.LabelValues[idx].Value, true
+		}
+	}
+	return "", false
+}
+
+// matchedTimeseries returns whether each time series of the metric matches the operation's condition.
+func (op internalOperation) matchedTimeseries(metric *metricspb.Metric) []bool {
+	matched := make([]bool, len(metric.Timeseries))
+	for i, ts := range metric.Timeseries {
+		matched[i] = op.condition == nil || op.condition.matches(metric.MetricDescriptor, ts)
+	}
+	return matched
+}
+
+// dataPointsMatched returns the metric with only the time series matching the condition, or nil if none match.
+// The metric itself is returned if all of its time series match.
+func dataPointsMatched(condition *internalDataPointCondition, metric *metricspb.Metric) *metricspb.Metric {
+	if condition == nil {
+		return metric
+	}
+
+	var matched []*metricspb.TimeSeries
+	for _, ts := range metric.Timeseries {
+		if condition.matches(metric.MetricDescriptor, ts) {
+			matched = append(matched, ts)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+	if len(matched) == len(metric.Timeseries) {
+		return metric
+	}
+
+	return &metricspb.Metric{
+		MetricDescriptor: proto.Clone(metric.MetricDescriptor).(*metricspb.MetricDescriptor),
+		Resource:         proto.Clone(metric.Resource).(*resourcepb.Resource),
+		Timeseries:       matched,
+	}
+}
+
+// removeTimeseries returns the time series not contained in toRemove.
+func removeTimeseries(timeseries []*metricspb.TimeSeries, toRemove []*metricspb.TimeSeries) []*metricspb.TimeSeries {
+	removed := make(map[*metricspb.TimeSeries]bool, len(toRemove))
+	for _, ts := range toRemove {
+		removed[ts] = true
+	}
+
+	remaining := make([]*metricspb.TimeSeries, 0, len(timeseries))
+	for _, ts := range timeseries {
+		if !removed[ts] {
+			remaining = append(remaining, ts)
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,66 @@
+// Copyright 2021 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"regexp"
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataPointConditionMatches(t *testing.T) {
+	metric := metricBuilder().setName("metric").setLabels([]string{"label", "latency"}).
+		setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+		addTimeseries(1, []string{"value", "150"}).addInt64Point(0, 5, 2).
+		addTimeseries(1, []string{"value", "abc"}).addInt64Point(1, 50, 2).
+		addTimeseries(1, []string{"value", "150"}).addDistributionPoints(2, 1, 5, []float64{1}, []int64{0, 1}).
+		build()
+
+	tests := []struct {
+		name      string
+		condition *internalDataPointCondition
+		want      []bool
+	}{
+		{
+			name:      "match labels",
+			condition: &internalDataPointCondition{matchLabels: map[string]*regexp.Regexp{"label": regexp.MustCompile("^val")}},
+			want:      []bool{true, true, true},
+		},
+		{
+			name:      "missing label matches empty string",
+			condition: &internalDataPointCondition{matchLabels: map[string]*regexp.Regexp{"missing": regexp.MustCompile("^$")}},
+			want:      []bool{true, true, true},
+		},
+		{
+			name:      "value range",
+			condition: &internalDataPointCondition{minValue: float64Ptr(10), maxValue: float64Ptr(100)},
+			want:      []bool{false, true, false},
+		},
+		{
+			name:      "value label range",
+			condition: &internalDataPointCondition{valueLabel: "latency", minValue: float64Ptr(100)},
+			want:      []bool{true, false, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := internalOperation{condition: tt.condition}
+			assert.Equal(t, tt.want, op.matchedTimeseries(metric))
+		})
+	}
+}
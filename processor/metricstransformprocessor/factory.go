@@ -114,6 +114,10 @@ func validateConfiguration(config *Config) error {
 			return fmt.Errorf("%q must be in %q", SubmatchCaseFieldName, submatchCases)
 		}
 
+		if err := validateDataPointCondition(transform.MetricIncludeFilter.MatchDataPoints); err != nil {
+			return err
+		}
+
 		for i, op := range transform.Operations {
 			if !op.Action.isValid() {
 				return fmt.Errorf("operation %v: %q must be in %q", i+1, ActionFieldName, operationActions)
@@ -135,6 +139,13 @@ func validateConfiguration(config *Config) error {
 			if op.AggregationType != "" && !op.AggregationType.isValid() {
 				return fmt.Errorf("operation %v: %q must be in %q", i+1, AggregationTypeFieldName, aggregationTypes)
 			}
+
+			if op.MatchDataPoints != nil && !op.Action.supportsCondition() {
+				return fmt.Errorf("operation %v: %q is only supported while %q is in %q", i+1, MatchDataPointsFieldName, ActionFieldName, conditionalOperationActions)
+			}
+			if err := validateDataPointCondition(op.MatchDataPoints); err != nil {
+				return fmt.Errorf("operation %v: %w", i+1, err)
+			}
 		}
 	}
 	return nil
@@ -182,6 +193,9 @@ func buildHelperConfig(config *Config, version string) ([]internalTransform, err
 			} else if op.Action == AggregateLabelValues {
 				mtpOp.aggregatedValuesSet = sliceToSet(op.AggregatedValues)
 			}
+			if mtpOp.condition, err = createDataPointCondition(op.MatchDataPoints); err != nil {
+				return nil, err
+			}
 			helperT.Operations[j] = mtpOp
 		}
 		helperDataTransforms[i] = helperT
@@ -190,19 +204,24 @@ func buildHelperConfig(config *Config, version string) ([]internalTransform, err
 }
 
 func createFilter(filterConfig FilterConfig) (internalFilter, error) {
+	matchDataPoints, err := createDataPointCondition(filterConfig.MatchDataPoints)
+	if err != nil {
+		return nil, err
+	}
+
 	switch filterConfig.MatchType {
 	case StrictMatchType:
 		matchers, err := getMatcherMap(filterConfig.MatchLabels, func(str string) (StringMatcher, error) { return strictMatcher(str), nil })
 		if err != nil {
 			return nil, err
 		}
-		return internalFilterStrict{include: filterConfig.Include, matchLabels: matchers}, nil
+		return internalFilterStrict{include: filterConfig.Include, matchLabels: matchers, matchDataPoints: matchDataPoints}, nil
 	case RegexpMatchType:
 		matchers, err := getMatcherMap(filterConfig.MatchLabels, func(str string) (StringMatcher, error) { return regexp.Compile(str) })
 		if err != nil {
 			return nil, err
 		}
-		return internalFilterRegexp{include: regexp.MustCompile(filterConfig.Include), matchLabels: matchers}, nil
+		return internalFilterRegexp{include: regexp.MustCompile(filterConfig.Include), matchLabels: matchers, matchDataPoints: matchDataPoints}, nil
 	}
 
 	return nil, fmt.Errorf("invalid match type: %v", filterConfig.MatchType)
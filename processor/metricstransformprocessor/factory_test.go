@@ -114,6 +114,16 @@ func TestCreateProcessors(t *testing.T) {
 			succeed:      false,
 			errorMessage: fmt.Sprintf("operation %v: %q must be in %q", 1, AggregationTypeFieldName, aggregationTypes),
 		},
+		{
+			configName:   "config_invalid_match_datapoints.yaml",
+			succeed:      false,
+			errorMessage: fmt.Sprintf("%q: min_value must be less than max_value", MatchDataPointsFieldName),
+		},
+		{
+			configName:   "config_invalid_operation_match_datapoints.yaml",
+			succeed:      false,
+			errorMessage: fmt.Sprintf("operation %v: %q is only supported while %q is in %q", 1, MatchDataPointsFieldName, ActionFieldName, conditionalOperationActions),
+		},
 		{
 			configName:   "config_invalid_submatchcase.yaml",
 			succeed:      false,
@@ -52,6 +52,7 @@ type internalOperation struct {
 	valueActionsMapping map[string]string
 	labelSetMap         map[string]bool
 	aggregatedValuesSet map[string]bool
+	condition           *internalDataPointCondition
 }
 
 type internalFilter interface {
@@ -63,6 +64,10 @@ type match struct {
 	metric     *metricspb.Metric
 	pattern    *regexp.Regexp
 	submatches []int
+
+	// original is the metric the data points matched by a data point condition were taken from,
+	// if the condition didn't match all of them.
+	original *metricspb.Metric
 }
 
 type StringMatcher interface {
@@ -76,8 +81,9 @@ func (s strictMatcher) MatchString(cmp string) bool {
 }
 
 type internalFilterStrict struct {
-	include     string
-	matchLabels map[string]StringMatcher
+	include         string
+	matchLabels     map[string]StringMatcher
+	matchDataPoints *internalDataPointCondition
 }
 
 func (f internalFilterStrict) getMatches(toMatch metricNameMapping) []*match {
@@ -85,9 +91,8 @@ func (f internalFilterStrict) getMatches(toMatch metricNameMapping) []*match {
 	if metrics, ok := toMatch[f.include]; ok {
 		matches := make([]*match, 0)
 		for _, metric := range metrics {
-			matchedMetric := labelMatched(f.matchLabels, metric)
-			if matchedMetric != nil {
-				matches = append(matches, &match{metric: matchedMetric})
+			if m := filterMetric(f.matchLabels, f.matchDataPoints, metric); m != nil {
+				matches = append(matches, m)
 			}
 		}
 		return matches
@@ -101,8 +106,9 @@ func (f internalFilterStrict) getSubexpNames() []string {
 }
 
 type internalFilterRegexp struct {
-	include     *regexp.Regexp
-	matchLabels map[string]StringMatcher
+	include         *regexp.Regexp
+	matchLabels     map[string]StringMatcher
+	matchDataPoints *internalDataPointCondition
 }
 
 func (f internalFilterRegexp) getMatches(toMatch metricNameMapping) []*match {
@@ -110,9 +116,10 @@ func (f internalFilterRegexp) getMatches(toMatch metricNameMapping) []*match {
 	for name, metrics := range toMatch {
 		if submatches := f.include.FindStringSubmatchIndex(name); submatches != nil {
 			for _, metric := range metrics {
-				matchedMetric := labelMatched(f.matchLabels, metric)
-				if matchedMetric != nil {
-					matches = append(matches, &match{metric: matchedMetric, pattern: f.include, submatches: submatches})
+				if m := filterMetric(f.matchLabels, f.matchDataPoints, metric); m != nil {
+					m.pattern = f.include
+					m.submatches = submatches
+					matches = append(matches, m)
 				}
 			}
 		}
@@ -124,6 +131,27 @@ func (f internalFilterRegexp) getSubexpNames() []string {
 	return f.include.SubexpNames()
 }
 
+// filterMetric returns the match of the metric's time series matching both the labels and the data point
+// condition, or nil if there is none.
+func filterMetric(matchLabels map[string]StringMatcher, matchDataPoints *internalDataPointCondition, metric *metricspb.Metric) *match {
+	matchedMetric := labelMatched(matchLabels, metric)
+	if matchedMetric == nil {
+		return nil
+	}
+	if matchDataPoints == nil {
+		return &match{metric: matchedMetric}
+	}
+
+	matchedMetric = dataPointsMatched(matchDataPoints, matchedMetric)
+	if matchedMetric == nil {
+		return nil
+	}
+	if matchedMetric == metric {
+		return &match{metric: metric}
+	}
+	return &match{metric: matchedMetric, original: metric}
+}
+
 func labelMatched(matchLabels map[string]StringMatcher, metric *metricspb.Metric) *metricspb.Metric {
 	if len(matchLabels) == 0 {
 		return metric
@@ -251,6 +279,19 @@ func (mtp *metricsTransformProcessor) processMetrics(_ context.Context, md pdata
 					metrics = append(metrics, match.metric)
 				}
 
+				if transform.Action == Update && match.original != nil {
+					// Move the matched data points out of the original metric, so only they are updated.
+					match.original.Timeseries = removeTimeseries(match.original.Timeseries, match.metric.Timeseries)
+					if len(match.original.Timeseries) == 0 {
+						metrics = removeMetric(metrics, match.original)
+						nameToMetricMapping.remove(metricName, match.original)
+					}
+					metrics = append(metrics, match.metric)
+					if transform.NewName == "" {
+						nameToMetricMapping.add(metricName, match.metric)
+					}
+				}
+
 				mtp.update(match, transform)
 
 				if transform.NewName != "" {
@@ -409,6 +450,16 @@ func (mtp *metricsTransformProcessor) removeMatchedMetrics(metrics []*metricspb.
 	return filteredMetrics
 }
 
+// removeMetric removes the given metric from metrics
+func removeMetric(metrics []*metricspb.Metric, toRemove *metricspb.Metric) []*metricspb.Metric {
+	for i, metric := range metrics {
+		if metric == toRemove {
+			return append(metrics[:i], metrics[i+1:]...)
+		}
+	}
+	return metrics
+}
+
 // removeMatchedMetricsAndAppendCombined removes the set of matched metrics from metrics and appends the combined metric at the end.
 func (mtp *metricsTransformProcessor) removeMatchedMetricsAndAppendCombined(metrics []*metricspb.Metric, matchedMetrics []*match, combined *metricspb.Metric) []*metricspb.Metric {
 	filteredMetrics := mtp.removeMatchedMetrics(metrics, matchedMetrics)
@@ -1869,5 +1869,209 @@ var (
 					build(),
 			},
 		},
+		// DATA POINT CONDITIONS
+		{
+			name: "metric_add_label_bucketing_with_datapoint_condition",
+			transforms: []internalTransform{
+				{
+					MetricIncludeFilter: internalFilterStrict{include: "metric1"},
+					Action:              Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action:   AddLabel,
+								NewLabel: "latency_range",
+								NewValue: "0-100",
+							},
+							condition: &internalDataPointCondition{valueLabel: "latency", maxValue: float64Ptr(100)},
+						},
+						{
+							configOperation: Operation{
+								Action:   AddLabel,
+								NewLabel: "latency_range",
+								NewValue: "100+",
+							},
+							condition: &internalDataPointCondition{valueLabel: "latency", minValue: float64Ptr(100)},
+						},
+					},
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setLabels([]string{"latency"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, []string{"30"}).addInt64Point(0, 1, 2).
+					addTimeseries(1, []string{"250"}).addInt64Point(1, 2, 2).
+					build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setLabels([]string{"latency", "latency_range"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, []string{"30", "0-100"}).addInt64Point(0, 1, 2).
+					addTimeseries(1, []string{"250", "100+"}).addInt64Point(1, 2, 2).
+					build(),
+			},
+		},
+		{
+			name: "metric_update_label_value_with_datapoint_condition",
+			transforms: []internalTransform{
+				{
+					MetricIncludeFilter: internalFilterStrict{include: "metric1"},
+					Action:              Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action: UpdateLabel,
+								Label:  "label1",
+							},
+							valueActionsMapping: map[string]string{"value1": "slow"},
+							condition:           &internalDataPointCondition{minValue: float64Ptr(10)},
+						},
+					},
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setLabels([]string{"label1", "label2"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_DOUBLE).
+					addTimeseries(1, []string{"value1", "a"}).addDoublePoint(0, 5, 2).
+					addTimeseries(1, []string{"value1", "b"}).addDoublePoint(1, 20, 2).
+					build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setLabels([]string{"label1", "label2"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_DOUBLE).
+					addTimeseries(1, []string{"value1", "a"}).addDoublePoint(0, 5, 2).
+					addTimeseries(1, []string{"slow", "b"}).addDoublePoint(1, 20, 2).
+					build(),
+			},
+		},
+		{
+			name: "metric_scale_value_with_datapoint_condition",
+			transforms: []internalTransform{
+				{
+					MetricIncludeFilter: internalFilterStrict{include: "metric1"},
+					Action:              Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action: ScaleValue,
+								Scale:  10,
+							},
+							condition: &internalDataPointCondition{matchLabels: map[string]*regexp.Regexp{"unit": regexp.MustCompile("^s$")}},
+						},
+					},
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setLabels([]string{"unit"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, []string{"s"}).addInt64Point(0, 3, 2).
+					addTimeseries(1, []string{"ds"}).addInt64Point(1, 3, 2).
+					build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setLabels([]string{"unit"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, []string{"s"}).addInt64Point(0, 30, 2).
+					addTimeseries(1, []string{"ds"}).addInt64Point(1, 3, 2).
+					build(),
+			},
+		},
+		{
+			name: "metric_name_update_with_match_datapoints",
+			transforms: []internalTransform{
+				{
+					MetricIncludeFilter: internalFilterStrict{
+						include:         "metric1",
+						matchDataPoints: &internalDataPointCondition{matchLabels: map[string]*regexp.Regexp{"code": regexp.MustCompile("^5..$")}},
+					},
+					Action:  Update,
+					NewName: "metric1_errors",
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setLabels([]string{"code"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, []string{"200"}).addInt64Point(0, 3, 2).
+					addTimeseries(1, []string{"503"}).addInt64Point(1, 1, 2).
+					build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setLabels([]string{"code"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, []string{"200"}).addInt64Point(0, 3, 2).
+					build(),
+				metricBuilder().setName("metric1_errors").setLabels([]string{"code"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, []string{"503"}).addInt64Point(0, 1, 2).
+					build(),
+			},
+		},
+		{
+			name: "metric_add_label_with_match_datapoints_partial_match",
+			transforms: []internalTransform{
+				{
+					MetricIncludeFilter: internalFilterStrict{
+						include:         "metric1",
+						matchDataPoints: &internalDataPointCondition{matchLabels: map[string]*regexp.Regexp{"code": regexp.MustCompile("^5..$")}},
+					},
+					Action: Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action:   AddLabel,
+								NewLabel: "error",
+								NewValue: "true",
+							},
+						},
+					},
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setLabels([]string{"code"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, []string{"200"}).addInt64Point(0, 3, 2).
+					addTimeseries(1, []string{"503"}).addInt64Point(1, 1, 2).
+					build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("metric1").setLabels([]string{"code"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, []string{"200"}).addInt64Point(0, 3, 2).
+					build(),
+				metricBuilder().setName("metric1").setLabels([]string{"code", "error"}).
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, []string{"503", "true"}).addInt64Point(0, 1, 2).
+					build(),
+			},
+		},
+		{
+			name: "metric_name_update_with_match_datapoints_all_matching",
+			transforms: []internalTransform{
+				{
+					MetricIncludeFilter: internalFilterStrict{
+						include:         "metric1",
+						matchDataPoints: &internalDataPointCondition{minValue: float64Ptr(0)},
+					},
+					Action:  Update,
+					NewName: "new/metric1",
+				},
+			},
+			in: []*metricspb.Metric{
+				metricBuilder().setName("metric1").
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, nil).addInt64Point(0, 3, 2).
+					build(),
+			},
+			out: []*metricspb.Metric{
+				metricBuilder().setName("new/metric1").
+					setDataType(metricspb.MetricDescriptor_GAUGE_INT64).
+					addTimeseries(1, nil).addInt64Point(0, 3, 2).
+					build(),
+			},
+		},
 	}
 )
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
@@ -17,6 +17,11 @@ package metricstransformprocessor
 import metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 
 func (mtp *metricsTransformProcessor) addLabelOp(metric *metricspb.Metric, op internalOperation) {
+	if op.condition != nil {
+		mtp.addLabelToMatchedOp(metric, op)
+		return
+	}
+
 	var lb = metricspb.LabelKey{
 		Key: op.configOperation.NewLabel,
 	}
@@ -29,3 +34,33 @@ func (mtp *metricsTransformProcessor) addLabelOp(metric *metricspb.Metric, op in
 		ts.LabelValues = append(ts.LabelValues, lv)
 	}
 }
+
+// addLabelToMatchedOp sets the label on the data points matching the operation's condition. The label
+// is added to the metric if it doesn't exist yet, without a value on the other data points.
+func (mtp *metricsTransformProcessor) addLabelToMatchedOp(metric *metricspb.Metric, op internalOperation) {
+	matched := op.matchedTimeseries(metric)
+
+	labelIdx := -1
+	for idx, label := range metric.MetricDescriptor.LabelKeys {
+		if label.Key == op.configOperation.NewLabel {
+			labelIdx = idx
+			break
+		}
+	}
+	if labelIdx < 0 {
+		metric.MetricDescriptor.LabelKeys = append(metric.MetricDescriptor.LabelKeys, &metricspb.LabelKey{Key: op.configOperation.NewLabel})
+		for _, ts := range metric.Timeseries {
+			ts.LabelValues = append(ts.LabelValues, &metricspb.LabelValue{})
+		}
+		labelIdx = len(metric.MetricDescriptor.LabelKeys) - 1
+	}
+
+	for i, ts := range metric.Timeseries {
+		if matched[i] {
+			ts.LabelValues[labelIdx] = &metricspb.LabelValue{
+				Value:    op.configOperation.NewValue,
+				HasValue: true,
+			}
+		}
+	}
+}
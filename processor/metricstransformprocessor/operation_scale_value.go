@@ -17,7 +17,11 @@ package metricstransformprocessor
 import metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 
 func (mtp *metricsTransformProcessor) scaleValueOp(metric *metricspb.Metric, op internalOperation) {
-	for _, ts := range metric.Timeseries {
+	matched := op.matchedTimeseries(metric)
+	for i, ts := range metric.Timeseries {
+		if !matched[i] {
+			continue
+		}
 		for _, dp := range ts.Points {
 			switch metric.MetricDescriptor.Type {
 			case metricspb.MetricDescriptor_GAUGE_INT64, metricspb.MetricDescriptor_CUMULATIVE_INT64:
@@ -26,12 +26,18 @@ func (mtp *metricsTransformProcessor) updateLabelOp(metric *metricspb.Metric, mt
 			continue
 		}
 
+		// The condition is evaluated before the label is renamed.
+		matched := mtpOp.matchedTimeseries(metric)
+
 		if op.NewLabel != "" {
 			label.Key = op.NewLabel
 		}
 
 		labelValuesMapping := mtpOp.valueActionsMapping
-		for _, timeseries := range metric.Timeseries {
+		for i, timeseries := range metric.Timeseries {
+			if !matched[i] {
+				continue
+			}
 			newValue, ok := labelValuesMapping[timeseries.LabelValues[idx].Value]
 			if ok {
 				timeseries.LabelValues[idx].Value = newValue
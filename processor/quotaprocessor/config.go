@@ -0,0 +1,101 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quotaprocessor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	// overflowRefuse refuses the whole request when any of its tenants is out of quota.
+	overflowRefuse = "refuse"
+	// overflowDrop drops the data of the tenants out of quota and lets the rest through.
+	overflowDrop = "drop"
+)
+
+// Config defines the configuration options for the quota processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// TenantAttribute is the resource attribute identifying the tenant the data belongs to.
+	// When empty, all the data of the pipeline shares the Default quota.
+	TenantAttribute string `mapstructure:"tenant_attribute"`
+
+	// Period is the duration of the window the quotas are enforced over. Default is 1s.
+	Period time.Duration `mapstructure:"period"`
+
+	// Default is the quota of each tenant without an entry in Tenants.
+	Default Quota `mapstructure:"default"`
+
+	// Tenants overrides the default quota of particular tenants, keyed by the tenant attribute value.
+	Tenants map[string]Quota `mapstructure:"tenants"`
+
+	// Overflow defines what happens to data over quota, either "refuse" (the default) or "drop".
+	Overflow string `mapstructure:"overflow"`
+}
+
+// Quota is the budget of a tenant per period. Zero values mean unlimited.
+type Quota struct {
+	// MaxItems is the maximum number of spans, metric data points or log records.
+	MaxItems int64 `mapstructure:"max_items"`
+	// MaxBytes is the maximum size of the data, as encoded in OTLP protobuf.
+	MaxBytes int64 `mapstructure:"max_bytes"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if err := cfg.ProcessorSettings.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.Period <= 0 {
+		return errors.New("\"period\" must be positive")
+	}
+
+	if cfg.Overflow != overflowRefuse && cfg.Overflow != overflowDrop {
+		return fmt.Errorf("\"overflow\" must be one of %q or %q, got %q", overflowRefuse, overflowDrop, cfg.Overflow)
+	}
+
+	if err := cfg.Default.validate(); err != nil {
+		return fmt.Errorf("default quota: %w", err)
+	}
+
+	if len(cfg.Tenants) > 0 && cfg.TenantAttribute == "" {
+		return errors.New("\"tenants\" requires \"tenant_attribute\" to be specified")
+	}
+
+	for tenant, quota := range cfg.Tenants {
+		if err := quota.validate(); err != nil {
+			return fmt.Errorf("quota of tenant %q: %w", tenant, err)
+		}
+	}
+	return nil
+}
+
+func (q Quota) validate() error {
+	if q.MaxItems < 0 {
+		return errors.New("\"max_items\" must not be negative")
+	}
+	if q.MaxBytes < 0 {
+		return errors.New("\"max_bytes\" must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quotaprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Len(t, cfg.Processors, 2)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Processors[config.NewID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "tenants")),
+		TenantAttribute:   "tenant.id",
+		Period:            time.Minute,
+		Default:           Quota{MaxItems: 10000, MaxBytes: 1048576},
+		Tenants: map[string]Quota{
+			"big-tenant": {MaxItems: 100000},
+			"internal":   {},
+		},
+		Overflow: "drop",
+	}, cfg.Processors[config.NewIDWithName(typeStr, "tenants")])
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name: "valid tenants",
+			modify: func(cfg *Config) {
+				cfg.TenantAttribute = "tenant.id"
+				cfg.Tenants = map[string]Quota{"tenant-a": {MaxBytes: 1024}}
+			},
+		},
+		{
+			name:   "non positive period",
+			modify: func(cfg *Config) { cfg.Period = 0 },
+			err:    "\"period\" must be positive",
+		},
+		{
+			name:   "invalid overflow",
+			modify: func(cfg *Config) { cfg.Overflow = "block" },
+			err:    "\"overflow\" must be one of \"refuse\" or \"drop\", got \"block\"",
+		},
+		{
+			name:   "negative default items",
+			modify: func(cfg *Config) { cfg.Default.MaxItems = -1 },
+			err:    "default quota: \"max_items\" must not be negative",
+		},
+		{
+			name:   "tenants without attribute",
+			modify: func(cfg *Config) { cfg.Tenants = map[string]Quota{"tenant-a": {MaxItems: 1}} },
+			err:    "\"tenants\" requires \"tenant_attribute\" to be specified",
+		},
+		{
+			name: "negative tenant bytes",
+			modify: func(cfg *Config) {
+				cfg.TenantAttribute = "tenant.id"
+				cfg.Tenants = map[string]Quota{"tenant-a": {MaxBytes: -1}}
+			},
+			err: "quota of tenant \"tenant-a\": \"max_bytes\" must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
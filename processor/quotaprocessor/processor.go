@@ -0,0 +1,215 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quotaprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+)
+
+var errQuotaExceeded = errors.New("quota exceeded")
+
+// quotaProcessor enforces the item and byte quotas of the tenants, identified by a
+// resource attribute, over fixed periods. The data of each resource is admitted or
+// not as a whole.
+type quotaProcessor struct {
+	logger *zap.Logger
+	config *Config
+	quotas *quotas
+
+	tracesMarshaler  pdata.TracesMarshaler
+	metricsMarshaler pdata.MetricsMarshaler
+	logsMarshaler    pdata.LogsMarshaler
+}
+
+func newQuotaProcessor(logger *zap.Logger, cfg *Config) *quotaProcessor {
+	return &quotaProcessor{
+		logger:           logger,
+		config:           cfg,
+		quotas:           newQuotas(cfg),
+		tracesMarshaler:  otlp.NewProtobufTracesMarshaler(),
+		metricsMarshaler: otlp.NewProtobufMetricsMarshaler(),
+		logsMarshaler:    otlp.NewProtobufLogsMarshaler(),
+	}
+}
+
+func (qp *quotaProcessor) processTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	rss := td.ResourceSpans()
+	demands := make([]demand, rss.Len())
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		tenant := qp.tenant(rs.Resource())
+		if !qp.isLimited(tenant) {
+			demands[i] = demand{tenant: tenant}
+			continue
+		}
+		single := pdata.NewTraces()
+		rs.CopyTo(single.ResourceSpans().AppendEmpty())
+		d, err := qp.demand(tenant, single.SpanCount(), func() ([]byte, error) { return qp.tracesMarshaler.MarshalTraces(single) })
+		if err != nil {
+			return td, err
+		}
+		demands[i] = d
+	}
+
+	admitted, err := qp.admit(demands)
+	if err != nil || admitted == nil {
+		return td, err
+	}
+	out := pdata.NewTraces()
+	for i, ok := range admitted {
+		if ok {
+			rss.At(i).CopyTo(out.ResourceSpans().AppendEmpty())
+		}
+	}
+	if out.ResourceSpans().Len() == 0 {
+		return out, processorhelper.ErrSkipProcessingData
+	}
+	return out, nil
+}
+
+func (qp *quotaProcessor) processMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	demands := make([]demand, rms.Len())
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		tenant := qp.tenant(rm.Resource())
+		if !qp.isLimited(tenant) {
+			demands[i] = demand{tenant: tenant}
+			continue
+		}
+		single := pdata.NewMetrics()
+		rm.CopyTo(single.ResourceMetrics().AppendEmpty())
+		d, err := qp.demand(tenant, single.DataPointCount(), func() ([]byte, error) { return qp.metricsMarshaler.MarshalMetrics(single) })
+		if err != nil {
+			return md, err
+		}
+		demands[i] = d
+	}
+
+	admitted, err := qp.admit(demands)
+	if err != nil || admitted == nil {
+		return md, err
+	}
+	out := pdata.NewMetrics()
+	for i, ok := range admitted {
+		if ok {
+			rms.At(i).CopyTo(out.ResourceMetrics().AppendEmpty())
+		}
+	}
+	if out.ResourceMetrics().Len() == 0 {
+		return out, processorhelper.ErrSkipProcessingData
+	}
+	return out, nil
+}
+
+func (qp *quotaProcessor) processLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	demands := make([]demand, rls.Len())
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		tenant := qp.tenant(rl.Resource())
+		if !qp.isLimited(tenant) {
+			demands[i] = demand{tenant: tenant}
+			continue
+		}
+		single := pdata.NewLogs()
+		rl.CopyTo(single.ResourceLogs().AppendEmpty())
+		d, err := qp.demand(tenant, single.LogRecordCount(), func() ([]byte, error) { return qp.logsMarshaler.MarshalLogs(single) })
+		if err != nil {
+			return ld, err
+		}
+		demands[i] = d
+	}
+
+	admitted, err := qp.admit(demands)
+	if err != nil || admitted == nil {
+		return ld, err
+	}
+	out := pdata.NewLogs()
+	for i, ok := range admitted {
+		if ok {
+			rls.At(i).CopyTo(out.ResourceLogs().AppendEmpty())
+		}
+	}
+	if out.ResourceLogs().Len() == 0 {
+		return out, processorhelper.ErrSkipProcessingData
+	}
+	return out, nil
+}
+
+// tenant returns the tenant the data of the given resource belongs to.
+func (qp *quotaProcessor) tenant(resource pdata.Resource) string {
+	if qp.config.TenantAttribute == "" {
+		return ""
+	}
+	if attr, ok := resource.Attributes().Get(qp.config.TenantAttribute); ok {
+		return tracetranslator.AttributeValueToString(attr)
+	}
+	return ""
+}
+
+// isLimited returns whether the given tenant has any quota to enforce.
+func (qp *quotaProcessor) isLimited(tenant string) bool {
+	quota := qp.quotas.quota(tenant)
+	return quota.MaxItems > 0 || quota.MaxBytes > 0
+}
+
+// demand returns the demand of the data of a single resource of the given tenant.
+// The data is only encoded when the tenant has a byte quota.
+func (qp *quotaProcessor) demand(tenant string, items int, marshal func() ([]byte, error)) (demand, error) {
+	d := demand{tenant: tenant, items: int64(items)}
+	if qp.quotas.quota(tenant).MaxBytes > 0 {
+		buf, err := marshal()
+		if err != nil {
+			return d, fmt.Errorf("failed to determine the size of the data: %w", err)
+		}
+		d.bytes = int64(len(buf))
+	}
+	return d, nil
+}
+
+// admit returns whether each of the demands is admitted, or nil when all are.
+// With the "refuse" overflow, an error is returned instead when any isn't.
+func (qp *quotaProcessor) admit(demands []demand) ([]bool, error) {
+	refuse := qp.config.Overflow == overflowRefuse
+	admitted := qp.quotas.admit(demands, refuse)
+
+	dropped := 0
+	for i, ok := range admitted {
+		if ok {
+			continue
+		}
+		if refuse {
+			if qp.config.TenantAttribute == "" {
+				return nil, errQuotaExceeded
+			}
+			return nil, fmt.Errorf("%w for tenant %q", errQuotaExceeded, demands[i].tenant)
+		}
+		dropped++
+		qp.logger.Debug("Dropping data over quota", zap.String("tenant", demands[i].tenant))
+	}
+	if dropped == 0 {
+		return nil, nil
+	}
+	return admitted, nil
+}
@@ -0,0 +1,168 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quotaprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+// newTestProcessor returns a processor whose clock is controlled by the returned function.
+func newTestProcessor(cfg *Config) (*quotaProcessor, func(time.Duration)) {
+	qp := newQuotaProcessor(zap.NewNop(), cfg)
+	now := time.Unix(1000, 0)
+	qp.quotas.now = func() time.Time { return now }
+	return qp, func(d time.Duration) { now = now.Add(d) }
+}
+
+func buildTraces(tenant string, spans int) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	if tenant != "" {
+		rs.Resource().Attributes().InsertString("tenant.id", tenant)
+	}
+	ils := rs.InstrumentationLibrarySpans().AppendEmpty()
+	for i := 0; i < spans; i++ {
+		ils.Spans().AppendEmpty().SetName("span")
+	}
+	return td
+}
+
+func appendLogs(ld pdata.Logs, tenant string, records int) {
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("tenant.id", tenant)
+	ill := rl.InstrumentationLibraryLogs().AppendEmpty()
+	for i := 0; i < records; i++ {
+		ill.Logs().AppendEmpty().Body().SetStringVal("log")
+	}
+}
+
+func appendMetrics(md pdata.Metrics, tenant string) {
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("tenant.id", tenant)
+	metric := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("gauge")
+	metric.SetDataType(pdata.MetricDataTypeGauge)
+	metric.Gauge().DataPoints().AppendEmpty().SetIntVal(1)
+}
+
+func TestProcessTracesRefuse(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Default.MaxItems = 3
+	qp, advance := newTestProcessor(cfg)
+
+	td := buildTraces("", 2)
+	out, err := qp.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, td, out)
+
+	_, err = qp.processTraces(context.Background(), buildTraces("", 2))
+	assert.True(t, errors.Is(err, errQuotaExceeded))
+	assert.EqualError(t, err, "quota exceeded")
+
+	// The refused request isn't charged, so the remaining quota can still be used.
+	_, err = qp.processTraces(context.Background(), buildTraces("", 1))
+	require.NoError(t, err)
+
+	advance(cfg.Period)
+	_, err = qp.processTraces(context.Background(), buildTraces("", 3))
+	require.NoError(t, err)
+}
+
+func TestProcessTracesRefuseTenant(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TenantAttribute = "tenant.id"
+	cfg.Default.MaxItems = 1
+	qp, _ := newTestProcessor(cfg)
+
+	_, err := qp.processTraces(context.Background(), buildTraces("tenant-a", 2))
+	assert.True(t, errors.Is(err, errQuotaExceeded))
+	assert.EqualError(t, err, "quota exceeded for tenant \"tenant-a\"")
+
+	_, err = qp.processTraces(context.Background(), buildTraces("tenant-b", 1))
+	require.NoError(t, err)
+}
+
+func TestProcessLogsDrop(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TenantAttribute = "tenant.id"
+	cfg.Default.MaxItems = 2
+	cfg.Tenants = map[string]Quota{"big": {MaxItems: 10}, "unlimited": {}}
+	cfg.Overflow = overflowDrop
+	qp, _ := newTestProcessor(cfg)
+
+	ld := pdata.NewLogs()
+	appendLogs(ld, "tenant-a", 2)
+	appendLogs(ld, "tenant-b", 3)
+	appendLogs(ld, "big", 5)
+	appendLogs(ld, "unlimited", 20)
+	out, err := qp.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	require.Equal(t, 3, out.ResourceLogs().Len())
+	for i, tenant := range []string{"tenant-a", "big", "unlimited"} {
+		attr, ok := out.ResourceLogs().At(i).Resource().Attributes().Get("tenant.id")
+		require.True(t, ok)
+		assert.Equal(t, tenant, attr.StringVal())
+	}
+
+	// The dropped data isn't charged, and one tenant's burst doesn't affect the quota of the others.
+	ld = pdata.NewLogs()
+	appendLogs(ld, "tenant-a", 1)
+	appendLogs(ld, "tenant-b", 2)
+	out, err = qp.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	require.Equal(t, 1, out.ResourceLogs().Len())
+	assert.Equal(t, 2, out.LogRecordCount())
+
+	ld = pdata.NewLogs()
+	appendLogs(ld, "tenant-a", 1)
+	out, err = qp.processLogs(context.Background(), ld)
+	require.Equal(t, processorhelper.ErrSkipProcessingData, err)
+	assert.Equal(t, 0, out.ResourceLogs().Len())
+}
+
+func TestProcessMetricsMaxBytes(t *testing.T) {
+	single := pdata.NewMetrics()
+	appendMetrics(single, "tenant-a")
+	buf, err := newQuotaProcessor(zap.NewNop(), createDefaultConfig().(*Config)).metricsMarshaler.MarshalMetrics(single)
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.TenantAttribute = "tenant.id"
+	cfg.Default.MaxBytes = int64(len(buf))
+	cfg.Overflow = overflowDrop
+	qp, advance := newTestProcessor(cfg)
+
+	md := pdata.NewMetrics()
+	appendMetrics(md, "tenant-a")
+	appendMetrics(md, "tenant-a")
+	out, err := qp.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, 1, out.ResourceMetrics().Len())
+	assert.Equal(t, 1, out.DataPointCount())
+
+	advance(cfg.Period)
+	out, err = qp.processMetrics(context.Background(), single)
+	require.NoError(t, err)
+	assert.Equal(t, single, out)
+}
@@ -0,0 +1,97 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quotaprocessor
+
+import (
+	"sync"
+	"time"
+)
+
+// demand is what the data of a single resource would use of its tenant's quota.
+type demand struct {
+	tenant string
+	items  int64
+	bytes  int64
+}
+
+type usage struct {
+	items int64
+	bytes int64
+}
+
+// quotas keeps track of the usage of each tenant over the current period.
+type quotas struct {
+	lock   sync.Mutex
+	config *Config
+	now    func() time.Time
+
+	periodStart time.Time
+	used        map[string]usage
+}
+
+func newQuotas(cfg *Config) *quotas {
+	return &quotas{
+		config: cfg,
+		now:    time.Now,
+		used:   make(map[string]usage),
+	}
+}
+
+// quota returns the quota of the given tenant.
+func (q *quotas) quota(tenant string) Quota {
+	if quota, ok := q.config.Tenants[tenant]; ok {
+		return quota
+	}
+	return q.config.Default
+}
+
+// admit returns whether each of the demands fits in the remaining quota of its
+// tenant, in order, and charges the admitted ones to the usage of the period.
+// When allOrNothing is set, nothing is charged unless all the demands fit.
+func (q *quotas) admit(demands []demand, allOrNothing bool) []bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if now := q.now(); now.Sub(q.periodStart) >= q.config.Period {
+		q.periodStart = now
+		q.used = make(map[string]usage)
+	}
+
+	admitted := make([]bool, len(demands))
+	pending := make(map[string]usage)
+	for i, d := range demands {
+		used, ok := pending[d.tenant]
+		if !ok {
+			used = q.used[d.tenant]
+		}
+		used.items += d.items
+		used.bytes += d.bytes
+
+		quota := q.quota(d.tenant)
+		if (quota.MaxItems > 0 && used.items > quota.MaxItems) || (quota.MaxBytes > 0 && used.bytes > quota.MaxBytes) {
+			if allOrNothing {
+				return admitted
+			}
+			continue
+		}
+		admitted[i] = true
+		pending[d.tenant] = used
+	}
+
+	for tenant, used := range pending {
+		q.used[tenant] = used
+	}
+	return admitted
+}
@@ -0,0 +1,92 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines the configuration options for the servicegraph processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MetricsExporter is the name of the metrics exporter the service graph metrics are sent to.
+	MetricsExporter string `mapstructure:"metrics_exporter"`
+
+	// LatencyHistogramBuckets is the list of durations representing the request duration histogram buckets.
+	// See defaultLatencyHistogramBuckets in processor.go for the default value.
+	LatencyHistogramBuckets []time.Duration `mapstructure:"latency_histogram_buckets"`
+
+	// Dimensions defines the list of additional span attributes added as labels to the
+	// metrics. Each attribute is looked up on the server span first and on the client
+	// span second.
+	Dimensions []string `mapstructure:"dimensions"`
+
+	// Store defines how long and how many unpaired spans are kept while waiting for the
+	// other side of a request.
+	Store StoreConfig `mapstructure:"store"`
+}
+
+// StoreConfig defines the configuration of the store of incomplete edges.
+type StoreConfig struct {
+	// MaxItems is the maximum number of incomplete edges kept in the store. Spans
+	// that would create a new edge when the store is full are dropped.
+	MaxItems int `mapstructure:"max_items"`
+
+	// TTL is how long an incomplete edge is kept before it is dropped.
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if err := cfg.ProcessorSettings.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.MetricsExporter == "" {
+		return errors.New("\"metrics_exporter\" must be specified")
+	}
+
+	for i, bucket := range cfg.LatencyHistogramBuckets {
+		if bucket <= 0 {
+			return fmt.Errorf("\"latency_histogram_buckets\" must be positive, got %v", bucket)
+		}
+		if i > 0 && bucket <= cfg.LatencyHistogramBuckets[i-1] {
+			return errors.New("\"latency_histogram_buckets\" must be in increasing order")
+		}
+	}
+
+	labels := map[string]struct{}{clientKey: {}, serverKey: {}}
+	for _, dim := range cfg.Dimensions {
+		if _, ok := labels[dim]; ok {
+			return fmt.Errorf("duplicate dimension name %q", dim)
+		}
+		labels[dim] = struct{}{}
+	}
+
+	if cfg.Store.MaxItems <= 0 {
+		return errors.New("\"store.max_items\" must be positive")
+	}
+	if cfg.Store.TTL <= 0 {
+		return errors.New("\"store.ttl\" must be positive")
+	}
+	return nil
+}
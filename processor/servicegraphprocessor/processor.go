@@ -0,0 +1,340 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	clientKey          = "client"
+	serverKey          = "server"
+	metricKeySeparator = string(byte(0))
+)
+
+var defaultLatencyHistogramBuckets = []time.Duration{
+	100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond,
+	1600 * time.Millisecond, 3200 * time.Millisecond, 6400 * time.Millisecond, 12800 * time.Millisecond,
+}
+
+type metricKey string
+
+// processorImp pairs the client and server spans of requests between services and
+// sends request, error and duration metrics of each pair of services to the configured
+// metrics exporter. The spans are forwarded to the next consumer unmodified.
+type processorImp struct {
+	lock   sync.Mutex
+	logger *zap.Logger
+	config Config
+
+	metricsExporter consumer.Metrics
+	nextConsumer    consumer.Traces
+
+	store *store
+
+	// The starting time of the data points.
+	startTime time.Time
+
+	// Request & failed request counts.
+	requestCount       map[metricKey]int64
+	failedRequestCount map[metricKey]int64
+
+	// Request duration histograms in seconds, as seen by the server and by the client.
+	latencyBounds             []float64
+	serverLatencyCount        map[metricKey]uint64
+	serverLatencySum          map[metricKey]float64
+	serverLatencyBucketCounts map[metricKey][]uint64
+	clientLatencyCount        map[metricKey]uint64
+	clientLatencySum          map[metricKey]float64
+	clientLatencyBucketCounts map[metricKey][]uint64
+
+	// A cache of the labels of each metric key, built on the first request of the key.
+	metricKeyToLabels map[metricKey]map[string]string
+}
+
+func newProcessor(logger *zap.Logger, cfg *Config, nextConsumer consumer.Traces) *processorImp {
+	buckets := cfg.LatencyHistogramBuckets
+	if buckets == nil {
+		buckets = defaultLatencyHistogramBuckets
+	}
+	bounds := make([]float64, len(buckets))
+	for i, bucket := range buckets {
+		bounds[i] = bucket.Seconds()
+	}
+
+	return &processorImp{
+		logger:                    logger,
+		config:                    *cfg,
+		nextConsumer:              nextConsumer,
+		store:                     newStore(cfg.Store.TTL, cfg.Store.MaxItems),
+		startTime:                 time.Now(),
+		requestCount:              make(map[metricKey]int64),
+		failedRequestCount:        make(map[metricKey]int64),
+		latencyBounds:             bounds,
+		serverLatencyCount:        make(map[metricKey]uint64),
+		serverLatencySum:          make(map[metricKey]float64),
+		serverLatencyBucketCounts: make(map[metricKey][]uint64),
+		clientLatencyCount:        make(map[metricKey]uint64),
+		clientLatencySum:          make(map[metricKey]float64),
+		clientLatencyBucketCounts: make(map[metricKey][]uint64),
+		metricKeyToLabels:         make(map[metricKey]map[string]string),
+	}
+}
+
+// Start implements the component.Component interface.
+func (p *processorImp) Start(_ context.Context, host component.Host) error {
+	var availableMetricsExporters []string
+
+	// The available list of exporters come from any configured metrics pipelines' exporters.
+	for k, exp := range host.GetExporters()[config.MetricsDataType] {
+		metricsExp, ok := exp.(component.MetricsExporter)
+		if !ok {
+			return fmt.Errorf("the exporter %q isn't a metrics exporter", k.String())
+		}
+		availableMetricsExporters = append(availableMetricsExporters, k.String())
+
+		if k.String() == p.config.MetricsExporter {
+			p.metricsExporter = metricsExp
+			p.logger.Info("Found exporter", zap.String("metrics-exporter", p.config.MetricsExporter))
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to find metrics exporter: '%s'; please configure metrics_exporter from one of: %+v",
+		p.config.MetricsExporter, availableMetricsExporters)
+}
+
+// Shutdown implements the component.Component interface.
+func (p *processorImp) Shutdown(context.Context) error {
+	return nil
+}
+
+// Capabilities implements the consumer interface.
+func (p *processorImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces pairs the client and server spans, sends the service graph metrics to
+// the metrics exporter and forwards the spans to the next consumer.
+func (p *processorImp) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	p.lock.Lock()
+	now := time.Now()
+	if expired := p.store.expire(now); expired > 0 {
+		p.logger.Debug("Dropped expired unpaired spans", zap.Int("count", expired))
+	}
+	p.aggregateMetrics(td, now)
+	p.lock.Unlock()
+
+	// Firstly, export metrics to avoid being impacted by downstream trace processor errors/latency.
+	if err := p.exportMetrics(ctx); err != nil {
+		return err
+	}
+	return p.nextConsumer.ConsumeTraces(ctx, td)
+}
+
+func (p *processorImp) aggregateMetrics(td pdata.Traces, now time.Time) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		attr, ok := rs.Resource().Attributes().Get(conventions.AttributeServiceName)
+		if !ok {
+			continue
+		}
+		serviceName := attr.StringVal()
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				p.aggregateSpan(serviceName, spans.At(k), now)
+			}
+		}
+	}
+}
+
+// aggregateSpan adds a client or server span to its edge, and records the metrics of
+// the edge if the span completes it. Spans of other kinds are ignored.
+func (p *processorImp) aggregateSpan(serviceName string, span pdata.Span, now time.Time) {
+	var key edgeKey
+	var update func(e *edge)
+	s := p.newSide(serviceName, span)
+	switch span.Kind() {
+	case pdata.SpanKindClient:
+		key = edgeKey{traceID: span.TraceID(), spanID: span.SpanID()}
+		update = func(e *edge) { e.client = s }
+	case pdata.SpanKindServer:
+		if span.ParentSpanID().IsEmpty() {
+			return
+		}
+		key = edgeKey{traceID: span.TraceID(), spanID: span.ParentSpanID()}
+		update = func(e *edge) { e.server = s }
+	default:
+		return
+	}
+
+	e, err := p.store.upsert(key, update, now)
+	if err != nil {
+		p.logger.Debug("Dropped unpaired span", zap.Error(err))
+		return
+	}
+	if e != nil {
+		p.updateMetrics(e)
+	}
+}
+
+func (p *processorImp) newSide(serviceName string, span pdata.Span) *side {
+	s := &side{
+		service: serviceName,
+		latency: time.Duration(span.EndTimestamp() - span.StartTimestamp()),
+		failed:  span.Status().Code() == pdata.StatusCodeError,
+	}
+	if len(p.config.Dimensions) > 0 {
+		s.dimensions = make(map[string]string, len(p.config.Dimensions))
+		attrs := span.Attributes()
+		for _, dim := range p.config.Dimensions {
+			if attr, ok := attrs.Get(dim); ok {
+				s.dimensions[dim] = tracetranslator.AttributeValueToString(attr)
+			}
+		}
+	}
+	return s
+}
+
+// updateMetrics records a completed request between two services.
+func (p *processorImp) updateMetrics(e *edge) {
+	labels := map[string]string{
+		clientKey: e.client.service,
+		serverKey: e.server.service,
+	}
+	var keyBuilder strings.Builder
+	keyBuilder.WriteString(e.client.service)
+	keyBuilder.WriteString(metricKeySeparator)
+	keyBuilder.WriteString(e.server.service)
+	for _, dim := range p.config.Dimensions {
+		value, ok := e.server.dimensions[dim]
+		if !ok {
+			value, ok = e.client.dimensions[dim]
+		}
+		if ok {
+			labels[dim] = value
+		}
+		keyBuilder.WriteString(metricKeySeparator)
+		keyBuilder.WriteString(value)
+	}
+
+	key := metricKey(keyBuilder.String())
+	if _, ok := p.metricKeyToLabels[key]; !ok {
+		p.metricKeyToLabels[key] = labels
+	}
+
+	p.requestCount[key]++
+	if e.client.failed || e.server.failed {
+		p.failedRequestCount[key]++
+	}
+	p.updateLatency(p.serverLatencyCount, p.serverLatencySum, p.serverLatencyBucketCounts, key, e.server.latency)
+	p.updateLatency(p.clientLatencyCount, p.clientLatencySum, p.clientLatencyBucketCounts, key, e.client.latency)
+}
+
+func (p *processorImp) updateLatency(count map[metricKey]uint64, sum map[metricKey]float64, bucketCounts map[metricKey][]uint64, key metricKey, latency time.Duration) {
+	if _, ok := bucketCounts[key]; !ok {
+		// The last bucket counts the requests above the highest bound.
+		bucketCounts[key] = make([]uint64, len(p.latencyBounds)+1)
+	}
+	seconds := latency.Seconds()
+	count[key]++
+	sum[key] += seconds
+	bucketCounts[key][sort.SearchFloat64s(p.latencyBounds, seconds)]++
+}
+
+// exportMetrics sends the cumulative service graph metrics to the metrics exporter.
+func (p *processorImp) exportMetrics(ctx context.Context) error {
+	md := pdata.NewMetrics()
+	ilm := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName("servicegraphprocessor")
+
+	p.lock.Lock()
+	if len(p.requestCount) == 0 {
+		p.lock.Unlock()
+		return nil
+	}
+	now := time.Now()
+	p.collectCountMetric(ilm.Metrics(), "traces_service_graph_request_total", "The number of requests between two services.", p.requestCount, now)
+	p.collectCountMetric(ilm.Metrics(), "traces_service_graph_request_failed_total", "The number of failed requests between two services.", p.failedRequestCount, now)
+	p.collectLatencyMetric(ilm.Metrics(), "traces_service_graph_request_server_seconds", "The duration of requests between two services as seen by the server.",
+		p.serverLatencyCount, p.serverLatencySum, p.serverLatencyBucketCounts, now)
+	p.collectLatencyMetric(ilm.Metrics(), "traces_service_graph_request_client_seconds", "The duration of requests between two services as seen by the client.",
+		p.clientLatencyCount, p.clientLatencySum, p.clientLatencyBucketCounts, now)
+	p.lock.Unlock()
+
+	return p.metricsExporter.ConsumeMetrics(ctx, md)
+}
+
+func (p *processorImp) collectCountMetric(metrics pdata.MetricSlice, name, description string, counts map[metricKey]int64, now time.Time) {
+	if len(counts) == 0 {
+		return
+	}
+
+	m := metrics.AppendEmpty()
+	m.SetName(name)
+	m.SetDescription(description)
+	m.SetUnit("1")
+	m.SetDataType(pdata.MetricDataTypeSum)
+	m.Sum().SetIsMonotonic(true)
+	m.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+	dps := m.Sum().DataPoints()
+	for key, count := range counts {
+		dp := dps.AppendEmpty()
+		dp.SetStartTimestamp(pdata.TimestampFromTime(p.startTime))
+		dp.SetTimestamp(pdata.TimestampFromTime(now))
+		dp.SetIntVal(count)
+		dp.LabelsMap().InitFromMap(p.metricKeyToLabels[key])
+	}
+}
+
+func (p *processorImp) collectLatencyMetric(metrics pdata.MetricSlice, name, description string,
+	count map[metricKey]uint64, sum map[metricKey]float64, bucketCounts map[metricKey][]uint64, now time.Time) {
+	m := metrics.AppendEmpty()
+	m.SetName(name)
+	m.SetDescription(description)
+	m.SetUnit("s")
+	m.SetDataType(pdata.MetricDataTypeHistogram)
+	m.Histogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+	dps := m.Histogram().DataPoints()
+	for key := range count {
+		dp := dps.AppendEmpty()
+		dp.SetStartTimestamp(pdata.TimestampFromTime(p.startTime))
+		dp.SetTimestamp(pdata.TimestampFromTime(now))
+		dp.SetExplicitBounds(p.latencyBounds)
+		// Copy the bucket counts, as they keep changing after the metric is exported.
+		dp.SetBucketCounts(append([]uint64(nil), bucketCounts[key]...))
+		dp.SetCount(count[key])
+		dp.SetSum(sum[key])
+		dp.LabelsMap().InitFromMap(p.metricKeyToLabels[key])
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// exportersHost is a host with the given exporters.
+type exportersHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *exportersHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
+func TestProcessorStart(t *testing.T) {
+	exporterFactory := componenttest.NewNopExporterFactory()
+	exp, err := exporterFactory.CreateMetricsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(),
+		exporterFactory.CreateDefaultConfig())
+	require.NoError(t, err)
+	host := &exportersHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.MetricsDataType: {config.NewID("nop"): exp},
+		},
+	}
+
+	for _, tc := range []struct {
+		name            string
+		metricsExporter string
+		wantErrorMsg    string
+	}{
+		{"export to active metrics exporter", "nop", ""},
+		{"unable to find configured exporter in active exporter list", "prometheus", "failed to find metrics exporter: 'prometheus'; please configure metrics_exporter from one of: [nop]"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.MetricsExporter = tc.metricsExporter
+			p := newProcessor(zap.NewNop(), cfg, consumertest.NewNop())
+
+			err := p.Start(context.Background(), host)
+			if tc.wantErrorMsg != "" {
+				assert.EqualError(t, err, tc.wantErrorMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, exp, p.metricsExporter)
+			}
+		})
+	}
+}
+
+type testSpan struct {
+	kind     pdata.SpanKind
+	spanID   byte
+	parentID byte
+	latency  time.Duration
+	failed   bool
+	method   string
+}
+
+func appendSpans(td pdata.Traces, serviceName string, spans ...testSpan) {
+	rs := td.ResourceSpans().AppendEmpty()
+	if serviceName != "" {
+		rs.Resource().Attributes().InsertString("service.name", serviceName)
+	}
+	ss := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	start := time.Now()
+	for _, s := range spans {
+		span := ss.AppendEmpty()
+		span.SetKind(s.kind)
+		span.SetTraceID(pdata.NewTraceID([16]byte{1}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{s.spanID}))
+		if s.parentID != 0 {
+			span.SetParentSpanID(pdata.NewSpanID([8]byte{s.parentID}))
+		}
+		span.SetStartTimestamp(pdata.TimestampFromTime(start))
+		span.SetEndTimestamp(pdata.TimestampFromTime(start.Add(s.latency)))
+		if s.failed {
+			span.Status().SetCode(pdata.StatusCodeError)
+		}
+		if s.method != "" {
+			span.Attributes().InsertString("http.method", s.method)
+		}
+	}
+}
+
+func findMetric(t *testing.T, md pdata.Metrics, name string) pdata.Metric {
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	ilm := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0)
+	assert.Equal(t, "servicegraphprocessor", ilm.InstrumentationLibrary().Name())
+
+	for i := 0; i < ilm.Metrics().Len(); i++ {
+		if m := ilm.Metrics().At(i); m.Name() == name {
+			return m
+		}
+	}
+	t.Fatalf("metric %q not found", name)
+	return pdata.Metric{}
+}
+
+func labelsOf(sm pdata.StringMap) map[string]string {
+	labels := make(map[string]string)
+	sm.Range(func(k string, v string) bool {
+		labels[k] = v
+		return true
+	})
+	return labels
+}
+
+func TestConsumeTraces(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.LatencyHistogramBuckets = []time.Duration{100 * time.Millisecond, time.Second}
+	cfg.Dimensions = []string{"http.method"}
+	next := new(consumertest.TracesSink)
+	p := newProcessor(zap.NewNop(), cfg, next)
+	exporter := new(consumertest.MetricsSink)
+	p.metricsExporter = exporter
+
+	// The client spans of both requests arrive first. Internal spans and spans without
+	// a service name are ignored.
+	td := pdata.NewTraces()
+	appendSpans(td, "frontend",
+		testSpan{kind: pdata.SpanKindClient, spanID: 1, latency: 500 * time.Millisecond, method: "GET"},
+		testSpan{kind: pdata.SpanKindClient, spanID: 2, latency: 2 * time.Second, method: "GET"},
+		testSpan{kind: pdata.SpanKindInternal, spanID: 3},
+	)
+	appendSpans(td, "", testSpan{kind: pdata.SpanKindServer, spanID: 4, parentID: 3})
+	require.NoError(t, p.ConsumeTraces(context.Background(), td))
+	assert.Empty(t, exporter.AllMetrics())
+	assert.Equal(t, 2, p.store.len())
+
+	td = pdata.NewTraces()
+	appendSpans(td, "checkout",
+		testSpan{kind: pdata.SpanKindServer, spanID: 5, parentID: 1, latency: 50 * time.Millisecond},
+		testSpan{kind: pdata.SpanKindServer, spanID: 6, parentID: 2, latency: 1500 * time.Millisecond, failed: true},
+	)
+	require.NoError(t, p.ConsumeTraces(context.Background(), td))
+	assert.Len(t, next.AllTraces(), 2)
+	assert.Equal(t, 0, p.store.len())
+
+	require.Len(t, exporter.AllMetrics(), 1)
+	md := exporter.AllMetrics()[0]
+	labels := map[string]string{"client": "frontend", "server": "checkout", "http.method": "GET"}
+
+	requests := findMetric(t, md, "traces_service_graph_request_total")
+	require.Equal(t, pdata.MetricDataTypeSum, requests.DataType())
+	assert.True(t, requests.Sum().IsMonotonic())
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, requests.Sum().AggregationTemporality())
+	require.Equal(t, 1, requests.Sum().DataPoints().Len())
+	assert.Equal(t, int64(2), requests.Sum().DataPoints().At(0).IntVal())
+	assert.Equal(t, labels, labelsOf(requests.Sum().DataPoints().At(0).LabelsMap()))
+
+	failed := findMetric(t, md, "traces_service_graph_request_failed_total")
+	require.Equal(t, 1, failed.Sum().DataPoints().Len())
+	assert.Equal(t, int64(1), failed.Sum().DataPoints().At(0).IntVal())
+
+	server := findMetric(t, md, "traces_service_graph_request_server_seconds")
+	require.Equal(t, pdata.MetricDataTypeHistogram, server.DataType())
+	require.Equal(t, 1, server.Histogram().DataPoints().Len())
+	dp := server.Histogram().DataPoints().At(0)
+	assert.Equal(t, []float64{0.1, 1}, dp.ExplicitBounds())
+	assert.Equal(t, []uint64{1, 0, 1}, dp.BucketCounts())
+	assert.Equal(t, uint64(2), dp.Count())
+	assert.InDelta(t, 1.55, dp.Sum(), 1e-9)
+	assert.Equal(t, labels, labelsOf(dp.LabelsMap()))
+
+	client := findMetric(t, md, "traces_service_graph_request_client_seconds")
+	require.Equal(t, 1, client.Histogram().DataPoints().Len())
+	assert.Equal(t, []uint64{0, 1, 1}, client.Histogram().DataPoints().At(0).BucketCounts())
+}
+
+func TestConsumeTracesExportError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	next := new(consumertest.TracesSink)
+	p := newProcessor(zap.NewNop(), cfg, next)
+	p.metricsExporter = consumertest.NewErr(errors.New("export failed"))
+
+	td := pdata.NewTraces()
+	appendSpans(td, "frontend", testSpan{kind: pdata.SpanKindClient, spanID: 1})
+	appendSpans(td, "checkout", testSpan{kind: pdata.SpanKindServer, spanID: 2, parentID: 1})
+
+	assert.EqualError(t, p.ConsumeTraces(context.Background(), td), "export failed")
+	assert.Empty(t, next.AllTraces())
+}
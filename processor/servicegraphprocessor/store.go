@@ -0,0 +1,118 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"container/list"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+var errStoreFull = errors.New("store is full")
+
+// edgeKey identifies a request by the trace and the span ID of the client span, which
+// is the parent span ID of the server span.
+type edgeKey struct {
+	traceID pdata.TraceID
+	spanID  pdata.SpanID
+}
+
+// side holds the details of one of the spans of a request.
+type side struct {
+	service    string
+	latency    time.Duration
+	failed     bool
+	dimensions map[string]string
+}
+
+// edge is a request between a client and a server service, completed once both
+// spans have been seen.
+type edge struct {
+	key        edgeKey
+	client     *side
+	server     *side
+	expiration time.Time
+}
+
+func (e *edge) isComplete() bool {
+	return e.client != nil && e.server != nil
+}
+
+// store keeps the incomplete edges until the other span of the request arrives or
+// they expire. It isn't safe for concurrent use.
+type store struct {
+	ttl      time.Duration
+	maxItems int
+
+	// The edges in the order they were created, which is also the order they expire in.
+	edges *list.List
+	index map[edgeKey]*list.Element
+}
+
+func newStore(ttl time.Duration, maxItems int) *store {
+	return &store{
+		ttl:      ttl,
+		maxItems: maxItems,
+		edges:    list.New(),
+		index:    make(map[edgeKey]*list.Element),
+	}
+}
+
+func (s *store) len() int {
+	return s.edges.Len()
+}
+
+// upsert updates the edge with the given key, creating it if necessary. The edge is
+// removed from the store and returned if the update completes it.
+func (s *store) upsert(key edgeKey, update func(e *edge), now time.Time) (*edge, error) {
+	if elem, ok := s.index[key]; ok {
+		e := elem.Value.(*edge)
+		update(e)
+		if e.isComplete() {
+			s.edges.Remove(elem)
+			delete(s.index, key)
+			return e, nil
+		}
+		return nil, nil
+	}
+
+	e := &edge{key: key, expiration: now.Add(s.ttl)}
+	update(e)
+	if e.isComplete() {
+		return e, nil
+	}
+	if s.edges.Len() >= s.maxItems {
+		return nil, errStoreFull
+	}
+	s.index[key] = s.edges.PushBack(e)
+	return nil, nil
+}
+
+// expire removes the edges that expired before now and returns how many were removed.
+func (s *store) expire(now time.Time) int {
+	removed := 0
+	for elem := s.edges.Front(); elem != nil; elem = s.edges.Front() {
+		e := elem.Value.(*edge)
+		if now.Before(e.expiration) {
+			break
+		}
+		s.edges.Remove(elem)
+		delete(s.index, e.key)
+		removed++
+	}
+	return removed
+}
@@ -0,0 +1,93 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func testKey(id byte) edgeKey {
+	return edgeKey{
+		traceID: pdata.NewTraceID([16]byte{id}),
+		spanID:  pdata.NewSpanID([8]byte{id}),
+	}
+}
+
+func TestStoreUpsert(t *testing.T) {
+	s := newStore(time.Second, 10)
+	now := time.Now()
+
+	e, err := s.upsert(testKey(1), func(e *edge) { e.client = &side{service: "frontend"} }, now)
+	require.NoError(t, err)
+	assert.Nil(t, e)
+	assert.Equal(t, 1, s.len())
+
+	// The other side of another request doesn't complete the edge.
+	e, err = s.upsert(testKey(2), func(e *edge) { e.server = &side{service: "checkout"} }, now)
+	require.NoError(t, err)
+	assert.Nil(t, e)
+	assert.Equal(t, 2, s.len())
+
+	e, err = s.upsert(testKey(1), func(e *edge) { e.server = &side{service: "checkout"} }, now)
+	require.NoError(t, err)
+	require.NotNil(t, e)
+	assert.Equal(t, "frontend", e.client.service)
+	assert.Equal(t, "checkout", e.server.service)
+	assert.Equal(t, 1, s.len())
+}
+
+func TestStoreFull(t *testing.T) {
+	s := newStore(time.Second, 1)
+	now := time.Now()
+
+	_, err := s.upsert(testKey(1), func(e *edge) { e.client = &side{} }, now)
+	require.NoError(t, err)
+	_, err = s.upsert(testKey(2), func(e *edge) { e.client = &side{} }, now)
+	assert.Equal(t, errStoreFull, err)
+
+	// Edges already in the store can still be completed.
+	e, err := s.upsert(testKey(1), func(e *edge) { e.server = &side{} }, now)
+	require.NoError(t, err)
+	assert.NotNil(t, e)
+	assert.Equal(t, 0, s.len())
+}
+
+func TestStoreExpire(t *testing.T) {
+	s := newStore(time.Second, 10)
+	now := time.Now()
+
+	_, err := s.upsert(testKey(1), func(e *edge) { e.client = &side{} }, now)
+	require.NoError(t, err)
+	_, err = s.upsert(testKey(2), func(e *edge) { e.client = &side{} }, now.Add(500*time.Millisecond))
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, s.expire(now.Add(999*time.Millisecond)))
+	assert.Equal(t, 1, s.expire(now.Add(time.Second)))
+	assert.Equal(t, 1, s.len())
+
+	// The server span of the expired edge starts a new edge.
+	e, err := s.upsert(testKey(1), func(e *edge) { e.server = &side{} }, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Nil(t, e)
+	assert.Equal(t, 2, s.len())
+
+	assert.Equal(t, 2, s.expire(now.Add(2*time.Second)))
+	assert.Equal(t, 0, s.len())
+}
@@ -37,6 +37,10 @@ type Config struct {
 	// See defaultLatencyHistogramBucketsMs in processor.go for the default value.
 	LatencyHistogramBuckets []time.Duration `mapstructure:"latency_histogram_buckets"`
 
+	// LatencyHistogramUnit is the unit of the latency histogram, either "ms" (milliseconds) or "s" (seconds).
+	// Defaults to "ms" if not set.
+	LatencyHistogramUnit string `mapstructure:"latency_histogram_unit"`
+
 	// Dimensions defines the list of additional dimensions on top of the provided:
 	// - service.name
 	// - operation
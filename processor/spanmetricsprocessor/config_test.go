@@ -38,6 +38,7 @@ func TestLoadConfig(t *testing.T) {
 		configFile                  string
 		wantMetricsExporter         string
 		wantLatencyHistogramBuckets []time.Duration
+		wantLatencyHistogramUnit    string
 		wantDimensions              []Dimension
 	}{
 		{configFile: "config-2-pipelines.yaml", wantMetricsExporter: "prometheus"},
@@ -54,6 +55,7 @@ func TestLoadConfig(t *testing.T) {
 				100 * time.Millisecond,
 				250 * time.Millisecond,
 			},
+			wantLatencyHistogramUnit: "ms",
 			wantDimensions: []Dimension{
 				{"http.method", &defaultMethod},
 				{"http.status_code", nil},
@@ -87,6 +89,7 @@ func TestLoadConfig(t *testing.T) {
 					ProcessorSettings:       config.NewProcessorSettings(config.NewID(typeStr)),
 					MetricsExporter:         tc.wantMetricsExporter,
 					LatencyHistogramBuckets: tc.wantLatencyHistogramBuckets,
+					LatencyHistogramUnit:    tc.wantLatencyHistogramUnit,
 					Dimensions:              tc.wantDimensions,
 				},
 				cfg.Processors[config.NewID(typeStr)],
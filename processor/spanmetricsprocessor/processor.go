@@ -39,6 +39,9 @@ const (
 	spanKindKey        = tracetranslator.TagSpanKind
 	statusCodeKey      = tracetranslator.TagStatusCode
 	metricKeySeparator = string(byte(0))
+
+	latencyUnitMilliseconds = "ms"
+	latencyUnitSeconds      = "s"
 )
 
 var (
@@ -77,6 +80,7 @@ type processorImp struct {
 	latencySum          map[metricKey]float64
 	latencyBucketCounts map[metricKey][]uint64
 	latencyBounds       []float64
+	latencyUnit         time.Duration
 
 	// A cache of dimension key-value maps keyed by a unique identifier formed by a concatenation of its values:
 	// e.g. { "foo/barOK": { "serviceName": "foo", "operation": "/bar", "status_code": "OK" }}
@@ -87,6 +91,11 @@ func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer cons
 	logger.Info("Building spanmetricsprocessor")
 	pConfig := config.(*Config)
 
+	unit, err := parseLatencyUnit(pConfig.LatencyHistogramUnit)
+	if err != nil {
+		return nil, err
+	}
+
 	bounds := defaultLatencyHistogramBucketsMs
 	if pConfig.LatencyHistogramBuckets != nil {
 		bounds = mapDurationsToMillis(pConfig.LatencyHistogramBuckets)
@@ -96,6 +105,9 @@ func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer cons
 			bounds = append(bounds, maxDurationMs)
 		}
 	}
+	if unit == time.Second {
+		bounds = mapMillisToSeconds(bounds)
+	}
 
 	if err := validateDimensions(pConfig.Dimensions); err != nil {
 		return nil, err
@@ -107,6 +119,7 @@ func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer cons
 		startTime:             time.Now(),
 		callSum:               make(map[metricKey]int64),
 		latencyBounds:         bounds,
+		latencyUnit:           unit,
 		latencySum:            make(map[metricKey]float64),
 		latencyCount:          make(map[metricKey]uint64),
 		latencyBucketCounts:   make(map[metricKey][]uint64),
@@ -130,6 +143,34 @@ func mapDurationsToMillis(vs []time.Duration) []float64 {
 	return vsm
 }
 
+func mapMillisToSeconds(vs []float64) []float64 {
+	vss := make([]float64, len(vs))
+	for i, v := range vs {
+		vss[i] = v / 1000
+	}
+	return vss
+}
+
+// parseLatencyUnit returns the duration of one unit of the latency histogram.
+func parseLatencyUnit(unit string) (time.Duration, error) {
+	switch unit {
+	case "", latencyUnitMilliseconds:
+		return time.Millisecond, nil
+	case latencyUnitSeconds:
+		return time.Second, nil
+	}
+	return 0, fmt.Errorf("unsupported latency_histogram_unit %q, must be one of %q or %q",
+		unit, latencyUnitMilliseconds, latencyUnitSeconds)
+}
+
+// latencyUnitName returns the metric unit of the latency histogram.
+func (p *processorImp) latencyUnitName() string {
+	if p.latencyUnit == time.Second {
+		return latencyUnitSeconds
+	}
+	return latencyUnitMilliseconds
+}
+
 // validateDimensions checks duplicates for reserved dimensions and additional dimensions. Considering
 // the usage of Prometheus related exporters, we also validate the dimensions after sanitization.
 func validateDimensions(dimensions []Dimension) error {
@@ -243,6 +284,7 @@ func (p *processorImp) collectLatencyMetrics(ilm pdata.InstrumentationLibraryMet
 		mLatency := ilm.Metrics().AppendEmpty()
 		mLatency.SetDataType(pdata.MetricDataTypeHistogram)
 		mLatency.SetName("latency")
+		mLatency.SetUnit(p.latencyUnitName())
 		mLatency.Histogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 
 		dpLatency := mLatency.Histogram().DataPoints().AppendEmpty()
@@ -307,17 +349,17 @@ func (p *processorImp) aggregateMetricsForServiceSpans(rspans pdata.ResourceSpan
 }
 
 func (p *processorImp) aggregateMetricsForSpan(serviceName string, span pdata.Span) {
-	latencyInMilliseconds := float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
+	latency := float64(span.EndTimestamp()-span.StartTimestamp()) / float64(p.latencyUnit.Nanoseconds())
 
-	// Binary search to find the latencyInMilliseconds bucket index.
-	index := sort.SearchFloat64s(p.latencyBounds, latencyInMilliseconds)
+	// Binary search to find the latency bucket index.
+	index := sort.SearchFloat64s(p.latencyBounds, latency)
 
 	key := buildKey(serviceName, span, p.dimensions)
 
 	p.lock.Lock()
 	p.cache(serviceName, span, key)
 	p.updateCallMetrics(key)
-	p.updateLatencyMetrics(key, latencyInMilliseconds, index)
+	p.updateLatencyMetrics(key, latency, index)
 	p.lock.Unlock()
 }
 
@@ -158,6 +158,56 @@ func TestConfigureLatencyBounds(t *testing.T) {
 	assert.Equal(t, []float64{0.000003, 0.003, 3, 3000, maxDurationMs}, p.latencyBounds)
 }
 
+func TestConfigureLatencyUnitSeconds(t *testing.T) {
+	// Prepare
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.LatencyHistogramBuckets = []time.Duration{
+		3 * time.Millisecond,
+		3 * time.Second,
+	}
+	cfg.LatencyHistogramUnit = "s"
+
+	// Test
+	next := new(consumertest.TracesSink)
+	p, err := newProcessor(zap.NewNop(), cfg, next)
+
+	// Verify
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0.003, 3, maxDurationMs / 1000}, p.latencyBounds)
+
+	now := time.Now()
+	span := pdata.NewSpan()
+	span.SetStartTimestamp(pdata.TimestampFromTime(now))
+	span.SetEndTimestamp(pdata.TimestampFromTime(now.Add(1500 * time.Millisecond)))
+	p.aggregateMetricsForSpan("service", span)
+
+	m := p.buildMetrics()
+	metrics := m.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	latency := metrics.At(1)
+	assert.Equal(t, "latency", latency.Name())
+	assert.Equal(t, "s", latency.Unit())
+	dp := latency.Histogram().DataPoints().At(0)
+	assert.Equal(t, 1.5, dp.Sum())
+	assert.Equal(t, []uint64{0, 1, 0}, dp.BucketCounts())
+}
+
+func TestConfigureLatencyUnitInvalid(t *testing.T) {
+	// Prepare
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.LatencyHistogramUnit = "us"
+
+	// Test
+	next := new(consumertest.TracesSink)
+	p, err := newProcessor(zap.NewNop(), cfg, next)
+
+	// Verify
+	assert.EqualError(t, err, `unsupported latency_histogram_unit "us", must be one of "ms" or "s"`)
+	assert.Nil(t, p)
+}
+
 func TestProcessorCapabilities(t *testing.T) {
 	// Prepare
 	factory := NewFactory()
@@ -299,6 +349,7 @@ func newProcessorImp(mexp *mocks.MetricsExporter, tcon *mocks.TracesConsumer, de
 		latencyCount:        make(map[metricKey]uint64),
 		latencyBucketCounts: make(map[metricKey][]uint64),
 		latencyBounds:       defaultLatencyHistogramBucketsMs,
+		latencyUnit:         time.Millisecond,
 		dimensions: []Dimension{
 			// Set nil defaults to force a lookup for the attribute in the span.
 			{stringAttrName, nil},
@@ -360,6 +411,7 @@ func verifyConsumeMetricsInput(input pdata.Metrics, t *testing.T) bool {
 	// The remaining metrics are for latency.
 	for ; mi < m.Len(); mi++ {
 		assert.Equal(t, "latency", m.At(mi).Name())
+		assert.Equal(t, "ms", m.At(mi).Unit())
 
 		data := m.At(mi).Histogram()
 		assert.Equal(t, pdata.AggregationTemporalityCumulative, data.AggregationTemporality())
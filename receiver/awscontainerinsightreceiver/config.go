@@ -27,7 +27,8 @@ type Config struct {
 	// CollectionInterval is the interval at which metrics should be collected. The default is 60 second.
 	CollectionInterval time.Duration `mapstructure:"collection_interval"`
 
-	// ContainerOrchestrator is the type of container orchestration service, e.g. eks or ecs. The default is eks.
+	// ContainerOrchestrator is the type of container orchestration service, e.g. eks, ecs or ecs_fargate. The default is eks.
+	// With ecs_fargate the metrics of the task the collector runs in are collected from the ECS task metadata endpoint.
 	ContainerOrchestrator string `mapstructure:"container_orchestrator"`
 
 	// Whether to add the associated service name as attribute. The default is true
@@ -37,4 +38,12 @@ type Config struct {
 	// If it can not be set that way and PrefFullPodName is true, the "PodName" attribute is set to the pod's own name.
 	// The default value is false
 	PrefFullPodName bool `mapstructure:"prefer_full_pod_name"`
+
+	// DCGMExporterEndpoint is the metrics endpoint of the NVIDIA DCGM exporter running on the node, e.g.
+	// http://localhost:9400/metrics. GPU metrics are collected only if it is set. It is only supported for eks.
+	DCGMExporterEndpoint string `mapstructure:"dcgm_exporter_endpoint"`
+
+	// Whether to collect the metrics of the Elastic Fabric Adapter (EFA) devices of the node. It is only
+	// supported for eks. The default value is false
+	CollectEFAMetrics bool `mapstructure:"collect_efa_metrics"`
 }
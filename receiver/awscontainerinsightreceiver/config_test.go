@@ -37,7 +37,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 2)
+	assert.Equal(t, len(cfg.Receivers), 3)
 
 	//ensure default configurations are generated when users provide nothing
 	r0 := cfg.Receivers[config.NewID(typeStr)]
@@ -55,4 +55,16 @@ func TestLoadConfig(t *testing.T) {
 			TagService:            true,
 			PrefFullPodName:       false,
 		})
+
+	r3 := cfg.Receivers[config.NewIDWithName(typeStr, "accelerated_nodes")].(*Config)
+	assert.Equal(t, r3,
+		&Config{
+			ReceiverSettings:      config.NewReceiverSettings(config.NewIDWithName(typeStr, "accelerated_nodes")),
+			CollectionInterval:    60 * time.Second,
+			ContainerOrchestrator: "eks",
+			TagService:            true,
+			PrefFullPodName:       false,
+			DCGMExporterEndpoint:  "http://localhost:9400/metrics",
+			CollectEFAMetrics:     true,
+		})
 }
@@ -0,0 +1,177 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package efa
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	ci "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/containerinsight"
+	awsmetrics "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/metrics"
+)
+
+const (
+	// defaultSysfsRoot is where the kernel exposes the infiniband devices, EFA devices included.
+	defaultSysfsRoot = "/sys/class/infiniband"
+	hwCountersDir    = "hw_counters"
+)
+
+// counters maps the EFA hardware counters to the metrics they are reported as.
+var counters = map[string]string{
+	"rx_bytes":              ci.EfaRxBytes,
+	"tx_bytes":              ci.EfaTxBytes,
+	"rx_drops":              ci.EfaRxDropped,
+	"rdma_read_bytes":       ci.EfaRdmaReadBytes,
+	"rdma_write_bytes":      ci.EfaRdmaWriteBytes,
+	"rdma_write_recv_bytes": ci.EfaRdmaWriteRecvBytes,
+}
+
+type hostInfo interface {
+	GetClusterName() string
+	GetInstanceID() string
+	GetInstanceType() string
+	GetAutoScalingGroupName() string
+}
+
+// Scraper reads the hardware counters of the Elastic Fabric Adapter (EFA) devices of the
+// node from sysfs and reports them as per device rates.
+type Scraper struct {
+	logger         *zap.Logger
+	sysfsRoot      string
+	nodeName       string
+	hostInfo       hostInfo
+	rateCalculator awsmetrics.MetricCalculator
+}
+
+// New creates a Scraper for the EFA devices of the node.
+func New(hostInfo hostInfo, logger *zap.Logger) (*Scraper, error) {
+	nodeName := os.Getenv("HOST_NAME")
+	if nodeName == "" {
+		return nil, errors.New("missing environment variable HOST_NAME. Please check your deployment YAML config")
+	}
+
+	return &Scraper{
+		logger:         logger,
+		sysfsRoot:      defaultSysfsRoot,
+		nodeName:       nodeName,
+		hostInfo:       hostInfo,
+		rateCalculator: newFloat64RateCalculator(),
+	}, nil
+}
+
+// GetMetrics reads the EFA counters and converts their rates to metrics, one per device
+func (s *Scraper) GetMetrics() []pdata.Metrics {
+	var result []pdata.Metrics
+
+	devices, err := ioutil.ReadDir(s.sysfsRoot)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			s.logger.Warn("Failed to list EFA devices", zap.Error(err))
+		}
+		return result
+	}
+
+	now := time.Now()
+	for _, device := range devices {
+		values, err := s.readDeviceCounters(device.Name())
+		if err != nil {
+			s.logger.Debug("Failed to read EFA device counters", zap.String("device", device.Name()), zap.Error(err))
+			continue
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		fields := make(map[string]interface{})
+		for metric, value := range values {
+			rate, ok := s.rateCalculator.Calculate(metric, map[string]string{ci.EfaDevice: device.Name()}, value, now)
+			if ok {
+				fields[ci.MetricName(ci.TypeNodeEFA, metric)] = rate
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		result = append(result, ci.ConvertToOTLPMetrics(fields, s.tags(device.Name(), now), s.logger))
+	}
+	return result
+}
+
+// readDeviceCounters returns the counters of the device summed over all of its ports.
+func (s *Scraper) readDeviceCounters(device string) (map[string]float64, error) {
+	ports, err := ioutil.ReadDir(filepath.Join(s.sysfsRoot, device, "ports"))
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]float64)
+	for _, port := range ports {
+		dir := filepath.Join(s.sysfsRoot, device, "ports", port.Name(), hwCountersDir)
+		for counter, metric := range counters {
+			content, err := ioutil.ReadFile(filepath.Join(dir, counter))
+			if err != nil {
+				continue
+			}
+			value, err := strconv.ParseFloat(strings.TrimSpace(string(content)), 64)
+			if err != nil {
+				s.logger.Debug("Failed to parse EFA counter", zap.String("counter", counter), zap.Error(err))
+				continue
+			}
+			values[metric] += value
+		}
+	}
+	return values, nil
+}
+
+func (s *Scraper) tags(device string, now time.Time) map[string]string {
+	tags := map[string]string{
+		ci.MetricType:              ci.TypeNodeEFA,
+		ci.EfaDevice:               device,
+		ci.NodeNameKey:             s.nodeName,
+		ci.ClusterNameKey:          s.hostInfo.GetClusterName(),
+		ci.AutoScalingGroupNameKey: s.hostInfo.GetAutoScalingGroupName(),
+		ci.Version:                 "0",
+		ci.Timestamp:               strconv.FormatInt(now.UnixNano(), 10),
+	}
+	if instanceID := s.hostInfo.GetInstanceID(); instanceID != "" {
+		tags[ci.InstanceID] = instanceID
+	}
+	if instanceType := s.hostInfo.GetInstanceType(); instanceType != "" {
+		tags[ci.InstanceType] = instanceType
+	}
+	return tags
+}
+
+func newFloat64RateCalculator() awsmetrics.MetricCalculator {
+	return awsmetrics.NewMetricCalculator(func(prev *awsmetrics.MetricValue, val interface{}, timestamp time.Time) (interface{}, bool) {
+		if prev != nil {
+			deltaNs := timestamp.Sub(prev.Timestamp)
+			deltaValue := val.(float64) - prev.RawValue.(float64)
+			if deltaNs > ci.MinTimeDiff && deltaValue >= 0 {
+				return deltaValue / deltaNs.Seconds(), true
+			}
+		}
+		return float64(0), false
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package efa
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	ci "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/containerinsight"
+)
+
+type mockHostInfo struct{}
+
+func (m *mockHostInfo) GetClusterName() string {
+	return "cluster"
+}
+
+func (m *mockHostInfo) GetInstanceID() string {
+	return "i-1234567890"
+}
+
+func (m *mockHostInfo) GetInstanceType() string {
+	return "p4d.24xlarge"
+}
+
+func (m *mockHostInfo) GetAutoScalingGroupName() string {
+	return "asg"
+}
+
+func writeCounters(t *testing.T, root string, device string, port string, counters map[string]int) {
+	dir := filepath.Join(root, device, "ports", port, hwCountersDir)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	for counter, value := range counters {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, counter), []byte(strconv.Itoa(value)+"\n"), 0600))
+	}
+}
+
+func TestNewWithoutHostName(t *testing.T) {
+	os.Unsetenv("HOST_NAME")
+	_, err := New(&mockHostInfo{}, zap.NewNop())
+	assert.Error(t, err)
+}
+
+func TestGetMetrics(t *testing.T) {
+	os.Setenv("HOST_NAME", "host")
+	defer os.Unsetenv("HOST_NAME")
+
+	root := t.TempDir()
+	s, err := New(&mockHostInfo{}, zap.NewNop())
+	require.NoError(t, err)
+	s.sysfsRoot = root
+
+	writeCounters(t, root, "rdmap0s6", "1", map[string]int{"rx_bytes": 100, "tx_bytes": 200, "rx_drops": 0})
+
+	// The first read only initializes the rates.
+	assert.Empty(t, s.GetMetrics())
+
+	time.Sleep(10 * time.Millisecond)
+	writeCounters(t, root, "rdmap0s6", "1", map[string]int{"rx_bytes": 1100, "tx_bytes": 1200, "rx_drops": 0})
+
+	mds := s.GetMetrics()
+	require.Len(t, mds, 1)
+	rm := mds[0].ResourceMetrics().At(0)
+
+	device, ok := rm.Resource().Attributes().Get(ci.EfaDevice)
+	require.True(t, ok)
+	assert.Equal(t, "rdmap0s6", device.StringVal())
+	metricType, ok := rm.Resource().Attributes().Get(ci.MetricType)
+	require.True(t, ok)
+	assert.Equal(t, ci.TypeNodeEFA, metricType.StringVal())
+	nodeName, ok := rm.Resource().Attributes().Get(ci.NodeNameKey)
+	require.True(t, ok)
+	assert.Equal(t, "host", nodeName.StringVal())
+
+	values := map[string]float64{}
+	ilms := rm.InstrumentationLibraryMetrics()
+	for i := 0; i < ilms.Len(); i++ {
+		metric := ilms.At(i).Metrics().At(0)
+		require.Equal(t, pdata.MetricDataTypeGauge, metric.DataType())
+		values[metric.Name()] = metric.Gauge().DataPoints().At(0).DoubleVal()
+	}
+	require.Len(t, values, 3)
+	assert.Greater(t, values["node_efa_rx_bytes"], float64(0))
+	assert.Greater(t, values["node_efa_tx_bytes"], float64(0))
+	assert.Equal(t, float64(0), values["node_efa_rx_dropped"])
+}
+
+func TestGetMetricsWithoutDevices(t *testing.T) {
+	os.Setenv("HOST_NAME", "host")
+	defer os.Unsetenv("HOST_NAME")
+
+	s, err := New(&mockHostInfo{}, zap.NewNop())
+	require.NoError(t, err)
+	s.sysfsRoot = filepath.Join(t.TempDir(), "missing")
+	assert.Empty(t, s.GetMetrics())
+}
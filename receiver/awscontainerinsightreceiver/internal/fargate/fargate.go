@@ -0,0 +1,343 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fargate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	ci "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/containerinsight"
+	awsmetrics "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/metrics"
+)
+
+const (
+	// metadataEndpointEnv is set by the ECS agent in every container of a task on Fargate.
+	metadataEndpointEnv = "ECS_CONTAINER_METADATA_URI_V4"
+
+	scrapeTimeout = 5 * time.Second
+
+	containerStatusRunning = "RUNNING"
+
+	decimalToMillicores = 1000
+	// cpuUnitsPerVCPU is the number of ECS cpu units of a vCPU.
+	cpuUnitsPerVCPU = 1024
+	mebibyte        = 1024 * 1024
+)
+
+// taskMetadata is the response of the task metadata endpoint v4.
+type taskMetadata struct {
+	Cluster    string              `json:"Cluster"`
+	TaskARN    string              `json:"TaskARN"`
+	Family     string              `json:"Family"`
+	Revision   string              `json:"Revision"`
+	Limits     limits              `json:"Limits"`
+	Containers []containerMetadata `json:"Containers"`
+}
+
+// limits holds the cpu (in vCPUs for tasks and cpu units for containers) and memory (in MiB) limits.
+type limits struct {
+	CPU    *float64 `json:"CPU,omitempty"`
+	Memory *uint64  `json:"Memory,omitempty"`
+}
+
+type containerMetadata struct {
+	DockerID    string `json:"DockerId"`
+	Name        string `json:"Name"`
+	KnownStatus string `json:"KnownStatus"`
+	Limits      limits `json:"Limits"`
+}
+
+// containerStats is the subset of the docker stats reported by the task stats endpoint v4.
+type containerStats struct {
+	Read        time.Time               `json:"read"`
+	CPUStats    cpuStats                `json:"cpu_stats"`
+	MemoryStats memoryStats             `json:"memory_stats"`
+	Networks    map[string]networkStats `json:"networks"`
+}
+
+type cpuStats struct {
+	CPUUsage struct {
+		TotalUsage        uint64 `json:"total_usage"`
+		UsageInKernelmode uint64 `json:"usage_in_kernelmode"`
+		UsageInUsermode   uint64 `json:"usage_in_usermode"`
+	} `json:"cpu_usage"`
+}
+
+type memoryStats struct {
+	Usage    uint64            `json:"usage"`
+	MaxUsage uint64            `json:"max_usage"`
+	Failcnt  uint64            `json:"failcnt"`
+	Stats    map[string]uint64 `json:"stats"`
+}
+
+type networkStats struct {
+	RxBytes   uint64 `json:"rx_bytes"`
+	RxPackets uint64 `json:"rx_packets"`
+	RxErrors  uint64 `json:"rx_errors"`
+	RxDropped uint64 `json:"rx_dropped"`
+	TxBytes   uint64 `json:"tx_bytes"`
+	TxPackets uint64 `json:"tx_packets"`
+	TxErrors  uint64 `json:"tx_errors"`
+	TxDropped uint64 `json:"tx_dropped"`
+}
+
+// Scraper collects the Container Insights metrics of the task it runs in from the ECS task metadata
+// endpoint v4, for ECS tasks on Fargate where neither cadvisor nor the host are accessible.
+type Scraper struct {
+	logger         *zap.Logger
+	endpoint       string
+	client         *http.Client
+	rateCalculator awsmetrics.MetricCalculator
+}
+
+// New creates a Scraper for the task metadata endpoint of the current task.
+func New(logger *zap.Logger) (*Scraper, error) {
+	endpoint := os.Getenv(metadataEndpointEnv)
+	if endpoint == "" {
+		return nil, fmt.Errorf("missing environment variable %s. Please check that the collector runs in an ECS task on Fargate", metadataEndpointEnv)
+	}
+
+	return &Scraper{
+		logger:   logger,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   &http.Client{Timeout: scrapeTimeout},
+		rateCalculator: awsmetrics.NewMetricCalculator(func(prev *awsmetrics.MetricValue, val interface{}, timestamp time.Time) (interface{}, bool) {
+			if prev != nil {
+				deltaNs := timestamp.Sub(prev.Timestamp)
+				deltaValue := val.(float64) - prev.RawValue.(float64)
+				if deltaNs > ci.MinTimeDiff && deltaValue >= 0 {
+					return deltaValue / float64(deltaNs), true
+				}
+			}
+			return float64(0), false
+		}),
+	}, nil
+}
+
+// GetMetrics reports the metrics of each container of the task and of the task itself.
+func (s *Scraper) GetMetrics() []pdata.Metrics {
+	var result []pdata.Metrics
+
+	ctx := context.Background()
+	var task taskMetadata
+	if err := s.get(ctx, "/task", &task); err != nil {
+		s.logger.Warn("Failed to get the task metadata", zap.String("endpoint", s.endpoint), zap.Error(err))
+		return result
+	}
+	var stats map[string]*containerStats
+	if err := s.get(ctx, "/task/stats", &stats); err != nil {
+		s.logger.Warn("Failed to get the task stats", zap.String("endpoint", s.endpoint), zap.Error(err))
+		return result
+	}
+
+	now := time.Now()
+	taskFields := make(map[string]interface{})
+	var taskCPUTotal float64
+	var taskMemUsage, taskMemWorkingset uint64
+	var runningContainers int64
+	var netStats *containerStats
+	for _, container := range task.Containers {
+		if container.KnownStatus == containerStatusRunning {
+			runningContainers++
+		}
+		cs := stats[container.DockerID]
+		if cs == nil || cs.Read.IsZero() {
+			continue
+		}
+		// Containers in a Fargate task share its network namespace, so any of them reports the task traffic.
+		if netStats == nil && len(cs.Networks) > 0 {
+			netStats = cs
+		}
+
+		fields := s.containerFields(container, cs, task.Limits)
+		if cpuTotal, ok := fields[ci.MetricName(ci.TypeContainer, ci.CPUTotal)]; ok {
+			taskCPUTotal += cpuTotal.(float64)
+		}
+		taskMemUsage += cs.MemoryStats.Usage
+		taskMemWorkingset += memoryWorkingset(cs.MemoryStats)
+
+		tags := taskTags(ci.TypeContainer, task, cs.Read)
+		tags[ci.ContainerNamekey] = container.Name
+		tags[ci.ContainerIDkey] = container.DockerID
+		result = append(result, ci.ConvertToOTLPMetrics(fields, tags, s.logger))
+	}
+
+	taskFields[ci.MetricName(ci.TypeTask, ci.CPUTotal)] = taskCPUTotal
+	taskFields[ci.MetricName(ci.TypeTask, ci.MemUsage)] = taskMemUsage
+	taskFields[ci.MetricName(ci.TypeTask, ci.MemWorkingset)] = taskMemWorkingset
+	taskFields[ci.MetricName(ci.TypeTask, ci.ContainerCount)] = int64(len(task.Containers))
+	taskFields[ci.MetricName(ci.TypeTask, ci.RunningContainerCount)] = runningContainers
+	if cpuLimit := taskCPULimit(task.Limits); cpuLimit > 0 {
+		taskFields[ci.MetricName(ci.TypeTask, ci.CPULimit)] = cpuLimit
+		taskFields[ci.MetricName(ci.TypeTask, ci.CPUUtilization)] = taskCPUTotal / cpuLimit * 100
+	}
+	if memLimit := taskMemLimit(task.Limits); memLimit > 0 {
+		taskFields[ci.MetricName(ci.TypeTask, ci.MemLimit)] = memLimit
+		taskFields[ci.MetricName(ci.TypeTask, ci.MemUtilization)] = float64(taskMemWorkingset) / float64(memLimit) * 100
+	}
+	if netStats != nil {
+		s.addNetworkFields(taskFields, task.TaskARN, netStats)
+	}
+	result = append(result, ci.ConvertToOTLPMetrics(taskFields, taskTags(ci.TypeTask, task, now), s.logger))
+
+	return result
+}
+
+func (s *Scraper) containerFields(container containerMetadata, cs *containerStats, taskLimits limits) map[string]interface{} {
+	fields := make(map[string]interface{})
+	cpu := cs.CPUStats.CPUUsage
+	s.assignRate(fields, ci.MetricName(ci.TypeContainer, ci.CPUTotal), container.DockerID, float64(cpu.TotalUsage), cs.Read, decimalToMillicores)
+	s.assignRate(fields, ci.MetricName(ci.TypeContainer, ci.CPUUser), container.DockerID, float64(cpu.UsageInUsermode), cs.Read, decimalToMillicores)
+	s.assignRate(fields, ci.MetricName(ci.TypeContainer, ci.CPUSystem), container.DockerID, float64(cpu.UsageInKernelmode), cs.Read, decimalToMillicores)
+	if cpuTotal, ok := fields[ci.MetricName(ci.TypeContainer, ci.CPUTotal)]; ok {
+		if cpuLimit := taskCPULimit(taskLimits); cpuLimit > 0 {
+			fields[ci.MetricName(ci.TypeContainer, ci.CPUUtilization)] = cpuTotal.(float64) / cpuLimit * 100
+		}
+	}
+	if container.Limits.CPU != nil && *container.Limits.CPU > 0 {
+		fields[ci.MetricName(ci.TypeContainer, ci.CPULimit)] = *container.Limits.CPU / cpuUnitsPerVCPU * decimalToMillicores
+	}
+
+	mem := cs.MemoryStats
+	workingset := memoryWorkingset(mem)
+	fields[ci.MetricName(ci.TypeContainer, ci.MemUsage)] = mem.Usage
+	fields[ci.MetricName(ci.TypeContainer, ci.MemMaxusage)] = mem.MaxUsage
+	fields[ci.MetricName(ci.TypeContainer, ci.MemFailcnt)] = mem.Failcnt
+	fields[ci.MetricName(ci.TypeContainer, ci.MemCache)] = mem.Stats["cache"]
+	fields[ci.MetricName(ci.TypeContainer, ci.MemRss)] = mem.Stats["rss"]
+	fields[ci.MetricName(ci.TypeContainer, ci.MemMappedfile)] = mem.Stats["mapped_file"]
+	fields[ci.MetricName(ci.TypeContainer, ci.MemWorkingset)] = workingset
+	if container.Limits.Memory != nil && *container.Limits.Memory > 0 {
+		fields[ci.MetricName(ci.TypeContainer, ci.MemLimit)] = *container.Limits.Memory * mebibyte
+	}
+	if memLimit := taskMemLimit(taskLimits); memLimit > 0 {
+		fields[ci.MetricName(ci.TypeContainer, ci.MemUtilization)] = float64(workingset) / float64(memLimit) * 100
+	}
+	return fields
+}
+
+func (s *Scraper) addNetworkFields(fields map[string]interface{}, taskARN string, cs *containerStats) {
+	var total networkStats
+	for _, ifce := range cs.Networks {
+		total.RxBytes += ifce.RxBytes
+		total.RxPackets += ifce.RxPackets
+		total.RxErrors += ifce.RxErrors
+		total.RxDropped += ifce.RxDropped
+		total.TxBytes += ifce.TxBytes
+		total.TxPackets += ifce.TxPackets
+		total.TxErrors += ifce.TxErrors
+		total.TxDropped += ifce.TxDropped
+	}
+
+	counters := map[string]uint64{
+		ci.NetRxBytes:   total.RxBytes,
+		ci.NetRxPackets: total.RxPackets,
+		ci.NetRxErrors:  total.RxErrors,
+		ci.NetRxDropped: total.RxDropped,
+		ci.NetTxBytes:   total.TxBytes,
+		ci.NetTxPackets: total.TxPackets,
+		ci.NetTxErrors:  total.TxErrors,
+		ci.NetTxDropped: total.TxDropped,
+	}
+	for metric, value := range counters {
+		s.assignRate(fields, ci.MetricName(ci.TypeTask, metric), taskARN, float64(value), cs.Read, float64(time.Second))
+	}
+	rxBytes, rxOk := fields[ci.MetricName(ci.TypeTask, ci.NetRxBytes)]
+	txBytes, txOk := fields[ci.MetricName(ci.TypeTask, ci.NetTxBytes)]
+	if rxOk && txOk {
+		fields[ci.MetricName(ci.TypeTask, ci.NetTotalBytes)] = rxBytes.(float64) + txBytes.(float64)
+	}
+}
+
+// assignRate sets the field to the per nanosecond rate of the counter, scaled by multiplier, once a
+// previous value is known.
+func (s *Scraper) assignRate(fields map[string]interface{}, metricName string, id string, value float64, ts time.Time, multiplier float64) {
+	if rate, ok := s.rateCalculator.Calculate(id+metricName, nil, value, ts); ok {
+		fields[metricName] = rate.(float64) * multiplier
+	}
+}
+
+func (s *Scraper) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode the response: %w", err)
+	}
+	return nil
+}
+
+// memoryWorkingset is the memory usage excluding the inactive file cache, the same as cadvisor reports.
+func memoryWorkingset(mem memoryStats) uint64 {
+	inactiveFile := mem.Stats["inactive_file"]
+	if mem.Usage < inactiveFile {
+		return 0
+	}
+	return mem.Usage - inactiveFile
+}
+
+// taskCPULimit returns the task cpu limit in millicores, or 0 if it is not known.
+func taskCPULimit(l limits) float64 {
+	if l.CPU == nil {
+		return 0
+	}
+	return *l.CPU * decimalToMillicores
+}
+
+// taskMemLimit returns the task memory limit in bytes, or 0 if it is not known.
+func taskMemLimit(l limits) uint64 {
+	if l.Memory == nil {
+		return 0
+	}
+	return *l.Memory * mebibyte
+}
+
+func taskTags(mType string, task taskMetadata, ts time.Time) map[string]string {
+	sources, _ := json.Marshal([]string{"ecstaskmetadata", "calculated"})
+	return map[string]string{
+		ci.MetricType:                mType,
+		ci.ClusterNameKey:            lastSegment(task.Cluster),
+		ci.TaskIDKey:                 lastSegment(task.TaskARN),
+		ci.TaskDefinitionFamilyKey:   task.Family,
+		ci.TaskDefinitionRevisionKey: task.Revision,
+		ci.SourcesKey:                string(sources),
+		ci.Version:                   "0",
+		ci.Timestamp:                 strconv.FormatInt(ts.UnixNano(), 10),
+	}
+}
+
+// lastSegment returns the name of a resource from its ARN, e.g. the cluster name of a cluster ARN.
+func lastSegment(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
@@ -0,0 +1,204 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fargate
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	ci "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/containerinsight"
+)
+
+const taskMetadataJSON = `{
+  "Cluster": "arn:aws:ecs:us-west-2:111122223333:cluster/default",
+  "TaskARN": "arn:aws:ecs:us-west-2:111122223333:task/default/158d1c8083dd49d6b527399fd6414f5c",
+  "Family": "app",
+  "Revision": "26",
+  "Limits": {"CPU": 0.5, "Memory": 1024},
+  "LaunchType": "FARGATE",
+  "Containers": [
+    {"DockerId": "app-id", "Name": "app", "KnownStatus": "RUNNING", "Limits": {"CPU": 256, "Memory": 512}},
+    {"DockerId": "collector-id", "Name": "collector", "KnownStatus": "RUNNING", "Limits": {"CPU": 0}},
+    {"DockerId": "init-id", "Name": "init", "KnownStatus": "STOPPED", "Limits": {"CPU": 0}}
+  ]
+}`
+
+// taskStatsJSON renders the stats of the app and collector containers at the given time, with
+// cumulative counters that grow linearly with it.
+func taskStatsJSON(read time.Time, seconds uint64) string {
+	containerStats := func(cpuNs uint64, networks string) string {
+		return fmt.Sprintf(`{
+  "read": %q,
+  "cpu_stats": {"cpu_usage": {"total_usage": %d, "usage_in_kernelmode": %d, "usage_in_usermode": %d}},
+  "memory_stats": {"usage": 104857600, "max_usage": 209715200, "failcnt": 0,
+    "stats": {"cache": 4194304, "rss": 83886080, "mapped_file": 1048576, "inactive_file": 20971520}},
+  "networks": %s
+}`, read.Format(time.RFC3339Nano), cpuNs*seconds, cpuNs*seconds/4, cpuNs*seconds*3/4, networks)
+	}
+	networks := fmt.Sprintf(`{"eth1": {"rx_bytes": %d, "rx_packets": %d, "tx_bytes": %d, "tx_packets": %d}}`,
+		1000*seconds, 10*seconds, 500*seconds, 5*seconds)
+	return fmt.Sprintf(`{"app-id": %s, "collector-id": %s, "init-id": null}`,
+		containerStats(250000000, networks), containerStats(50000000, networks))
+}
+
+func metricsByType(mds []pdata.Metrics) map[string]map[string]float64 {
+	result := make(map[string]map[string]float64)
+	for _, md := range mds {
+		rm := md.ResourceMetrics().At(0)
+		attrs := rm.Resource().Attributes()
+		mType, _ := attrs.Get(ci.MetricType)
+		key := mType.StringVal()
+		if name, ok := attrs.Get(ci.ContainerNamekey); ok {
+			key += "/" + name.StringVal()
+		}
+		result[key] = make(map[string]float64)
+		ilms := rm.InstrumentationLibraryMetrics()
+		for i := 0; i < ilms.Len(); i++ {
+			metrics := ilms.At(i).Metrics()
+			for j := 0; j < metrics.Len(); j++ {
+				m := metrics.At(j)
+				dp := m.Gauge().DataPoints().At(0)
+				switch dp.Type() {
+				case pdata.MetricValueTypeDouble:
+					result[key][m.Name()] = dp.DoubleVal()
+				case pdata.MetricValueTypeInt:
+					result[key][m.Name()] = float64(dp.IntVal())
+				}
+			}
+		}
+	}
+	return result
+}
+
+func TestNewWithoutEndpoint(t *testing.T) {
+	os.Unsetenv(metadataEndpointEnv)
+	_, err := New(zap.NewNop())
+	assert.Error(t, err)
+}
+
+func TestGetMetrics(t *testing.T) {
+	start := time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)
+	var stats string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/task":
+			w.Write([]byte(taskMetadataJSON))
+		case "/task/stats":
+			w.Write([]byte(stats))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	os.Setenv(metadataEndpointEnv, server.URL)
+	defer os.Unsetenv(metadataEndpointEnv)
+	s, err := New(zap.NewNop())
+	require.NoError(t, err)
+
+	// The first scrape reports no rates since there is no previous value of the counters yet.
+	stats = taskStatsJSON(start, 10)
+	metrics := metricsByType(s.GetMetrics())
+	require.Len(t, metrics, 3)
+	assert.NotContains(t, metrics["Container/app"], "container_cpu_usage_total")
+	assert.NotContains(t, metrics["Task"], "task_network_rx_bytes")
+
+	stats = taskStatsJSON(start.Add(10*time.Second), 20)
+	metrics = metricsByType(s.GetMetrics())
+	require.Len(t, metrics, 3)
+
+	app := metrics["Container/app"]
+	assert.InDelta(t, 250, app["container_cpu_usage_total"], 0.001)
+	assert.InDelta(t, 187.5, app["container_cpu_usage_user"], 0.001)
+	assert.InDelta(t, 62.5, app["container_cpu_usage_system"], 0.001)
+	assert.InDelta(t, 50, app["container_cpu_utilization"], 0.001)
+	assert.InDelta(t, 250, app["container_cpu_limit"], 0.001)
+	assert.Equal(t, float64(104857600), app["container_memory_usage"])
+	assert.Equal(t, float64(83886080), app["container_memory_working_set"])
+	assert.Equal(t, float64(512*mebibyte), app["container_memory_limit"])
+	assert.InDelta(t, 7.8125, app["container_memory_utilization"], 0.001)
+
+	collector := metrics["Container/collector"]
+	assert.InDelta(t, 50, collector["container_cpu_usage_total"], 0.001)
+	assert.NotContains(t, collector, "container_cpu_limit")
+	assert.NotContains(t, collector, "container_memory_limit")
+
+	task := metrics["Task"]
+	assert.InDelta(t, 300, task["task_cpu_usage_total"], 0.001)
+	assert.InDelta(t, 500, task["task_cpu_limit"], 0.001)
+	assert.InDelta(t, 60, task["task_cpu_utilization"], 0.001)
+	assert.Equal(t, float64(2*104857600), task["task_memory_usage"])
+	assert.Equal(t, float64(1024*mebibyte), task["task_memory_limit"])
+	assert.InDelta(t, 15.625, task["task_memory_utilization"], 0.001)
+	assert.Equal(t, float64(3), task["task_number_of_containers"])
+	assert.Equal(t, float64(2), task["task_number_of_running_containers"])
+	assert.InDelta(t, 1000, task["task_network_rx_bytes"], 0.001)
+	assert.InDelta(t, 500, task["task_network_tx_bytes"], 0.001)
+	assert.InDelta(t, 1500, task["task_network_total_bytes"], 0.001)
+	assert.InDelta(t, 10, task["task_network_rx_packets"], 0.001)
+}
+
+func TestTaskTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/task" {
+			w.Write([]byte(taskMetadataJSON))
+			return
+		}
+		w.Write([]byte(taskStatsJSON(time.Now(), 1)))
+	}))
+	defer server.Close()
+
+	os.Setenv(metadataEndpointEnv, server.URL+"/")
+	defer os.Unsetenv(metadataEndpointEnv)
+	s, err := New(zap.NewNop())
+	require.NoError(t, err)
+
+	mds := s.GetMetrics()
+	require.NotEmpty(t, mds)
+	attrs := mds[len(mds)-1].ResourceMetrics().At(0).Resource().Attributes()
+	for key, expected := range map[string]string{
+		ci.MetricType:                ci.TypeTask,
+		ci.ClusterNameKey:            "default",
+		ci.TaskIDKey:                 "158d1c8083dd49d6b527399fd6414f5c",
+		ci.TaskDefinitionFamilyKey:   "app",
+		ci.TaskDefinitionRevisionKey: "26",
+	} {
+		value, ok := attrs.Get(key)
+		require.True(t, ok, key)
+		assert.Equal(t, expected, value.StringVal(), key)
+	}
+}
+
+func TestGetMetricsWithUnavailableEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	os.Setenv(metadataEndpointEnv, server.URL)
+	defer os.Unsetenv(metadataEndpointEnv)
+	s, err := New(zap.NewNop())
+	require.NoError(t, err)
+	assert.Empty(t, s.GetMetrics())
+}
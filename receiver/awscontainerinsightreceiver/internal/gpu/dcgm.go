@@ -0,0 +1,225 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gpu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	ci "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/containerinsight"
+)
+
+const (
+	scrapeTimeout = 5 * time.Second
+
+	// Labels set by the NVIDIA DCGM exporter
+	labelUUID      = "UUID"
+	labelDevice    = "device"
+	labelPod       = "pod"
+	labelNamespace = "namespace"
+	labelContainer = "container"
+
+	mebibyte = 1024 * 1024
+)
+
+// dcgmMetrics maps the DCGM exporter metrics to the metrics they are reported as.
+var dcgmMetrics = map[string]string{
+	"DCGM_FI_DEV_GPU_UTIL":    ci.GPUUtilization,
+	"DCGM_FI_DEV_FB_USED":     ci.GPUMemUsed,
+	"DCGM_FI_DEV_GPU_TEMP":    ci.GPUTemperature,
+	"DCGM_FI_DEV_POWER_USAGE": ci.GPUPowerDraw,
+}
+
+// dcgmFBFree is the free frame buffer memory, used to compute the total GPU memory.
+const dcgmFBFree = "DCGM_FI_DEV_FB_FREE"
+
+type hostInfo interface {
+	GetClusterName() string
+	GetInstanceID() string
+	GetInstanceType() string
+	GetAutoScalingGroupName() string
+}
+
+// Scraper collects GPU metrics of the node from the metrics endpoint of the NVIDIA DCGM exporter.
+type Scraper struct {
+	logger   *zap.Logger
+	endpoint string
+	client   *http.Client
+	nodeName string
+	hostInfo hostInfo
+}
+
+// gpuSample holds the metric values of a single GPU.
+type gpuSample struct {
+	labels map[string]string
+	fields map[string]float64
+}
+
+// New creates a Scraper for the DCGM exporter metrics at endpoint.
+func New(endpoint string, hostInfo hostInfo, logger *zap.Logger) (*Scraper, error) {
+	nodeName := os.Getenv("HOST_NAME")
+	if nodeName == "" {
+		return nil, errors.New("missing environment variable HOST_NAME. Please check your deployment YAML config")
+	}
+
+	return &Scraper{
+		logger:   logger,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: scrapeTimeout},
+		nodeName: nodeName,
+		hostInfo: hostInfo,
+	}, nil
+}
+
+// GetMetrics scrapes the DCGM exporter and converts its metrics to node metrics per GPU, and to
+// container metrics for the GPUs that are allocated to a container.
+func (s *Scraper) GetMetrics() []pdata.Metrics {
+	var result []pdata.Metrics
+
+	families, err := s.scrape(context.Background())
+	if err != nil {
+		s.logger.Warn("Failed to scrape DCGM exporter", zap.String("endpoint", s.endpoint), zap.Error(err))
+		return result
+	}
+
+	now := time.Now()
+	for _, sample := range samplesByGPU(families) {
+		fields := sample.fields
+		if used, ok := fields[ci.GPUMemUsed]; ok {
+			if free, ok := fields[dcgmFBFree]; ok && used+free > 0 {
+				fields[ci.GPUMemTotal] = used + free
+				fields[ci.GPUMemUtilization] = used / (used + free) * 100
+			}
+		}
+		delete(fields, dcgmFBFree)
+		for _, metric := range []string{ci.GPUMemUsed, ci.GPUMemTotal} {
+			if v, ok := fields[metric]; ok {
+				fields[metric] = v * mebibyte
+			}
+		}
+
+		result = append(result, ci.ConvertToOTLPMetrics(metricFields(ci.TypeNodeGPU, fields), s.tags(ci.TypeNodeGPU, sample.labels, now), s.logger))
+		if sample.labels[labelPod] != "" {
+			result = append(result, ci.ConvertToOTLPMetrics(metricFields(ci.TypeContainerGPU, fields), s.tags(ci.TypeContainerGPU, sample.labels, now), s.logger))
+		}
+	}
+	return result
+}
+
+func (s *Scraper) scrape(ctx context.Context) (map[string]*dto.MetricFamily, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	return parser.TextToMetricFamilies(resp.Body)
+}
+
+// samplesByGPU groups the values of the known DCGM metrics by GPU.
+func samplesByGPU(families map[string]*dto.MetricFamily) map[string]*gpuSample {
+	samples := make(map[string]*gpuSample)
+	for name, family := range families {
+		field, ok := dcgmMetrics[name]
+		if !ok && name != dcgmFBFree {
+			continue
+		}
+		if !ok {
+			field = dcgmFBFree
+		}
+
+		for _, m := range family.GetMetric() {
+			labels := make(map[string]string, len(m.GetLabel()))
+			for _, label := range m.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			key := labels[labelUUID]
+			if key == "" {
+				key = labels[labelDevice]
+			}
+
+			sample, ok := samples[key]
+			if !ok {
+				sample = &gpuSample{labels: labels, fields: make(map[string]float64)}
+				samples[key] = sample
+			}
+			sample.fields[field] = metricValue(m)
+		}
+	}
+	return samples
+}
+
+func metricValue(m *dto.Metric) float64 {
+	switch {
+	case m.Gauge != nil:
+		return m.GetGauge().GetValue()
+	case m.Counter != nil:
+		return m.GetCounter().GetValue()
+	default:
+		return m.GetUntyped().GetValue()
+	}
+}
+
+func metricFields(mType string, values map[string]float64) map[string]interface{} {
+	fields := make(map[string]interface{}, len(values))
+	for metric, value := range values {
+		fields[ci.MetricName(mType, metric)] = value
+	}
+	return fields
+}
+
+func (s *Scraper) tags(mType string, labels map[string]string, now time.Time) map[string]string {
+	tags := map[string]string{
+		ci.MetricType:              mType,
+		ci.GpuDevice:               labels[labelDevice],
+		ci.NodeNameKey:             s.nodeName,
+		ci.ClusterNameKey:          s.hostInfo.GetClusterName(),
+		ci.AutoScalingGroupNameKey: s.hostInfo.GetAutoScalingGroupName(),
+		ci.Version:                 "0",
+		ci.Timestamp:               strconv.FormatInt(now.UnixNano(), 10),
+	}
+	if instanceID := s.hostInfo.GetInstanceID(); instanceID != "" {
+		tags[ci.InstanceID] = instanceID
+	}
+	if instanceType := s.hostInfo.GetInstanceType(); instanceType != "" {
+		tags[ci.InstanceType] = instanceType
+	}
+	if mType == ci.TypeContainerGPU {
+		tags[ci.K8sNamespace] = labels[labelNamespace]
+		tags[ci.K8sPodNameKey] = labels[labelPod]
+		tags[ci.PodNameKey] = labels[labelPod]
+		tags[ci.ContainerNamekey] = labels[labelContainer]
+	}
+	return tags
+}
@@ -0,0 +1,165 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gpu
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	ci "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/containerinsight"
+)
+
+const dcgmMetricsText = `# HELP DCGM_FI_DEV_GPU_UTIL GPU utilization (in %).
+# TYPE DCGM_FI_DEV_GPU_UTIL gauge
+DCGM_FI_DEV_GPU_UTIL{gpu="0",UUID="GPU-0",device="nvidia0",modelName="Tesla V100",container="trainer",namespace="default",pod="trainer-0"} 75
+DCGM_FI_DEV_GPU_UTIL{gpu="1",UUID="GPU-1",device="nvidia1",modelName="Tesla V100"} 0
+# HELP DCGM_FI_DEV_FB_USED Framebuffer memory used (in MiB).
+# TYPE DCGM_FI_DEV_FB_USED gauge
+DCGM_FI_DEV_FB_USED{gpu="0",UUID="GPU-0",device="nvidia0",modelName="Tesla V100",container="trainer",namespace="default",pod="trainer-0"} 4096
+DCGM_FI_DEV_FB_USED{gpu="1",UUID="GPU-1",device="nvidia1",modelName="Tesla V100"} 0
+# HELP DCGM_FI_DEV_FB_FREE Framebuffer memory free (in MiB).
+# TYPE DCGM_FI_DEV_FB_FREE gauge
+DCGM_FI_DEV_FB_FREE{gpu="0",UUID="GPU-0",device="nvidia0",modelName="Tesla V100",container="trainer",namespace="default",pod="trainer-0"} 12288
+DCGM_FI_DEV_FB_FREE{gpu="1",UUID="GPU-1",device="nvidia1",modelName="Tesla V100"} 16384
+# HELP DCGM_FI_DEV_GPU_TEMP GPU temperature (in C).
+# TYPE DCGM_FI_DEV_GPU_TEMP gauge
+DCGM_FI_DEV_GPU_TEMP{gpu="0",UUID="GPU-0",device="nvidia0",modelName="Tesla V100",container="trainer",namespace="default",pod="trainer-0"} 65
+# HELP DCGM_FI_DEV_POWER_USAGE Power draw (in W).
+# TYPE DCGM_FI_DEV_POWER_USAGE gauge
+DCGM_FI_DEV_POWER_USAGE{gpu="0",UUID="GPU-0",device="nvidia0",modelName="Tesla V100",container="trainer",namespace="default",pod="trainer-0"} 180.5
+# HELP DCGM_FI_DEV_SM_CLOCK SM clock frequency (in MHz).
+# TYPE DCGM_FI_DEV_SM_CLOCK gauge
+DCGM_FI_DEV_SM_CLOCK{gpu="0",UUID="GPU-0",device="nvidia0",modelName="Tesla V100"} 1530
+`
+
+type mockHostInfo struct{}
+
+func (m *mockHostInfo) GetClusterName() string {
+	return "cluster"
+}
+
+func (m *mockHostInfo) GetInstanceID() string {
+	return "i-1234567890"
+}
+
+func (m *mockHostInfo) GetInstanceType() string {
+	return "p3.8xlarge"
+}
+
+func (m *mockHostInfo) GetAutoScalingGroupName() string {
+	return "asg"
+}
+
+// metricsByType indexes the gauge values of the given metrics by metric type and metric name.
+func metricsByType(mds []pdata.Metrics) map[string]map[string]float64 {
+	result := make(map[string]map[string]float64)
+	for _, md := range mds {
+		rm := md.ResourceMetrics().At(0)
+		mType, _ := rm.Resource().Attributes().Get(ci.MetricType)
+		device, _ := rm.Resource().Attributes().Get(ci.GpuDevice)
+		key := mType.StringVal() + "/" + device.StringVal()
+		result[key] = make(map[string]float64)
+		ilms := rm.InstrumentationLibraryMetrics()
+		for i := 0; i < ilms.Len(); i++ {
+			m := ilms.At(i).Metrics().At(0)
+			result[key][m.Name()] = m.Gauge().DataPoints().At(0).DoubleVal()
+		}
+	}
+	return result
+}
+
+func TestNewWithoutHostName(t *testing.T) {
+	os.Unsetenv("HOST_NAME")
+	_, err := New("http://localhost:9400/metrics", &mockHostInfo{}, zap.NewNop())
+	assert.Error(t, err)
+}
+
+func TestGetMetrics(t *testing.T) {
+	os.Setenv("HOST_NAME", "host")
+	defer os.Unsetenv("HOST_NAME")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(dcgmMetricsText))
+	}))
+	defer server.Close()
+
+	s, err := New(server.URL, &mockHostInfo{}, zap.NewNop())
+	require.NoError(t, err)
+
+	mds := s.GetMetrics()
+	require.Len(t, mds, 3)
+
+	metrics := metricsByType(mds)
+	assert.Equal(t, map[string]float64{
+		"node_gpu_utilization":        75,
+		"node_gpu_memory_used":        4096 * 1024 * 1024,
+		"node_gpu_memory_total":       16384 * 1024 * 1024,
+		"node_gpu_memory_utilization": 25,
+		"node_gpu_temperature":        65,
+		"node_gpu_power_draw":         180.5,
+	}, metrics[ci.TypeNodeGPU+"/nvidia0"])
+	assert.Equal(t, map[string]float64{
+		"container_gpu_utilization":        75,
+		"container_gpu_memory_used":        4096 * 1024 * 1024,
+		"container_gpu_memory_total":       16384 * 1024 * 1024,
+		"container_gpu_memory_utilization": 25,
+		"container_gpu_temperature":        65,
+		"container_gpu_power_draw":         180.5,
+	}, metrics[ci.TypeContainerGPU+"/nvidia0"])
+	assert.Equal(t, map[string]float64{
+		"node_gpu_utilization":        0,
+		"node_gpu_memory_used":        0,
+		"node_gpu_memory_total":       16384 * 1024 * 1024,
+		"node_gpu_memory_utilization": 0,
+	}, metrics[ci.TypeNodeGPU+"/nvidia1"])
+
+	for _, md := range mds {
+		attrs := md.ResourceMetrics().At(0).Resource().Attributes()
+		nodeName, _ := attrs.Get(ci.NodeNameKey)
+		assert.Equal(t, "host", nodeName.StringVal())
+		clusterName, _ := attrs.Get(ci.ClusterNameKey)
+		assert.Equal(t, "cluster", clusterName.StringVal())
+		mType, _ := attrs.Get(ci.MetricType)
+		if mType.StringVal() == ci.TypeContainerGPU {
+			pod, _ := attrs.Get(ci.PodNameKey)
+			assert.Equal(t, "trainer-0", pod.StringVal())
+			namespace, _ := attrs.Get(ci.K8sNamespace)
+			assert.Equal(t, "default", namespace.StringVal())
+			container, _ := attrs.Get(ci.ContainerNamekey)
+			assert.Equal(t, "trainer", container.StringVal())
+		}
+	}
+}
+
+func TestGetMetricsUnavailable(t *testing.T) {
+	os.Setenv("HOST_NAME", "host")
+	defer os.Unsetenv("HOST_NAME")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	s, err := New(server.URL, &mockHostInfo{}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Empty(t, s.GetMetrics())
+}
@@ -28,6 +28,9 @@ import (
 	ci "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/containerinsight"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/cadvisor"
 	ecsinfo "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/ecsInfo"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/efa"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/fargate"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/gpu"
 	hostInfo "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/host"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/k8sapiserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/stores"
@@ -47,6 +50,9 @@ type awsContainerInsightReceiver struct {
 	cancel       context.CancelFunc
 	cadvisor     metricsProvider
 	k8sapiserver metricsProvider
+	gpu          metricsProvider
+	efa          metricsProvider
+	fargate      metricsProvider
 }
 
 // newAWSContainerInsightReceiver creates the aws container insight receiver with the given parameters.
@@ -70,6 +76,17 @@ func newAWSContainerInsightReceiver(
 func (acir *awsContainerInsightReceiver) Start(ctx context.Context, host component.Host) error {
 	ctx, acir.cancel = context.WithCancel(context.Background())
 
+	// On Fargate the host is not accessible, all metrics come from the task metadata endpoint
+	if acir.config.ContainerOrchestrator == ci.ECSFargate {
+		var err error
+		acir.fargate, err = fargate.New(acir.logger)
+		if err != nil {
+			return err
+		}
+		go acir.collect(ctx)
+		return nil
+	}
+
 	hostinfo, err := hostInfo.NewInfo(acir.config.ContainerOrchestrator, acir.config.CollectionInterval, acir.logger)
 	if err != nil {
 		return err
@@ -90,6 +107,18 @@ func (acir *awsContainerInsightReceiver) Start(ctx context.Context, host compone
 		if err != nil {
 			return err
 		}
+		if acir.config.DCGMExporterEndpoint != "" {
+			acir.gpu, err = gpu.New(acir.config.DCGMExporterEndpoint, hostinfo, acir.logger)
+			if err != nil {
+				return err
+			}
+		}
+		if acir.config.CollectEFAMetrics {
+			acir.efa, err = efa.New(hostinfo, acir.logger)
+			if err != nil {
+				return err
+			}
+		}
 	}
 	if acir.config.ContainerOrchestrator == ci.ECS {
 
@@ -114,22 +143,27 @@ func (acir *awsContainerInsightReceiver) Start(ctx context.Context, host compone
 		if secondsInMin < 30 {
 			time.Sleep(time.Duration(30-secondsInMin) * time.Second)
 		}
-		ticker := time.NewTicker(acir.config.CollectionInterval)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ticker.C:
-				acir.collectData(ctx)
-			case <-ctx.Done():
-				return
-			}
-		}
+		acir.collect(ctx)
 	}()
 
 	return nil
 }
 
+// collect collects the metrics every collection interval until ctx is done
+func (acir *awsContainerInsightReceiver) collect(ctx context.Context) {
+	ticker := time.NewTicker(acir.config.CollectionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			acir.collectData(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // Shutdown stops the awsContainerInsightReceiver receiver.
 func (acir *awsContainerInsightReceiver) Shutdown(context.Context) error {
 	acir.cancel()
@@ -139,8 +173,8 @@ func (acir *awsContainerInsightReceiver) Shutdown(context.Context) error {
 // collectData collects container stats from Amazon ECS Task Metadata Endpoint
 func (acir *awsContainerInsightReceiver) collectData(ctx context.Context) error {
 	var mds []pdata.Metrics
-	if acir.cadvisor == nil && acir.k8sapiserver == nil {
-		err := errors.New("both cadvisor and k8sapiserver failed to start")
+	if acir.cadvisor == nil && acir.k8sapiserver == nil && acir.fargate == nil {
+		err := errors.New("cadvisor, k8sapiserver and the task metadata scraper all failed to start")
 		acir.logger.Error("Failed to collect stats", zap.Error(err))
 		return err
 	}
@@ -153,6 +187,18 @@ func (acir *awsContainerInsightReceiver) collectData(ctx context.Context) error
 		mds = append(mds, acir.k8sapiserver.GetMetrics()...)
 	}
 
+	if acir.gpu != nil {
+		mds = append(mds, acir.gpu.GetMetrics()...)
+	}
+
+	if acir.efa != nil {
+		mds = append(mds, acir.efa.GetMetrics()...)
+	}
+
+	if acir.fargate != nil {
+		mds = append(mds, acir.fargate.GetMetrics()...)
+	}
+
 	for _, md := range mds {
 		err := acir.nextConsumer.ConsumeMetrics(ctx, md)
 		if err != nil {
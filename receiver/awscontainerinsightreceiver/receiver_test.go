@@ -150,3 +150,33 @@ func TestCollectDataWithECS(t *testing.T) {
 	err = r.collectData(ctx)
 	require.NotNil(t, err)
 }
+
+func TestCollectDataWithECSFargate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ContainerOrchestrator = ci.ECSFargate
+	metricsReceiver, err := newAWSContainerInsightReceiver(
+		zap.NewNop(),
+		cfg,
+		new(consumertest.MetricsSink),
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, metricsReceiver)
+
+	r := metricsReceiver.(*awsContainerInsightReceiver)
+	ctx := context.Background()
+
+	// The task metadata endpoint is only known within an ECS task
+	err = r.Start(ctx, componenttest.NewNopHost())
+	require.Error(t, err)
+
+	r.fargate = &MockCadvisor{}
+	err = r.collectData(ctx)
+	require.Nil(t, err)
+
+	r.fargate = nil
+	err = r.collectData(ctx)
+	require.NotNil(t, err)
+
+	require.NoError(t, r.Shutdown(ctx))
+}
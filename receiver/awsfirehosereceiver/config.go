@@ -0,0 +1,53 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsfirehosereceiver
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver/internal/unmarshaler/cwlog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver/internal/unmarshaler/cwmetricstream"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver/internal/unmarshaler/otlpmetricstream"
+)
+
+// Config defines configuration for the AWS Kinesis Data Firehose receiver.
+type Config struct {
+	config.ReceiverSettings       `mapstructure:",squash"`
+	confighttp.HTTPServerSettings `mapstructure:",squash"`
+
+	// RecordType is the format of the delivered records, one of `cwmetrics` (CloudWatch metric
+	// stream in the JSON format), `otlp_v1` (CloudWatch metric stream in the OpenTelemetry 0.7
+	// format) or `cwlogs` (CloudWatch Logs subscription).
+	RecordType string `mapstructure:"record_type"`
+
+	// AccessKey is checked against the X-Amz-Firehose-Access-Key header of the requests when set.
+	AccessKey string `mapstructure:"access_key"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return fmt.Errorf("missing required field `endpoint`")
+	}
+	switch cfg.RecordType {
+	case cwmetricstream.TypeStr, otlpmetricstream.TypeStr, cwlog.TypeStr:
+	default:
+		return fmt.Errorf("record_type must be one of %q, %q or %q: %q",
+			cwmetricstream.TypeStr, otlpmetricstream.TypeStr, cwlog.TypeStr, cfg.RecordType)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsfirehosereceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestFactory(t *testing.T) {
+	f := NewFactory()
+	require.Equal(t, config.Type("awsfirehose"), f.Type())
+
+	cfg := f.CreateDefaultConfig()
+	rCfg, ok := cfg.(*Config)
+	require.True(t, ok)
+	require.NoError(t, rCfg.Validate())
+
+	_, err := f.CreateTracesReceiver(
+		context.Background(), componenttest.NewNopReceiverCreateSettings(),
+		rCfg, consumertest.NewNop(),
+	)
+	require.Error(t, err)
+
+	mr, err := f.CreateMetricsReceiver(
+		context.Background(), componenttest.NewNopReceiverCreateSettings(),
+		rCfg, consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, mr)
+
+	_, err = f.CreateLogsReceiver(
+		context.Background(), componenttest.NewNopReceiverCreateSettings(),
+		rCfg, consumertest.NewNop(),
+	)
+	require.EqualError(t, err, `record_type "cwmetrics" does not hold logs`)
+
+	rCfg.RecordType = "cwlogs"
+	lr, err := f.CreateLogsReceiver(
+		context.Background(), componenttest.NewNopReceiverCreateSettings(),
+		rCfg, consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, lr)
+
+	_, err = f.CreateMetricsReceiver(
+		context.Background(), componenttest.NewNopReceiverCreateSettings(),
+		rCfg, consumertest.NewNop(),
+	)
+	require.EqualError(t, err, `record_type "cwlogs" does not hold metrics`)
+
+	_, err = f.CreateLogsReceiver(
+		context.Background(), componenttest.NewNopReceiverCreateSettings(),
+		rCfg, nil,
+	)
+	require.Equal(t, componenterror.ErrNilNextConsumer, err)
+}
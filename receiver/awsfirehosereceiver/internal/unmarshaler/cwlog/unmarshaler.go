@@ -0,0 +1,153 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cwlog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver/internal/unmarshaler"
+)
+
+const (
+	// TypeStr is the record type of CloudWatch Logs subscriptions.
+	TypeStr = "cwlogs"
+
+	// messageTypeData is the type of the messages holding log events, the others are control messages
+	// checking that the destination is reachable.
+	messageTypeData = "DATA_MESSAGE"
+
+	attributeLogEventID = "aws.log.event.id"
+)
+
+// gzipMagic prefixes gzip compressed records.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// cwLogs is a message of a CloudWatch Logs subscription, see
+// https://docs.aws.amazon.com/AmazonCloudWatch/latest/logs/SubscriptionFilters.html#FirehoseExample.
+type cwLogs struct {
+	MessageType         string       `json:"messageType"`
+	Owner               string       `json:"owner"`
+	LogGroup            string       `json:"logGroup"`
+	LogStream           string       `json:"logStream"`
+	SubscriptionFilters []string     `json:"subscriptionFilters"`
+	LogEvents           []cwLogEvent `json:"logEvents"`
+}
+
+type cwLogEvent struct {
+	ID        string `json:"id"`
+	Timestamp int64  `json:"timestamp"`
+	Message   string `json:"message"`
+}
+
+// resourceKey identifies the resource the log events of a subscription belong to.
+type resourceKey struct {
+	owner     string
+	logGroup  string
+	logStream string
+}
+
+// Unmarshaler deserializes the messages of CloudWatch Logs subscriptions.
+type Unmarshaler struct {
+	logger *zap.Logger
+}
+
+var _ unmarshaler.LogsUnmarshaler = (*Unmarshaler)(nil)
+
+// NewUnmarshaler creates an Unmarshaler for CloudWatch Logs subscriptions.
+func NewUnmarshaler(logger *zap.Logger) *Unmarshaler {
+	return &Unmarshaler{logger: logger}
+}
+
+// Unmarshal converts the log events of the records, which may be gzip compressed, into log
+// records grouped by account, log group and log stream. Control messages and invalid records
+// are skipped.
+func (u *Unmarshaler) Unmarshal(records [][]byte) (pdata.Logs, error) {
+	ld := pdata.NewLogs()
+	resources := make(map[resourceKey]pdata.LogSlice)
+	valid := false
+	for recordIndex, record := range records {
+		message, err := decodeRecord(record)
+		if err != nil {
+			u.logger.Debug("Unable to unmarshal record", zap.Int("record", recordIndex), zap.Error(err))
+			continue
+		}
+		valid = true
+		if message.MessageType != messageTypeData {
+			continue
+		}
+
+		key := resourceKey{owner: message.Owner, logGroup: message.LogGroup, logStream: message.LogStream}
+		logs, ok := resources[key]
+		if !ok {
+			rl := ld.ResourceLogs().AppendEmpty()
+			setResourceAttributes(rl.Resource().Attributes(), key)
+			logs = rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+			resources[key] = logs
+		}
+		for _, event := range message.LogEvents {
+			lr := logs.AppendEmpty()
+			lr.SetTimestamp(pdata.TimestampFromTime(time.Unix(0, event.Timestamp*int64(time.Millisecond))))
+			lr.Body().SetStringVal(event.Message)
+			if event.ID != "" {
+				lr.Attributes().InsertString(attributeLogEventID, event.ID)
+			}
+		}
+	}
+
+	if !valid {
+		return ld, unmarshaler.ErrNoValidRecords
+	}
+	return ld, nil
+}
+
+// Type of the serialized records.
+func (u *Unmarshaler) Type() string {
+	return TypeStr
+}
+
+func decodeRecord(record []byte) (cwLogs, error) {
+	var message cwLogs
+	if bytes.HasPrefix(record, gzipMagic) {
+		r, err := gzip.NewReader(bytes.NewReader(record))
+		if err != nil {
+			return message, err
+		}
+		defer r.Close()
+		if record, err = ioutil.ReadAll(r); err != nil {
+			return message, err
+		}
+	}
+	err := json.Unmarshal(record, &message)
+	return message, err
+}
+
+func setResourceAttributes(attrs pdata.AttributeMap, key resourceKey) {
+	attrs.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAWS)
+	attrs.InsertString(conventions.AttributeCloudAccountID, key.owner)
+	logGroupNames := pdata.NewAttributeValueArray()
+	logGroupNames.ArrayVal().AppendEmpty().SetStringVal(key.logGroup)
+	attrs.Insert(conventions.AttributeAWSLogGroupNames, logGroupNames)
+	logStreamNames := pdata.NewAttributeValueArray()
+	logStreamNames.ArrayVal().AppendEmpty().SetStringVal(key.logStream)
+	attrs.Insert(conventions.AttributeAWSLogStreamNames, logStreamNames)
+}
@@ -0,0 +1,90 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cwlog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver/internal/unmarshaler"
+)
+
+const (
+	dataMessage = `{"messageType":"DATA_MESSAGE","owner":"123456789012","logGroup":"/aws/lambda/app","logStream":"2021/08/01/stream",` +
+		`"subscriptionFilters":["firehose"],"logEvents":[` +
+		`{"id":"1","timestamp":1627776000000,"message":"START"},{"id":"2","timestamp":1627776000500,"message":"END"}]}`
+	controlMessage = `{"messageType":"CONTROL_MESSAGE","owner":"CloudwatchLogs","logGroup":"","logStream":"",` +
+		`"subscriptionFilters":[],"logEvents":[{"id":"","timestamp":1627776000000,"message":"CWL CONTROL MESSAGE: Checking health of destination Firehose."}]}`
+)
+
+func gzipRecord(t *testing.T, record string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(record))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestType(t *testing.T) {
+	assert.Equal(t, TypeStr, NewUnmarshaler(zap.NewNop()).Type())
+}
+
+func TestUnmarshal(t *testing.T) {
+	u := NewUnmarshaler(zap.NewNop())
+
+	ld, err := u.Unmarshal([][]byte{gzipRecord(t, dataMessage), []byte(dataMessage), gzipRecord(t, controlMessage)})
+	require.NoError(t, err)
+	require.Equal(t, 1, ld.ResourceLogs().Len())
+	assert.Equal(t, 4, ld.LogRecordCount())
+
+	rl := ld.ResourceLogs().At(0)
+	attrs := rl.Resource().Attributes()
+	accountID, ok := attrs.Get(conventions.AttributeCloudAccountID)
+	require.True(t, ok)
+	assert.Equal(t, "123456789012", accountID.StringVal())
+	logGroups, ok := attrs.Get(conventions.AttributeAWSLogGroupNames)
+	require.True(t, ok)
+	require.Equal(t, 1, logGroups.ArrayVal().Len())
+	assert.Equal(t, "/aws/lambda/app", logGroups.ArrayVal().At(0).StringVal())
+	logStreams, ok := attrs.Get(conventions.AttributeAWSLogStreamNames)
+	require.True(t, ok)
+	assert.Equal(t, "2021/08/01/stream", logStreams.ArrayVal().At(0).StringVal())
+
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(1)
+	assert.Equal(t, "END", lr.Body().StringVal())
+	assert.Equal(t, pdata.Timestamp(1627776000500000000), lr.Timestamp())
+	id, ok := lr.Attributes().Get(attributeLogEventID)
+	require.True(t, ok)
+	assert.Equal(t, "2", id.StringVal())
+}
+
+func TestUnmarshalControlMessage(t *testing.T) {
+	ld, err := NewUnmarshaler(zap.NewNop()).Unmarshal([][]byte{gzipRecord(t, controlMessage)})
+	require.NoError(t, err)
+	assert.Equal(t, 0, ld.LogRecordCount())
+}
+
+func TestUnmarshalInvalidRecords(t *testing.T) {
+	_, err := NewUnmarshaler(zap.NewNop()).Unmarshal([][]byte{[]byte("not json"), {0x1f, 0x8b, 0x00}})
+	assert.Equal(t, unmarshaler.ErrNoValidRecords, err)
+}
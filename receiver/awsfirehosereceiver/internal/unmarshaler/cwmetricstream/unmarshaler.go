@@ -0,0 +1,171 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cwmetricstream
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver/internal/unmarshaler"
+)
+
+const (
+	// TypeStr is the record type of CloudWatch metric streams in the JSON format.
+	TypeStr = "cwmetrics"
+
+	// attributeMetricStreamName is the name of the metric stream the metrics were sent by.
+	attributeMetricStreamName = "aws.cloudwatch.metric_stream_name"
+
+	metricNamePrefix   = "amazonaws.com"
+	namespaceDelimiter = "/"
+)
+
+// cwMetric is a single metric of a CloudWatch metric stream in the JSON format, see
+// https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/CloudWatch-metric-streams-formats-json.html.
+type cwMetric struct {
+	MetricStreamName string            `json:"metric_stream_name"`
+	AccountID        string            `json:"account_id"`
+	Region           string            `json:"region"`
+	Namespace        string            `json:"namespace"`
+	MetricName       string            `json:"metric_name"`
+	Dimensions       map[string]string `json:"dimensions"`
+	Timestamp        int64             `json:"timestamp"`
+	Value            *cwMetricValue    `json:"value"`
+	Unit             string            `json:"unit"`
+}
+
+// cwMetricValue holds the statistics of a metric over the aggregation period.
+type cwMetricValue struct {
+	Max   float64 `json:"max"`
+	Min   float64 `json:"min"`
+	Sum   float64 `json:"sum"`
+	Count float64 `json:"count"`
+}
+
+// resourceKey identifies the resource the metrics of a metric stream belong to.
+type resourceKey struct {
+	metricStreamName string
+	accountID        string
+	region           string
+	namespace        string
+}
+
+// Unmarshaler deserializes the newline delimited JSON metrics of CloudWatch metric streams.
+type Unmarshaler struct {
+	logger *zap.Logger
+}
+
+var _ unmarshaler.MetricsUnmarshaler = (*Unmarshaler)(nil)
+
+// NewUnmarshaler creates an Unmarshaler for CloudWatch metric streams in the JSON format.
+func NewUnmarshaler(logger *zap.Logger) *Unmarshaler {
+	return &Unmarshaler{logger: logger}
+}
+
+// Unmarshal converts the metrics of the records into summaries with the minimum and maximum
+// as the 0 and 1 quantiles, grouped by metric stream, account, region and namespace. Invalid
+// metrics are skipped.
+func (u *Unmarshaler) Unmarshal(records [][]byte) (pdata.Metrics, error) {
+	md := pdata.NewMetrics()
+	resources := make(map[resourceKey]pdata.MetricSlice)
+	for recordIndex, record := range records {
+		for lineIndex, line := range bytes.Split(record, []byte("\n")) {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			var metric cwMetric
+			if err := json.Unmarshal(line, &metric); err != nil {
+				u.logger.Debug("Unable to unmarshal metric", zap.Int("record", recordIndex), zap.Int("line", lineIndex), zap.Error(err))
+				continue
+			}
+			if !isValid(metric) {
+				u.logger.Debug("Invalid metric", zap.Int("record", recordIndex), zap.Int("line", lineIndex))
+				continue
+			}
+
+			key := resourceKey{
+				metricStreamName: metric.MetricStreamName,
+				accountID:        metric.AccountID,
+				region:           metric.Region,
+				namespace:        metric.Namespace,
+			}
+			metrics, ok := resources[key]
+			if !ok {
+				rm := md.ResourceMetrics().AppendEmpty()
+				setResourceAttributes(rm.Resource().Attributes(), key)
+				metrics = rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+				resources[key] = metrics
+			}
+			appendMetric(metrics, metric)
+		}
+	}
+
+	if len(resources) == 0 {
+		return md, unmarshaler.ErrNoValidRecords
+	}
+	return md, nil
+}
+
+// Type of the serialized records.
+func (u *Unmarshaler) Type() string {
+	return TypeStr
+}
+
+func isValid(metric cwMetric) bool {
+	return metric.MetricName != "" && metric.Namespace != "" && metric.Value != nil
+}
+
+func setResourceAttributes(attrs pdata.AttributeMap, key resourceKey) {
+	attrs.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAWS)
+	attrs.InsertString(conventions.AttributeCloudAccountID, key.accountID)
+	attrs.InsertString(conventions.AttributeCloudRegion, key.region)
+	if key.metricStreamName != "" {
+		attrs.InsertString(attributeMetricStreamName, key.metricStreamName)
+	}
+	// AWS namespaces are made of the provider and the service, e.g. AWS/EC2.
+	if i := strings.Index(key.namespace, namespaceDelimiter); i >= 0 {
+		attrs.InsertString(conventions.AttributeServiceNamespace, key.namespace[:i])
+		attrs.InsertString(conventions.AttributeServiceName, key.namespace[i+1:])
+	} else {
+		attrs.InsertString(conventions.AttributeServiceName, key.namespace)
+	}
+}
+
+func appendMetric(metrics pdata.MetricSlice, metric cwMetric) {
+	m := metrics.AppendEmpty()
+	m.SetName(strings.Join([]string{metricNamePrefix, metric.Namespace, metric.MetricName}, namespaceDelimiter))
+	m.SetUnit(metric.Unit)
+	m.SetDataType(pdata.MetricDataTypeSummary)
+
+	dp := m.Summary().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.TimestampFromTime(time.Unix(0, metric.Timestamp*int64(time.Millisecond))))
+	dp.SetCount(uint64(metric.Value.Count))
+	dp.SetSum(metric.Value.Sum)
+	for name, value := range metric.Dimensions {
+		dp.LabelsMap().Insert(name, value)
+	}
+	minQuantile := dp.QuantileValues().AppendEmpty()
+	minQuantile.SetQuantile(0)
+	minQuantile.SetValue(metric.Value.Min)
+	maxQuantile := dp.QuantileValues().AppendEmpty()
+	maxQuantile.SetQuantile(1)
+	maxQuantile.SetValue(metric.Value.Max)
+}
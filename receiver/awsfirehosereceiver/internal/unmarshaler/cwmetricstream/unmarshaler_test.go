@@ -0,0 +1,91 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cwmetricstream
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver/internal/unmarshaler"
+)
+
+func TestType(t *testing.T) {
+	assert.Equal(t, TypeStr, NewUnmarshaler(zap.NewNop()).Type())
+}
+
+func TestUnmarshal(t *testing.T) {
+	record, err := ioutil.ReadFile(path.Join("testdata", "metrics.json"))
+	require.NoError(t, err)
+
+	md, err := NewUnmarshaler(zap.NewNop()).Unmarshal([][]byte{record})
+	require.NoError(t, err)
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+	assert.Equal(t, 3, md.MetricCount())
+
+	rm := md.ResourceMetrics().At(0)
+	attrs := rm.Resource().Attributes()
+	for key, expected := range map[string]string{
+		conventions.AttributeCloudProvider:    conventions.AttributeCloudProviderAWS,
+		conventions.AttributeCloudAccountID:   "1234567890",
+		conventions.AttributeCloudRegion:      "us-east-1",
+		conventions.AttributeServiceNamespace: "AWS",
+		conventions.AttributeServiceName:      "EC2",
+		attributeMetricStreamName:             "stream",
+	} {
+		value, ok := attrs.Get(key)
+		require.True(t, ok, key)
+		assert.Equal(t, expected, value.StringVal(), key)
+	}
+
+	metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	m := metrics.At(0)
+	assert.Equal(t, "amazonaws.com/AWS/EC2/CPUUtilization", m.Name())
+	assert.Equal(t, "Percent", m.Unit())
+	require.Equal(t, pdata.MetricDataTypeSummary, m.DataType())
+	dp := m.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, 40.0, dp.Sum())
+	assert.Equal(t, pdata.Timestamp(1611929698000000000), dp.Timestamp())
+	instanceID, ok := dp.LabelsMap().Get("InstanceId")
+	require.True(t, ok)
+	assert.Equal(t, "i-123456789012", instanceID)
+	require.Equal(t, 2, dp.QuantileValues().Len())
+	assert.Equal(t, 0.0, dp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 1.5, dp.QuantileValues().At(0).Value())
+	assert.Equal(t, 1.0, dp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 12.5, dp.QuantileValues().At(1).Value())
+}
+
+func TestUnmarshalInvalidRecords(t *testing.T) {
+	u := NewUnmarshaler(zap.NewNop())
+
+	_, err := u.Unmarshal([][]byte{[]byte("not json"), []byte(`{"metric_name":"NoValue","namespace":"AWS/EC2"}`)})
+	assert.Equal(t, unmarshaler.ErrNoValidRecords, err)
+
+	// Invalid metrics are skipped.
+	md, err := u.Unmarshal([][]byte{
+		[]byte("not json\n" + `{"namespace":"AWS/EC2","metric_name":"CPUUtilization","value":{"max":1,"min":1,"sum":1,"count":1}}` + "\n"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, md.MetricCount())
+}
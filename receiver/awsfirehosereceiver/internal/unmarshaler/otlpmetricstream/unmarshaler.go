@@ -0,0 +1,88 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetricstream
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver/internal/unmarshaler"
+)
+
+// TypeStr is the record type of CloudWatch metric streams in the OpenTelemetry 0.7 format.
+const TypeStr = "otlp_v1"
+
+var errInvalidLength = errors.New("invalid length prefix")
+
+// Unmarshaler deserializes the records of CloudWatch metric streams in the OpenTelemetry 0.7
+// format, each made of ExportMetricsServiceRequest messages prefixed with their varint length, see
+// https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/CloudWatch-metric-streams-formats-opentelemetry.html.
+// The metrics are summaries whose dimensions are held by the labels of the data points, which are
+// wire compatible with the OTLP version of the collector.
+type Unmarshaler struct {
+	logger      *zap.Logger
+	unmarshaler pdata.MetricsUnmarshaler
+}
+
+var _ unmarshaler.MetricsUnmarshaler = (*Unmarshaler)(nil)
+
+// NewUnmarshaler creates an Unmarshaler for CloudWatch metric streams in the OpenTelemetry 0.7 format.
+func NewUnmarshaler(logger *zap.Logger) *Unmarshaler {
+	return &Unmarshaler{
+		logger:      logger,
+		unmarshaler: otlp.NewProtobufMetricsUnmarshaler(),
+	}
+}
+
+// Unmarshal merges the metrics of all the requests of the records. The remainder of a record
+// is skipped once one of its requests cannot be unmarshaled.
+func (u *Unmarshaler) Unmarshal(records [][]byte) (pdata.Metrics, error) {
+	md := pdata.NewMetrics()
+	for recordIndex, record := range records {
+		if err := u.unmarshalRecord(record, md); err != nil {
+			u.logger.Debug("Unable to unmarshal record", zap.Int("record", recordIndex), zap.Error(err))
+		}
+	}
+
+	if md.ResourceMetrics().Len() == 0 {
+		return md, unmarshaler.ErrNoValidRecords
+	}
+	return md, nil
+}
+
+func (u *Unmarshaler) unmarshalRecord(record []byte, md pdata.Metrics) error {
+	for len(record) > 0 {
+		length, n := binary.Uvarint(record)
+		if n <= 0 || uint64(len(record)-n) < length {
+			return errInvalidLength
+		}
+		request, err := u.unmarshaler.UnmarshalMetrics(record[n : n+int(length)])
+		if err != nil {
+			return err
+		}
+		request.ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+		record = record[n+int(length):]
+	}
+	return nil
+}
+
+// Type of the serialized records.
+func (u *Unmarshaler) Type() string {
+	return TypeStr
+}
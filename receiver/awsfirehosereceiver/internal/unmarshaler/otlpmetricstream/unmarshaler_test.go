@@ -0,0 +1,84 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetricstream
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver/internal/unmarshaler"
+)
+
+func createMetrics(t *testing.T, metricNames ...string) []byte {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("cloud.provider", "aws")
+	metrics := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	for _, name := range metricNames {
+		m := metrics.AppendEmpty()
+		m.SetName(name)
+		m.SetDataType(pdata.MetricDataTypeSummary)
+		dp := m.Summary().DataPoints().AppendEmpty()
+		dp.SetCount(1)
+		dp.LabelsMap().Insert("InstanceId", "i-123456789012")
+	}
+	request, err := otlp.NewProtobufMetricsMarshaler().MarshalMetrics(md)
+	require.NoError(t, err)
+
+	buf := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(request))
+	n := binary.PutUvarint(buf, uint64(len(request)))
+	return append(buf[:n], request...)
+}
+
+func TestType(t *testing.T) {
+	assert.Equal(t, TypeStr, NewUnmarshaler(zap.NewNop()).Type())
+}
+
+func TestUnmarshal(t *testing.T) {
+	u := NewUnmarshaler(zap.NewNop())
+
+	first := createMetrics(t, "amazonaws.com/AWS/EC2/CPUUtilization", "amazonaws.com/AWS/EC2/NetworkIn")
+	second := createMetrics(t, "amazonaws.com/AWS/SQS/NumberOfMessagesSent")
+	md, err := u.Unmarshal([][]byte{append(first, second...), second})
+	require.NoError(t, err)
+	assert.Equal(t, 3, md.ResourceMetrics().Len())
+	assert.Equal(t, 4, md.MetricCount())
+
+	m := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "amazonaws.com/AWS/EC2/CPUUtilization", m.Name())
+	require.Equal(t, pdata.MetricDataTypeSummary, m.DataType())
+	instanceID, ok := m.Summary().DataPoints().At(0).LabelsMap().Get("InstanceId")
+	require.True(t, ok)
+	assert.Equal(t, "i-123456789012", instanceID)
+}
+
+func TestUnmarshalInvalidRecords(t *testing.T) {
+	u := NewUnmarshaler(zap.NewNop())
+	valid := createMetrics(t, "amazonaws.com/AWS/EC2/CPUUtilization")
+
+	_, err := u.Unmarshal([][]byte{{0xff}, valid[:len(valid)-1]})
+	assert.Equal(t, unmarshaler.ErrNoValidRecords, err)
+
+	// The requests preceding an invalid one are kept.
+	md, err := u.Unmarshal([][]byte{append(valid, 0x05, 0x01)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, md.MetricCount())
+}
@@ -0,0 +1,42 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package unmarshaler defines the interfaces of the unmarshalers of the records delivered by
+// Kinesis Data Firehose.
+package unmarshaler
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// ErrNoValidRecords is returned when none of the records could be unmarshaled.
+var ErrNoValidRecords = errors.New("no valid records")
+
+// MetricsUnmarshaler deserializes the records of a Firehose request into metrics.
+type MetricsUnmarshaler interface {
+	// Unmarshal deserializes the records into metrics.
+	Unmarshal(records [][]byte) (pdata.Metrics, error)
+	// Type of the serialized records.
+	Type() string
+}
+
+// LogsUnmarshaler deserializes the records of a Firehose request into logs.
+type LogsUnmarshaler interface {
+	// Unmarshal deserializes the records into logs.
+	Unmarshal(records [][]byte) (pdata.Logs, error)
+	// Type of the serialized records.
+	Type() string
+}
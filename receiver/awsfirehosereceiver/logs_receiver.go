@@ -0,0 +1,78 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsfirehosereceiver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver/internal/unmarshaler"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver/internal/unmarshaler/cwlog"
+)
+
+// logsConsumer unmarshals the records into logs for the next consumer.
+type logsConsumer struct {
+	unmarshaler  unmarshaler.LogsUnmarshaler
+	nextConsumer consumer.Logs
+	obsrecv      *obsreport.Receiver
+}
+
+var _ firehoseConsumer = (*logsConsumer)(nil)
+
+// newLogsReceiver creates a receiver for the record types holding logs.
+func newLogsReceiver(config *Config, logger *zap.Logger, nextConsumer consumer.Logs) (component.LogsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
+	var u unmarshaler.LogsUnmarshaler
+	switch config.RecordType {
+	case cwlog.TypeStr:
+		u = cwlog.NewUnmarshaler(logger)
+	default:
+		return nil, fmt.Errorf("record_type %q does not hold logs", config.RecordType)
+	}
+
+	return &firehoseReceiver{
+		logger: logger,
+		config: config,
+		consumer: &logsConsumer{
+			unmarshaler:  u,
+			nextConsumer: nextConsumer,
+			obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: config.ID(), Transport: "http"}),
+		},
+	}, nil
+}
+
+// Consume unmarshals the records into logs and passes them on to the next consumer.
+func (lc *logsConsumer) Consume(ctx context.Context, records [][]byte) (int, error) {
+	ctx = lc.obsrecv.StartLogsOp(ctx)
+	ld, err := lc.unmarshaler.Unmarshal(records)
+	if err != nil {
+		lc.obsrecv.EndLogsOp(ctx, lc.unmarshaler.Type(), 0, err)
+		return http.StatusBadRequest, err
+	}
+
+	err = lc.nextConsumer.ConsumeLogs(ctx, ld)
+	lc.obsrecv.EndLogsOp(ctx, lc.unmarshaler.Type(), ld.LogRecordCount(), err)
+	return consumeStatusCode(err), err
+}
@@ -0,0 +1,81 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsfirehosereceiver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver/internal/unmarshaler"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver/internal/unmarshaler/cwmetricstream"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver/internal/unmarshaler/otlpmetricstream"
+)
+
+// metricsConsumer unmarshals the records into metrics for the next consumer.
+type metricsConsumer struct {
+	unmarshaler  unmarshaler.MetricsUnmarshaler
+	nextConsumer consumer.Metrics
+	obsrecv      *obsreport.Receiver
+}
+
+var _ firehoseConsumer = (*metricsConsumer)(nil)
+
+// newMetricsReceiver creates a receiver for the record types holding metrics.
+func newMetricsReceiver(config *Config, logger *zap.Logger, nextConsumer consumer.Metrics) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
+	var u unmarshaler.MetricsUnmarshaler
+	switch config.RecordType {
+	case cwmetricstream.TypeStr:
+		u = cwmetricstream.NewUnmarshaler(logger)
+	case otlpmetricstream.TypeStr:
+		u = otlpmetricstream.NewUnmarshaler(logger)
+	default:
+		return nil, fmt.Errorf("record_type %q does not hold metrics", config.RecordType)
+	}
+
+	return &firehoseReceiver{
+		logger: logger,
+		config: config,
+		consumer: &metricsConsumer{
+			unmarshaler:  u,
+			nextConsumer: nextConsumer,
+			obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: config.ID(), Transport: "http"}),
+		},
+	}, nil
+}
+
+// Consume unmarshals the records into metrics and passes them on to the next consumer.
+func (mc *metricsConsumer) Consume(ctx context.Context, records [][]byte) (int, error) {
+	ctx = mc.obsrecv.StartMetricsOp(ctx)
+	md, err := mc.unmarshaler.Unmarshal(records)
+	if err != nil {
+		mc.obsrecv.EndMetricsOp(ctx, mc.unmarshaler.Type(), 0, err)
+		return http.StatusBadRequest, err
+	}
+
+	err = mc.nextConsumer.ConsumeMetrics(ctx, md)
+	mc.obsrecv.EndMetricsOp(ctx, mc.unmarshaler.Type(), md.DataPointCount(), err)
+	return consumeStatusCode(err), err
+}
@@ -0,0 +1,202 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsfirehosereceiver
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+)
+
+const (
+	headerFirehoseRequestID = "X-Amz-Firehose-Request-Id"
+	headerFirehoseAccessKey = "X-Amz-Firehose-Access-Key"
+	headerContentType       = "Content-Type"
+	headerContentEncoding   = "Content-Encoding"
+
+	contentTypeJSON = "application/json"
+	gzipEncoding    = "gzip"
+)
+
+var (
+	errMissingRequestID  = errors.New("missing request id in header")
+	errInvalidAccessKey  = errors.New("invalid firehose access key")
+	errRequestIDMismatch = errors.New("request id in body does not match the header")
+)
+
+// firehoseConsumer passes the records of a Firehose request on to the next consumer.
+type firehoseConsumer interface {
+	// Consume unmarshals and consumes the records, and returns the status code of the response.
+	Consume(ctx context.Context, records [][]byte) (int, error)
+}
+
+// firehoseRequest is the body of the requests of the Firehose HTTP endpoint delivery, see
+// https://docs.aws.amazon.com/firehose/latest/dev/httpdeliveryrequestresponse.html.
+type firehoseRequest struct {
+	RequestID string           `json:"requestId"`
+	Timestamp int64            `json:"timestamp"`
+	Records   []firehoseRecord `json:"records"`
+}
+
+// firehoseRecord holds the base64 encoded data of a record.
+type firehoseRecord struct {
+	Data string `json:"data"`
+}
+
+// firehoseResponse is the body of the responses expected by Firehose.
+type firehoseResponse struct {
+	RequestID    string `json:"requestId"`
+	Timestamp    int64  `json:"timestamp"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// firehoseReceiver receives the records delivered by Kinesis Data Firehose to an HTTP endpoint.
+type firehoseReceiver struct {
+	logger   *zap.Logger
+	config   *Config
+	server   *http.Server
+	consumer firehoseConsumer
+}
+
+var _ component.Receiver = (*firehoseReceiver)(nil)
+var _ http.Handler = (*firehoseReceiver)(nil)
+
+// Start starts the HTTP server receiving the Firehose requests.
+func (fr *firehoseReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := fr.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", fr.config.Endpoint, err)
+	}
+
+	fr.server = fr.config.HTTPServerSettings.ToServer(fr)
+	go func() {
+		if errHTTP := fr.server.Serve(ln); errHTTP != http.ErrServerClosed {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (fr *firehoseReceiver) Shutdown(context.Context) error {
+	if fr.server == nil {
+		return nil
+	}
+	return fr.server.Close()
+}
+
+// ServeHTTP validates the Firehose request and passes its records on to the consumer.
+func (fr *firehoseReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestID := r.Header.Get(headerFirehoseRequestID)
+	if requestID == "" {
+		fr.sendResponse(w, requestID, http.StatusBadRequest, errMissingRequestID)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		fr.sendResponse(w, requestID, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+		return
+	}
+
+	if fr.config.AccessKey != "" &&
+		subtle.ConstantTimeCompare([]byte(r.Header.Get(headerFirehoseAccessKey)), []byte(fr.config.AccessKey)) != 1 {
+		fr.sendResponse(w, requestID, http.StatusUnauthorized, errInvalidAccessKey)
+		return
+	}
+
+	records, err := fr.readRecords(r, requestID)
+	if err != nil {
+		fr.sendResponse(w, requestID, http.StatusBadRequest, err)
+		return
+	}
+
+	statusCode, err := fr.consumer.Consume(r.Context(), records)
+	if err != nil {
+		fr.logger.Debug("Unable to consume the records", zap.String("request_id", requestID), zap.Error(err))
+	}
+	fr.sendResponse(w, requestID, statusCode, err)
+}
+
+// readRecords decodes the records of the request body, which may be gzip compressed.
+func (fr *firehoseReceiver) readRecords(r *http.Request, requestID string) ([][]byte, error) {
+	var body io.Reader = r.Body
+	if r.Header.Get(headerContentEncoding) == gzipEncoding {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		body = gz
+	}
+
+	var request firehoseRequest
+	if err := json.NewDecoder(body).Decode(&request); err != nil {
+		return nil, fmt.Errorf("failed to decode the request body: %w", err)
+	}
+	if request.RequestID != requestID {
+		return nil, errRequestIDMismatch
+	}
+
+	records := make([][]byte, 0, len(request.Records))
+	for i, record := range request.Records {
+		data, err := base64.StdEncoding.DecodeString(record.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the data of record %d: %w", i, err)
+		}
+		records = append(records, data)
+	}
+	return records, nil
+}
+
+// sendResponse writes the response Firehose expects, with the error message on failures.
+func (fr *firehoseReceiver) sendResponse(w http.ResponseWriter, requestID string, statusCode int, err error) {
+	response := firehoseResponse{
+		RequestID: requestID,
+		Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+	}
+	if err != nil {
+		response.ErrorMessage = err.Error()
+	}
+	payload, _ := json.Marshal(response)
+	w.Header().Set(headerContentType, contentTypeJSON)
+	w.WriteHeader(statusCode)
+	if _, err = w.Write(payload); err != nil {
+		fr.logger.Debug("Failed to write the response", zap.Error(err))
+	}
+}
+
+// consumeStatusCode is the status code of the response to a request whose records failed to be
+// consumed with err. Firehose retries the requests failing with a server error.
+func consumeStatusCode(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case consumererror.IsPermanent(err):
+		return http.StatusBadRequest
+	default:
+		return http.StatusServiceUnavailable
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsfirehosereceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/testutil"
+	"go.uber.org/zap"
+)
+
+const (
+	testRequestID = "ed4acda5-034f-9f42-bba1-f29aea6d7d8f"
+	testAccessKey = "test-access-key"
+
+	cwMetric = `{"metric_stream_name":"stream","account_id":"1234567890","region":"us-east-1","namespace":"AWS/EC2",` +
+		`"metric_name":"CPUUtilization","dimensions":{"InstanceId":"i-123456789012"},"timestamp":1611929698000,` +
+		`"value":{"max":12.5,"min":1.5,"sum":40,"count":5},"unit":"Percent"}`
+	cwLogs = `{"messageType":"DATA_MESSAGE","owner":"123456789012","logGroup":"group","logStream":"stream",` +
+		`"subscriptionFilters":["firehose"],"logEvents":[{"id":"1","timestamp":1627776000000,"message":"hello"}]}`
+)
+
+func firehoseRequestBody(t *testing.T, requestID string, records ...string) []byte {
+	request := firehoseRequest{RequestID: requestID, Timestamp: 1611929699000}
+	for _, record := range records {
+		request.Records = append(request.Records, firehoseRecord{Data: base64.StdEncoding.EncodeToString([]byte(record))})
+	}
+	body, err := json.Marshal(request)
+	require.NoError(t, err)
+	return body
+}
+
+func newFirehoseRequest(body []byte) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set(headerContentType, contentTypeJSON)
+	req.Header.Set(headerFirehoseRequestID, testRequestID)
+	req.Header.Set(headerFirehoseAccessKey, testAccessKey)
+	return req
+}
+
+func testConfig(recordType string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RecordType = recordType
+	cfg.AccessKey = testAccessKey
+	return cfg
+}
+
+func serve(t *testing.T, fr *firehoseReceiver, req *http.Request) (int, firehoseResponse) {
+	w := httptest.NewRecorder()
+	fr.ServeHTTP(w, req)
+
+	var response firehoseResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, contentTypeJSON, w.Header().Get(headerContentType))
+	return w.Code, response
+}
+
+func TestMetricsRequest(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	r, err := newMetricsReceiver(testConfig("cwmetrics"), zap.NewNop(), sink)
+	require.NoError(t, err)
+	fr := r.(*firehoseReceiver)
+
+	code, response := serve(t, fr, newFirehoseRequest(firehoseRequestBody(t, testRequestID, cwMetric, cwMetric+"\n"+cwMetric)))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, testRequestID, response.RequestID)
+	assert.Empty(t, response.ErrorMessage)
+	assert.NotZero(t, response.Timestamp)
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 3, sink.AllMetrics()[0].MetricCount())
+}
+
+func TestLogsRequest(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	r, err := newLogsReceiver(testConfig("cwlogs"), zap.NewNop(), sink)
+	require.NoError(t, err)
+	fr := r.(*firehoseReceiver)
+
+	var body bytes.Buffer
+	gz := gzip.NewWriter(&body)
+	_, err = gz.Write(firehoseRequestBody(t, testRequestID, cwLogs))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	req := newFirehoseRequest(body.Bytes())
+	req.Header.Set(headerContentEncoding, gzipEncoding)
+
+	code, response := serve(t, fr, req)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, response.ErrorMessage)
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestInvalidRequests(t *testing.T) {
+	validBody := firehoseRequestBody(t, testRequestID, cwMetric)
+	testCases := []struct {
+		name         string
+		req          func() *http.Request
+		expectedCode int
+		expectedErr  string
+	}{
+		{
+			name: "missing request id",
+			req: func() *http.Request {
+				req := newFirehoseRequest(validBody)
+				req.Header.Del(headerFirehoseRequestID)
+				return req
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  errMissingRequestID.Error(),
+		},
+		{
+			name: "invalid method",
+			req: func() *http.Request {
+				req := newFirehoseRequest(validBody)
+				req.Method = http.MethodGet
+				return req
+			},
+			expectedCode: http.StatusMethodNotAllowed,
+			expectedErr:  "method GET is not allowed",
+		},
+		{
+			name: "invalid access key",
+			req: func() *http.Request {
+				req := newFirehoseRequest(validBody)
+				req.Header.Set(headerFirehoseAccessKey, "wrong")
+				return req
+			},
+			expectedCode: http.StatusUnauthorized,
+			expectedErr:  errInvalidAccessKey.Error(),
+		},
+		{
+			name: "request id mismatch",
+			req: func() *http.Request {
+				return newFirehoseRequest(firehoseRequestBody(t, "other", cwMetric))
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  errRequestIDMismatch.Error(),
+		},
+		{
+			name: "invalid body",
+			req: func() *http.Request {
+				return newFirehoseRequest([]byte("{"))
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid record data",
+			req: func() *http.Request {
+				return newFirehoseRequest([]byte(fmt.Sprintf(`{"requestId":%q,"records":[{"data":"%%"}]}`, testRequestID)))
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "no valid records",
+			req: func() *http.Request {
+				return newFirehoseRequest(firehoseRequestBody(t, testRequestID, "not json"))
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "no valid records",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			r, err := newMetricsReceiver(testConfig("cwmetrics"), zap.NewNop(), sink)
+			require.NoError(t, err)
+
+			code, response := serve(t, r.(*firehoseReceiver), tc.req())
+			assert.Equal(t, tc.expectedCode, code)
+			assert.NotEmpty(t, response.ErrorMessage)
+			if tc.expectedErr != "" {
+				assert.Equal(t, tc.expectedErr, response.ErrorMessage)
+			}
+			assert.Empty(t, sink.AllMetrics())
+		})
+	}
+}
+
+func TestConsumerErrors(t *testing.T) {
+	testCases := []struct {
+		err          error
+		expectedCode int
+	}{
+		{err: errors.New("temporary"), expectedCode: http.StatusServiceUnavailable},
+		{err: consumererror.Permanent(errors.New("permanent")), expectedCode: http.StatusBadRequest},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			r, err := newMetricsReceiver(testConfig("cwmetrics"), zap.NewNop(), consumertest.NewErr(tc.err))
+			require.NoError(t, err)
+
+			code, response := serve(t, r.(*firehoseReceiver), newFirehoseRequest(firehoseRequestBody(t, testRequestID, cwMetric)))
+			assert.Equal(t, tc.expectedCode, code)
+			assert.Contains(t, response.ErrorMessage, tc.err.Error())
+		})
+	}
+}
+
+func TestWithoutAccessKey(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := testConfig("cwmetrics")
+	cfg.AccessKey = ""
+	r, err := newMetricsReceiver(cfg, zap.NewNop(), sink)
+	require.NoError(t, err)
+
+	req := newFirehoseRequest(firehoseRequestBody(t, testRequestID, cwMetric))
+	req.Header.Del(headerFirehoseAccessKey)
+	code, _ := serve(t, r.(*firehoseReceiver), req)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, sink.AllMetrics(), 1)
+}
+
+func TestStartShutdown(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := testConfig("cwmetrics")
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	r, err := newMetricsReceiver(cfg, zap.NewNop(), sink)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, r.Shutdown(ctx))
+	}()
+
+	req, err := http.NewRequest(http.MethodPost, "http://"+cfg.Endpoint, bytes.NewReader(firehoseRequestBody(t, testRequestID, cwMetric)))
+	require.NoError(t, err)
+	req.Header.Set(headerFirehoseRequestID, testRequestID)
+	req.Header.Set(headerFirehoseAccessKey, testAccessKey)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, sink.AllMetrics(), 1)
+}
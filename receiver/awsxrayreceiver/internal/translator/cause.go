@@ -90,21 +90,30 @@ func convertStackFramesToStackTraceStr(excp awsxray.Exception) string {
 	// resulting stacktrace looks like:
 	// "<*excp.Type>: <*excp.Message>\n" +
 	// "\tat <*frameN.Label>(<*frameN.Path>: <*frameN.Line>)\n"
+	// all the fields are optional, the missing ones are left empty.
+	excpType := stringOrEmpty(excp.Type)
+	excpMessage := stringOrEmpty(excp.Message)
+
 	var b strings.Builder
-	b.Grow(len(*excp.Type) + len(": ") + len(*excp.Message) + len("\n"))
-	b.WriteString(*excp.Type)
+	b.Grow(len(excpType) + len(": ") + len(excpMessage) + len("\n"))
+	b.WriteString(excpType)
 	b.WriteString(": ")
-	b.WriteString(*excp.Message)
+	b.WriteString(excpMessage)
 	b.WriteString("\n")
 	for _, frame := range excp.Stack {
-		line := strconv.Itoa(*frame.Line)
+		label := stringOrEmpty(frame.Label)
+		path := stringOrEmpty(frame.Path)
+		line := ""
+		if frame.Line != nil {
+			line = strconv.Itoa(*frame.Line)
+		}
 		// the string representation of a frame looks like:
 		// <*frame.Label>(<*frame.Path>):line\n
-		b.Grow(4 + len(*frame.Label) + 2 + len(*frame.Path) + len(": ") + len(line) + len("\n"))
+		b.Grow(4 + len(label) + 2 + len(path) + len(": ") + len(line) + len("\n"))
 		b.WriteString("\tat ")
-		b.WriteString(*frame.Label)
+		b.WriteString(label)
 		b.WriteString("(")
-		b.WriteString(*frame.Path)
+		b.WriteString(path)
 		b.WriteString(": ")
 		b.WriteString(line)
 		b.WriteString(")")
@@ -112,3 +121,69 @@ func convertStackFramesToStackTraceStr(excp awsxray.Exception) string {
 	}
 	return b.String()
 }
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// addCauseLinks links the spans whose cause is an exception recorded by
+// another (sub)segment of the same document to the span of that
+// (sub)segment, so that the cause chains across subsegments are kept.
+// `segs` must be in the same order as `spans`.
+func addCauseLinks(segs []*awsxray.Segment, spans *pdata.SpanSlice) {
+	// the span recording each exception of the document
+	exceptionSpans := make(map[string]pdata.SpanID)
+	for i, seg := range segs {
+		if seg.Cause == nil || seg.Cause.Type != awsxray.CauseTypeObject {
+			continue
+		}
+		for _, excp := range seg.Cause.Exceptions {
+			if excp.ID != nil {
+				exceptionSpans[*excp.ID] = spans.At(i).SpanID()
+			}
+		}
+	}
+	if len(exceptionSpans) == 0 {
+		return
+	}
+
+	for i, seg := range segs {
+		span := spans.At(i)
+		linked := make(map[string]bool)
+		for _, causeID := range causeExceptionIDs(seg) {
+			causeSpanID, ok := exceptionSpans[causeID]
+			if !ok || causeSpanID == span.SpanID() || linked[causeID] {
+				continue
+			}
+			linked[causeID] = true
+
+			link := span.Links().AppendEmpty()
+			link.SetTraceID(span.TraceID())
+			link.SetSpanID(causeSpanID)
+			link.Attributes().UpsertString(awsxray.AWSXrayExceptionIDAttribute, causeID)
+		}
+	}
+}
+
+// causeExceptionIDs returns the IDs of the exceptions the cause of seg refers to.
+func causeExceptionIDs(seg *awsxray.Segment) []string {
+	if seg.Cause == nil {
+		return nil
+	}
+	switch seg.Cause.Type {
+	case awsxray.CauseTypeExceptionID:
+		return []string{*seg.Cause.ExceptionID}
+	case awsxray.CauseTypeObject:
+		var ids []string
+		for _, excp := range seg.Cause.Exceptions {
+			if excp.Cause != nil {
+				ids = append(ids, *excp.Cause)
+			}
+		}
+		return ids
+	}
+	return nil
+}
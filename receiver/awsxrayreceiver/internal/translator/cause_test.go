@@ -43,3 +43,20 @@ func TestConvertStackFramesToStackTraceStr(t *testing.T) {
 	actual := convertStackFramesToStackTraceStr(excp)
 	assert.Equal(t, actual, "exceptionType: exceptionMessage\n\tat label0(path0: 10)\n\tat label1(path1: 11)\n")
 }
+
+func TestConvertStackFramesToStackTraceStrWithMissingFields(t *testing.T) {
+	excp := awsxray.Exception{
+		Message: awsxray.String("exceptionMessage"),
+		Stack: []awsxray.StackFrame{
+			{
+				Path: awsxray.String("path0"),
+			},
+			{
+				Line:  aws.Int(11),
+				Label: awsxray.String("label1"),
+			},
+		},
+	}
+	actual := convertStackFramesToStackTraceStr(excp)
+	assert.Equal(t, actual, ": exceptionMessage\n\tat (path0: )\n\tat label1(: 11)\n")
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
 
 	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
 )
@@ -40,6 +41,16 @@ func addNameAndNamespace(seg *awsxray.Segment, span *pdata.Span) error {
 		span.SetKind(pdata.SpanKindServer)
 	}
 
+	attrs := span.Attributes()
+
+	if seg.Inferred != nil && *seg.Inferred {
+		// Inferred segments describe an uninstrumented downstream service as
+		// seen by its caller, which OTel represents as a client span to the peer.
+		span.SetKind(pdata.SpanKindClient)
+		attrs.UpsertString(conventions.AttributePeerService, *seg.Name)
+		return nil
+	}
+
 	if seg.Namespace == nil {
 		if span.Kind() == pdata.SpanKindUnspecified {
 			span.SetKind(pdata.SpanKindInternal)
@@ -49,7 +60,6 @@ func addNameAndNamespace(seg *awsxray.Segment, span *pdata.Span) error {
 
 	// seg is a subsegment
 
-	attrs := span.Attributes()
 	// https://github.com/open-telemetry/opentelemetry-collector-contrib/blob/c615d2db351929b99e46f7b427f39c12afe15b54/exporter/awsxrayexporter/translator/segment.go#L163
 	// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/trace/api.md#spankind
 	span.SetKind(pdata.SpanKindClient)
@@ -57,9 +67,12 @@ func addNameAndNamespace(seg *awsxray.Segment, span *pdata.Span) error {
 	case validAWSNamespace:
 		// https://github.com/open-telemetry/opentelemetry-collector-contrib/blob/c615d2db351929b99e46f7b427f39c12afe15b54/exporter/awsxrayexporter/translator/segment.go#L116
 		attrs.UpsertString(awsxray.AWSServiceAttribute, *seg.Name)
+		attrs.UpsertString(conventions.AttributePeerService, *seg.Name)
 
 	case validRemoteNamespace:
-		// no op
+		// the name of a remote subsegment is the one of the downstream service,
+		// which X-Ray shows as an inferred node of the service graph.
+		attrs.UpsertString(conventions.AttributePeerService, *seg.Name)
 	default:
 		return fmt.Errorf("unexpected namespace: %s", *seg.Namespace)
 	}
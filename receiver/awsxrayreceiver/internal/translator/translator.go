@@ -77,6 +77,10 @@ func ToTraces(rawSeg []byte) (*pdata.Traces, int, error) {
 		return nil, count, err
 	}
 
+	// the spans were appended in the same (pre-)order as the flattened
+	// segment and subsegments.
+	addCauseLinks(flattenSegments(&seg, make([]*awsxray.Segment, 0, count)), &spans)
+
 	return &traceData, count, nil
 }
 
@@ -181,6 +185,7 @@ func populateSpan(
 	addStartTime(seg.StartTime, span)
 	addEndTime(seg.EndTime, span)
 	addBool(seg.InProgress, awsxray.AWSXRayInProgressAttribute, &attrs)
+	addBool(seg.Inferred, awsxray.AWSXRayInferredAttribute, &attrs)
 	addString(seg.User, conventions.AttributeEnduserID, &attrs)
 
 	addHTTP(seg, span)
@@ -217,6 +222,14 @@ func populateResource(seg *awsxray.Segment, rs *pdata.Resource) {
 	addString(seg.ResourceARN, awsxray.AWSXRayResourceARNAttribute, &attrs)
 }
 
+func flattenSegments(seg *awsxray.Segment, segs []*awsxray.Segment) []*awsxray.Segment {
+	segs = append(segs, seg)
+	for i := range seg.Subsegments {
+		segs = flattenSegments(&seg.Subsegments[i], segs)
+	}
+	return segs
+}
+
 func totalSegmentsCount(seg awsxray.Segment) int {
 	subsegmentCount := 0
 	for _, s := range seg.Subsegments {
@@ -39,6 +39,7 @@ type perSpanProperties struct {
 	spanKind     pdata.SpanKind
 	spanStatus   spanSt
 	eventsProps  []eventProps
+	linksProps   []linkProps
 	attrs        map[string]pdata.AttributeValue
 }
 
@@ -52,6 +53,12 @@ type eventProps struct {
 	attrs map[string]pdata.AttributeValue
 }
 
+type linkProps struct {
+	traceID string
+	spanID  string
+	attrs   map[string]pdata.AttributeValue
+}
+
 func TestTranslation(t *testing.T) {
 	var defaultServerSpanAttrs = func(seg *awsxray.Segment) map[string]pdata.AttributeValue {
 		attrs := make(map[string]pdata.AttributeValue)
@@ -197,6 +204,8 @@ func TestTranslation(t *testing.T) {
 				childSpan7318Attrs := make(map[string]pdata.AttributeValue)
 				childSpan7318Attrs[awsxray.AWSServiceAttribute] = pdata.NewAttributeValueString(
 					*subseg7318.Name)
+				childSpan7318Attrs[conventions.AttributePeerService] = pdata.NewAttributeValueString(
+					*subseg7318.Name)
 				childSpan7318Attrs[conventions.AttributeHTTPStatusCode] = pdata.NewAttributeValueInt(
 					*subseg7318.HTTP.Response.Status)
 
@@ -404,6 +413,8 @@ func TestTranslation(t *testing.T) {
 				childSpan7163Attrs := make(map[string]pdata.AttributeValue)
 				childSpan7163Attrs[awsxray.AWSServiceAttribute] = pdata.NewAttributeValueString(
 					*subseg7163.Name)
+				childSpan7163Attrs[conventions.AttributePeerService] = pdata.NewAttributeValueString(
+					*subseg7163.Name)
 				childSpan7163Attrs[conventions.AttributeHTTPStatusCode] = pdata.NewAttributeValueInt(
 					*subseg7163.HTTP.Response.Status)
 				contentLength = subseg7163.HTTP.Response.ContentLength.(float64)
@@ -685,6 +696,136 @@ func TestTranslation(t *testing.T) {
 				compare2ResourceSpans(t, testCase, expectedRs, &actualRs)
 			},
 		},
+		{
+			testCase:   "TranslateCauseChain",
+			samplePath: path.Join("../../../../internal/aws/xray", "testdata", "causeChain.txt"),
+			expectedResourceAttrs: func(seg *awsxray.Segment) map[string]pdata.AttributeValue {
+				attrs := make(map[string]pdata.AttributeValue)
+				attrs[conventions.AttributeCloudProvider] = pdata.NewAttributeValueString("unknown")
+				return attrs
+			},
+			propsPerSpan: func(testCase string, t *testing.T, seg *awsxray.Segment) []perSpanProperties {
+				subsegQuery := seg.Subsegments[0]
+				subsegHandler := seg.Subsegments[1]
+				// the root segment's cause is the exception of the handler
+				// subsegment, whose cause is the exception of the query subsegment.
+				rootSpan := perSpanProperties{
+					traceID:      *seg.TraceID,
+					spanID:       *seg.ID,
+					name:         *seg.Name,
+					startTimeSec: *seg.StartTime,
+					endTimeSec:   seg.EndTime,
+					spanKind:     pdata.SpanKindServer,
+					spanStatus: spanSt{
+						message: *seg.Cause.ExceptionID,
+						code:    pdata.StatusCodeError,
+					},
+					linksProps: []linkProps{
+						{
+							traceID: *seg.TraceID,
+							spanID:  *subsegHandler.ID,
+							attrs: map[string]pdata.AttributeValue{
+								awsxray.AWSXrayExceptionIDAttribute: pdata.NewAttributeValueString(*seg.Cause.ExceptionID),
+							},
+						},
+					},
+				}
+				querySpanEvts := initExceptionEvents(&subsegQuery)
+				assert.Len(t, querySpanEvts, 1, testCase+": querySpanEvts has incorrect size")
+				querySpan := perSpanProperties{
+					traceID:      *seg.TraceID,
+					spanID:       *subsegQuery.ID,
+					parentSpanID: seg.ID,
+					name:         *subsegQuery.Name,
+					startTimeSec: *subsegQuery.StartTime,
+					endTimeSec:   subsegQuery.EndTime,
+					spanKind:     pdata.SpanKindInternal,
+					spanStatus: spanSt{
+						code: pdata.StatusCodeError,
+					},
+					eventsProps: querySpanEvts,
+				}
+				handlerSpanEvts := initExceptionEvents(&subsegHandler)
+				assert.Len(t, handlerSpanEvts, 1, testCase+": handlerSpanEvts has incorrect size")
+				handlerSpan := perSpanProperties{
+					traceID:      *seg.TraceID,
+					spanID:       *subsegHandler.ID,
+					parentSpanID: seg.ID,
+					name:         *subsegHandler.Name,
+					startTimeSec: *subsegHandler.StartTime,
+					endTimeSec:   subsegHandler.EndTime,
+					spanKind:     pdata.SpanKindInternal,
+					spanStatus: spanSt{
+						code: pdata.StatusCodeError,
+					},
+					eventsProps: handlerSpanEvts,
+					linksProps: []linkProps{
+						{
+							traceID: *seg.TraceID,
+							spanID:  *subsegQuery.ID,
+							attrs: map[string]pdata.AttributeValue{
+								awsxray.AWSXrayExceptionIDAttribute: pdata.NewAttributeValueString(*subsegHandler.Cause.Exceptions[0].Cause),
+							},
+						},
+					},
+				}
+				return []perSpanProperties{rootSpan, querySpan, handlerSpan}
+			},
+			verification: func(testCase string,
+				_ *awsxray.Segment,
+				expectedRs *pdata.ResourceSpans, actualTraces *pdata.Traces, err error) {
+				assert.NoError(t, err, testCase+": translation should've succeeded")
+				assert.Equal(t, 1, actualTraces.ResourceSpans().Len(),
+					testCase+": one segment should translate to 1 ResourceSpans")
+
+				actualRs := actualTraces.ResourceSpans().At(0)
+				compare2ResourceSpans(t, testCase, expectedRs, &actualRs)
+			},
+		},
+		{
+			testCase:   "TranslateInferredSegment",
+			samplePath: path.Join("../../../../internal/aws/xray", "testdata", "inferredSegment.txt"),
+			expectedResourceAttrs: func(seg *awsxray.Segment) map[string]pdata.AttributeValue {
+				attrs := make(map[string]pdata.AttributeValue)
+				attrs[conventions.AttributeCloudProvider] = pdata.NewAttributeValueString(conventions.AttributeCloudProviderAWS)
+				return attrs
+			},
+			propsPerSpan: func(_ string, _ *testing.T, seg *awsxray.Segment) []perSpanProperties {
+				attrs := make(map[string]pdata.AttributeValue)
+				attrs[conventions.AttributePeerService] = pdata.NewAttributeValueString(*seg.Name)
+				attrs[awsxray.AWSXRayInferredAttribute] = pdata.NewAttributeValueBool(true)
+				attrs[conventions.AttributeHTTPStatusCode] = pdata.NewAttributeValueInt(
+					*seg.HTTP.Response.Status)
+				attrs[awsxray.AWSOperationAttribute] = pdata.NewAttributeValueString(
+					*seg.AWS.Operation)
+				attrs[awsxray.AWSTableNameAttribute] = pdata.NewAttributeValueString(
+					*seg.AWS.TableName)
+				res := perSpanProperties{
+					traceID:      *seg.TraceID,
+					spanID:       *seg.ID,
+					parentSpanID: seg.ParentID,
+					name:         *seg.Name,
+					startTimeSec: *seg.StartTime,
+					endTimeSec:   seg.EndTime,
+					spanKind:     pdata.SpanKindClient,
+					spanStatus: spanSt{
+						code: pdata.StatusCodeUnset,
+					},
+					attrs: attrs,
+				}
+				return []perSpanProperties{res}
+			},
+			verification: func(testCase string,
+				_ *awsxray.Segment,
+				expectedRs *pdata.ResourceSpans, actualTraces *pdata.Traces, err error) {
+				assert.NoError(t, err, testCase+": translation should've succeeded")
+				assert.Equal(t, 1, actualTraces.ResourceSpans().Len(),
+					testCase+": one segment should translate to 1 ResourceSpans")
+
+				actualRs := actualTraces.ResourceSpans().At(0)
+				compare2ResourceSpans(t, testCase, expectedRs, &actualRs)
+			},
+		},
 		{
 			testCase:   "TranslateInvalidNamespace",
 			samplePath: path.Join("../../../../internal/aws/xray", "testdata", "invalidNamespace.txt"),
@@ -722,6 +863,7 @@ func TestTranslation(t *testing.T) {
 				contentLength := seg.HTTP.Response.ContentLength.(float64)
 				attrs[conventions.AttributeHTTPResponseContentLength] = pdata.NewAttributeValueInt(int64(contentLength))
 				attrs[awsxray.AWSXRayTracedAttribute] = pdata.NewAttributeValueBool(true)
+				attrs[conventions.AttributePeerService] = pdata.NewAttributeValueString(*seg.Name)
 				res := perSpanProperties{
 					traceID:      *seg.TraceID,
 					spanID:       *seg.ID,
@@ -769,6 +911,7 @@ func TestTranslation(t *testing.T) {
 				attrs[conventions.AttributeHTTPResponseContentLength] = pdata.NewAttributeValueString(contentLength)
 
 				attrs[awsxray.AWSXRayTracedAttribute] = pdata.NewAttributeValueBool(true)
+				attrs[conventions.AttributePeerService] = pdata.NewAttributeValueString(*seg.Name)
 				res := perSpanProperties{
 					traceID:      *seg.TraceID,
 					spanID:       *seg.ID,
@@ -814,6 +957,7 @@ func TestTranslation(t *testing.T) {
 					*seg.SQL.SanitizedQuery)
 				attrs[conventions.AttributeDBUser] = pdata.NewAttributeValueString(
 					*seg.SQL.User)
+				attrs[conventions.AttributePeerService] = pdata.NewAttributeValueString(*seg.Name)
 				res := perSpanProperties{
 					traceID:      *seg.TraceID,
 					spanID:       *seg.ID,
@@ -1032,6 +1176,15 @@ func initResourceSpans(expectedSeg *awsxray.Segment,
 			}
 		}
 
+		for _, lnkProps := range props.linksProps {
+			lnk := sp.Links().AppendEmpty()
+			lnkTraceIDBytes, _ := decodeXRayTraceID(&lnkProps.traceID)
+			lnk.SetTraceID(pdata.NewTraceID(lnkTraceIDBytes))
+			lnkSpanIDBytes, _ := decodeXRaySpanID(&lnkProps.spanID)
+			lnk.SetSpanID(pdata.NewSpanID(lnkSpanIDBytes))
+			lnk.Attributes().InitFromMap(lnkProps.attrs)
+		}
+
 		if len(props.attrs) > 0 {
 			sp.Attributes().InitFromMap(props.attrs)
 		} else {
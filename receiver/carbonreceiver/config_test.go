@@ -40,7 +40,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 3)
+	assert.Equal(t, len(cfg.Receivers), 4)
 
 	r0 := cfg.Receivers[config.NewID(typeStr)]
 	assert.Equal(t, factory.CreateDefaultConfig(), r0)
@@ -54,6 +54,7 @@ func TestLoadConfig(t *testing.T) {
 				Transport: "udp",
 			},
 			TCPIdleTimeout: 5 * time.Second,
+			Protocol:       "plaintext",
 			Parser: &protocol.Config{
 				Type:   "plaintext",
 				Config: &protocol.PlaintextConfig{},
@@ -61,7 +62,24 @@ func TestLoadConfig(t *testing.T) {
 		},
 		r1)
 
-	r2 := cfg.Receivers[config.NewIDWithName(typeStr, "regex")].(*Config)
+	r2 := cfg.Receivers[config.NewIDWithName(typeStr, "pickle")].(*Config)
+	assert.Equal(t,
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewIDWithName(typeStr, "pickle")),
+			NetAddr: confignet.NetAddr{
+				Endpoint:  "localhost:2004",
+				Transport: "tcp",
+			},
+			TCPIdleTimeout: 30 * time.Second,
+			Protocol:       "pickle",
+			Parser: &protocol.Config{
+				Type:   "plaintext",
+				Config: &protocol.PlaintextConfig{},
+			},
+		},
+		r2)
+
+	r3 := cfg.Receivers[config.NewIDWithName(typeStr, "regex")].(*Config)
 	assert.Equal(t,
 		&Config{
 			ReceiverSettings: config.NewReceiverSettings(config.NewIDWithName(typeStr, "regex")),
@@ -70,6 +88,7 @@ func TestLoadConfig(t *testing.T) {
 				Transport: "tcp",
 			},
 			TCPIdleTimeout: 30 * time.Second,
+			Protocol:       "plaintext",
 			Parser: &protocol.Config{
 				Type: "regex",
 				Config: &protocol.RegexParserConfig{
@@ -91,5 +110,5 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
-		r2)
+		r3)
 }
@@ -50,6 +50,7 @@ func createDefaultConfig() config.Receiver {
 			Transport: "tcp",
 		},
 		TCPIdleTimeout: transport.TCPIdleTimeoutDefault,
+		Protocol:       protocolPlaintext,
 		Parser: &protocol.Config{
 			Type:   "plaintext",
 			Config: &protocol.PlaintextConfig{},
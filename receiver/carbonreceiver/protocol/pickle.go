@@ -0,0 +1,645 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+)
+
+// Opcodes of the Python pickle protocols 0 to 5 needed to decode the lists of
+// metric tuples sent by the Carbon pickle protocol, see
+// https://github.com/python/cpython/blob/main/Lib/pickletools.py.
+const (
+	opMark           = '('
+	opStop           = '.'
+	opPop            = '0'
+	opPopMark        = '1'
+	opDup            = '2'
+	opFloat          = 'F'
+	opInt            = 'I'
+	opBinInt         = 'J'
+	opBinInt1        = 'K'
+	opLong           = 'L'
+	opBinInt2        = 'M'
+	opNone           = 'N'
+	opString         = 'S'
+	opBinString      = 'T'
+	opShortBinString = 'U'
+	opUnicode        = 'V'
+	opBinUnicode     = 'X'
+	opAppend         = 'a'
+	opGet            = 'g'
+	opBinGet         = 'h'
+	opLongBinGet     = 'j'
+	opList           = 'l'
+	opPut            = 'p'
+	opBinPut         = 'q'
+	opLongBinPut     = 'r'
+	opTuple          = 't'
+	opAppends        = 'e'
+	opEmptyList      = ']'
+	opEmptyTuple     = ')'
+	opBinFloat       = 'G'
+	opBinBytes       = 'B'
+	opShortBinBytes  = 'C'
+	opProto          = '\x80'
+	opTuple1         = '\x85'
+	opTuple2         = '\x86'
+	opTuple3         = '\x87'
+	opNewTrue        = '\x88'
+	opNewFalse       = '\x89'
+	opLong1          = '\x8a'
+	opLong4          = '\x8b'
+	opShortBinUni    = '\x8c'
+	opBinUnicode8    = '\x8d'
+	opBinBytes8      = '\x8e'
+	opMemoize        = '\x94'
+	opFrame          = '\x95'
+
+	// maxPickleProtocol is the highest pickle protocol version supported.
+	maxPickleProtocol = 5
+)
+
+var errPickleStackUnderflow = errors.New("pickle stack underflow")
+
+// pickleMark is pushed on the stack by the MARK opcode.
+type pickleMark struct{}
+
+// pickleList is a list being built by the unpickler, it is a pointer so the
+// items appended to it are seen from both the stack and the memo.
+type pickleList struct {
+	items []interface{}
+}
+
+// unpickler decodes the subset of the pickle format holding lists and tuples
+// of strings and numbers, which is what the Carbon pickle protocol uses.
+// Tuples are decoded as []interface{}.
+type unpickler struct {
+	r     *bufio.Reader
+	size  int
+	stack []interface{}
+	memo  map[int]interface{}
+}
+
+// unpickle decodes the object serialized in data.
+func unpickle(data []byte) (interface{}, error) {
+	u := unpickler{
+		r:    bufio.NewReader(bytes.NewReader(data)),
+		size: len(data),
+		memo: make(map[int]interface{}),
+	}
+	return u.load()
+}
+
+func (u *unpickler) load() (interface{}, error) {
+	for {
+		op, err := u.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch op {
+		case opStop:
+			return u.pop()
+		case opProto:
+			version, err := u.r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			if version > maxPickleProtocol {
+				return nil, fmt.Errorf("unsupported pickle protocol %d", version)
+			}
+		case opFrame:
+			if _, err = u.readBytes(8); err != nil {
+				return nil, err
+			}
+		case opMark:
+			u.push(pickleMark{})
+		case opPop:
+			if _, err = u.pop(); err != nil {
+				return nil, err
+			}
+		case opPopMark:
+			if _, err = u.popMark(); err != nil {
+				return nil, err
+			}
+		case opDup:
+			top, err := u.top()
+			if err != nil {
+				return nil, err
+			}
+			u.push(top)
+		case opNone:
+			u.push(nil)
+		case opNewTrue:
+			u.push(true)
+		case opNewFalse:
+			u.push(false)
+		case opInt:
+			err = u.loadInt()
+		case opLong:
+			err = u.loadLong()
+		case opBinInt:
+			var b []byte
+			if b, err = u.readBytes(4); err == nil {
+				u.push(int64(int32(binary.LittleEndian.Uint32(b))))
+			}
+		case opBinInt1:
+			var b byte
+			if b, err = u.r.ReadByte(); err == nil {
+				u.push(int64(b))
+			}
+		case opBinInt2:
+			var b []byte
+			if b, err = u.readBytes(2); err == nil {
+				u.push(int64(binary.LittleEndian.Uint16(b)))
+			}
+		case opLong1:
+			err = u.loadBinLong(1)
+		case opLong4:
+			err = u.loadBinLong(4)
+		case opFloat:
+			var line string
+			if line, err = u.readLine(); err == nil {
+				var f float64
+				if f, err = strconv.ParseFloat(line, 64); err == nil {
+					u.push(f)
+				}
+			}
+		case opBinFloat:
+			var b []byte
+			if b, err = u.readBytes(8); err == nil {
+				u.push(math.Float64frombits(binary.BigEndian.Uint64(b)))
+			}
+		case opString:
+			err = u.loadString()
+		case opUnicode:
+			var line string
+			if line, err = u.readLine(); err == nil {
+				var s string
+				if s, err = decodeRawUnicodeEscape(line); err == nil {
+					u.push(s)
+				}
+			}
+		case opShortBinString, opShortBinBytes, opShortBinUni:
+			err = u.loadBinString(1)
+		case opBinString, opBinBytes, opBinUnicode:
+			err = u.loadBinString(4)
+		case opBinUnicode8, opBinBytes8:
+			err = u.loadBinString(8)
+		case opEmptyList:
+			u.push(&pickleList{})
+		case opList:
+			var items []interface{}
+			if items, err = u.popMark(); err == nil {
+				u.push(&pickleList{items: items})
+			}
+		case opAppend:
+			var item interface{}
+			if item, err = u.pop(); err == nil {
+				err = u.appendToList(item)
+			}
+		case opAppends:
+			var items []interface{}
+			if items, err = u.popMark(); err == nil {
+				err = u.appendToList(items...)
+			}
+		case opEmptyTuple:
+			u.push([]interface{}{})
+		case opTuple:
+			var items []interface{}
+			if items, err = u.popMark(); err == nil {
+				u.push(items)
+			}
+		case opTuple1, opTuple2, opTuple3:
+			err = u.loadTuple(int(op-opTuple1) + 1)
+		case opPut:
+			var line string
+			if line, err = u.readLine(); err == nil {
+				var idx int
+				if idx, err = strconv.Atoi(line); err == nil {
+					err = u.put(idx)
+				}
+			}
+		case opBinPut:
+			var b byte
+			if b, err = u.r.ReadByte(); err == nil {
+				err = u.put(int(b))
+			}
+		case opLongBinPut:
+			var b []byte
+			if b, err = u.readBytes(4); err == nil {
+				err = u.put(int(binary.LittleEndian.Uint32(b)))
+			}
+		case opMemoize:
+			err = u.put(len(u.memo))
+		case opGet:
+			var line string
+			if line, err = u.readLine(); err == nil {
+				var idx int
+				if idx, err = strconv.Atoi(line); err == nil {
+					err = u.get(idx)
+				}
+			}
+		case opBinGet:
+			var b byte
+			if b, err = u.r.ReadByte(); err == nil {
+				err = u.get(int(b))
+			}
+		case opLongBinGet:
+			var b []byte
+			if b, err = u.readBytes(4); err == nil {
+				err = u.get(int(binary.LittleEndian.Uint32(b)))
+			}
+		default:
+			return nil, fmt.Errorf("unsupported pickle opcode 0x%02x", op)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (u *unpickler) push(v interface{}) {
+	u.stack = append(u.stack, v)
+}
+
+func (u *unpickler) top() (interface{}, error) {
+	if len(u.stack) == 0 {
+		return nil, errPickleStackUnderflow
+	}
+	return u.stack[len(u.stack)-1], nil
+}
+
+func (u *unpickler) pop() (interface{}, error) {
+	v, err := u.top()
+	if err != nil {
+		return nil, err
+	}
+	u.stack = u.stack[:len(u.stack)-1]
+	return v, nil
+}
+
+// popMark pops the items pushed since the last MARK, and the mark itself.
+func (u *unpickler) popMark() ([]interface{}, error) {
+	for i := len(u.stack) - 1; i >= 0; i-- {
+		if _, ok := u.stack[i].(pickleMark); ok {
+			items := make([]interface{}, len(u.stack)-i-1)
+			copy(items, u.stack[i+1:])
+			u.stack = u.stack[:i]
+			return items, nil
+		}
+	}
+	return nil, errors.New("pickle mark not found")
+}
+
+func (u *unpickler) appendToList(items ...interface{}) error {
+	top, err := u.top()
+	if err != nil {
+		return err
+	}
+	list, ok := top.(*pickleList)
+	if !ok {
+		return fmt.Errorf("cannot append to pickle object of type %T", top)
+	}
+	list.items = append(list.items, items...)
+	return nil
+}
+
+func (u *unpickler) loadTuple(n int) error {
+	if len(u.stack) < n {
+		return errPickleStackUnderflow
+	}
+	items := make([]interface{}, n)
+	copy(items, u.stack[len(u.stack)-n:])
+	u.stack = u.stack[:len(u.stack)-n]
+	u.push(items)
+	return nil
+}
+
+func (u *unpickler) put(idx int) error {
+	top, err := u.top()
+	if err != nil {
+		return err
+	}
+	u.memo[idx] = top
+	return nil
+}
+
+func (u *unpickler) get(idx int) error {
+	v, ok := u.memo[idx]
+	if !ok {
+		return fmt.Errorf("pickle memo key %d not found", idx)
+	}
+	u.push(v)
+	return nil
+}
+
+// loadInt decodes the text of an INT, which is also used by protocol 0 for
+// booleans.
+func (u *unpickler) loadInt() error {
+	line, err := u.readLine()
+	if err != nil {
+		return err
+	}
+	switch line {
+	case "00":
+		u.push(false)
+	case "01":
+		u.push(true)
+	default:
+		i, err := strconv.ParseInt(line, 10, 64)
+		if err != nil {
+			return err
+		}
+		u.push(i)
+	}
+	return nil
+}
+
+func (u *unpickler) loadLong() error {
+	line, err := u.readLine()
+	if err != nil {
+		return err
+	}
+	i, err := strconv.ParseInt(strings.TrimSuffix(line, "L"), 10, 64)
+	if err != nil {
+		return err
+	}
+	u.push(i)
+	return nil
+}
+
+// loadBinLong decodes a little-endian two's complement integer whose size is
+// given by the lenSize bytes preceding it. Only integers fitting in 64 bits are
+// supported.
+func (u *unpickler) loadBinLong(lenSize int) error {
+	n, err := u.readLength(lenSize)
+	if err != nil {
+		return err
+	}
+	if n > 8 {
+		return fmt.Errorf("pickle long of %d bytes overflows int64", n)
+	}
+	b, err := u.readBytes(n)
+	if err != nil {
+		return err
+	}
+	var i int64
+	for j := n - 1; j >= 0; j-- {
+		i = i<<8 | int64(b[j])
+	}
+	if n > 0 && n < 8 && b[n-1]&0x80 != 0 {
+		i -= 1 << (8 * uint(n))
+	}
+	u.push(i)
+	return nil
+}
+
+// loadString decodes the text of a STRING, the Python repr of a string.
+func (u *unpickler) loadString() error {
+	line, err := u.readLine()
+	if err != nil {
+		return err
+	}
+	if len(line) < 2 || line[0] != line[len(line)-1] || (line[0] != '\'' && line[0] != '"') {
+		return fmt.Errorf("invalid pickle string %q", line)
+	}
+	s, err := decodeStringEscape(line[1 : len(line)-1])
+	if err != nil {
+		return err
+	}
+	u.push(s)
+	return nil
+}
+
+// loadBinString decodes a string, or bytes, whose length is given by the
+// lenSize bytes preceding it.
+func (u *unpickler) loadBinString(lenSize int) error {
+	n, err := u.readLength(lenSize)
+	if err != nil {
+		return err
+	}
+	b, err := u.readBytes(n)
+	if err != nil {
+		return err
+	}
+	u.push(string(b))
+	return nil
+}
+
+func (u *unpickler) readLength(size int) (int, error) {
+	b, err := u.readBytes(size)
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	for j := size - 1; j >= 0; j-- {
+		n = n<<8 | uint64(b[j])
+	}
+	// No object can be longer than the payload holding it.
+	if n > uint64(u.size) {
+		return 0, fmt.Errorf("invalid pickle length %d", n)
+	}
+	return int(n), nil
+}
+
+func (u *unpickler) readBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(u.r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (u *unpickler) readLine() (string, error) {
+	line, err := u.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(line[:len(line)-1], "\r"), nil
+}
+
+// decodeStringEscape decodes the escape sequences of a Python string literal.
+func decodeStringEscape(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		if i == len(s) {
+			return "", fmt.Errorf("invalid escape at the end of pickle string %q", s)
+		}
+		switch s[i] {
+		case '\\', '\'', '"':
+			sb.WriteByte(s[i])
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case 'x':
+			if i+2 >= len(s) {
+				return "", fmt.Errorf("invalid hex escape in pickle string %q", s)
+			}
+			b, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+			if err != nil {
+				return "", fmt.Errorf("invalid hex escape in pickle string %q: %w", s, err)
+			}
+			sb.WriteByte(byte(b))
+			i += 2
+		default:
+			return "", fmt.Errorf("unsupported escape \\%c in pickle string %q", s[i], s)
+		}
+	}
+	return sb.String(), nil
+}
+
+// decodeRawUnicodeEscape decodes the raw-unicode-escape encoding, where the
+// characters below U+0100 are encoded as Latin-1 bytes and the others with the
+// \uXXXX and \UXXXXXXXX escape sequences.
+func decodeRawUnicodeEscape(s string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) || (s[i+1] != 'u' && s[i+1] != 'U') {
+			sb.WriteRune(rune(s[i]))
+			continue
+		}
+		size := 4
+		if s[i+1] == 'U' {
+			size = 8
+		}
+		if i+2+size > len(s) {
+			return "", fmt.Errorf("invalid unicode escape in pickle string %q", s)
+		}
+		r, err := strconv.ParseUint(s[i+2:i+2+size], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(r)) {
+			return "", fmt.Errorf("invalid unicode escape in pickle string %q", s)
+		}
+		sb.WriteRune(rune(r))
+		i += 1 + size
+	}
+	return sb.String(), nil
+}
+
+// ParsePickle decodes the payload of a Carbon pickle protocol message, a list
+// of (path, (timestamp, value)) tuples, and parses each of its metrics with p,
+// so the path is handled as for the plaintext protocol. See
+// https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-pickle-protocol.
+//
+// The metrics that cannot be parsed are skipped and their errors returned.
+func ParsePickle(payload []byte, p Parser) ([]*metricspb.Metric, []error) {
+	obj, err := unpickle(payload)
+	if err != nil {
+		return nil, []error{fmt.Errorf("invalid carbon pickle payload: %w", err)}
+	}
+	items, ok := pickleSequence(obj)
+	if !ok {
+		return nil, []error{fmt.Errorf("invalid carbon pickle payload: expected a list of metrics, got %T", obj)}
+	}
+
+	var metrics []*metricspb.Metric
+	var errs []error
+	for _, item := range items {
+		line, err := pickleMetricToLine(item)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		metric, err := p.Parse(line)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics, errs
+}
+
+// pickleMetricToLine converts a (path, (timestamp, value)) tuple into the
+// equivalent plaintext line.
+func pickleMetricToLine(item interface{}) (string, error) {
+	metric, ok := pickleSequence(item)
+	if !ok || len(metric) != 2 {
+		return "", fmt.Errorf("invalid carbon pickle metric %v", item)
+	}
+	path, ok := metric[0].(string)
+	if !ok {
+		return "", fmt.Errorf("invalid carbon pickle metric path %v", metric[0])
+	}
+	datapoint, ok := pickleSequence(metric[1])
+	if !ok || len(datapoint) != 2 {
+		return "", fmt.Errorf("invalid carbon pickle datapoint for metric [%s]: %v", path, metric[1])
+	}
+
+	var timestamp string
+	switch ts := datapoint[0].(type) {
+	case int64:
+		timestamp = strconv.FormatInt(ts, 10)
+	case float64:
+		timestamp = strconv.FormatInt(int64(ts), 10)
+	case string:
+		timestamp = ts
+	default:
+		return "", fmt.Errorf("invalid carbon pickle timestamp for metric [%s]: %v", path, datapoint[0])
+	}
+
+	var value string
+	switch v := datapoint[1].(type) {
+	case int64:
+		value = strconv.FormatInt(v, 10)
+	case float64:
+		value = strconv.FormatFloat(v, 'f', -1, 64)
+		// Keep the value a double when it has no fractional part.
+		if !math.IsInf(v, 0) && !math.IsNaN(v) && !strings.Contains(value, ".") {
+			value += ".0"
+		}
+	case string:
+		value = v
+	default:
+		return "", fmt.Errorf("invalid carbon pickle value for metric [%s]: %v", path, datapoint[1])
+	}
+
+	return path + " " + value + " " + timestamp, nil
+}
+
+// pickleSequence returns the items of a decoded list or tuple.
+func pickleSequence(v interface{}) ([]interface{}, bool) {
+	switch s := v.(type) {
+	case *pickleList:
+		return s.items, true
+	case []interface{}:
+		return s, true
+	}
+	return nil, false
+}
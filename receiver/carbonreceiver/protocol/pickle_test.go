@@ -0,0 +1,144 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParsePickle(t *testing.T) {
+	p, err := (&PlaintextConfig{}).BuildParser()
+	require.NoError(t, err)
+
+	// Each payload is pickle.dumps() of the same list of metrics:
+	//   [("tst.int;k0=v_0", (1582230020, 128)),
+	//    ("tst.dbl", (1582230020.5, 3.14)),
+	//    ("tst.dbl", (1582230021, 2.0))]
+	wantLines := []string{
+		"tst.int;k0=v_0 128 1582230020",
+		"tst.dbl 3.14 1582230020",
+		"tst.dbl 2.0 1582230021",
+	}
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{
+			name:    "protocol_0",
+			payload: "(lp0\n(Vtst.int;k0=v_0\np1\n(I1582230020\nI128\ntp2\ntp3\na(Vtst.dbl\np4\n(F1582230020.5\nF3.14\ntp5\ntp6\na(g4\n(I1582230021\nF2.0\ntp7\ntp8\na.",
+		},
+		{
+			name:    "protocol_0_python2",
+			payload: "(lp0\n(S'tst.int;k0=v_0'\np1\n(I1582230020\nI128\ntp2\ntp3\na(S'tst.dbl'\np4\n(F1582230020.5\nF3.14\ntp5\ntp6\na(g4\n(I1582230021\nF2.0\ntp7\ntp8\na.",
+		},
+		{
+			name:    "protocol_2",
+			payload: "\x80\x02]q\x00(X\x0e\x00\x00\x00tst.int;k0=v_0q\x01J\x04\xeaN^K\x80\x86q\x02\x86q\x03X\x07\x00\x00\x00tst.dblq\x04GA\xd7\x93\xba\x81 \x00\x00G@\x09\x1e\xb8Q\xeb\x85\x1f\x86q\x05\x86q\x06h\x04J\x05\xeaN^G@\x00\x00\x00\x00\x00\x00\x00\x86q\x07\x86q\x08e.",
+		},
+		{
+			name:    "protocol_4",
+			payload: "\x80\x04\x95U\x00\x00\x00\x00\x00\x00\x00]\x94(\x8c\x0etst.int;k0=v_0\x94J\x04\xeaN^K\x80\x86\x94\x86\x94\x8c\x07tst.dbl\x94GA\xd7\x93\xba\x81 \x00\x00G@\x09\x1e\xb8Q\xeb\x85\x1f\x86\x94\x86\x94h\x04J\x05\xeaN^G@\x00\x00\x00\x00\x00\x00\x00\x86\x94\x86\x94e.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errs := ParsePickle([]byte(tt.payload), p)
+			assert.Empty(t, errs)
+			assert.Equal(t, parseLines(t, p, wantLines...), got)
+		})
+	}
+}
+
+func Test_ParsePickle_Integers(t *testing.T) {
+	p, err := (&PlaintextConfig{}).BuildParser()
+	require.NoError(t, err)
+
+	// [("big", (1582230020, 2**40)), ("neg", (1582230020, -5))] with protocol 2.
+	payload := "\x80\x02]q\x00(X\x03\x00\x00\x00bigq\x01J\x04\xeaN^\x8a\x06\x00\x00\x00\x00\x00\x01\x86q\x02\x86q\x03X\x03\x00\x00\x00negq\x04J\x04\xeaN^J\xfb\xff\xff\xff\x86q\x05\x86q\x06e."
+	got, errs := ParsePickle([]byte(payload), p)
+	assert.Empty(t, errs)
+	assert.Equal(t, parseLines(t, p, "big 1099511627776 1582230020", "neg -5 1582230020"), got)
+}
+
+func Test_ParsePickle_InvalidMetrics(t *testing.T) {
+	p, err := (&PlaintextConfig{}).BuildParser()
+	require.NoError(t, err)
+
+	// [("ok", (1582230020, 1)), ("bad", (1582230020,)), ("bad.value", (1582230020, None)),
+	//  ("bad path", (1582230020, 1))] with protocol 2.
+	payload := "\x80\x02]q\x00(X\x02\x00\x00\x00okq\x01J\x04\xeaN^K\x01\x86q\x02\x86q\x03X\x03\x00\x00\x00badq\x04J\x04\xeaN^\x85q\x05\x86q\x06X\x09\x00\x00\x00bad.valueq\x07J\x04\xeaN^N\x86q\x08\x86q\x09X\x08\x00\x00\x00bad pathq\nh\x02\x86q\x0be."
+	got, errs := ParsePickle([]byte(payload), p)
+	assert.Len(t, errs, 3)
+	assert.Equal(t, parseLines(t, p, "ok 1 1582230020"), got)
+}
+
+func Test_ParsePickle_InvalidPayload(t *testing.T) {
+	p, err := (&PlaintextConfig{}).BuildParser()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{name: "empty", payload: ""},
+		{name: "truncated", payload: "\x80\x02]q\x00(X\x0e\x00\x00\x00tst"},
+		{name: "not_a_list", payload: "\x80\x02K\x01."},
+		{name: "unsupported_opcode", payload: "\x80\x02}q\x00."},
+		{name: "unsupported_protocol", payload: "\x80\x06]."},
+		{name: "stack_underflow", payload: "\x80\x02a."},
+		{name: "missing_mark", payload: "\x80\x02]e."},
+		{name: "missing_memo", payload: "\x80\x02h\x01."},
+		{name: "oversized_length", payload: "\x80\x02X\xff\xff\xff\x7f."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errs := ParsePickle([]byte(tt.payload), p)
+			assert.Len(t, errs, 1)
+			assert.Empty(t, got)
+		})
+	}
+}
+
+func Test_decodeStringEscape(t *testing.T) {
+	got, err := decodeStringEscape(`a\'b\\c\x41\n`)
+	require.NoError(t, err)
+	assert.Equal(t, "a'b\\cA\n", got)
+
+	_, err = decodeStringEscape(`a\q`)
+	assert.Error(t, err)
+}
+
+func Test_decodeRawUnicodeEscape(t *testing.T) {
+	got, err := decodeRawUnicodeEscape("caf\xe9.\\u00e9\\U0001f600\\x")
+	require.NoError(t, err)
+	assert.Equal(t, "café.é\U0001f600\\x", got)
+
+	_, err = decodeRawUnicodeEscape(`\u00`)
+	assert.Error(t, err)
+}
+
+func parseLines(t *testing.T, p Parser, lines ...string) []*metricspb.Metric {
+	var metrics []*metricspb.Metric
+	for _, line := range lines {
+		metric, err := p.Parse(line)
+		require.NoError(t, err)
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
@@ -29,12 +29,18 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver/transport"
 )
 
+const (
+	// Supported Carbon protocols.
+	protocolPlaintext = "plaintext"
+	protocolPickle    = "pickle"
+)
+
 var (
 	errEmptyEndpoint = errors.New("empty endpoint")
 )
 
-// carbonreceiver implements a component.MetricsReceiver for Carbon plaintext, aka "line", protocol.
-// see https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-plaintext-protocol.
+// carbonreceiver implements a component.MetricsReceiver for Carbon plaintext, aka "line", protocol,
+// and pickle protocol, see https://graphite.readthedocs.io/en/latest/feeding-carbon.html.
 type carbonReceiver struct {
 	logger *zap.Logger
 	config *Config
@@ -95,10 +101,25 @@ func New(
 }
 
 func buildTransportServer(config Config) (transport.Server, error) {
+	var pickle bool
+	switch strings.ToLower(config.Protocol) {
+	case "", protocolPlaintext:
+	case protocolPickle:
+		pickle = true
+	default:
+		return nil, fmt.Errorf("unsupported protocol %q for receiver %v", config.Protocol, config.ID())
+	}
+
 	switch strings.ToLower(config.Transport) {
 	case "", "tcp":
+		if pickle {
+			return transport.NewPickleTCPServer(config.Endpoint, config.TCPIdleTimeout)
+		}
 		return transport.NewTCPServer(config.Endpoint, config.TCPIdleTimeout)
 	case "udp":
+		if pickle {
+			return nil, fmt.Errorf("protocol %q is not supported over udp for receiver %v", config.Protocol, config.ID())
+		}
 		return transport.NewUDPServer(config.Endpoint)
 	}
 
@@ -106,6 +106,36 @@ func Test_carbonreceiver_New(t *testing.T) {
 			},
 			wantErr: errors.New("unsupported transport \"unknown_transp\" for receiver carbon/invalid_transport_rcv"),
 		},
+		{
+			name: "invalid_protocol",
+			args: args{
+				config: Config{
+					ReceiverSettings: config.NewReceiverSettings(config.NewIDWithName(typeStr, "invalid_protocol_rcv")),
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "localhost:2003",
+						Transport: "tcp",
+					},
+					Protocol: "unknown_proto",
+				},
+				nextConsumer: consumertest.NewNop(),
+			},
+			wantErr: errors.New("unsupported protocol \"unknown_proto\" for receiver carbon/invalid_protocol_rcv"),
+		},
+		{
+			name: "pickle_over_udp",
+			args: args{
+				config: Config{
+					ReceiverSettings: config.NewReceiverSettings(config.NewIDWithName(typeStr, "pickle_udp_rcv")),
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "localhost:2004",
+						Transport: "udp",
+					},
+					Protocol: "pickle",
+				},
+				nextConsumer: consumertest.NewNop(),
+			},
+			wantErr: errors.New("protocol \"pickle\" is not supported over udp for receiver carbon/pickle_udp_rcv"),
+		},
 		{
 			name: "regex_parser",
 			args: args{
@@ -195,6 +225,19 @@ func Test_carbonreceiver_EndToEnd(t *testing.T) {
 				return c
 			},
 		},
+		{
+			name: "pickle",
+			configFn: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Protocol = "pickle"
+				return cfg
+			},
+			clientFn: func(t *testing.T) *client.Graphite {
+				c, err := client.NewGraphite(client.TCP, host, port)
+				require.NoError(t, err)
+				return c
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -220,7 +263,11 @@ func Test_carbonreceiver_EndToEnd(t *testing.T) {
 				Value:     1.23,
 				Timestamp: ts,
 			}
-			err = snd.SendMetric(carbonMetric)
+			if cfg.Protocol == protocolPickle {
+				err = snd.SendPickleMetrics([]client.Metric{carbonMetric})
+			} else {
+				err = snd.SendMetric(carbonMetric)
+			}
 			require.NoError(t, err)
 
 			mr.WaitAllOnMetricsProcessedCalls()
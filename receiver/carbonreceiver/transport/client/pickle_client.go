@@ -0,0 +1,64 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+)
+
+// SendPickleMetrics sends the metrics as a single message of the Carbon pickle
+// protocol, pickled with the opcodes of the pickle protocol 2.
+func (g *Graphite) SendPickleMetrics(metrics []Metric) error {
+	_, err := g.Conn.Write(PickleMetrics(metrics))
+	return err
+}
+
+// PickleMetrics encodes the metrics as a message of the Carbon pickle protocol:
+// a list of (path, (timestamp, value)) tuples prefixed by its length.
+func PickleMetrics(metrics []Metric) []byte {
+	var buf bytes.Buffer
+	// PROTO 2, EMPTY_LIST and MARK.
+	buf.Write([]byte{0x80, 0x02, ']', '('})
+	for _, metric := range metrics {
+		// BINUNICODE path.
+		buf.WriteByte('X')
+		writeUint32LE(&buf, uint32(len(metric.Name)))
+		buf.WriteString(metric.Name)
+		// BININT timestamp.
+		buf.WriteByte('J')
+		writeUint32LE(&buf, uint32(metric.Timestamp.Unix()))
+		// BINFLOAT value.
+		buf.WriteByte('G')
+		var value [8]byte
+		binary.BigEndian.PutUint64(value[:], math.Float64bits(metric.Value))
+		buf.Write(value[:])
+		// TUPLE2 twice for the datapoint and the metric.
+		buf.Write([]byte{0x86, 0x86})
+	}
+	// APPENDS and STOP.
+	buf.Write([]byte{'e', '.'})
+
+	msg := make([]byte, 4, 4+buf.Len())
+	binary.BigEndian.PutUint32(msg, uint32(buf.Len()))
+	return append(msg, buf.Bytes()...)
+}
+
+func writeUint32LE(buf *bytes.Buffer, v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	buf.Write(b[:])
+}
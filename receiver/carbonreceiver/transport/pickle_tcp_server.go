@@ -0,0 +1,113 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/translator/internaldata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver/protocol"
+)
+
+// MaxPicklePayloadSize is the largest pickle payload accepted, the same limit
+// enforced by Carbon.
+const MaxPicklePayloadSize = 1 << 20
+
+// NewPickleTCPServer creates a transport.Server using TCP as its transport and
+// receiving the Carbon pickle protocol, where each message is a pickled list
+// of metrics prefixed by its length as a 4 bytes big-endian integer. See
+// https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-pickle-protocol.
+func NewPickleTCPServer(
+	addr string,
+	idleTimeout time.Duration,
+) (Server, error) {
+	t, err := newTCPServer(addr, idleTimeout)
+	if err != nil {
+		return nil, err
+	}
+	t.pickle = true
+	return t, nil
+}
+
+func (t *tcpServer) handlePickleConnection(
+	p protocol.Parser,
+	nextConsumer consumer.Metrics,
+	conn net.Conn,
+) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	header := make([]byte, 4)
+	for {
+		if err := conn.SetDeadline(time.Now().Add(t.idleTimeout)); err != nil {
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - conn.SetDeadLine error: %v",
+				t.ln.Addr(),
+				err)
+			return
+		}
+
+		// Any error while reading a message, including io.EOF once the client
+		// is done and the idle timeout, ends the connection since the stream
+		// cannot be resynchronized.
+		if _, err := io.ReadFull(reader, header); err != nil {
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - pickle header read error: %v",
+				t.ln.Addr(),
+				err)
+			return
+		}
+		length := binary.BigEndian.Uint32(header)
+		if length > MaxPicklePayloadSize {
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - pickle payload of %d bytes exceeds the maximum of %d bytes",
+				t.ln.Addr(),
+				length,
+				MaxPicklePayloadSize)
+			return
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - pickle payload read error: %v",
+				t.ln.Addr(),
+				err)
+			return
+		}
+
+		ctx := t.reporter.OnDataReceived(context.Background())
+		metrics, errs := protocol.ParsePickle(payload, p)
+		for _, err := range errs {
+			t.reporter.OnTranslationError(ctx, err)
+		}
+
+		var err error
+		if len(metrics) > 0 {
+			err = nextConsumer.ConsumeMetrics(ctx, internaldata.OCToMetrics(nil, nil, metrics))
+		}
+		t.reporter.OnMetricsProcessed(ctx, len(metrics), err)
+		if err != nil {
+			// As for the plaintext protocol, closing the connection is the only
+			// way to report the error back to the client.
+			return
+		}
+	}
+}
@@ -37,6 +37,7 @@ func Test_Server_ListenAndServe(t *testing.T) {
 		name          string
 		buildServerFn func(addr string) (Server, error)
 		buildClientFn func(host string, port int) (*client.Graphite, error)
+		pickle        bool
 	}{
 		{
 			name: "tcp",
@@ -56,6 +57,16 @@ func Test_Server_ListenAndServe(t *testing.T) {
 				return client.NewGraphite(client.UDP, host, port)
 			},
 		},
+		{
+			name: "pickle_tcp",
+			buildServerFn: func(addr string) (Server, error) {
+				return NewPickleTCPServer(addr, 1*time.Second)
+			},
+			buildClientFn: func(host string, port int) (*client.Graphite, error) {
+				return client.NewGraphite(client.TCP, host, port)
+			},
+			pickle: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -88,8 +99,12 @@ func Test_Server_ListenAndServe(t *testing.T) {
 			require.NotNil(t, gc)
 
 			ts := time.Date(2020, 2, 20, 20, 20, 20, 20, time.UTC)
-			err = gc.SendMetric(client.Metric{
-				Name: "test.metric", Value: 1, Timestamp: ts})
+			metric := client.Metric{Name: "test.metric", Value: 1, Timestamp: ts}
+			if tt.pickle {
+				err = gc.SendPickleMetrics([]client.Metric{metric})
+			} else {
+				err = gc.SendMetric(metric)
+			}
 			assert.NoError(t, err)
 			runtime.Gosched()
 
@@ -42,6 +42,8 @@ type tcpServer struct {
 	wg          sync.WaitGroup
 	idleTimeout time.Duration
 	reporter    Reporter
+	// pickle selects the pickle protocol instead of the plaintext one.
+	pickle bool
 }
 
 var _ Server = (*tcpServer)(nil)
@@ -51,6 +53,10 @@ func NewTCPServer(
 	addr string,
 	idleTimeout time.Duration,
 ) (Server, error) {
+	return newTCPServer(addr, idleTimeout)
+}
+
+func newTCPServer(addr string, idleTimeout time.Duration) (*tcpServer, error) {
 	if idleTimeout < 0 {
 		return nil, fmt.Errorf("invalid idle timeout: %v", idleTimeout)
 	}
@@ -93,7 +99,11 @@ func (t *tcpServer) ListenAndServe(
 			connMapMtx.Unlock()
 			t.wg.Add(1)
 			go func(c net.Conn) {
-				t.handleConnection(parser, nextConsumer, c)
+				if t.pickle {
+					t.handlePickleConnection(parser, nextConsumer, c)
+				} else {
+					t.handleConnection(parser, nextConsumer, c)
+				}
 				connMapMtx.Lock()
 				delete(acceptedConnMap, c)
 				connMapMtx.Unlock()
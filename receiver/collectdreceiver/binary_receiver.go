@@ -0,0 +1,150 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectdreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/translator/internaldata"
+	"go.uber.org/zap"
+)
+
+// maxPacketSize is the largest UDP payload, collectd itself sends packets of
+// at most 1452 bytes by default.
+const maxPacketSize = 65535
+
+var _ component.MetricsReceiver = (*collectdBinaryReceiver)(nil)
+
+// collectdBinaryReceiver implements the component.MetricsReceiver for the
+// binary protocol of the collectd network plugin.
+type collectdBinaryReceiver struct {
+	logger       *zap.Logger
+	addr         string
+	parser       *networkParser
+	nextConsumer consumer.Metrics
+
+	conn net.PacketConn
+	wg   sync.WaitGroup
+}
+
+// newCollectdBinaryReceiver creates the collectd network protocol receiver with
+// the given parameters.
+func newCollectdBinaryReceiver(
+	logger *zap.Logger,
+	cfg *Config,
+	nextConsumer consumer.Metrics) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
+	level, err := parseSecurityLevel(cfg.SecurityLevel)
+	if err != nil {
+		return nil, err
+	}
+	parser := &networkParser{level: level}
+	if cfg.AuthFile != "" {
+		if parser.passwords, err = loadAuthFile(cfg.AuthFile); err != nil {
+			return nil, err
+		}
+	} else if level != securityLevelNone {
+		return nil, fmt.Errorf("auth_file is required with security_level %q", cfg.SecurityLevel)
+	}
+	if parser.typesDB, err = loadTypesDB(cfg.TypesDB); err != nil {
+		return nil, err
+	}
+
+	return &collectdBinaryReceiver{
+		logger:       logger,
+		addr:         cfg.Endpoint,
+		parser:       parser,
+		nextConsumer: nextConsumer,
+	}, nil
+}
+
+// Start listens for collectd network packets on the configured UDP address.
+func (cdr *collectdBinaryReceiver) Start(_ context.Context, host component.Host) error {
+	conn, err := net.ListenPacket("udp", cdr.addr)
+	if err != nil {
+		return fmt.Errorf("error starting collectd receiver: %w", err)
+	}
+	cdr.conn = conn
+
+	cdr.wg.Add(1)
+	go func() {
+		defer cdr.wg.Done()
+		buf := make([]byte, maxPacketSize)
+		for {
+			n, _, err := conn.ReadFrom(buf)
+			if n > 0 {
+				cdr.handlePacket(buf[:n])
+			}
+			if err != nil {
+				if errors.Is(err, net.ErrClosed) {
+					return
+				}
+				host.ReportFatalError(fmt.Errorf("error reading collectd packets: %w", err))
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the collectd network protocol receiver.
+func (cdr *collectdBinaryReceiver) Shutdown(context.Context) error {
+	if cdr.conn == nil {
+		return nil
+	}
+	err := cdr.conn.Close()
+	cdr.wg.Wait()
+	return err
+}
+
+func (cdr *collectdBinaryReceiver) handlePacket(packet []byte) {
+	recordRequestReceived()
+
+	records, err := cdr.parser.parse(packet)
+	if err != nil {
+		// Keep the values decoded before the error, collectd does the same.
+		recordRequestErrors()
+		cdr.logger.Debug("unable to decode collectd packet", zap.Error(err))
+	}
+
+	var metrics []*metricspb.Metric
+	for _, record := range records {
+		metrics, err = record.appendToMetrics(metrics, nil)
+		if err != nil {
+			recordRequestErrors()
+			cdr.logger.Error("unable to process metrics", zap.Error(err))
+			return
+		}
+	}
+	if len(metrics) == 0 {
+		return
+	}
+
+	if err = cdr.nextConsumer.ConsumeMetrics(context.Background(), internaldata.OCToMetrics(nil, nil, metrics)); err != nil {
+		recordRequestErrors()
+		cdr.logger.Error("unable to process metrics", zap.Error(err))
+	}
+}
@@ -29,4 +29,14 @@ type Config struct {
 	Timeout          time.Duration `mapstructure:"timeout"`
 	AttributesPrefix string        `mapstructure:"attributes_prefix"`
 	Encoding         string        `mapstructure:"encoding"`
+
+	// SecurityLevel, AuthFile and TypesDB only apply to the binary encoding.
+	// SecurityLevel is the minimum security of the accepted data, one of
+	// "none", "sign" or "encrypt", as the collectd network plugin option.
+	SecurityLevel string `mapstructure:"security_level"`
+	// AuthFile holds the "username: password" entries used to verify signed
+	// and decrypt encrypted data.
+	AuthFile string `mapstructure:"auth_file"`
+	// TypesDB lists the types.db files used to name the values of each type.
+	TypesDB []string `mapstructure:"typesdb"`
 }
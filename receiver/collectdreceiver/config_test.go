@@ -38,7 +38,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 2)
+	assert.Equal(t, len(cfg.Receivers), 3)
 
 	r0 := cfg.Receivers[config.NewID(typeStr)]
 	assert.Equal(t, r0, factory.CreateDefaultConfig())
@@ -54,4 +54,18 @@ func TestLoadConfig(t *testing.T) {
 			AttributesPrefix: "dap_",
 			Encoding:         "command",
 		})
+
+	r2 := cfg.Receivers[config.NewIDWithName(typeStr, "binary")].(*Config)
+	assert.Equal(t, r2,
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewIDWithName(typeStr, "binary")),
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "localhost:25826",
+			},
+			Timeout:       defaultTimeout,
+			Encoding:      "binary",
+			SecurityLevel: "sign",
+			AuthFile:      "./testdata/auth_file",
+			TypesDB:       []string{"./testdata/types.db"},
+		})
 }
@@ -34,6 +34,7 @@ const (
 	defaultBindEndpoint   = "localhost:8081"
 	defaultTimeout        = time.Second * 30
 	defaultEncodingFormat = "json"
+	binaryEncodingFormat  = "binary"
 )
 
 // NewFactory creates a factory for collectd receiver.
@@ -62,13 +63,16 @@ func createMetricsReceiver(
 ) (component.MetricsReceiver, error) {
 	c := cfg.(*Config)
 	c.Encoding = strings.ToLower(c.Encoding)
-	// CollectD receiver only supports JSON encoding. We expose a config option
-	// to make it explicit and obvious to the users.
-	if c.Encoding != defaultEncodingFormat {
-		return nil, fmt.Errorf(
-			"CollectD only support JSON encoding format. %s is not supported",
-			c.Encoding,
-		)
+	// CollectD receiver supports the write_http JSON format over HTTP and the
+	// network plugin binary format over UDP.
+	switch c.Encoding {
+	case defaultEncodingFormat:
+		return newCollectdReceiver(params.Logger, c.Endpoint, c.Timeout, c.AttributesPrefix, nextConsumer)
+	case binaryEncodingFormat:
+		return newCollectdBinaryReceiver(params.Logger, c, nextConsumer)
 	}
-	return newCollectdReceiver(params.Logger, c.Endpoint, c.Timeout, c.AttributesPrefix, nextConsumer)
+	return nil, fmt.Errorf(
+		"CollectD only support JSON and binary encoding formats. %s is not supported",
+		c.Encoding,
+	)
 }
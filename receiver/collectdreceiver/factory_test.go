@@ -40,3 +40,18 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, tReceiver, "receiver creation failed")
 }
+
+func TestCreateBinaryReceiver(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Encoding = "Binary"
+
+	params := componenttest.NewNopReceiverCreateSettings()
+	tReceiver, err := factory.CreateMetricsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.IsType(t, &collectdBinaryReceiver{}, tReceiver)
+
+	cfg.Encoding = "command"
+	_, err = factory.CreateMetricsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.EqualError(t, err, "CollectD only support JSON and binary encoding formats. command is not supported")
+}
@@ -0,0 +1,347 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectdreceiver
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha1" // #nosec SHA1 is mandated by the collectd network protocol
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// This file implements a decoder for the binary protocol spoken by the collectd
+// network plugin, see https://collectd.org/wiki/index.php/Binary_protocol.
+
+const (
+	partTypeHost           = 0x0000
+	partTypeTime           = 0x0001
+	partTypePlugin         = 0x0002
+	partTypePluginInstance = 0x0003
+	partTypeType           = 0x0004
+	partTypeTypeInstance   = 0x0005
+	partTypeValues         = 0x0006
+	partTypeInterval       = 0x0007
+	partTypeTimeHR         = 0x0008
+	partTypeIntervalHR     = 0x0009
+	partTypeMessage        = 0x0100
+	partTypeSeverity       = 0x0101
+	partTypeSignature      = 0x0200
+	partTypeEncryption     = 0x0210
+)
+
+const (
+	dsTypeCounter  = 0
+	dsTypeGauge    = 1
+	dsTypeDerive   = 2
+	dsTypeAbsolute = 3
+)
+
+const (
+	partHeaderLen = 4
+	numericLen    = 8
+	signatureLen  = sha256.Size
+	ivLen         = aes.BlockSize
+	checksumLen   = sha1.Size
+)
+
+// securityLevel mirrors the SecurityLevel option of the collectd network plugin.
+type securityLevel int
+
+const (
+	securityLevelNone securityLevel = iota
+	securityLevelSign
+	securityLevelEncrypt
+)
+
+func parseSecurityLevel(s string) (securityLevel, error) {
+	switch strings.ToLower(s) {
+	case "", "none":
+		return securityLevelNone, nil
+	case "sign":
+		return securityLevelSign, nil
+	case "encrypt":
+		return securityLevelEncrypt, nil
+	}
+	return securityLevelNone, fmt.Errorf("invalid security_level %q, must be one of none, sign or encrypt", s)
+}
+
+var errInsufficientSecurity = errors.New("received values without the required security level")
+
+// networkParser decodes collectd binary packets into collectDRecords.
+type networkParser struct {
+	level     securityLevel
+	passwords map[string]string
+	typesDB   map[string][]string
+}
+
+// networkState holds the fields that parts set for every following values part.
+type networkState struct {
+	host           string
+	plugin         string
+	pluginInstance string
+	typeS          string
+	typeInstance   string
+	time           float64
+	interval       float64
+}
+
+// parse decodes a packet. The records decoded before an error is encountered are
+// returned along with the error.
+func (p *networkParser) parse(buf []byte) ([]collectDRecord, error) {
+	return p.parseParts(buf, securityLevelNone, nil)
+}
+
+func (p *networkParser) parseParts(buf []byte, trust securityLevel, records []collectDRecord) ([]collectDRecord, error) {
+	state := networkState{}
+	for len(buf) > 0 {
+		if len(buf) < partHeaderLen {
+			return records, errors.New("truncated part header")
+		}
+		partType := binary.BigEndian.Uint16(buf[0:2])
+		partLen := int(binary.BigEndian.Uint16(buf[2:4]))
+		if partLen < partHeaderLen || partLen > len(buf) {
+			return records, fmt.Errorf("invalid length %d for part type %#04x", partLen, partType)
+		}
+		part, rest := buf[partHeaderLen:partLen], buf[partLen:]
+
+		var err error
+		switch partType {
+		case partTypeHost:
+			state.host, err = decodeString(part)
+		case partTypePlugin:
+			state.plugin, err = decodeString(part)
+		case partTypePluginInstance:
+			state.pluginInstance, err = decodeString(part)
+		case partTypeType:
+			state.typeS, err = decodeString(part)
+		case partTypeTypeInstance:
+			state.typeInstance, err = decodeString(part)
+		case partTypeTime, partTypeInterval, partTypeTimeHR, partTypeIntervalHR:
+			var v uint64
+			if v, err = decodeNumeric(part); err != nil {
+				break
+			}
+			seconds := float64(v)
+			if partType == partTypeTimeHR || partType == partTypeIntervalHR {
+				// High resolution values are expressed in units of 2^-30 seconds.
+				seconds /= 1 << 30
+			}
+			if partType == partTypeTime || partType == partTypeTimeHR {
+				state.time = seconds
+			} else {
+				state.interval = seconds
+			}
+		case partTypeSeverity:
+			_, err = decodeNumeric(part)
+		case partTypeMessage:
+			// Notifications are not converted to metrics, the same way events
+			// are dropped from write_http JSON payloads.
+			if _, err = decodeString(part); err == nil {
+				recordEventsReceived()
+			}
+		case partTypeValues:
+			if trust < p.level {
+				return records, errInsufficientSecurity
+			}
+			var record collectDRecord
+			if record, err = p.decodeValues(part, &state); err == nil {
+				records = append(records, record)
+			}
+		case partTypeSignature:
+			return p.parseSigned(part, rest, trust, records)
+		case partTypeEncryption:
+			records, err = p.parseEncrypted(part, records)
+		}
+		// Unknown part types are skipped, as collectd itself does.
+		if err != nil {
+			return records, fmt.Errorf("error decoding part type %#04x: %w", partType, err)
+		}
+		buf = rest
+	}
+	return records, nil
+}
+
+// parseSigned verifies the HMAC-SHA256 signature covering the username and the
+// rest of the packet, then decodes the rest of the packet as signed data.
+func (p *networkParser) parseSigned(part, rest []byte, trust securityLevel, records []collectDRecord) ([]collectDRecord, error) {
+	if len(part) < signatureLen {
+		return records, errors.New("truncated signature part")
+	}
+	if p.passwords == nil && p.level == securityLevelNone {
+		// Without credentials the signature cannot be verified, the data is
+		// accepted as unsigned.
+		return p.parseParts(rest, trust, records)
+	}
+	signature, username := part[:signatureLen], string(part[signatureLen:])
+	password, ok := p.passwords[username]
+	if !ok {
+		return records, fmt.Errorf("unknown user %q in signed part", username)
+	}
+	mac := hmac.New(sha256.New, []byte(password))
+	mac.Write([]byte(username))
+	mac.Write(rest)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return records, fmt.Errorf("signature verification failed for user %q", username)
+	}
+	if trust < securityLevelSign {
+		trust = securityLevelSign
+	}
+	return p.parseParts(rest, trust, records)
+}
+
+// parseEncrypted decrypts an AES-256-OFB encrypted part, checks its SHA1
+// checksum and decodes the plaintext as encrypted data.
+func (p *networkParser) parseEncrypted(part []byte, records []collectDRecord) ([]collectDRecord, error) {
+	if len(part) < 2 {
+		return records, errors.New("truncated encrypted part")
+	}
+	usernameLen := int(binary.BigEndian.Uint16(part[0:2]))
+	part = part[2:]
+	if len(part) < usernameLen+ivLen+checksumLen {
+		return records, errors.New("truncated encrypted part")
+	}
+	if p.passwords == nil && p.level == securityLevelNone {
+		// Without credentials the part cannot be decrypted, skip it.
+		return records, nil
+	}
+	username := string(part[:usernameLen])
+	password, ok := p.passwords[username]
+	if !ok {
+		return records, fmt.Errorf("unknown user %q in encrypted part", username)
+	}
+	iv, ciphertext := part[usernameLen:usernameLen+ivLen], part[usernameLen+ivLen:]
+
+	key := sha256.Sum256([]byte(password))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return records, err
+	}
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewOFB(block, iv).XORKeyStream(plaintext, ciphertext)
+
+	checksum, payload := plaintext[:checksumLen], plaintext[checksumLen:]
+	if sum := sha1.Sum(payload); !bytes.Equal(checksum, sum[:]) { // #nosec
+		return records, fmt.Errorf("checksum mismatch decrypting data for user %q", username)
+	}
+	return p.parseParts(payload, securityLevelEncrypt, records)
+}
+
+func (p *networkParser) decodeValues(part []byte, state *networkState) (collectDRecord, error) {
+	if len(part) < 2 {
+		return collectDRecord{}, errors.New("truncated values part")
+	}
+	count := int(binary.BigEndian.Uint16(part[0:2]))
+	part = part[2:]
+	if len(part) != count*(1+numericLen) {
+		return collectDRecord{}, fmt.Errorf("values part of %d bytes does not hold %d values", len(part), count)
+	}
+	types, data := part[:count], part[count:]
+
+	dsNames := p.typesDB[state.typeS]
+	if len(dsNames) != count {
+		dsNames = defaultDSNames(count)
+	}
+
+	record := collectDRecord{
+		Dsnames:        make([]*string, count),
+		Dstypes:        make([]*string, count),
+		Values:         make([]*json.Number, count),
+		Host:           stringPtr(state.host),
+		Plugin:         stringPtr(state.plugin),
+		PluginInstance: stringPtr(state.pluginInstance),
+		TypeS:          stringPtr(state.typeS),
+		TypeInstance:   stringPtr(state.typeInstance),
+	}
+	if state.time != 0 {
+		t := state.time
+		record.Time = &t
+	}
+	if state.interval != 0 {
+		interval := state.interval
+		record.Interval = &interval
+	}
+
+	for i := 0; i < count; i++ {
+		raw := data[i*numericLen : (i+1)*numericLen]
+		var dsType, val string
+		switch types[i] {
+		case dsTypeCounter:
+			dsType, val = collectDMetricCounter, strconv.FormatUint(binary.BigEndian.Uint64(raw), 10)
+		case dsTypeGauge:
+			dsType, val = collectDMetricGauge, formatGauge(math.Float64frombits(binary.LittleEndian.Uint64(raw)))
+		case dsTypeDerive:
+			dsType, val = collectDMetricDerive, strconv.FormatInt(int64(binary.BigEndian.Uint64(raw)), 10)
+		case dsTypeAbsolute:
+			dsType, val = collectDMetricAbsolute, strconv.FormatUint(binary.BigEndian.Uint64(raw), 10)
+		default:
+			return collectDRecord{}, fmt.Errorf("unknown data source type %d", types[i])
+		}
+		num := json.Number(val)
+		record.Dsnames[i] = stringPtr(dsNames[i])
+		record.Dstypes[i] = &dsType
+		record.Values[i] = &num
+	}
+	return record, nil
+}
+
+// defaultDSNames names the values of types missing from types.db: "value" for a
+// single value, the value index otherwise.
+func defaultDSNames(count int) []string {
+	if count == 1 {
+		return []string{"value"}
+	}
+	names := make([]string, count)
+	for i := range names {
+		names[i] = strconv.Itoa(i)
+	}
+	return names
+}
+
+// formatGauge formats a gauge so that it is always decoded as a double, even if it
+// has no fractional part.
+func formatGauge(v float64) string {
+	s := strconv.FormatFloat(v, 'g', -1, 64)
+	if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+		s += ".0"
+	}
+	return s
+}
+
+func decodeString(part []byte) (string, error) {
+	if len(part) == 0 || part[len(part)-1] != 0 {
+		return "", errors.New("string is not null terminated")
+	}
+	return string(part[:len(part)-1]), nil
+}
+
+func decodeNumeric(part []byte) (uint64, error) {
+	if len(part) != numericLen {
+		return 0, fmt.Errorf("numeric part of %d bytes, expected %d", len(part), numericLen)
+	}
+	return binary.BigEndian.Uint64(part), nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
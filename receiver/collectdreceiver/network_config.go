@@ -0,0 +1,96 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectdreceiver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// loadTypesDB reads the value names of every type defined in the given types.db
+// files. Types defined in later files override the earlier definitions.
+func loadTypesDB(paths []string) (map[string][]string, error) {
+	types := make(map[string][]string)
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open types.db: %w", err)
+		}
+		err = parseTypesDB(f, types)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse types.db %s: %w", path, err)
+		}
+	}
+	return types, nil
+}
+
+// parseTypesDB parses lines in the format
+// "if_octets  rx:DERIVE:0:U, tx:DERIVE:0:U".
+func parseTypesDB(r io.Reader, types map[string][]string) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return fmt.Errorf("invalid type definition %q", line)
+		}
+		sources := strings.Split(strings.Join(fields[1:], ""), ",")
+		names := make([]string, 0, len(sources))
+		for _, source := range sources {
+			spec := strings.Split(source, ":")
+			if len(spec) != 4 || spec[0] == "" {
+				return fmt.Errorf("invalid data source %q for type %s", source, fields[0])
+			}
+			names = append(names, spec[0])
+		}
+		types[fields[0]] = names
+	}
+	return scanner.Err()
+}
+
+// loadAuthFile reads the credentials from a collectd network plugin AuthFile,
+// one "username: password" entry per line.
+func loadAuthFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open auth_file: %w", err)
+	}
+	defer f.Close()
+
+	passwords := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		idx := strings.Index(line, ":")
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid auth_file entry on line %d", lineNum)
+		}
+		passwords[strings.TrimSpace(line[:idx])] = strings.TrimSpace(line[idx+1:])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read auth_file: %w", err)
+	}
+	return passwords, nil
+}
@@ -0,0 +1,391 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectdreceiver
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha1" // #nosec
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testUser     = "collectd"
+	testPassword = "secret"
+)
+
+func part(partType uint16, payload []byte) []byte {
+	buf := make([]byte, partHeaderLen, partHeaderLen+len(payload))
+	binary.BigEndian.PutUint16(buf[0:2], partType)
+	binary.BigEndian.PutUint16(buf[2:4], uint16(partHeaderLen+len(payload)))
+	return append(buf, payload...)
+}
+
+func stringPart(partType uint16, s string) []byte {
+	return part(partType, append([]byte(s), 0))
+}
+
+func numericPart(partType uint16, v uint64) []byte {
+	buf := make([]byte, numericLen)
+	binary.BigEndian.PutUint64(buf, v)
+	return part(partType, buf)
+}
+
+type testValue struct {
+	dsType byte
+	value  uint64
+}
+
+func gaugeValue(v float64) testValue {
+	return testValue{dsType: dsTypeGauge, value: math.Float64bits(v)}
+}
+
+func valuesPart(values ...testValue) []byte {
+	buf := make([]byte, 2, 2+len(values)*(1+numericLen))
+	binary.BigEndian.PutUint16(buf, uint16(len(values)))
+	for _, v := range values {
+		buf = append(buf, v.dsType)
+	}
+	for _, v := range values {
+		raw := make([]byte, numericLen)
+		if v.dsType == dsTypeGauge {
+			binary.LittleEndian.PutUint64(raw, v.value)
+		} else {
+			binary.BigEndian.PutUint64(raw, v.value)
+		}
+		buf = append(buf, raw...)
+	}
+	return part(partTypeValues, buf)
+}
+
+func packet(parts ...[]byte) []byte {
+	var buf []byte
+	for _, p := range parts {
+		buf = append(buf, p...)
+	}
+	return buf
+}
+
+func signedPacket(user, password string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(password))
+	mac.Write([]byte(user))
+	mac.Write(payload)
+	return append(part(partTypeSignature, append(mac.Sum(nil), user...)), payload...)
+}
+
+func encryptedPacket(user, password string, payload []byte) []byte {
+	sum := sha1.Sum(payload) // #nosec
+	plaintext := append(sum[:], payload...)
+
+	key := sha256.Sum256([]byte(password))
+	block, _ := aes.NewCipher(key[:])
+	iv := []byte("0123456789abcdef")
+	ciphertext := make([]byte, len(plaintext))
+	cipher.NewOFB(block, iv).XORKeyStream(ciphertext, plaintext)
+
+	buf := make([]byte, 2)
+	binary.BigEndian.PutUint16(buf, uint16(len(user)))
+	buf = append(buf, user...)
+	buf = append(buf, iv...)
+	buf = append(buf, ciphertext...)
+	return part(partTypeEncryption, buf)
+}
+
+func testPayload() []byte {
+	return packet(
+		stringPart(partTypeHost, "i-b13d1e5f"),
+		numericPart(partTypeTimeHR, 1415062577<<30),
+		numericPart(partTypeIntervalHR, 10<<30),
+		stringPart(partTypePlugin, "interface"),
+		stringPart(partTypePluginInstance, "eth0"),
+		stringPart(partTypeType, "if_octets"),
+		valuesPart(testValue{dsType: dsTypeDerive, value: 100}, testValue{dsType: dsTypeDerive, value: 200}),
+		stringPart(partTypePlugin, "memory"),
+		stringPart(partTypePluginInstance, ""),
+		stringPart(partTypeType, "memory"),
+		stringPart(partTypeTypeInstance, "free"),
+		valuesPart(gaugeValue(2)),
+	)
+}
+
+func TestNetworkParse(t *testing.T) {
+	p := &networkParser{typesDB: map[string][]string{"if_octets": {"rx", "tx"}}}
+	records, err := p.parse(testPayload())
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	r := records[0]
+	assert.Equal(t, "i-b13d1e5f", *r.Host)
+	assert.Equal(t, float64(1415062577), *r.Time)
+	assert.Equal(t, float64(10), *r.Interval)
+	assert.Equal(t, "interface", *r.Plugin)
+	assert.Equal(t, "eth0", *r.PluginInstance)
+	assert.Equal(t, "if_octets", *r.TypeS)
+	assert.Equal(t, "", *r.TypeInstance)
+	require.Len(t, r.Values, 2)
+	assert.Equal(t, "rx", *r.Dsnames[0])
+	assert.Equal(t, "tx", *r.Dsnames[1])
+	assert.Equal(t, collectDMetricDerive, *r.Dstypes[0])
+	assert.Equal(t, "100", r.Values[0].String())
+	assert.Equal(t, "200", r.Values[1].String())
+
+	r = records[1]
+	assert.Equal(t, "i-b13d1e5f", *r.Host)
+	assert.Equal(t, "memory", *r.Plugin)
+	assert.Equal(t, "free", *r.TypeInstance)
+	require.Len(t, r.Values, 1)
+	assert.Equal(t, "value", *r.Dsnames[0])
+	assert.Equal(t, collectDMetricGauge, *r.Dstypes[0])
+	assert.Equal(t, "2.0", r.Values[0].String())
+
+	metrics, err := r.appendToMetrics(nil, nil)
+	require.NoError(t, err)
+	require.Len(t, metrics, 1)
+	assert.Equal(t, "memory.free", metrics[0].MetricDescriptor.Name)
+	assert.Equal(t, 2.0, metrics[0].Timeseries[0].Points[0].GetDoubleValue())
+}
+
+func TestNetworkParseDefaultDSNames(t *testing.T) {
+	p := &networkParser{}
+	records, err := p.parse(packet(
+		stringPart(partTypeType, "if_octets"),
+		valuesPart(testValue{dsType: dsTypeCounter, value: 1}, testValue{dsType: dsTypeAbsolute, value: 2}),
+	))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "0", *records[0].Dsnames[0])
+	assert.Equal(t, "1", *records[0].Dsnames[1])
+	assert.Equal(t, collectDMetricCounter, *records[0].Dstypes[0])
+	assert.Equal(t, collectDMetricAbsolute, *records[0].Dstypes[1])
+}
+
+func TestNetworkParseErrors(t *testing.T) {
+	valid := valuesPart(gaugeValue(1))
+	tests := []struct {
+		name    string
+		packet  []byte
+		wantErr string
+		records int
+	}{
+		{
+			name:    "truncated header",
+			packet:  []byte{0, 0},
+			wantErr: "truncated part header",
+		},
+		{
+			name:    "invalid length",
+			packet:  []byte{0, 0, 0, 10, 'a', 0},
+			wantErr: "invalid length 10 for part type 0x0000",
+		},
+		{
+			name:    "string not null terminated",
+			packet:  part(partTypeHost, []byte("host")),
+			wantErr: "error decoding part type 0x0000: string is not null terminated",
+		},
+		{
+			name:    "invalid numeric",
+			packet:  part(partTypeTime, []byte{1, 2}),
+			wantErr: "error decoding part type 0x0001: numeric part of 2 bytes, expected 8",
+		},
+		{
+			name:    "unknown data source type",
+			packet:  packet(valid, valuesPart(testValue{dsType: 9})),
+			wantErr: "error decoding part type 0x0006: unknown data source type 9",
+			records: 1,
+		},
+		{
+			name:    "values count mismatch",
+			packet:  part(partTypeValues, []byte{0, 2, dsTypeGauge}),
+			wantErr: "error decoding part type 0x0006: values part of 1 bytes does not hold 2 values",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := (&networkParser{}).parse(tt.packet)
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Len(t, records, tt.records)
+		})
+	}
+}
+
+func TestNetworkParseUnknownPart(t *testing.T) {
+	records, err := (&networkParser{}).parse(packet(
+		part(0x0f00, []byte{1, 2, 3}),
+		stringPart(partTypeMessage, "notification"),
+		numericPart(partTypeSeverity, 4),
+		valuesPart(gaugeValue(1)),
+	))
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+}
+
+func TestNetworkSecurity(t *testing.T) {
+	payload := testPayload()
+	passwords := map[string]string{testUser: testPassword}
+
+	tests := []struct {
+		name      string
+		level     securityLevel
+		passwords map[string]string
+		packet    []byte
+		wantErr   string
+		records   int
+	}{
+		{
+			name:    "none accepts plain data",
+			packet:  payload,
+			records: 2,
+		},
+		{
+			name:    "none accepts signed data without credentials",
+			packet:  signedPacket(testUser, "unknown", payload),
+			records: 2,
+		},
+		{
+			name:    "none skips encrypted data without credentials",
+			packet:  encryptedPacket(testUser, testPassword, payload),
+			records: 0,
+		},
+		{
+			name:      "none decrypts with credentials",
+			passwords: passwords,
+			packet:    encryptedPacket(testUser, testPassword, payload),
+			records:   2,
+		},
+		{
+			name:      "sign rejects plain data",
+			level:     securityLevelSign,
+			passwords: passwords,
+			packet:    payload,
+			wantErr:   errInsufficientSecurity.Error(),
+		},
+		{
+			name:      "sign accepts signed data",
+			level:     securityLevelSign,
+			passwords: passwords,
+			packet:    signedPacket(testUser, testPassword, payload),
+			records:   2,
+		},
+		{
+			name:      "sign accepts encrypted data",
+			level:     securityLevelSign,
+			passwords: passwords,
+			packet:    encryptedPacket(testUser, testPassword, payload),
+			records:   2,
+		},
+		{
+			name:      "sign rejects invalid signature",
+			level:     securityLevelSign,
+			passwords: passwords,
+			packet:    signedPacket(testUser, "wrong", payload),
+			wantErr:   `signature verification failed for user "collectd"`,
+		},
+		{
+			name:      "sign rejects unknown user",
+			level:     securityLevelSign,
+			passwords: passwords,
+			packet:    signedPacket("other", testPassword, payload),
+			wantErr:   `unknown user "other" in signed part`,
+		},
+		{
+			name:      "encrypt rejects signed data",
+			level:     securityLevelEncrypt,
+			passwords: passwords,
+			packet:    signedPacket(testUser, testPassword, payload),
+			wantErr:   errInsufficientSecurity.Error(),
+		},
+		{
+			name:      "encrypt accepts encrypted data",
+			level:     securityLevelEncrypt,
+			passwords: passwords,
+			packet:    encryptedPacket(testUser, testPassword, payload),
+			records:   2,
+		},
+		{
+			name:      "encrypt accepts signed and encrypted data",
+			level:     securityLevelEncrypt,
+			passwords: passwords,
+			packet:    encryptedPacket(testUser, testPassword, signedPacket(testUser, testPassword, payload)),
+			records:   2,
+		},
+		{
+			name:      "encrypt rejects invalid password",
+			level:     securityLevelEncrypt,
+			passwords: passwords,
+			packet:    encryptedPacket(testUser, "wrong", payload),
+			wantErr:   `error decoding part type 0x0210: checksum mismatch decrypting data for user "collectd"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &networkParser{level: tt.level, passwords: tt.passwords}
+			records, err := p.parse(tt.packet)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, records, tt.records)
+		})
+	}
+}
+
+func TestParseSecurityLevel(t *testing.T) {
+	for s, want := range map[string]securityLevel{
+		"":        securityLevelNone,
+		"None":    securityLevelNone,
+		"sign":    securityLevelSign,
+		"Encrypt": securityLevelEncrypt,
+	} {
+		level, err := parseSecurityLevel(s)
+		assert.NoError(t, err)
+		assert.Equal(t, want, level)
+	}
+	_, err := parseSecurityLevel("invalid")
+	assert.EqualError(t, err, `invalid security_level "invalid", must be one of none, sign or encrypt`)
+}
+
+func TestLoadTypesDB(t *testing.T) {
+	types, err := loadTypesDB([]string{"./testdata/types.db"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"if_octets": {"rx", "tx"},
+		"load":      {"shortterm", "midterm", "longterm"},
+		"memory":    {"value"},
+	}, types)
+
+	_, err = loadTypesDB([]string{"./testdata/missing.db"})
+	assert.Error(t, err)
+
+	err = parseTypesDB(strings.NewReader("invalid value"), map[string][]string{})
+	assert.EqualError(t, err, `invalid data source "value" for type invalid`)
+}
+
+func TestLoadAuthFile(t *testing.T) {
+	passwords, err := loadAuthFile("./testdata/auth_file")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{testUser: testPassword}, passwords)
+
+	_, err = loadAuthFile("./testdata/missing")
+	assert.Error(t, err)
+}
@@ -17,6 +17,7 @@ package collectdreceiver
 import (
 	"bytes"
 	"context"
+	"net"
 	"net/http"
 	"testing"
 	"time"
@@ -209,6 +210,76 @@ func TestCollectDServer(t *testing.T) {
 	}
 }
 
+func TestCollectDBinaryServer(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0"
+	cfg.Encoding = binaryEncodingFormat
+	cfg.SecurityLevel = "sign"
+	cfg.AuthFile = "./testdata/auth_file"
+	cfg.TypesDB = []string{"./testdata/types.db"}
+
+	sink := new(consumertest.MetricsSink)
+	cdr, err := newCollectdBinaryReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, cdr.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, cdr.Shutdown(context.Background()))
+	}()
+
+	conn, err := net.Dial("udp", cdr.(*collectdBinaryReceiver).conn.LocalAddr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Unsigned data is dropped with security_level "sign".
+	_, err = conn.Write(testPayload())
+	require.NoError(t, err)
+	_, err = conn.Write(signedPacket(testUser, testPassword, testPayload()))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return sink.DataPointCount() == 3
+	}, 10*time.Second, 5*time.Millisecond)
+
+	mds := sink.AllMetrics()
+	require.Len(t, mds, 1)
+	metrics := mds[0].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	names := make([]string, 0, metrics.Len())
+	for i := 0; i < metrics.Len(); i++ {
+		names = append(names, metrics.At(i).Name())
+	}
+	assert.Equal(t, []string{"if_octets.rx", "if_octets.tx", "memory.free"}, names)
+}
+
+func TestNewBinaryReceiverErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr string
+	}{
+		{
+			name:    "invalid security level",
+			cfg:     &Config{SecurityLevel: "invalid"},
+			wantErr: `invalid security_level "invalid", must be one of none, sign or encrypt`,
+		},
+		{
+			name:    "missing auth file",
+			cfg:     &Config{SecurityLevel: "encrypt"},
+			wantErr: `auth_file is required with security_level "encrypt"`,
+		},
+		{
+			name:    "unreadable types.db",
+			cfg:     &Config{TypesDB: []string{"./testdata/missing.db"}},
+			wantErr: "failed to open types.db: open ./testdata/missing.db: no such file or directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newCollectdBinaryReceiver(zap.NewNop(), tt.cfg, consumertest.NewNop())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
 func assertMetricsDataAreEqual(t *testing.T, metricsData1, metricsData2 []*agentmetricspb.ExportMetricsServiceRequest) {
 	if len(metricsData1) != len(metricsData2) {
 		t.Errorf("metrics data length mismatch. got:\n%d\nwant:\n%d\n", len(metricsData1), len(metricsData2))
@@ -0,0 +1,78 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogreceiver
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+)
+
+// Config defines configuration for the Datadog receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"`
+	// HTTPServerSettings configures the server of the trace intake, used by the traces pipelines.
+	confighttp.HTTPServerSettings `mapstructure:",squash"`
+	// MaxRequestBodySize is the largest body of a trace intake request, in bytes.
+	MaxRequestBodySize int64 `mapstructure:"max_request_body_size"`
+	// DogStatsD configures the DogStatsD server, used by the metrics pipelines.
+	DogStatsD DogStatsDConfig `mapstructure:"dogstatsd"`
+}
+
+// DogStatsDConfig defines how DogStatsD metrics are received and aggregated.
+type DogStatsDConfig struct {
+	// Endpoint is the UDP address DogStatsD metrics are received on.
+	Endpoint string `mapstructure:"endpoint"`
+	// AggregationInterval is the interval the received metrics are aggregated over.
+	AggregationInterval time.Duration `mapstructure:"aggregation_interval"`
+	// EnableMetricType adds the DogStatsD metric type as the "metric_type" label.
+	EnableMetricType bool `mapstructure:"enable_metric_type"`
+	// TimerHistogramMapping configures how timings, histograms and distributions are observed,
+	// as the statsd receiver setting of the same name.
+	TimerHistogramMapping []protocol.TimerHistogramMapping `mapstructure:"timer_histogram_mapping"`
+}
+
+var (
+	supportedStatsdTypes   = map[string]bool{"timing": true, "timer": true, "histogram": true, "distribution": true}
+	supportedObserverTypes = map[string]bool{"gauge": true, "summary": true, "histogram": true}
+)
+
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return fmt.Errorf("missing required field `endpoint`")
+	}
+	if cfg.MaxRequestBodySize <= 0 {
+		return fmt.Errorf("max_request_body_size must be positive")
+	}
+	if cfg.DogStatsD.Endpoint == "" {
+		return fmt.Errorf("missing required field `dogstatsd.endpoint`")
+	}
+	if cfg.DogStatsD.AggregationInterval <= 0 {
+		return fmt.Errorf("dogstatsd.aggregation_interval must be a positive duration")
+	}
+	for _, mapping := range cfg.DogStatsD.TimerHistogramMapping {
+		if !supportedStatsdTypes[mapping.StatsdType] {
+			return fmt.Errorf("statsd_type is not supported: %q", mapping.StatsdType)
+		}
+		if !supportedObserverTypes[mapping.ObserverType] {
+			return fmt.Errorf("observer_type is not supported: %q", mapping.ObserverType)
+		}
+	}
+	return nil
+}
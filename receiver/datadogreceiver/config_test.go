@@ -0,0 +1,115 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r1 := cfg.Receivers[config.NewID(typeStr)]
+	assert.Equal(t, r1, factory.CreateDefaultConfig())
+
+	r2 := cfg.Receivers[config.NewIDWithName(typeStr, "custom")].(*Config)
+	assert.Equal(t, r2,
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewIDWithName(typeStr, "custom")),
+			HTTPServerSettings: confighttp.HTTPServerSettings{
+				Endpoint: "0.0.0.0:8127",
+			},
+			MaxRequestBodySize: 1024 * 1024,
+			DogStatsD: DogStatsDConfig{
+				Endpoint:            "0.0.0.0:8135",
+				AggregationInterval: 10 * time.Second,
+				EnableMetricType:    true,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "distribution", ObserverType: "summary"},
+				},
+			},
+		})
+}
+
+func TestInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "missing endpoint",
+			modify:  func(cfg *Config) { cfg.Endpoint = "" },
+			wantErr: "missing required field `endpoint`",
+		},
+		{
+			name:    "invalid max request body size",
+			modify:  func(cfg *Config) { cfg.MaxRequestBodySize = 0 },
+			wantErr: "max_request_body_size must be positive",
+		},
+		{
+			name:    "missing dogstatsd endpoint",
+			modify:  func(cfg *Config) { cfg.DogStatsD.Endpoint = "" },
+			wantErr: "missing required field `dogstatsd.endpoint`",
+		},
+		{
+			name:    "invalid aggregation interval",
+			modify:  func(cfg *Config) { cfg.DogStatsD.AggregationInterval = 0 },
+			wantErr: "dogstatsd.aggregation_interval must be a positive duration",
+		},
+		{
+			name: "invalid statsd type",
+			modify: func(cfg *Config) {
+				cfg.DogStatsD.TimerHistogramMapping = []protocol.TimerHistogramMapping{{StatsdType: "counter", ObserverType: "gauge"}}
+			},
+			wantErr: `statsd_type is not supported: "counter"`,
+		},
+		{
+			name: "invalid observer type",
+			modify: func(cfg *Config) {
+				cfg.DogStatsD.TimerHistogramMapping = []protocol.TimerHistogramMapping{{StatsdType: "timer", ObserverType: "counter"}}
+			},
+			wantErr: `observer_type is not supported: "counter"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,488 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogreceiver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/tinylib/msgp/msgp"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+)
+
+// ddSpan is a span of the Datadog trace intake, see
+// https://github.com/DataDog/datadog-agent/blob/main/pkg/trace/pb/span.proto.
+type ddSpan struct {
+	Service  string             `json:"service"`
+	Name     string             `json:"name"`
+	Resource string             `json:"resource"`
+	TraceID  uint64             `json:"trace_id"`
+	SpanID   uint64             `json:"span_id"`
+	ParentID uint64             `json:"parent_id"`
+	Start    int64              `json:"start"`
+	Duration int64              `json:"duration"`
+	Error    int32              `json:"error"`
+	Meta     map[string]string  `json:"meta"`
+	Metrics  map[string]float64 `json:"metrics"`
+	Type     string             `json:"type"`
+}
+
+// tracePayload holds the traces of an intake request along with the resource attributes that
+// apply to all of them.
+type tracePayload struct {
+	traces   [][]*ddSpan
+	resource map[string]string
+}
+
+// v05SpanFields is the number of fields of a span encoded with the v0.5 API.
+const v05SpanFields = 12
+
+var errInvalidSpan = errors.New("span is not an array of 12 elements")
+
+// decodeJSONTraces decodes the traces sent as JSON to the v0.3 and v0.4 APIs.
+func decodeJSONTraces(body []byte) ([][]*ddSpan, error) {
+	var traces [][]*ddSpan
+	if err := json.Unmarshal(body, &traces); err != nil {
+		return nil, err
+	}
+	return traces, nil
+}
+
+// decodeMsgpackTraces decodes the traces sent as MessagePack to the v0.3 and v0.4 APIs, an
+// array of traces, each of them an array of spans encoded as maps.
+func decodeMsgpackTraces(b []byte) ([][]*ddSpan, error) {
+	n, b, err := msgp.ReadArrayHeaderBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkCount(n, b); err != nil {
+		return nil, err
+	}
+	traces := make([][]*ddSpan, 0, n)
+	for i := uint32(0); i < n; i++ {
+		var trace []*ddSpan
+		if trace, b, err = decodeMsgpackSpans(b); err != nil {
+			return nil, err
+		}
+		traces = append(traces, trace)
+	}
+	return traces, nil
+}
+
+func decodeMsgpackSpans(b []byte) ([]*ddSpan, []byte, error) {
+	n, b, err := msgp.ReadArrayHeaderBytes(b)
+	if err != nil {
+		return nil, b, err
+	}
+	if err = checkCount(n, b); err != nil {
+		return nil, b, err
+	}
+	spans := make([]*ddSpan, 0, n)
+	for i := uint32(0); i < n; i++ {
+		var span *ddSpan
+		if span, b, err = decodeMsgpackSpan(b); err != nil {
+			return nil, b, err
+		}
+		spans = append(spans, span)
+	}
+	return spans, b, nil
+}
+
+func decodeMsgpackSpan(b []byte) (*ddSpan, []byte, error) {
+	n, b, err := msgp.ReadMapHeaderBytes(b)
+	if err != nil {
+		return nil, b, err
+	}
+	span := &ddSpan{}
+	for i := uint32(0); i < n; i++ {
+		var key string
+		if key, b, err = readString(b); err != nil {
+			return nil, b, err
+		}
+		switch key {
+		case "service":
+			span.Service, b, err = readString(b)
+		case "name":
+			span.Name, b, err = readString(b)
+		case "resource":
+			span.Resource, b, err = readString(b)
+		case "trace_id":
+			span.TraceID, b, err = readUint64(b)
+		case "span_id":
+			span.SpanID, b, err = readUint64(b)
+		case "parent_id":
+			span.ParentID, b, err = readUint64(b)
+		case "start":
+			span.Start, b, err = readInt64(b)
+		case "duration":
+			span.Duration, b, err = readInt64(b)
+		case "error":
+			var v int64
+			v, b, err = readInt64(b)
+			span.Error = int32(v)
+		case "meta":
+			span.Meta, b, err = readStringMap(b)
+		case "metrics":
+			span.Metrics, b, err = readFloat64Map(b)
+		case "type":
+			span.Type, b, err = readString(b)
+		default:
+			b, err = msgp.Skip(b)
+		}
+		if err != nil {
+			return nil, b, fmt.Errorf("failed to decode span field %q: %w", key, err)
+		}
+	}
+	return span, b, nil
+}
+
+// decodeMsgpackV05Traces decodes the traces sent to the v0.5 API, an array holding a dictionary
+// of strings and the traces, whose spans are arrays of fields referencing the dictionary.
+func decodeMsgpackV05Traces(b []byte) ([][]*ddSpan, error) {
+	n, b, err := msgp.ReadArrayHeaderBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	if n != 2 {
+		return nil, errors.New("payload is not an array of 2 elements")
+	}
+	if n, b, err = msgp.ReadArrayHeaderBytes(b); err != nil {
+		return nil, err
+	}
+	if err = checkCount(n, b); err != nil {
+		return nil, err
+	}
+	dict := make([]string, n)
+	for i := range dict {
+		if dict[i], b, err = readString(b); err != nil {
+			return nil, err
+		}
+	}
+
+	if n, b, err = msgp.ReadArrayHeaderBytes(b); err != nil {
+		return nil, err
+	}
+	if err = checkCount(n, b); err != nil {
+		return nil, err
+	}
+	traces := make([][]*ddSpan, 0, n)
+	for i := uint32(0); i < n; i++ {
+		var spanCount uint32
+		if spanCount, b, err = msgp.ReadArrayHeaderBytes(b); err != nil {
+			return nil, err
+		}
+		if err = checkCount(spanCount, b); err != nil {
+			return nil, err
+		}
+		trace := make([]*ddSpan, 0, spanCount)
+		for j := uint32(0); j < spanCount; j++ {
+			var span *ddSpan
+			if span, b, err = decodeMsgpackV05Span(b, dict); err != nil {
+				return nil, err
+			}
+			trace = append(trace, span)
+		}
+		traces = append(traces, trace)
+	}
+	return traces, nil
+}
+
+func decodeMsgpackV05Span(b []byte, dict []string) (*ddSpan, []byte, error) {
+	n, b, err := msgp.ReadArrayHeaderBytes(b)
+	if err != nil {
+		return nil, b, err
+	}
+	if n != v05SpanFields {
+		return nil, b, errInvalidSpan
+	}
+	span := &ddSpan{}
+	if span.Service, b, err = readDictString(b, dict); err != nil {
+		return nil, b, err
+	}
+	if span.Name, b, err = readDictString(b, dict); err != nil {
+		return nil, b, err
+	}
+	if span.Resource, b, err = readDictString(b, dict); err != nil {
+		return nil, b, err
+	}
+	if span.TraceID, b, err = readUint64(b); err != nil {
+		return nil, b, err
+	}
+	if span.SpanID, b, err = readUint64(b); err != nil {
+		return nil, b, err
+	}
+	if span.ParentID, b, err = readUint64(b); err != nil {
+		return nil, b, err
+	}
+	if span.Start, b, err = readInt64(b); err != nil {
+		return nil, b, err
+	}
+	if span.Duration, b, err = readInt64(b); err != nil {
+		return nil, b, err
+	}
+	var spanErr int64
+	if spanErr, b, err = readInt64(b); err != nil {
+		return nil, b, err
+	}
+	span.Error = int32(spanErr)
+
+	if n, b, err = msgp.ReadMapHeaderBytes(b); err != nil {
+		return nil, b, err
+	}
+	if err = checkCount(n, b); err != nil {
+		return nil, b, err
+	}
+	span.Meta = make(map[string]string, n)
+	for i := uint32(0); i < n; i++ {
+		var k, v string
+		if k, b, err = readDictString(b, dict); err != nil {
+			return nil, b, err
+		}
+		if v, b, err = readDictString(b, dict); err != nil {
+			return nil, b, err
+		}
+		span.Meta[k] = v
+	}
+
+	if n, b, err = msgp.ReadMapHeaderBytes(b); err != nil {
+		return nil, b, err
+	}
+	if err = checkCount(n, b); err != nil {
+		return nil, b, err
+	}
+	span.Metrics = make(map[string]float64, n)
+	for i := uint32(0); i < n; i++ {
+		var k string
+		var v float64
+		if k, b, err = readDictString(b, dict); err != nil {
+			return nil, b, err
+		}
+		if v, b, err = readFloat64(b); err != nil {
+			return nil, b, err
+		}
+		span.Metrics[k] = v
+	}
+
+	span.Type, b, err = readDictString(b, dict)
+	return span, b, err
+}
+
+// decodeMsgpackV07Payload decodes the tracer payload sent to the v0.7 API, a map holding the
+// metadata of the tracer and the trace chunks.
+func decodeMsgpackV07Payload(b []byte) (*tracePayload, error) {
+	n, b, err := msgp.ReadMapHeaderBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	payload := &tracePayload{resource: map[string]string{}}
+	for i := uint32(0); i < n; i++ {
+		var key, value string
+		if key, b, err = readString(b); err != nil {
+			return nil, err
+		}
+		switch key {
+		case "chunks":
+			var chunkCount uint32
+			if chunkCount, b, err = msgp.ReadArrayHeaderBytes(b); err != nil {
+				return nil, err
+			}
+			for j := uint32(0); j < chunkCount; j++ {
+				var spans []*ddSpan
+				if spans, b, err = decodeMsgpackV07Chunk(b); err != nil {
+					return nil, err
+				}
+				payload.traces = append(payload.traces, spans)
+			}
+		case "container_id", "language_name", "language_version", "tracer_version", "env", "hostname", "app_version":
+			if value, b, err = readString(b); err != nil {
+				return nil, err
+			}
+			if value != "" {
+				payload.resource[v07ResourceAttributes[key]] = value
+			}
+		default:
+			if b, err = msgp.Skip(b); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return payload, nil
+}
+
+// v07ResourceAttributes maps the fields of a v0.7 payload to resource attributes.
+var v07ResourceAttributes = map[string]string{
+	"container_id":     conventions.AttributeContainerID,
+	"language_name":    conventions.AttributeTelemetrySDKLanguage,
+	"language_version": attributeProcessRuntimeVersion,
+	"tracer_version":   conventions.AttributeTelemetrySDKVersion,
+	"env":              conventions.AttributeDeploymentEnvironment,
+	"hostname":         conventions.AttributeHostName,
+	"app_version":      conventions.AttributeServiceVersion,
+}
+
+func decodeMsgpackV07Chunk(b []byte) ([]*ddSpan, []byte, error) {
+	n, b, err := msgp.ReadMapHeaderBytes(b)
+	if err != nil {
+		return nil, b, err
+	}
+	var spans []*ddSpan
+	for i := uint32(0); i < n; i++ {
+		var key string
+		if key, b, err = readString(b); err != nil {
+			return nil, b, err
+		}
+		if key == "spans" {
+			spans, b, err = decodeMsgpackSpans(b)
+		} else {
+			b, err = msgp.Skip(b)
+		}
+		if err != nil {
+			return nil, b, err
+		}
+	}
+	return spans, b, nil
+}
+
+// checkCount checks that the n elements announced by an array or map header could fit in the
+// bytes left in b, each of them taking at least one byte, before allocating room for them.
+func checkCount(n uint32, b []byte) error {
+	if uint64(n) > uint64(len(b)) {
+		return fmt.Errorf("%d elements announced with only %d bytes left", n, len(b))
+	}
+	return nil
+}
+
+// The read functions below accept the different MessagePack types the tracers encode values
+// with, nil being decoded as the zero value.
+
+func readString(b []byte) (string, []byte, error) {
+	v, o, err := msgp.ReadIntfBytes(b)
+	if err != nil {
+		return "", b, err
+	}
+	switch v := v.(type) {
+	case string:
+		return v, o, nil
+	case []byte:
+		return string(v), o, nil
+	case nil:
+		return "", o, nil
+	}
+	return "", b, fmt.Errorf("expected a string, got %T", v)
+}
+
+func readDictString(b []byte, dict []string) (string, []byte, error) {
+	idx, b, err := readUint64(b)
+	if err != nil {
+		return "", b, err
+	}
+	if idx >= uint64(len(dict)) {
+		return "", b, fmt.Errorf("string index %d out of the dictionary of %d strings", idx, len(dict))
+	}
+	return dict[idx], b, nil
+}
+
+func readUint64(b []byte) (uint64, []byte, error) {
+	v, o, err := msgp.ReadIntfBytes(b)
+	if err != nil {
+		return 0, b, err
+	}
+	switch v := v.(type) {
+	case uint64:
+		return v, o, nil
+	case int64:
+		return uint64(v), o, nil
+	case nil:
+		return 0, o, nil
+	}
+	return 0, b, fmt.Errorf("expected an integer, got %T", v)
+}
+
+func readInt64(b []byte) (int64, []byte, error) {
+	v, o, err := readUint64(b)
+	return int64(v), o, err
+}
+
+func readFloat64(b []byte) (float64, []byte, error) {
+	v, o, err := msgp.ReadIntfBytes(b)
+	if err != nil {
+		return 0, b, err
+	}
+	switch v := v.(type) {
+	case float64:
+		return v, o, nil
+	case float32:
+		return float64(v), o, nil
+	case uint64:
+		return float64(v), o, nil
+	case int64:
+		return float64(v), o, nil
+	case nil:
+		return 0, o, nil
+	}
+	return 0, b, fmt.Errorf("expected a number, got %T", v)
+}
+
+func readStringMap(b []byte) (map[string]string, []byte, error) {
+	if msgp.IsNil(b) {
+		return nil, b[1:], nil
+	}
+	n, b, err := msgp.ReadMapHeaderBytes(b)
+	if err != nil {
+		return nil, b, err
+	}
+	if err = checkCount(n, b); err != nil {
+		return nil, b, err
+	}
+	m := make(map[string]string, n)
+	for i := uint32(0); i < n; i++ {
+		var k, v string
+		if k, b, err = readString(b); err != nil {
+			return nil, b, err
+		}
+		if v, b, err = readString(b); err != nil {
+			return nil, b, err
+		}
+		m[k] = v
+	}
+	return m, b, nil
+}
+
+func readFloat64Map(b []byte) (map[string]float64, []byte, error) {
+	if msgp.IsNil(b) {
+		return nil, b[1:], nil
+	}
+	n, b, err := msgp.ReadMapHeaderBytes(b)
+	if err != nil {
+		return nil, b, err
+	}
+	if err = checkCount(n, b); err != nil {
+		return nil, b, err
+	}
+	m := make(map[string]float64, n)
+	for i := uint32(0); i < n; i++ {
+		var k string
+		var v float64
+		if k, b, err = readString(b); err != nil {
+			return nil, b, err
+		}
+		if v, b, err = readFloat64(b); err != nil {
+			return nil, b, err
+		}
+		m[k] = v
+	}
+	return m, b, nil
+}
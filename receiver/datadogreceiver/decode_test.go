@@ -0,0 +1,333 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogreceiver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+)
+
+const testTraceID = uint64(1<<63 + 5)
+
+func testSpans() []*ddSpan {
+	return []*ddSpan{
+		{
+			Service:  "web",
+			Name:     "http.request",
+			Resource: "GET /users",
+			TraceID:  testTraceID,
+			SpanID:   1,
+			Start:    1627776000000000000,
+			Duration: 1000000,
+			Meta:     map[string]string{"http.method": "GET"},
+			Metrics:  map[string]float64{"_sampling_priority_v1": 1},
+			Type:     "web",
+		},
+		{
+			Service:  "db",
+			Name:     "postgres.query",
+			Resource: "SELECT 1",
+			TraceID:  testTraceID,
+			SpanID:   2,
+			ParentID: 1,
+			Start:    1627776000000000100,
+			Duration: 500,
+			Error:    1,
+			Meta:     map[string]string{"error.msg": "timeout"},
+			Metrics:  map[string]float64{},
+			Type:     "sql",
+		},
+	}
+}
+
+func appendMsgpackSpan(b []byte, span *ddSpan) []byte {
+	b = msgp.AppendMapHeader(b, 12)
+	b = msgp.AppendString(b, "service")
+	b = msgp.AppendString(b, span.Service)
+	b = msgp.AppendString(b, "name")
+	b = msgp.AppendString(b, span.Name)
+	b = msgp.AppendString(b, "resource")
+	b = msgp.AppendString(b, span.Resource)
+	b = msgp.AppendString(b, "trace_id")
+	b = msgp.AppendUint64(b, span.TraceID)
+	b = msgp.AppendString(b, "span_id")
+	b = msgp.AppendUint64(b, span.SpanID)
+	b = msgp.AppendString(b, "parent_id")
+	b = msgp.AppendUint64(b, span.ParentID)
+	b = msgp.AppendString(b, "start")
+	b = msgp.AppendInt64(b, span.Start)
+	b = msgp.AppendString(b, "duration")
+	b = msgp.AppendInt64(b, span.Duration)
+	b = msgp.AppendString(b, "error")
+	b = msgp.AppendInt32(b, span.Error)
+	b = msgp.AppendString(b, "meta")
+	b = msgp.AppendMapHeader(b, uint32(len(span.Meta)))
+	for k, v := range span.Meta {
+		b = msgp.AppendString(b, k)
+		b = msgp.AppendString(b, v)
+	}
+	b = msgp.AppendString(b, "metrics")
+	b = msgp.AppendMapHeader(b, uint32(len(span.Metrics)))
+	for k, v := range span.Metrics {
+		b = msgp.AppendString(b, k)
+		b = msgp.AppendFloat64(b, v)
+	}
+	b = msgp.AppendString(b, "type")
+	return msgp.AppendString(b, span.Type)
+}
+
+func msgpackTraces(traces ...[]*ddSpan) []byte {
+	b := msgp.AppendArrayHeader(nil, uint32(len(traces)))
+	for _, trace := range traces {
+		b = msgp.AppendArrayHeader(b, uint32(len(trace)))
+		for _, span := range trace {
+			b = appendMsgpackSpan(b, span)
+		}
+	}
+	return b
+}
+
+// v05Dictionary builds the string dictionary of a v0.5 payload.
+type v05Dictionary struct {
+	strings []string
+	index   map[string]uint32
+}
+
+func (d *v05Dictionary) ref(b []byte, s string) []byte {
+	idx, ok := d.index[s]
+	if !ok {
+		idx = uint32(len(d.strings))
+		d.strings = append(d.strings, s)
+		d.index[s] = idx
+	}
+	return msgp.AppendUint32(b, idx)
+}
+
+func msgpackV05Traces(traces ...[]*ddSpan) []byte {
+	dict := &v05Dictionary{index: map[string]uint32{}}
+	body := msgp.AppendArrayHeader(nil, uint32(len(traces)))
+	for _, trace := range traces {
+		body = msgp.AppendArrayHeader(body, uint32(len(trace)))
+		for _, span := range trace {
+			body = msgp.AppendArrayHeader(body, v05SpanFields)
+			body = dict.ref(body, span.Service)
+			body = dict.ref(body, span.Name)
+			body = dict.ref(body, span.Resource)
+			body = msgp.AppendUint64(body, span.TraceID)
+			body = msgp.AppendUint64(body, span.SpanID)
+			body = msgp.AppendUint64(body, span.ParentID)
+			body = msgp.AppendInt64(body, span.Start)
+			body = msgp.AppendInt64(body, span.Duration)
+			body = msgp.AppendInt32(body, span.Error)
+			body = msgp.AppendMapHeader(body, uint32(len(span.Meta)))
+			for k, v := range span.Meta {
+				body = dict.ref(body, k)
+				body = dict.ref(body, v)
+			}
+			body = msgp.AppendMapHeader(body, uint32(len(span.Metrics)))
+			for k, v := range span.Metrics {
+				body = dict.ref(body, k)
+				body = msgp.AppendFloat64(body, v)
+			}
+			body = dict.ref(body, span.Type)
+		}
+	}
+
+	b := msgp.AppendArrayHeader(nil, 2)
+	b = msgp.AppendArrayHeader(b, uint32(len(dict.strings)))
+	for _, s := range dict.strings {
+		b = msgp.AppendString(b, s)
+	}
+	return append(b, body...)
+}
+
+func msgpackV07Payload(spans []*ddSpan) []byte {
+	b := msgp.AppendMapHeader(nil, 5)
+	b = msgp.AppendString(b, "language_name")
+	b = msgp.AppendString(b, "go")
+	b = msgp.AppendString(b, "env")
+	b = msgp.AppendString(b, "prod")
+	b = msgp.AppendString(b, "hostname")
+	b = msgp.AppendString(b, "host-1")
+	b = msgp.AppendString(b, "tags")
+	b = msgp.AppendMapHeader(b, 1)
+	b = msgp.AppendString(b, "_dd.apm_mode")
+	b = msgp.AppendString(b, "full")
+	b = msgp.AppendString(b, "chunks")
+	b = msgp.AppendArrayHeader(b, 1)
+	b = msgp.AppendMapHeader(b, 3)
+	b = msgp.AppendString(b, "priority")
+	b = msgp.AppendInt32(b, 1)
+	b = msgp.AppendString(b, "spans")
+	b = msgp.AppendArrayHeader(b, uint32(len(spans)))
+	for _, span := range spans {
+		b = appendMsgpackSpan(b, span)
+	}
+	b = msgp.AppendString(b, "dropped_trace")
+	return msgp.AppendBool(b, false)
+}
+
+func TestDecodeMsgpackTraces(t *testing.T) {
+	traces, err := decodeMsgpackTraces(msgpackTraces(testSpans()))
+	require.NoError(t, err)
+	assert.Equal(t, [][]*ddSpan{testSpans()}, traces)
+}
+
+func TestDecodeMsgpackTracesLenientTypes(t *testing.T) {
+	b := msgp.AppendArrayHeader(nil, 1)
+	b = msgp.AppendArrayHeader(b, 1)
+	b = msgp.AppendMapHeader(b, 6)
+	b = msgp.AppendString(b, "service")
+	b = msgp.AppendBytes(b, []byte("web"))
+	b = msgp.AppendString(b, "trace_id")
+	b = msgp.AppendInt64(b, 42)
+	b = msgp.AppendString(b, "parent_id")
+	b = msgp.AppendNil(b)
+	b = msgp.AppendString(b, "meta")
+	b = msgp.AppendNil(b)
+	b = msgp.AppendString(b, "metrics")
+	b = msgp.AppendMapHeader(b, 2)
+	b = msgp.AppendString(b, "int")
+	b = msgp.AppendInt(b, 3)
+	b = msgp.AppendString(b, "float32")
+	b = msgp.AppendFloat32(b, 0.5)
+	b = msgp.AppendString(b, "meta_struct")
+	b = msgp.AppendMapHeader(b, 0)
+
+	traces, err := decodeMsgpackTraces(b)
+	require.NoError(t, err)
+	assert.Equal(t, [][]*ddSpan{{{
+		Service: "web",
+		TraceID: 42,
+		Metrics: map[string]float64{"int": 3, "float32": 0.5},
+	}}}, traces)
+}
+
+func TestDecodeJSONTraces(t *testing.T) {
+	body, err := json.Marshal([][]*ddSpan{testSpans()})
+	require.NoError(t, err)
+	traces, err := decodeJSONTraces(body)
+	require.NoError(t, err)
+	assert.Equal(t, [][]*ddSpan{testSpans()}, traces)
+}
+
+func TestDecodeMsgpackV05Traces(t *testing.T) {
+	traces, err := decodeMsgpackV05Traces(msgpackV05Traces(testSpans()))
+	require.NoError(t, err)
+	assert.Equal(t, [][]*ddSpan{testSpans()}, traces)
+}
+
+func TestDecodeMsgpackV07Payload(t *testing.T) {
+	payload, err := decodeMsgpackV07Payload(msgpackV07Payload(testSpans()))
+	require.NoError(t, err)
+	assert.Equal(t, [][]*ddSpan{testSpans()}, payload.traces)
+	assert.Equal(t, map[string]string{
+		"telemetry.sdk.language": "go",
+		"deployment.environment": "prod",
+		"host.name":              "host-1",
+	}, payload.resource)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	invalidDictRef := msgp.AppendArrayHeader(nil, 2)
+	invalidDictRef = msgp.AppendArrayHeader(invalidDictRef, 0)
+	invalidDictRef = msgp.AppendArrayHeader(invalidDictRef, 1)
+	invalidDictRef = msgp.AppendArrayHeader(invalidDictRef, 1)
+	invalidDictRef = msgp.AppendArrayHeader(invalidDictRef, v05SpanFields)
+	invalidDictRef = msgp.AppendUint32(invalidDictRef, 3)
+
+	invalidSpan := msgp.AppendArrayHeader(nil, 2)
+	invalidSpan = msgp.AppendArrayHeader(invalidSpan, 0)
+	invalidSpan = msgp.AppendArrayHeader(invalidSpan, 1)
+	invalidSpan = msgp.AppendArrayHeader(invalidSpan, 1)
+	invalidSpan = msgp.AppendArrayHeader(invalidSpan, 3)
+
+	invalidField := msgp.AppendArrayHeader(nil, 1)
+	invalidField = msgp.AppendArrayHeader(invalidField, 1)
+	invalidField = msgp.AppendMapHeader(invalidField, 1)
+	invalidField = msgp.AppendString(invalidField, "trace_id")
+	invalidField = msgp.AppendString(invalidField, "1")
+
+	tests := []struct {
+		name   string
+		decode func([]byte) error
+		body   []byte
+		// wantErr is empty for the errors of the MessagePack library.
+		wantErr string
+	}{
+		{
+			name:    "v0.4 not an array",
+			decode:  func(b []byte) error { _, err := decodeMsgpackTraces(b); return err },
+			body:    msgp.AppendString(nil, "traces"),
+			wantErr: "",
+		},
+		{
+			name:    "v0.4 invalid field type",
+			decode:  func(b []byte) error { _, err := decodeMsgpackTraces(b); return err },
+			body:    invalidField,
+			wantErr: `failed to decode span field "trace_id": expected an integer, got string`,
+		},
+		{
+			name:    "v0.4 array header larger than the body",
+			decode:  func(b []byte) error { _, err := decodeMsgpackTraces(b); return err },
+			body:    []byte{0xdd, 0x7f, 0xff, 0xff, 0xff},
+			wantErr: "2147483647 elements announced with only 0 bytes left",
+		},
+		{
+			name:    "v0.5 dictionary header larger than the body",
+			decode:  func(b []byte) error { _, err := decodeMsgpackV05Traces(b); return err },
+			body:    []byte{0x92, 0xdd, 0x7f, 0xff, 0xff, 0xff, 0x90},
+			wantErr: "2147483647 elements announced with only 1 bytes left",
+		},
+		{
+			name:    "v0.5 not a pair",
+			decode:  func(b []byte) error { _, err := decodeMsgpackV05Traces(b); return err },
+			body:    msgp.AppendArrayHeader(nil, 3),
+			wantErr: "payload is not an array of 2 elements",
+		},
+		{
+			name:    "v0.5 invalid span",
+			decode:  func(b []byte) error { _, err := decodeMsgpackV05Traces(b); return err },
+			body:    invalidSpan,
+			wantErr: errInvalidSpan.Error(),
+		},
+		{
+			name:    "v0.5 string index out of the dictionary",
+			decode:  func(b []byte) error { _, err := decodeMsgpackV05Traces(b); return err },
+			body:    invalidDictRef,
+			wantErr: "string index 3 out of the dictionary of 0 strings",
+		},
+		{
+			name:    "v0.7 not a map",
+			decode:  func(b []byte) error { _, err := decodeMsgpackV07Payload(b); return err },
+			body:    msgp.AppendArrayHeader(nil, 0),
+			wantErr: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.decode(tt.body)
+			if tt.wantErr == "" {
+				assert.Error(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
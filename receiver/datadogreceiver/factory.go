@@ -0,0 +1,109 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+)
+
+const (
+	// Value of "type" key in configuration.
+	typeStr = "datadog"
+
+	// Default endpoints to bind to, the ports of the Datadog agent.
+	defaultEndpoint          = "localhost:8126"
+	defaultDogStatsDEndpoint = "localhost:8125"
+
+	defaultAggregationInterval = 60 * time.Second
+
+	// The default body size limit of trace intake requests, that of the Datadog agent.
+	defaultMaxRequestBodySize = 50 * 1024 * 1024
+)
+
+// defaultTimerHistogramMapping observes the timings, histograms and distributions as gauges.
+var defaultTimerHistogramMapping = []protocol.TimerHistogramMapping{
+	{StatsdType: "timer", ObserverType: "gauge"},
+	{StatsdType: "histogram", ObserverType: "gauge"},
+	{StatsdType: "distribution", ObserverType: "gauge"},
+}
+
+// NewFactory creates a factory for the Datadog receiver.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithTraces(createTracesReceiver),
+		receiverhelper.WithMetrics(createMetricsReceiver))
+}
+
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: defaultEndpoint,
+		},
+		MaxRequestBodySize: defaultMaxRequestBodySize,
+		DogStatsD: DogStatsDConfig{
+			Endpoint:            defaultDogStatsDEndpoint,
+			AggregationInterval: defaultAggregationInterval,
+		},
+	}
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Traces,
+) (component.TracesReceiver, error) {
+	return newTracesReceiver(cfg.(*Config), params.Logger, nextConsumer)
+}
+
+// createMetricsReceiver creates a statsd receiver parsing the DogStatsD extensions of the protocol.
+func createMetricsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	rCfg := cfg.(*Config)
+	// The default mapping is applied here rather than in the default config, where the
+	// configured mappings would be merged into it.
+	mapping := rCfg.DogStatsD.TimerHistogramMapping
+	if len(mapping) == 0 {
+		mapping = defaultTimerHistogramMapping
+	}
+	return statsdreceiver.New(params.Logger, statsdreceiver.Config{
+		ReceiverSettings: rCfg.ReceiverSettings,
+		NetAddr: confignet.NetAddr{
+			Endpoint:  rCfg.DogStatsD.Endpoint,
+			Transport: "udp",
+		},
+		AggregationInterval:   rCfg.DogStatsD.AggregationInterval,
+		EnableMetricType:      rCfg.DogStatsD.EnableMetricType,
+		TimerHistogramMapping: mapping,
+	}, nextConsumer)
+}
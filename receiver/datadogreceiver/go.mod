@@ -0,0 +1,15 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/receiver/datadogreceiver
+
+go 1.16
+
+require (
+	github.com/mattn/go-colorable v0.1.7 // indirect
+	github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.7.0
+	github.com/tinylib/msgp v1.1.5
+	go.opentelemetry.io/collector v0.31.1-0.20210810171211-8038673eba9e
+	go.opentelemetry.io/collector/model v0.31.1-0.20210810171211-8038673eba9e
+	go.uber.org/zap v1.19.0
+)
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver => ../statsdreceiver
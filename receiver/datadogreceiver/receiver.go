@@ -0,0 +1,205 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogreceiver
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+	"go.uber.org/zap"
+)
+
+const (
+	headerContentType   = "Content-Type"
+	headerLang          = "Datadog-Meta-Lang"
+	headerLangVersion   = "Datadog-Meta-Lang-Version"
+	headerTracerVersion = "Datadog-Meta-Tracer-Version"
+	headerContainerID   = "Datadog-Container-Id"
+
+	contentTypeJSON = "application/json"
+
+	formatJSON    = "json"
+	formatMsgpack = "msgpack"
+
+	// rateByServiceResponse is the response of the v0.4 and later APIs, the tracers reading the
+	// sampling rates to apply from it. No rates are set, so the tracers keep their defaults.
+	rateByServiceResponse = `{"rate_by_service":{}}`
+)
+
+// headerResourceAttributes maps the headers set by the Datadog tracers to resource attributes.
+var headerResourceAttributes = map[string]string{
+	headerLang:          conventions.AttributeTelemetrySDKLanguage,
+	headerLangVersion:   attributeProcessRuntimeVersion,
+	headerTracerVersion: conventions.AttributeTelemetrySDKVersion,
+	headerContainerID:   conventions.AttributeContainerID,
+}
+
+// datadogReceiver implements the trace intake of the Datadog agent, converting the received
+// spans into traces.
+type datadogReceiver struct {
+	logger       *zap.Logger
+	config       *Config
+	server       *http.Server
+	nextConsumer consumer.Traces
+	obsrecv      *obsreport.Receiver
+}
+
+var _ component.TracesReceiver = (*datadogReceiver)(nil)
+
+// newTracesReceiver creates a Datadog receiver passing the received traces on to nextConsumer.
+func newTracesReceiver(config *Config, logger *zap.Logger, nextConsumer consumer.Traces) (component.TracesReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
+	return &datadogReceiver{
+		logger:       logger,
+		config:       config,
+		nextConsumer: nextConsumer,
+		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: config.ID(), Transport: "http"}),
+	}, nil
+}
+
+// Start starts the HTTP server serving the trace intake.
+func (ddr *datadogReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := ddr.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", ddr.config.Endpoint, err)
+	}
+
+	mux := http.NewServeMux()
+	for _, version := range []string{"v0.3", "v0.4", "v0.5", "v0.7"} {
+		mux.HandleFunc("/"+version+"/traces", ddr.tracesHandler(version))
+	}
+	ddr.server = ddr.config.HTTPServerSettings.ToServer(mux)
+	go func() {
+		if errHTTP := ddr.server.Serve(ln); errHTTP != http.ErrServerClosed {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (ddr *datadogReceiver) Shutdown(context.Context) error {
+	if ddr.server == nil {
+		return nil
+	}
+	return ddr.server.Close()
+}
+
+// tracesHandler returns the handler of the traces endpoint of the given API version.
+func (ddr *datadogReceiver) tracesHandler(version string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut && r.Method != http.MethodPost {
+			http.Error(w, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		ctx := ddr.obsrecv.StartTracesOp(r.Context())
+		r.Body = http.MaxBytesReader(w, r.Body, ddr.config.MaxRequestBodySize)
+		format, payload, err := decodeTracesRequest(version, r)
+		if err != nil {
+			ddr.obsrecv.EndTracesOp(ctx, format, 0, err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		td := toTraces(payload)
+		if spanCount := td.SpanCount(); spanCount > 0 {
+			err = ddr.nextConsumer.ConsumeTraces(ctx, td)
+			ddr.obsrecv.EndTracesOp(ctx, format, spanCount, err)
+			if err != nil {
+				ddr.logger.Debug("Unable to consume the received traces", zap.Error(err))
+				http.Error(w, err.Error(), consumeStatusCode(err))
+				return
+			}
+		} else {
+			ddr.obsrecv.EndTracesOp(ctx, format, 0, nil)
+		}
+
+		if version == "v0.3" {
+			_, err = w.Write([]byte("OK"))
+		} else {
+			w.Header().Set(headerContentType, contentTypeJSON)
+			_, err = w.Write([]byte(rateByServiceResponse))
+		}
+		if err != nil {
+			ddr.logger.Debug("Unable to write the response", zap.Error(err))
+		}
+	}
+}
+
+// decodeTracesRequest decodes the body of a request to the traces endpoint of the given API
+// version. The v0.3 and v0.4 APIs accept JSON and MessagePack bodies, the later ones only
+// MessagePack.
+func decodeTracesRequest(version string, r *http.Request) (string, *tracePayload, error) {
+	format := formatMsgpack
+	if version == "v0.3" || version == "v0.4" {
+		if mediaType, _, err := mime.ParseMediaType(r.Header.Get(headerContentType)); err == nil && mediaType == contentTypeJSON {
+			format = formatJSON
+		}
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return format, nil, err
+	}
+
+	payload := &tracePayload{}
+	switch {
+	case version == "v0.7":
+		payload, err = decodeMsgpackV07Payload(body)
+	case version == "v0.5":
+		payload.traces, err = decodeMsgpackV05Traces(body)
+	case format == formatJSON:
+		payload.traces, err = decodeJSONTraces(body)
+	default:
+		payload.traces, err = decodeMsgpackTraces(body)
+	}
+	if err != nil {
+		return format, nil, fmt.Errorf("failed to decode the %s traces: %w", version, err)
+	}
+
+	// The metadata of v0.7 payloads takes precedence over the headers.
+	if payload.resource == nil {
+		payload.resource = map[string]string{}
+	}
+	for header, attr := range headerResourceAttributes {
+		if _, ok := payload.resource[attr]; !ok {
+			if value := r.Header.Get(header); value != "" {
+				payload.resource[attr] = value
+			}
+		}
+	}
+	return format, payload, nil
+}
+
+// consumeStatusCode is the status code of the response to a request whose traces failed to be
+// consumed with err. The tracers retry the requests failing with a server error.
+func consumeStatusCode(err error) int {
+	if consumererror.IsPermanent(err) {
+		return http.StatusBadRequest
+	}
+	return http.StatusServiceUnavailable
+}
@@ -0,0 +1,293 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogreceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/testutil"
+	"go.uber.org/zap"
+)
+
+const contentTypeMsgpack = "application/msgpack"
+
+func newTestReceiver(t *testing.T, nextConsumer *consumertest.TracesSink) *datadogReceiver {
+	r, err := newTracesReceiver(createDefaultConfig().(*Config), zap.NewNop(), nextConsumer)
+	require.NoError(t, err)
+	return r.(*datadogReceiver)
+}
+
+func newTracesRequest(version string, body []byte, contentType string) *http.Request {
+	req := httptest.NewRequest(http.MethodPut, "/"+version+"/traces", bytes.NewReader(body))
+	req.Header.Set(headerContentType, contentType)
+	req.Header.Set(headerLang, "go")
+	req.Header.Set(headerTracerVersion, "1.32.0")
+	return req
+}
+
+func serve(ddr *datadogReceiver, version string, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	ddr.tracesHandler(version)(w, req)
+	return w
+}
+
+// assertTestTraces checks the traces converted from testSpans.
+func assertTestTraces(t *testing.T, td pdata.Traces, resource map[string]string) {
+	require.Equal(t, 2, td.ResourceSpans().Len())
+	traceID := pdata.NewTraceID([16]byte{8: 0x80, 15: 5})
+
+	web := td.ResourceSpans().At(0)
+	assert.Equal(t, withService(resource, "web"), attributesAsMap(web.Resource().Attributes()))
+	require.Equal(t, 1, web.InstrumentationLibrarySpans().At(0).Spans().Len())
+	span := web.InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, "GET /users", span.Name())
+	assert.Equal(t, pdata.SpanKindServer, span.Kind())
+	assert.Equal(t, traceID, span.TraceID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{7: 1}), span.SpanID())
+	assert.True(t, span.ParentSpanID().IsEmpty())
+	assert.Equal(t, pdata.Timestamp(1627776000000000000), span.StartTimestamp())
+	assert.Equal(t, pdata.Timestamp(1627776000001000000), span.EndTimestamp())
+	assert.Equal(t, pdata.StatusCodeUnset, span.Status().Code())
+	assert.Equal(t, map[string]string{
+		"operation.name": "http.request",
+		"resource.name":  "GET /users",
+		"span.type":      "web",
+		"http.method":    "GET",
+	}, attributesAsMap(span.Attributes()))
+	priority, ok := span.Attributes().Get("_sampling_priority_v1")
+	require.True(t, ok)
+	assert.Equal(t, 1.0, priority.DoubleVal())
+
+	db := td.ResourceSpans().At(1)
+	assert.Equal(t, withService(resource, "db"), attributesAsMap(db.Resource().Attributes()))
+	span = db.InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, "SELECT 1", span.Name())
+	assert.Equal(t, pdata.SpanKindClient, span.Kind())
+	assert.Equal(t, traceID, span.TraceID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{7: 1}), span.ParentSpanID())
+	assert.Equal(t, pdata.StatusCodeError, span.Status().Code())
+	assert.Equal(t, "timeout", span.Status().Message())
+}
+
+func withService(resource map[string]string, service string) map[string]string {
+	attrs := map[string]string{"service.name": service}
+	for k, v := range resource {
+		attrs[k] = v
+	}
+	return attrs
+}
+
+// attributesAsMap returns the string attributes.
+func attributesAsMap(attrs pdata.AttributeMap) map[string]string {
+	m := make(map[string]string, attrs.Len())
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		if v.Type() == pdata.AttributeValueTypeString {
+			m[k] = v.StringVal()
+		}
+		return true
+	})
+	return m
+}
+
+var testHeaderResource = map[string]string{
+	"telemetry.sdk.language": "go",
+	"telemetry.sdk.version":  "1.32.0",
+}
+
+func TestTraces(t *testing.T) {
+	jsonBody, err := json.Marshal([][]*ddSpan{testSpans()})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		version      string
+		contentType  string
+		body         []byte
+		wantResource map[string]string
+		wantResponse string
+	}{
+		{
+			name:         "v0.3 json",
+			version:      "v0.3",
+			contentType:  contentTypeJSON,
+			body:         jsonBody,
+			wantResource: testHeaderResource,
+			wantResponse: "OK",
+		},
+		{
+			name:         "v0.3 msgpack",
+			version:      "v0.3",
+			contentType:  contentTypeMsgpack,
+			body:         msgpackTraces(testSpans()),
+			wantResource: testHeaderResource,
+			wantResponse: "OK",
+		},
+		{
+			name:         "v0.4 json",
+			version:      "v0.4",
+			contentType:  "application/json; charset=utf-8",
+			body:         jsonBody,
+			wantResource: testHeaderResource,
+			wantResponse: rateByServiceResponse,
+		},
+		{
+			name:         "v0.4 msgpack",
+			version:      "v0.4",
+			contentType:  contentTypeMsgpack,
+			body:         msgpackTraces(testSpans()),
+			wantResource: testHeaderResource,
+			wantResponse: rateByServiceResponse,
+		},
+		{
+			name:         "v0.5",
+			version:      "v0.5",
+			contentType:  contentTypeMsgpack,
+			body:         msgpackV05Traces(testSpans()),
+			wantResource: testHeaderResource,
+			wantResponse: rateByServiceResponse,
+		},
+		{
+			name:        "v0.7",
+			version:     "v0.7",
+			contentType: contentTypeMsgpack,
+			body:        msgpackV07Payload(testSpans()),
+			wantResource: map[string]string{
+				"telemetry.sdk.language": "go",
+				"telemetry.sdk.version":  "1.32.0",
+				"deployment.environment": "prod",
+				"host.name":              "host-1",
+			},
+			wantResponse: rateByServiceResponse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.TracesSink)
+			ddr := newTestReceiver(t, sink)
+
+			w := serve(ddr, tt.version, newTracesRequest(tt.version, tt.body, tt.contentType))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.wantResponse, w.Body.String())
+
+			require.Len(t, sink.AllTraces(), 1)
+			assertTestTraces(t, sink.AllTraces()[0], tt.wantResource)
+		})
+	}
+}
+
+func TestEmptyTraces(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	ddr := newTestReceiver(t, sink)
+
+	w := serve(ddr, "v0.4", newTracesRequest("v0.4", msgpackTraces(), contentTypeMsgpack))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, sink.AllTraces())
+}
+
+func TestInvalidRequests(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	ddr := newTestReceiver(t, sink)
+
+	req := newTracesRequest("v0.4", msgpackTraces(testSpans()), contentTypeMsgpack)
+	req.Method = http.MethodGet
+	w := serve(ddr, "v0.4", req)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	w = serve(ddr, "v0.4", newTracesRequest("v0.4", []byte("[[{"), contentTypeJSON))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// The v0.5 API only accepts MessagePack.
+	w = serve(ddr, "v0.5", newTracesRequest("v0.5", []byte("[[],[]]"), contentTypeJSON))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	body := msgpackTraces(testSpans())
+	ddr.config.MaxRequestBodySize = int64(len(body) - 1)
+	w = serve(ddr, "v0.4", newTracesRequest("v0.4", body, contentTypeMsgpack))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	assert.Empty(t, sink.AllTraces())
+}
+
+func TestConsumerErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{
+			name:     "permanent",
+			err:      consumererror.Permanent(errors.New("invalid traces")),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "retryable",
+			err:      errors.New("queue is full"),
+			wantCode: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := newTracesReceiver(createDefaultConfig().(*Config), zap.NewNop(), consumertest.NewErr(tt.err))
+			require.NoError(t, err)
+
+			w := serve(r.(*datadogReceiver), "v0.4", newTracesRequest("v0.4", msgpackTraces(testSpans()), contentTypeMsgpack))
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
+
+func TestStartShutdown(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	r, err := newTracesReceiver(cfg, zap.NewNop(), sink)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, r.Shutdown(ctx))
+	}()
+
+	req, err := http.NewRequest(http.MethodPut, "http://"+cfg.Endpoint+"/v0.4/traces", bytes.NewReader(msgpackTraces(testSpans())))
+	require.NoError(t, err)
+	req.Header.Set(headerContentType, contentTypeMsgpack)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, rateByServiceResponse, string(body))
+	assert.Equal(t, 2, sink.SpanCount())
+
+	resp, err = http.Post("http://"+cfg.Endpoint+"/v0.6/traces", contentTypeMsgpack, bytes.NewReader(msgpackTraces()))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,134 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogreceiver
+
+import (
+	"encoding/binary"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+)
+
+// Attributes keeping the Datadog span fields that have no OpenTelemetry equivalent. The Datadog
+// exporter and agent read them back when exporting the spans to Datadog.
+const (
+	attributeOperationName = "operation.name"
+	attributeResourceName  = "resource.name"
+	attributeSpanType      = "span.type"
+
+	attributeProcessRuntimeVersion = "process.runtime.version"
+
+	tagSpanKind = "span.kind"
+	tagErrorMsg = "error.msg"
+)
+
+// spanKinds maps the span.kind tag set by the Datadog tracers to span kinds.
+var spanKinds = map[string]pdata.SpanKind{
+	"server":   pdata.SpanKindServer,
+	"client":   pdata.SpanKindClient,
+	"producer": pdata.SpanKindProducer,
+	"consumer": pdata.SpanKindConsumer,
+	"internal": pdata.SpanKindInternal,
+}
+
+// spanTypeKinds maps the Datadog span types to the span kinds they imply, for the spans without
+// a span.kind tag.
+var spanTypeKinds = map[string]pdata.SpanKind{
+	"web":   pdata.SpanKindServer,
+	"http":  pdata.SpanKindClient,
+	"db":    pdata.SpanKindClient,
+	"sql":   pdata.SpanKindClient,
+	"cache": pdata.SpanKindClient,
+}
+
+// toTraces converts the spans of a payload into traces, with a resource per service.
+func toTraces(payload *tracePayload) pdata.Traces {
+	td := pdata.NewTraces()
+	services := map[string]pdata.SpanSlice{}
+	for _, trace := range payload.traces {
+		for _, span := range trace {
+			spans, ok := services[span.Service]
+			if !ok {
+				rs := td.ResourceSpans().AppendEmpty()
+				attrs := rs.Resource().Attributes()
+				for k, v := range payload.resource {
+					attrs.InsertString(k, v)
+				}
+				if span.Service != "" {
+					attrs.UpsertString(conventions.AttributeServiceName, span.Service)
+				}
+				spans = rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+				services[span.Service] = spans
+			}
+			toSpan(span, spans.AppendEmpty())
+		}
+	}
+	return td
+}
+
+// toSpan converts a Datadog span. The span is named after its resource, like the Datadog UI
+// does, the operation name being kept as an attribute.
+func toSpan(in *ddSpan, out pdata.Span) {
+	var traceID [16]byte
+	binary.BigEndian.PutUint64(traceID[8:], in.TraceID)
+	out.SetTraceID(pdata.NewTraceID(traceID))
+	out.SetSpanID(toSpanID(in.SpanID))
+	if in.ParentID != 0 {
+		out.SetParentSpanID(toSpanID(in.ParentID))
+	}
+
+	name := in.Resource
+	if name == "" {
+		name = in.Name
+	}
+	out.SetName(name)
+	out.SetKind(toSpanKind(in))
+	out.SetStartTimestamp(pdata.Timestamp(in.Start))
+	out.SetEndTimestamp(pdata.Timestamp(in.Start + in.Duration))
+
+	attrs := out.Attributes()
+	attrs.InsertString(attributeOperationName, in.Name)
+	attrs.InsertString(attributeResourceName, in.Resource)
+	if in.Type != "" {
+		attrs.InsertString(attributeSpanType, in.Type)
+	}
+	for k, v := range in.Meta {
+		attrs.UpsertString(k, v)
+	}
+	for k, v := range in.Metrics {
+		attrs.UpsertDouble(k, v)
+	}
+
+	if in.Error != 0 {
+		out.Status().SetCode(pdata.StatusCodeError)
+		out.Status().SetMessage(in.Meta[tagErrorMsg])
+	}
+}
+
+func toSpanID(id uint64) pdata.SpanID {
+	var spanID [8]byte
+	binary.BigEndian.PutUint64(spanID[:], id)
+	return pdata.NewSpanID(spanID)
+}
+
+func toSpanKind(span *ddSpan) pdata.SpanKind {
+	if kind, ok := spanKinds[span.Meta[tagSpanKind]]; ok {
+		return kind
+	}
+	if kind, ok := spanTypeKinds[span.Type]; ok {
+		return kind
+	}
+	return pdata.SpanKindInternal
+}
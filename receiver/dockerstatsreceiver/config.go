@@ -51,6 +51,19 @@ type Config struct {
 	// A list of filters whose matching images are to be excluded.  Supports literals, globs, and regex.
 	ExcludedImages []string `mapstructure:"excluded_images"`
 
+	// A list of filters whose matching images are the only ones to be monitored, unless also
+	// matched by ExcludedImages.  Supports literals, globs, and regex.  All images are monitored when empty.
+	IncludedImages []string `mapstructure:"included_images"`
+
+	// A mapping of container label names to filters on their values.  Only containers with every
+	// listed label set to a matching value are monitored.  Supports literals, globs, and regex.
+	// E.g. `com.docker.compose.project: [myproject]` would only monitor the `myproject` compose containers.
+	IncludedContainerLabels map[string][]string `mapstructure:"included_container_labels"`
+
+	// A mapping of container label names to filters on their values.  Containers with any listed
+	// label set to a matching value are excluded.  Supports literals, globs, and regex.
+	ExcludedContainerLabels map[string][]string `mapstructure:"excluded_container_labels"`
+
 	// Whether to report all CPU metrics.  Default is false
 	ProvidePerCoreCPUMetrics bool `mapstructure:"provide_per_core_cpu_metrics"`
 }
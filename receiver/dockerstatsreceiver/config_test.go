@@ -48,6 +48,9 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, 5*time.Second, dcfg.Timeout)
 
 	assert.Nil(t, dcfg.ExcludedImages)
+	assert.Nil(t, dcfg.IncludedImages)
+	assert.Nil(t, dcfg.IncludedContainerLabels)
+	assert.Nil(t, dcfg.ExcludedContainerLabels)
 	assert.Nil(t, dcfg.ContainerLabelsToMetricLabels)
 	assert.Nil(t, dcfg.EnvVarsToMetricLabels)
 
@@ -64,6 +67,16 @@ func TestLoadConfig(t *testing.T) {
 		"another-*-container",
 	}, ascfg.ExcludedImages)
 
+	assert.Equal(t, []string{"myregistry/*"}, ascfg.IncludedImages)
+
+	assert.Equal(t, map[string][]string{
+		"com.docker.compose.project": {"myproject"},
+	}, ascfg.IncludedContainerLabels)
+
+	assert.Equal(t, map[string][]string{
+		"monitoring": {"disabled", "off"},
+	}, ascfg.ExcludedContainerLabels)
+
 	assert.Equal(t, map[string]string{
 		"my.container.label":       "my-metric-label",
 		"my.other.container.label": "my-other-metric-label",
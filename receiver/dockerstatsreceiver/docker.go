@@ -19,6 +19,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -40,17 +42,20 @@ const (
 // from client.ContainerInspect() for container information (id, name, hostname, labels, and env)
 // and dtypes.StatsJSON from client.ContainerStats() for metric values.
 type dockerClient struct {
-	client               *docker.Client
-	config               *Config
-	containers           map[string]DockerContainer
-	containersLock       sync.Mutex
-	excludedImageMatcher *StringMatcher
-	logger               *zap.Logger
+	client                *docker.Client
+	config                *Config
+	containers            map[string]DockerContainer
+	containersLock        sync.Mutex
+	excludedImageMatcher  *StringMatcher
+	includedImageMatcher  *StringMatcher
+	includedLabelMatchers map[string]*StringMatcher
+	excludedLabelMatchers map[string]*StringMatcher
+	logger                *zap.Logger
 }
 
 func newDockerClient(config *Config, logger *zap.Logger) (*dockerClient, error) {
 	client, err := docker.NewClientWithOpts(
-		docker.WithHost(config.Endpoint),
+		docker.WithHost(resolveEndpoint(config.Endpoint)),
 		docker.WithVersion(dockerAPIVersion),
 		docker.WithHTTPHeaders(map[string]string{"User-Agent": userAgent}),
 	)
@@ -63,18 +68,61 @@ func newDockerClient(config *Config, logger *zap.Logger) (*dockerClient, error)
 		return nil, fmt.Errorf("could not determine docker client excluded images: %w", err)
 	}
 
+	var includedImageMatcher *StringMatcher
+	if len(config.IncludedImages) > 0 {
+		includedImageMatcher, err = NewStringMatcher(config.IncludedImages)
+		if err != nil {
+			return nil, fmt.Errorf("could not determine docker client included images: %w", err)
+		}
+	}
+
+	includedLabelMatchers, err := newLabelMatchers(config.IncludedContainerLabels)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine docker client included container labels: %w", err)
+	}
+
+	excludedLabelMatchers, err := newLabelMatchers(config.ExcludedContainerLabels)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine docker client excluded container labels: %w", err)
+	}
+
 	dc := &dockerClient{
-		client:               client,
-		config:               config,
-		logger:               logger,
-		containers:           make(map[string]DockerContainer),
-		containersLock:       sync.Mutex{},
-		excludedImageMatcher: excludedImageMatcher,
+		client:                client,
+		config:                config,
+		logger:                logger,
+		containers:            make(map[string]DockerContainer),
+		containersLock:        sync.Mutex{},
+		excludedImageMatcher:  excludedImageMatcher,
+		includedImageMatcher:  includedImageMatcher,
+		includedLabelMatchers: includedLabelMatchers,
+		excludedLabelMatchers: excludedLabelMatchers,
 	}
 
 	return dc, nil
 }
 
+// resolveEndpoint returns the address of the docker daemon to connect to.  When the
+// default endpoint is configured, DOCKER_HOST is honored and the rootless daemon socket
+// under XDG_RUNTIME_DIR is used if the system wide socket doesn't exist.
+func resolveEndpoint(endpoint string) string {
+	if endpoint != defaultEndpoint {
+		return endpoint
+	}
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		return host
+	}
+	if _, err := os.Stat(strings.TrimPrefix(defaultEndpoint, "unix://")); err == nil {
+		return endpoint
+	}
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		socket := filepath.Join(runtimeDir, "docker.sock")
+		if _, err := os.Stat(socket); err == nil {
+			return "unix://" + socket
+		}
+	}
+	return endpoint
+}
+
 // Provides a slice of DockerContainers to use for individual FetchContainerStats calls.
 func (dc *dockerClient) Containers() []DockerContainer {
 	dc.containersLock.Lock()
@@ -108,13 +156,13 @@ func (dc *dockerClient) LoadContainerList(ctx context.Context) error {
 	for _, c := range containerList {
 		wg.Add(1)
 		go func(container dtypes.Container) {
-			if !dc.shouldBeExcluded(container.Image) {
+			if !dc.shouldBeExcluded(container.Image, container.Labels) {
 				if cnt, ok := dc.inspectedContainerIsOfInterest(ctx, container.ID); ok {
 					dc.persistContainer(cnt)
 				}
 			} else {
 				dc.logger.Debug(
-					"Not monitoring container per ExcludedImages",
+					"Not monitoring container per image and container label filters",
 					zap.String("image", container.Image),
 					zap.String("id", container.ID),
 				)
@@ -272,7 +320,7 @@ func (dc *dockerClient) inspectedContainerIsOfInterest(ctx context.Context, cid
 			zap.String("id", cid),
 			zap.Error(err),
 		)
-	} else if !dc.shouldBeExcluded(container.Config.Image) {
+	} else if !dc.shouldBeExcluded(container.Config.Image, container.Config.Labels) {
 		return &container, true
 	}
 	return nil, false
@@ -306,8 +354,36 @@ func (dc *dockerClient) removeContainer(cid string) {
 	dc.logger.Debug("Removed container from stores.", zap.String("id", cid))
 }
 
-func (dc *dockerClient) shouldBeExcluded(image string) bool {
-	return dc.excludedImageMatcher != nil && dc.excludedImageMatcher.Matches(image)
+func (dc *dockerClient) shouldBeExcluded(image string, labels map[string]string) bool {
+	if dc.excludedImageMatcher != nil && dc.excludedImageMatcher.Matches(image) {
+		return true
+	}
+	if dc.includedImageMatcher != nil && !dc.includedImageMatcher.Matches(image) {
+		return true
+	}
+	for label, matcher := range dc.excludedLabelMatchers {
+		if v, ok := labels[label]; ok && matcher.Matches(v) {
+			return true
+		}
+	}
+	for label, matcher := range dc.includedLabelMatchers {
+		if v, ok := labels[label]; !ok || !matcher.Matches(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func newLabelMatchers(labelFilters map[string][]string) (map[string]*StringMatcher, error) {
+	matchers := make(map[string]*StringMatcher, len(labelFilters))
+	for label, filters := range labelFilters {
+		matcher, err := NewStringMatcher(filters)
+		if err != nil {
+			return nil, fmt.Errorf("label %q: %w", label, err)
+		}
+		matchers[label] = matcher
+	}
+	return matchers, nil
 }
 
 func containerEnvToMap(env []string) map[string]string {
@@ -49,6 +49,15 @@ func TestInvalidEndpoint(t *testing.T) {
 	assert.Equal(t, "could not create docker client: unable to parse docker host `$notavalidendpoint*`", err.Error())
 }
 
+func TestResolveEndpoint(t *testing.T) {
+	assert.Equal(t, "tcp://localhost:2375", resolveEndpoint("tcp://localhost:2375"))
+
+	os.Setenv("DOCKER_HOST", "unix:///run/user/1000/docker.sock")
+	defer os.Unsetenv("DOCKER_HOST")
+	assert.Equal(t, "unix:///run/user/1000/docker.sock", resolveEndpoint(defaultEndpoint))
+	assert.Equal(t, "tcp://localhost:2375", resolveEndpoint("tcp://localhost:2375"))
+}
+
 func TestInvalidExclude(t *testing.T) {
 	config := NewFactory().CreateDefaultConfig().(*Config)
 	config.ExcludedImages = []string{"["}
@@ -58,6 +67,58 @@ func TestInvalidExclude(t *testing.T) {
 	assert.Equal(t, "could not determine docker client excluded images: invalid glob item: unexpected end of input", err.Error())
 }
 
+func TestInvalidIncludeAndLabelFilters(t *testing.T) {
+	config := NewFactory().CreateDefaultConfig().(*Config)
+	config.IncludedImages = []string{"["}
+	cli, err := newDockerClient(config, zap.NewNop())
+	assert.Nil(t, cli)
+	require.Error(t, err)
+	assert.Equal(t, "could not determine docker client included images: invalid glob item: unexpected end of input", err.Error())
+
+	config = NewFactory().CreateDefaultConfig().(*Config)
+	config.IncludedContainerLabels = map[string][]string{"my.label": {"/(/"}}
+	cli, err = newDockerClient(config, zap.NewNop())
+	assert.Nil(t, cli)
+	require.Error(t, err)
+	assert.Equal(t, "could not determine docker client included container labels: label \"my.label\": invalid regex item: error parsing regexp: missing closing ): `(`", err.Error())
+
+	config = NewFactory().CreateDefaultConfig().(*Config)
+	config.ExcludedContainerLabels = map[string][]string{"my.label": {"["}}
+	cli, err = newDockerClient(config, zap.NewNop())
+	assert.Nil(t, cli)
+	require.Error(t, err)
+	assert.Equal(t, "could not determine docker client excluded container labels: label \"my.label\": invalid glob item: unexpected end of input", err.Error())
+}
+
+func TestShouldBeExcluded(t *testing.T) {
+	config := NewFactory().CreateDefaultConfig().(*Config)
+	config.ExcludedImages = []string{"*redis*"}
+	config.IncludedImages = []string{"myregistry/*"}
+	config.IncludedContainerLabels = map[string][]string{"com.docker.compose.project": {"myproject"}}
+	config.ExcludedContainerLabels = map[string][]string{"monitoring": {"disabled", "off"}}
+	cli, err := newDockerClient(config, zap.NewNop())
+	require.NoError(t, err)
+
+	projectLabels := map[string]string{"com.docker.compose.project": "myproject"}
+	assert.False(t, cli.shouldBeExcluded("myregistry/app", projectLabels))
+	assert.True(t, cli.shouldBeExcluded("myregistry/redis", projectLabels))
+	assert.True(t, cli.shouldBeExcluded("otherregistry/app", projectLabels))
+	assert.True(t, cli.shouldBeExcluded("myregistry/app", nil))
+	assert.True(t, cli.shouldBeExcluded("myregistry/app", map[string]string{"com.docker.compose.project": "otherproject"}))
+	assert.True(t, cli.shouldBeExcluded("myregistry/app", map[string]string{
+		"com.docker.compose.project": "myproject",
+		"monitoring":                 "off",
+	}))
+	assert.False(t, cli.shouldBeExcluded("myregistry/app", map[string]string{
+		"com.docker.compose.project": "myproject",
+		"monitoring":                 "enabled",
+	}))
+
+	cli, err = newDockerClient(NewFactory().CreateDefaultConfig().(*Config), zap.NewNop())
+	require.NoError(t, err)
+	assert.False(t, cli.shouldBeExcluded("anyimage", nil))
+}
+
 func tmpSock(t *testing.T) (net.Listener, string) {
 	f, err := ioutil.TempFile(os.TempDir(), "testsock")
 	if err != nil {
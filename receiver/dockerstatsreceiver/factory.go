@@ -25,7 +25,8 @@ import (
 )
 
 const (
-	typeStr = "docker_stats"
+	typeStr         = "docker_stats"
+	defaultEndpoint = "unix:///var/run/docker.sock"
 )
 
 func NewFactory() component.ReceiverFactory {
@@ -38,7 +39,7 @@ func NewFactory() component.ReceiverFactory {
 func createDefaultConfig() config.Receiver {
 	return &Config{
 		ReceiverSettings:   config.NewReceiverSettings(config.NewID(typeStr)),
-		Endpoint:           "unix:///var/run/docker.sock",
+		Endpoint:           defaultEndpoint,
 		CollectionInterval: 10 * time.Second,
 		Timeout:            5 * time.Second,
 	}
@@ -97,6 +97,7 @@ type blkioStat struct {
 }
 
 // metrics for https://www.kernel.org/doc/Documentation/cgroup-v1/blkio-controller.txt
+// Under cgroup v2 the daemon only reports io_service_bytes_recursive and io_serviced_recursive.
 func blockioMetrics(
 	blkioStats *dtypes.BlkioStats,
 	ts *timestamp.Timestamp,
@@ -155,7 +156,8 @@ func cpuMetrics(
 
 	metrics = append(metrics, GaugeF("cpu.percent", []float64{calculateCPUPercent(previousCPUStats, cpuStats)}, ts, "1", nil, nil))
 
-	if !providePerCoreMetrics {
+	// cgroup v2 does not report per core usage.
+	if !providePerCoreMetrics || len(cpuStats.CPUUsage.PercpuUsage) == 0 {
 		return metrics
 	}
 
@@ -211,13 +213,66 @@ var memoryStatsThatAreCumulative = map[string]bool{
 	"total_pgpgout":    true,
 }
 
+// cgroupV2MemoryStats maps the memory.stat keys reported under cgroup v2 to
+// their cgroup v1 equivalents, so both hierarchies produce the same metrics.
+// cgroup v2 stats are always hierarchical, so they also populate the
+// corresponding total_* metrics.
+var cgroupV2MemoryStats = map[string]string{
+	"active_anon":    "active_anon",
+	"active_file":    "active_file",
+	"anon":           "rss",
+	"anon_thp":       "rss_huge",
+	"file":           "cache",
+	"file_dirty":     "dirty",
+	"file_mapped":    "mapped_file",
+	"file_writeback": "writeback",
+	"inactive_anon":  "inactive_anon",
+	"inactive_file":  "inactive_file",
+	"pgfault":        "pgfault",
+	"pgmajfault":     "pgmajfault",
+	"unevictable":    "unevictable",
+}
+
+// isCgroupV2MemoryStats reports whether the stats were read from a cgroup v2
+// hierarchy, which reports "anon" where cgroup v1 reports "rss".
+func isCgroupV2MemoryStats(memoryStats *dtypes.MemoryStats) bool {
+	_, ok := memoryStats.Stats["anon"]
+	return ok
+}
+
+// cgroupV1MemoryStats translates cgroup v2 memory stats to their cgroup v1 names.
+// Stats without a cgroup v1 equivalent are kept under their original name.
+func cgroupV1MemoryStats(stats map[string]uint64) map[string]uint64 {
+	translated := make(map[string]uint64, 2*len(stats))
+	for statName, v := range stats {
+		v1Name, ok := cgroupV2MemoryStats[statName]
+		if !ok {
+			translated[statName] = v
+			continue
+		}
+		translated[v1Name] = v
+		translated["total_"+v1Name] = v
+	}
+	return translated
+}
+
 func memoryMetrics(
 	memoryStats *dtypes.MemoryStats,
 	ts *timestamp.Timestamp,
 ) []*metricspb.Metric {
 	var metrics []*metricspb.Metric
 
-	totalUsage := int64(memoryStats.Usage - memoryStats.Stats["total_cache"])
+	stats := memoryStats.Stats
+	// Page cache that is excluded from usage, following the docker CLI.
+	totalCache, cache := stats["total_cache"], stats["cache"]
+	cgroupV2 := isCgroupV2MemoryStats(memoryStats)
+	if cgroupV2 {
+		stats = cgroupV1MemoryStats(stats)
+		// cgroup v2 does not expose total_cache, the docker CLI uses inactive_file instead.
+		totalCache, cache = stats["inactive_file"], stats["inactive_file"]
+	}
+
+	totalUsage := int64(memoryStats.Usage - totalCache)
 	metrics = append(metrics, []*metricspb.Metric{
 		Gauge("memory.usage.limit", []int64{int64(memoryStats.Limit)}, ts, "By", nil, nil),
 		Gauge("memory.usage.total", []int64{totalUsage}, ts, "By", nil, nil),
@@ -227,23 +282,24 @@ func memoryMetrics(
 	if float64(memoryStats.Limit) == 0 {
 		pctUsed = 0
 	} else {
-		pctUsed = 100.0 * (float64(memoryStats.Usage) - float64(memoryStats.Stats["cache"])) / float64(memoryStats.Limit)
+		pctUsed = 100.0 * (float64(memoryStats.Usage) - float64(cache)) / float64(memoryStats.Limit)
 	}
 
-	metrics = append(metrics, []*metricspb.Metric{
-		GaugeF("memory.percent", []float64{pctUsed}, ts, "1", nil, nil),
-		Gauge("memory.usage.max", []int64{int64(memoryStats.MaxUsage)}, ts, "By", nil, nil),
-	}...)
+	metrics = append(metrics, GaugeF("memory.percent", []float64{pctUsed}, ts, "1", nil, nil))
+	// cgroup v2 does not track the maximum memory usage.
+	if !cgroupV2 {
+		metrics = append(metrics, Gauge("memory.usage.max", []int64{int64(memoryStats.MaxUsage)}, ts, "By", nil, nil))
+	}
 
 	// Sorted iteration for reproducibility, largely for testing
-	sortedNames := make([]string, 0, len(memoryStats.Stats))
-	for statName := range memoryStats.Stats {
+	sortedNames := make([]string, 0, len(stats))
+	for statName := range stats {
 		sortedNames = append(sortedNames, statName)
 	}
 	sort.Strings(sortedNames)
 
 	for _, statName := range sortedNames {
-		v := memoryStats.Stats[statName]
+		v := stats[statName]
 		metricName := fmt.Sprintf("memory.%s", statName)
 		if _, exists := memoryStatsThatAreCumulative[statName]; exists {
 			metrics = append(metrics, Cumulative(metricName, []int64{int64(v)}, ts, "1", nil, nil))
@@ -423,3 +423,48 @@ func TestContainerLabelToMetricLabels(t *testing.T) {
 
 	assertMetricsDataEqual(t, defaultMetrics(), expectedLabels, md)
 }
+
+func TestCgroupV2MemoryStats(t *testing.T) {
+	memoryStats := &dtypes.MemoryStats{
+		Usage: 100,
+		Limit: 1000,
+		Stats: map[string]uint64{
+			"anon":          40,
+			"file":          50,
+			"inactive_file": 20,
+			"pgfault":       7,
+			"sock":          3,
+		},
+	}
+
+	metrics := memoryMetrics(memoryStats, &timestamp.Timestamp{})
+
+	values := map[string]*metricspb.Point{}
+	for _, m := range metrics {
+		values[m.MetricDescriptor.Name] = m.Timeseries[0].Points[0]
+	}
+	assert.NotContains(t, values, "container.memory.usage.max")
+	assert.Equal(t, int64(80), values["container.memory.usage.total"].GetInt64Value())
+	assert.Equal(t, 8.0, values["container.memory.percent"].GetDoubleValue())
+	assert.Equal(t, int64(40), values["container.memory.rss"].GetInt64Value())
+	assert.Equal(t, int64(40), values["container.memory.total_rss"].GetInt64Value())
+	assert.Equal(t, int64(50), values["container.memory.cache"].GetInt64Value())
+	assert.Equal(t, int64(50), values["container.memory.total_cache"].GetInt64Value())
+	assert.Equal(t, int64(20), values["container.memory.total_inactive_file"].GetInt64Value())
+	assert.Equal(t, int64(7), values["container.memory.total_pgfault"].GetInt64Value())
+	assert.Equal(t, int64(3), values["container.memory.sock"].GetInt64Value())
+}
+
+func TestCgroupV2NoPerCoreMetrics(t *testing.T) {
+	cpuStats := &dtypes.CPUStats{
+		CPUUsage:    dtypes.CPUUsage{TotalUsage: 100},
+		SystemUsage: 1000,
+		OnlineCPUs:  2,
+	}
+
+	metrics := cpuMetrics(cpuStats, &dtypes.CPUStats{}, &timestamp.Timestamp{}, true)
+
+	for _, m := range metrics {
+		assert.NotEqual(t, "container.cpu.usage.percpu", m.MetricDescriptor.Name)
+	}
+}
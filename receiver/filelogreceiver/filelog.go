@@ -15,6 +15,9 @@
 package filelogreceiver
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/open-telemetry/opentelemetry-log-collection/operator"
 	"github.com/open-telemetry/opentelemetry-log-collection/operator/builtin/input/file"
 	"go.opentelemetry.io/collector/component"
@@ -24,7 +27,14 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza"
 )
 
-const typeStr = "filelog"
+const (
+	typeStr = "filelog"
+
+	// headerOperatorID is the id of the file_header operator added for the header section.
+	// A "$" has to be escaped as "$$" in the collector configuration, so configured
+	// operators are unlikely to use the same id.
+	headerOperatorID = "$$filelog_header"
+)
 
 // NewFactory creates a factory for filelog receiver
 func NewFactory() component.ReceiverFactory {
@@ -58,17 +68,44 @@ func createDefaultConfig() *FileLogConfig {
 	}
 }
 
-// BaseConfig gets the base config from config, for now
+// BaseConfig gets the base config from config, for now. If a header is configured,
+// a file_header operator is added in front of the configured operators.
 func (f ReceiverType) BaseConfig(cfg config.Receiver) stanza.BaseConfig {
-	return cfg.(*FileLogConfig).BaseConfig
+	logConfig := cfg.(*FileLogConfig)
+	baseConfig := logConfig.BaseConfig
+	if logConfig.Header != nil {
+		headerOperator := map[string]interface{}{
+			"id":                 headerOperatorID,
+			"type":               headerOperatorType,
+			"header_attribute":   "",
+			"metadata_operators": logConfig.Header.MetadataOperators,
+		}
+		if logConfig.Header.LineCount != 0 {
+			headerOperator["line_count"] = logConfig.Header.LineCount
+		}
+		baseConfig.Operators = append(stanza.OperatorConfigs{headerOperator}, baseConfig.Operators...)
+	}
+	return baseConfig
 }
 
 // FileLogConfig defines configuration for the filelog receiver
 type FileLogConfig struct {
 	stanza.BaseConfig `mapstructure:",squash"`
+	Header            *FileHeaderConfig  `mapstructure:"header"`
 	Input             stanza.InputConfig `mapstructure:",remain"`
 }
 
+// FileHeaderConfig defines how the header of each file is turned into resource
+// attributes of the records of the file. It requires include_file_path to be enabled.
+type FileHeaderConfig struct {
+	// LineCount is the number of lines at the beginning of the file that make up the
+	// header. It defaults to 1.
+	LineCount int `mapstructure:"line_count"`
+	// MetadataOperators are the parser operators the header is run through. The fields
+	// they extract become resource attributes of every record of the file.
+	MetadataOperators stanza.OperatorConfigs `mapstructure:"metadata_operators"`
+}
+
 // DecodeInputConfig unmarshals the input operator
 func (f ReceiverType) DecodeInputConfig(cfg config.Receiver) (*operator.Config, error) {
 	logConfig := cfg.(*FileLogConfig)
@@ -77,5 +114,18 @@ func (f ReceiverType) DecodeInputConfig(cfg config.Receiver) (*operator.Config,
 	if err := yaml.Unmarshal(yamlBytes, &inputCfg); err != nil {
 		return nil, err
 	}
+	if logConfig.Header != nil {
+		if len(logConfig.Header.MetadataOperators) == 0 {
+			return nil, errors.New("header requires at least one metadata operator")
+		}
+		if !inputCfg.IncludeFilePath {
+			return nil, errors.New("header requires include_file_path to be enabled")
+		}
+		for _, op := range logConfig.Operators {
+			if id, ok := op["id"].(string); ok && id == headerOperatorID {
+				return nil, fmt.Errorf("operator id %q is reserved for the header", headerOperatorID)
+			}
+		}
+	}
 	return &operator.Config{Builder: inputCfg}, nil
 }
@@ -0,0 +1,395 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/entry"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator/helper"
+)
+
+const (
+	headerOperatorType = "file_header"
+	metadataOutputID   = "file_header_metadata_output"
+
+	defaultHeaderLineCount         = 1
+	defaultHeaderAttribute         = "file_header"
+	defaultHeaderFilePathAttribute = "file_path"
+
+	// identityCheckInterval is how often the file at a path is checked for having been replaced.
+	identityCheckInterval = time.Second
+	// idleFileTimeout is how long the state of a file no entry was received from is kept.
+	idleFileTimeout = 5 * time.Minute
+)
+
+func init() {
+	operator.Register(headerOperatorType, func() operator.Builder { return NewHeaderConfig("") })
+}
+
+// NewHeaderConfig creates a new file header operator config with default values
+func NewHeaderConfig(operatorID string) *HeaderConfig {
+	return &HeaderConfig{
+		TransformerConfig: helper.NewTransformerConfig(operatorID, headerOperatorType),
+		LineCount:         defaultHeaderLineCount,
+		HeaderAttribute:   defaultHeaderAttribute,
+		FilePathAttribute: defaultHeaderFilePathAttribute,
+	}
+}
+
+// HeaderConfig is the configuration of a file header operator.
+//
+// The operator reads the first LineCount lines of the file an entry was read
+// from and adds them to the entry as the HeaderAttribute attribute, so that
+// parser operators further down the pipeline can use them. If MetadataOperators
+// are configured, the header is also run through them and the fields they
+// extract are added to the resource of the entry. The first LineCount entries
+// read from the beginning of a file are the header lines themselves and are dropped.
+type HeaderConfig struct {
+	helper.TransformerConfig `yaml:",inline"`
+
+	// LineCount is the number of lines at the beginning of the file that make up the header.
+	LineCount int `json:"line_count" yaml:"line_count"`
+	// HeaderAttribute is the attribute the header is written to. Multiple header lines are
+	// joined by a newline. It may only be empty if MetadataOperators are configured, in
+	// which case the header isn't written to an attribute.
+	HeaderAttribute string `json:"header_attribute" yaml:"header_attribute"`
+	// FilePathAttribute is the attribute holding the path of the file the entry was read from.
+	// It is written by the file input when include_file_path is enabled.
+	FilePathAttribute string `json:"file_path_attribute" yaml:"file_path_attribute"`
+	// MetadataOperators are the parser operators the header is run through, in order.
+	// The fields they parse into the body or the attributes of the header become
+	// resource attributes of every entry of the file.
+	MetadataOperators []operator.Config `json:"metadata_operators" yaml:"metadata_operators"`
+}
+
+// Build will build a file header operator
+func (c HeaderConfig) Build(bc operator.BuildContext) ([]operator.Operator, error) {
+	transformerOperator, err := c.TransformerConfig.Build(bc)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.LineCount < 1 {
+		return nil, fmt.Errorf("line_count must be at least 1")
+	}
+	if c.HeaderAttribute == "" && len(c.MetadataOperators) == 0 {
+		return nil, fmt.Errorf("header_attribute must be specified")
+	}
+	if c.FilePathAttribute == "" {
+		return nil, fmt.Errorf("file_path_attribute must be specified")
+	}
+
+	output := &metadataOutput{
+		OutputOperator: helper.OutputOperator{
+			BasicOperator: helper.BasicOperator{
+				OperatorID:    metadataOutputID,
+				OperatorType:  metadataOutputID,
+				SugaredLogger: transformerOperator.SugaredLogger,
+			},
+		},
+	}
+	metadataOperators, err := buildMetadataOperators(bc, c.MetadataOperators, output)
+	if err != nil {
+		return nil, err
+	}
+
+	headerOperator := &HeaderOperator{
+		TransformerOperator: transformerOperator,
+		lineCount:           c.LineCount,
+		headerAttribute:     c.HeaderAttribute,
+		filePathAttribute:   c.FilePathAttribute,
+		metadataOperators:   metadataOperators,
+		metadataOutput:      output,
+		files:               map[string]*headerFile{},
+	}
+	return []operator.Operator{headerOperator}, nil
+}
+
+// buildMetadataOperators builds the metadata operators, which all write to output so
+// that the header can be passed from one to the next.
+func buildMetadataOperators(bc operator.BuildContext, configs []operator.Config, output operator.Operator) ([]operator.Operator, error) {
+	metadataBC := bc.WithDefaultOutputIds([]string{output.ID()})
+	var metadataOperators []operator.Operator
+	for _, cfg := range configs {
+		ops, err := cfg.Build(metadataBC)
+		if err != nil {
+			return nil, fmt.Errorf("build metadata operator: %w", err)
+		}
+		for _, op := range ops {
+			if !op.CanProcess() || !op.CanOutput() {
+				return nil, fmt.Errorf("metadata operator %s must be a parser", op.ID())
+			}
+			if err := op.SetOutputs([]operator.Operator{output}); err != nil {
+				return nil, fmt.Errorf("metadata operator %s: %w", op.ID(), err)
+			}
+			metadataOperators = append(metadataOperators, op)
+		}
+	}
+	return metadataOperators, nil
+}
+
+// metadataOutput receives the entries written by the metadata operators
+type metadataOutput struct {
+	helper.OutputOperator
+	entry *entry.Entry
+}
+
+// Process will keep the entry until the next one is received
+func (o *metadataOutput) Process(_ context.Context, e *entry.Entry) error {
+	o.entry = e
+	return nil
+}
+
+// HeaderOperator is an operator that adds the header of the file an entry
+// was read from to the entry
+type HeaderOperator struct {
+	helper.TransformerOperator
+
+	lineCount         int
+	headerAttribute   string
+	filePathAttribute string
+	metadataOperators []operator.Operator
+
+	// metadataMu serializes the use of the metadata operators and their output.
+	metadataMu     sync.Mutex
+	metadataOutput *metadataOutput
+
+	// mu guards files, the state of the files entries are read from by path.
+	mu        sync.Mutex
+	files     map[string]*headerFile
+	lastEvict time.Time
+}
+
+// headerFile is the state of the file at a path. Its entries are processed
+// under its own lock, so that files don't wait on each other.
+type headerFile struct {
+	mu sync.Mutex
+	// lastSeen is when the last entry was received from the file, guarded by the operator's mu.
+	lastSeen time.Time
+
+	info      os.FileInfo
+	checkedAt time.Time
+	header    *fileHeader
+	// position is the number of entries received from the file.
+	position int
+	// fromStart is whether the entries are read from the beginning of the file,
+	// which makes the first ones the header lines.
+	fromStart bool
+}
+
+// fileHeader is the header read from a single file.
+type fileHeader struct {
+	lines []string
+	value string
+	// resource holds the fields the metadata operators extracted from the header.
+	resource map[string]string
+}
+
+// Process will add the file header to the entry, or drop the entry if it is
+// part of the header
+func (o *HeaderOperator) Process(ctx context.Context, e *entry.Entry) error {
+	path, ok := e.Attributes[o.filePathAttribute]
+	if !ok {
+		o.Write(ctx, e)
+		return nil
+	}
+
+	file := o.file(path, time.Now())
+	file.mu.Lock()
+	header, isHeaderLine, err := o.processFileEntry(file, path, e)
+	file.mu.Unlock()
+	if err != nil {
+		return o.HandleEntryError(ctx, e, err)
+	}
+	if isHeaderLine {
+		return nil
+	}
+
+	if o.headerAttribute != "" {
+		e.AddAttribute(o.headerAttribute, header.value)
+	}
+	for k, v := range header.resource {
+		e.AddResourceKey(k, v)
+	}
+	o.Write(ctx, e)
+	return nil
+}
+
+// file returns the state of the file at path, evicting the state of the files
+// no entry was received from for idleFileTimeout.
+func (o *HeaderOperator) file(path string, now time.Time) *headerFile {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if now.Sub(o.lastEvict) >= idleFileTimeout {
+		o.evictIdleFiles(now)
+	}
+
+	file, ok := o.files[path]
+	if !ok {
+		file = &headerFile{}
+		o.files[path] = file
+	}
+	file.lastSeen = now
+	return file
+}
+
+// evictIdleFiles forgets the files that were closed by the file input, rotated
+// away or deleted. Should one of them be read again, its header is read again and
+// its entries are no longer at the beginning of the file. It must be called with o.mu held.
+func (o *HeaderOperator) evictIdleFiles(now time.Time) {
+	for path, file := range o.files {
+		if now.Sub(file.lastSeen) >= idleFileTimeout {
+			delete(o.files, path)
+		}
+	}
+	o.lastEvict = now
+}
+
+// processFileEntry returns the header of the file an entry was read from, and
+// whether the entry is one of the header lines. It must be called with file.mu held.
+func (o *HeaderOperator) processFileEntry(file *headerFile, path string, e *entry.Entry) (*fileHeader, bool, error) {
+	body, _ := e.Body.(string)
+	now := time.Now()
+
+	// The file at the path is checked for having been replaced periodically, and
+	// whenever an entry may be the first line of a new file.
+	mayBeNewFile := file.header != nil && len(file.header.lines) > 0 && body == file.header.lines[0]
+	if file.header == nil || len(file.header.lines) < o.lineCount || mayBeNewFile || now.Sub(file.checkedAt) >= identityCheckInterval {
+		if err := o.checkFile(file, path, now); err != nil {
+			return nil, false, err
+		}
+	}
+
+	position := file.position
+	file.position++
+	if position == 0 {
+		// Entries read from a checkpoint or the end of the file don't start with the header.
+		file.fromStart = len(file.header.lines) > 0 && body == file.header.lines[0]
+	}
+	return file.header, file.fromStart && position < o.lineCount, nil
+}
+
+// checkFile reads the header of the file at path if it wasn't read yet, is
+// incomplete or the file was replaced. It must be called with file.mu held.
+func (o *HeaderOperator) checkFile(file *headerFile, path string, now time.Time) error {
+	file.checkedAt = now
+	info, err := os.Stat(path)
+	if err != nil {
+		if file.header != nil {
+			// The file was rotated away, keep using the last known header.
+			return nil
+		}
+		return fmt.Errorf("stat file: %w", err)
+	}
+
+	replaced := file.info != nil && (!os.SameFile(file.info, info) || info.Size() < file.info.Size())
+	file.info = info
+	if file.header != nil && len(file.header.lines) == o.lineCount && !replaced {
+		return nil
+	}
+
+	lines, err := readHeaderLines(path, o.lineCount)
+	if err != nil {
+		return err
+	}
+	header := &fileHeader{
+		lines: lines,
+		value: strings.Join(lines, "\n"),
+	}
+	// A file shorter than the header is still being written, it is read again with the next entry.
+	if len(lines) == o.lineCount {
+		if header.resource, err = o.parseMetadata(header.value); err != nil {
+			o.Errorw("Failed to parse file header", "path", path, "error", err)
+		}
+	}
+	file.header = header
+	if replaced {
+		// The new file is read from its beginning.
+		file.position = 0
+	}
+	return nil
+}
+
+// parseMetadata runs the header through the metadata operators and returns the
+// fields they extracted.
+func (o *HeaderOperator) parseMetadata(value string) (map[string]string, error) {
+	if len(o.metadataOperators) == 0 {
+		return nil, nil
+	}
+
+	o.metadataMu.Lock()
+	defer o.metadataMu.Unlock()
+
+	e := entry.New()
+	e.Body = value
+	for _, op := range o.metadataOperators {
+		o.metadataOutput.entry = nil
+		if err := op.Process(context.Background(), e); err != nil {
+			return nil, fmt.Errorf("%s: %w", op.ID(), err)
+		}
+		if o.metadataOutput.entry == nil {
+			// The operator dropped the header.
+			return nil, nil
+		}
+		e = o.metadataOutput.entry
+	}
+
+	fields := make(map[string]string, len(e.Attributes))
+	if body, ok := e.Body.(map[string]interface{}); ok {
+		for k, v := range body {
+			fields[k] = fmt.Sprintf("%v", v)
+		}
+	}
+	for k, v := range e.Attributes {
+		fields[k] = v
+	}
+	return fields, nil
+}
+
+// readHeaderLines reads up to count lines from the beginning of the file at path
+func readHeaderLines(path string, count int) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	lines := make([]string, 0, count)
+	for len(lines) < count {
+		line, err := reader.ReadString('\n')
+		if line != "" && err == nil {
+			lines = append(lines, strings.TrimRight(line, "\r\n"))
+		}
+		if err == io.EOF {
+			// An unterminated last line may still be written to, so it isn't part of the header yet.
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read header: %w", err)
+		}
+	}
+	return lines, nil
+}
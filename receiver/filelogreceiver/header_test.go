@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza"
+)
+
+func TestFileHeader(t *testing.T) {
+	tempDir := newTempDir(t)
+	path := filepath.Join(tempDir, "data.csv")
+	require.NoError(t, ioutil.WriteFile(path, []byte("# host=web-1\nid,name\n1,foo\n2,bar\n"), 0600))
+
+	cfg := &FileLogConfig{
+		BaseConfig: stanza.BaseConfig{
+			ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+			Operators: stanza.OperatorConfigs{
+				map[string]interface{}{
+					"type":       "file_header",
+					"line_count": 2,
+				},
+			},
+			Converter: stanza.ConverterConfig{
+				MaxFlushCount: 1,
+				FlushInterval: time.Millisecond,
+			},
+		},
+		Input: stanza.InputConfig{
+			"include":           []interface{}{path},
+			"include_file_path": true,
+			"poll_interval":     "10ms",
+			"start_at":          "beginning",
+		},
+	}
+
+	sink := new(consumertest.LogsSink)
+	rcvr, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, rcvr.Shutdown(context.Background())) }()
+
+	require.Eventually(t, expectNLogs(sink, 2), 2*time.Second, 5*time.Millisecond,
+		"expected %d but got %d logs", 2, sink.LogRecordCount())
+	// The header lines must not be emitted as log records.
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, 2, sink.LogRecordCount())
+
+	var bodies []string
+	for _, logs := range sink.AllLogs() {
+		rls := logs.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			ills := rls.At(i).InstrumentationLibraryLogs()
+			for j := 0; j < ills.Len(); j++ {
+				records := ills.At(j).Logs()
+				for k := 0; k < records.Len(); k++ {
+					record := records.At(k)
+					bodies = append(bodies, record.Body().StringVal())
+					header, ok := record.Attributes().Get("file_header")
+					require.True(t, ok)
+					assert.Equal(t, "# host=web-1\nid,name", header.StringVal())
+				}
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"1,foo", "2,bar"}, bodies)
+}
+
+func TestFileHeaderKeepsLinesEqualToTheHeader(t *testing.T) {
+	tempDir := newTempDir(t)
+	path := filepath.Join(tempDir, "data.csv")
+	require.NoError(t, ioutil.WriteFile(path, []byte("id,name\n1,foo\nid,name\n2,bar\n"), 0600))
+
+	cfg := &FileLogConfig{
+		BaseConfig: stanza.BaseConfig{
+			ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+			Operators: stanza.OperatorConfigs{
+				map[string]interface{}{
+					"type": "file_header",
+				},
+			},
+			Converter: stanza.ConverterConfig{
+				MaxFlushCount: 1,
+				FlushInterval: time.Millisecond,
+			},
+		},
+		Input: stanza.InputConfig{
+			"include":           []interface{}{path},
+			"include_file_path": true,
+			"poll_interval":     "10ms",
+			"start_at":          "beginning",
+		},
+	}
+
+	sink := new(consumertest.LogsSink)
+	rcvr, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, rcvr.Shutdown(context.Background())) }()
+
+	// Only the first line is the header, the same line further down the file is a record.
+	require.Eventually(t, expectNLogs(sink, 3), 2*time.Second, 5*time.Millisecond,
+		"expected %d but got %d logs", 3, sink.LogRecordCount())
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, 3, sink.LogRecordCount())
+
+	var bodies []string
+	for _, logs := range sink.AllLogs() {
+		rls := logs.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			ills := rls.At(i).InstrumentationLibraryLogs()
+			for j := 0; j < ills.Len(); j++ {
+				records := ills.At(j).Logs()
+				for k := 0; k < records.Len(); k++ {
+					bodies = append(bodies, records.At(k).Body().StringVal())
+				}
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"1,foo", "id,name", "2,bar"}, bodies)
+}
+
+func TestFileHeaderEvictsIdleFiles(t *testing.T) {
+	o := &HeaderOperator{lineCount: 1, files: map[string]*headerFile{}}
+	start := time.Now()
+
+	active := o.file("active.log", start)
+	o.file("idle.log", start)
+	require.Len(t, o.files, 2)
+
+	o.file("active.log", start.Add(idleFileTimeout/2))
+	assert.Len(t, o.files, 2)
+
+	o.file("active.log", start.Add(idleFileTimeout))
+	assert.Len(t, o.files, 1)
+	assert.Same(t, active, o.files["active.log"])
+}
+
+func TestFileHeaderInvalidConfig(t *testing.T) {
+	cfg := testdataConfigYamlAsMap()
+	cfg.Operators = stanza.OperatorConfigs{
+		map[string]interface{}{
+			"type":       "file_header",
+			"line_count": 0,
+		},
+	}
+
+	_, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, new(consumertest.LogsSink))
+	require.Error(t, err)
+}
+
+func TestFileHeaderResourceAttributes(t *testing.T) {
+	tempDir := newTempDir(t)
+	path := filepath.Join(tempDir, "data.log")
+	require.NoError(t, ioutil.WriteFile(path, []byte("# host=web-1 region=eu\nfirst\nsecond\n"), 0600))
+
+	cfg := &FileLogConfig{
+		BaseConfig: stanza.BaseConfig{
+			ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+			Operators:        stanza.OperatorConfigs{},
+			Converter: stanza.ConverterConfig{
+				MaxFlushCount: 1,
+				FlushInterval: time.Millisecond,
+			},
+		},
+		Header: &FileHeaderConfig{
+			MetadataOperators: stanza.OperatorConfigs{
+				map[string]interface{}{
+					"type":  "regex_parser",
+					"regex": `^# host=(?P<host>\S+) region=(?P<region>\S+)$`,
+				},
+			},
+		},
+		Input: stanza.InputConfig{
+			"include":           []interface{}{path},
+			"include_file_path": true,
+			"poll_interval":     "10ms",
+			"start_at":          "beginning",
+		},
+	}
+
+	sink := new(consumertest.LogsSink)
+	rcvr, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, rcvr.Shutdown(context.Background())) }()
+
+	require.Eventually(t, expectNLogs(sink, 2), 2*time.Second, 5*time.Millisecond,
+		"expected %d but got %d logs", 2, sink.LogRecordCount())
+
+	var bodies []string
+	for _, logs := range sink.AllLogs() {
+		rls := logs.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			resource := rls.At(i).Resource().Attributes()
+			host, ok := resource.Get("host")
+			require.True(t, ok)
+			assert.Equal(t, "web-1", host.StringVal())
+			region, ok := resource.Get("region")
+			require.True(t, ok)
+			assert.Equal(t, "eu", region.StringVal())
+
+			ills := rls.At(i).InstrumentationLibraryLogs()
+			for j := 0; j < ills.Len(); j++ {
+				records := ills.At(j).Logs()
+				for k := 0; k < records.Len(); k++ {
+					record := records.At(k)
+					bodies = append(bodies, record.Body().StringVal())
+					_, ok := record.Attributes().Get("file_header")
+					assert.False(t, ok)
+				}
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"first", "second"}, bodies)
+}
+
+func TestFileHeaderSectionReservedOperatorID(t *testing.T) {
+	cfg := testdataConfigYamlAsMap()
+	cfg.Input["include_file_path"] = true
+	cfg.Operators = append(cfg.Operators, map[string]interface{}{"id": headerOperatorID, "type": "noop"})
+	cfg.Header = &FileHeaderConfig{
+		MetadataOperators: stanza.OperatorConfigs{
+			map[string]interface{}{"type": "regex_parser", "regex": "^(?P<host>.*)$"},
+		},
+	}
+
+	_, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, new(consumertest.LogsSink))
+	require.EqualError(t, err, `operator id "$$filelog_header" is reserved for the header`)
+}
+
+func TestFileHeaderSectionInvalidConfig(t *testing.T) {
+	for name, header := range map[string]*FileHeaderConfig{
+		"missing metadata operators": {LineCount: 1},
+		"missing file path": {
+			MetadataOperators: stanza.OperatorConfigs{
+				map[string]interface{}{"type": "regex_parser", "regex": "^(?P<host>.*)$"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := testdataConfigYamlAsMap()
+			cfg.Header = header
+
+			_, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, new(consumertest.LogsSink))
+			require.Error(t, err)
+		})
+	}
+}
@@ -14,7 +14,12 @@
 
 package fluentforwardreceiver
 
-import "go.opentelemetry.io/collector/config"
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
+)
 
 // Config defines configuration for the SignalFx receiver.
 type Config struct {
@@ -24,4 +29,57 @@ type Config struct {
 	// of the form `<ip addr>:<port>` (TCP) or `unix://<socket_path>` (Unix
 	// domain socket).
 	ListenAddress string `mapstructure:"endpoint"`
+
+	// The number of workers decoding the events read off the connections.
+	// Defaults to the number of CPUs.
+	NumWorkers int `mapstructure:"num_workers"`
+
+	// TLSSetting enables TLS on the connections of the clients. The heartbeats
+	// are still answered over plain UDP.
+	TLSSetting *configtls.TLSServerSetting `mapstructure:"tls_settings"`
+
+	// Security enables the handshake of the forward protocol, authenticating
+	// the clients with a shared key and, optionally, a username and password.
+	Security *SecurityConfig `mapstructure:"security"`
+}
+
+// SecurityConfig defines the handshake authentication, matching the security
+// section of the Fluentd forward input.
+type SecurityConfig struct {
+	// SelfHostname is the hostname sent to the clients. Defaults to the
+	// hostname of the machine.
+	SelfHostname string `mapstructure:"self_hostname"`
+
+	// SharedKey is the key shared with the clients.
+	SharedKey string `mapstructure:"shared_key"`
+
+	// UserAuth requires the clients to authenticate as one of the Users.
+	UserAuth bool `mapstructure:"user_auth"`
+
+	// Users are the usernames and passwords the clients authenticate with.
+	Users []UserConfig `mapstructure:"users"`
+}
+
+// UserConfig defines a user the clients authenticate as.
+type UserConfig struct {
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid.
+func (c *Config) Validate() error {
+	if c.NumWorkers <= 0 {
+		return errors.New("num_workers must be positive")
+	}
+	if c.Security != nil {
+		if c.Security.SharedKey == "" {
+			return errors.New("security.shared_key must not be empty")
+		}
+		if c.Security.UserAuth && len(c.Security.Users) == 0 {
+			return errors.New("security.users must not be empty when security.user_auth is enabled")
+		}
+	}
+	return nil
 }
@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/config/configtls"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -36,9 +37,41 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 1)
+	assert.Equal(t, len(cfg.Receivers), 2)
 
 	r0 := cfg.Receivers[config.NewID("fluentforward")]
 	assert.Equal(t, r0, factory.CreateDefaultConfig())
 
+	r1 := cfg.Receivers[config.NewIDWithName("fluentforward", "secure")].(*Config)
+	assert.Equal(t, "0.0.0.0:24224", r1.ListenAddress)
+	assert.Equal(t, &configtls.TLSServerSetting{
+		TLSSetting: configtls.TLSSetting{
+			CertFile: "/etc/otel/server.crt",
+			KeyFile:  "/etc/otel/server.key",
+		},
+	}, r1.TLSSetting)
+	assert.Equal(t, &SecurityConfig{
+		SelfHostname: "collector",
+		SharedKey:    "secret",
+		UserAuth:     true,
+		Users:        []UserConfig{{Username: "fluent", Password: "bit"}},
+	}, r1.Security)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	require.NoError(t, cfg.Validate())
+
+	cfg.NumWorkers = 0
+	require.EqualError(t, cfg.Validate(), "num_workers must be positive")
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.Security = &SecurityConfig{}
+	require.EqualError(t, cfg.Validate(), "security.shared_key must not be empty")
+
+	cfg.Security.SharedKey = "secret"
+	require.NoError(t, cfg.Validate())
+
+	cfg.Security.UserAuth = true
+	require.EqualError(t, cfg.Validate(), "security.users must not be empty when security.user_auth is enabled")
 }
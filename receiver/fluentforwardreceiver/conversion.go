@@ -19,7 +19,7 @@ import (
 	"compress/gzip"
 	"encoding/json"
 	"fmt"
-	"io"
+	"sync"
 	"time"
 
 	"github.com/tinylib/msgp/msgp"
@@ -34,6 +34,9 @@ const tagAttributeKey = "fluent.tag"
 
 type Event interface {
 	DecodeMsg(dc *msgp.Reader) error
+	// UnmarshalMsg decodes the event from b, which holds exactly one event,
+	// without copying the strings and byte arrays it reads before converting them.
+	UnmarshalMsg(b []byte) ([]byte, error)
 	LogRecords() pdata.LogSlice
 	Chunk() string
 	Compressed() string
@@ -120,63 +123,136 @@ func timeFromTimestamp(ts interface{}) (time.Time, error) {
 	}
 }
 
-func decodeTimestampToLogRecord(dc *msgp.Reader, lr pdata.LogRecord) error {
-	tsIntf, err := dc.ReadIntf()
-	if err != nil {
-		return msgp.WrapError(err, "Time")
+// decodeMsg reads the next event off dc and decodes it into event.
+func decodeMsg(dc *msgp.Reader, event Event) error {
+	var raw msgp.Raw
+	if err := raw.DecodeMsg(dc); err != nil {
+		return msgp.WrapError(err)
 	}
+	_, err := event.UnmarshalMsg(raw)
+	return err
+}
 
-	ts, err := timeFromTimestamp(tsIntf)
-	if err != nil {
-		return msgp.WrapError(err, "Time")
+func decodeTimestampToLogRecord(b []byte, lr pdata.LogRecord) ([]byte, error) {
+	var ts time.Time
+	if msgp.NextType(b) == msgp.IntType {
+		// Fast path for the timestamps in seconds, avoiding to box them.
+		secs, o, err := msgp.ReadInt64Bytes(b)
+		if err != nil {
+			return b, msgp.WrapError(err, "Time")
+		}
+		b = o
+		ts = time.Unix(secs, 0)
+	} else {
+		tsIntf, o, err := msgp.ReadIntfBytes(b)
+		if err != nil {
+			return b, msgp.WrapError(err, "Time")
+		}
+		b = o
+		ts, err = timeFromTimestamp(tsIntf)
+		if err != nil {
+			return b, msgp.WrapError(err, "Time")
+		}
 	}
 
 	lr.SetTimestamp(pdata.TimestampFromTime(ts))
-	return nil
+	return b, nil
 }
 
-func parseRecordToLogRecord(dc *msgp.Reader, lr pdata.LogRecord) error {
+func parseRecordToLogRecord(b []byte, lr pdata.LogRecord) ([]byte, error) {
 	attrs := lr.Attributes()
 
-	recordLen, err := dc.ReadMapHeader()
+	recordLen, b, err := msgp.ReadMapHeaderBytes(b)
 	if err != nil {
-		return msgp.WrapError(err, "Record")
+		return b, msgp.WrapError(err, "Record")
 	}
 
 	for recordLen > 0 {
 		recordLen--
-		key, err := dc.ReadString()
-		if err != nil {
-			// The protocol doesn't specify this but apparently some map keys
-			// can be binary type instead of string
-			keyBytes, keyBytesErr := dc.ReadBytes(nil)
-			if keyBytesErr != nil {
-				return msgp.WrapError(keyBytesErr, "Record")
-			}
-			key = string(keyBytes)
-		}
-		val, err := dc.ReadIntf()
+		// The protocol doesn't specify this but apparently some map keys
+		// can be binary type instead of string, which ReadMapKeyZC accepts.
+		var keyBytes []byte
+		keyBytes, b, err = msgp.ReadMapKeyZC(b)
 		if err != nil {
-			return msgp.WrapError(err, "Record", key)
+			return b, msgp.WrapError(err, "Record")
 		}
+		key := string(keyBytes)
 
 		// fluentd uses message, fluentbit log.
 		if key == "message" || key == "log" {
-			switch v := val.(type) {
-			case string:
-				lr.Body().SetStringVal(v)
-			case []uint8:
+			var body []byte
+			switch msgp.NextType(b) {
+			case msgp.StrType:
+				body, b, err = msgp.ReadStringZC(b)
+			case msgp.BinType:
 				// Sometimes strings come in as uint8's.
-				lr.Body().SetStringVal(string(v))
+				body, b, err = msgp.ReadBytesZC(b)
 			default:
-				return fmt.Errorf("cannot convert message type %T to string", val)
+				var val interface{}
+				val, _, err = msgp.ReadIntfBytes(b)
+				if err == nil {
+					err = fmt.Errorf("cannot convert message type %T to string", val)
+				}
 			}
-		} else {
-			insertToAttributeMap(key, val, &attrs)
+			if err != nil {
+				return b, msgp.WrapError(err, "Record", key)
+			}
+			lr.Body().SetStringVal(string(body))
+			continue
+		}
+
+		b, err = insertValueToAttributeMap(key, b, &attrs)
+		if err != nil {
+			return b, msgp.WrapError(err, "Record", key)
 		}
 	}
 
-	return nil
+	return b, nil
+}
+
+// insertValueToAttributeMap reads the next value of b into the key attribute
+// of dest, decoding the scalar types in place.
+func insertValueToAttributeMap(key string, b []byte, dest *pdata.AttributeMap) ([]byte, error) {
+	var err error
+	switch msgp.NextType(b) {
+	case msgp.StrType:
+		var v []byte
+		v, b, err = msgp.ReadStringZC(b)
+		if err == nil {
+			dest.InsertString(key, string(v))
+		}
+	case msgp.BinType:
+		var v []byte
+		v, b, err = msgp.ReadBytesZC(b)
+		if err == nil {
+			dest.InsertString(key, string(v))
+		}
+	case msgp.IntType:
+		var v int64
+		v, b, err = msgp.ReadInt64Bytes(b)
+		if err == nil {
+			dest.InsertInt(key, v)
+		}
+	case msgp.UintType:
+		var v uint64
+		v, b, err = msgp.ReadUint64Bytes(b)
+		if err == nil {
+			dest.InsertInt(key, int64(v))
+		}
+	case msgp.BoolType:
+		var v bool
+		v, b, err = msgp.ReadBoolBytes(b)
+		if err == nil {
+			dest.InsertBool(key, v)
+		}
+	default:
+		var v interface{}
+		v, b, err = msgp.ReadIntfBytes(b)
+		if err == nil {
+			insertToAttributeMap(key, v, dest)
+		}
+	}
+	return b, err
 }
 
 type MessageEventLogRecord struct {
@@ -189,68 +265,70 @@ func (melr *MessageEventLogRecord) LogRecords() pdata.LogSlice {
 }
 
 func (melr *MessageEventLogRecord) DecodeMsg(dc *msgp.Reader) error {
+	return decodeMsg(dc, melr)
+}
+
+func (melr *MessageEventLogRecord) UnmarshalMsg(b []byte) ([]byte, error) {
 	melr.LogSlice = pdata.NewLogSlice()
 	log := melr.LogSlice.AppendEmpty()
 
-	var arrLen uint32
-	var err error
-
-	arrLen, err = dc.ReadArrayHeader()
+	arrLen, b, err := msgp.ReadArrayHeaderBytes(b)
 	if err != nil {
-		return msgp.WrapError(err)
+		return b, msgp.WrapError(err)
 	}
 	if arrLen > 4 || arrLen < 3 {
-		return msgp.ArrayError{Wanted: 3, Got: arrLen}
+		return b, msgp.ArrayError{Wanted: 3, Got: arrLen}
 	}
 
-	tag, err := dc.ReadString()
+	tag, b, err := msgp.ReadStringBytes(b)
 	if err != nil {
-		return msgp.WrapError(err, "Tag")
+		return b, msgp.WrapError(err, "Tag")
 	}
 
 	attrs := log.Attributes()
 	attrs.InsertString(tagAttributeKey, tag)
 
-	err = decodeTimestampToLogRecord(dc, log)
+	b, err = decodeTimestampToLogRecord(b, log)
 	if err != nil {
-		return msgp.WrapError(err, "Time")
+		return b, msgp.WrapError(err, "Time")
 	}
 
-	err = parseRecordToLogRecord(dc, log)
+	b, err = parseRecordToLogRecord(b, log)
 	if err != nil {
-		return err
+		return b, err
 	}
 
 	if arrLen == 4 {
-		melr.OptionsMap, err = parseOptions(dc)
+		melr.OptionsMap, b, err = parseOptions(b)
 		if err != nil {
-			return err
+			return b, err
 		}
 	}
-	return nil
+	return b, nil
 }
 
-func parseOptions(dc *msgp.Reader) (OptionsMap, error) {
-	var optionLen uint32
-	optionLen, err := dc.ReadMapHeader()
+func parseOptions(b []byte) (OptionsMap, []byte, error) {
+	optionLen, b, err := msgp.ReadMapHeaderBytes(b)
 	if err != nil {
-		return nil, msgp.WrapError(err, "Option")
+		return nil, b, msgp.WrapError(err, "Option")
 	}
 	out := make(OptionsMap, optionLen)
 
 	for optionLen > 0 {
 		optionLen--
-		key, err := dc.ReadString()
+		var key string
+		key, b, err = msgp.ReadStringBytes(b)
 		if err != nil {
-			return nil, msgp.WrapError(err, "Option")
+			return nil, b, msgp.WrapError(err, "Option")
 		}
-		val, err := dc.ReadIntf()
+		var val interface{}
+		val, b, err = msgp.ReadIntfBytes(b)
 		if err != nil {
-			return nil, msgp.WrapError(err, "Option", key)
+			return nil, b, msgp.WrapError(err, "Option", key)
 		}
 		out[key] = val
 	}
-	return out, nil
+	return out, b, nil
 }
 
 type ForwardEventLogRecords struct {
@@ -262,67 +340,67 @@ func (fe *ForwardEventLogRecords) LogRecords() pdata.LogSlice {
 	return fe.LogSlice
 }
 
-func (fe *ForwardEventLogRecords) DecodeMsg(dc *msgp.Reader) (err error) {
+func (fe *ForwardEventLogRecords) DecodeMsg(dc *msgp.Reader) error {
+	return decodeMsg(dc, fe)
+}
+
+func (fe *ForwardEventLogRecords) UnmarshalMsg(b []byte) ([]byte, error) {
 	fe.LogSlice = pdata.NewLogSlice()
 
-	var arrLen uint32
-	arrLen, err = dc.ReadArrayHeader()
+	arrLen, b, err := msgp.ReadArrayHeaderBytes(b)
 	if err != nil {
-		err = msgp.WrapError(err)
-		return
+		return b, msgp.WrapError(err)
 	}
 	if arrLen < 2 || arrLen > 3 {
-		err = msgp.ArrayError{Wanted: 2, Got: arrLen}
-		return
+		return b, msgp.ArrayError{Wanted: 2, Got: arrLen}
 	}
 
-	tag, err := dc.ReadString()
+	tag, b, err := msgp.ReadStringBytes(b)
 	if err != nil {
-		return msgp.WrapError(err, "Tag")
+		return b, msgp.WrapError(err, "Tag")
 	}
 
-	entryLen, err := dc.ReadArrayHeader()
+	entryLen, b, err := msgp.ReadArrayHeaderBytes(b)
 	if err != nil {
-		err = msgp.WrapError(err, "Record")
-		return
+		return b, msgp.WrapError(err, "Record")
 	}
 
 	fe.LogSlice.EnsureCapacity(int(entryLen))
 	for i := 0; i < int(entryLen); i++ {
 		lr := fe.LogSlice.AppendEmpty()
 
-		err = parseEntryToLogRecord(dc, lr)
+		b, err = parseEntryToLogRecord(b, lr)
 		if err != nil {
-			return msgp.WrapError(err, "Entries", i)
+			return b, msgp.WrapError(err, "Entries", i)
 		}
-		fe.LogSlice.At(i).Attributes().InsertString(tagAttributeKey, tag)
+		lr.Attributes().InsertString(tagAttributeKey, tag)
 	}
 
 	if arrLen == 3 {
-		fe.OptionsMap, err = parseOptions(dc)
+		fe.OptionsMap, b, err = parseOptions(b)
 		if err != nil {
-			return err
+			return b, err
 		}
 	}
 
-	return
+	return b, nil
 }
 
-func parseEntryToLogRecord(dc *msgp.Reader, lr pdata.LogRecord) error {
-	arrLen, err := dc.ReadArrayHeader()
+func parseEntryToLogRecord(b []byte, lr pdata.LogRecord) ([]byte, error) {
+	arrLen, b, err := msgp.ReadArrayHeaderBytes(b)
 	if err != nil {
-		return msgp.WrapError(err)
+		return b, msgp.WrapError(err)
 	}
 	if arrLen != 2 {
-		return msgp.ArrayError{Wanted: 2, Got: arrLen}
+		return b, msgp.ArrayError{Wanted: 2, Got: arrLen}
 	}
 
-	err = decodeTimestampToLogRecord(dc, lr)
+	b, err = decodeTimestampToLogRecord(b, lr)
 	if err != nil {
-		return msgp.WrapError(err, "Time")
+		return b, msgp.WrapError(err, "Time")
 	}
 
-	return parseRecordToLogRecord(dc, lr)
+	return parseRecordToLogRecord(b, lr)
 }
 
 type PackedForwardEventLogRecords struct {
@@ -337,92 +415,91 @@ func (pfe *PackedForwardEventLogRecords) LogRecords() pdata.LogSlice {
 // DecodeMsg implements msgp.Decodable.  This was originally code generated but
 // then manually copied here in order to handle the optional Options field.
 func (pfe *PackedForwardEventLogRecords) DecodeMsg(dc *msgp.Reader) error {
+	return decodeMsg(dc, pfe)
+}
+
+func (pfe *PackedForwardEventLogRecords) UnmarshalMsg(b []byte) ([]byte, error) {
 	pfe.LogSlice = pdata.NewLogSlice()
 
-	arrLen, err := dc.ReadArrayHeader()
+	arrLen, b, err := msgp.ReadArrayHeaderBytes(b)
 	if err != nil {
-		return msgp.WrapError(err)
+		return b, msgp.WrapError(err)
 	}
 	if arrLen < 2 || arrLen > 3 {
-		return msgp.ArrayError{Wanted: 2, Got: arrLen}
-	}
-
-	tag, err := dc.ReadString()
-	if err != nil {
-		return msgp.WrapError(err, "Tag")
+		return b, msgp.ArrayError{Wanted: 2, Got: arrLen}
 	}
 
-	entriesFirstByte, err := dc.R.Peek(1)
+	tag, b, err := msgp.ReadStringBytes(b)
 	if err != nil {
-		return msgp.WrapError(err, "EntriesRaw")
+		return b, msgp.WrapError(err, "Tag")
 	}
 
-	entriesType := msgp.NextType(entriesFirstByte)
 	// We have to read out the entries raw all the way first because we don't
 	// know whether it is compressed or not until we read the options map which
 	// comes after.  I guess we could use some kind of detection logic to
 	// determine if it is gzipped by peeking and just ignoring options, but
-	// this seems simpler for now.
+	// this seems simpler for now.  The entries are not copied out of b.
 	var entriesRaw []byte
-	switch entriesType {
+	switch entriesType := msgp.NextType(b); entriesType {
 	case msgp.StrType:
-		var entriesStr string
-		entriesStr, err = dc.ReadString()
-		if err != nil {
-			return msgp.WrapError(err, "EntriesRaw")
-		}
-		entriesRaw = []byte(entriesStr)
+		entriesRaw, b, err = msgp.ReadStringZC(b)
 	case msgp.BinType:
-		entriesRaw, err = dc.ReadBytes(nil)
-		if err != nil {
-			return msgp.WrapError(err, "EntriesRaw")
-		}
+		entriesRaw, b, err = msgp.ReadBytesZC(b)
 	default:
-		return msgp.WrapError(fmt.Errorf("invalid type %d", entriesType), "EntriesRaw")
+		err = fmt.Errorf("invalid type %d", entriesType)
+	}
+	if err != nil {
+		return b, msgp.WrapError(err, "EntriesRaw")
 	}
 
 	if arrLen == 3 {
-		pfe.OptionsMap, err = parseOptions(dc)
+		pfe.OptionsMap, b, err = parseOptions(b)
 		if err != nil {
-			return err
+			return b, err
 		}
 	}
 
 	err = pfe.parseEntries(entriesRaw, pfe.Compressed() == "gzip", tag)
 	if err != nil {
-		return err
+		return b, err
 	}
 
-	return nil
+	return b, nil
 }
 
-func (pfe *PackedForwardEventLogRecords) parseEntries(entriesRaw []byte, isGzipped bool, tag string) error {
-	var reader io.Reader
-	reader = bytes.NewReader(entriesRaw)
+// gzipBufferPool holds the buffers compressed entries are inflated into.
+var gzipBufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
 
+func (pfe *PackedForwardEventLogRecords) parseEntries(entriesRaw []byte, isGzipped bool, tag string) error {
 	if isGzipped {
-		var err error
-		reader, err = gzip.NewReader(reader)
+		reader, err := gzip.NewReader(bytes.NewReader(entriesRaw))
 		if err != nil {
 			return err
 		}
-		defer reader.(*gzip.Reader).Close()
+		defer reader.Close()
+
+		buf := gzipBufferPool.Get().(*bytes.Buffer)
+		buf.Reset()
+		defer gzipBufferPool.Put(buf)
+		if _, err = buf.ReadFrom(reader); err != nil {
+			return err
+		}
+		entriesRaw = buf.Bytes()
 	}
 
-	msgpReader := msgp.NewReader(reader)
-	for {
-		lr := pdata.NewLogRecord()
-		err := parseEntryToLogRecord(msgpReader, lr)
+	for len(entriesRaw) > 0 {
+		lr := pfe.LogSlice.AppendEmpty()
+		var err error
+		entriesRaw, err = parseEntryToLogRecord(entriesRaw, lr)
 		if err != nil {
-			if msgp.Cause(err) == io.EOF {
-				return nil
-			}
 			return err
 		}
 
 		lr.Attributes().InsertString(tagAttributeKey, tag)
-
-		tgt := pfe.LogSlice.AppendEmpty()
-		lr.CopyTo(tgt)
 	}
+	return nil
 }
@@ -213,3 +213,36 @@ func TestPackedForwardEventConversionWithErrors(t *testing.T) {
 		print(err.Error())
 	})
 }
+
+func TestUnmarshalMsgMatchesDecodeMsg(t *testing.T) {
+	cases := []struct {
+		name     string
+		newEvent func() Event
+	}{
+		{"message-event", func() Event { return &MessageEventLogRecord{} }},
+		{"forward-event", func() Event { return &ForwardEventLogRecords{} }},
+		{"forward-packed", func() Event { return &PackedForwardEventLogRecords{} }},
+		{"forward-packed-compressed", func() Event { return &PackedForwardEventLogRecords{} }},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := parseHexDump("testdata/" + tc.name)
+
+			decoded := tc.newEvent()
+			require.NoError(t, decoded.DecodeMsg(msgp.NewReader(bytes.NewReader(b))))
+
+			unmarshaled := tc.newEvent()
+			rest, err := unmarshaled.UnmarshalMsg(b)
+			require.NoError(t, err)
+			require.Empty(t, rest)
+
+			require.Equal(t, decoded.Chunk(), unmarshaled.Chunk())
+			require.Equal(t, decoded.LogRecords().Len(), unmarshaled.LogRecords().Len())
+			require.Greater(t, unmarshaled.LogRecords().Len(), 0)
+			for i := 0; i < decoded.LogRecords().Len(); i++ {
+				require.EqualValues(t, decoded.LogRecords().At(i), unmarshaled.LogRecords().At(i))
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package fluentforwardreceiver
 
 import (
 	"context"
+	"runtime"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -39,6 +40,7 @@ func NewFactory() component.ReceiverFactory {
 func createDefaultConfig() config.Receiver {
 	return &Config{
 		ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+		NumWorkers:       runtime.NumCPU(),
 	}
 }
 
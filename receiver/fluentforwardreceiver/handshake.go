@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardreceiver
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+const (
+	// handshakeTimeout bounds the time a client has to complete the handshake
+	// before its connection is closed.
+	handshakeTimeout = 30 * time.Second
+	// maxPingFieldSize bounds the size of the fields of a PING, read before the
+	// client is authenticated. Hostnames, salts, usernames and digests are far
+	// smaller.
+	maxPingFieldSize = 4 * 1024
+)
+
+// authenticator runs the handshake of the forward protocol on the new
+// connections, as described in the spec:
+// https://github.com/fluent/fluentd/wiki/Forward-Protocol-Specification-v1#handshake-messages
+type authenticator struct {
+	hostname  string
+	sharedKey []byte
+	userAuth  bool
+	passwords map[string]string
+}
+
+func newAuthenticator(conf *SecurityConfig, hostname string) *authenticator {
+	passwords := make(map[string]string, len(conf.Users))
+	for _, user := range conf.Users {
+		passwords[user.Username] = user.Password
+	}
+	return &authenticator{
+		hostname:  hostname,
+		sharedKey: []byte(conf.SharedKey),
+		userAuth:  conf.UserAuth,
+		passwords: passwords,
+	}
+}
+
+// ping is the message a client authenticates with, in answer to the HELO of
+// the server.
+type ping struct {
+	hostname           []byte
+	sharedKeySalt      []byte
+	sharedKeyHexDigest []byte
+	username           []byte
+	passwordHexDigest  []byte
+}
+
+// handshake sends a HELO to the client of conn, reads its PING off reader and
+// answers with a PONG. An error is returned if the client did not
+// authenticate, in which case the connection must be closed.
+func (a *authenticator) handshake(conn net.Conn, reader *msgp.Reader) error {
+	nonce, err := randomBytes(16)
+	if err != nil {
+		return err
+	}
+	var authSalt []byte
+	if a.userAuth {
+		if authSalt, err = randomBytes(16); err != nil {
+			return err
+		}
+	}
+
+	if err = conn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return err
+	}
+
+	writer := msgp.NewWriter(conn)
+	if err = writeHelo(writer, nonce, authSalt); err != nil {
+		return fmt.Errorf("failed to send HELO: %v", err)
+	}
+
+	p, err := readPing(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read PING: %v", err)
+	}
+
+	if reason := a.verify(p, nonce, authSalt); reason != "" {
+		// The client is told why it was rejected before the connection is
+		// closed, so that the misconfiguration shows up in its logs.
+		if err = writePong(writer, false, reason, "", ""); err != nil {
+			return fmt.Errorf("failed to send PONG: %v", err)
+		}
+		return fmt.Errorf("handshake failed: %s", reason)
+	}
+
+	digest := hexDigest(p.sharedKeySalt, []byte(a.hostname), nonce, a.sharedKey)
+	if err = writePong(writer, true, "", a.hostname, digest); err != nil {
+		return fmt.Errorf("failed to send PONG: %v", err)
+	}
+
+	return conn.SetDeadline(time.Time{})
+}
+
+// verify returns the reason p is rejected, or an empty string if the client
+// authenticated.
+func (a *authenticator) verify(p *ping, nonce, authSalt []byte) string {
+	expected := hexDigest(p.sharedKeySalt, p.hostname, nonce, a.sharedKey)
+	if subtle.ConstantTimeCompare([]byte(expected), p.sharedKeyHexDigest) != 1 {
+		return "shared_key mismatch"
+	}
+
+	if a.userAuth {
+		password, ok := a.passwords[string(p.username)]
+		// The digest is compared even for unknown users, so that they cannot
+		// be told apart from wrong passwords by the response time.
+		expected = hexDigest(authSalt, p.username, []byte(password))
+		if subtle.ConstantTimeCompare([]byte(expected), p.passwordHexDigest) != 1 || !ok {
+			return "username/password mismatch"
+		}
+	}
+
+	return ""
+}
+
+func writeHelo(writer *msgp.Writer, nonce, authSalt []byte) error {
+	if err := writer.WriteArrayHeader(2); err != nil {
+		return err
+	}
+	if err := writer.WriteString("HELO"); err != nil {
+		return err
+	}
+	if err := writer.WriteMapHeader(3); err != nil {
+		return err
+	}
+	if err := writer.WriteString("nonce"); err != nil {
+		return err
+	}
+	if err := writer.WriteBytes(nonce); err != nil {
+		return err
+	}
+	if err := writer.WriteString("auth"); err != nil {
+		return err
+	}
+	if err := writer.WriteBytes(authSalt); err != nil {
+		return err
+	}
+	if err := writer.WriteString("keepalive"); err != nil {
+		return err
+	}
+	if err := writer.WriteBool(true); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+func readPing(reader *msgp.Reader) (*ping, error) {
+	arrLen, err := reader.ReadArrayHeader()
+	if err != nil {
+		return nil, err
+	}
+	if arrLen != 6 {
+		return nil, msgp.ArrayError{Wanted: 6, Got: arrLen}
+	}
+
+	var fields [6][]byte
+	for i := range fields {
+		if fields[i], err = readStrOrBin(reader); err != nil {
+			return nil, err
+		}
+	}
+	if string(fields[0]) != "PING" {
+		return nil, errors.New("unexpected message type " + string(fields[0]))
+	}
+
+	return &ping{
+		hostname:           fields[1],
+		sharedKeySalt:      fields[2],
+		sharedKeyHexDigest: fields[3],
+		username:           fields[4],
+		passwordHexDigest:  fields[5],
+	}, nil
+}
+
+// readStrOrBin reads a field clients may encode as either a string or binary,
+// depending on their msgpack library. The length of the field is checked
+// before it is read, so that a client cannot make the server allocate an
+// arbitrary amount of memory.
+func readStrOrBin(reader *msgp.Reader) ([]byte, error) {
+	typ, err := reader.NextType()
+	if err != nil {
+		return nil, err
+	}
+	var size uint32
+	switch typ {
+	case msgp.StrType:
+		size, err = reader.ReadStringHeader()
+	case msgp.BinType:
+		size, err = reader.ReadBytesHeader()
+	default:
+		return nil, msgp.TypeError{Method: msgp.StrType, Encoded: typ}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if size > maxPingFieldSize {
+		return nil, fmt.Errorf("field of %d bytes exceeds the limit of %d bytes", size, maxPingFieldSize)
+	}
+	field := make([]byte, size)
+	if _, err = reader.ReadFull(field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+func writePong(writer *msgp.Writer, authenticated bool, reason, hostname, digest string) error {
+	if err := writer.WriteArrayHeader(5); err != nil {
+		return err
+	}
+	if err := writer.WriteString("PONG"); err != nil {
+		return err
+	}
+	if err := writer.WriteBool(authenticated); err != nil {
+		return err
+	}
+	if err := writer.WriteString(reason); err != nil {
+		return err
+	}
+	if err := writer.WriteString(hostname); err != nil {
+		return err
+	}
+	if err := writer.WriteString(digest); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+func hexDigest(parts ...[]byte) string {
+	h := sha512.New()
+	for _, part := range parts {
+		h.Write(part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardreceiver
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.uber.org/zap"
+)
+
+func startReceiver(t *testing.T, conf *Config) (string, *consumertest.LogsSink) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	next := new(consumertest.LogsSink)
+	conf.ListenAddress = "127.0.0.1:0"
+	receiver, err := newFluentReceiver(zap.NewNop(), conf, next)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(ctx, nil))
+	t.Cleanup(func() {
+		require.NoError(t, receiver.Shutdown(ctx))
+	})
+
+	return receiver.(*fluentReceiver).listener.Addr().String(), next
+}
+
+// clientHandshake authenticates conn the way a Fluentd forward output does,
+// returning whether the server accepted the client and why not.
+func clientHandshake(t *testing.T, conn net.Conn, sharedKey, username, password string) (bool, string) {
+	reader := msgp.NewReader(conn)
+
+	arrLen, err := reader.ReadArrayHeader()
+	require.NoError(t, err)
+	require.EqualValues(t, 2, arrLen)
+	typ, err := reader.ReadString()
+	require.NoError(t, err)
+	require.Equal(t, "HELO", typ)
+
+	var nonce, authSalt []byte
+	mapLen, err := reader.ReadMapHeader()
+	require.NoError(t, err)
+	for i := uint32(0); i < mapLen; i++ {
+		key, err := reader.ReadString()
+		require.NoError(t, err)
+		switch key {
+		case "nonce":
+			nonce, err = reader.ReadBytes(nil)
+		case "auth":
+			authSalt, err = reader.ReadBytes(nil)
+		default:
+			err = reader.Skip()
+		}
+		require.NoError(t, err)
+	}
+	require.Len(t, nonce, 16)
+
+	salt := []byte("client-salt")
+	b := msgp.AppendArrayHeader(nil, 6)
+	b = msgp.AppendString(b, "PING")
+	b = msgp.AppendString(b, "client")
+	b = msgp.AppendBytes(b, salt)
+	b = msgp.AppendString(b, hexDigest(salt, []byte("client"), nonce, []byte(sharedKey)))
+	b = msgp.AppendString(b, username)
+	b = msgp.AppendString(b, hexDigest(authSalt, []byte(username), []byte(password)))
+	_, err = conn.Write(b)
+	require.NoError(t, err)
+
+	arrLen, err = reader.ReadArrayHeader()
+	require.NoError(t, err)
+	require.EqualValues(t, 5, arrLen)
+	typ, err = reader.ReadString()
+	require.NoError(t, err)
+	require.Equal(t, "PONG", typ)
+	authenticated, err := reader.ReadBool()
+	require.NoError(t, err)
+	reason, err := reader.ReadString()
+	require.NoError(t, err)
+	hostname, err := reader.ReadString()
+	require.NoError(t, err)
+	digest, err := reader.ReadString()
+	require.NoError(t, err)
+
+	if authenticated {
+		require.Equal(t, "collector", hostname)
+		require.Equal(t, hexDigest(salt, []byte(hostname), nonce, []byte(sharedKey)), digest)
+	}
+	return authenticated, reason
+}
+
+func TestHandshake(t *testing.T) {
+	addr, next := startReceiver(t, &Config{
+		Security: &SecurityConfig{SelfHostname: "collector", SharedKey: "secret"},
+	})
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	authenticated, reason := clientHandshake(t, conn, "secret", "", "")
+	require.True(t, authenticated)
+	require.Empty(t, reason)
+
+	_, err = conn.Write(parseHexDump("testdata/message-event"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(next.AllLogs()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestHandshakeSharedKeyMismatch(t *testing.T) {
+	addr, next := startReceiver(t, &Config{
+		Security: &SecurityConfig{SelfHostname: "collector", SharedKey: "secret"},
+	})
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	authenticated, reason := clientHandshake(t, conn, "wrong", "", "")
+	require.False(t, authenticated)
+	require.Equal(t, "shared_key mismatch", reason)
+
+	waitForConnectionClose(t, conn)
+	require.Empty(t, next.AllLogs())
+}
+
+func TestHandshakeUserAuth(t *testing.T) {
+	addr, _ := startReceiver(t, &Config{
+		Security: &SecurityConfig{
+			SelfHostname: "collector",
+			SharedKey:    "secret",
+			UserAuth:     true,
+			Users:        []UserConfig{{Username: "fluent", Password: "bit"}},
+		},
+	})
+
+	tests := []struct {
+		name          string
+		username      string
+		password      string
+		authenticated bool
+	}{
+		{name: "valid", username: "fluent", password: "bit", authenticated: true},
+		{name: "wrong password", username: "fluent", password: "d"},
+		{name: "unknown user", username: "fluentd", password: "bit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := net.Dial("tcp", addr)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			authenticated, reason := clientHandshake(t, conn, "secret", tt.username, tt.password)
+			require.Equal(t, tt.authenticated, authenticated)
+			if !tt.authenticated {
+				require.Equal(t, "username/password mismatch", reason)
+				waitForConnectionClose(t, conn)
+			}
+		})
+	}
+}
+
+func TestReadStrOrBinLimitsSize(t *testing.T) {
+	b := msgp.AppendString(nil, "client")
+	b = msgp.AppendBytes(b, []byte("salt"))
+	b = msgp.AppendString(b, strings.Repeat("a", maxPingFieldSize+1))
+	reader := msgp.NewReader(bytes.NewReader(b))
+
+	field, err := readStrOrBin(reader)
+	require.NoError(t, err)
+	require.Equal(t, "client", string(field))
+	field, err = readStrOrBin(reader)
+	require.NoError(t, err)
+	require.Equal(t, "salt", string(field))
+	_, err = readStrOrBin(reader)
+	require.EqualError(t, err, "field of 4097 bytes exceeds the limit of 4096 bytes")
+}
+
+func TestTLS(t *testing.T) {
+	addr, next := startReceiver(t, &Config{
+		TLSSetting: &configtls.TLSServerSetting{
+			TLSSetting: configtls.TLSSetting{
+				CertFile: "./testdata/server.crt",
+				KeyFile:  "./testdata/server.key",
+			},
+		},
+		Security: &SecurityConfig{SelfHostname: "collector", SharedKey: "secret"},
+	})
+
+	caCert, err := ioutil.ReadFile("./testdata/ca.crt")
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(caCert))
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{RootCAs: roots, ServerName: "localhost"})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	authenticated, _ := clientHandshake(t, conn, "secret", "", "")
+	require.True(t, authenticated)
+
+	_, err = conn.Write(parseHexDump("testdata/message-event"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(next.AllLogs()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestTLSInvalidCertificate(t *testing.T) {
+	receiver, err := newFluentReceiver(zap.NewNop(), &Config{
+		ListenAddress: "127.0.0.1:0",
+		TLSSetting: &configtls.TLSServerSetting{
+			TLSSetting: configtls.TLSSetting{
+				CertFile: "./testdata/missing.crt",
+				KeyFile:  "./testdata/missing.key",
+			},
+		},
+	}, consumertest.NewNop())
+	require.NoError(t, err)
+	require.Error(t, receiver.Start(context.Background(), nil))
+	require.NoError(t, receiver.Shutdown(context.Background()))
+}
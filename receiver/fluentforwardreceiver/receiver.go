@@ -16,7 +16,9 @@ package fluentforwardreceiver
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
+	"os"
 	"strings"
 
 	"go.opentelemetry.io/collector/component"
@@ -42,7 +44,19 @@ func newFluentReceiver(logger *zap.Logger, conf *Config, next consumer.Logs) (co
 
 	collector := newCollector(eventCh, next, logger)
 
-	server := newServer(eventCh, logger)
+	var auth *authenticator
+	if conf.Security != nil {
+		hostname := conf.Security.SelfHostname
+		if hostname == "" {
+			var err error
+			if hostname, err = os.Hostname(); err != nil {
+				return nil, err
+			}
+		}
+		auth = newAuthenticator(conf.Security, hostname)
+	}
+
+	server := newServer(eventCh, logger, conf.NumWorkers, auth)
 
 	return &fluentReceiver{
 		collector: collector,
@@ -76,6 +90,19 @@ func (r *fluentReceiver) Start(ctx context.Context, _ component.Host) error {
 		return err
 	}
 
+	if r.conf.TLSSetting != nil {
+		var tlsConfig *tls.Config
+		tlsConfig, err = r.conf.TLSSetting.LoadTLSConfig()
+		if err != nil {
+			listener.Close()
+			if udpListener != nil {
+				udpListener.Close()
+			}
+			return err
+		}
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+
 	r.listener = listener
 
 	r.server.Start(receiverCtx, listener)
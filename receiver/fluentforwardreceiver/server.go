@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/tinylib/msgp/msgp"
@@ -34,19 +36,114 @@ import (
 // than this, but this serves as a starting point.
 const readBufferSize = 10 * 1024
 
+// The largest buffer put back into framePool. Buffers grown past it by bigger
+// events are left to the garbage collector, so they aren't held onto.
+const maxPooledFrameSize = 1024 * 1024
+
+// framePool holds the buffers the events are read into off the connections,
+// until a worker decoded them.
+var framePool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, readBufferSize)
+		return &b
+	},
+}
+
+// putFrame returns frame to framePool, unless it grew too big to keep.
+func putFrame(frame *[]byte) {
+	if cap(*frame) <= maxPooledFrameSize {
+		framePool.Put(frame)
+	}
+}
+
 type server struct {
-	outCh  chan<- Event
-	logger *zap.Logger
+	outCh      chan<- Event
+	logger     *zap.Logger
+	numWorkers int
+	// auth runs the handshake on the new connections, if security is enabled.
+	auth *authenticator
+	// decodeChs feed each worker with the events read off the connections
+	// assigned to it.
+	decodeChs []chan decodeJob
+	// nextWorker is the worker the next connection is assigned to.
+	nextWorker uint32
+}
+
+// decodeJob is an event read off a connection, waiting for a worker to decode it.
+type decodeJob struct {
+	mode  EventMode
+	frame *[]byte
+	conn  *connection
+}
+
+// connection is a client connection whose events are decoded by a worker.
+// All the events of a connection go to the same worker, so they reach the
+// pipeline and are acknowledged in the order they were sent.
+type connection struct {
+	net.Conn
+
+	// decodeCh feeds the worker the connection is assigned to.
+	decodeCh chan<- decodeJob
+
+	errLock sync.Mutex
+	// err is the first error decoding or acknowledging the events of the
+	// connection, after which it is closed.
+	err error
+}
+
+// fail records err as the reason the connection is closed.
+func (c *connection) fail(err error) {
+	c.errLock.Lock()
+	defer c.errLock.Unlock()
+	if c.err == nil {
+		c.err = err
+		c.Conn.Close()
+	}
+}
+
+// failure returns the error the connection was closed for, if any.
+func (c *connection) failure() error {
+	c.errLock.Lock()
+	defer c.errLock.Unlock()
+	return c.err
 }
 
-func newServer(outCh chan<- Event, logger *zap.Logger) *server {
+// errOr returns the error the connection was closed for by a worker, if any,
+// rather than the read error it caused.
+func (c *connection) errOr(err error) error {
+	if failure := c.failure(); failure != nil {
+		return failure
+	}
+	return err
+}
+
+// ack acknowledges the chunk of an event.
+func (c *connection) ack(chunk string) error {
+	return msgp.Encode(c.Conn, AckResponse{Ack: chunk})
+}
+
+func newServer(outCh chan<- Event, logger *zap.Logger, numWorkers int, auth *authenticator) *server {
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	decodeChs := make([]chan decodeJob, numWorkers)
+	for i := range decodeChs {
+		decodeChs[i] = make(chan decodeJob, 1)
+	}
 	return &server{
-		outCh:  outCh,
-		logger: logger,
+		outCh:      outCh,
+		logger:     logger,
+		numWorkers: numWorkers,
+		auth:       auth,
+		decodeChs:  decodeChs,
 	}
 }
 
 func (s *server) Start(ctx context.Context, listener net.Listener) {
+	for _, decodeCh := range s.decodeChs {
+		go s.decodeEvents(ctx, decodeCh)
+	}
+
 	go func() {
 		s.handleConnections(ctx, listener)
 		if ctx.Err() == nil {
@@ -75,7 +172,7 @@ func (s *server) handleConnections(ctx context.Context, listener net.Listener) {
 		go func() {
 			defer stats.Record(ctx, observ.ConnectionsClosed.M(1))
 
-			err := s.handleConn(ctx, conn)
+			err := s.handleConn(ctx, s.newConnection(conn))
 			if err != nil {
 				if err == io.EOF {
 					s.logger.Debug("Closing connection", zap.String("remoteAddr", conn.RemoteAddr().String()), zap.Error(err))
@@ -88,50 +185,139 @@ func (s *server) handleConnections(ctx context.Context, listener net.Listener) {
 	}
 }
 
-func (s *server) handleConn(ctx context.Context, conn net.Conn) error {
+// newConnection assigns conn to the next worker, round-robin.
+func (s *server) newConnection(conn net.Conn) *connection {
+	worker := (atomic.AddUint32(&s.nextWorker, 1) - 1) % uint32(s.numWorkers)
+	return &connection{Conn: conn, decodeCh: s.decodeChs[worker]}
+}
+
+// handleConn reads the events off conn and hands them over to its worker,
+// which decodes and acknowledges them.
+func (s *server) handleConn(ctx context.Context, conn *connection) error {
 	reader := msgp.NewReaderSize(conn, readBufferSize)
 
+	if s.auth != nil {
+		if err := s.auth.handshake(conn.Conn, reader); err != nil {
+			return err
+		}
+	}
+
 	for {
 		mode, err := DetermineNextEventMode(reader.R)
 		if err != nil {
-			return err
+			return conn.errOr(err)
 		}
 
-		var event Event
-		switch mode {
-		case UnknownMode:
+		if mode == UnknownMode {
 			return errors.New("could not determine event mode")
-		case MessageMode:
-			event = &MessageEventLogRecord{}
-		case ForwardMode:
-			event = &ForwardEventLogRecords{}
-		case PackedForwardMode:
-			event = &PackedForwardEventLogRecords{}
-		default:
-			panic("programmer bug in mode handling")
 		}
 
-		err = event.DecodeMsg(reader)
+		frame := framePool.Get().(*[]byte)
+		*frame, err = readEvent(reader, mode, (*frame)[:0])
 		if err != nil {
+			putFrame(frame)
 			if err != io.EOF {
 				stats.Record(ctx, observ.FailedToParse.M(1))
 			}
-			return fmt.Errorf("failed to parse %s mode event: %v", mode.String(), err)
+			return conn.errOr(fmt.Errorf("failed to parse %s mode event: %v", mode.String(), err))
 		}
 
-		stats.Record(ctx, observ.EventsParsed.M(1))
+		select {
+		case conn.decodeCh <- decodeJob{mode: mode, frame: frame, conn: conn}:
+		case <-ctx.Done():
+			putFrame(frame)
+			return ctx.Err()
+		}
+	}
+}
 
-		s.outCh <- event
+// readEvent appends the next event of the given mode to frame without decoding
+// it. The length of the event array is checked first, so that malformed events
+// fail right away rather than wait for elements that will never come.
+func readEvent(reader *msgp.Reader, mode EventMode, frame []byte) ([]byte, error) {
+	header, err := reader.R.Peek(1)
+	if err != nil {
+		return frame, err
+	}
+	switch header[0] {
+	case 0xdc:
+		header, err = reader.R.Peek(3)
+	case 0xdd:
+		header, err = reader.R.Peek(5)
+	}
+	if err != nil {
+		return frame, err
+	}
+	arrLen, _, err := msgp.ReadArrayHeaderBytes(header)
+	if err != nil {
+		return frame, err
+	}
 
-		// We must acknowledge the 'chunk' option if given. We could do this in
-		// another goroutine if it is too much of a bottleneck to reading
-		// messages -- this is the only thing that sends data back to the
-		// client.
-		if event.Chunk() != "" {
-			err := msgp.Encode(conn, AckResponse{Ack: event.Chunk()})
-			if err != nil {
-				return fmt.Errorf("failed to acknowledge chunk %s: %v", event.Chunk(), err)
-			}
+	minLen, maxLen := uint32(2), uint32(3)
+	if mode == MessageMode {
+		minLen, maxLen = 3, 4
+	}
+	if arrLen < minLen || arrLen > maxLen {
+		return frame, msgp.ArrayError{Wanted: minLen, Got: arrLen}
+	}
+
+	raw := msgp.Raw(frame)
+	err = raw.DecodeMsg(reader)
+	return raw, err
+}
+
+// decodeEvents decodes the events read off the connections assigned to the
+// worker of decodeCh until ctx is done.
+func (s *server) decodeEvents(ctx context.Context, decodeCh <-chan decodeJob) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-decodeCh:
+			s.decodeEvent(ctx, job)
+			putFrame(job.frame)
+		}
+	}
+}
+
+func (s *server) decodeEvent(ctx context.Context, job decodeJob) {
+	// Events still queued when their connection failed are dropped, as the
+	// client resends those it did not get acknowledgments for.
+	if job.conn.failure() != nil {
+		return
+	}
+
+	var event Event
+	switch job.mode {
+	case MessageMode:
+		event = &MessageEventLogRecord{}
+	case ForwardMode:
+		event = &ForwardEventLogRecords{}
+	case PackedForwardMode:
+		event = &PackedForwardEventLogRecords{}
+	default:
+		panic("programmer bug in mode handling")
+	}
+
+	if _, err := event.UnmarshalMsg(*job.frame); err != nil {
+		stats.Record(ctx, observ.FailedToParse.M(1))
+		job.conn.fail(fmt.Errorf("failed to parse %s mode event: %v", job.mode.String(), err))
+		return
+	}
+
+	stats.Record(ctx, observ.EventsParsed.M(1))
+
+	select {
+	case s.outCh <- event:
+	case <-ctx.Done():
+		return
+	}
+
+	// We must acknowledge the 'chunk' option if given. This is the only thing
+	// that sends data back to the client.
+	if event.Chunk() != "" {
+		if err := job.conn.ack(event.Chunk()); err != nil {
+			job.conn.fail(fmt.Errorf("failed to acknowledge chunk %s: %v", event.Chunk(), err))
 		}
 	}
 }
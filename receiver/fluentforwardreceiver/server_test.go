@@ -17,12 +17,17 @@ package fluentforwardreceiver
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"net"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tinylib/msgp/msgp"
+	"go.uber.org/zap"
 )
 
 func TestDetermineNextEventMode(t *testing.T) {
@@ -125,3 +130,46 @@ func TestDetermineNextEventMode(t *testing.T) {
 		})
 	}
 }
+
+func TestConnectionEventsStayInOrder(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	outCh := make(chan Event)
+	newServer(outCh, zap.NewNop(), 4, nil).Start(ctx, listener)
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	const totalEvents = 100
+	go func() {
+		for i := 0; i < totalEvents; i++ {
+			if _, err := conn.Write(makeSampleEvent(fmt.Sprintf("tag-%d", i))); err != nil {
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < totalEvents; i++ {
+		select {
+		case event := <-outCh:
+			tag, ok := event.LogRecords().At(0).Attributes().Get(tagAttributeKey)
+			require.True(t, ok)
+			require.Equal(t, fmt.Sprintf("tag-%d", i), tag.StringVal())
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for event %d", i)
+		}
+	}
+}
+
+func TestPutFrameDropsLargeFrames(t *testing.T) {
+	frame := make([]byte, 0, maxPooledFrameSize+1)
+	putFrame(&frame)
+	pooled := framePool.Get().(*[]byte)
+	require.LessOrEqual(t, cap(*pooled), maxPooledFrameSize)
+}
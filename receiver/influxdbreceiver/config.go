@@ -23,4 +23,9 @@ import (
 type Config struct {
 	config.ReceiverSettings       `mapstructure:"-"`
 	confighttp.HTTPServerSettings `mapstructure:",squash"`
+
+	// Token, when set, is required to write: InfluxDB 2.x clients pass it in the
+	// "Authorization: Token <token>" header, and InfluxDB 1.x clients as the
+	// password of the basic authentication or of the "p" query parameter.
+	Token string `mapstructure:"token"`
 }
@@ -6,6 +6,7 @@ require (
 	github.com/influxdata/influxdb-observability/common v0.2.4
 	github.com/influxdata/influxdb-observability/influx2otel v0.2.4
 	github.com/influxdata/line-protocol/v2 v2.0.0-20210520103755-6551a972d603
+	github.com/stretchr/testify v1.7.0
 	go.opentelemetry.io/collector v0.31.1-0.20210810171211-8038673eba9e
 	go.opentelemetry.io/collector/model v0.31.1-0.20210810171211-8038673eba9e // indirect
 	go.uber.org/zap v1.19.0
@@ -16,8 +16,10 @@ package influxdbreceiver
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -34,6 +36,7 @@ type metricsReceiver struct {
 	nextConsumer       consumer.Metrics
 	httpServerSettings *confighttp.HTTPServerSettings
 	converter          *influx2otel.LineProtocolToOtelMetrics
+	token              string
 
 	server *http.Server
 	wg     sync.WaitGroup
@@ -50,6 +53,7 @@ func newMetricsReceiver(config *Config, influxLogger common.Logger, nextConsumer
 		nextConsumer:       nextConsumer,
 		httpServerSettings: &config.HTTPServerSettings,
 		converter:          converter,
+		token:              config.Token,
 		logger:             influxLogger,
 	}
 	return receiver, nil
@@ -88,10 +92,33 @@ func (r *metricsReceiver) Shutdown(ctx context.Context) error {
 const defaultPrecision = lineprotocol.Nanosecond
 
 var precisions = map[string]lineprotocol.Precision{
-	lineprotocol.Nanosecond.String():  lineprotocol.Nanosecond,
-	lineprotocol.Microsecond.String(): lineprotocol.Microsecond,
-	lineprotocol.Millisecond.String(): lineprotocol.Millisecond,
-	lineprotocol.Second.String():      lineprotocol.Second,
+	// InfluxDB 2.x
+	"ns": lineprotocol.Nanosecond,
+	"us": lineprotocol.Microsecond,
+	"ms": lineprotocol.Millisecond,
+	"s":  lineprotocol.Second,
+	// InfluxDB 1.x
+	"n": lineprotocol.Nanosecond,
+	"u": lineprotocol.Microsecond,
+}
+
+// authorized checks the credential of the write request against the configured token.
+func (r *metricsReceiver) authorized(req *http.Request) bool {
+	if r.token == "" {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(requestToken(req)), []byte(r.token)) == 1
+}
+
+// requestToken returns the credential passed by InfluxDB 2.x or 1.x clients.
+func requestToken(req *http.Request) string {
+	if auth := req.Header.Get("Authorization"); strings.HasPrefix(auth, "Token ") {
+		return strings.TrimPrefix(auth, "Token ")
+	}
+	if _, password, ok := req.BasicAuth(); ok {
+		return password
+	}
+	return req.URL.Query().Get("p")
 }
 
 func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request) {
@@ -99,6 +126,12 @@ func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request)
 		_ = req.Body.Close()
 	}()
 
+	if !r.authorized(req) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = fmt.Fprint(w, "unauthorized access")
+		return
+	}
+
 	precision := defaultPrecision
 	if precisionStr := req.URL.Query().Get("precision"); precisionStr != "" {
 		var ok bool
@@ -0,0 +1,194 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbreceiver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+const testToken = "my-token"
+
+func newTestReceiver(t *testing.T, token string, nextConsumer consumer.Metrics) *metricsReceiver {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Token = token
+	r, err := newMetricsReceiver(cfg, newZapInfluxLogger(zap.NewNop()), nextConsumer)
+	require.NoError(t, err)
+	return r
+}
+
+func write(r *metricsReceiver, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r.handleWrite(w, req)
+	return w
+}
+
+func newWriteRequest(target string, body string) *http.Request {
+	return httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+}
+
+func TestWritePrecision(t *testing.T) {
+	ts := time.Unix(1622848686, 0)
+	tests := []struct {
+		precision string
+		timestamp string
+	}{
+		{precision: "", timestamp: "1622848686000000000"},
+		{precision: "ns", timestamp: "1622848686000000000"},
+		{precision: "n", timestamp: "1622848686000000000"},
+		{precision: "us", timestamp: "1622848686000000"},
+		{precision: "u", timestamp: "1622848686000000"},
+		{precision: "ms", timestamp: "1622848686000"},
+		{precision: "s", timestamp: "1622848686"},
+	}
+	for _, tt := range tests {
+		t.Run("precision_"+tt.precision, func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			r := newTestReceiver(t, "", sink)
+
+			target := "/api/v2/write"
+			if tt.precision != "" {
+				target += "?precision=" + tt.precision
+			}
+			w := write(r, newWriteRequest(target, "cpu_temp,foo=bar gauge=87.332 "+tt.timestamp))
+			assert.Equal(t, http.StatusAccepted, w.Code)
+
+			require.Len(t, sink.AllMetrics(), 1)
+			md := sink.AllMetrics()[0]
+			require.Equal(t, 1, md.DataPointCount())
+			metric := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+			require.Equal(t, pdata.MetricDataTypeGauge, metric.DataType())
+			assert.Equal(t, pdata.TimestampFromTime(ts), metric.Gauge().DataPoints().At(0).Timestamp())
+		})
+	}
+}
+
+func TestWriteInvalidPrecision(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	r := newTestReceiver(t, "", sink)
+
+	w := write(r, newWriteRequest("/write?precision=h", "cpu_temp,foo=bar gauge=87.332 1"))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "unrecognized precision 'h'", w.Body.String())
+	assert.Empty(t, sink.AllMetrics())
+}
+
+func TestWriteAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		req        func() *http.Request
+		wantStatus int
+	}{
+		{
+			name:  "no_token_configured",
+			token: "",
+			req: func() *http.Request {
+				return newWriteRequest("/api/v2/write", "cpu_temp gauge=1 1622848686000000000")
+			},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:  "v2_token",
+			token: testToken,
+			req: func() *http.Request {
+				req := newWriteRequest("/api/v2/write", "cpu_temp gauge=1 1622848686000000000")
+				req.Header.Set("Authorization", "Token "+testToken)
+				return req
+			},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:  "v1_basic_auth",
+			token: testToken,
+			req: func() *http.Request {
+				req := newWriteRequest("/write?db=telegraf", "cpu_temp gauge=1 1622848686000000000")
+				req.SetBasicAuth("telegraf", testToken)
+				return req
+			},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:  "v1_query_parameters",
+			token: testToken,
+			req: func() *http.Request {
+				return newWriteRequest("/write?db=telegraf&u=telegraf&p="+testToken, "cpu_temp gauge=1 1622848686000000000")
+			},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:  "missing_token",
+			token: testToken,
+			req: func() *http.Request {
+				return newWriteRequest("/api/v2/write", "cpu_temp gauge=1 1622848686000000000")
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "wrong_token",
+			token: testToken,
+			req: func() *http.Request {
+				req := newWriteRequest("/api/v2/write", "cpu_temp gauge=1 1622848686000000000")
+				req.Header.Set("Authorization", "Token wrong")
+				return req
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			r := newTestReceiver(t, tt.token, sink)
+
+			w := write(r, tt.req())
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusAccepted {
+				assert.Equal(t, 1, sink.DataPointCount())
+			} else {
+				assert.Empty(t, sink.AllMetrics())
+			}
+		})
+	}
+}
+
+func TestWriteConsumerErrors(t *testing.T) {
+	tests := []struct {
+		err        error
+		wantStatus int
+	}{
+		{err: errors.New("temporary"), wantStatus: http.StatusInternalServerError},
+		{err: consumererror.Permanent(errors.New("permanent")), wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			r := newTestReceiver(t, "", consumertest.NewErr(tt.err))
+
+			w := write(r, newWriteRequest("/api/v2/write", "cpu_temp gauge=1 1622848686000000000"))
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
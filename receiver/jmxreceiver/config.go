@@ -34,6 +34,8 @@ type Config struct {
 	TargetSystem string `mapstructure:"target_system"`
 	// The script for the metric gatherer to run on the configured interval.  Cannot be set with TargetSystem.
 	GroovyScript string `mapstructure:"groovy_script"`
+	// The MBean to metric mappings to compile into a generated groovy script.  Cannot be set with TargetSystem or GroovyScript.
+	MBeans []MBeanConfig `mapstructure:"mbeans"`
 	// The duration in between groovy script invocations and metric exports (10 seconds by default).
 	// Will be converted to milliseconds.
 	CollectionInterval time.Duration `mapstructure:"collection_interval"`
@@ -106,8 +108,8 @@ func (c *Config) validate() error {
 	if c.Endpoint == "" {
 		missingFields = append(missingFields, "`endpoint`")
 	}
-	if c.TargetSystem == "" && c.GroovyScript == "" {
-		missingFields = append(missingFields, "`target_system` or `groovy_script`")
+	if c.TargetSystem == "" && c.GroovyScript == "" && len(c.MBeans) == 0 {
+		missingFields = append(missingFields, "`target_system`, `groovy_script` or `mbeans`")
 	}
 	if missingFields != nil {
 		baseMsg := fmt.Sprintf("%v missing required field", c.ID())
@@ -117,6 +119,17 @@ func (c *Config) validate() error {
 		return fmt.Errorf("%v: %v", baseMsg, strings.Join(missingFields, ", "))
 	}
 
+	if len(c.MBeans) > 0 {
+		if c.TargetSystem != "" || c.GroovyScript != "" {
+			return fmt.Errorf("%v `mbeans` cannot be set with `target_system` or `groovy_script`", c.ID())
+		}
+		for _, mbean := range c.MBeans {
+			if err := mbean.validate(); err != nil {
+				return fmt.Errorf("%v invalid `mbeans`: %w", c.ID(), err)
+			}
+		}
+	}
+
 	if c.CollectionInterval < 0 {
 		return fmt.Errorf("%v `interval` must be positive: %vms", c.ID(), c.CollectionInterval.Milliseconds())
 	}
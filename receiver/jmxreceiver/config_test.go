@@ -39,14 +39,14 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 6)
+	assert.Equal(t, len(cfg.Receivers), 8)
 
 	r0 := cfg.Receivers[config.NewID(typeStr)].(*Config)
 	require.NoError(t, configcheck.ValidateConfig(r0))
 	assert.Equal(t, r0, factory.CreateDefaultConfig())
 	err = r0.validate()
 	require.Error(t, err)
-	assert.Equal(t, "jmx missing required fields: `endpoint`, `target_system` or `groovy_script`", err.Error())
+	assert.Equal(t, "jmx missing required fields: `endpoint`, `target_system`, `groovy_script` or `mbeans`", err.Error())
 
 	r1 := cfg.Receivers[config.NewIDWithName(typeStr, "all")].(*Config)
 	require.NoError(t, configcheck.ValidateConfig(r1))
@@ -124,7 +124,7 @@ func TestLoadConfig(t *testing.T) {
 		}, r3)
 	err = r3.validate()
 	require.Error(t, err)
-	assert.Equal(t, "jmx/missinggroovy missing required field: `target_system` or `groovy_script`", err.Error())
+	assert.Equal(t, "jmx/missinggroovy missing required field: `target_system`, `groovy_script` or `mbeans`", err.Error())
 
 	r4 := cfg.Receivers[config.NewIDWithName(typeStr, "invalidinterval")].(*Config)
 	require.NoError(t, configcheck.ValidateConfig(r4))
@@ -165,4 +165,31 @@ func TestLoadConfig(t *testing.T) {
 	err = r5.validate()
 	require.Error(t, err)
 	assert.Equal(t, "jmx/invalidotlptimeout `otlp.timeout` must be positive: -100ms", err.Error())
+
+	r6 := cfg.Receivers[config.NewIDWithName(typeStr, "mbeans")].(*Config)
+	require.NoError(t, configcheck.ValidateConfig(r6))
+	require.NoError(t, r6.validate())
+	assert.Equal(t,
+		[]MBeanConfig{
+			{
+				ObjectName: "com.example:type=Cache,name=*",
+				Metrics: []MBeanMetricConfig{
+					{
+						Name:        "example.cache.size",
+						Description: "The number of entries in the cache",
+						Unit:        "{entries}",
+						Type:        "gauge",
+						ValueType:   "long",
+						Attribute:   "Size",
+						LabelKeys:   []string{"name"},
+					},
+				},
+			},
+		}, r6.MBeans)
+
+	r7 := cfg.Receivers[config.NewIDWithName(typeStr, "invalidmbeans")].(*Config)
+	require.NoError(t, configcheck.ValidateConfig(r7))
+	err = r7.validate()
+	require.Error(t, err)
+	assert.Equal(t, "jmx/invalidmbeans `mbeans` cannot be set with `target_system` or `groovy_script`", err.Error())
 }
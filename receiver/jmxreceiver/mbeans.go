@@ -0,0 +1,153 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jmxreceiver
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	mbeanMetricTypeGauge         = "gauge"
+	mbeanMetricTypeCounter       = "counter"
+	mbeanMetricTypeUpDownCounter = "updowncounter"
+
+	mbeanValueTypeDouble = "double"
+	mbeanValueTypeLong   = "long"
+)
+
+// otel helper instrument callbacks keyed by metric type then value type.
+var mbeanInstruments = map[string]map[string]string{
+	mbeanMetricTypeGauge: {
+		mbeanValueTypeDouble: "doubleValueCallback",
+		mbeanValueTypeLong:   "longValueCallback",
+	},
+	mbeanMetricTypeCounter: {
+		mbeanValueTypeDouble: "doubleCounterCallback",
+		mbeanValueTypeLong:   "longCounterCallback",
+	},
+	mbeanMetricTypeUpDownCounter: {
+		mbeanValueTypeDouble: "doubleUpDownCounterCallback",
+		mbeanValueTypeLong:   "longUpDownCounterCallback",
+	},
+}
+
+// MBeanConfig maps the attributes of the MBeans matching an object name pattern to metrics.
+type MBeanConfig struct {
+	// The MBean object name or object name pattern to query (e.g. `com.example:type=Cache,name=*`).
+	ObjectName string `mapstructure:"object_name"`
+	// The metrics to report from the matched MBeans' attributes.
+	Metrics []MBeanMetricConfig `mapstructure:"metrics"`
+}
+
+// MBeanMetricConfig maps a single MBean attribute to a metric.
+type MBeanMetricConfig struct {
+	// The metric name.
+	Name string `mapstructure:"name"`
+	// The metric description.
+	Description string `mapstructure:"description"`
+	// The metric unit.
+	Unit string `mapstructure:"unit"`
+	// The instrument to report with.  One of `gauge`, `counter`, or `updowncounter`.
+	Type string `mapstructure:"type"`
+	// The instrument value type.  One of `double` (default) or `long`.
+	ValueType string `mapstructure:"value_type"`
+	// The MBean attribute whose value is reported.
+	Attribute string `mapstructure:"attribute"`
+	// The object name key properties whose values are added as metric labels.
+	LabelKeys []string `mapstructure:"label_keys"`
+}
+
+func (mc MBeanConfig) validate() error {
+	if mc.ObjectName == "" {
+		return fmt.Errorf("missing required field `object_name`")
+	}
+	if len(mc.Metrics) == 0 {
+		return fmt.Errorf("%q missing required field `metrics`", mc.ObjectName)
+	}
+	for _, metric := range mc.Metrics {
+		if metric.Name == "" {
+			return fmt.Errorf("%q metric missing required field `name`", mc.ObjectName)
+		}
+		if metric.Attribute == "" {
+			return fmt.Errorf("%q metric %q missing required field `attribute`", mc.ObjectName, metric.Name)
+		}
+		if _, err := metric.instrument(); err != nil {
+			return fmt.Errorf("%q metric %q %w", mc.ObjectName, metric.Name, err)
+		}
+	}
+	return nil
+}
+
+func (mmc MBeanMetricConfig) instrument() (string, error) {
+	byValueType, ok := mbeanInstruments[mmc.Type]
+	if !ok {
+		return "", fmt.Errorf("`type` must be one of %q, %q, or %q: %q",
+			mbeanMetricTypeGauge, mbeanMetricTypeCounter, mbeanMetricTypeUpDownCounter, mmc.Type)
+	}
+	valueType := mmc.ValueType
+	if valueType == "" {
+		valueType = mbeanValueTypeDouble
+	}
+	instrument, ok := byValueType[valueType]
+	if !ok {
+		return "", fmt.Errorf("`value_type` must be one of %q or %q: %q",
+			mbeanValueTypeDouble, mbeanValueTypeLong, mmc.ValueType)
+	}
+	return instrument, nil
+}
+
+// buildMBeansGroovyScript renders the configured MBean mappings as a Groovy script
+// using the JMX Metric Gatherer's `otel` helper.
+func buildMBeansGroovyScript(mbeans []MBeanConfig) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("// Generated by the OpenTelemetry Collector jmx receiver from its `mbeans` config.\n")
+	for i, mbean := range mbeans {
+		helper := fmt.Sprintf("mbeans%d", i)
+		fmt.Fprintf(&sb, "\ndef %s = otel.mbeans(%s)\n", helper, groovyString(mbean.ObjectName))
+		for _, metric := range mbean.Metrics {
+			instrument, err := metric.instrument()
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&sb, "otel.instrument(%s, %s, %s, %s, %s, %s, otel.&%s)\n",
+				helper, groovyString(metric.Name), groovyString(metric.Description), groovyString(metric.Unit),
+				groovyLabelFuncs(metric.LabelKeys), groovyString(metric.Attribute), instrument)
+		}
+	}
+	return sb.String(), nil
+}
+
+// groovyLabelFuncs renders a map of label names to closures reading the
+// matching key property from each MBean's object name.
+func groovyLabelFuncs(labelKeys []string) string {
+	if len(labelKeys) == 0 {
+		return "[:]"
+	}
+	funcs := make([]string, 0, len(labelKeys))
+	for _, key := range labelKeys {
+		quoted := groovyString(key)
+		funcs = append(funcs, fmt.Sprintf("%s: { mbean -> mbean.name().getKeyProperty(%s) }", quoted, quoted))
+	}
+	return "[" + strings.Join(funcs, ", ") + "]"
+}
+
+// groovyString quotes s as a single quoted (non-interpolated) Groovy string.
+func groovyString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return "'" + s + "'"
+}
@@ -0,0 +1,138 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jmxreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMBeansGroovyScript(t *testing.T) {
+	script, err := buildMBeansGroovyScript([]MBeanConfig{
+		{
+			ObjectName: "com.example:type=Cache,name=*",
+			Metrics: []MBeanMetricConfig{
+				{
+					Name:        "example.cache.size",
+					Description: "The cache's entry count",
+					Unit:        "{entries}",
+					Type:        "gauge",
+					ValueType:   "long",
+					Attribute:   "Size",
+					LabelKeys:   []string{"name"},
+				},
+				{
+					Name:      "example.cache.hits",
+					Unit:      "1",
+					Type:      "counter",
+					Attribute: "Hits",
+				},
+			},
+		},
+		{
+			ObjectName: "com.example:type=Pool",
+			Metrics: []MBeanMetricConfig{
+				{
+					Name:      "example.pool.active",
+					Type:      "updowncounter",
+					ValueType: "long",
+					Attribute: "Active",
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `// Generated by the OpenTelemetry Collector jmx receiver from its `+"`mbeans`"+` config.
+
+def mbeans0 = otel.mbeans('com.example:type=Cache,name=*')
+otel.instrument(mbeans0, 'example.cache.size', 'The cache\'s entry count', '{entries}', ['name': { mbean -> mbean.name().getKeyProperty('name') }], 'Size', otel.&longValueCallback)
+otel.instrument(mbeans0, 'example.cache.hits', '', '1', [:], 'Hits', otel.&doubleCounterCallback)
+
+def mbeans1 = otel.mbeans('com.example:type=Pool')
+otel.instrument(mbeans1, 'example.pool.active', '', '', [:], 'Active', otel.&longUpDownCounterCallback)
+`, script)
+}
+
+func TestMBeanConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      MBeanConfig
+		expectedErr string
+	}{
+		{
+			"valid",
+			MBeanConfig{
+				ObjectName: "com.example:type=Cache",
+				Metrics:    []MBeanMetricConfig{{Name: "example.cache.size", Type: "gauge", Attribute: "Size"}},
+			},
+			"",
+		},
+		{
+			"missing object name",
+			MBeanConfig{Metrics: []MBeanMetricConfig{{Name: "example.cache.size", Type: "gauge", Attribute: "Size"}}},
+			"missing required field `object_name`",
+		},
+		{
+			"missing metrics",
+			MBeanConfig{ObjectName: "com.example:type=Cache"},
+			`"com.example:type=Cache" missing required field ` + "`metrics`",
+		},
+		{
+			"missing metric name",
+			MBeanConfig{
+				ObjectName: "com.example:type=Cache",
+				Metrics:    []MBeanMetricConfig{{Type: "gauge", Attribute: "Size"}},
+			},
+			`"com.example:type=Cache" metric missing required field ` + "`name`",
+		},
+		{
+			"missing attribute",
+			MBeanConfig{
+				ObjectName: "com.example:type=Cache",
+				Metrics:    []MBeanMetricConfig{{Name: "example.cache.size", Type: "gauge"}},
+			},
+			`"com.example:type=Cache" metric "example.cache.size" missing required field ` + "`attribute`",
+		},
+		{
+			"invalid type",
+			MBeanConfig{
+				ObjectName: "com.example:type=Cache",
+				Metrics:    []MBeanMetricConfig{{Name: "example.cache.size", Type: "histogram", Attribute: "Size"}},
+			},
+			`"com.example:type=Cache" metric "example.cache.size" ` + "`type`" + ` must be one of "gauge", "counter", or "updowncounter": "histogram"`,
+		},
+		{
+			"invalid value type",
+			MBeanConfig{
+				ObjectName: "com.example:type=Cache",
+				Metrics:    []MBeanMetricConfig{{Name: "example.cache.size", Type: "gauge", ValueType: "int", Attribute: "Size"}},
+			},
+			`"com.example:type=Cache" metric "example.cache.size" ` + "`value_type`" + ` must be one of "double" or "long": "int"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.validate()
+			if test.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+}
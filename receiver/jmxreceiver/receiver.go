@@ -17,8 +17,10 @@ package jmxreceiver
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
 
@@ -40,6 +42,8 @@ type jmxMetricReceiver struct {
 	params       component.ReceiverCreateSettings
 	otlpReceiver component.MetricsReceiver
 	nextConsumer consumer.Metrics
+	// The path of the groovy script generated from config.MBeans, if any.
+	mbeansScriptPath string
 }
 
 func newJMXMetricReceiver(
@@ -63,6 +67,20 @@ func (jmx *jmxMetricReceiver) Start(ctx context.Context, host component.Host) (e
 		return err
 	}
 
+	if len(jmx.config.MBeans) > 0 {
+		jmx.mbeansScriptPath, err = jmx.writeMBeansGroovyScript()
+		if err != nil {
+			return err
+		}
+		// The script is removed on shutdown once started, and right away if starting fails.
+		defer func() {
+			if err != nil {
+				os.Remove(jmx.mbeansScriptPath)
+				jmx.mbeansScriptPath = ""
+			}
+		}()
+	}
+
 	javaConfig, err := jmx.buildJMXMetricGathererConfig()
 	if err != nil {
 		return err
@@ -94,10 +112,36 @@ func (jmx *jmxMetricReceiver) Shutdown(ctx context.Context) error {
 	jmx.logger.Debug("Shutting down JMX Receiver")
 	subprocessErr := jmx.subprocess.Shutdown(ctx)
 	otlpErr := jmx.otlpReceiver.Shutdown(ctx)
+	var scriptErr error
+	if jmx.mbeansScriptPath != "" {
+		scriptErr = os.Remove(jmx.mbeansScriptPath)
+	}
 	if subprocessErr != nil {
 		return subprocessErr
 	}
-	return otlpErr
+	if otlpErr != nil {
+		return otlpErr
+	}
+	return scriptErr
+}
+
+// writeMBeansGroovyScript renders config.MBeans to a temporary groovy script
+// for the metric gatherer to run, returning its path.
+func (jmx *jmxMetricReceiver) writeMBeansGroovyScript() (string, error) {
+	script, err := buildMBeansGroovyScript(jmx.config.MBeans)
+	if err != nil {
+		return "", fmt.Errorf("failed to build mbeans groovy script: %w", err)
+	}
+	file, err := ioutil.TempFile("", "otelcol-jmx-*.groovy")
+	if err != nil {
+		return "", fmt.Errorf("failed to create mbeans groovy script: %w", err)
+	}
+	defer file.Close()
+	if _, err = file.WriteString(script); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write mbeans groovy script: %w", err)
+	}
+	return file.Name(), nil
 }
 
 func (jmx *jmxMetricReceiver) buildOTLPReceiver() (component.MetricsReceiver, error) {
@@ -157,6 +201,8 @@ otel.jmx.interval.milliseconds = %v
 		javaConfig += fmt.Sprintf("otel.jmx.target.system = %v\n", jmx.config.TargetSystem)
 	} else if jmx.config.GroovyScript != "" {
 		javaConfig += fmt.Sprintf("otel.jmx.groovy.script = %v\n", jmx.config.GroovyScript)
+	} else if jmx.mbeansScriptPath != "" {
+		javaConfig += fmt.Sprintf("otel.jmx.groovy.script = %v\n", jmx.mbeansScriptPath)
 	}
 
 	endpoint := jmx.config.OTLPExporterConfig.Endpoint
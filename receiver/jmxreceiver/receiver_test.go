@@ -17,6 +17,9 @@ package jmxreceiver
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -188,6 +191,75 @@ otel.exporter.otlp.headers = one=two,three=four
 	}
 }
 
+func TestBuildJMXMetricGathererConfigWithMBeans(t *testing.T) {
+	params := componenttest.NewNopReceiverCreateSettings()
+	config := &Config{
+		Endpoint:           "service:jmx:rmi///jndi/rmi://myservice:12345/jmxrmi/",
+		CollectionInterval: 123 * time.Second,
+		MBeans: []MBeanConfig{
+			{
+				ObjectName: "com.example:type=Cache",
+				Metrics:    []MBeanMetricConfig{{Name: "example.cache.size", Type: "gauge", Attribute: "Size"}},
+			},
+		},
+		OTLPExporterConfig: otlpExporterConfig{
+			Endpoint: "myotlpendpoint",
+			TimeoutSettings: exporterhelper.TimeoutSettings{
+				Timeout: 234 * time.Second,
+			},
+		},
+	}
+	receiver := newJMXMetricReceiver(params, config, consumertest.NewNop())
+
+	scriptPath, err := receiver.writeMBeansGroovyScript()
+	require.NoError(t, err)
+	defer os.Remove(scriptPath)
+	receiver.mbeansScriptPath = scriptPath
+
+	script, err := ioutil.ReadFile(scriptPath)
+	require.NoError(t, err)
+	expectedScript, err := buildMBeansGroovyScript(config.MBeans)
+	require.NoError(t, err)
+	require.Equal(t, expectedScript, string(script))
+
+	jmxConfig, err := receiver.buildJMXMetricGathererConfig()
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf(`otel.jmx.service.url = service:jmx:rmi///jndi/rmi://myservice:12345/jmxrmi/
+otel.jmx.interval.milliseconds = 123000
+otel.jmx.groovy.script = %s
+otel.metrics.exporter = otlp
+otel.exporter.otlp.endpoint = http://myotlpendpoint
+otel.exporter.otlp.timeout = 234000
+`, scriptPath), jmxConfig)
+}
+
+func TestStartRemovesMBeansScriptOnError(t *testing.T) {
+	params := componenttest.NewNopReceiverCreateSettings()
+	config := &Config{
+		Endpoint: "myservice",
+		MBeans: []MBeanConfig{
+			{
+				ObjectName: "com.example:type=Cache",
+				Metrics:    []MBeanMetricConfig{{Name: "example.cache.size", Type: "gauge", Attribute: "Size"}},
+			},
+		},
+		OTLPExporterConfig: otlpExporterConfig{
+			Endpoint: fmt.Sprintf("localhost:%d", testutil.GetAvailablePort(t)),
+		},
+	}
+	scriptsPattern := filepath.Join(os.TempDir(), "otelcol-jmx-*.groovy")
+	scriptsBefore, err := filepath.Glob(scriptsPattern)
+	require.NoError(t, err)
+
+	receiver := newJMXMetricReceiver(params, config, consumertest.NewNop())
+	require.Error(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	require.Empty(t, receiver.mbeansScriptPath)
+
+	scriptsAfter, err := filepath.Glob(scriptsPattern)
+	require.NoError(t, err)
+	require.ElementsMatch(t, scriptsBefore, scriptsAfter)
+}
+
 func TestBuildOTLPReceiverInvalidEndpoints(t *testing.T) {
 	tests := []struct {
 		name        string
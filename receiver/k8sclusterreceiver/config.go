@@ -17,8 +17,10 @@ package k8sclusterreceiver
 import (
 	"time"
 
+	configclientset "github.com/openshift/client-go/config/clientset/versioned"
 	quotaclientset "github.com/openshift/client-go/quota/clientset/versioned"
 	"go.opentelemetry.io/collector/config"
+	"k8s.io/client-go/dynamic"
 	k8s "k8s.io/client-go/kubernetes"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -41,13 +43,29 @@ type Config struct {
 	// Whether OpenShift supprot should be enabled or not.
 	Distribution string `mapstructure:"distribution"`
 
+	// LeaderElection allows running several replicas of the receiver with only one emitting data.
+	LeaderElection k8sconfig.LeaderElectionConfig `mapstructure:"leader_election"`
+
+	// CustomResources lists the custom resources whose fields are reported as gauges.
+	CustomResources []CustomResourceConfig `mapstructure:"custom_resources"`
+
 	// For mocking.
-	makeClient               func(apiConf k8sconfig.APIConfig) (k8s.Interface, error)
-	makeOpenShiftQuotaClient func(apiConf k8sconfig.APIConfig) (quotaclientset.Interface, error)
+	makeClient                func(apiConf k8sconfig.APIConfig) (k8s.Interface, error)
+	makeOpenShiftQuotaClient  func(apiConf k8sconfig.APIConfig) (quotaclientset.Interface, error)
+	makeOpenShiftConfigClient func(apiConf k8sconfig.APIConfig) (configclientset.Interface, error)
+	makeDynamicClient         func(apiConf k8sconfig.APIConfig) (dynamic.Interface, error)
 }
 
 func (cfg *Config) Validate() error {
-	return cfg.APIConfig.Validate()
+	if err := cfg.APIConfig.Validate(); err != nil {
+		return err
+	}
+	for _, cr := range cfg.CustomResources {
+		if err := cr.validate(); err != nil {
+			return err
+		}
+	}
+	return cfg.LeaderElection.Validate()
 }
 
 func (cfg *Config) getK8sClient() (k8s.Interface, error) {
@@ -63,3 +81,17 @@ func (cfg *Config) getOpenShiftQuotaClient() (quotaclientset.Interface, error) {
 	}
 	return cfg.makeOpenShiftQuotaClient(cfg.APIConfig)
 }
+
+func (cfg *Config) getOpenShiftConfigClient() (configclientset.Interface, error) {
+	if cfg.makeOpenShiftConfigClient == nil {
+		cfg.makeOpenShiftConfigClient = k8sconfig.MakeOpenShiftConfigClient
+	}
+	return cfg.makeOpenShiftConfigClient(cfg.APIConfig)
+}
+
+func (cfg *Config) getDynamicClient() (dynamic.Interface, error) {
+	if cfg.makeDynamicClient == nil {
+		cfg.makeDynamicClient = k8sconfig.MakeDynamicClient
+	}
+	return cfg.makeDynamicClient(cfg.APIConfig)
+}
@@ -40,7 +40,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 3)
+	assert.Equal(t, len(cfg.Receivers), 5)
 
 	r1 := cfg.Receivers[config.NewID(typeStr)]
 	assert.Equal(t, r1, factory.CreateDefaultConfig())
@@ -56,6 +56,7 @@ func TestLoadConfig(t *testing.T) {
 			APIConfig: k8sconfig.APIConfig{
 				AuthType: k8sconfig.AuthTypeServiceAccount,
 			},
+			LeaderElection: defaultLeaderElectionConfig(),
 		})
 
 	r3 := cfg.Receivers[config.NewIDWithName(typeStr, "partial_settings")].(*Config)
@@ -68,5 +69,95 @@ func TestLoadConfig(t *testing.T) {
 			APIConfig: k8sconfig.APIConfig{
 				AuthType: k8sconfig.AuthTypeServiceAccount,
 			},
+			LeaderElection: defaultLeaderElectionConfig(),
 		})
+
+	r4 := cfg.Receivers[config.NewIDWithName(typeStr, "leader_election")].(*Config)
+	assert.Equal(t, r4,
+		&Config{
+			ReceiverSettings:           config.NewReceiverSettings(config.NewIDWithName(typeStr, "leader_election")),
+			Distribution:               distributionKubernetes,
+			CollectionInterval:         10 * time.Second,
+			NodeConditionTypesToReport: []string{"Ready"},
+			APIConfig: k8sconfig.APIConfig{
+				AuthType: k8sconfig.AuthTypeServiceAccount,
+			},
+			LeaderElection: k8sconfig.LeaderElectionConfig{
+				Enabled:        true,
+				LeaseName:      defaultLeaseName,
+				LeaseNamespace: "observability",
+				LeaseDuration:  30 * time.Second,
+				RenewDeadline:  20 * time.Second,
+				RetryPeriod:    k8sconfig.DefaultRetryPeriod,
+			},
+		})
+
+	r5 := cfg.Receivers[config.NewIDWithName(typeStr, "custom_resources")].(*Config)
+	assert.Equal(t, r5,
+		&Config{
+			ReceiverSettings:           config.NewReceiverSettings(config.NewIDWithName(typeStr, "custom_resources")),
+			Distribution:               distributionKubernetes,
+			CollectionInterval:         10 * time.Second,
+			NodeConditionTypesToReport: []string{"Ready"},
+			APIConfig: k8sconfig.APIConfig{
+				AuthType: k8sconfig.AuthTypeServiceAccount,
+			},
+			LeaderElection: defaultLeaderElectionConfig(),
+			CustomResources: []CustomResourceConfig{
+				{
+					Group:    "cert-manager.io",
+					Version:  "v1",
+					Resource: "certificates",
+					Metrics: []CustomResourceMetricConfig{
+						{
+							Name:        "certmanager.certificate.revision",
+							Description: "The revision of the certificate.",
+							Field:       "status.revision",
+						},
+					},
+				},
+			},
+		})
+}
+
+func TestInvalidCustomResourcesConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		cr      CustomResourceConfig
+		wantErr string
+	}{
+		{
+			name:    "missing resource",
+			cr:      CustomResourceConfig{Version: "v1"},
+			wantErr: "custom_resources: version and resource must be set",
+		},
+		{
+			name:    "no metrics",
+			cr:      CustomResourceConfig{Group: "cert-manager.io", Version: "v1", Resource: "certificates"},
+			wantErr: "custom_resources: cert-manager.io/v1, Resource=certificates has no metrics",
+		},
+		{
+			name: "metric without field",
+			cr: CustomResourceConfig{Group: "cert-manager.io", Version: "v1", Resource: "certificates",
+				Metrics: []CustomResourceMetricConfig{{Name: "certmanager.certificate.revision"}}},
+			wantErr: "custom_resources: cert-manager.io/v1, Resource=certificates metrics must have a name and a field",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.CustomResources = []CustomResourceConfig{tt.cr}
+			assert.EqualError(t, cfg.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestInvalidLeaderElectionConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.LeaderElection.Enabled = true
+	cfg.LeaderElection.RenewDeadline = cfg.LeaderElection.LeaseDuration
+	assert.EqualError(t, cfg.Validate(), "leader_election: lease_duration must be greater than renew_deadline")
+}
+
+func defaultLeaderElectionConfig() k8sconfig.LeaderElectionConfig {
+	return createDefaultConfig().(*Config).LeaderElection
 }
@@ -0,0 +1,124 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sclusterreceiver
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/collection"
+)
+
+// CustomResourceConfig maps the fields of the custom resources of a type to gauges.
+type CustomResourceConfig struct {
+	// Group of the custom resource definition, e.g. `cert-manager.io`.
+	Group string `mapstructure:"group"`
+	// Version of the custom resources to watch, e.g. `v1`.
+	Version string `mapstructure:"version"`
+	// Resource is the plural name of the custom resources, e.g. `certificates`.
+	Resource string `mapstructure:"resource"`
+	// Metrics reported for each custom resource.
+	Metrics []CustomResourceMetricConfig `mapstructure:"metrics"`
+}
+
+// CustomResourceMetricConfig maps a field of custom resources to a gauge.
+type CustomResourceMetricConfig struct {
+	// Name of the metric.
+	Name string `mapstructure:"name"`
+	// Description of the metric.
+	Description string `mapstructure:"description"`
+	// Unit of the metric.
+	Unit string `mapstructure:"unit"`
+	// Field is the dot separated path to a numeric or boolean field of the
+	// resources, e.g. `status.readyReplicas`. Booleans are reported as 1 or 0.
+	Field string `mapstructure:"field"`
+}
+
+func (cfg CustomResourceConfig) validate() error {
+	if cfg.Version == "" || cfg.Resource == "" {
+		return errors.New("custom_resources: version and resource must be set")
+	}
+	if len(cfg.Metrics) == 0 {
+		return fmt.Errorf("custom_resources: %s has no metrics", cfg.groupVersionResource())
+	}
+	for _, m := range cfg.Metrics {
+		if m.Name == "" || m.Field == "" {
+			return fmt.Errorf("custom_resources: %s metrics must have a name and a field", cfg.groupVersionResource())
+		}
+	}
+	return nil
+}
+
+func (cfg CustomResourceConfig) groupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: cfg.Group, Version: cfg.Version, Resource: cfg.Resource}
+}
+
+func (cfg CustomResourceConfig) metrics() []collection.CustomResourceMetric {
+	metrics := make([]collection.CustomResourceMetric, 0, len(cfg.Metrics))
+	for _, m := range cfg.Metrics {
+		description := m.Description
+		if description == "" {
+			description = fmt.Sprintf("The %s field of the %s", m.Field, cfg.groupVersionResource().GroupResource())
+		}
+		metrics = append(metrics, collection.CustomResourceMetric{
+			Name:        m.Name,
+			Description: description,
+			Unit:        m.Unit,
+			Field:       strings.Split(m.Field, "."),
+		})
+	}
+	return metrics
+}
+
+// dynamicInformerFactory adapts the informer factory of custom resources to sharedInformer.
+type dynamicInformerFactory struct {
+	dynamicinformer.DynamicSharedInformerFactory
+}
+
+func (f dynamicInformerFactory) WaitForCacheSync(stopCh <-chan struct{}) map[reflect.Type]bool {
+	f.DynamicSharedInformerFactory.WaitForCacheSync(stopCh)
+	return nil
+}
+
+// setupCustomResourceInformers adds the informers of the configured custom resources.
+func (rw *resourceWatcher) setupCustomResourceInformers(client dynamic.Interface, customResources []CustomResourceConfig) {
+	if len(customResources) == 0 {
+		return
+	}
+
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(client, 0)
+	for _, cr := range customResources {
+		crMetrics := cr.metrics()
+		syncMetrics := func(obj interface{}) {
+			rw.waitForInitialInformerSync()
+			rw.dataCollector.SyncCustomResourceMetrics(obj, crMetrics)
+		}
+		factory.ForResource(cr.groupVersionResource()).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: syncMetrics,
+			UpdateFunc: func(_, newObj interface{}) {
+				syncMetrics(newObj)
+			},
+			DeleteFunc: rw.onDelete,
+		})
+	}
+	rw.informerFactories = append(rw.informerFactories, dynamicInformerFactory{factory})
+}
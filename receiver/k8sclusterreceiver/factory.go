@@ -19,11 +19,13 @@ import (
 	"fmt"
 	"time"
 
+	configclientset "github.com/openshift/client-go/config/clientset/versioned"
 	quotaclientset "github.com/openshift/client-go/quota/clientset/versioned"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"k8s.io/client-go/dynamic"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
@@ -39,6 +41,7 @@ const (
 	// Default config values.
 	defaultCollectionInterval = 10 * time.Second
 	defaultDistribution       = distributionKubernetes
+	defaultLeaseName          = "otel-k8s-cluster-receiver"
 )
 
 var defaultNodeConditionsToReport = []string{"Ready"}
@@ -52,6 +55,12 @@ func createDefaultConfig() config.Receiver {
 		APIConfig: k8sconfig.APIConfig{
 			AuthType: k8sconfig.AuthTypeServiceAccount,
 		},
+		LeaderElection: k8sconfig.LeaderElectionConfig{
+			LeaseName:     defaultLeaseName,
+			LeaseDuration: k8sconfig.DefaultLeaseDuration,
+			RenewDeadline: k8sconfig.DefaultRenewDeadline,
+			RetryPeriod:   k8sconfig.DefaultRetryPeriod,
+		},
 	}
 }
 
@@ -66,19 +75,32 @@ func createMetricsReceiver(
 	}
 
 	var osQuotaClient quotaclientset.Interface
+	var osConfigClient configclientset.Interface
 	switch rCfg.Distribution {
 	case distributionOpenShift:
 		osQuotaClient, err = rCfg.getOpenShiftQuotaClient()
 		if err != nil {
 			return nil, err
 		}
+		osConfigClient, err = rCfg.getOpenShiftConfigClient()
+		if err != nil {
+			return nil, err
+		}
 	case distributionKubernetes:
 		// default case, nothing to initialize
 	default:
 		return nil, fmt.Errorf("\"%s\" is not a supported distribution. Must be one of: \"openshift\", \"kubernetes\"", rCfg.Distribution)
 	}
 
-	return newReceiver(params.Logger, rCfg, consumer, k8sClient, osQuotaClient)
+	var dynamicClient dynamic.Interface
+	if len(rCfg.CustomResources) > 0 {
+		dynamicClient, err = rCfg.getDynamicClient()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newReceiver(params.Logger, rCfg, consumer, k8sClient, osQuotaClient, osConfigClient, dynamicClient)
 }
 
 // NewFactory creates a factory for k8s_cluster receiver.
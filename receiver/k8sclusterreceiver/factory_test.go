@@ -16,9 +16,12 @@ package k8sclusterreceiver
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	configclientset "github.com/openshift/client-go/config/clientset/versioned"
+	fakeConfig "github.com/openshift/client-go/config/clientset/versioned/fake"
 	quotaclientset "github.com/openshift/client-go/quota/clientset/versioned"
 	fakeQuota "github.com/openshift/client-go/quota/clientset/versioned/fake"
 	"github.com/stretchr/testify/require"
@@ -26,6 +29,9 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -47,6 +53,12 @@ func TestFactory(t *testing.T) {
 		APIConfig: k8sconfig.APIConfig{
 			AuthType: k8sconfig.AuthTypeServiceAccount,
 		},
+		LeaderElection: k8sconfig.LeaderElectionConfig{
+			LeaseName:     defaultLeaseName,
+			LeaseDuration: 15 * time.Second,
+			RenewDeadline: 10 * time.Second,
+			RetryPeriod:   2 * time.Second,
+		},
 	}, rCfg)
 
 	r, err := f.CreateTracesReceiver(
@@ -97,6 +109,9 @@ func TestFactoryDistributions(t *testing.T) {
 	rCfg.makeOpenShiftQuotaClient = func(apiConf k8sconfig.APIConfig) (quotaclientset.Interface, error) {
 		return fakeQuota.NewSimpleClientset(), nil
 	}
+	rCfg.makeOpenShiftConfigClient = func(apiConf k8sconfig.APIConfig) (configclientset.Interface, error) {
+		return fakeConfig.NewSimpleClientset(), nil
+	}
 
 	// default
 	r, err := f.CreateMetricsReceiver(
@@ -107,6 +122,7 @@ func TestFactoryDistributions(t *testing.T) {
 	require.NotNil(t, r)
 	rr := r.(*kubernetesReceiver)
 	require.Nil(t, rr.resourceWatcher.osQuotaClient)
+	require.Nil(t, rr.resourceWatcher.osConfigClient)
 
 	// openshift
 	rCfg.Distribution = "openshift"
@@ -118,6 +134,7 @@ func TestFactoryDistributions(t *testing.T) {
 	require.NotNil(t, r)
 	rr = r.(*kubernetesReceiver)
 	require.NotNil(t, rr.resourceWatcher.osQuotaClient)
+	require.NotNil(t, rr.resourceWatcher.osConfigClient)
 
 	// bad distribution
 	rCfg.Distribution = "unknown-distro"
@@ -130,6 +147,46 @@ func TestFactoryDistributions(t *testing.T) {
 	require.EqualError(t, err, "\"unknown-distro\" is not a supported distribution. Must be one of: \"openshift\", \"kubernetes\"")
 }
 
+func TestFactoryCustomResources(t *testing.T) {
+	f := NewFactory()
+	rCfg := f.CreateDefaultConfig().(*Config)
+	rCfg.makeClient = func(apiConf k8sconfig.APIConfig) (kubernetes.Interface, error) {
+		return nil, nil
+	}
+	rCfg.makeDynamicClient = func(apiConf k8sconfig.APIConfig) (dynamic.Interface, error) {
+		return nil, errors.New("dynamic client error")
+	}
+	rCfg.CustomResources = []CustomResourceConfig{
+		{
+			Group:    "cert-manager.io",
+			Version:  "v1",
+			Resource: "certificates",
+			Metrics: []CustomResourceMetricConfig{
+				{Name: "certmanager.certificate.revision", Field: "status.revision"},
+			},
+		},
+	}
+
+	r, err := f.CreateMetricsReceiver(
+		context.Background(), componenttest.NewNopReceiverCreateSettings(),
+		rCfg, consumertest.NewNop(),
+	)
+	require.EqualError(t, err, "dynamic client error")
+	require.Nil(t, r)
+
+	rCfg.makeDynamicClient = func(apiConf k8sconfig.APIConfig) (dynamic.Interface, error) {
+		return fakedynamic.NewSimpleDynamicClient(runtime.NewScheme()), nil
+	}
+	r, err = f.CreateMetricsReceiver(
+		context.Background(), componenttest.NewNopReceiverCreateSettings(),
+		rCfg, consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	rr := r.(*kubernetesReceiver)
+	require.Len(t, rr.resourceWatcher.informerFactories, 2)
+}
+
 // nopHostWithExporters mocks a receiver.ReceiverHost for test purposes.
 type nopHostWithExporters struct {
 }
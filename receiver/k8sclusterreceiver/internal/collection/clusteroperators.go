@@ -0,0 +1,101 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	"fmt"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	configv1 "github.com/openshift/api/config/v1"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/utils"
+)
+
+// clusterOperatorConditions are the ClusterOperator status conditions reported as metrics,
+// along with the names of the metrics they are reported as.
+var clusterOperatorConditions = []struct {
+	metric    string
+	condition configv1.ClusterStatusConditionType
+}{
+	{"openshift.clusteroperator.available", configv1.OperatorAvailable},
+	{"openshift.clusteroperator.progressing", configv1.OperatorProgressing},
+	{"openshift.clusteroperator.degraded", configv1.OperatorDegraded},
+	{"openshift.clusteroperator.upgradeable", configv1.OperatorUpgradeable},
+}
+
+var clusterOperatorConditionValues = map[configv1.ConditionStatus]int64{
+	configv1.ConditionTrue:    1,
+	configv1.ConditionFalse:   0,
+	configv1.ConditionUnknown: -1,
+}
+
+func getMetricsForClusterOperator(co *configv1.ClusterOperator) []*resourceMetrics {
+	metrics := make([]*metricspb.Metric, 0, len(clusterOperatorConditions))
+	for _, c := range clusterOperatorConditions {
+		metrics = append(metrics, &metricspb.Metric{
+			MetricDescriptor: &metricspb.MetricDescriptor{
+				Name: c.metric,
+				Description: fmt.Sprintf("Whether the cluster operator is %s (1), not %s (0) or in an unknown state (-1)",
+					c.condition, c.condition),
+				Type: metricspb.MetricDescriptor_GAUGE_INT64,
+			},
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetInt64TimeSeries(clusterOperatorConditionValue(co, c.condition)),
+			},
+		})
+	}
+
+	return []*resourceMetrics{
+		{
+			resource: getResourceForClusterOperator(co),
+			metrics:  metrics,
+		},
+	}
+}
+
+func clusterOperatorConditionValue(co *configv1.ClusterOperator, condType configv1.ClusterStatusConditionType) int64 {
+	status := configv1.ConditionUnknown
+	for _, c := range co.Status.Conditions {
+		if c.Type == condType {
+			status = c.Status
+			break
+		}
+	}
+	if value, ok := clusterOperatorConditionValues[status]; ok {
+		return value
+	}
+	return clusterOperatorConditionValues[configv1.ConditionUnknown]
+}
+
+func getResourceForClusterOperator(co *configv1.ClusterOperator) *resourcepb.Resource {
+	labels := map[string]string{
+		k8sKeyClusterOperatorUID:            string(co.UID),
+		k8sKeyClusterOperatorName:           co.Name,
+		conventions.AttributeK8SClusterName: co.ClusterName,
+	}
+	// The version of the operator itself is reported under the "operator" operand.
+	for _, v := range co.Status.Versions {
+		if v.Name == "operator" {
+			labels[k8sKeyClusterOperatorVersion] = v.Version
+			break
+		}
+	}
+	return &resourcepb.Resource{
+		Type:   k8sType,
+		Labels: labels,
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/testutils"
+)
+
+func TestClusterOperatorMetrics(t *testing.T) {
+	co := newMockClusterOperator("1")
+
+	actualResourceMetrics := getMetricsForClusterOperator(co)
+
+	require.Equal(t, 1, len(actualResourceMetrics))
+
+	metrics := actualResourceMetrics[0].metrics
+	require.Equal(t, 4, len(metrics))
+	testutils.AssertResource(t, actualResourceMetrics[0].resource, k8sType,
+		map[string]string{
+			"openshift.clusteroperator.uid":     "test-clusteroperator-1-uid",
+			"openshift.clusteroperator.name":    "test-clusteroperator-1",
+			"openshift.clusteroperator.version": "4.8.2",
+			"k8s.cluster.name":                  "test-openshift-cluster",
+		},
+	)
+
+	for i, tc := range []struct {
+		name  string
+		value int64
+	}{
+		{"openshift.clusteroperator.available", 1},
+		{"openshift.clusteroperator.progressing", 0},
+		{"openshift.clusteroperator.degraded", -1},
+		{"openshift.clusteroperator.upgradeable", -1},
+	} {
+		testutils.AssertMetrics(t, metrics[i], tc.name, metricspb.MetricDescriptor_GAUGE_INT64, tc.value)
+	}
+}
+
+func newMockClusterOperator(id string) *configv1.ClusterOperator {
+	return &configv1.ClusterOperator{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "test-clusteroperator-" + id,
+			UID:         types.UID("test-clusteroperator-" + id + "-uid"),
+			ClusterName: "test-openshift-cluster",
+		},
+		Status: configv1.ClusterOperatorStatus{
+			Conditions: []configv1.ClusterOperatorStatusCondition{
+				{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+				{Type: configv1.OperatorProgressing, Status: configv1.ConditionFalse},
+				{Type: configv1.OperatorDegraded, Status: configv1.ConditionUnknown},
+			},
+			Versions: []configv1.OperandVersion{
+				{Name: "kube-apiserver", Version: "1.21.1"},
+				{Name: "operator", Version: "4.8.2"},
+			},
+		},
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	agentmetricspb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/metrics/v1"
+	configv1 "github.com/openshift/api/config/v1"
 	quotav1 "github.com/openshift/api/quota/v1"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/zap"
@@ -50,12 +51,17 @@ const (
 	k8sKeyHPAUID                   = "k8s.hpa.uid"
 	k8sKeyResourceQuotaUID         = "k8s.resourcequota.uid"
 	k8sKeyClusterResourceQuotaUID  = "openshift.clusterquota.uid"
+	k8sKeyClusterOperatorUID       = "openshift.clusteroperator.uid"
 
 	// Resource labels keys for Name.
 	k8sKeyReplicationControllerName = "k8s.replicationcontroller.name"
 	k8sKeyHPAName                   = "k8s.hpa.name"
 	k8sKeyResourceQuotaName         = "k8s.resourcequota.name"
 	k8sKeyClusterResourceQuotaName  = "openshift.clusterquota.name"
+	k8sKeyClusterOperatorName       = "openshift.clusteroperator.name"
+
+	// Resource labels keys for Version.
+	k8sKeyClusterOperatorVersion = "openshift.clusteroperator.version"
 
 	// Kubernetes resource kinds
 	k8sKindCronJob               = "CronJob"
@@ -150,6 +156,8 @@ func (dc *DataCollector) SyncMetrics(obj interface{}) {
 		rm = getMetricsForHPA(o)
 	case *quotav1.ClusterResourceQuota:
 		rm = getMetricsForClusterResourceQuota(o)
+	case *configv1.ClusterOperator:
+		rm = getMetricsForClusterOperator(o)
 	default:
 		return
 	}
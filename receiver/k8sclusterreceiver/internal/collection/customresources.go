@@ -0,0 +1,111 @@
+// Copyright 2021 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	"strings"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/utils"
+)
+
+// CustomResourceMetric maps a field of custom resources to a gauge.
+type CustomResourceMetric struct {
+	Name        string
+	Description string
+	Unit        string
+	// Field is the path to the field in the resource, e.g. ["status", "readyReplicas"].
+	// Numeric fields are reported as is and boolean fields as 1 (true) or 0 (false).
+	Field []string
+}
+
+// SyncCustomResourceMetrics updates the metric store with the given metrics
+// read from the custom resource.
+func (dc *DataCollector) SyncCustomResourceMetrics(obj interface{}, crMetrics []CustomResourceMetric) {
+	cr, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	dc.UpdateMetricsStore(obj, getMetricsForCustomResource(cr, crMetrics))
+}
+
+func getMetricsForCustomResource(cr *unstructured.Unstructured, crMetrics []CustomResourceMetric) []*resourceMetrics {
+	metrics := make([]*metricspb.Metric, 0, len(crMetrics))
+	for _, m := range crMetrics {
+		field, found, err := unstructured.NestedFieldNoCopy(cr.Object, m.Field...)
+		if err != nil || !found {
+			continue
+		}
+		value, ok := customResourceFieldValue(field)
+		if !ok {
+			continue
+		}
+		metrics = append(metrics, &metricspb.Metric{
+			MetricDescriptor: &metricspb.MetricDescriptor{
+				Name:        m.Name,
+				Description: m.Description,
+				Unit:        m.Unit,
+				Type:        metricspb.MetricDescriptor_GAUGE_DOUBLE,
+			},
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetDoubleTimeSeries(value),
+			},
+		})
+	}
+
+	return []*resourceMetrics{
+		{
+			resource: getResourceForCustomResource(cr),
+			metrics:  metrics,
+		},
+	}
+}
+
+func customResourceFieldValue(field interface{}) (float64, bool) {
+	switch v := field.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// getResourceForCustomResource returns the resource of a custom resource, with
+// its UID and name keyed by its lower cased kind, e.g. k8s.certificate.name.
+func getResourceForCustomResource(cr *unstructured.Unstructured) *resourcepb.Resource {
+	kind := strings.ToLower(cr.GetKind())
+	labels := map[string]string{
+		"k8s." + kind + ".uid":              string(cr.GetUID()),
+		"k8s." + kind + ".name":             cr.GetName(),
+		conventions.AttributeK8SClusterName: cr.GetClusterName(),
+	}
+	if namespace := cr.GetNamespace(); namespace != "" {
+		labels[conventions.AttributeK8SNamespaceName] = namespace
+	}
+	return &resourcepb.Resource{
+		Type:   k8sType,
+		Labels: labels,
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2021 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/testutils"
+)
+
+func TestCustomResourceMetrics(t *testing.T) {
+	cr := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"metadata": map[string]interface{}{
+			"name":        "test-widget-1",
+			"namespace":   "test-namespace",
+			"uid":         "test-widget-1-uid",
+			"clusterName": "test-cluster",
+		},
+		"status": map[string]interface{}{
+			"readyReplicas": int64(3),
+			"progress":      0.75,
+			"healthy":       true,
+			"phase":         "Running",
+		},
+	}}
+	crMetrics := []CustomResourceMetric{
+		{Name: "widget.ready_replicas", Description: "Ready replicas", Unit: "1", Field: []string{"status", "readyReplicas"}},
+		{Name: "widget.progress", Description: "Progress", Unit: "1", Field: []string{"status", "progress"}},
+		{Name: "widget.healthy", Description: "Healthy", Unit: "1", Field: []string{"status", "healthy"}},
+		// Not numeric nor boolean.
+		{Name: "widget.phase", Description: "Phase", Field: []string{"status", "phase"}},
+		// Missing.
+		{Name: "widget.replicas", Description: "Replicas", Field: []string{"status", "replicas"}},
+	}
+
+	actualResourceMetrics := getMetricsForCustomResource(cr, crMetrics)
+
+	require.Equal(t, 1, len(actualResourceMetrics))
+	require.Equal(t, 3, len(actualResourceMetrics[0].metrics))
+
+	rm := actualResourceMetrics[0]
+	testutils.AssertResource(t, rm.resource, k8sType,
+		map[string]string{
+			"k8s.widget.uid":     "test-widget-1-uid",
+			"k8s.widget.name":    "test-widget-1",
+			"k8s.namespace.name": "test-namespace",
+			"k8s.cluster.name":   "test-cluster",
+		},
+	)
+
+	for i, want := range []struct {
+		name  string
+		value float64
+	}{
+		{"widget.ready_replicas", 3},
+		{"widget.progress", 0.75},
+		{"widget.healthy", 1},
+	} {
+		metric := rm.metrics[i]
+		require.Equal(t, want.name, metric.MetricDescriptor.Name)
+		require.Equal(t, metricspb.MetricDescriptor_GAUGE_DOUBLE, metric.MetricDescriptor.Type)
+		require.Equal(t, want.value, metric.Timeseries[0].Points[0].GetDoubleValue())
+	}
+}
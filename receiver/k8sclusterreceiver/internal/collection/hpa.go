@@ -52,6 +52,28 @@ var hpaDesiredReplicasMetric = &metricspb.MetricDescriptor{
 	Type:        metricspb.MetricDescriptor_GAUGE_INT64,
 }
 
+var hpaTargetUtilizationMetric = &metricspb.MetricDescriptor{
+	Name: "k8s.hpa.target_utilization",
+	Description: "Target average utilization of a resource of the pods managed by this autoscaler," +
+		" in percent of their requests",
+	Unit: "%",
+	Type: metricspb.MetricDescriptor_GAUGE_INT64,
+	LabelKeys: []*metricspb.LabelKey{{
+		Key: "resource",
+	}},
+}
+
+var hpaCurrentUtilizationMetric = &metricspb.MetricDescriptor{
+	Name: "k8s.hpa.current_utilization",
+	Description: "Current average utilization of a resource of the pods managed by this autoscaler," +
+		" in percent of their requests",
+	Unit: "%",
+	Type: metricspb.MetricDescriptor_GAUGE_INT64,
+	LabelKeys: []*metricspb.LabelKey{{
+		Key: "resource",
+	}},
+}
+
 func getMetricsForHPA(hpa *v2beta1.HorizontalPodAutoscaler) []*resourceMetrics {
 	metrics := []*metricspb.Metric{
 		{
@@ -79,6 +101,7 @@ func getMetricsForHPA(hpa *v2beta1.HorizontalPodAutoscaler) []*resourceMetrics {
 			},
 		},
 	}
+	metrics = append(metrics, getUtilizationMetricsForHPA(hpa)...)
 
 	return []*resourceMetrics{
 		{
@@ -88,6 +111,37 @@ func getMetricsForHPA(hpa *v2beta1.HorizontalPodAutoscaler) []*resourceMetrics {
 	}
 }
 
+// getUtilizationMetricsForHPA returns the target and current utilizations of
+// the resources, such as cpu or memory, the autoscaler scales on.
+func getUtilizationMetricsForHPA(hpa *v2beta1.HorizontalPodAutoscaler) []*metricspb.Metric {
+	var metrics []*metricspb.Metric
+	for _, m := range hpa.Spec.Metrics {
+		if m.Type != v2beta1.ResourceMetricSourceType || m.Resource == nil || m.Resource.TargetAverageUtilization == nil {
+			continue
+		}
+		metrics = append(metrics, &metricspb.Metric{
+			MetricDescriptor: hpaTargetUtilizationMetric,
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetInt64TimeSeriesWithLabels(int64(*m.Resource.TargetAverageUtilization),
+					[]*metricspb.LabelValue{{Value: string(m.Resource.Name), HasValue: true}}),
+			},
+		})
+	}
+	for _, m := range hpa.Status.CurrentMetrics {
+		if m.Type != v2beta1.ResourceMetricSourceType || m.Resource == nil || m.Resource.CurrentAverageUtilization == nil {
+			continue
+		}
+		metrics = append(metrics, &metricspb.Metric{
+			MetricDescriptor: hpaCurrentUtilizationMetric,
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetInt64TimeSeriesWithLabels(int64(*m.Resource.CurrentAverageUtilization),
+					[]*metricspb.LabelValue{{Value: string(m.Resource.Name), HasValue: true}}),
+			},
+		})
+	}
+	return metrics
+}
+
 func getResourceForHPA(hpa *v2beta1.HorizontalPodAutoscaler) *resourcepb.Resource {
 	return &resourcepb.Resource{
 		Type: k8sType,
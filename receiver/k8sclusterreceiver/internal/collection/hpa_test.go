@@ -20,6 +20,7 @@ import (
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	"github.com/stretchr/testify/require"
 	"k8s.io/api/autoscaling/v2beta1"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -57,6 +58,47 @@ func TestHPAMetrics(t *testing.T) {
 		metricspb.MetricDescriptor_GAUGE_INT64, 7)
 }
 
+func TestHPAUtilizationMetrics(t *testing.T) {
+	hpa := newHPA("1")
+	targetUtilization, currentUtilization := int32(80), int32(65)
+	hpa.Spec.Metrics = []v2beta1.MetricSpec{
+		{
+			Type: v2beta1.ResourceMetricSourceType,
+			Resource: &v2beta1.ResourceMetricSource{
+				Name:                     corev1.ResourceCPU,
+				TargetAverageUtilization: &targetUtilization,
+			},
+		},
+		{
+			Type: v2beta1.PodsMetricSourceType,
+			Pods: &v2beta1.PodsMetricSource{
+				MetricName: "requests_per_second",
+			},
+		},
+	}
+	hpa.Status.CurrentMetrics = []v2beta1.MetricStatus{
+		{
+			Type: v2beta1.ResourceMetricSourceType,
+			Resource: &v2beta1.ResourceMetricStatus{
+				Name:                      corev1.ResourceCPU,
+				CurrentAverageUtilization: &currentUtilization,
+			},
+		},
+	}
+
+	actualResourceMetrics := getMetricsForHPA(hpa)
+
+	require.Equal(t, 1, len(actualResourceMetrics))
+	require.Equal(t, 6, len(actualResourceMetrics[0].metrics))
+
+	rm := actualResourceMetrics[0]
+	testutils.AssertMetricsWithLabels(t, rm.metrics[4], "k8s.hpa.target_utilization",
+		metricspb.MetricDescriptor_GAUGE_INT64, map[string]string{"resource": "cpu"}, 80)
+
+	testutils.AssertMetricsWithLabels(t, rm.metrics[5], "k8s.hpa.current_utilization",
+		metricspb.MetricDescriptor_GAUGE_INT64, map[string]string{"resource": "cpu"}, 65)
+}
+
 func newHPA(id string) *v2beta1.HorizontalPodAutoscaler {
 	minReplicas := int32(2)
 	return &v2beta1.HorizontalPodAutoscaler{
@@ -27,6 +27,10 @@ import (
 // GetUIDForObject returns the UID for a Kubernetes object.
 func GetUIDForObject(obj runtime.Object) (types.UID, error) {
 	var key types.UID
+	// Unstructured objects, such as custom resources, only expose their metadata through getters.
+	if o, ok := obj.(metav1.Object); ok {
+		return o.GetUID(), nil
+	}
 	oma, ok := obj.(metav1.ObjectMetaAccessor)
 	if !ok || oma.GetObjectMeta() == nil {
 		return key, errors.New("kubernetes object is not of the expected form")
@@ -21,7 +21,9 @@ import (
 	"testing"
 	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
 	quotav1 "github.com/openshift/api/quota/v1"
+	fakeConfig "github.com/openshift/client-go/config/clientset/versioned/fake"
 	fakeQuota "github.com/openshift/client-go/quota/clientset/versioned/fake"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -125,3 +127,27 @@ func createClusterQuota(t *testing.T, client *fakeQuota.Clientset, numQuotas int
 		time.Sleep(2 * time.Millisecond)
 	}
 }
+
+func createClusterOperators(t *testing.T, client *fakeConfig.Clientset, numOperators int) {
+	for i := 0; i < numOperators; i++ {
+		co := &configv1.ClusterOperator{
+			ObjectMeta: v1.ObjectMeta{
+				Name:        fmt.Sprintf("test-clusteroperator-%d", i),
+				UID:         types.UID(fmt.Sprintf("test-clusteroperator-%d-uid", i)),
+				ClusterName: "test-openshift-cluster",
+			},
+			Status: configv1.ClusterOperatorStatus{
+				Conditions: []configv1.ClusterOperatorStatusCondition{
+					{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+					{Type: configv1.OperatorDegraded, Status: configv1.ConditionFalse},
+				},
+			},
+		}
+
+		_, err := client.ConfigV1().ClusterOperators().Create(context.Background(), co, v1.CreateOptions{})
+		if err != nil {
+			t.Errorf("error creating cluster operator, %v", err)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+}
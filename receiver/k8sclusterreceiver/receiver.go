@@ -19,13 +19,17 @@ import (
 	"fmt"
 	"time"
 
+	configclientset "github.com/openshift/client-go/config/clientset/versioned"
 	quotaclientset "github.com/openshift/client-go/quota/clientset/versioned"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.uber.org/zap"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 const (
@@ -55,6 +59,19 @@ func (kr *kubernetesReceiver) Start(ctx context.Context, host component.Host) er
 		return err
 	}
 
+	if kr.config.LeaderElection.Enabled {
+		kr.resourceWatcher.isLeader.Store(false)
+		elector, err := k8sconfig.NewLeaderElector(kr.logger, kr.config.LeaderElection, kr.resourceWatcher.client,
+			kr.config.ID().String(),
+			func() { kr.resourceWatcher.isLeader.Store(true) },
+			func() { kr.resourceWatcher.isLeader.Store(false) },
+		)
+		if err != nil {
+			return fmt.Errorf("failed to set up leader election: %w", err)
+		}
+		go k8sconfig.RunLeaderElection(ctx, elector)
+	}
+
 	go func() {
 		kr.logger.Info("Starting shared informers and wait for initial cache sync.")
 		for _, informer := range kr.resourceWatcher.informerFactories {
@@ -102,6 +119,11 @@ func (kr *kubernetesReceiver) Shutdown(context.Context) error {
 }
 
 func (kr *kubernetesReceiver) dispatchMetrics(ctx context.Context) {
+	// Only the leader emits metrics, the other replicas stand by.
+	if !kr.resourceWatcher.isLeader.Load() {
+		return
+	}
+
 	now := time.Now()
 	mds := kr.resourceWatcher.dataCollector.CollectMetricData(now)
 
@@ -115,8 +137,11 @@ func (kr *kubernetesReceiver) dispatchMetrics(ctx context.Context) {
 // newReceiver creates the Kubernetes cluster receiver with the given configuration.
 func newReceiver(
 	logger *zap.Logger, config *Config, consumer consumer.Metrics,
-	client kubernetes.Interface, osQuotaClient quotaclientset.Interface) (component.MetricsReceiver, error) {
-	resourceWatcher := newResourceWatcher(logger, client, osQuotaClient, config.NodeConditionTypesToReport, defaultInitialSyncTimeout)
+	client kubernetes.Interface, osQuotaClient quotaclientset.Interface,
+	osConfigClient configclientset.Interface, dynamicClient dynamic.Interface) (component.MetricsReceiver, error) {
+	resourceWatcher := newResourceWatcher(logger, client, osQuotaClient, osConfigClient,
+		config.NodeConditionTypesToReport, defaultInitialSyncTimeout)
+	resourceWatcher.setupCustomResourceInformers(dynamicClient, config.CustomResources)
 
 	return &kubernetesReceiver{
 		resourceWatcher: resourceWatcher,
@@ -19,6 +19,8 @@ import (
 	"testing"
 	"time"
 
+	configclientset "github.com/openshift/client-go/config/clientset/versioned"
+	fakeConfig "github.com/openshift/client-go/config/clientset/versioned/fake"
 	quotaclientset "github.com/openshift/client-go/quota/clientset/versioned"
 	fakeQuota "github.com/openshift/client-go/quota/clientset/versioned/fake"
 	"github.com/stretchr/testify/require"
@@ -30,33 +32,42 @@ import (
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/testutils"
 )
 
 func TestReceiver(t *testing.T) {
 	client := fake.NewSimpleClientset()
 	osQuotaClient := fakeQuota.NewSimpleClientset()
+	osConfigClient := fakeConfig.NewSimpleClientset()
 	sink := new(consumertest.MetricsSink)
 
-	r := setupReceiver(client, osQuotaClient, sink, 10*time.Second)
+	r := setupReceiver(client, osQuotaClient, osConfigClient, sink, 10*time.Second)
 
 	// Setup k8s resources.
 	numPods := 2
 	numNodes := 1
 	numQuotas := 2
 	numClusterQuotaMetrics := numQuotas * 4
+	numOperators := 2
+	numClusterOperatorMetrics := numOperators * 4
 	createPods(t, client, numPods)
 	createNodes(t, client, numNodes)
 	createClusterQuota(t, osQuotaClient, 2)
+	createClusterOperators(t, osConfigClient, numOperators)
 
 	ctx := context.Background()
 	require.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
 
 	// Expects metric data from nodes and pods where each metric data
 	// struct corresponds to one resource.
-	expectedNumMetrics := numPods + numNodes + numClusterQuotaMetrics
+	expectedNumMetrics := numPods + numNodes + numClusterQuotaMetrics + numClusterOperatorMetrics
 	var initialDataPointCount int
 	require.Eventually(t, func() bool {
 		initialDataPointCount = sink.DataPointCount()
@@ -68,7 +79,7 @@ func TestReceiver(t *testing.T) {
 	deletePods(t, client, numPodsToDelete)
 
 	// Expects metric data from a node, since other resources were deleted.
-	expectedNumMetrics = (numPods - numPodsToDelete) + numNodes + numClusterQuotaMetrics
+	expectedNumMetrics = (numPods - numPodsToDelete) + numNodes + numClusterQuotaMetrics + numClusterOperatorMetrics
 	var metricsCountDelta int
 	require.Eventually(t, func() bool {
 		metricsCountDelta = sink.DataPointCount() - initialDataPointCount
@@ -83,7 +94,7 @@ func TestReceiverTimesOutAfterStartup(t *testing.T) {
 	client := fake.NewSimpleClientset()
 
 	// Mock initial cache sync timing out, using a small timeout.
-	r := setupReceiver(client, nil, consumertest.NewNop(), 1*time.Millisecond)
+	r := setupReceiver(client, nil, nil, consumertest.NewNop(), 1*time.Millisecond)
 
 	createPods(t, client, 1)
 
@@ -100,7 +111,7 @@ func TestReceiverWithManyResources(t *testing.T) {
 	osQuotaClient := fakeQuota.NewSimpleClientset()
 	sink := new(consumertest.MetricsSink)
 
-	r := setupReceiver(client, osQuotaClient, sink, 10*time.Second)
+	r := setupReceiver(client, osQuotaClient, nil, sink, 10*time.Second)
 
 	numPods := 1000
 	numQuotas := 2
@@ -120,6 +131,96 @@ func TestReceiverWithManyResources(t *testing.T) {
 	r.Shutdown(ctx)
 }
 
+func TestReceiverWithLeaderElection(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	createPods(t, client, 1)
+	ctx := context.Background()
+
+	leaderSink := new(consumertest.MetricsSink)
+	leader := setupReceiver(client, nil, nil, leaderSink, 10*time.Second)
+	leader.config.LeaderElection = testLeaderElectionConfig("leader")
+	require.NoError(t, leader.Start(ctx, componenttest.NewNopHost()))
+	require.Eventually(t, leader.resourceWatcher.isLeader.Load, 10*time.Second, 100*time.Millisecond,
+		"lease not acquired")
+
+	standbySink := new(consumertest.MetricsSink)
+	standby := setupReceiver(client, nil, nil, standbySink, 10*time.Second)
+	standby.config.LeaderElection = testLeaderElectionConfig("standby")
+	require.NoError(t, standby.Start(ctx, componenttest.NewNopHost()))
+
+	require.Eventually(t, func() bool {
+		return len(leaderSink.AllMetrics()) > 1
+	}, 10*time.Second, 100*time.Millisecond,
+		"metrics not collected by the leader")
+	require.False(t, standby.resourceWatcher.isLeader.Load())
+	require.Zero(t, standbySink.DataPointCount())
+
+	// The leader releases the lease on shutdown, so the standby replica takes over.
+	require.NoError(t, leader.Shutdown(ctx))
+	require.Eventually(t, func() bool {
+		return standbySink.DataPointCount() > 0
+	}, 10*time.Second, 100*time.Millisecond,
+		"metrics not collected after the failover")
+
+	require.NoError(t, standby.Shutdown(ctx))
+}
+
+func TestReceiverWithCustomResources(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	gvr := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	widget := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"metadata": map[string]interface{}{
+			"name":      "test-widget",
+			"namespace": "test-namespace",
+			"uid":       "test-widget-uid",
+		},
+		"status": map[string]interface{}{
+			"readyReplicas": int64(2),
+			"healthy":       true,
+		},
+	}}
+	dynamicClient := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "WidgetList"}, widget)
+	sink := new(consumertest.MetricsSink)
+
+	r := setupReceiver(client, nil, nil, sink, 10*time.Second)
+	r.resourceWatcher.setupCustomResourceInformers(dynamicClient, []CustomResourceConfig{
+		{
+			Group:    gvr.Group,
+			Version:  gvr.Version,
+			Resource: gvr.Resource,
+			Metrics: []CustomResourceMetricConfig{
+				{Name: "widget.ready_replicas", Field: "status.readyReplicas"},
+				{Name: "widget.healthy", Field: "status.healthy"},
+			},
+		},
+	})
+
+	ctx := context.Background()
+	require.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
+
+	require.Eventually(t, func() bool {
+		return sink.DataPointCount() == 2
+	}, 10*time.Second, 100*time.Millisecond,
+		"custom resource metrics not collected")
+
+	require.NoError(t, r.Shutdown(ctx))
+}
+
+func testLeaderElectionConfig(identity string) k8sconfig.LeaderElectionConfig {
+	return k8sconfig.LeaderElectionConfig{
+		Enabled:        true,
+		LeaseName:      defaultLeaseName,
+		LeaseNamespace: "default",
+		Identity:       identity,
+		LeaseDuration:  3 * time.Second,
+		RenewDeadline:  2 * time.Second,
+		RetryPeriod:    500 * time.Millisecond,
+	}
+}
+
 var numCalls *atomic.Int32
 var consumeMetadataInvocation = func() {
 	if numCalls != nil {
@@ -132,7 +233,7 @@ func TestReceiverWithMetadata(t *testing.T) {
 	next := &mockExporterWithK8sMetadata{MetricsSink: new(consumertest.MetricsSink)}
 	numCalls = atomic.NewInt32(0)
 
-	r := setupReceiver(client, nil, next, 10*time.Second)
+	r := setupReceiver(client, nil, nil, next, 10*time.Second)
 	r.config.MetadataExporters = []string{"nop/withmetadata"}
 
 	// Setup k8s resources.
@@ -178,6 +279,7 @@ func getUpdatedPod(pod *corev1.Pod) interface{} {
 func setupReceiver(
 	client *fake.Clientset,
 	osQuotaClient quotaclientset.Interface,
+	osConfigClient configclientset.Interface,
 	consumer consumer.Metrics,
 	initialSyncTimeout time.Duration) *kubernetesReceiver {
 
@@ -192,7 +294,7 @@ func setupReceiver(
 		Distribution:               distribution,
 	}
 
-	rw := newResourceWatcher(logger, client, osQuotaClient, config.NodeConditionTypesToReport, initialSyncTimeout)
+	rw := newResourceWatcher(logger, client, osQuotaClient, osConfigClient, config.NodeConditionTypesToReport, initialSyncTimeout)
 	rw.dataCollector.SetupMetadataStore(&corev1.Service{}, &testutils.MockStore{})
 
 	return &kubernetesReceiver{
@@ -20,7 +20,10 @@ import (
 	"reflect"
 	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
 	quotav1 "github.com/openshift/api/quota/v1"
+	configclientset "github.com/openshift/client-go/config/clientset/versioned"
+	configinformersv1 "github.com/openshift/client-go/config/informers/externalversions"
 	quotaclientset "github.com/openshift/client-go/quota/clientset/versioned"
 	quotainformersv1 "github.com/openshift/client-go/quota/informers/externalversions"
 	"go.opentelemetry.io/collector/component"
@@ -50,6 +53,7 @@ type sharedInformer interface {
 type resourceWatcher struct {
 	client              kubernetes.Interface
 	osQuotaClient       quotaclientset.Interface
+	osConfigClient      configclientset.Interface
 	informerFactories   []sharedInformer
 	dataCollector       *collection.DataCollector
 	logger              *zap.Logger
@@ -57,6 +61,8 @@ type resourceWatcher struct {
 	initialTimeout      time.Duration
 	initialSyncDone     *atomic.Bool
 	initialSyncTimedOut *atomic.Bool
+	// isLeader is false while another replica holds the leader election lease.
+	isLeader *atomic.Bool
 }
 
 type metadataConsumer func(metadata []*metadata.MetadataUpdate) error
@@ -64,16 +70,18 @@ type metadataConsumer func(metadata []*metadata.MetadataUpdate) error
 // newResourceWatcher creates a Kubernetes resource watcher.
 func newResourceWatcher(
 	logger *zap.Logger, client kubernetes.Interface, osQuotaClient quotaclientset.Interface,
-	nodeConditionTypesToReport []string, initialSyncTimeout time.Duration) *resourceWatcher {
+	osConfigClient configclientset.Interface, nodeConditionTypesToReport []string, initialSyncTimeout time.Duration) *resourceWatcher {
 	rw := &resourceWatcher{
 		client:              client,
 		osQuotaClient:       osQuotaClient,
+		osConfigClient:      osConfigClient,
 		informerFactories:   []sharedInformer{},
 		logger:              logger,
 		dataCollector:       collection.NewDataCollector(logger, nodeConditionTypesToReport),
 		initialSyncDone:     atomic.NewBool(false),
 		initialSyncTimedOut: atomic.NewBool(false),
 		initialTimeout:      initialSyncTimeout,
+		isLeader:            atomic.NewBool(true),
 	}
 
 	rw.prepareSharedInformerFactory()
@@ -108,6 +116,11 @@ func (rw *resourceWatcher) prepareSharedInformerFactory() {
 		rw.setupInformers(&quotav1.ClusterResourceQuota{}, quotaFactory.Quota().V1().ClusterResourceQuotas().Informer())
 		rw.informerFactories = append(rw.informerFactories, quotaFactory)
 	}
+	if rw.osConfigClient != nil {
+		configFactory := configinformersv1.NewSharedInformerFactory(rw.osConfigClient, 0)
+		rw.setupInformers(&configv1.ClusterOperator{}, configFactory.Config().V1().ClusterOperators().Informer())
+		rw.informerFactories = append(rw.informerFactories, configFactory)
+	}
 	rw.informerFactories = append(rw.informerFactories, factory)
 }
 
@@ -238,6 +251,11 @@ func validateMetadataExporters(metadataExporters map[string]bool,
 func (rw *resourceWatcher) syncMetadataUpdate(oldMetadata,
 	newMetadata map[metadata.ResourceID]*collection.KubernetesMetadata) {
 
+	// Only the leader syncs metadata, the other replicas stand by.
+	if !rw.isLeader.Load() {
+		return
+	}
+
 	metadataUpdate := collection.GetMetadataUpdate(oldMetadata, newMetadata)
 	if len(metadataUpdate) == 0 {
 		return
@@ -0,0 +1,68 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8seventsreceiver
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+	k8s "k8s.io/client-go/kubernetes"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+const (
+	// apiVersionCore selects the Events of the core API group.
+	apiVersionCore = "v1"
+	// apiVersionEvents selects the Events of the events.k8s.io API group.
+	apiVersionEvents = "events.k8s.io/v1"
+)
+
+// Config defines configuration for the Kubernetes events receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"`
+	k8sconfig.APIConfig     `mapstructure:",squash"`
+
+	// Namespaces to watch the events of. All namespaces are watched when empty.
+	Namespaces []string `mapstructure:"namespaces"`
+
+	// APIVersion of the Events to watch, either `v1` (core) or `events.k8s.io/v1`.
+	APIVersion string `mapstructure:"api_version"`
+
+	// LeaderElection allows running several replicas of the receiver with only one emitting events.
+	LeaderElection k8sconfig.LeaderElectionConfig `mapstructure:"leader_election"`
+
+	// For mocking.
+	makeClient func(apiConf k8sconfig.APIConfig) (k8s.Interface, error)
+}
+
+func (cfg *Config) Validate() error {
+	if err := cfg.APIConfig.Validate(); err != nil {
+		return err
+	}
+	switch cfg.APIVersion {
+	case apiVersionCore, apiVersionEvents:
+	default:
+		return fmt.Errorf("api_version must be one of %q or %q: %q", apiVersionCore, apiVersionEvents, cfg.APIVersion)
+	}
+	return cfg.LeaderElection.Validate()
+}
+
+func (cfg *Config) getK8sClient() (k8s.Interface, error) {
+	if cfg.makeClient == nil {
+		cfg.makeClient = k8sconfig.MakeClient
+	}
+	return cfg.makeClient(cfg.APIConfig)
+}
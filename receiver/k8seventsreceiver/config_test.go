@@ -0,0 +1,76 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8seventsreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r1 := cfg.Receivers[config.NewID(typeStr)]
+	assert.Equal(t, r1, factory.CreateDefaultConfig())
+
+	r2 := cfg.Receivers[config.NewIDWithName(typeStr, "all_settings")].(*Config)
+	assert.Equal(t, r2,
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewIDWithName(typeStr, "all_settings")),
+			APIConfig: k8sconfig.APIConfig{
+				AuthType: k8sconfig.AuthTypeKubeConfig,
+			},
+			Namespaces: []string{"default", "kube-system"},
+			APIVersion: apiVersionEvents,
+			LeaderElection: k8sconfig.LeaderElectionConfig{
+				Enabled:        true,
+				LeaseName:      defaultLeaseName,
+				LeaseNamespace: "observability",
+				LeaseDuration:  15 * time.Second,
+				RenewDeadline:  10 * time.Second,
+				RetryPeriod:    2 * time.Second,
+			},
+		})
+}
+
+func TestInvalidConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.APIVersion = "v2"
+	assert.EqualError(t, cfg.Validate(), `api_version must be one of "v1" or "events.k8s.io/v1": "v2"`)
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.LeaderElection.Enabled = true
+	cfg.LeaderElection.RenewDeadline = cfg.LeaderElection.LeaseDuration
+	assert.EqualError(t, cfg.Validate(), "leader_election: lease_duration must be greater than renew_deadline")
+}
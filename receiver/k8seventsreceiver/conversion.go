@@ -0,0 +1,171 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8seventsreceiver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+)
+
+const (
+	attributeObjectKind            = "k8s.object.kind"
+	attributeObjectName            = "k8s.object.name"
+	attributeObjectUID             = "k8s.object.uid"
+	attributeObjectAPIVersion      = "k8s.object.api_version"
+	attributeObjectResourceVersion = "k8s.object.resource_version"
+	attributeObjectFieldPath       = "k8s.object.fieldpath"
+
+	attributeEventName                = "k8s.event.name"
+	attributeEventUID                 = "k8s.event.uid"
+	attributeEventReason              = "k8s.event.reason"
+	attributeEventAction              = "k8s.event.action"
+	attributeEventCount               = "k8s.event.count"
+	attributeEventStartTime           = "k8s.event.start_time"
+	attributeEventReportingController = "k8s.event.reporting_controller"
+	attributeEventReportingInstance   = "k8s.event.reporting_instance"
+	attributeEventSourceComponent     = "k8s.event.source.component"
+	attributeEventSourceHost          = "k8s.event.source.host"
+
+	eventTypeWarning = "Warning"
+)
+
+// kindAttributes maps the kinds of involved objects to their name and UID
+// resource attributes of the semantic conventions.
+var kindAttributes = map[string][2]string{
+	"Pod":         {conventions.AttributeK8SPodName, conventions.AttributeK8SPodUID},
+	"Node":        {conventions.AttributeK8SNodeName, conventions.AttributeK8SNodeUID},
+	"Deployment":  {conventions.AttributeK8SDeploymentName, conventions.AttributeK8SDeploymentUID},
+	"ReplicaSet":  {conventions.AttributeK8SReplicasetName, conventions.AttributeK8SReplicasetUID},
+	"StatefulSet": {conventions.AttributeK8SStatefulsetName, conventions.AttributeK8SStatefulsetUID},
+	"DaemonSet":   {conventions.AttributeK8SDaemonsetName, conventions.AttributeK8SDaemonsetUID},
+	"Job":         {conventions.AttributeK8SJobName, conventions.AttributeK8SJobUID},
+	"CronJob":     {conventions.AttributeK8SCronJobName, conventions.AttributeK8SCronJobUID},
+}
+
+// eventToLogs converts an event to a log record whose resource is the object
+// involved in the event.
+func eventToLogs(ev *corev1.Event) pdata.Logs {
+	logs := pdata.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+
+	obj := ev.InvolvedObject
+	resourceAttrs := rl.Resource().Attributes()
+	resourceAttrs.InsertString(attributeObjectKind, obj.Kind)
+	resourceAttrs.InsertString(attributeObjectName, obj.Name)
+	resourceAttrs.InsertString(attributeObjectUID, string(obj.UID))
+	resourceAttrs.InsertString(attributeObjectAPIVersion, obj.APIVersion)
+	resourceAttrs.InsertString(attributeObjectResourceVersion, obj.ResourceVersion)
+	if obj.FieldPath != "" {
+		resourceAttrs.InsertString(attributeObjectFieldPath, obj.FieldPath)
+	}
+	if obj.Namespace != "" {
+		resourceAttrs.InsertString(conventions.AttributeK8SNamespaceName, obj.Namespace)
+	}
+	if attrs, ok := kindAttributes[obj.Kind]; ok {
+		resourceAttrs.InsertString(attrs[0], obj.Name)
+		resourceAttrs.InsertString(attrs[1], string(obj.UID))
+	}
+
+	lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.SetTimestamp(pdata.TimestampFromTime(eventTimestamp(ev)))
+	lr.SetSeverityText(ev.Type)
+	if ev.Type == eventTypeWarning {
+		lr.SetSeverityNumber(pdata.SeverityNumberWARN)
+	} else {
+		lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+	}
+	lr.Body().SetStringVal(ev.Message)
+
+	attrs := lr.Attributes()
+	attrs.InsertString(attributeEventName, ev.Name)
+	attrs.InsertString(attributeEventUID, string(ev.UID))
+	attrs.InsertString(attributeEventReason, ev.Reason)
+	attrs.InsertInt(attributeEventCount, int64(eventCount(ev)))
+	insertNonEmpty(attrs, attributeEventAction, ev.Action)
+	insertNonEmpty(attrs, attributeEventReportingController, ev.ReportingController)
+	insertNonEmpty(attrs, attributeEventReportingInstance, ev.ReportingInstance)
+	insertNonEmpty(attrs, attributeEventSourceComponent, ev.Source.Component)
+	insertNonEmpty(attrs, attributeEventSourceHost, ev.Source.Host)
+	if !ev.FirstTimestamp.IsZero() {
+		attrs.InsertString(attributeEventStartTime, ev.FirstTimestamp.UTC().Format(time.RFC3339))
+	}
+	return logs
+}
+
+func insertNonEmpty(attrs pdata.AttributeMap, key string, value string) {
+	if value != "" {
+		attrs.InsertString(key, value)
+	}
+}
+
+// eventTimestamp returns the time of the last occurrence of the event, falling
+// back to its creation time for the events not setting any.
+func eventTimestamp(ev *corev1.Event) time.Time {
+	switch {
+	case ev.Series != nil && !ev.Series.LastObservedTime.IsZero():
+		return ev.Series.LastObservedTime.Time
+	case !ev.LastTimestamp.IsZero():
+		return ev.LastTimestamp.Time
+	case !ev.EventTime.IsZero():
+		return ev.EventTime.Time
+	case !ev.FirstTimestamp.IsZero():
+		return ev.FirstTimestamp.Time
+	default:
+		return ev.CreationTimestamp.Time
+	}
+}
+
+// eventCount returns how many times the event occurred.
+func eventCount(ev *corev1.Event) int32 {
+	if ev.Series != nil && ev.Series.Count > 0 {
+		return ev.Series.Count
+	}
+	if ev.Count > 0 {
+		return ev.Count
+	}
+	return 1
+}
+
+// fromEventsV1 converts an event of the events.k8s.io API group to the
+// equivalent core event.
+func fromEventsV1(ev *eventsv1.Event) *corev1.Event {
+	out := &corev1.Event{
+		ObjectMeta:          ev.ObjectMeta,
+		InvolvedObject:      ev.Regarding,
+		Related:             ev.Related,
+		Reason:              ev.Reason,
+		Message:             ev.Note,
+		Source:              ev.DeprecatedSource,
+		FirstTimestamp:      ev.DeprecatedFirstTimestamp,
+		LastTimestamp:       ev.DeprecatedLastTimestamp,
+		Count:               ev.DeprecatedCount,
+		Type:                ev.Type,
+		EventTime:           ev.EventTime,
+		Action:              ev.Action,
+		ReportingController: ev.ReportingController,
+		ReportingInstance:   ev.ReportingInstance,
+	}
+	if ev.Series != nil {
+		out.Series = &corev1.EventSeries{
+			Count:            ev.Series.Count,
+			LastObservedTime: ev.Series.LastObservedTime,
+		}
+	}
+	return out
+}
@@ -0,0 +1,151 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8seventsreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEventToLogs(t *testing.T) {
+	first := time.Date(2021, 8, 12, 10, 0, 0, 0, time.UTC)
+	last := first.Add(time.Minute)
+	ev := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-pod.169a8f2b2a5e8d43",
+			Namespace: "default",
+			UID:       "event-uid",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:            "Pod",
+			Namespace:       "default",
+			Name:            "my-pod",
+			UID:             "pod-uid",
+			APIVersion:      "v1",
+			ResourceVersion: "1234",
+			FieldPath:       "spec.containers{app}",
+		},
+		Reason:         "BackOff",
+		Message:        "Back-off restarting failed container",
+		Source:         corev1.EventSource{Component: "kubelet", Host: "node-1"},
+		FirstTimestamp: metav1.NewTime(first),
+		LastTimestamp:  metav1.NewTime(last),
+		Count:          5,
+		Type:           "Warning",
+	}
+
+	logs := eventToLogs(ev)
+	require.Equal(t, 1, logs.LogRecordCount())
+	rl := logs.ResourceLogs().At(0)
+
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"k8s.object.kind":             pdata.NewAttributeValueString("Pod"),
+		"k8s.object.name":             pdata.NewAttributeValueString("my-pod"),
+		"k8s.object.uid":              pdata.NewAttributeValueString("pod-uid"),
+		"k8s.object.api_version":      pdata.NewAttributeValueString("v1"),
+		"k8s.object.resource_version": pdata.NewAttributeValueString("1234"),
+		"k8s.object.fieldpath":        pdata.NewAttributeValueString("spec.containers{app}"),
+		"k8s.namespace.name":          pdata.NewAttributeValueString("default"),
+		"k8s.pod.name":                pdata.NewAttributeValueString("my-pod"),
+		"k8s.pod.uid":                 pdata.NewAttributeValueString("pod-uid"),
+	}, attributesToMap(rl.Resource().Attributes()))
+
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.TimestampFromTime(last), lr.Timestamp())
+	assert.Equal(t, pdata.SeverityNumberWARN, lr.SeverityNumber())
+	assert.Equal(t, "Warning", lr.SeverityText())
+	assert.Equal(t, "Back-off restarting failed container", lr.Body().StringVal())
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"k8s.event.name":             pdata.NewAttributeValueString("my-pod.169a8f2b2a5e8d43"),
+		"k8s.event.uid":              pdata.NewAttributeValueString("event-uid"),
+		"k8s.event.reason":           pdata.NewAttributeValueString("BackOff"),
+		"k8s.event.count":            pdata.NewAttributeValueInt(5),
+		"k8s.event.start_time":       pdata.NewAttributeValueString("2021-08-12T10:00:00Z"),
+		"k8s.event.source.component": pdata.NewAttributeValueString("kubelet"),
+		"k8s.event.source.host":      pdata.NewAttributeValueString("node-1"),
+	}, attributesToMap(lr.Attributes()))
+}
+
+func TestEventsV1ToLogs(t *testing.T) {
+	observed := time.Date(2021, 8, 12, 10, 0, 0, 0, time.UTC)
+	ev := fromEventsV1(&eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1.169a8f2b2a5e8d43", UID: "event-uid"},
+		EventTime:  metav1.NewMicroTime(observed.Add(-time.Hour)),
+		Series: &eventsv1.EventSeries{
+			Count:            3,
+			LastObservedTime: metav1.NewMicroTime(observed),
+		},
+		ReportingController: "kubelet",
+		ReportingInstance:   "kubelet-node-1",
+		Action:              "Rebooted",
+		Reason:              "Rebooted",
+		Regarding:           corev1.ObjectReference{Kind: "Node", Name: "node-1", UID: "node-uid"},
+		Note:                "Node node-1 has been rebooted",
+		Type:                "Normal",
+	})
+
+	logs := eventToLogs(ev)
+	rl := logs.ResourceLogs().At(0)
+	resourceAttrs := attributesToMap(rl.Resource().Attributes())
+	assert.Equal(t, pdata.NewAttributeValueString("node-1"), resourceAttrs["k8s.node.name"])
+	assert.Equal(t, pdata.NewAttributeValueString("node-uid"), resourceAttrs["k8s.node.uid"])
+	assert.NotContains(t, resourceAttrs, "k8s.namespace.name")
+
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.TimestampFromTime(observed), lr.Timestamp())
+	assert.Equal(t, pdata.SeverityNumberINFO, lr.SeverityNumber())
+	assert.Equal(t, "Node node-1 has been rebooted", lr.Body().StringVal())
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"k8s.event.name":                 pdata.NewAttributeValueString("node-1.169a8f2b2a5e8d43"),
+		"k8s.event.uid":                  pdata.NewAttributeValueString("event-uid"),
+		"k8s.event.reason":               pdata.NewAttributeValueString("Rebooted"),
+		"k8s.event.action":               pdata.NewAttributeValueString("Rebooted"),
+		"k8s.event.count":                pdata.NewAttributeValueInt(3),
+		"k8s.event.reporting_controller": pdata.NewAttributeValueString("kubelet"),
+		"k8s.event.reporting_instance":   pdata.NewAttributeValueString("kubelet-node-1"),
+	}, attributesToMap(lr.Attributes()))
+}
+
+func TestEventTimestamp(t *testing.T) {
+	created := time.Date(2021, 8, 12, 10, 0, 0, 0, time.UTC)
+	ev := &corev1.Event{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)}}
+	assert.Equal(t, created, eventTimestamp(ev))
+	assert.EqualValues(t, 1, eventCount(ev))
+
+	ev.FirstTimestamp = metav1.NewTime(created.Add(time.Second))
+	assert.Equal(t, created.Add(time.Second), eventTimestamp(ev))
+
+	ev.EventTime = metav1.NewMicroTime(created.Add(2 * time.Second))
+	assert.Equal(t, created.Add(2*time.Second), eventTimestamp(ev))
+
+	ev.LastTimestamp = metav1.NewTime(created.Add(3 * time.Second))
+	assert.Equal(t, created.Add(3*time.Second), eventTimestamp(ev))
+}
+
+func attributesToMap(am pdata.AttributeMap) map[string]pdata.AttributeValue {
+	m := make(map[string]pdata.AttributeValue, am.Len())
+	am.Range(func(k string, v pdata.AttributeValue) bool {
+		m[k] = v
+		return true
+	})
+	return m
+}
@@ -0,0 +1,71 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8seventsreceiver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+const (
+	// Value of "type" key in configuration.
+	typeStr = "k8s_events"
+
+	// Name of the Lease object used by the leader election.
+	defaultLeaseName = "otel-k8s-events-receiver"
+)
+
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+		APIConfig: k8sconfig.APIConfig{
+			AuthType: k8sconfig.AuthTypeServiceAccount,
+		},
+		APIVersion: apiVersionCore,
+		LeaderElection: k8sconfig.LeaderElectionConfig{
+			LeaseName:     defaultLeaseName,
+			LeaseDuration: k8sconfig.DefaultLeaseDuration,
+			RenewDeadline: k8sconfig.DefaultRenewDeadline,
+			RetryPeriod:   k8sconfig.DefaultRetryPeriod,
+		},
+	}
+}
+
+func createLogsReceiver(
+	_ context.Context, params component.ReceiverCreateSettings, cfg config.Receiver,
+	consumer consumer.Logs) (component.LogsReceiver, error) {
+	rCfg := cfg.(*Config)
+
+	k8sClient, err := rCfg.getK8sClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return newReceiver(params.Logger, rCfg, consumer, k8sClient), nil
+}
+
+// NewFactory creates a factory for the k8s_events receiver.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithLogs(createLogsReceiver))
+}
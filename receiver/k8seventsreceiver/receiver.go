@@ -0,0 +1,147 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8seventsreceiver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+const transport = "http"
+
+var _ component.LogsReceiver = (*k8seventsReceiver)(nil)
+
+type k8seventsReceiver struct {
+	config   *Config
+	client   kubernetes.Interface
+	logger   *zap.Logger
+	consumer consumer.Logs
+	obsrecv  *obsreport.Receiver
+	cancel   context.CancelFunc
+
+	// startTime is when the receiver started. The events that last occurred
+	// before are listed by the informers but not emitted.
+	startTime time.Time
+	// isLeader is false while another replica holds the leader election lease.
+	isLeader *atomic.Bool
+}
+
+// newReceiver creates the Kubernetes events receiver with the given configuration.
+func newReceiver(logger *zap.Logger, config *Config, consumer consumer.Logs, client kubernetes.Interface) *k8seventsReceiver {
+	return &k8seventsReceiver{
+		config:   config,
+		client:   client,
+		logger:   logger,
+		consumer: consumer,
+		obsrecv:  obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: config.ID(), Transport: transport}),
+		isLeader: atomic.NewBool(true),
+	}
+}
+
+func (kr *k8seventsReceiver) Start(ctx context.Context, _ component.Host) error {
+	ctx, kr.cancel = context.WithCancel(ctx)
+	// The timestamps of the events have a precision of a second.
+	kr.startTime = time.Now().Truncate(time.Second)
+
+	if kr.config.LeaderElection.Enabled {
+		kr.isLeader.Store(false)
+		elector, err := k8sconfig.NewLeaderElector(kr.logger, kr.config.LeaderElection, kr.client,
+			kr.config.ID().String(),
+			func() { kr.isLeader.Store(true) },
+			func() { kr.isLeader.Store(false) },
+		)
+		if err != nil {
+			kr.cancel()
+			return fmt.Errorf("failed to set up leader election: %w", err)
+		}
+		go k8sconfig.RunLeaderElection(ctx, elector)
+	}
+
+	namespaces := kr.config.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+	for _, namespace := range namespaces {
+		factory := informers.NewSharedInformerFactoryWithOptions(kr.client, 0, informers.WithNamespace(namespace))
+		var informer cache.SharedIndexInformer
+		if kr.config.APIVersion == apiVersionEvents {
+			informer = factory.Events().V1().Events().Informer()
+		} else {
+			informer = factory.Core().V1().Events().Informer()
+		}
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: kr.handleEvent,
+			// Recurring events are updated with their new count and timestamp.
+			UpdateFunc: func(_, newObj interface{}) {
+				kr.handleEvent(newObj)
+			},
+		})
+		factory.Start(ctx.Done())
+	}
+
+	kr.logger.Info("Watching Kubernetes events.", zap.Strings("namespaces", kr.config.Namespaces),
+		zap.String("api_version", kr.config.APIVersion))
+	return nil
+}
+
+func (kr *k8seventsReceiver) Shutdown(context.Context) error {
+	if kr.cancel != nil {
+		kr.cancel()
+	}
+	return nil
+}
+
+func (kr *k8seventsReceiver) handleEvent(obj interface{}) {
+	var ev *corev1.Event
+	switch o := obj.(type) {
+	case *corev1.Event:
+		ev = o
+	case *eventsv1.Event:
+		ev = fromEventsV1(o)
+	default:
+		return
+	}
+
+	// Only the leader emits events, the other replicas stand by.
+	if !kr.isLeader.Load() {
+		return
+	}
+	if eventTimestamp(ev).Before(kr.startTime) {
+		return
+	}
+
+	ld := eventToLogs(ev)
+	ctx := kr.obsrecv.StartLogsOp(context.Background())
+	err := kr.consumer.ConsumeLogs(ctx, ld)
+	kr.obsrecv.EndLogsOp(ctx, typeStr, ld.LogRecordCount(), err)
+	if err != nil {
+		kr.logger.Error("Failed to consume event", zap.String("event", ev.Namespace+"/"+ev.Name), zap.Error(err))
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8seventsreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+func TestReceiver(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	sink := new(consumertest.LogsSink)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Namespaces = []string{"default"}
+	r := newReceiver(zap.NewNop(), cfg, sink, client)
+
+	// Events that occurred before the receiver started are not emitted.
+	createEvent(t, client, "default", "old", time.Now().Add(-time.Hour))
+
+	ctx := context.Background()
+	require.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
+
+	createEvent(t, client, "default", "new", time.Now())
+	// Events of the namespaces that aren't watched are not emitted.
+	createEvent(t, client, "other", "new", time.Now())
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, 10*time.Second, 100*time.Millisecond,
+		"event not collected")
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	name, _ := lr.Attributes().Get(attributeEventName)
+	require.Equal(t, "new", name.StringVal())
+
+	require.Never(t, func() bool {
+		return sink.LogRecordCount() > 1
+	}, time.Second, 100*time.Millisecond)
+
+	require.NoError(t, r.Shutdown(ctx))
+}
+
+func TestReceiverEventsAPI(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	sink := new(consumertest.LogsSink)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.APIVersion = apiVersionEvents
+	r := newReceiver(zap.NewNop(), cfg, sink, client)
+
+	ctx := context.Background()
+	require.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
+
+	_, err := client.EventsV1().Events("default").Create(ctx, &eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-pod.1", Namespace: "default"},
+		EventTime:  metav1.NowMicro(),
+		Regarding:  corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "my-pod"},
+		Reason:     "Scheduled",
+		Note:       "Successfully assigned default/my-pod to node-1",
+		Type:       "Normal",
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, 10*time.Second, 100*time.Millisecond,
+		"event not collected")
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	require.Equal(t, "Successfully assigned default/my-pod to node-1", lr.Body().StringVal())
+
+	require.NoError(t, r.Shutdown(ctx))
+}
+
+func TestReceiverWithLeaderElection(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	ctx := context.Background()
+
+	leaderSink := new(consumertest.LogsSink)
+	leader := newReceiver(zap.NewNop(), testLeaderElectionConfig("leader"), leaderSink, client)
+	require.NoError(t, leader.Start(ctx, componenttest.NewNopHost()))
+	require.Eventually(t, leader.isLeader.Load, 10*time.Second, 100*time.Millisecond,
+		"lease not acquired")
+
+	standbySink := new(consumertest.LogsSink)
+	standby := newReceiver(zap.NewNop(), testLeaderElectionConfig("standby"), standbySink, client)
+	require.NoError(t, standby.Start(ctx, componenttest.NewNopHost()))
+
+	createEvent(t, client, "default", "first", time.Now())
+	require.Eventually(t, func() bool {
+		return leaderSink.LogRecordCount() == 1
+	}, 10*time.Second, 100*time.Millisecond,
+		"event not collected by the leader")
+	require.False(t, standby.isLeader.Load())
+	require.Zero(t, standbySink.LogRecordCount())
+
+	// The leader releases the lease on shutdown, so the standby replica takes over.
+	require.NoError(t, leader.Shutdown(ctx))
+	require.Eventually(t, standby.isLeader.Load, 10*time.Second, 100*time.Millisecond,
+		"lease not acquired after the failover")
+
+	createEvent(t, client, "default", "second", time.Now())
+	require.Eventually(t, func() bool {
+		return standbySink.LogRecordCount() == 1
+	}, 10*time.Second, 100*time.Millisecond,
+		"event not collected after the failover")
+
+	require.NoError(t, standby.Shutdown(ctx))
+}
+
+func testLeaderElectionConfig(identity string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ReceiverSettings = config.NewReceiverSettings(config.NewIDWithName(typeStr, identity))
+	cfg.LeaderElection = k8sconfig.LeaderElectionConfig{
+		Enabled:        true,
+		LeaseName:      defaultLeaseName,
+		LeaseNamespace: "default",
+		Identity:       identity,
+		LeaseDuration:  3 * time.Second,
+		RenewDeadline:  2 * time.Second,
+		RetryPeriod:    500 * time.Millisecond,
+	}
+	return cfg
+}
+
+func createEvent(t *testing.T, client *fake.Clientset, namespace string, name string, timestamp time.Time) {
+	_, err := client.CoreV1().Events(namespace).Create(context.Background(), &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: namespace,
+			Name:      "my-pod",
+		},
+		Reason:         "Started",
+		Message:        "Started container app",
+		FirstTimestamp: metav1.NewTime(timestamp),
+		LastTimestamp:  metav1.NewTime(timestamp),
+		Type:           "Normal",
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+}
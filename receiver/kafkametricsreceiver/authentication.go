@@ -0,0 +1,131 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/exporter/kafkaexporter"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// Authentication defines authentication. It accepts every mechanism supported
+// by the kafka exporter plus SASL/OAUTHBEARER.
+type Authentication struct {
+	kafkaexporter.Authentication `mapstructure:",squash"`
+
+	// OAuthBearer configures SASL/OAUTHBEARER using the OAuth2 client credentials flow.
+	OAuthBearer *OAuthBearerConfig `mapstructure:"oauthbearer"`
+}
+
+// OAuthBearerConfig defines the configuration for SASL/OAUTHBEARER authentication.
+type OAuthBearerConfig struct {
+	// ClientID is the application's ID.
+	ClientID string `mapstructure:"client_id"`
+	// ClientSecret is the application's secret.
+	ClientSecret string `mapstructure:"client_secret"`
+	// TokenURL is the resource server's token endpoint URL.
+	TokenURL string `mapstructure:"token_url"`
+	// Scopes specifies optional requested permissions.
+	Scopes []string `mapstructure:"scopes"`
+	// Extensions are SASL extensions sent to the broker along with the token.
+	Extensions map[string]string `mapstructure:"extensions"`
+}
+
+// validate checks that at most one SASL mechanism is configured, since sarama
+// authenticates with a single one and the one configured last would silently win.
+func (config Authentication) validate() error {
+	var mechanisms []string
+	if config.PlainText != nil {
+		mechanisms = append(mechanisms, "plain_text")
+	}
+	if config.SASL != nil {
+		mechanisms = append(mechanisms, "sasl")
+	}
+	if config.Kerberos != nil {
+		mechanisms = append(mechanisms, "kerberos")
+	}
+	if config.OAuthBearer != nil {
+		mechanisms = append(mechanisms, "oauthbearer")
+	}
+	if len(mechanisms) > 1 {
+		return fmt.Errorf("auth: only one of plain_text, sasl, kerberos and oauthbearer can be configured, got %s",
+			strings.Join(mechanisms, ", "))
+	}
+	return nil
+}
+
+// configureAuthentication configures authentication in sarama.Config.
+func configureAuthentication(config Authentication, saramaConfig *sarama.Config) error {
+	if err := kafkaexporter.ConfigureAuthentication(config.Authentication, saramaConfig); err != nil {
+		return err
+	}
+	if config.OAuthBearer != nil {
+		if err := configureOAuthBearer(*config.OAuthBearer, saramaConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func configureOAuthBearer(config OAuthBearerConfig, saramaConfig *sarama.Config) error {
+	if config.ClientID == "" {
+		return fmt.Errorf("client_id have to be provided")
+	}
+	if config.ClientSecret == "" {
+		return fmt.Errorf("client_secret have to be provided")
+	}
+	if config.TokenURL == "" {
+		return fmt.Errorf("token_url have to be provided")
+	}
+
+	ccConfig := clientcredentials.Config{
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		TokenURL:     config.TokenURL,
+		Scopes:       config.Scopes,
+	}
+	saramaConfig.Net.SASL.Enable = true
+	saramaConfig.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+	saramaConfig.Net.SASL.TokenProvider = &oauthTokenProvider{
+		tokenSource: ccConfig.TokenSource(context.Background()),
+		extensions:  config.Extensions,
+	}
+	return nil
+}
+
+// oauthTokenProvider implements sarama.AccessTokenProvider on top of an
+// oauth2.TokenSource, which caches the token and refreshes it when it expires.
+type oauthTokenProvider struct {
+	tokenSource oauth2.TokenSource
+	extensions  map[string]string
+}
+
+var _ sarama.AccessTokenProvider = (*oauthTokenProvider)(nil)
+
+func (p *oauthTokenProvider) Token() (*sarama.AccessToken, error) {
+	token, err := p.tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OAuth token: %w", err)
+	}
+	return &sarama.AccessToken{
+		Token:      token.AccessToken,
+		Extensions: p.extensions,
+	}, nil
+}
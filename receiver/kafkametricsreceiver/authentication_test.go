@@ -0,0 +1,98 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkametricsreceiver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/exporter/kafkaexporter"
+)
+
+func TestConfigureAuthentication_oauthBearer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"test-token","token_type":"bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	sc := sarama.NewConfig()
+	err := configureAuthentication(Authentication{
+		OAuthBearer: &OAuthBearerConfig{
+			ClientID:     "id",
+			ClientSecret: "secret",
+			TokenURL:     server.URL,
+			Extensions:   map[string]string{"logicalCluster": "lkc-1"},
+		},
+	}, sc)
+	require.NoError(t, err)
+	assert.True(t, sc.Net.SASL.Enable)
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeOAuth), sc.Net.SASL.Mechanism)
+
+	token, err := sc.Net.SASL.TokenProvider.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "test-token", token.Token)
+	assert.Equal(t, map[string]string{"logicalCluster": "lkc-1"}, token.Extensions)
+}
+
+func TestConfigureAuthentication_oauthBearerInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		config OAuthBearerConfig
+		err    string
+	}{
+		{
+			name:   "missing client id",
+			config: OAuthBearerConfig{ClientSecret: "secret", TokenURL: "http://localhost"},
+			err:    "client_id have to be provided",
+		},
+		{
+			name:   "missing client secret",
+			config: OAuthBearerConfig{ClientID: "id", TokenURL: "http://localhost"},
+			err:    "client_secret have to be provided",
+		},
+		{
+			name:   "missing token url",
+			config: OAuthBearerConfig{ClientID: "id", ClientSecret: "secret"},
+			err:    "token_url have to be provided",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oauthConfig := tt.config
+			err := configureAuthentication(Authentication{OAuthBearer: &oauthConfig}, sarama.NewConfig())
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestAuthenticationValidate(t *testing.T) {
+	assert.NoError(t, Authentication{}.validate())
+	assert.NoError(t, Authentication{OAuthBearer: &OAuthBearerConfig{}}.validate())
+
+	auth := Authentication{OAuthBearer: &OAuthBearerConfig{}}
+	auth.PlainText = &kafkaexporter.PlainTextConfig{Username: "user", Password: "pass"}
+	assert.EqualError(t, auth.validate(),
+		"auth: only one of plain_text, sasl, kerberos and oauthbearer can be configured, got plain_text, oauthbearer")
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Authentication.SASL = &kafkaexporter.SASLConfig{Username: "user", Password: "pass", Mechanism: "PLAIN"}
+	cfg.Authentication.Kerberos = &kafkaexporter.KerberosConfig{}
+	assert.Error(t, cfg.Validate())
+}
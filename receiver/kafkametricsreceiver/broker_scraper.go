@@ -60,7 +60,7 @@ func (s *brokerScraper) scrape(context.Context) (pdata.ResourceMetricsSlice, err
 	brokers := s.client.Brokers()
 
 	rms := pdata.NewResourceMetricsSlice()
-	ilm := rms.AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
+	ilm := appendResourceMetrics(rms, s.config).InstrumentationLibraryMetrics().AppendEmpty()
 	ilm.InstrumentationLibrary().SetName(instrumentationLibName)
 	addIntGauge(ilm.Metrics(), metadata.M.KafkaBrokers.Name(), pdata.TimestampFromTime(time.Now()), pdata.NewStringMap(), int64(len(brokers)))
 
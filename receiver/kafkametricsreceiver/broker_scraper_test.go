@@ -106,6 +106,21 @@ func TestBrokerScraper_scrape(t *testing.T) {
 	assert.NotNil(t, ms)
 }
 
+func TestBrokerScraper_scrape_clusterName(t *testing.T) {
+	client := newMockClient()
+	client.Mock.On("Brokers").Return(testBrokers)
+	bs := brokerScraper{
+		client: client,
+		logger: zap.NewNop(),
+		config: Config{ClusterName: "test-cluster"},
+	}
+	ms, err := bs.scrape(context.Background())
+	assert.NoError(t, err)
+	v, ok := ms.At(0).Resource().Attributes().Get(clusterNameAttribute)
+	assert.True(t, ok)
+	assert.Equal(t, "test-cluster", v.StringVal())
+}
+
 func TestBrokersScraper_createBrokerScraper(t *testing.T) {
 	sc := sarama.NewConfig()
 	newSaramaClient = mockNewSaramaClient
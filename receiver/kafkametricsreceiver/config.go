@@ -15,7 +15,6 @@
 package kafkametricsreceiver
 
 import (
-	"go.opentelemetry.io/collector/exporter/kafkaexporter"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
@@ -36,11 +35,20 @@ type Config struct {
 	GroupMatch string `mapstructure:"group_match"`
 
 	// Authentication data
-	Authentication kafkaexporter.Authentication `mapstructure:"auth"`
+	Authentication Authentication `mapstructure:"auth"`
 
 	// Scrapers defines which metric data points to be captured from kafka
 	Scrapers []string `mapstructure:"scrapers"`
 
 	// ClientID is the id associated with the consumer that reads from topics in kafka.
 	ClientID string `mapstructure:"client_id"`
+
+	// ClusterName is added to every emitted resource as the kafka.cluster.name attribute,
+	// so metrics scraped from multiple clusters can be told apart downstream.
+	ClusterName string `mapstructure:"cluster_name"`
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	return cfg.Authentication.validate()
 }
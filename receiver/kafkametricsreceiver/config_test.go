@@ -45,16 +45,19 @@ func TestLoadConfig(t *testing.T) {
 		ProtocolVersion:           "2.0.0",
 		TopicMatch:                "test_\\w+",
 		GroupMatch:                "test_\\w+",
-		Authentication: kafkaexporter.Authentication{
-			TLS: &configtls.TLSClientSetting{
-				TLSSetting: configtls.TLSSetting{
-					CAFile:   "ca.pem",
-					CertFile: "cert.pem",
-					KeyFile:  "key.pem",
+		Authentication: Authentication{
+			Authentication: kafkaexporter.Authentication{
+				TLS: &configtls.TLSClientSetting{
+					TLSSetting: configtls.TLSSetting{
+						CAFile:   "ca.pem",
+						CertFile: "cert.pem",
+						KeyFile:  "key.pem",
+					},
 				},
 			},
 		},
-		ClientID: defaultClientID,
-		Scrapers: []string{"brokers", "topics", "consumers"},
+		ClientID:    defaultClientID,
+		ClusterName: "test-cluster",
+		Scrapers:    []string{"brokers", "topics", "consumers"},
 	}, r)
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/Shopify/sarama"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
@@ -31,6 +32,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver/internal/metadata"
 )
 
+// messageTimestampFetchSize is the maximum number of bytes fetched to read the
+// timestamp of the oldest message not consumed by a consumer group.
+const messageTimestampFetchSize = 64 * 1024
+
 type consumerScraper struct {
 	client       sarama.Client
 	logger       *zap.Logger
@@ -39,6 +44,29 @@ type consumerScraper struct {
 	clusterAdmin sarama.ClusterAdmin
 	saramaConfig *sarama.Config
 	config       Config
+	// messageTimestamps returns the timestamps of the messages at the offsets.
+	messageTimestamps func(offsets []partitionOffset) (map[partitionOffset]time.Time, error)
+}
+
+// partitionOffset identifies a message in a topic.
+type partitionOffset struct {
+	topic     string
+	partition int32
+	offset    int64
+}
+
+// lagSecondsPoint is a lag in seconds data point waiting for the timestamp of the
+// oldest message not consumed by the group.
+type lagSecondsPoint struct {
+	labels pdata.StringMap
+	offset partitionOffset
+}
+
+// timestampFetch is a fetch request for message timestamps sent to a partition leader.
+type timestampFetch struct {
+	broker  *sarama.Broker
+	request *sarama.FetchRequest
+	offsets []partitionOffset
 }
 
 func (s *consumerScraper) Name() string {
@@ -120,9 +148,12 @@ func (s *consumerScraper) scrape(context.Context) (pdata.ResourceMetricsSlice, e
 		return pdata.ResourceMetricsSlice{}, listErr
 	}
 
-	now := pdata.TimestampFromTime(time.Now())
+	scrapeTime := time.Now()
+	now := pdata.TimestampFromTime(scrapeTime)
+	// lag in seconds of the lagging partitions, emitted once the timestamps are fetched
+	var lagSecondsPoints []lagSecondsPoint
 	rms := pdata.NewResourceMetricsSlice()
-	ilm := rms.AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
+	ilm := appendResourceMetrics(rms, s.config).InstrumentationLibraryMetrics().AppendEmpty()
 	ilm.InstrumentationLibrary().SetName(instrumentationLibName)
 	for _, group := range consumerGroups {
 		labels := pdata.NewStringMap()
@@ -154,14 +185,22 @@ func (s *consumerScraper) scrape(context.Context) (pdata.ResourceMetricsSlice, e
 					addIntGauge(ilm.Metrics(), metadata.M.KafkaConsumerGroupOffset.Name(), now, labels, consumerOffset)
 					// default -1 to indicate no lag measured.
 					var consumerLag int64 = -1
-					if partitionOffset, ok := topicPartitionOffset[topic][partition]; ok {
+					if newestOffset, ok := topicPartitionOffset[topic][partition]; ok {
 						// only consider partitions with an offset
 						if block.Offset != -1 {
-							consumerLag = partitionOffset - consumerOffset
+							consumerLag = newestOffset - consumerOffset
 							lagSum += consumerLag
 						}
 					}
 					addIntGauge(ilm.Metrics(), metadata.M.KafkaConsumerGroupLag.Name(), now, labels, consumerLag)
+					switch {
+					case consumerLag == 0:
+						addDoubleGauge(ilm.Metrics(), metadata.M.KafkaConsumerGroupLagSeconds.Name(), now, labels, 0)
+					case consumerLag > 0:
+						point := lagSecondsPoint{labels: pdata.NewStringMap(), offset: partitionOffset{topic, partition, consumerOffset}}
+						labels.CopyTo(point.labels)
+						lagSecondsPoints = append(lagSecondsPoints, point)
+					}
 				}
 				labels.Delete(metadata.L.Partition)
 				addIntGauge(ilm.Metrics(), metadata.M.KafkaConsumerGroupOffsetSum.Name(), now, labels, offsetSum)
@@ -170,9 +209,202 @@ func (s *consumerScraper) scrape(context.Context) (pdata.ResourceMetricsSlice, e
 		}
 	}
 
+	if len(lagSecondsPoints) > 0 {
+		s.addLagSeconds(ilm.Metrics(), lagSecondsPoints, now, scrapeTime, &scrapeErrors)
+	}
+
 	return rms, scrapeErrors.Combine()
 }
 
+// addLagSeconds adds the lag in seconds of the points, the age of the oldest message not
+// consumed by the group. The timestamps of all points are fetched together, and the
+// timestamp of an offset is shared by the groups at that offset.
+func (s *consumerScraper) addLagSeconds(metrics pdata.MetricSlice, points []lagSecondsPoint, now pdata.Timestamp, scrapeTime time.Time, scrapeErrors *scrapererror.ScrapeErrors) {
+	seen := map[partitionOffset]bool{}
+	var offsets []partitionOffset
+	for _, point := range points {
+		if !seen[point.offset] {
+			seen[point.offset] = true
+			offsets = append(offsets, point.offset)
+		}
+	}
+	timestamps, err := s.messageTimestamps(offsets)
+	if err != nil {
+		scrapeErrors.Add(err)
+	}
+	for _, point := range points {
+		timestamp, ok := timestamps[point.offset]
+		if !ok {
+			continue
+		}
+		lagSeconds := 0.0
+		if age := scrapeTime.Sub(timestamp).Seconds(); age > 0 {
+			lagSeconds = age
+		}
+		addDoubleGauge(metrics, metadata.M.KafkaConsumerGroupLagSeconds.Name(), now, point.labels, lagSeconds)
+	}
+}
+
+// fetchMessageTimestamps fetches the messages at the offsets from the partition leaders
+// and returns their timestamps. The messages at an offset removed by the retention of
+// the topic were never consumed, the timestamp of such offset is the one of the oldest
+// message left in the partition.
+func (s *consumerScraper) fetchMessageTimestamps(offsets []partitionOffset) (map[partitionOffset]time.Time, error) {
+	timestamps, outOfRange, errs := s.fetchTimestamps(offsets)
+	if len(outOfRange) == 0 {
+		return timestamps, consumererror.Combine(errs)
+	}
+
+	// offsets of the oldest messages left in partitions with the offsets out of range
+	oldest := map[partitionOffset][]partitionOffset{}
+	var oldestOffsets []partitionOffset
+	for _, po := range outOfRange {
+		offset, err := s.client.GetOffset(po.topic, po.partition, sarama.OffsetOldest)
+		if err != nil {
+			errs = append(errs, timestampError(po, err))
+			continue
+		}
+		oldestOffset := partitionOffset{po.topic, po.partition, offset}
+		if _, ok := oldest[oldestOffset]; !ok {
+			oldestOffsets = append(oldestOffsets, oldestOffset)
+		}
+		oldest[oldestOffset] = append(oldest[oldestOffset], po)
+	}
+	oldestTimestamps, stillOutOfRange, oldestErrs := s.fetchTimestamps(oldestOffsets)
+	errs = append(errs, oldestErrs...)
+	for _, po := range stillOutOfRange {
+		errs = append(errs, timestampError(po, sarama.ErrOffsetOutOfRange))
+	}
+	for oldestOffset, timestamp := range oldestTimestamps {
+		for _, po := range oldest[oldestOffset] {
+			timestamps[po] = timestamp
+		}
+	}
+	return timestamps, consumererror.Combine(errs)
+}
+
+// fetchTimestamps fetches the messages at the offsets with one request per partition
+// leader and returns their timestamps, along with the offsets out of range.
+func (s *consumerScraper) fetchTimestamps(offsets []partitionOffset) (map[partitionOffset]time.Time, []partitionOffset, []error) {
+	timestamps := map[partitionOffset]time.Time{}
+	var version int16
+	switch {
+	case s.saramaConfig.Version.IsAtLeast(sarama.V0_11_0_0):
+		version = 4
+	case s.saramaConfig.Version.IsAtLeast(sarama.V0_10_0_0):
+		version = 2
+	default:
+		return timestamps, nil, []error{fmt.Errorf("message timestamps require protocol_version 0.10.0.0 or later")}
+	}
+
+	fetches, errs := timestampFetches(offsets, version, s.client.Leader)
+	var outOfRange []partitionOffset
+	for _, fetch := range fetches {
+		response, err := fetch.broker.Fetch(fetch.request)
+		if err != nil {
+			for _, po := range fetch.offsets {
+				errs = append(errs, timestampError(po, err))
+			}
+			continue
+		}
+		for _, po := range fetch.offsets {
+			block := response.GetBlock(po.topic, po.partition)
+			switch {
+			case block == nil:
+				errs = append(errs, timestampError(po, fmt.Errorf("fetch response is missing the partition")))
+			case block.Err == sarama.ErrOffsetOutOfRange:
+				outOfRange = append(outOfRange, po)
+			case block.Err != sarama.ErrNoError:
+				errs = append(errs, timestampError(po, block.Err))
+			default:
+				if timestamp, ok := firstTimestamp(block.RecordsSet, po.offset); ok {
+					timestamps[po] = timestamp
+				} else {
+					errs = append(errs, timestampError(po, fmt.Errorf("no message found at offset")))
+				}
+			}
+		}
+	}
+	return timestamps, outOfRange, errs
+}
+
+// timestampFetches groups the offsets into fetch requests to their partition leaders.
+// A request fetches a single offset per partition, so different offsets of a partition
+// are fetched by separate requests.
+func timestampFetches(offsets []partitionOffset, version int16, leader func(topic string, partition int32) (*sarama.Broker, error)) ([]*timestampFetch, []error) {
+	var fetches []*timestampFetch
+	var errs []error
+	brokerFetches := map[string][]*timestampFetch{}
+	for _, po := range offsets {
+		broker, err := leader(po.topic, po.partition)
+		if err != nil {
+			errs = append(errs, timestampError(po, err))
+			continue
+		}
+		var fetch *timestampFetch
+		for _, f := range brokerFetches[broker.Addr()] {
+			if !f.hasPartition(po) {
+				fetch = f
+				break
+			}
+		}
+		if fetch == nil {
+			fetch = &timestampFetch{
+				broker:  broker,
+				request: &sarama.FetchRequest{MinBytes: 1, Version: version},
+			}
+			brokerFetches[broker.Addr()] = append(brokerFetches[broker.Addr()], fetch)
+			fetches = append(fetches, fetch)
+		}
+		fetch.request.MaxBytes += messageTimestampFetchSize
+		fetch.request.AddBlock(po.topic, po.partition, po.offset, messageTimestampFetchSize)
+		fetch.offsets = append(fetch.offsets, po)
+	}
+	return fetches, errs
+}
+
+// hasPartition returns whether the fetch already fetches an offset of the partition.
+func (f *timestampFetch) hasPartition(po partitionOffset) bool {
+	for _, fetched := range f.offsets {
+		if fetched.topic == po.topic && fetched.partition == po.partition {
+			return true
+		}
+	}
+	return false
+}
+
+func timestampError(po partitionOffset, err error) error {
+	return fmt.Errorf("failed to read timestamp of offset %d of partition %d of topic %s: %w",
+		po.offset, po.partition, po.topic, err)
+}
+
+// firstTimestamp returns the timestamp of the first message at or after offset.
+func firstTimestamp(recordsSet []*sarama.Records, offset int64) (time.Time, bool) {
+	for _, records := range recordsSet {
+		if batch := records.RecordBatch; batch != nil {
+			for _, record := range batch.Records {
+				if batch.FirstOffset+record.OffsetDelta < offset {
+					continue
+				}
+				if batch.LogAppendTime {
+					return batch.MaxTimestamp, true
+				}
+				return batch.FirstTimestamp.Add(record.TimestampDelta), true
+			}
+		}
+		if msgSet := records.MsgSet; msgSet != nil {
+			// The offset of a compressed message set is the offset of its last message,
+			// whose timestamp is the one of the message set.
+			for _, block := range msgSet.Messages {
+				if block.Offset >= offset && block.Msg != nil {
+					return block.Msg.Timestamp, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
 func createConsumerScraper(_ context.Context, cfg Config, saramaConfig *sarama.Config, logger *zap.Logger) (scraperhelper.Scraper, error) {
 	groupFilter, err := regexp.Compile(cfg.GroupMatch)
 	if err != nil {
@@ -189,6 +421,7 @@ func createConsumerScraper(_ context.Context, cfg Config, saramaConfig *sarama.C
 		config:       cfg,
 		saramaConfig: saramaConfig,
 	}
+	s.messageTimestamps = s.fetchMessageTimestamps
 	return scraperhelper.NewResourceMetricsScraper(
 		config.NewID(config.Type(s.Name())),
 		s.scrape,
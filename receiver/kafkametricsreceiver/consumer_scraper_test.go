@@ -19,10 +19,15 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver/internal/metadata"
 )
 
 func TestConsumerShutdown(t *testing.T) {
@@ -223,3 +228,154 @@ func TestConsumerScraper_scrape_handlesPartitionPartialError(t *testing.T) {
 	assert.NotNil(t, s)
 	assert.Error(t, err)
 }
+
+func TestConsumerScraper_scrape_lagSeconds(t *testing.T) {
+	filter := regexp.MustCompile(defaultGroupMatch)
+	client := newMockClient()
+	// the consumer group is at offset 1, 10 messages behind
+	client.offset = 11
+	var fetched []partitionOffset
+	cs := consumerScraper{
+		client:       client,
+		logger:       zap.NewNop(),
+		clusterAdmin: newMockClusterAdmin(),
+		topicFilter:  filter,
+		groupFilter:  filter,
+		messageTimestamps: func(offsets []partitionOffset) (map[partitionOffset]time.Time, error) {
+			fetched = append(fetched, offsets...)
+			timestamps := map[partitionOffset]time.Time{}
+			for _, po := range offsets {
+				timestamps[po] = time.Now().Add(-30 * time.Second)
+			}
+			return timestamps, nil
+		},
+	}
+	rms, err := cs.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []partitionOffset{{testTopic, testPartition, 1}}, fetched)
+
+	lagSeconds := findMetric(t, rms, metadata.M.KafkaConsumerGroupLagSeconds.Name())
+	dp := lagSeconds.Gauge().DataPoints().At(0)
+	assert.GreaterOrEqual(t, dp.DoubleVal(), 30.0)
+	group, _ := dp.LabelsMap().Get(metadata.L.Group)
+	assert.Equal(t, testGroup, group)
+}
+
+func TestConsumerScraper_scrape_lagSecondsWithoutLag(t *testing.T) {
+	filter := regexp.MustCompile(defaultGroupMatch)
+	cs := consumerScraper{
+		client:       newMockClient(),
+		logger:       zap.NewNop(),
+		clusterAdmin: newMockClusterAdmin(),
+		topicFilter:  filter,
+		groupFilter:  filter,
+		messageTimestamps: func([]partitionOffset) (map[partitionOffset]time.Time, error) {
+			t.Fatal("no message should be fetched without lag")
+			return nil, nil
+		},
+	}
+	rms, err := cs.scrape(context.Background())
+	require.NoError(t, err)
+	lagSeconds := findMetric(t, rms, metadata.M.KafkaConsumerGroupLagSeconds.Name())
+	assert.Equal(t, 0.0, lagSeconds.Gauge().DataPoints().At(0).DoubleVal())
+}
+
+func TestConsumerScraper_scrape_handlesMessageTimestampError(t *testing.T) {
+	filter := regexp.MustCompile(defaultGroupMatch)
+	client := newMockClient()
+	client.offset = 11
+	cs := consumerScraper{
+		client:       client,
+		logger:       zap.NewNop(),
+		clusterAdmin: newMockClusterAdmin(),
+		topicFilter:  filter,
+		groupFilter:  filter,
+		messageTimestamps: func([]partitionOffset) (map[partitionOffset]time.Time, error) {
+			return nil, fmt.Errorf("fetch failed")
+		},
+	}
+	rms, err := cs.scrape(context.Background())
+	assert.EqualError(t, err, "fetch failed")
+	findMetric(t, rms, metadata.M.KafkaConsumerGroupLag.Name())
+}
+
+func TestTimestampFetches(t *testing.T) {
+	brokers := map[int32]*sarama.Broker{
+		0: sarama.NewBroker("broker0:9092"),
+		1: sarama.NewBroker("broker1:9092"),
+		2: sarama.NewBroker("broker0:9092"),
+	}
+	leader := func(topic string, partition int32) (*sarama.Broker, error) {
+		if partition == 3 {
+			return nil, fmt.Errorf("no leader")
+		}
+		return brokers[partition], nil
+	}
+
+	fetches, errs := timestampFetches([]partitionOffset{
+		{"topic", 0, 10},
+		{"topic", 1, 10},
+		{"topic", 2, 10},
+		{"topic", 0, 20},
+		{"topic", 3, 10},
+	}, 4, leader)
+	assert.Equal(t, []error{fmt.Errorf("failed to read timestamp of offset 10 of partition 3 of topic topic: %w", fmt.Errorf("no leader"))}, errs)
+	require.Len(t, fetches, 3)
+	// partitions 0 and 2 share a leader, the second offset of partition 0 needs another request
+	assert.Equal(t, []partitionOffset{{"topic", 0, 10}, {"topic", 2, 10}}, fetches[0].offsets)
+	assert.Equal(t, int32(2*messageTimestampFetchSize), fetches[0].request.MaxBytes)
+	assert.Equal(t, []partitionOffset{{"topic", 1, 10}}, fetches[1].offsets)
+	assert.Equal(t, []partitionOffset{{"topic", 0, 20}}, fetches[2].offsets)
+	assert.Equal(t, "broker0:9092", fetches[2].broker.Addr())
+}
+
+func TestFirstTimestamp(t *testing.T) {
+	first := time.Unix(1629455400, 0)
+	batch := &sarama.RecordBatch{
+		FirstOffset:    10,
+		FirstTimestamp: first,
+		MaxTimestamp:   first.Add(2 * time.Second),
+		Records: []*sarama.Record{
+			{OffsetDelta: 0},
+			{OffsetDelta: 1, TimestampDelta: time.Second},
+			{OffsetDelta: 2, TimestampDelta: 2 * time.Second},
+		},
+	}
+	records := []*sarama.Records{{RecordBatch: batch}}
+
+	timestamp, ok := firstTimestamp(records, 11)
+	require.True(t, ok)
+	assert.Equal(t, first.Add(time.Second), timestamp)
+
+	batch.LogAppendTime = true
+	timestamp, ok = firstTimestamp(records, 11)
+	require.True(t, ok)
+	assert.Equal(t, first.Add(2*time.Second), timestamp)
+
+	_, ok = firstTimestamp(records, 13)
+	assert.False(t, ok)
+
+	msgSet := &sarama.MessageSet{Messages: []*sarama.MessageBlock{
+		{Offset: 4, Msg: &sarama.Message{Timestamp: first}},
+		{Offset: 5, Msg: &sarama.Message{Timestamp: first.Add(time.Second)}},
+	}}
+	timestamp, ok = firstTimestamp([]*sarama.Records{{MsgSet: msgSet}}, 5)
+	require.True(t, ok)
+	assert.Equal(t, first.Add(time.Second), timestamp)
+}
+
+func findMetric(t *testing.T, rms pdata.ResourceMetricsSlice, name string) pdata.Metric {
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ms := ilms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				if ms.At(k).Name() == name {
+					return ms.At(k)
+				}
+			}
+		}
+	}
+	t.Fatalf("metric %s not found", name)
+	return pdata.Metric{}
+}
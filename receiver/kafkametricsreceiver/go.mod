@@ -9,6 +9,7 @@ require (
 	go.opentelemetry.io/collector v0.31.1-0.20210810171211-8038673eba9e
 	go.opentelemetry.io/collector/model v0.31.1-0.20210810171211-8038673eba9e
 	go.uber.org/zap v1.19.0
+	golang.org/x/oauth2 v0.0.0-20210514164344-f6687ab2804c
 )
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/common => ../../internal/common
@@ -57,6 +57,7 @@ func (m *metricImpl) Init(metric pdata.Metric) {
 type metricStruct struct {
 	KafkaBrokers                 MetricIntf
 	KafkaConsumerGroupLag        MetricIntf
+	KafkaConsumerGroupLagSeconds MetricIntf
 	KafkaConsumerGroupLagSum     MetricIntf
 	KafkaConsumerGroupMembers    MetricIntf
 	KafkaConsumerGroupOffset     MetricIntf
@@ -73,6 +74,7 @@ func (m *metricStruct) Names() []string {
 	return []string{
 		"kafka.brokers",
 		"kafka.consumer_group.lag",
+		"kafka.consumer_group.lag_seconds",
 		"kafka.consumer_group.lag_sum",
 		"kafka.consumer_group.members",
 		"kafka.consumer_group.offset",
@@ -88,6 +90,7 @@ func (m *metricStruct) Names() []string {
 var metricsByName = map[string]MetricIntf{
 	"kafka.brokers":                    Metrics.KafkaBrokers,
 	"kafka.consumer_group.lag":         Metrics.KafkaConsumerGroupLag,
+	"kafka.consumer_group.lag_seconds": Metrics.KafkaConsumerGroupLagSeconds,
 	"kafka.consumer_group.lag_sum":     Metrics.KafkaConsumerGroupLagSum,
 	"kafka.consumer_group.members":     Metrics.KafkaConsumerGroupMembers,
 	"kafka.consumer_group.offset":      Metrics.KafkaConsumerGroupOffset,
@@ -107,6 +110,7 @@ func (m *metricStruct) FactoriesByName() map[string]func(pdata.Metric) {
 	return map[string]func(pdata.Metric){
 		Metrics.KafkaBrokers.Name():                 Metrics.KafkaBrokers.Init,
 		Metrics.KafkaConsumerGroupLag.Name():        Metrics.KafkaConsumerGroupLag.Init,
+		Metrics.KafkaConsumerGroupLagSeconds.Name(): Metrics.KafkaConsumerGroupLagSeconds.Init,
 		Metrics.KafkaConsumerGroupLagSum.Name():     Metrics.KafkaConsumerGroupLagSum.Init,
 		Metrics.KafkaConsumerGroupMembers.Name():    Metrics.KafkaConsumerGroupMembers.Init,
 		Metrics.KafkaConsumerGroupOffset.Name():     Metrics.KafkaConsumerGroupOffset.Init,
@@ -140,6 +144,15 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"kafka.consumer_group.lag_seconds",
+		func(metric pdata.Metric) {
+			metric.SetName("kafka.consumer_group.lag_seconds")
+			metric.SetDescription("Current approximate lag of consumer group at partition of topic in seconds, the age of the oldest message not consumed yet")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"kafka.consumer_group.lag_sum",
 		func(metric pdata.Metric) {
@@ -21,7 +21,7 @@ import (
 	"github.com/Shopify/sarama"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
-	"go.opentelemetry.io/collector/exporter/kafkaexporter"
+	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 	"go.uber.org/zap"
 )
@@ -31,6 +31,8 @@ const (
 	brokersScraperName     = "brokers"
 	topicsScraperName      = "topics"
 	consumersScraperName   = "consumers"
+
+	clusterNameAttribute = "kafka.cluster.name"
 )
 
 type createKafkaScraper func(context.Context, Config, *sarama.Config, *zap.Logger) (scraperhelper.Scraper, error)
@@ -58,7 +60,7 @@ var newMetricsReceiver = func(
 		}
 		sc.Version = version
 	}
-	if err := kafkaexporter.ConfigureAuthentication(config.Authentication, sc); err != nil {
+	if err := configureAuthentication(config.Authentication, sc); err != nil {
 		return nil, err
 	}
 	scraperControllerOptions := make([]scraperhelper.ScraperControllerOption, 0, len(config.Scrapers))
@@ -81,3 +83,13 @@ var newMetricsReceiver = func(
 		scraperControllerOptions...,
 	)
 }
+
+// appendResourceMetrics appends a new ResourceMetrics to rms, identifying the
+// cluster when a cluster name is configured.
+func appendResourceMetrics(rms pdata.ResourceMetricsSlice, config Config) pdata.ResourceMetrics {
+	rm := rms.AppendEmpty()
+	if config.ClusterName != "" {
+		rm.Resource().Attributes().UpsertString(clusterNameAttribute, config.ClusterName)
+	}
+	return rm
+}
@@ -70,7 +70,7 @@ func (s *topicScraper) scrape(context.Context) (pdata.ResourceMetricsSlice, erro
 
 	now := pdata.TimestampFromTime(time.Now())
 	rms := pdata.NewResourceMetricsSlice()
-	ilm := rms.AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
+	ilm := appendResourceMetrics(rms, s.config).InstrumentationLibraryMetrics().AppendEmpty()
 	ilm.InstrumentationLibrary().SetName(instrumentationLibName)
 	for _, topic := range topics {
 		if !s.topicFilter.MatchString(topic) {
@@ -143,3 +143,13 @@ func addIntGauge(ms pdata.MetricSlice, name string, now pdata.Timestamp, labels
 	dp.SetIntVal(value)
 	labels.CopyTo(dp.LabelsMap())
 }
+
+func addDoubleGauge(ms pdata.MetricSlice, name string, now pdata.Timestamp, labels pdata.StringMap, value float64) {
+	m := ms.AppendEmpty()
+	m.SetName(name)
+	m.SetDataType(pdata.MetricDataTypeGauge)
+	dp := m.Gauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(now)
+	dp.SetDoubleVal(value)
+	labels.CopyTo(dp.LabelsMap())
+}
@@ -50,6 +50,11 @@ type Config struct {
 
 	// Configuration of the Kubernetes API client.
 	K8sAPIConfig *k8sconfig.APIConfig `mapstructure:"k8s_api_config"`
+
+	// UtilizationMetrics enables the pod and container CPU and memory usage metrics as
+	// ratios of their limits and requests. The pod specs are fetched from the API server
+	// when K8sAPIConfig is set, and from the /pods endpoint of the kubelet otherwise.
+	UtilizationMetrics bool `mapstructure:"utilization_metrics"`
 }
 
 // getReceiverOptions returns receiverOptions is the config is valid,
@@ -79,6 +84,7 @@ func (cfg *Config) getReceiverOptions() (*receiverOptions, error) {
 		extraMetadataLabels:   cfg.ExtraMetadataLabels,
 		metricGroupsToCollect: mgs,
 		k8sAPIClient:          k8sAPIClient,
+		utilizationMetrics:    cfg.UtilizationMetrics,
 	}, nil
 }
 
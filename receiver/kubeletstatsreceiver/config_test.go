@@ -155,6 +155,24 @@ func TestLoadConfig(t *testing.T) {
 		},
 		K8sAPIConfig: &k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeKubeConfig},
 	}, metadataWithK8sAPICfg)
+
+	utilizationCfg := cfg.Receivers[config.NewIDWithName(typeStr, "utilization")].(*Config)
+	require.Equal(t, &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewIDWithName(typeStr, "utilization")),
+		ClientConfig: kube.ClientConfig{
+			APIConfig: k8sconfig.APIConfig{
+				AuthType: "serviceAccount",
+			},
+		},
+		CollectionInterval: duration,
+		MetricGroupsToCollect: []kubelet.MetricGroup{
+			kubelet.ContainerMetricGroup,
+			kubelet.PodMetricGroup,
+			kubelet.NodeMetricGroup,
+		},
+		K8sAPIConfig:       &k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeKubeConfig},
+		UtilizationMetrics: true,
+	}, utilizationCfg)
 }
 
 func TestGetReceiverOptions(t *testing.T) {
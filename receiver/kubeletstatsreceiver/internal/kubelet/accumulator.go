@@ -90,9 +90,23 @@ func (a *metricDataAccumulator) podStats(podResource *resourcepb.Resource, s sta
 		fsMetrics(podPrefix, s.EphemeralStorage),
 		memMetrics(podPrefix, s.Memory),
 		networkMetrics(podPrefix, s.Network),
+		a.podUtilizationMetrics(s),
 	)
 }
 
+func (a *metricDataAccumulator) podUtilizationMetrics(s stats.PodStats) []*metricspb.Metric {
+	if !a.metadata.UtilizationMetrics {
+		return nil
+	}
+	r, ok := a.metadata.getPodResources(s.PodRef.UID)
+	if !ok {
+		a.logger.Debug("pod not found in the fetched metadata, skipping utilization metrics",
+			zap.String("pod", s.PodRef.Name))
+		return nil
+	}
+	return utilizationMetrics(podPrefix, s.CPU, s.Memory, r)
+}
+
 func (a *metricDataAccumulator) containerStats(podResource *resourcepb.Resource, s stats.ContainerStats) {
 	if !a.metricGroupsToCollect[ContainerMetricGroup] {
 		return
@@ -113,9 +127,23 @@ func (a *metricDataAccumulator) containerStats(podResource *resourcepb.Resource,
 		cpuMetrics(containerPrefix, s.CPU),
 		memMetrics(containerPrefix, s.Memory),
 		fsMetrics(containerPrefix, s.Rootfs),
+		a.containerUtilizationMetrics(podResource, s),
 	)
 }
 
+func (a *metricDataAccumulator) containerUtilizationMetrics(podResource *resourcepb.Resource, s stats.ContainerStats) []*metricspb.Metric {
+	if !a.metadata.UtilizationMetrics {
+		return nil
+	}
+	r, ok := a.metadata.getContainerResources(podResource.Labels[conventions.AttributeK8SPodUID], s.Name)
+	if !ok {
+		a.logger.Debug("container not found in the fetched metadata, skipping utilization metrics",
+			zap.String("pod", podResource.Labels[conventions.AttributeK8SPodName]), zap.String("container", s.Name))
+		return nil
+	}
+	return utilizationMetrics(containerPrefix, s.CPU, s.Memory, r)
+}
+
 func (a *metricDataAccumulator) volumeStats(podResource *resourcepb.Resource, s stats.VolumeStats) {
 	if !a.metricGroupsToCollect[VolumeMetricGroup] {
 		return
@@ -16,6 +16,8 @@ package kubelet
 
 const (
 	labelPersistentVolumeClaimName = "k8s.persistentvolumeclaim.name"
+	labelPersistentVolumeName      = "k8s.persistentvolume.name"
+	labelStorageClassName          = "k8s.storageclass.name"
 	labelVolumeName                = "k8s.volume.name"
 	labelVolumeType                = "k8s.volume.type"
 
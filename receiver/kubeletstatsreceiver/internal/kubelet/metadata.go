@@ -57,6 +57,9 @@ type Metadata struct {
 	Labels                  map[MetadataLabel]bool
 	PodsMetadata            *v1.PodList
 	DetailedPVCLabelsSetter func(volCacheID, volumeClaim, namespace string, labels map[string]string) error
+	// UtilizationMetrics enables the pod and container usage ratios of their
+	// limits and requests, taken from the specs in PodsMetadata.
+	UtilizationMetrics bool
 }
 
 func NewMetadata(
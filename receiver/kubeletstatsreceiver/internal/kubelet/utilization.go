@@ -0,0 +1,124 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubelet
+
+import (
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	stats "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+)
+
+// resources are the CPU (in cores) and memory (in bytes) limits and requests
+// of a container or pod. Zero values mean unset.
+type resources struct {
+	cpuLimit      float64
+	cpuRequest    float64
+	memoryLimit   float64
+	memoryRequest float64
+}
+
+func containerSpecResources(c v1.Container) resources {
+	return resources{
+		cpuLimit:      float64(c.Resources.Limits.Cpu().MilliValue()) / 1000,
+		cpuRequest:    float64(c.Resources.Requests.Cpu().MilliValue()) / 1000,
+		memoryLimit:   float64(c.Resources.Limits.Memory().Value()),
+		memoryRequest: float64(c.Resources.Requests.Memory().Value()),
+	}
+}
+
+// getContainerResources returns the resources of the given container of the given pod
+// from the fetched pod specs, and false when the container isn't found.
+func (m *Metadata) getContainerResources(podUID string, containerName string) (resources, bool) {
+	pod := m.getPod(podUID)
+	if pod == nil {
+		return resources{}, false
+	}
+	for _, c := range pod.Spec.Containers {
+		if c.Name == containerName {
+			return containerSpecResources(c), true
+		}
+	}
+	return resources{}, false
+}
+
+// getPodResources returns the resources of the given pod from the fetched pod specs,
+// and false when the pod isn't found. The limits of the pod are only set when all its
+// containers have limits, while its requests are the sum of the containers' requests.
+func (m *Metadata) getPodResources(podUID string) (resources, bool) {
+	pod := m.getPod(podUID)
+	if pod == nil {
+		return resources{}, false
+	}
+	var podResources resources
+	cpuLimited, memoryLimited := true, true
+	for _, c := range pod.Spec.Containers {
+		r := containerSpecResources(c)
+		cpuLimited = cpuLimited && r.cpuLimit > 0
+		memoryLimited = memoryLimited && r.memoryLimit > 0
+		podResources.cpuLimit += r.cpuLimit
+		podResources.cpuRequest += r.cpuRequest
+		podResources.memoryLimit += r.memoryLimit
+		podResources.memoryRequest += r.memoryRequest
+	}
+	if !cpuLimited {
+		podResources.cpuLimit = 0
+	}
+	if !memoryLimited {
+		podResources.memoryLimit = 0
+	}
+	return podResources, true
+}
+
+func (m *Metadata) getPod(podUID string) *v1.Pod {
+	if m.PodsMetadata == nil {
+		return nil
+	}
+	uid := types.UID(podUID)
+	for i := range m.PodsMetadata.Items {
+		if m.PodsMetadata.Items[i].UID == uid {
+			return &m.PodsMetadata.Items[i]
+		}
+	}
+	return nil
+}
+
+// utilizationMetrics returns the CPU and memory usage as ratios of the given limits and requests.
+func utilizationMetrics(prefix string, cpu *stats.CPUStats, mem *stats.MemoryStats, r resources) []*metricspb.Metric {
+	var metrics []*metricspb.Metric
+	if cpu != nil && cpu.UsageNanoCores != nil {
+		usage := float64(*cpu.UsageNanoCores) / 1_000_000_000
+		metrics = append(metrics,
+			ratioGauge(prefix+"cpu.limit_utilization", usage, r.cpuLimit),
+			ratioGauge(prefix+"cpu.request_utilization", usage, r.cpuRequest),
+		)
+	}
+	if mem != nil && mem.UsageBytes != nil {
+		usage := float64(*mem.UsageBytes)
+		metrics = append(metrics,
+			ratioGauge(prefix+"memory.limit_utilization", usage, r.memoryLimit),
+			ratioGauge(prefix+"memory.request_utilization", usage, r.memoryRequest),
+		)
+	}
+	return metrics
+}
+
+func ratioGauge(metricName string, value float64, of float64) *metricspb.Metric {
+	if of <= 0 {
+		return nil
+	}
+	ratio := value / of
+	return doubleGauge(metricName, "1", &ratio)
+}
@@ -0,0 +1,135 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubelet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	stats "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+)
+
+func TestUtilizationMetrics(t *testing.T) {
+	usageNanoCores := uint64(250_000_000)
+	usageBytes := uint64(64 << 20)
+	cpu := &stats.CPUStats{UsageNanoCores: &usageNanoCores}
+	mem := &stats.MemoryStats{UsageBytes: &usageBytes}
+
+	pods := &v1.PodList{Items: []v1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{UID: "pod-uid", Name: "pod"},
+		Spec: v1.PodSpec{Containers: []v1.Container{
+			{
+				Name: "limited",
+				Resources: v1.ResourceRequirements{
+					Limits: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("500m"),
+						v1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("250m"),
+						v1.ResourceMemory: resource.MustParse("128Mi"),
+					},
+				},
+			},
+			{
+				Name: "unlimited",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU: resource.MustParse("250m"),
+					},
+				},
+			},
+		}},
+	}}}
+
+	summary := &stats.Summary{Pods: []stats.PodStats{{
+		PodRef: stats.PodReference{UID: "pod-uid", Name: "pod"},
+		CPU:    cpu,
+		Memory: mem,
+		Containers: []stats.ContainerStats{
+			{Name: "limited", CPU: cpu, Memory: mem},
+			{Name: "unlimited", CPU: cpu, Memory: mem},
+			{Name: "unknown", CPU: cpu, Memory: mem},
+		},
+	}}}
+
+	tests := []struct {
+		name        string
+		utilization bool
+		want        map[string]map[string]float64
+	}{
+		{
+			name:        "disabled",
+			utilization: false,
+			want: map[string]map[string]float64{
+				"pod":       {},
+				"limited":   {},
+				"unlimited": {},
+				"unknown":   {},
+			},
+		},
+		{
+			name:        "enabled",
+			utilization: true,
+			want: map[string]map[string]float64{
+				// Pod limits are unset as one of its containers has none.
+				"pod": {
+					"k8s.pod.cpu.request_utilization":    0.5,
+					"k8s.pod.memory.request_utilization": 0.5,
+				},
+				"limited": {
+					"container.cpu.limit_utilization":      0.5,
+					"container.cpu.request_utilization":    1,
+					"container.memory.limit_utilization":   0.25,
+					"container.memory.request_utilization": 0.5,
+				},
+				"unlimited": {
+					"container.cpu.request_utilization": 1,
+				},
+				"unknown": {},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := NewMetadata(nil, pods, nil)
+			metadata.UtilizationMetrics = tt.utilization
+			mds := MetricsData(zap.NewNop(), summary, metadata, "", map[MetricGroup]bool{
+				PodMetricGroup:       true,
+				ContainerMetricGroup: true,
+			})
+
+			got := map[string]map[string]float64{}
+			for _, md := range mds {
+				name := md.Resource.Labels["k8s.container.name"]
+				if name == "" {
+					name = md.Resource.Labels["k8s.pod.name"]
+				}
+				got[name] = map[string]float64{}
+				for _, m := range md.Metrics {
+					if strings.HasSuffix(m.MetricDescriptor.Name, "_utilization") {
+						got[name][m.MetricDescriptor.Name] = m.Timeseries[0].Points[0].GetDoubleValue()
+					}
+				}
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -113,6 +113,21 @@ func getLabelsFromVolume(volume v1.Volume, labels map[string]string) {
 	}
 }
 
+// GetPersistentVolumeClaimLabels sets the labels of the persistent volume bound
+// to the claim, along with the names of the volume and of its storage class.
+func GetPersistentVolumeClaimLabels(pvc v1.PersistentVolumeClaim, pv v1.PersistentVolume, labels map[string]string) {
+	GetPersistentVolumeLabels(pv.Spec.PersistentVolumeSource, labels)
+	labels[labelPersistentVolumeName] = pv.Name
+
+	storageClassName := pv.Spec.StorageClassName
+	if storageClassName == "" && pvc.Spec.StorageClassName != nil {
+		storageClassName = *pvc.Spec.StorageClassName
+	}
+	if storageClassName != "" {
+		labels[labelStorageClassName] = storageClassName
+	}
+}
+
 func GetPersistentVolumeLabels(pv v1.PersistentVolumeSource, labels map[string]string) {
 	// TODO: Support more types
 	switch {
@@ -158,6 +158,47 @@ func TestDetailedPVCLabels(t *testing.T) {
 				"k8s.namespace.name":             "pod-namespace",
 			},
 		},
+		{
+			name:       "persistentVolumeClaim - with persistent volume and storage class names",
+			volumeName: "volume0",
+			volumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "claim-name",
+				},
+			},
+			pod: pod{uid: "uid-1234", name: "pod-name", namespace: "pod-namespace"},
+			detailedPVCLabelsSetterOverride: func(volCacheID, volumeClaim, namespace string, labels map[string]string) error {
+				storageClassName := "fast"
+				GetPersistentVolumeClaimLabels(v1.PersistentVolumeClaim{
+					Spec: v1.PersistentVolumeClaimSpec{
+						VolumeName:       "pv-name",
+						StorageClassName: &storageClassName,
+					},
+				}, v1.PersistentVolume{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "pv-name",
+					},
+					Spec: v1.PersistentVolumeSpec{
+						PersistentVolumeSource: v1.PersistentVolumeSource{
+							Local: &v1.LocalVolumeSource{
+								Path: "path",
+							},
+						},
+					},
+				}, labels)
+				return nil
+			},
+			want: map[string]string{
+				"k8s.volume.name":                "volume0",
+				"k8s.volume.type":                "local",
+				"k8s.persistentvolumeclaim.name": "claim-name",
+				"k8s.persistentvolume.name":      "pv-name",
+				"k8s.storageclass.name":          "fast",
+				"k8s.pod.uid":                    "uid-1234",
+				"k8s.pod.name":                   "pod-name",
+				"k8s.namespace.name":             "pod-namespace",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -66,6 +66,7 @@ var awsPersistentVolume = func() *v1.PersistentVolume {
 					Partition: 10,
 				},
 			},
+			StorageClassName: "gp2",
 		},
 	}
 }()
@@ -84,6 +85,7 @@ var gcePersistentVolume = func() *v1.PersistentVolume {
 					Partition: 10,
 				},
 			},
+			StorageClassName: "standard",
 		},
 	}
 }()
@@ -44,6 +44,7 @@ type receiverOptions struct {
 	extraMetadataLabels   []kubelet.MetadataLabel
 	metricGroupsToCollect map[kubelet.MetricGroup]bool
 	k8sAPIClient          kubernetes.Interface
+	utilizationMetrics    bool
 }
 
 func newReceiver(rOptions *receiverOptions,
@@ -25,6 +25,7 @@ import (
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/kubernetes"
 
 	// todo replace with scraping lib when it's ready
@@ -46,6 +47,7 @@ type runnable struct {
 	extraMetadataLabels   []kubelet.MetadataLabel
 	metricGroupsToCollect map[kubelet.MetricGroup]bool
 	k8sAPIClient          kubernetes.Interface
+	utilizationMetrics    bool
 	cachedVolumeLabels    map[string]map[string]string
 	obsrecv               *obsreport.Receiver
 }
@@ -65,6 +67,7 @@ func newRunnable(
 		extraMetadataLabels:   rOptions.extraMetadataLabels,
 		metricGroupsToCollect: rOptions.metricGroupsToCollect,
 		k8sAPIClient:          rOptions.k8sAPIClient,
+		utilizationMetrics:    rOptions.utilizationMetrics,
 		cachedVolumeLabels:    make(map[string]map[string]string),
 		obsrecv:               obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: rOptions.id, Transport: transport}),
 	}
@@ -85,16 +88,16 @@ func (r *runnable) Run() error {
 	}
 
 	var podsMetadata *v1.PodList
-	// fetch metadata only when extra metadata labels are needed
-	if len(r.extraMetadataLabels) > 0 {
-		podsMetadata, err = r.metadataProvider.Pods()
+	// fetch metadata only when extra metadata labels or utilization metrics are needed
+	if len(r.extraMetadataLabels) > 0 || r.utilizationMetrics {
+		podsMetadata, err = r.pods(summary.Node.NodeName)
 		if err != nil {
-			r.logger.Error("call to /pods endpoint failed", zap.Error(err))
 			return nil
 		}
 	}
 
 	metadata := kubelet.NewMetadata(r.extraMetadataLabels, podsMetadata, r.detailedPVCLabelsSetter())
+	metadata.UtilizationMetrics = r.utilizationMetrics
 	mds := kubelet.MetricsData(r.logger, summary, metadata, typeStr, r.metricGroupsToCollect)
 	metrics := pdata.NewMetrics()
 	for i := range mds {
@@ -112,6 +115,27 @@ func (r *runnable) Run() error {
 	return nil
 }
 
+// pods returns the pods of the node. They are listed from the API server when
+// utilization metrics are enabled and the API client is configured, so that the
+// pod specs are the ones of the control plane, and from the kubelet otherwise.
+func (r *runnable) pods(nodeName string) (*v1.PodList, error) {
+	if r.utilizationMetrics && r.k8sAPIClient != nil {
+		pods, err := r.k8sAPIClient.CoreV1().Pods(metav1.NamespaceAll).List(r.ctx, metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+		})
+		if err != nil {
+			r.logger.Error("listing the pods of the node from the API server failed", zap.String("node", nodeName), zap.Error(err))
+		}
+		return pods, err
+	}
+
+	pods, err := r.metadataProvider.Pods()
+	if err != nil {
+		r.logger.Error("call to /pods endpoint failed", zap.Error(err))
+	}
+	return pods, err
+}
+
 func (r *runnable) detailedPVCLabelsSetter() func(volCacheID, volumeClaim, namespace string, labels map[string]string) error {
 	return func(volCacheID, volumeClaim, namespace string, labels map[string]string) error {
 		if r.k8sAPIClient == nil {
@@ -136,7 +160,7 @@ func (r *runnable) detailedPVCLabelsSetter() func(volCacheID, volumeClaim, names
 			}
 
 			labelsToCache := make(map[string]string)
-			kubelet.GetPersistentVolumeLabels(pv.Spec.PersistentVolumeSource, labelsToCache)
+			kubelet.GetPersistentVolumeClaimLabels(*pvc, *pv, labelsToCache)
 
 			// Cache collected labels.
 			r.cachedVolumeLabels[volCacheID] = labelsToCache
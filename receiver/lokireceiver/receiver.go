@@ -0,0 +1,203 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokireceiver
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/golang/snappy"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/loki/third_party/logproto"
+)
+
+const (
+	// pushPath is the path of the Loki push API.
+	pushPath = "/loki/api/v1/push"
+
+	headerContentType     = "Content-Type"
+	headerContentEncoding = "Content-Encoding"
+
+	contentTypeJSON     = "application/json"
+	contentTypeProtobuf = "application/x-protobuf"
+	gzipEncoding        = "gzip"
+
+	formatJSON     = "json"
+	formatProtobuf = "protobuf"
+)
+
+// lokiReceiver implements the Loki push API, converting the pushed streams into logs.
+type lokiReceiver struct {
+	logger       *zap.Logger
+	config       *Config
+	server       *http.Server
+	nextConsumer consumer.Logs
+	obsrecv      *obsreport.Receiver
+}
+
+var _ component.LogsReceiver = (*lokiReceiver)(nil)
+
+// newLogsReceiver creates a Loki receiver passing the pushed logs on to nextConsumer.
+func newLogsReceiver(config *Config, logger *zap.Logger, nextConsumer consumer.Logs) (component.LogsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
+	return &lokiReceiver{
+		logger:       logger,
+		config:       config,
+		nextConsumer: nextConsumer,
+		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: config.ID(), Transport: "http"}),
+	}, nil
+}
+
+// Start starts the HTTP server serving the push API.
+func (lr *lokiReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := lr.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", lr.config.Endpoint, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(pushPath, lr.handlePush)
+	lr.server = lr.config.HTTPServerSettings.ToServer(mux)
+	go func() {
+		if errHTTP := lr.server.Serve(ln); errHTTP != http.ErrServerClosed {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (lr *lokiReceiver) Shutdown(context.Context) error {
+	if lr.server == nil {
+		return nil
+	}
+	return lr.server.Close()
+}
+
+// handlePush decodes a push request, either snappy compressed protobuf or JSON, and passes its
+// streams on to the next consumer. Like Loki, it answers with a 204 on success.
+func (lr *lokiReceiver) handlePush(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := lr.obsrecv.StartLogsOp(r.Context())
+	r.Body = http.MaxBytesReader(w, r.Body, lr.config.MaxRequestBodySize)
+	format, req, err := decodePushRequest(r, lr.config.MaxRequestBodySize)
+	if err != nil {
+		lr.obsrecv.EndLogsOp(ctx, format, 0, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ld, err := pushRequestToLogs(req)
+	if err != nil {
+		lr.obsrecv.EndLogsOp(ctx, format, 0, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if ld.ResourceLogs().Len() == 0 {
+		lr.obsrecv.EndLogsOp(ctx, format, 0, nil)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	err = lr.nextConsumer.ConsumeLogs(ctx, ld)
+	lr.obsrecv.EndLogsOp(ctx, format, ld.LogRecordCount(), err)
+	if err != nil {
+		lr.logger.Debug("Unable to consume the pushed logs", zap.Error(err))
+		http.Error(w, err.Error(), consumeStatusCode(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodePushRequest decodes the body of a push request according to its content type. Bodies
+// without a content type are decoded as protobuf, as Loki does. Bodies decompressing to more
+// than maxSize bytes are rejected.
+func decodePushRequest(r *http.Request, maxSize int64) (string, *logproto.PushRequest, error) {
+	contentType := r.Header.Get(headerContentType)
+	if contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return formatProtobuf, nil, fmt.Errorf("invalid content type %q: %w", contentType, err)
+		}
+		contentType = mediaType
+	}
+
+	switch contentType {
+	case contentTypeJSON:
+		var body io.Reader = r.Body
+		if r.Header.Get(headerContentEncoding) == gzipEncoding {
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				return formatJSON, nil, err
+			}
+			defer gz.Close()
+			// The JSON is cut short past the limit, failing to decode.
+			body = io.LimitReader(gz, maxSize)
+		}
+		req, err := decodeJSONPushRequest(body)
+		return formatJSON, req, err
+	case contentTypeProtobuf, "":
+		compressed, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return formatProtobuf, nil, err
+		}
+		decodedLen, err := snappy.DecodedLen(compressed)
+		if err != nil {
+			return formatProtobuf, nil, fmt.Errorf("failed to decompress the request body: %w", err)
+		}
+		if int64(decodedLen) > maxSize {
+			return formatProtobuf, nil, fmt.Errorf("decompressed request body of %d bytes exceeds the limit of %d bytes", decodedLen, maxSize)
+		}
+		buf, err := snappy.Decode(nil, compressed)
+		if err != nil {
+			return formatProtobuf, nil, fmt.Errorf("failed to decompress the request body: %w", err)
+		}
+		var req logproto.PushRequest
+		if err = req.Unmarshal(buf); err != nil {
+			return formatProtobuf, nil, fmt.Errorf("failed to unmarshal the request body: %w", err)
+		}
+		return formatProtobuf, &req, nil
+	default:
+		return contentType, nil, fmt.Errorf("unsupported content type %q", contentType)
+	}
+}
+
+// consumeStatusCode is the status code of the response to a push request whose logs failed to be
+// consumed with err. Promtail and the Grafana Agent retry the requests failing with a server error.
+func consumeStatusCode(err error) int {
+	if consumererror.IsPermanent(err) {
+		return http.StatusBadRequest
+	}
+	return http.StatusServiceUnavailable
+}
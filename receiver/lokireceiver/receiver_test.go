@@ -0,0 +1,290 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokireceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/testutil"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/loki/third_party/logproto"
+)
+
+const testJSONPush = `{"streams":[{"stream":{"job":"varlogs","host":"node-1"},` +
+	`"values":[["1627776000000000000","first line"],["1627776001000000000","second line"]]}]}`
+
+var testTimestamp = time.Unix(1627776000, 0)
+
+func protobufPushBody(t *testing.T, streams ...logproto.Stream) []byte {
+	req := logproto.PushRequest{Streams: streams}
+	buf, err := req.Marshal()
+	require.NoError(t, err)
+	return snappy.Encode(nil, buf)
+}
+
+func newPushRequest(body []byte, contentType string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, pushPath, bytes.NewReader(body))
+	if contentType != "" {
+		req.Header.Set(headerContentType, contentType)
+	}
+	return req
+}
+
+func newTestReceiver(t *testing.T, nextConsumer *consumertest.LogsSink) *lokiReceiver {
+	r, err := newLogsReceiver(createDefaultConfig().(*Config), zap.NewNop(), nextConsumer)
+	require.NoError(t, err)
+	return r.(*lokiReceiver)
+}
+
+func serve(lr *lokiReceiver, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	lr.handlePush(w, req)
+	return w
+}
+
+func TestProtobufPush(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	lr := newTestReceiver(t, sink)
+
+	body := protobufPushBody(t,
+		logproto.Stream{
+			Labels: `{job="varlogs", host="node-1"}`,
+			Entries: []logproto.Entry{
+				{Timestamp: testTimestamp, Line: "first line"},
+				{Timestamp: testTimestamp.Add(time.Second), Line: "second line"},
+			},
+		},
+		logproto.Stream{
+			Labels:  `{job="syslog"}`,
+			Entries: []logproto.Entry{{Timestamp: testTimestamp, Line: "syslog line"}},
+		})
+
+	for _, contentType := range []string{contentTypeProtobuf, ""} {
+		t.Run("content type "+contentType, func(t *testing.T) {
+			sink.Reset()
+			w := serve(lr, newPushRequest(body, contentType))
+			assert.Equal(t, http.StatusNoContent, w.Code)
+			require.Len(t, sink.AllLogs(), 1)
+
+			ld := sink.AllLogs()[0]
+			require.Equal(t, 2, ld.ResourceLogs().Len())
+			assert.Equal(t, 3, ld.LogRecordCount())
+
+			rl := ld.ResourceLogs().At(0)
+			assert.Equal(t, map[string]string{"job": "varlogs", "host": "node-1"}, attributesAsMap(rl.Resource().Attributes()))
+			logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+			require.Equal(t, 2, logs.Len())
+			assert.Equal(t, pdata.TimestampFromTime(testTimestamp), logs.At(0).Timestamp())
+			assert.Equal(t, "first line", logs.At(0).Body().StringVal())
+			assert.Equal(t, "second line", logs.At(1).Body().StringVal())
+
+			rl = ld.ResourceLogs().At(1)
+			assert.Equal(t, map[string]string{"job": "syslog"}, attributesAsMap(rl.Resource().Attributes()))
+		})
+	}
+}
+
+func TestJSONPush(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	lr := newTestReceiver(t, sink)
+
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	_, err := gz.Write([]byte(testJSONPush))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	gzipReq := newPushRequest(gzipped.Bytes(), contentTypeJSON+"; charset=utf-8")
+	gzipReq.Header.Set(headerContentEncoding, gzipEncoding)
+
+	for name, req := range map[string]*http.Request{
+		"plain": newPushRequest([]byte(testJSONPush), contentTypeJSON),
+		"gzip":  gzipReq,
+	} {
+		t.Run(name, func(t *testing.T) {
+			sink.Reset()
+			w := serve(lr, req)
+			assert.Equal(t, http.StatusNoContent, w.Code)
+			require.Len(t, sink.AllLogs(), 1)
+
+			ld := sink.AllLogs()[0]
+			require.Equal(t, 1, ld.ResourceLogs().Len())
+			rl := ld.ResourceLogs().At(0)
+			assert.Equal(t, map[string]string{"job": "varlogs", "host": "node-1"}, attributesAsMap(rl.Resource().Attributes()))
+			logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+			require.Equal(t, 2, logs.Len())
+			assert.Equal(t, pdata.TimestampFromTime(testTimestamp), logs.At(0).Timestamp())
+			assert.Equal(t, "first line", logs.At(0).Body().StringVal())
+			assert.Equal(t, pdata.TimestampFromTime(testTimestamp.Add(time.Second)), logs.At(1).Timestamp())
+			assert.Equal(t, "second line", logs.At(1).Body().StringVal())
+		})
+	}
+}
+
+func TestEmptyPush(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	lr := newTestReceiver(t, sink)
+
+	w := serve(lr, newPushRequest([]byte(`{"streams":[]}`), contentTypeJSON))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, sink.AllLogs())
+}
+
+func TestInvalidPush(t *testing.T) {
+	testCases := []struct {
+		name         string
+		req          *http.Request
+		expectedCode int
+	}{
+		{
+			name: "invalid method",
+			req: func() *http.Request {
+				req := newPushRequest(nil, contentTypeJSON)
+				req.Method = http.MethodGet
+				return req
+			}(),
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "unsupported content type",
+			req:          newPushRequest([]byte(testJSONPush), "text/plain"),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid json",
+			req:          newPushRequest([]byte("{"), contentTypeJSON),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid timestamp",
+			req:          newPushRequest([]byte(`{"streams":[{"stream":{"job":"a"},"values":[["now","line"]]}]}`), contentTypeJSON),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "not snappy compressed",
+			req:          newPushRequest([]byte("not snappy"), contentTypeProtobuf),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "snappy decompressing past the limit",
+			req:          newPushRequest([]byte{0xff, 0xff, 0xff, 0xff, 0x0f}, contentTypeProtobuf),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid protobuf",
+			req:          newPushRequest(snappy.Encode(nil, []byte("not protobuf")), contentTypeProtobuf),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid labels",
+			req: newPushRequest(protobufPushBody(t, logproto.Stream{
+				Labels:  `job="varlogs"`,
+				Entries: []logproto.Entry{{Timestamp: testTimestamp, Line: "line"}},
+			}), contentTypeProtobuf),
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sink := new(consumertest.LogsSink)
+			w := serve(newTestReceiver(t, sink), tc.req)
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.NotEmpty(t, w.Body.String())
+			assert.Empty(t, sink.AllLogs())
+		})
+	}
+}
+
+func TestPushOverLimit(t *testing.T) {
+	body := protobufPushBody(t, logproto.Stream{
+		Labels:  `{job="varlogs"}`,
+		Entries: []logproto.Entry{{Timestamp: testTimestamp, Line: "line"}},
+	})
+	sink := new(consumertest.LogsSink)
+	lr := newTestReceiver(t, sink)
+	lr.config.MaxRequestBodySize = int64(len(body) - 1)
+	w := serve(lr, newPushRequest(body, contentTypeProtobuf))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, sink.AllLogs())
+}
+
+func TestConsumerErrors(t *testing.T) {
+	testCases := []struct {
+		err          error
+		expectedCode int
+	}{
+		{err: errors.New("temporary"), expectedCode: http.StatusServiceUnavailable},
+		{err: consumererror.Permanent(errors.New("permanent")), expectedCode: http.StatusBadRequest},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			r, err := newLogsReceiver(createDefaultConfig().(*Config), zap.NewNop(), consumertest.NewErr(tc.err))
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			r.(*lokiReceiver).handlePush(w, newPushRequest([]byte(testJSONPush), contentTypeJSON))
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Contains(t, w.Body.String(), tc.err.Error())
+		})
+	}
+}
+
+func TestStartShutdown(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	r, err := newLogsReceiver(cfg, zap.NewNop(), sink)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, r.Shutdown(ctx))
+	}()
+
+	resp, err := http.Post("http://"+cfg.Endpoint+pushPath, contentTypeJSON, bytes.NewReader([]byte(testJSONPush)))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, 2, sink.LogRecordCount())
+
+	resp, err = http.Post("http://"+cfg.Endpoint+"/other", contentTypeJSON, bytes.NewReader([]byte(testJSONPush)))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func attributesAsMap(attrs pdata.AttributeMap) map[string]string {
+	m := make(map[string]string, attrs.Len())
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		m[k] = v.StringVal()
+		return true
+	})
+	return m
+}
@@ -0,0 +1,93 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokireceiver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/loki/third_party/logproto"
+)
+
+// jsonPushRequest is the JSON body of a push request, see
+// https://grafana.com/docs/loki/latest/api/#post-lokiapiv1push.
+type jsonPushRequest struct {
+	Streams []jsonStream `json:"streams"`
+}
+
+// jsonStream holds the labels of a stream and its entries as pairs of a timestamp, in
+// nanoseconds since the epoch, and a log line.
+type jsonStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// decodeJSONPushRequest decodes a JSON push request into its protobuf counterpart.
+func decodeJSONPushRequest(body io.Reader) (*logproto.PushRequest, error) {
+	var jsonReq jsonPushRequest
+	if err := json.NewDecoder(body).Decode(&jsonReq); err != nil {
+		return nil, fmt.Errorf("failed to decode the request body: %w", err)
+	}
+
+	req := &logproto.PushRequest{Streams: make([]logproto.Stream, 0, len(jsonReq.Streams))}
+	for _, s := range jsonReq.Streams {
+		stream := logproto.Stream{
+			Labels:  labels.FromMap(s.Stream).String(),
+			Entries: make([]logproto.Entry, 0, len(s.Values)),
+		}
+		for _, value := range s.Values {
+			ns, err := strconv.ParseInt(value[0], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid timestamp %q of stream %s: %w", value[0], stream.Labels, err)
+			}
+			stream.Entries = append(stream.Entries, logproto.Entry{Timestamp: time.Unix(0, ns), Line: value[1]})
+		}
+		req.Streams = append(req.Streams, stream)
+	}
+	return req, nil
+}
+
+// pushRequestToLogs converts the streams of a push request into logs, with a resource per stream
+// holding its labels as attributes and a log record per entry.
+func pushRequestToLogs(req *logproto.PushRequest) (pdata.Logs, error) {
+	ld := pdata.NewLogs()
+	for _, stream := range req.Streams {
+		ls, err := parser.ParseMetric(stream.Labels)
+		if err != nil {
+			return ld, fmt.Errorf("invalid labels %q: %w", stream.Labels, err)
+		}
+
+		rl := ld.ResourceLogs().AppendEmpty()
+		attrs := rl.Resource().Attributes()
+		for _, l := range ls {
+			attrs.InsertString(l.Name, l.Value)
+		}
+
+		logs := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+		for _, entry := range stream.Entries {
+			lr := logs.AppendEmpty()
+			lr.SetTimestamp(pdata.TimestampFromTime(entry.Timestamp))
+			lr.Body().SetStringVal(entry.Line)
+		}
+	}
+	return ld, nil
+}
@@ -15,11 +15,32 @@
 package nginxreceiver
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
+const (
+	// modeAuto selects the stub status or NGINX Plus API mode from the endpoint response.
+	modeAuto = "auto"
+	// modeStubStatus scrapes the ngx_http_stub_status_module endpoint.
+	modeStubStatus = "stub_status"
+	// modePlus scrapes the NGINX Plus REST API.
+	modePlus = "plus"
+)
+
 type Config struct {
 	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
 	confighttp.HTTPClientSettings           `mapstructure:",squash"`
+	// Mode is the kind of endpoint to scrape, one of "auto" (default), "stub_status" or "plus".
+	Mode string `mapstructure:"mode"`
+}
+
+func (cfg *Config) Validate() error {
+	switch cfg.Mode {
+	case "", modeAuto, modeStubStatus, modePlus:
+		return nil
+	}
+	return fmt.Errorf("mode must be one of %q, %q or %q, got %q", modeAuto, modeStubStatus, modePlus, cfg.Mode)
 }
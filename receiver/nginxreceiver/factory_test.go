@@ -38,6 +38,16 @@ func TestValidConfig(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestValidateMode(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	for _, mode := range []string{"", modeAuto, modeStubStatus, modePlus} {
+		cfg.Mode = mode
+		require.NoError(t, cfg.Validate())
+	}
+	cfg.Mode = "oss"
+	require.EqualError(t, cfg.Validate(), `mode must be one of "auto", "stub_status" or "plus", got "oss"`)
+}
+
 func TestCreateMetricsReceiver(t *testing.T) {
 	factory := NewFactory()
 	metricsReceiver, err := factory.CreateMetricsReceiver(
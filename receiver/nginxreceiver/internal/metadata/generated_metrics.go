@@ -55,27 +55,69 @@ func (m *metricImpl) Init(metric pdata.Metric) {
 }
 
 type metricStruct struct {
-	NginxConnectionsAccepted MetricIntf
-	NginxConnectionsCurrent  MetricIntf
-	NginxConnectionsHandled  MetricIntf
-	NginxRequests            MetricIntf
+	NginxCacheBytes                    MetricIntf
+	NginxCacheResponses                MetricIntf
+	NginxCacheSize                     MetricIntf
+	NginxConnectionsAccepted           MetricIntf
+	NginxConnectionsCurrent            MetricIntf
+	NginxConnectionsHandled            MetricIntf
+	NginxRequests                      MetricIntf
+	NginxServerZoneRequests            MetricIntf
+	NginxServerZoneRequestsProcessing  MetricIntf
+	NginxServerZoneResponses           MetricIntf
+	NginxSslHandshakes                 MetricIntf
+	NginxSslHandshakesFailed           MetricIntf
+	NginxSslSessionReuses              MetricIntf
+	NginxUpstreamPeerConnectionsActive MetricIntf
+	NginxUpstreamPeerFails             MetricIntf
+	NginxUpstreamPeerRequests          MetricIntf
+	NginxUpstreamPeerResponses         MetricIntf
+	NginxUpstreamPeerState             MetricIntf
 }
 
 // Names returns a list of all the metric name strings.
 func (m *metricStruct) Names() []string {
 	return []string{
+		"nginx.cache.bytes",
+		"nginx.cache.responses",
+		"nginx.cache.size",
 		"nginx.connections_accepted",
 		"nginx.connections_current",
 		"nginx.connections_handled",
 		"nginx.requests",
+		"nginx.server_zone.requests",
+		"nginx.server_zone.requests_processing",
+		"nginx.server_zone.responses",
+		"nginx.ssl.handshakes",
+		"nginx.ssl.handshakes_failed",
+		"nginx.ssl.session_reuses",
+		"nginx.upstream.peer.connections_active",
+		"nginx.upstream.peer.fails",
+		"nginx.upstream.peer.requests",
+		"nginx.upstream.peer.responses",
+		"nginx.upstream.peer.state",
 	}
 }
 
 var metricsByName = map[string]MetricIntf{
-	"nginx.connections_accepted": Metrics.NginxConnectionsAccepted,
-	"nginx.connections_current":  Metrics.NginxConnectionsCurrent,
-	"nginx.connections_handled":  Metrics.NginxConnectionsHandled,
-	"nginx.requests":             Metrics.NginxRequests,
+	"nginx.cache.bytes":                      Metrics.NginxCacheBytes,
+	"nginx.cache.responses":                  Metrics.NginxCacheResponses,
+	"nginx.cache.size":                       Metrics.NginxCacheSize,
+	"nginx.connections_accepted":             Metrics.NginxConnectionsAccepted,
+	"nginx.connections_current":              Metrics.NginxConnectionsCurrent,
+	"nginx.connections_handled":              Metrics.NginxConnectionsHandled,
+	"nginx.requests":                         Metrics.NginxRequests,
+	"nginx.server_zone.requests":             Metrics.NginxServerZoneRequests,
+	"nginx.server_zone.requests_processing":  Metrics.NginxServerZoneRequestsProcessing,
+	"nginx.server_zone.responses":            Metrics.NginxServerZoneResponses,
+	"nginx.ssl.handshakes":                   Metrics.NginxSslHandshakes,
+	"nginx.ssl.handshakes_failed":            Metrics.NginxSslHandshakesFailed,
+	"nginx.ssl.session_reuses":               Metrics.NginxSslSessionReuses,
+	"nginx.upstream.peer.connections_active": Metrics.NginxUpstreamPeerConnectionsActive,
+	"nginx.upstream.peer.fails":              Metrics.NginxUpstreamPeerFails,
+	"nginx.upstream.peer.requests":           Metrics.NginxUpstreamPeerRequests,
+	"nginx.upstream.peer.responses":          Metrics.NginxUpstreamPeerResponses,
+	"nginx.upstream.peer.state":              Metrics.NginxUpstreamPeerState,
 }
 
 func (m *metricStruct) ByName(n string) MetricIntf {
@@ -84,16 +126,61 @@ func (m *metricStruct) ByName(n string) MetricIntf {
 
 func (m *metricStruct) FactoriesByName() map[string]func(pdata.Metric) {
 	return map[string]func(pdata.Metric){
-		Metrics.NginxConnectionsAccepted.Name(): Metrics.NginxConnectionsAccepted.Init,
-		Metrics.NginxConnectionsCurrent.Name():  Metrics.NginxConnectionsCurrent.Init,
-		Metrics.NginxConnectionsHandled.Name():  Metrics.NginxConnectionsHandled.Init,
-		Metrics.NginxRequests.Name():            Metrics.NginxRequests.Init,
+		Metrics.NginxCacheBytes.Name():                    Metrics.NginxCacheBytes.Init,
+		Metrics.NginxCacheResponses.Name():                Metrics.NginxCacheResponses.Init,
+		Metrics.NginxCacheSize.Name():                     Metrics.NginxCacheSize.Init,
+		Metrics.NginxConnectionsAccepted.Name():           Metrics.NginxConnectionsAccepted.Init,
+		Metrics.NginxConnectionsCurrent.Name():            Metrics.NginxConnectionsCurrent.Init,
+		Metrics.NginxConnectionsHandled.Name():            Metrics.NginxConnectionsHandled.Init,
+		Metrics.NginxRequests.Name():                      Metrics.NginxRequests.Init,
+		Metrics.NginxServerZoneRequests.Name():            Metrics.NginxServerZoneRequests.Init,
+		Metrics.NginxServerZoneRequestsProcessing.Name():  Metrics.NginxServerZoneRequestsProcessing.Init,
+		Metrics.NginxServerZoneResponses.Name():           Metrics.NginxServerZoneResponses.Init,
+		Metrics.NginxSslHandshakes.Name():                 Metrics.NginxSslHandshakes.Init,
+		Metrics.NginxSslHandshakesFailed.Name():           Metrics.NginxSslHandshakesFailed.Init,
+		Metrics.NginxSslSessionReuses.Name():              Metrics.NginxSslSessionReuses.Init,
+		Metrics.NginxUpstreamPeerConnectionsActive.Name(): Metrics.NginxUpstreamPeerConnectionsActive.Init,
+		Metrics.NginxUpstreamPeerFails.Name():             Metrics.NginxUpstreamPeerFails.Init,
+		Metrics.NginxUpstreamPeerRequests.Name():          Metrics.NginxUpstreamPeerRequests.Init,
+		Metrics.NginxUpstreamPeerResponses.Name():         Metrics.NginxUpstreamPeerResponses.Init,
+		Metrics.NginxUpstreamPeerState.Name():             Metrics.NginxUpstreamPeerState.Init,
 	}
 }
 
 // Metrics contains a set of methods for each metric that help with
 // manipulating those metrics.
 var Metrics = &metricStruct{
+	&metricImpl{
+		"nginx.cache.bytes",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.cache.bytes")
+			metric.SetDescription("The total number of bytes of the responses read from or bypassing the cache by cache status. Only reported by NGINX Plus.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.cache.responses",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.cache.responses")
+			metric.SetDescription("The total number of responses read from or bypassing the cache by cache status. Only reported by NGINX Plus.")
+			metric.SetUnit("responses")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.cache.size",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.cache.size")
+			metric.SetDescription("The current size of the cache. Only reported by NGINX Plus.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"nginx.connections_accepted",
 		func(metric pdata.Metric) {
@@ -136,6 +223,121 @@ var Metrics = &metricStruct{
 			metric.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"nginx.server_zone.requests",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.server_zone.requests")
+			metric.SetDescription("The total number of client requests received by the server zone. Only reported by NGINX Plus.")
+			metric.SetUnit("requests")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.server_zone.requests_processing",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.server_zone.requests_processing")
+			metric.SetDescription("The current number of client requests being processed by the server zone. Only reported by NGINX Plus.")
+			metric.SetUnit("requests")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"nginx.server_zone.responses",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.server_zone.responses")
+			metric.SetDescription("The total number of responses sent to clients by the server zone by status code class. Only reported by NGINX Plus.")
+			metric.SetUnit("responses")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.ssl.handshakes",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.ssl.handshakes")
+			metric.SetDescription("The total number of successful SSL handshakes. Only reported by NGINX Plus.")
+			metric.SetUnit("handshakes")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.ssl.handshakes_failed",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.ssl.handshakes_failed")
+			metric.SetDescription("The total number of failed SSL handshakes. Only reported by NGINX Plus.")
+			metric.SetUnit("handshakes")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.ssl.session_reuses",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.ssl.session_reuses")
+			metric.SetDescription("The total number of session reuses during SSL handshakes. Only reported by NGINX Plus.")
+			metric.SetUnit("handshakes")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.connections_active",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.connections_active")
+			metric.SetDescription("The current number of active connections to the upstream peer. Only reported by NGINX Plus.")
+			metric.SetUnit("connections")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.fails",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.fails")
+			metric.SetDescription("The total number of unsuccessful attempts to communicate with the upstream peer. Only reported by NGINX Plus.")
+			metric.SetUnit("fails")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.requests",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.requests")
+			metric.SetDescription("The total number of client requests forwarded to the upstream peer. Only reported by NGINX Plus.")
+			metric.SetUnit("requests")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.responses",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.responses")
+			metric.SetDescription("The total number of responses obtained from the upstream peer by status code class. Only reported by NGINX Plus.")
+			metric.SetUnit("responses")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.state",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.state")
+			metric.SetDescription("The state of the upstream peer, 1 for its current state and 0 for the others. Only reported by NGINX Plus.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 }
 
 // M contains a set of methods for each metric that help with
@@ -144,16 +346,73 @@ var M = Metrics
 
 // Labels contains the possible metric labels that can be used.
 var Labels = struct {
+	// Cache (The name of the cache)
+	Cache string
+	// CacheStatus (The cache status of a response)
+	CacheStatus string
+	// Peer (The address of the upstream peer)
+	Peer string
+	// PeerState (The state of an upstream peer)
+	PeerState string
 	// State (The state of a connection)
 	State string
+	// StatusCode (The class of the response status code (1xx, 2xx, 3xx, 4xx or 5xx))
+	StatusCode string
+	// Upstream (The name of the upstream group)
+	Upstream string
+	// Zone (The name of the server zone)
+	Zone string
 }{
+	"cache",
+	"cache_status",
+	"peer",
+	"peer_state",
 	"state",
+	"status_code",
+	"upstream",
+	"zone",
 }
 
 // L contains the possible metric labels that can be used. L is an alias for
 // Labels.
 var L = Labels
 
+// LabelCacheStatus are the possible values that the label "cache_status" can have.
+var LabelCacheStatus = struct {
+	Hit         string
+	Stale       string
+	Updating    string
+	Revalidated string
+	Miss        string
+	Expired     string
+	Bypass      string
+}{
+	"hit",
+	"stale",
+	"updating",
+	"revalidated",
+	"miss",
+	"expired",
+	"bypass",
+}
+
+// LabelPeerState are the possible values that the label "peer_state" can have.
+var LabelPeerState = struct {
+	Up        string
+	Draining  string
+	Down      string
+	Unavail   string
+	Checking  string
+	Unhealthy string
+}{
+	"up",
+	"draining",
+	"down",
+	"unavail",
+	"checking",
+	"unhealthy",
+}
+
 // LabelState are the possible values that the label "state" can have.
 var LabelState = struct {
 	Active  string
@@ -0,0 +1,185 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nginxreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+// plusClient is a client of the NGINX Plus REST API
+// (https://nginx.org/en/docs/http/ngx_http_api_module.html).
+type plusClient struct {
+	httpClient *http.Client
+	endpoint   string
+	version    int
+}
+
+type plusResponses struct {
+	Class1xx int64 `json:"1xx"`
+	Class2xx int64 `json:"2xx"`
+	Class3xx int64 `json:"3xx"`
+	Class4xx int64 `json:"4xx"`
+	Class5xx int64 `json:"5xx"`
+}
+
+type plusConnections struct {
+	Accepted int64 `json:"accepted"`
+	Dropped  int64 `json:"dropped"`
+	Active   int64 `json:"active"`
+	Idle     int64 `json:"idle"`
+}
+
+type plusHTTPRequests struct {
+	Total   int64 `json:"total"`
+	Current int64 `json:"current"`
+}
+
+type plusSSL struct {
+	Handshakes       int64 `json:"handshakes"`
+	HandshakesFailed int64 `json:"handshakes_failed"`
+	SessionReuses    int64 `json:"session_reuses"`
+}
+
+type plusServerZone struct {
+	Processing int64         `json:"processing"`
+	Requests   int64         `json:"requests"`
+	Responses  plusResponses `json:"responses"`
+}
+
+type plusPeer struct {
+	Server    string        `json:"server"`
+	State     string        `json:"state"`
+	Active    int64         `json:"active"`
+	Requests  int64         `json:"requests"`
+	Responses plusResponses `json:"responses"`
+	Fails     int64         `json:"fails"`
+}
+
+type plusUpstream struct {
+	Peers []plusPeer `json:"peers"`
+}
+
+type plusCacheStats struct {
+	Responses int64 `json:"responses"`
+	Bytes     int64 `json:"bytes"`
+}
+
+type plusCache struct {
+	Size        int64          `json:"size"`
+	Hit         plusCacheStats `json:"hit"`
+	Stale       plusCacheStats `json:"stale"`
+	Updating    plusCacheStats `json:"updating"`
+	Revalidated plusCacheStats `json:"revalidated"`
+	Miss        plusCacheStats `json:"miss"`
+	Expired     plusCacheStats `json:"expired"`
+	Bypass      plusCacheStats `json:"bypass"`
+}
+
+// plusStats are the statistics reported by the NGINX Plus API. The statistics
+// of endpoints that couldn't be queried are nil.
+type plusStats struct {
+	Connections  *plusConnections
+	HTTPRequests *plusHTTPRequests
+	SSL          *plusSSL
+	ServerZones  map[string]plusServerZone
+	Upstreams    map[string]plusUpstream
+	Caches       map[string]plusCache
+}
+
+// newPlusClient returns a client of the NGINX Plus API at the given endpoint, e.g.
+// http://localhost:8080/api, using the latest API version the endpoint supports.
+func newPlusClient(ctx context.Context, httpClient *http.Client, endpoint string) (*plusClient, error) {
+	c := &plusClient{
+		httpClient: httpClient,
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+	}
+	var versions []int
+	if err := c.get(ctx, "", &versions); err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if v > c.version {
+			c.version = v
+		}
+	}
+	if c.version == 0 {
+		return nil, fmt.Errorf("no NGINX Plus API versions reported by %s", endpoint)
+	}
+	return c, nil
+}
+
+// isPlusAPIResponse returns whether the given response body is the list of API
+// versions returned by the root of the NGINX Plus API.
+func isPlusAPIResponse(body []byte) bool {
+	var versions []int
+	return json.Unmarshal(body, &versions) == nil && len(versions) > 0
+}
+
+// getStats queries each endpoint of the API for its statistics. Endpoints that
+// fail are skipped, and their errors are returned as a partial scrape error
+// counting the metrics they would have reported.
+func (c *plusClient) getStats(ctx context.Context) (*plusStats, error) {
+	stats := &plusStats{}
+	var errs scrapererror.ScrapeErrors
+	for _, endpoint := range []struct {
+		path    string
+		metrics int
+		v       interface{}
+	}{
+		{"connections", 3, &stats.Connections},
+		{"http/requests", 1, &stats.HTTPRequests},
+		{"ssl", 3, &stats.SSL},
+		{"http/server_zones", 3, &stats.ServerZones},
+		{"http/upstreams", 5, &stats.Upstreams},
+		{"http/caches", 3, &stats.Caches},
+	} {
+		if err := c.get(ctx, fmt.Sprintf("%d/%s", c.version, endpoint.path), endpoint.v); err != nil {
+			errs.AddPartial(endpoint.metrics, err)
+		}
+	}
+	return stats, errs.Combine()
+}
+
+func (c *plusClient) get(ctx context.Context, path string, v interface{}) error {
+	url := c.endpoint + "/" + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request to %s: %w", url, err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("expected 200 response from %s, got %d", url, resp.StatusCode)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body from %s: %w", url, err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to parse response body from %s: %w", url, err)
+	}
+	return nil
+}
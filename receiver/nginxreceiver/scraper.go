@@ -16,7 +16,9 @@ package nginxreceiver
 
 import (
 	"context"
+	"io/ioutil"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/nginxinc/nginx-prometheus-exporter/client"
@@ -30,6 +32,9 @@ import (
 type nginxScraper struct {
 	httpClient *http.Client
 	client     *client.NginxClient
+	plusClient *plusClient
+	// The mode detected from the endpoint response in auto mode.
+	detectedMode string
 
 	logger *zap.Logger
 	cfg    *Config
@@ -55,7 +60,11 @@ func (r *nginxScraper) start(_ context.Context, host component.Host) error {
 	return nil
 }
 
-func (r *nginxScraper) scrape(context.Context) (pdata.ResourceMetricsSlice, error) {
+func (r *nginxScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	if r.mode(ctx) == modePlus {
+		return r.scrapePlus(ctx)
+	}
+
 	// Init client in scrape method in case there are transient errors in the
 	// constructor.
 	if r.client == nil {
@@ -107,3 +116,213 @@ func addCurrentConnectionDataPoint(dps pdata.NumberDataPointSlice, stateValue st
 	dp.SetTimestamp(now)
 	dp.SetIntVal(value)
 }
+
+// mode returns the mode to scrape the endpoint in. In auto mode, the endpoint is
+// the NGINX Plus API if it responds with the list of API versions. When the
+// endpoint can't be queried, the stub status mode is used until it can be.
+func (r *nginxScraper) mode(ctx context.Context) string {
+	if r.cfg.Mode == modeStubStatus || r.cfg.Mode == modePlus {
+		return r.cfg.Mode
+	}
+	if r.detectedMode != "" {
+		return r.detectedMode
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.cfg.HTTPClientSettings.Endpoint, nil)
+	if err != nil {
+		return modeStubStatus
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return modeStubStatus
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return modeStubStatus
+	}
+
+	r.detectedMode = modeStubStatus
+	if isPlusAPIResponse(body) {
+		r.detectedMode = modePlus
+	}
+	r.logger.Debug("Detected nginx endpoint mode", zap.String("mode", r.detectedMode))
+	return r.detectedMode
+}
+
+func (r *nginxScraper) scrapePlus(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	if r.plusClient == nil {
+		var err error
+		r.plusClient, err = newPlusClient(ctx, r.httpClient, r.cfg.HTTPClientSettings.Endpoint)
+		if err != nil {
+			r.plusClient = nil
+			return pdata.ResourceMetricsSlice{}, err
+		}
+	}
+
+	stats, err := r.plusClient.getStats(ctx)
+	if err != nil {
+		r.logger.Error("Failed to fetch some nginx plus stats", zap.Error(err))
+	}
+
+	now := pdata.TimestampFromTime(time.Now())
+	metrics := pdata.NewMetrics()
+	ilm := metrics.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName("otelcol/nginx")
+	ms := ilm.Metrics()
+
+	if stats.HTTPRequests != nil {
+		addIntSum(ms, metadata.M.NginxRequests.Init, now, stats.HTTPRequests.Total)
+	}
+	if stats.Connections != nil {
+		addIntSum(ms, metadata.M.NginxConnectionsAccepted.Init, now, stats.Connections.Accepted)
+		addIntSum(ms, metadata.M.NginxConnectionsHandled.Init, now, stats.Connections.Accepted-stats.Connections.Dropped)
+
+		currConnMetric := ms.AppendEmpty()
+		metadata.M.NginxConnectionsCurrent.Init(currConnMetric)
+		dps := currConnMetric.Gauge().DataPoints()
+		addCurrentConnectionDataPoint(dps, metadata.LabelState.Active, now, stats.Connections.Active)
+		addCurrentConnectionDataPoint(dps, metadata.LabelState.Waiting, now, stats.Connections.Idle)
+	}
+	if stats.SSL != nil {
+		addIntSum(ms, metadata.M.NginxSslHandshakes.Init, now, stats.SSL.Handshakes)
+		addIntSum(ms, metadata.M.NginxSslHandshakesFailed.Init, now, stats.SSL.HandshakesFailed)
+		addIntSum(ms, metadata.M.NginxSslSessionReuses.Init, now, stats.SSL.SessionReuses)
+	}
+
+	addServerZoneMetrics(ms, now, stats.ServerZones)
+	addUpstreamMetrics(ms, now, stats.Upstreams)
+	addCacheMetrics(ms, now, stats.Caches)
+
+	return metrics.ResourceMetrics(), err
+}
+
+func addServerZoneMetrics(ms pdata.MetricSlice, now pdata.Timestamp, zones map[string]plusServerZone) {
+	if len(zones) == 0 {
+		return
+	}
+	requests := newMetric(ms, metadata.M.NginxServerZoneRequests.Init).Sum().DataPoints()
+	responses := newMetric(ms, metadata.M.NginxServerZoneResponses.Init).Sum().DataPoints()
+	processing := newMetric(ms, metadata.M.NginxServerZoneRequestsProcessing.Init).Gauge().DataPoints()
+	names := make([]string, 0, len(zones))
+	for name := range zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		zone := zones[name]
+		labels := map[string]string{metadata.L.Zone: name}
+		addIntDataPoint(requests, now, zone.Requests, labels)
+		addResponsesDataPoints(responses, now, zone.Responses, labels)
+		addIntDataPoint(processing, now, zone.Processing, labels)
+	}
+}
+
+var peerStates = []string{
+	metadata.LabelPeerState.Up,
+	metadata.LabelPeerState.Draining,
+	metadata.LabelPeerState.Down,
+	metadata.LabelPeerState.Unavail,
+	metadata.LabelPeerState.Checking,
+	metadata.LabelPeerState.Unhealthy,
+}
+
+func addUpstreamMetrics(ms pdata.MetricSlice, now pdata.Timestamp, upstreams map[string]plusUpstream) {
+	if len(upstreams) == 0 {
+		return
+	}
+	requests := newMetric(ms, metadata.M.NginxUpstreamPeerRequests.Init).Sum().DataPoints()
+	responses := newMetric(ms, metadata.M.NginxUpstreamPeerResponses.Init).Sum().DataPoints()
+	fails := newMetric(ms, metadata.M.NginxUpstreamPeerFails.Init).Sum().DataPoints()
+	active := newMetric(ms, metadata.M.NginxUpstreamPeerConnectionsActive.Init).Gauge().DataPoints()
+	state := newMetric(ms, metadata.M.NginxUpstreamPeerState.Init).Gauge().DataPoints()
+	names := make([]string, 0, len(upstreams))
+	for name := range upstreams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, peer := range upstreams[name].Peers {
+			labels := map[string]string{metadata.L.Upstream: name, metadata.L.Peer: peer.Server}
+			addIntDataPoint(requests, now, peer.Requests, labels)
+			addResponsesDataPoints(responses, now, peer.Responses, labels)
+			addIntDataPoint(fails, now, peer.Fails, labels)
+			addIntDataPoint(active, now, peer.Active, labels)
+			for _, peerState := range peerStates {
+				var value int64
+				if peer.State == peerState {
+					value = 1
+				}
+				addIntDataPoint(state, now, value, withLabel(labels, metadata.L.PeerState, peerState))
+			}
+		}
+	}
+}
+
+func addCacheMetrics(ms pdata.MetricSlice, now pdata.Timestamp, caches map[string]plusCache) {
+	if len(caches) == 0 {
+		return
+	}
+	responses := newMetric(ms, metadata.M.NginxCacheResponses.Init).Sum().DataPoints()
+	bytes := newMetric(ms, metadata.M.NginxCacheBytes.Init).Sum().DataPoints()
+	size := newMetric(ms, metadata.M.NginxCacheSize.Init).Gauge().DataPoints()
+	names := make([]string, 0, len(caches))
+	for name := range caches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cache := caches[name]
+		labels := map[string]string{metadata.L.Cache: name}
+		for _, status := range []struct {
+			name  string
+			stats plusCacheStats
+		}{
+			{metadata.LabelCacheStatus.Hit, cache.Hit},
+			{metadata.LabelCacheStatus.Stale, cache.Stale},
+			{metadata.LabelCacheStatus.Updating, cache.Updating},
+			{metadata.LabelCacheStatus.Revalidated, cache.Revalidated},
+			{metadata.LabelCacheStatus.Miss, cache.Miss},
+			{metadata.LabelCacheStatus.Expired, cache.Expired},
+			{metadata.LabelCacheStatus.Bypass, cache.Bypass},
+		} {
+			statusLabels := withLabel(labels, metadata.L.CacheStatus, status.name)
+			addIntDataPoint(responses, now, status.stats.Responses, statusLabels)
+			addIntDataPoint(bytes, now, status.stats.Bytes, statusLabels)
+		}
+		addIntDataPoint(size, now, cache.Size, labels)
+	}
+}
+
+func addResponsesDataPoints(dps pdata.NumberDataPointSlice, now pdata.Timestamp, responses plusResponses, labels map[string]string) {
+	addIntDataPoint(dps, now, responses.Class1xx, withLabel(labels, metadata.L.StatusCode, "1xx"))
+	addIntDataPoint(dps, now, responses.Class2xx, withLabel(labels, metadata.L.StatusCode, "2xx"))
+	addIntDataPoint(dps, now, responses.Class3xx, withLabel(labels, metadata.L.StatusCode, "3xx"))
+	addIntDataPoint(dps, now, responses.Class4xx, withLabel(labels, metadata.L.StatusCode, "4xx"))
+	addIntDataPoint(dps, now, responses.Class5xx, withLabel(labels, metadata.L.StatusCode, "5xx"))
+}
+
+func newMetric(metrics pdata.MetricSlice, initFunc func(pdata.Metric)) pdata.Metric {
+	metric := metrics.AppendEmpty()
+	initFunc(metric)
+	return metric
+}
+
+func addIntDataPoint(dps pdata.NumberDataPointSlice, now pdata.Timestamp, value int64, labels map[string]string) {
+	dp := dps.AppendEmpty()
+	for k, v := range labels {
+		dp.LabelsMap().Upsert(k, v)
+	}
+	dp.SetTimestamp(now)
+	dp.SetIntVal(value)
+}
+
+// withLabel returns a copy of the given labels with the given label added.
+func withLabel(labels map[string]string, key, value string) map[string]string {
+	out := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		out[k] = v
+	}
+	out[key] = value
+	return out
+}
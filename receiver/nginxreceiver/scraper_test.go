@@ -22,11 +22,13 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nginxreceiver/internal/metadata"
@@ -99,6 +101,164 @@ Reading: 6 Writing: 179 Waiting: 106
 	}, metricValues)
 }
 
+var plusAPIResponses = map[string]string{
+	"/api/":                    `[1,2,3,4,5,6]`,
+	"/api/6/connections":       `{"accepted":100,"dropped":2,"active":7,"idle":3}`,
+	"/api/6/http/requests":     `{"total":500,"current":4}`,
+	"/api/6/ssl":               `{"handshakes":80,"handshakes_failed":5,"session_reuses":20}`,
+	"/api/6/http/server_zones": `{"site":{"processing":1,"requests":450,"responses":{"1xx":0,"2xx":400,"3xx":10,"4xx":30,"5xx":10,"total":450}}}`,
+	"/api/6/http/upstreams": `{"backend":{"peers":[{"id":0,"server":"10.0.0.1:80","state":"up","active":2,"requests":300,` +
+		`"responses":{"1xx":0,"2xx":290,"3xx":0,"4xx":5,"5xx":5,"total":300},"fails":1}],"zone":"backend"}}`,
+	"/api/6/http/caches": `{"static":{"size":1024,"hit":{"responses":40,"bytes":4000},"stale":{"responses":0,"bytes":0},` +
+		`"updating":{"responses":0,"bytes":0},"revalidated":{"responses":0,"bytes":0},"miss":{"responses":10,"bytes":1000},` +
+		`"expired":{"responses":2,"bytes":200},"bypass":{"responses":1,"bytes":100}}}`,
+}
+
+func TestScraperPlus(t *testing.T) {
+	nginxMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if resp, ok := plusAPIResponses[req.URL.Path]; ok {
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(200)
+			_, _ = rw.Write([]byte(resp))
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+	defer nginxMock.Close()
+
+	for _, mode := range []string{"", modeAuto, modePlus} {
+		t.Run("mode "+mode, func(t *testing.T) {
+			sc := newNginxScraper(zap.NewNop(), &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: nginxMock.URL + "/api/",
+				},
+				Mode: mode,
+			})
+			err := sc.start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+			rms, err := sc.scrape(context.Background())
+			require.NoError(t, err)
+
+			require.Equal(t, 1, rms.Len())
+			ilms := rms.At(0).InstrumentationLibraryMetrics()
+			require.Equal(t, 1, ilms.Len())
+			ms := ilms.At(0).Metrics()
+
+			metricValues := make(map[string]int64)
+			for i := 0; i < ms.Len(); i++ {
+				m := ms.At(i)
+				var dps pdata.NumberDataPointSlice
+				switch m.DataType() {
+				case pdata.MetricDataTypeGauge:
+					dps = m.Gauge().DataPoints()
+				case pdata.MetricDataTypeSum:
+					dps = m.Sum().DataPoints()
+				}
+				for j := 0; j < dps.Len(); j++ {
+					dp := dps.At(j)
+					key := m.Name()
+					for _, label := range []string{
+						metadata.L.State, metadata.L.Zone, metadata.L.Upstream, metadata.L.Peer,
+						metadata.L.PeerState, metadata.L.Cache, metadata.L.CacheStatus, metadata.L.StatusCode,
+					} {
+						if v, ok := dp.LabelsMap().Get(label); ok {
+							key += fmt.Sprintf(" %s:%s", label, v)
+						}
+					}
+					metricValues[key] = dp.IntVal()
+				}
+			}
+
+			require.Equal(t, 18, ms.Len())
+			for key, value := range map[string]int64{
+				"nginx.requests":                                                                  500,
+				"nginx.connections_accepted":                                                      100,
+				"nginx.connections_handled":                                                       98,
+				"nginx.connections_current state:active":                                          7,
+				"nginx.connections_current state:waiting":                                         3,
+				"nginx.ssl.handshakes":                                                            80,
+				"nginx.ssl.handshakes_failed":                                                     5,
+				"nginx.ssl.session_reuses":                                                        20,
+				"nginx.server_zone.requests zone:site":                                            450,
+				"nginx.server_zone.requests_processing zone:site":                                 1,
+				"nginx.server_zone.responses zone:site status_code:2xx":                           400,
+				"nginx.server_zone.responses zone:site status_code:4xx":                           30,
+				"nginx.upstream.peer.requests upstream:backend peer:10.0.0.1:80":                  300,
+				"nginx.upstream.peer.responses upstream:backend peer:10.0.0.1:80 status_code:5xx": 5,
+				"nginx.upstream.peer.fails upstream:backend peer:10.0.0.1:80":                     1,
+				"nginx.upstream.peer.connections_active upstream:backend peer:10.0.0.1:80":        2,
+				"nginx.upstream.peer.state upstream:backend peer:10.0.0.1:80 peer_state:up":       1,
+				"nginx.upstream.peer.state upstream:backend peer:10.0.0.1:80 peer_state:down":     0,
+				"nginx.cache.size cache:static":                                                   1024,
+				"nginx.cache.responses cache:static cache_status:hit":                             40,
+				"nginx.cache.bytes cache:static cache_status:miss":                                1000,
+			} {
+				assert.Equal(t, value, metricValues[key], key)
+			}
+		})
+	}
+}
+
+func TestScraperPlusError(t *testing.T) {
+	nginxMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/api/" {
+			rw.WriteHeader(200)
+			_, _ = rw.Write([]byte(`[6]`))
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+	defer nginxMock.Close()
+
+	sc := newNginxScraper(zap.NewNop(), &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: nginxMock.URL + "/api",
+		},
+		Mode: modePlus,
+	})
+	err := sc.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	rms, err := sc.scrape(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "expected 200 response from "+nginxMock.URL+"/api/6/")
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.Equal(t, 18, err.(scrapererror.PartialScrapeError).Failed)
+	require.Equal(t, 0, rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics().Len())
+}
+
+func TestScraperPlusPartialError(t *testing.T) {
+	nginxMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if resp, ok := plusAPIResponses[req.URL.Path]; ok && req.URL.Path != "/api/6/ssl" {
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(200)
+			_, _ = rw.Write([]byte(resp))
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+	defer nginxMock.Close()
+
+	sc := newNginxScraper(zap.NewNop(), &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: nginxMock.URL + "/api",
+		},
+		Mode: modePlus,
+	})
+	err := sc.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	rms, err := sc.scrape(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "expected 200 response from "+nginxMock.URL+"/api/6/ssl")
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.Equal(t, 3, err.(scrapererror.PartialScrapeError).Failed)
+
+	ms := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 15, ms.Len())
+	for i := 0; i < ms.Len(); i++ {
+		require.NotContains(t, ms.At(i).Name(), "nginx.ssl.")
+	}
+}
+
 func TestScraperError(t *testing.T) {
 	nginxMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.Path == "/status" {
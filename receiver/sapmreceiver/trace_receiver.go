@@ -24,6 +24,7 @@ import (
 	"sync"
 
 	"github.com/gorilla/mux"
+	"github.com/klauspost/compress/zstd"
 	splunksapm "github.com/signalfx/sapm-proto/gen"
 	"github.com/signalfx/sapm-proto/sapmprotocol"
 	"go.opentelemetry.io/collector/component"
@@ -31,10 +32,20 @@ import (
 	"go.opentelemetry.io/collector/obsreport"
 	jaegertranslator "go.opentelemetry.io/collector/translator/trace/jaeger"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
 
+const (
+	zstdEncodingHeaderValue = "zstd"
+
+	// maxRequestBodySize is the maximum size of a request body as sent, compressed or not.
+	maxRequestBodySize = 64 << 20
+	// maxZstdDecodedBodySize is the maximum size of a zstd request body once decompressed.
+	maxZstdDecodedBodySize = 256 << 20
+)
+
 var gzipWriterPool = &sync.Pool{
 	New: func() interface{} {
 		return gzip.NewWriter(ioutil.Discard)
@@ -50,6 +61,9 @@ type sapmReceiver struct {
 
 	nextConsumer consumer.Traces
 
+	// zstdDecoder is only used through DecodeAll, which is safe for concurrent use.
+	zstdDecoder *zstd.Decoder
+
 	// defaultResponse is a placeholder. For now this receiver returns an empty sapm response.
 	// This defaultResponse is an optimization so we don't have to proto.Marshal the response
 	// for every request. At some point this may be removed when there is actual content to return.
@@ -60,8 +74,11 @@ type sapmReceiver struct {
 
 // handleRequest parses an http request containing sapm and passes the trace data to the next consumer
 func (sr *sapmReceiver) handleRequest(req *http.Request) error {
-	sapm, err := sapmprotocol.ParseTraceV2Request(req)
 	// errors processing the request should return http.StatusBadRequest
+	if err := sr.decodeZstdBody(req); err != nil {
+		return err
+	}
+	sapm, err := sapmprotocol.ParseTraceV2Request(req)
 	if err != nil {
 		return err
 	}
@@ -72,6 +89,7 @@ func (sr *sapmReceiver) handleRequest(req *http.Request) error {
 
 	if sr.config.AccessTokenPassthrough {
 		if accessToken := req.Header.Get(splunk.SFxAccessTokenHeader); accessToken != "" {
+			ctx = contextWithAccessToken(ctx, accessToken)
 			rSpans := td.ResourceSpans()
 			for i := 0; i < rSpans.Len(); i++ {
 				rSpan := rSpans.At(i)
@@ -91,8 +109,39 @@ func (sr *sapmReceiver) handleRequest(req *http.Request) error {
 	return err
 }
 
+// decodeZstdBody replaces a zstd encoded request body with its decompressed content
+// since sapmprotocol only handles gzip.
+func (sr *sapmReceiver) decodeZstdBody(req *http.Request) error {
+	if req.Header.Get(sapmprotocol.ContentEncodingHeaderName) != zstdEncodingHeaderValue {
+		return nil
+	}
+	compressed, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	body, err := sr.zstdDecoder.DecodeAll(compressed, nil)
+	if err != nil {
+		return fmt.Errorf("failed to decode zstd request body: %w", err)
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.Header.Del(sapmprotocol.ContentEncodingHeaderName)
+	return nil
+}
+
+// contextWithAccessToken adds the access token to the incoming metadata of ctx so
+// downstream components like the routing processor can act on it.
+func contextWithAccessToken(ctx context.Context, accessToken string) context.Context {
+	md := metadata.Pairs(splunk.SFxAccessTokenHeader, accessToken)
+	if existing, ok := metadata.FromIncomingContext(ctx); ok {
+		md = metadata.Join(existing, md)
+	}
+	return metadata.NewIncomingContext(ctx, md)
+}
+
 // HTTPHandlerFunc returns an http.HandlerFunc that handles SAPM requests
 func (sr *sapmReceiver) HTTPHandlerFunc(rw http.ResponseWriter, req *http.Request) {
+	req.Body = http.MaxBytesReader(rw, req.Body, maxRequestBodySize)
+
 	// handle the request payload
 	err := sr.handleRequest(req)
 	if err != nil {
@@ -193,6 +242,10 @@ func newReceiver(
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal default response body for %v receiver: %w", config.ID(), err)
 	}
+	zstdDecoder, err := zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxZstdDecodedBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd decoder for %v receiver: %w", config.ID(), err)
+	}
 	transport := "http"
 	if config.TLSSetting != nil {
 		transport = "https"
@@ -201,6 +254,7 @@ func newReceiver(
 		logger:          params.Logger,
 		config:          config,
 		nextConsumer:    nextConsumer,
+		zstdDecoder:     zstdDecoder,
 		defaultResponse: defaultResponseBytes,
 		obsrecv:         obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: config.ID(), Transport: transport}),
 	}, nil
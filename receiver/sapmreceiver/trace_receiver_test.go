@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/jaegertracing/jaeger/model"
+	"github.com/klauspost/compress/zstd"
 	splunksapm "github.com/signalfx/sapm-proto/gen"
 	"github.com/signalfx/sapm-proto/sapmprotocol"
 	"github.com/stretchr/testify/assert"
@@ -34,11 +35,13 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/testutil"
 	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
 	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
@@ -210,7 +213,7 @@ func sendSapm(endpoint string, sapm *splunksapm.PostSpansRequest, zipped bool, t
 	return resp, nil
 }
 
-func setupReceiver(t *testing.T, config *Config, sink *consumertest.TracesSink) component.TracesReceiver {
+func setupReceiver(t *testing.T, config *Config, sink consumer.Traces) component.TracesReceiver {
 	params := componenttest.NewNopReceiverCreateSettings()
 	sr, err := newReceiver(params, config, sink)
 	assert.NoError(t, err, "should not have failed to create the SAPM receiver")
@@ -360,7 +363,7 @@ func TestAccessTokenPassthrough(t *testing.T) {
 				Batches: []*model.Batch{grpcFixture(time.Now().UTC())},
 			}
 
-			sink := new(consumertest.TracesSink)
+			sink := &metadataTracesSink{TracesSink: new(consumertest.TracesSink)}
 			sr := setupReceiver(t, config, sink)
 			defer sr.Shutdown(context.Background())
 
@@ -372,6 +375,12 @@ func TestAccessTokenPassthrough(t *testing.T) {
 			got := sink.AllTraces()
 			assert.Equal(t, 1, len(got))
 
+			if tt.accessTokenPassthrough && tt.token != "" {
+				assert.Equal(t, []string{tt.token}, sink.md.Get(splunk.SFxAccessTokenHeader))
+			} else {
+				assert.Empty(t, sink.md.Get(splunk.SFxAccessTokenHeader))
+			}
+
 			received := got[0].ResourceSpans()
 			for i := 0; i < received.Len(); i++ {
 				rspan := received.At(i)
@@ -387,6 +396,60 @@ func TestAccessTokenPassthrough(t *testing.T) {
 	}
 }
 
+func TestReceptionZstd(t *testing.T) {
+	now := time.Unix(1542158650, 536343000).UTC()
+	config := &Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: defaultEndpoint,
+		},
+	}
+	sink := new(consumertest.TracesSink)
+	sr := setupReceiver(t, config, sink)
+	defer sr.Shutdown(context.Background())
+
+	sapm := &splunksapm.PostSpansRequest{Batches: []*model.Batch{grpcFixture(now)}}
+	reqBytes, err := sapm.Marshal()
+	require.NoError(t, err)
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	body := encoder.EncodeAll(reqBytes, nil)
+
+	url := fmt.Sprintf("http://%s%s", config.Endpoint, sapmprotocol.TraceEndpointV2)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set(sapmprotocol.ContentTypeHeaderName, sapmprotocol.ContentTypeHeaderValue)
+	req.Header.Set(sapmprotocol.ContentEncodingHeaderName, zstdEncodingHeaderValue)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, 200, resp.StatusCode)
+
+	got := sink.AllTraces()
+	require.Equal(t, 1, len(got))
+	assert.EqualValues(t, expectedTraceData(now, now.Add(10*time.Minute), now.Add(10*time.Minute).Add(2*time.Second)), got[0])
+
+	// A body that is not valid zstd is rejected.
+	req, err = http.NewRequest(http.MethodPost, url, bytes.NewReader(reqBytes))
+	require.NoError(t, err)
+	req.Header.Set(sapmprotocol.ContentTypeHeaderName, sapmprotocol.ContentTypeHeaderValue)
+	req.Header.Set(sapmprotocol.ContentEncodingHeaderName, zstdEncodingHeaderValue)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// metadataTracesSink records the incoming metadata of the last context it consumed.
+type metadataTracesSink struct {
+	*consumertest.TracesSink
+	md metadata.MD
+}
+
+func (s *metadataTracesSink) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	s.md, _ = metadata.FromIncomingContext(ctx)
+	return s.TracesSink.ConsumeTraces(ctx, td)
+}
+
 // assertNoErrorHost implements a component.Host that asserts that there were no errors.
 type assertNoErrorHost struct {
 	component.Host
@@ -0,0 +1,101 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skywalkingreceiver
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+const (
+	// Value of "type" key in configuration.
+	typeStr = "skywalking"
+
+	// Default endpoint to bind to, the gRPC port of the SkyWalking OAP server.
+	defaultEndpoint = "0.0.0.0:11800"
+)
+
+// NewFactory creates a factory for the SkyWalking receiver.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithTraces(createTracesReceiver),
+		receiverhelper.WithMetrics(createMetricsReceiver))
+}
+
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+		GRPCServerSettings: configgrpc.GRPCServerSettings{
+			NetAddr: confignet.NetAddr{
+				Endpoint:  defaultEndpoint,
+				Transport: "tcp",
+			},
+		},
+	}
+}
+
+// createTracesReceiver creates a receiver accepting the trace segments of the agents.
+func createTracesReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Traces,
+) (component.TracesReceiver, error) {
+	r := getReceiver(params, cfg.(*Config))
+	if err := r.registerTracesConsumer(nextConsumer); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// createMetricsReceiver creates a receiver accepting the meters of the agents.
+func createMetricsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	r := getReceiver(params, cfg.(*Config))
+	if err := r.registerMetricsConsumer(nextConsumer); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// getReceiver returns the receiver of rCfg, so that the traces and metrics pipelines share the
+// same server.
+func getReceiver(params component.ReceiverCreateSettings, rCfg *Config) *swReceiver {
+	receiverLock.Lock()
+	defer receiverLock.Unlock()
+
+	r := receivers[rCfg]
+	if r == nil {
+		r = newReceiver(params.Logger, rCfg)
+		receivers[rCfg] = r
+	}
+	return r
+}
+
+var receiverLock sync.Mutex
+var receivers = map[*Config]*swReceiver{}
@@ -0,0 +1,13 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/receiver/skywalkingreceiver
+
+go 1.16
+
+require (
+	github.com/mattn/go-colorable v0.1.7 // indirect
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/collector v0.31.1-0.20210810171211-8038673eba9e
+	go.opentelemetry.io/collector/model v0.31.1-0.20210810171211-8038673eba9e
+	go.uber.org/zap v1.19.0
+	google.golang.org/grpc v1.40.0
+	skywalking.apache.org/repo/goapi v0.0.0-20211122071111-ffc517fbfe21
+)
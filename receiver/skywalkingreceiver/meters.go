@@ -0,0 +1,112 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skywalkingreceiver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+	agent "skywalking.apache.org/repo/goapi/collect/language/agent/v3"
+)
+
+// metersToMetrics converts the meters an agent reported in a period into metrics: the single
+// values become gauges and the histograms cumulative histograms. The service, instance and
+// timestamp of the meters are only set on the first of them.
+func metersToMetrics(meters []*agent.MeterData) pdata.Metrics {
+	md := pdata.NewMetrics()
+	if len(meters) == 0 {
+		return md
+	}
+
+	first := meters[0]
+	rm := md.ResourceMetrics().AppendEmpty()
+	attrs := rm.Resource().Attributes()
+	if service := first.GetService(); service != "" {
+		attrs.InsertString(conventions.AttributeServiceName, service)
+	}
+	if instance := first.GetServiceInstance(); instance != "" {
+		attrs.InsertString(conventions.AttributeServiceInstanceID, instance)
+	}
+
+	ts := pdata.TimestampFromTime(time.Now())
+	if millis := first.GetTimestamp(); millis > 0 {
+		ts = millisToTimestamp(millis)
+	}
+
+	metrics := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	// The meters with different labels are sent separately, they are gathered into the data
+	// points of a single metric.
+	byName := map[string]pdata.Metric{}
+	getMetric := func(name string, dataType pdata.MetricDataType) pdata.Metric {
+		if metric, ok := byName[name]; ok && metric.DataType() == dataType {
+			return metric
+		}
+		metric := metrics.AppendEmpty()
+		metric.SetName(name)
+		metric.SetDataType(dataType)
+		byName[name] = metric
+		return metric
+	}
+
+	for _, meter := range meters {
+		if single := meter.GetSingleValue(); single != nil {
+			dp := getMetric(single.GetName(), pdata.MetricDataTypeGauge).Gauge().DataPoints().AppendEmpty()
+			dp.SetTimestamp(ts)
+			dp.SetDoubleVal(single.GetValue())
+			for _, label := range single.GetLabels() {
+				dp.Attributes().UpsertString(label.GetName(), label.GetValue())
+			}
+		} else if histogram := meter.GetHistogram(); histogram != nil {
+			metric := getMetric(histogram.GetName(), pdata.MetricDataTypeHistogram)
+			metric.Histogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+			dp := metric.Histogram().DataPoints().AppendEmpty()
+			dp.SetTimestamp(ts)
+			fillHistogramDataPoint(histogram.GetValues(), dp)
+			for _, label := range histogram.GetLabels() {
+				dp.Attributes().UpsertString(label.GetName(), label.GetValue())
+			}
+		}
+	}
+	return md
+}
+
+// fillHistogramDataPoint fills dp with the buckets of a SkyWalking histogram. Those buckets are
+// keyed by their lower bound, which is the upper bound of the previous bucket. Values below the
+// first bound are counted in an additional empty bucket, unless the first bucket is unbounded.
+func fillHistogramDataPoint(buckets []*agent.MeterBucketValue, dp pdata.HistogramDataPoint) {
+	bounds := make([]float64, 0, len(buckets))
+	counts := make([]uint64, 0, len(buckets)+1)
+	if len(buckets) == 0 || !buckets[0].GetIsNegativeInfinity() {
+		counts = append(counts, 0)
+	}
+
+	var count uint64
+	for i, bucket := range buckets {
+		if i > 0 || !bucket.GetIsNegativeInfinity() {
+			bounds = append(bounds, bucket.GetBucket())
+		}
+		var bucketCount uint64
+		if c := bucket.GetCount(); c > 0 {
+			bucketCount = uint64(c)
+		}
+		counts = append(counts, bucketCount)
+		count += bucketCount
+	}
+
+	dp.SetExplicitBounds(bounds)
+	dp.SetBucketCounts(counts)
+	dp.SetCount(count)
+}
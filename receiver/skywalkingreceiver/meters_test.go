@@ -0,0 +1,141 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skywalkingreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+	agent "skywalking.apache.org/repo/goapi/collect/language/agent/v3"
+)
+
+func testMeters() []*agent.MeterData {
+	return []*agent.MeterData{
+		{
+			Service:         "order",
+			ServiceInstance: "order-1@10.0.0.1",
+			Timestamp:       1629274821001,
+			Metric: &agent.MeterData_SingleValue{SingleValue: &agent.MeterSingleValue{
+				Name:   "thread_pool_active",
+				Labels: []*agent.Label{{Name: "pool", Value: "http"}},
+				Value:  4,
+			}},
+		},
+		{
+			Metric: &agent.MeterData_SingleValue{SingleValue: &agent.MeterSingleValue{
+				Name:   "thread_pool_active",
+				Labels: []*agent.Label{{Name: "pool", Value: "kafka"}},
+				Value:  2,
+			}},
+		},
+		{
+			Metric: &agent.MeterData_Histogram{Histogram: &agent.MeterHistogram{
+				Name: "order_latency",
+				Values: []*agent.MeterBucketValue{
+					{Bucket: 0, Count: 1},
+					{Bucket: 10, Count: 5},
+					{Bucket: 50, Count: 2},
+				},
+			}},
+		},
+	}
+}
+
+func TestMetersToMetrics(t *testing.T) {
+	md := metersToMetrics(testMeters())
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	assert.Equal(t, 3, md.DataPointCount())
+
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]interface{}{
+		conventions.AttributeServiceName:       "order",
+		conventions.AttributeServiceInstanceID: "order-1@10.0.0.1",
+	}, attributesAsMap(rm.Resource().Attributes()))
+
+	metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	gauge := metrics.At(0)
+	assert.Equal(t, "thread_pool_active", gauge.Name())
+	require.Equal(t, pdata.MetricDataTypeGauge, gauge.DataType())
+	require.Equal(t, 2, gauge.Gauge().DataPoints().Len())
+	for i, expected := range []struct {
+		pool  string
+		value float64
+	}{{"http", 4}, {"kafka", 2}} {
+		dp := gauge.Gauge().DataPoints().At(i)
+		assert.Equal(t, pdata.Timestamp(1629274821001000000), dp.Timestamp())
+		assert.Equal(t, expected.value, dp.DoubleVal())
+		assert.Equal(t, map[string]interface{}{"pool": expected.pool}, attributesAsMap(dp.Attributes()))
+	}
+
+	histogram := metrics.At(1)
+	assert.Equal(t, "order_latency", histogram.Name())
+	require.Equal(t, pdata.MetricDataTypeHistogram, histogram.DataType())
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, histogram.Histogram().AggregationTemporality())
+	dp := histogram.Histogram().DataPoints().At(0)
+	assert.Equal(t, pdata.Timestamp(1629274821001000000), dp.Timestamp())
+	assert.Equal(t, []float64{0, 10, 50}, dp.ExplicitBounds())
+	assert.Equal(t, []uint64{0, 1, 5, 2}, dp.BucketCounts())
+	assert.Equal(t, uint64(8), dp.Count())
+}
+
+func TestFillHistogramDataPoint(t *testing.T) {
+	tests := []struct {
+		name           string
+		buckets        []*agent.MeterBucketValue
+		expectedBounds []float64
+		expectedCounts []uint64
+	}{
+		{
+			name:           "no buckets",
+			expectedBounds: []float64{},
+			expectedCounts: []uint64{0},
+		},
+		{
+			name: "negative infinity",
+			buckets: []*agent.MeterBucketValue{
+				{IsNegativeInfinity: true, Count: 3},
+				{Bucket: 1, Count: 4},
+			},
+			expectedBounds: []float64{1},
+			expectedCounts: []uint64{3, 4},
+		},
+		{
+			name: "negative count",
+			buckets: []*agent.MeterBucketValue{
+				{Bucket: 5, Count: -1},
+			},
+			expectedBounds: []float64{5},
+			expectedCounts: []uint64{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dp := pdata.NewHistogramDataPoint()
+			fillHistogramDataPoint(tt.buckets, dp)
+			assert.Equal(t, tt.expectedBounds, dp.ExplicitBounds())
+			assert.Equal(t, tt.expectedCounts, dp.BucketCounts())
+		})
+	}
+}
+
+func TestMetersToMetricsEmpty(t *testing.T) {
+	assert.Equal(t, 0, metersToMetrics(nil).ResourceMetrics().Len())
+}
@@ -0,0 +1,225 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skywalkingreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	common "skywalking.apache.org/repo/goapi/collect/common/v3"
+	agent "skywalking.apache.org/repo/goapi/collect/language/agent/v3"
+)
+
+const (
+	transport = "grpc"
+	format    = "protobuf"
+)
+
+// swReceiver implements the gRPC services the SkyWalking agents report their trace segments and
+// meters to, converting them into traces and metrics.
+type swReceiver struct {
+	sync.Mutex
+	logger          *zap.Logger
+	config          *Config
+	tracesConsumer  consumer.Traces
+	metricsConsumer consumer.Metrics
+	server          *grpc.Server
+	obsrecv         *obsreport.Receiver
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+var _ component.TracesReceiver = (*swReceiver)(nil)
+var _ component.MetricsReceiver = (*swReceiver)(nil)
+
+func newReceiver(logger *zap.Logger, config *Config) *swReceiver {
+	return &swReceiver{
+		logger:  logger,
+		config:  config,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: config.ID(), Transport: transport}),
+	}
+}
+
+func (r *swReceiver) registerTracesConsumer(tc consumer.Traces) error {
+	if tc == nil {
+		return componenterror.ErrNilNextConsumer
+	}
+	r.Lock()
+	defer r.Unlock()
+	r.tracesConsumer = tc
+	return nil
+}
+
+func (r *swReceiver) registerMetricsConsumer(mc consumer.Metrics) error {
+	if mc == nil {
+		return componenterror.ErrNilNextConsumer
+	}
+	r.Lock()
+	defer r.Unlock()
+	r.metricsConsumer = mc
+	return nil
+}
+
+// Start starts the gRPC server, serving the services of the signals consumers were registered
+// for. It is only started once, whichever pipeline starts the receiver first.
+func (r *swReceiver) Start(_ context.Context, host component.Host) error {
+	var err error
+	r.startOnce.Do(func() {
+		err = r.startServer(host)
+	})
+	return err
+}
+
+func (r *swReceiver) startServer(host component.Host) error {
+	r.Lock()
+	defer r.Unlock()
+
+	opts, err := r.config.GRPCServerSettings.ToServerOption(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	ln, err := r.config.GRPCServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.config.NetAddr.Endpoint, err)
+	}
+
+	r.server = grpc.NewServer(opts...)
+	if r.tracesConsumer != nil {
+		agent.RegisterTraceSegmentReportServiceServer(r.server, &traceSegmentService{receiver: r})
+	}
+	if r.metricsConsumer != nil {
+		agent.RegisterMeterReportServiceServer(r.server, &meterService{receiver: r})
+	}
+
+	go func() {
+		if errGrpc := r.server.Serve(ln); errGrpc != nil && !errors.Is(errGrpc, grpc.ErrServerStopped) {
+			host.ReportFatalError(errGrpc)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the gRPC server.
+func (r *swReceiver) Shutdown(context.Context) error {
+	r.stopOnce.Do(func() {
+		r.Lock()
+		defer r.Unlock()
+		if r.server != nil {
+			r.server.Stop()
+		}
+	})
+	return nil
+}
+
+func (r *swReceiver) consumeSegments(ctx context.Context, segments []*agent.SegmentObject) error {
+	ctx = r.obsrecv.StartTracesOp(ctx)
+	td := segmentsToTraces(segments)
+	err := r.tracesConsumer.ConsumeTraces(ctx, td)
+	r.obsrecv.EndTracesOp(ctx, format, td.SpanCount(), err)
+	return err
+}
+
+func (r *swReceiver) consumeMeters(ctx context.Context, meters []*agent.MeterData) error {
+	ctx = r.obsrecv.StartMetricsOp(ctx)
+	md := metersToMetrics(meters)
+	err := r.metricsConsumer.ConsumeMetrics(ctx, md)
+	r.obsrecv.EndMetricsOp(ctx, format, md.DataPointCount(), err)
+	return err
+}
+
+// traceSegmentService receives the trace segments of the agents, either streamed one by one or
+// in collections.
+type traceSegmentService struct {
+	agent.UnimplementedTraceSegmentReportServiceServer
+	receiver *swReceiver
+}
+
+func (s *traceSegmentService) Collect(stream agent.TraceSegmentReportService_CollectServer) error {
+	for {
+		segment, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&common.Commands{})
+		}
+		if err != nil {
+			return err
+		}
+		if err = s.receiver.consumeSegments(stream.Context(), []*agent.SegmentObject{segment}); err != nil {
+			s.receiver.logger.Debug("Unable to consume the received trace segment", zap.Error(err))
+			return err
+		}
+	}
+}
+
+func (s *traceSegmentService) CollectInSync(ctx context.Context, segments *agent.SegmentCollection) (*common.Commands, error) {
+	if err := s.receiver.consumeSegments(ctx, segments.GetSegments()); err != nil {
+		s.receiver.logger.Debug("Unable to consume the received trace segments", zap.Error(err))
+		return nil, err
+	}
+	return &common.Commands{}, nil
+}
+
+// meterService receives the meters of the agents. The meters collected by an agent in a
+// reporting period are sent in a single stream, or in a single collection of a batch stream, in
+// which only the first meter names the service and instance they were collected from.
+type meterService struct {
+	agent.UnimplementedMeterReportServiceServer
+	receiver *swReceiver
+}
+
+func (s *meterService) Collect(stream agent.MeterReportService_CollectServer) error {
+	var meters []*agent.MeterData
+	for {
+		meter, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		meters = append(meters, meter)
+	}
+
+	if err := s.receiver.consumeMeters(stream.Context(), meters); err != nil {
+		s.receiver.logger.Debug("Unable to consume the received meters", zap.Error(err))
+		return err
+	}
+	return stream.SendAndClose(&common.Commands{})
+}
+
+func (s *meterService) CollectBatch(stream agent.MeterReportService_CollectBatchServer) error {
+	for {
+		collection, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&common.Commands{})
+		}
+		if err != nil {
+			return err
+		}
+		if err = s.receiver.consumeMeters(stream.Context(), collection.GetMeterData()); err != nil {
+			s.receiver.logger.Debug("Unable to consume the received meters", zap.Error(err))
+			return err
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skywalkingreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/testutil"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	agent "skywalking.apache.org/repo/goapi/collect/language/agent/v3"
+)
+
+func startReceiver(t *testing.T, tracesConsumer consumer.Traces, metricsConsumer consumer.Metrics) *grpc.ClientConn {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	r := newReceiver(zap.NewNop(), cfg)
+	if tracesConsumer != nil {
+		require.NoError(t, r.registerTracesConsumer(tracesConsumer))
+	}
+	if metricsConsumer != nil {
+		require.NoError(t, r.registerMetricsConsumer(metricsConsumer))
+	}
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	// Starting the receiver of another pipeline is a no-op.
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, r.Shutdown(context.Background()))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, cfg.NetAddr.Endpoint, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+	})
+	return conn
+}
+
+func TestCollectSegments(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	conn := startReceiver(t, sink, nil)
+	client := agent.NewTraceSegmentReportServiceClient(conn)
+
+	stream, err := client.Collect(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(testSegment()))
+	require.NoError(t, stream.Send(testSegment()))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	assert.Len(t, sink.AllTraces(), 2)
+	assert.Equal(t, 6, sink.SpanCount())
+
+	_, err = client.CollectInSync(context.Background(), &agent.SegmentCollection{
+		Segments: []*agent.SegmentObject{testSegment(), testSegment()},
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, sink.AllTraces(), 3)
+	assert.Equal(t, 12, sink.SpanCount())
+
+	// The meters are not received without a metrics pipeline.
+	meterStream, err := agent.NewMeterReportServiceClient(conn).Collect(context.Background())
+	require.NoError(t, err)
+	_, err = meterStream.CloseAndRecv()
+	require.Error(t, err)
+}
+
+func TestCollectMeters(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	conn := startReceiver(t, nil, sink)
+	client := agent.NewMeterReportServiceClient(conn)
+
+	stream, err := client.Collect(context.Background())
+	require.NoError(t, err)
+	for _, meter := range testMeters() {
+		require.NoError(t, stream.Send(meter))
+	}
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	// The meters of a stream are consumed together.
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 3, sink.DataPointCount())
+
+	batchStream, err := client.CollectBatch(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, batchStream.Send(&agent.MeterDataCollection{MeterData: testMeters()}))
+	require.NoError(t, batchStream.Send(&agent.MeterDataCollection{MeterData: testMeters()}))
+	_, err = batchStream.CloseAndRecv()
+	require.NoError(t, err)
+
+	require.Len(t, sink.AllMetrics(), 3)
+	assert.Equal(t, 9, sink.DataPointCount())
+	service, ok := sink.AllMetrics()[2].ResourceMetrics().At(0).Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "order", service.StringVal())
+}
+
+func TestCollectConsumerError(t *testing.T) {
+	conn := startReceiver(t, consumertest.NewErr(errors.New("consumer error")), nil)
+
+	_, err := agent.NewTraceSegmentReportServiceClient(conn).CollectInSync(context.Background(), &agent.SegmentCollection{
+		Segments: []*agent.SegmentObject{testSegment()},
+	})
+	require.Error(t, err)
+}
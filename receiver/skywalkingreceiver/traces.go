@@ -0,0 +1,192 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skywalkingreceiver
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+	common "skywalking.apache.org/repo/goapi/collect/common/v3"
+	agent "skywalking.apache.org/repo/goapi/collect/language/agent/v3"
+)
+
+// The attributes keeping the SkyWalking identifiers of the spans, which are not valid trace and
+// span IDs.
+const (
+	attributeTraceID         = "sw8.trace_id"
+	attributeSegmentID       = "sw8.segment_id"
+	attributeSpanID          = "sw8.span_id"
+	attributeParentSegmentID = "sw8.parent_segment_id"
+	attributeParentSpanID    = "sw8.parent_span_id"
+	attributeComponentID     = "sw8.component_id"
+	attributeSpanLayer       = "sw8.span_layer"
+)
+
+// segmentsToTraces converts the trace segments into traces, each segment becoming a resource
+// with the spans of a service instance.
+func segmentsToTraces(segments []*agent.SegmentObject) pdata.Traces {
+	td := pdata.NewTraces()
+	for _, segment := range segments {
+		rs := td.ResourceSpans().AppendEmpty()
+		attrs := rs.Resource().Attributes()
+		if service := segment.GetService(); service != "" {
+			attrs.InsertString(conventions.AttributeServiceName, service)
+		}
+		if instance := segment.GetServiceInstance(); instance != "" {
+			attrs.InsertString(conventions.AttributeServiceInstanceID, instance)
+		}
+
+		spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+		traceID := swTraceIDToTraceID(segment.GetTraceId())
+		for _, swSpan := range segment.GetSpans() {
+			toSpan(swSpan, segment, traceID, spans.AppendEmpty())
+		}
+	}
+	return td
+}
+
+func toSpan(swSpan *agent.SpanObject, segment *agent.SegmentObject, traceID pdata.TraceID, span pdata.Span) {
+	segmentID := segment.GetTraceSegmentId()
+	span.SetTraceID(traceID)
+	span.SetSpanID(swSpanIDToSpanID(segmentID, swSpan.GetSpanId()))
+	span.SetName(swSpan.GetOperationName())
+	span.SetKind(spanKind(swSpan))
+	span.SetStartTimestamp(millisToTimestamp(swSpan.GetStartTime()))
+	span.SetEndTimestamp(millisToTimestamp(swSpan.GetEndTime()))
+	if swSpan.GetIsError() {
+		span.Status().SetCode(pdata.StatusCodeError)
+	}
+
+	attrs := span.Attributes()
+	attrs.InsertString(attributeTraceID, segment.GetTraceId())
+	attrs.InsertString(attributeSegmentID, segmentID)
+	attrs.InsertInt(attributeSpanID, int64(swSpan.GetSpanId()))
+	if peer := swSpan.GetPeer(); peer != "" {
+		attrs.InsertString(conventions.AttributeNetPeerName, peer)
+	}
+	if componentID := swSpan.GetComponentId(); componentID != 0 {
+		attrs.InsertInt(attributeComponentID, int64(componentID))
+	}
+	if layer := swSpan.GetSpanLayer(); layer != agent.SpanLayer_Unknown {
+		attrs.InsertString(attributeSpanLayer, layer.String())
+	}
+	for _, tag := range swSpan.GetTags() {
+		attrs.UpsertString(tag.GetKey(), tag.GetValue())
+	}
+
+	// The parent of the first span of a segment is in the segment referenced first, usually the
+	// one of the caller in another process. The other references, e.g. to the messages consumed
+	// in a batch, become links.
+	refs := swSpan.GetRefs()
+	if parentSpanID := swSpan.GetParentSpanId(); parentSpanID >= 0 {
+		span.SetParentSpanID(swSpanIDToSpanID(segmentID, parentSpanID))
+	} else if len(refs) > 0 {
+		parent := refs[0]
+		span.SetParentSpanID(swSpanIDToSpanID(parent.GetParentTraceSegmentId(), parent.GetParentSpanId()))
+		attrs.InsertString(attributeParentSegmentID, parent.GetParentTraceSegmentId())
+		attrs.InsertInt(attributeParentSpanID, int64(parent.GetParentSpanId()))
+		refs = refs[1:]
+	}
+	for _, ref := range refs {
+		link := span.Links().AppendEmpty()
+		link.SetTraceID(swTraceIDToTraceID(ref.GetTraceId()))
+		link.SetSpanID(swSpanIDToSpanID(ref.GetParentTraceSegmentId(), ref.GetParentSpanId()))
+		link.Attributes().InsertString(attributeTraceID, ref.GetTraceId())
+		link.Attributes().InsertString(attributeSegmentID, ref.GetParentTraceSegmentId())
+		link.Attributes().InsertInt(attributeSpanID, int64(ref.GetParentSpanId()))
+	}
+
+	for _, log := range swSpan.GetLogs() {
+		event := span.Events().AppendEmpty()
+		event.SetTimestamp(millisToTimestamp(log.GetTime()))
+		event.SetName(eventName(log.GetData()))
+		for _, data := range log.GetData() {
+			event.Attributes().UpsertString(data.GetKey(), data.GetValue())
+		}
+	}
+}
+
+// spanKind maps the type of swSpan to a span kind, the entry and exit spans of the message
+// queues being the consumers and producers.
+func spanKind(swSpan *agent.SpanObject) pdata.SpanKind {
+	mq := swSpan.GetSpanLayer() == agent.SpanLayer_MQ
+	switch swSpan.GetSpanType() {
+	case agent.SpanType_Entry:
+		if mq {
+			return pdata.SpanKindConsumer
+		}
+		return pdata.SpanKindServer
+	case agent.SpanType_Exit:
+		if mq {
+			return pdata.SpanKindProducer
+		}
+		return pdata.SpanKindClient
+	case agent.SpanType_Local:
+		return pdata.SpanKindInternal
+	default:
+		return pdata.SpanKindUnspecified
+	}
+}
+
+// eventName names the event of a span log after its "event" key, as set by the agents when
+// logging errors.
+func eventName(data []*common.KeyStringValuePair) string {
+	for _, kv := range data {
+		if kv.GetKey() == "event" && kv.GetValue() != "" {
+			return kv.GetValue()
+		}
+	}
+	return "log"
+}
+
+// swTraceIDToTraceID converts a SkyWalking trace ID into a trace ID. The UUIDs some agents use
+// as trace IDs are kept as they are, the other IDs, e.g. those of the Java agent, are hashed so
+// that all the segments of a trace get the same trace ID.
+func swTraceIDToTraceID(traceID string) pdata.TraceID {
+	var id [16]byte
+	if traceID == "" {
+		return pdata.NewTraceID(id)
+	}
+	if b, err := hex.DecodeString(strings.ReplaceAll(traceID, "-", "")); err == nil && len(b) == len(id) {
+		copy(id[:], b)
+	} else {
+		sum := sha256.Sum256([]byte(traceID))
+		copy(id[:], sum[:])
+	}
+	return pdata.NewTraceID(id)
+}
+
+// swSpanIDToSpanID derives a span ID from the ID of a SkyWalking span, which is only unique
+// within its segment, so that references across segments point to the same span ID.
+func swSpanIDToSpanID(segmentID string, spanID int32) pdata.SpanID {
+	h := sha256.New()
+	h.Write([]byte(segmentID))
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(spanID))
+	h.Write(b[:])
+
+	var id [8]byte
+	copy(id[:], h.Sum(nil))
+	return pdata.NewSpanID(id)
+}
+
+func millisToTimestamp(millis int64) pdata.Timestamp {
+	return pdata.TimestampFromTime(time.Unix(0, millis*int64(time.Millisecond)))
+}
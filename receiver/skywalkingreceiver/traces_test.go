@@ -0,0 +1,195 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skywalkingreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+	common "skywalking.apache.org/repo/goapi/collect/common/v3"
+	agent "skywalking.apache.org/repo/goapi/collect/language/agent/v3"
+)
+
+const (
+	testTraceID   = "a2b1c3d4e5f6478c9d0e1f2a3b4c5d6e.45.16292748210010001"
+	testSegmentID = "a2b1c3d4e5f6478c9d0e1f2a3b4c5d6e.46.16292748210010002"
+)
+
+func testSegment() *agent.SegmentObject {
+	return &agent.SegmentObject{
+		TraceId:         testTraceID,
+		TraceSegmentId:  testSegmentID,
+		Service:         "order",
+		ServiceInstance: "order-1@10.0.0.1",
+		Spans: []*agent.SpanObject{
+			{
+				SpanId:        0,
+				ParentSpanId:  -1,
+				StartTime:     1629274821001,
+				EndTime:       1629274821051,
+				OperationName: "/orders",
+				SpanType:      agent.SpanType_Entry,
+				SpanLayer:     agent.SpanLayer_Http,
+				ComponentId:   1,
+				Refs: []*agent.SegmentReference{
+					{
+						RefType:              agent.RefType_CrossProcess,
+						TraceId:              testTraceID,
+						ParentTraceSegmentId: "caller-segment",
+						ParentSpanId:         2,
+						ParentService:        "gateway",
+					},
+					{
+						RefType:              agent.RefType_CrossProcess,
+						TraceId:              "other-trace",
+						ParentTraceSegmentId: "other-segment",
+						ParentSpanId:         1,
+					},
+				},
+				Tags: []*common.KeyStringValuePair{
+					{Key: "http.method", Value: "GET"},
+				},
+			},
+			{
+				SpanId:        1,
+				ParentSpanId:  0,
+				StartTime:     1629274821011,
+				EndTime:       1629274821021,
+				OperationName: "Kafka/orders/Producer",
+				Peer:          "kafka:9092",
+				SpanType:      agent.SpanType_Exit,
+				SpanLayer:     agent.SpanLayer_MQ,
+			},
+			{
+				SpanId:        2,
+				ParentSpanId:  0,
+				StartTime:     1629274821021,
+				EndTime:       1629274821041,
+				OperationName: "computeTotal",
+				SpanType:      agent.SpanType_Local,
+				IsError:       true,
+				Logs: []*agent.Log{
+					{
+						Time: 1629274821031,
+						Data: []*common.KeyStringValuePair{
+							{Key: "event", Value: "error"},
+							{Key: "error.kind", Value: "java.lang.ArithmeticException"},
+							{Key: "message", Value: "/ by zero"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSegmentsToTraces(t *testing.T) {
+	td := segmentsToTraces([]*agent.SegmentObject{testSegment()})
+	require.Equal(t, 1, td.ResourceSpans().Len())
+	require.Equal(t, 3, td.SpanCount())
+
+	rs := td.ResourceSpans().At(0)
+	assert.Equal(t, map[string]interface{}{
+		conventions.AttributeServiceName:       "order",
+		conventions.AttributeServiceInstanceID: "order-1@10.0.0.1",
+	}, attributesAsMap(rs.Resource().Attributes()))
+
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	traceID := swTraceIDToTraceID(testTraceID)
+
+	entry := spans.At(0)
+	assert.Equal(t, "/orders", entry.Name())
+	assert.Equal(t, pdata.SpanKindServer, entry.Kind())
+	assert.Equal(t, traceID, entry.TraceID())
+	assert.Equal(t, swSpanIDToSpanID(testSegmentID, 0), entry.SpanID())
+	assert.Equal(t, swSpanIDToSpanID("caller-segment", 2), entry.ParentSpanID())
+	assert.Equal(t, pdata.Timestamp(1629274821001000000), entry.StartTimestamp())
+	assert.Equal(t, pdata.Timestamp(1629274821051000000), entry.EndTimestamp())
+	assert.Equal(t, pdata.StatusCodeUnset, entry.Status().Code())
+	assert.Equal(t, map[string]interface{}{
+		attributeTraceID:         testTraceID,
+		attributeSegmentID:       testSegmentID,
+		attributeSpanID:          int64(0),
+		attributeParentSegmentID: "caller-segment",
+		attributeParentSpanID:    int64(2),
+		attributeComponentID:     int64(1),
+		attributeSpanLayer:       "Http",
+		"http.method":            "GET",
+	}, attributesAsMap(entry.Attributes()))
+	require.Equal(t, 1, entry.Links().Len())
+	link := entry.Links().At(0)
+	assert.Equal(t, swTraceIDToTraceID("other-trace"), link.TraceID())
+	assert.Equal(t, swSpanIDToSpanID("other-segment", 1), link.SpanID())
+
+	exit := spans.At(1)
+	assert.Equal(t, pdata.SpanKindProducer, exit.Kind())
+	assert.Equal(t, entry.SpanID(), exit.ParentSpanID())
+	peer, ok := exit.Attributes().Get(conventions.AttributeNetPeerName)
+	require.True(t, ok)
+	assert.Equal(t, "kafka:9092", peer.StringVal())
+
+	local := spans.At(2)
+	assert.Equal(t, pdata.SpanKindInternal, local.Kind())
+	assert.Equal(t, entry.SpanID(), local.ParentSpanID())
+	assert.Equal(t, pdata.StatusCodeError, local.Status().Code())
+	require.Equal(t, 1, local.Events().Len())
+	event := local.Events().At(0)
+	assert.Equal(t, "error", event.Name())
+	assert.Equal(t, pdata.Timestamp(1629274821031000000), event.Timestamp())
+	assert.Equal(t, map[string]interface{}{
+		"event":      "error",
+		"error.kind": "java.lang.ArithmeticException",
+		"message":    "/ by zero",
+	}, attributesAsMap(event.Attributes()))
+}
+
+func TestSwTraceIDToTraceID(t *testing.T) {
+	// The UUIDs are kept as they are.
+	assert.Equal(t,
+		pdata.NewTraceID([16]byte{0xde, 0x59, 0x80, 0xb8, 0xfc, 0xe3, 0x4a, 0x37, 0xaa, 0xb9, 0xb4, 0xac, 0x3a, 0xf7, 0xee, 0xdd}),
+		swTraceIDToTraceID("de5980b8-fce3-4a37-aab9-b4ac3af7eedd"))
+
+	// The other IDs are hashed the same way in every segment.
+	assert.Equal(t, swTraceIDToTraceID(testTraceID), swTraceIDToTraceID(testTraceID))
+	assert.NotEqual(t, swTraceIDToTraceID(testTraceID), swTraceIDToTraceID(testSegmentID))
+	assert.False(t, swTraceIDToTraceID(testTraceID).IsEmpty())
+
+	assert.True(t, swTraceIDToTraceID("").IsEmpty())
+}
+
+func TestSwSpanIDToSpanID(t *testing.T) {
+	assert.Equal(t, swSpanIDToSpanID(testSegmentID, 1), swSpanIDToSpanID(testSegmentID, 1))
+	assert.NotEqual(t, swSpanIDToSpanID(testSegmentID, 1), swSpanIDToSpanID(testSegmentID, 2))
+	assert.NotEqual(t, swSpanIDToSpanID(testSegmentID, 1), swSpanIDToSpanID(testTraceID, 1))
+}
+
+func attributesAsMap(attrs pdata.AttributeMap) map[string]interface{} {
+	m := make(map[string]interface{}, attrs.Len())
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		switch v.Type() {
+		case pdata.AttributeValueTypeInt:
+			m[k] = v.IntVal()
+		case pdata.AttributeValueTypeDouble:
+			m[k] = v.DoubleVal()
+		default:
+			m[k] = v.StringVal()
+		}
+		return true
+	})
+	return m
+}
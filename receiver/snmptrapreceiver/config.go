@@ -0,0 +1,164 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmptrapreceiver
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gosnmp/gosnmp"
+	"go.opentelemetry.io/collector/config"
+)
+
+var authProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+	"MD5":    gosnmp.MD5,
+	"SHA":    gosnmp.SHA,
+	"SHA224": gosnmp.SHA224,
+	"SHA256": gosnmp.SHA256,
+	"SHA384": gosnmp.SHA384,
+	"SHA512": gosnmp.SHA512,
+}
+
+var privacyProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+	"DES":    gosnmp.DES,
+	"AES":    gosnmp.AES,
+	"AES192": gosnmp.AES192,
+	"AES256": gosnmp.AES256,
+}
+
+// Config defines configuration for the SNMP trap receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Endpoint is the UDP address to listen on for traps and informs. Default is 0.0.0.0:162.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Community is the community string SNMP v1 and v2c traps must carry to be
+	// accepted. When empty, v1 and v2c traps are accepted regardless of their community.
+	Community string `mapstructure:"community"`
+
+	// Security holds the User-based Security Model settings of SNMP v3 traps.
+	// SNMP v3 traps are dropped when not set.
+	Security *SecurityConfig `mapstructure:"security"`
+
+	// MIBPaths lists MIB files, or directories of MIB files, used to resolve
+	// the OIDs of traps and their variables to names.
+	MIBPaths []string `mapstructure:"mib_paths"`
+}
+
+// SecurityConfig defines the SNMP v3 user traps are authenticated and decrypted with.
+type SecurityConfig struct {
+	// Username is the USM user name of the senders.
+	Username string `mapstructure:"username"`
+	// EngineID is the hex encoded authoritative engine ID of the senders,
+	// which the keys of the user are localized with.
+	EngineID string `mapstructure:"engine_id"`
+	// AuthProtocol is one of MD5, SHA, SHA224, SHA256, SHA384 or SHA512.
+	// When empty, traps are not authenticated.
+	AuthProtocol string `mapstructure:"auth_protocol"`
+	// AuthPassword is the authentication passphrase of the user.
+	AuthPassword string `mapstructure:"auth_password"`
+	// PrivacyProtocol is one of DES, AES, AES192 or AES256. When empty,
+	// traps are not encrypted. Requires AuthProtocol to be set.
+	PrivacyProtocol string `mapstructure:"privacy_protocol"`
+	// PrivacyPassword is the privacy passphrase of the user.
+	PrivacyPassword string `mapstructure:"privacy_password"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks if the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if err := cfg.ReceiverSettings.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" must be specified")
+	}
+
+	if cfg.Security != nil {
+		if err := cfg.Security.validate(); err != nil {
+			return fmt.Errorf("security: %w", err)
+		}
+	}
+	return nil
+}
+
+func (sc *SecurityConfig) validate() error {
+	if sc.Username == "" {
+		return errors.New("\"username\" must be specified")
+	}
+	if _, err := hex.DecodeString(strings.TrimPrefix(sc.EngineID, "0x")); err != nil || sc.EngineID == "" {
+		return errors.New("\"engine_id\" must be a hex encoded engine ID")
+	}
+	if sc.AuthProtocol != "" {
+		if _, ok := authProtocols[strings.ToUpper(sc.AuthProtocol)]; !ok {
+			return fmt.Errorf("unsupported \"auth_protocol\" %q", sc.AuthProtocol)
+		}
+		if sc.AuthPassword == "" {
+			return errors.New("\"auth_password\" must be specified with \"auth_protocol\"")
+		}
+	}
+	if sc.PrivacyProtocol != "" {
+		if sc.AuthProtocol == "" {
+			return errors.New("\"privacy_protocol\" requires \"auth_protocol\" to be specified")
+		}
+		if _, ok := privacyProtocols[strings.ToUpper(sc.PrivacyProtocol)]; !ok {
+			return fmt.Errorf("unsupported \"privacy_protocol\" %q", sc.PrivacyProtocol)
+		}
+		if sc.PrivacyPassword == "" {
+			return errors.New("\"privacy_password\" must be specified with \"privacy_protocol\"")
+		}
+	}
+	return nil
+}
+
+// params returns the gosnmp parameters traps are decoded with.
+func (cfg *Config) params() *gosnmp.GoSNMP {
+	params := &gosnmp.GoSNMP{
+		Version:   gosnmp.Version2c,
+		Community: cfg.Community,
+	}
+	if cfg.Security == nil {
+		return params
+	}
+
+	// Validated in Validate.
+	engineID, _ := hex.DecodeString(strings.TrimPrefix(cfg.Security.EngineID, "0x"))
+	usm := &gosnmp.UsmSecurityParameters{
+		UserName:                 cfg.Security.Username,
+		AuthoritativeEngineID:    string(engineID),
+		AuthenticationProtocol:   gosnmp.NoAuth,
+		PrivacyProtocol:          gosnmp.NoPriv,
+		AuthenticationPassphrase: cfg.Security.AuthPassword,
+		PrivacyPassphrase:        cfg.Security.PrivacyPassword,
+	}
+	params.MsgFlags = gosnmp.NoAuthNoPriv
+	if cfg.Security.AuthProtocol != "" {
+		usm.AuthenticationProtocol = authProtocols[strings.ToUpper(cfg.Security.AuthProtocol)]
+		params.MsgFlags = gosnmp.AuthNoPriv
+	}
+	if cfg.Security.PrivacyProtocol != "" {
+		usm.PrivacyProtocol = privacyProtocols[strings.ToUpper(cfg.Security.PrivacyProtocol)]
+		params.MsgFlags = gosnmp.AuthPriv
+	}
+	params.Version = gosnmp.Version3
+	params.SecurityModel = gosnmp.UserSecurityModel
+	params.SecurityParameters = usm
+	return params
+}
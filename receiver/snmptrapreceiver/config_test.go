@@ -0,0 +1,131 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmptrapreceiver
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Len(t, cfg.Receivers, 2)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Receivers[config.NewID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewIDWithName(typeStr, "customname")),
+		Endpoint:         "0.0.0.0:1162",
+		Community:        "public",
+		Security: &SecurityConfig{
+			Username:        "collector",
+			EngineID:        "80001f8880e9630000d61ff449",
+			AuthProtocol:    "SHA",
+			AuthPassword:    "authpassword",
+			PrivacyProtocol: "AES",
+			PrivacyPassword: "privacypassword",
+		},
+		MIBPaths: []string{"testdata/mibs"},
+	}, cfg.Receivers[config.NewIDWithName(typeStr, "customname")])
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name: "valid security without privacy",
+			modify: func(cfg *Config) {
+				cfg.Security = &SecurityConfig{Username: "user", EngineID: "0x8000000001", AuthProtocol: "sha256", AuthPassword: "password"}
+			},
+		},
+		{
+			name:   "missing endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "" },
+			err:    "\"endpoint\" must be specified",
+		},
+		{
+			name:   "missing username",
+			modify: func(cfg *Config) { cfg.Security = &SecurityConfig{EngineID: "8000000001"} },
+			err:    "security: \"username\" must be specified",
+		},
+		{
+			name:   "invalid engine ID",
+			modify: func(cfg *Config) { cfg.Security = &SecurityConfig{Username: "user", EngineID: "engine"} },
+			err:    "security: \"engine_id\" must be a hex encoded engine ID",
+		},
+		{
+			name: "unsupported auth protocol",
+			modify: func(cfg *Config) {
+				cfg.Security = &SecurityConfig{Username: "user", EngineID: "8000000001", AuthProtocol: "SHA1", AuthPassword: "password"}
+			},
+			err: "security: unsupported \"auth_protocol\" \"SHA1\"",
+		},
+		{
+			name: "missing auth password",
+			modify: func(cfg *Config) {
+				cfg.Security = &SecurityConfig{Username: "user", EngineID: "8000000001", AuthProtocol: "MD5"}
+			},
+			err: "security: \"auth_password\" must be specified with \"auth_protocol\"",
+		},
+		{
+			name: "privacy without auth",
+			modify: func(cfg *Config) {
+				cfg.Security = &SecurityConfig{Username: "user", EngineID: "8000000001", PrivacyProtocol: "AES", PrivacyPassword: "password"}
+			},
+			err: "security: \"privacy_protocol\" requires \"auth_protocol\" to be specified",
+		},
+		{
+			name: "unsupported privacy protocol",
+			modify: func(cfg *Config) {
+				cfg.Security = &SecurityConfig{Username: "user", EngineID: "8000000001", AuthProtocol: "SHA", AuthPassword: "password",
+					PrivacyProtocol: "3DES", PrivacyPassword: "password"}
+			},
+			err: "security: unsupported \"privacy_protocol\" \"3DES\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmptrapreceiver
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gosnmp/gosnmp"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+)
+
+const (
+	attributeVersion      = "snmp.version"
+	attributePDUType      = "snmp.pdu_type"
+	attributeTrapOID      = "snmp.trap.oid"
+	attributeTrapName     = "snmp.trap.name"
+	attributeUptime       = "snmp.uptime"
+	attributeEnterprise   = "snmp.trap.enterprise"
+	attributeAgentAddress = "snmp.agent.address"
+
+	oidSysUpTime   = "1.3.6.1.2.1.1.3.0"
+	oidSnmpTrapOID = "1.3.6.1.6.3.1.1.4.1.0"
+	// oidSnmpTraps is the parent of the OIDs of the generic SNMP v1 traps.
+	oidSnmpTraps = "1.3.6.1.6.3.1.1.5"
+
+	// genericTrapEnterpriseSpecific is the generic trap number of the SNMP v1
+	// traps identified by their enterprise and specific trap number.
+	genericTrapEnterpriseSpecific = 6
+)
+
+// trapToLogs converts a trap or inform to a log record. The body of the record is
+// the name of the trap, and its variables are added as attributes named after
+// the objects they are instances of.
+func trapToLogs(packet *gosnmp.SnmpPacket, peer *net.UDPAddr, resolver *mibResolver, observed time.Time) pdata.Logs {
+	logs := pdata.NewLogs()
+	lr := logs.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.SetTimestamp(pdata.TimestampFromTime(observed))
+
+	attrs := lr.Attributes()
+	attrs.InsertString(attributeVersion, "v"+packet.Version.String())
+	if packet.PDUType == gosnmp.InformRequest {
+		attrs.InsertString(attributePDUType, "inform")
+	} else {
+		attrs.InsertString(attributePDUType, "trap")
+	}
+	if peer != nil {
+		attrs.InsertString(conventions.AttributeNetPeerIP, peer.IP.String())
+		attrs.InsertInt(conventions.AttributeNetPeerPort, int64(peer.Port))
+	}
+
+	var trapOID string
+	if packet.PDUType == gosnmp.Trap {
+		// SNMP v1 traps carry their identity in the PDU rather than in variables.
+		enterprise := strings.TrimPrefix(packet.Enterprise, ".")
+		if packet.GenericTrap == genericTrapEnterpriseSpecific {
+			trapOID = fmt.Sprintf("%s.0.%d", enterprise, packet.SpecificTrap)
+		} else {
+			trapOID = fmt.Sprintf("%s.%d", oidSnmpTraps, packet.GenericTrap+1)
+		}
+		attrs.InsertString(attributeEnterprise, resolver.resolve(enterprise))
+		attrs.InsertString(attributeAgentAddress, packet.AgentAddress)
+		attrs.InsertInt(attributeUptime, int64(packet.Timestamp))
+	}
+
+	for _, variable := range packet.Variables {
+		switch strings.TrimPrefix(variable.Name, ".") {
+		case oidSysUpTime:
+			if uptime, ok := variable.Value.(uint32); ok {
+				attrs.InsertInt(attributeUptime, int64(uptime))
+			}
+		case oidSnmpTrapOID:
+			if oid, ok := variable.Value.(string); ok {
+				trapOID = strings.TrimPrefix(oid, ".")
+			}
+		default:
+			insertVariable(attrs, variable, resolver)
+		}
+	}
+
+	if trapOID != "" {
+		trapName := resolver.resolve(trapOID)
+		attrs.InsertString(attributeTrapOID, trapOID)
+		attrs.InsertString(attributeTrapName, trapName)
+		lr.Body().SetStringVal(trapName)
+	}
+	return logs
+}
+
+// insertVariable adds the value of a trap variable as an attribute named after the variable OID.
+func insertVariable(attrs pdata.AttributeMap, variable gosnmp.SnmpPDU, resolver *mibResolver) {
+	key := resolver.resolve(variable.Name)
+	switch value := variable.Value.(type) {
+	case int:
+		attrs.UpsertInt(key, int64(value))
+	case uint:
+		attrs.UpsertInt(key, int64(value))
+	case uint32:
+		attrs.UpsertInt(key, int64(value))
+	case uint64:
+		attrs.UpsertInt(key, int64(value))
+	case float32:
+		attrs.UpsertDouble(key, float64(value))
+	case float64:
+		attrs.UpsertDouble(key, value)
+	case []byte:
+		attrs.UpsertString(key, octetString(value))
+	case string:
+		if variable.Type == gosnmp.ObjectIdentifier {
+			value = resolver.resolve(value)
+		}
+		attrs.UpsertString(key, value)
+	}
+}
+
+// octetString returns b as text if it is printable, hex encoded otherwise.
+func octetString(b []byte) string {
+	if !utf8.Valid(b) {
+		return hex.EncodeToString(b)
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return hex.EncodeToString(b)
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,242 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmptrapreceiver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// wellKnownOIDs are the OIDs resolved without loading any MIB: the roots
+// MIB modules assign their objects under, and the generic traps of SNMPv2-MIB.
+var wellKnownOIDs = map[string]string{
+	"iso":                   "1",
+	"org":                   "1.3",
+	"dod":                   "1.3.6",
+	"internet":              "1.3.6.1",
+	"directory":             "1.3.6.1.1",
+	"mgmt":                  "1.3.6.1.2",
+	"mib-2":                 "1.3.6.1.2.1",
+	"system":                "1.3.6.1.2.1.1",
+	"sysUpTime":             "1.3.6.1.2.1.1.3",
+	"interfaces":            "1.3.6.1.2.1.2",
+	"ifIndex":               "1.3.6.1.2.1.2.2.1.1",
+	"ifDescr":               "1.3.6.1.2.1.2.2.1.2",
+	"ifAdminStatus":         "1.3.6.1.2.1.2.2.1.7",
+	"ifOperStatus":          "1.3.6.1.2.1.2.2.1.8",
+	"transmission":          "1.3.6.1.2.1.10",
+	"experimental":          "1.3.6.1.3",
+	"private":               "1.3.6.1.4",
+	"enterprises":           "1.3.6.1.4.1",
+	"security":              "1.3.6.1.5",
+	"snmpV2":                "1.3.6.1.6",
+	"snmpDomains":           "1.3.6.1.6.1",
+	"snmpProxys":            "1.3.6.1.6.2",
+	"snmpModules":           "1.3.6.1.6.3",
+	"snmpTrapOID":           "1.3.6.1.6.3.1.1.4.1",
+	"snmpTrapEnterprise":    "1.3.6.1.6.3.1.1.4.3",
+	"snmpTraps":             "1.3.6.1.6.3.1.1.5",
+	"coldStart":             "1.3.6.1.6.3.1.1.5.1",
+	"warmStart":             "1.3.6.1.6.3.1.1.5.2",
+	"linkDown":              "1.3.6.1.6.3.1.1.5.3",
+	"linkUp":                "1.3.6.1.6.3.1.1.5.4",
+	"authenticationFailure": "1.3.6.1.6.3.1.1.5.5",
+}
+
+var (
+	// mibAssignmentRegexp matches the OID value assignments of objects, e.g.
+	// `linkDown NOTIFICATION-TYPE ... ::= { snmpTraps 3 }`.
+	mibAssignmentRegexp = regexp.MustCompile(`(?s)\b([a-z][A-Za-z0-9-]*)\s+(?:OBJECT\s+IDENTIFIER|OBJECT-TYPE|OBJECT-IDENTITY|MODULE-IDENTITY|NOTIFICATION-TYPE|OBJECT-GROUP|NOTIFICATION-GROUP|MODULE-COMPLIANCE|AGENT-CAPABILITIES)\b.*?::=\s*\{([^}]*)\}`)
+	// mibTrapTypeRegexp matches the SMIv1 trap definitions, e.g.
+	// `myTrap TRAP-TYPE ENTERPRISE myEnterprise ... ::= 2`.
+	mibTrapTypeRegexp = regexp.MustCompile(`(?s)\b([a-z][A-Za-z0-9-]*)\s+TRAP-TYPE\s+ENTERPRISE\s+([a-z][A-Za-z0-9-]*).*?::=\s*(\d+)`)
+	mibSubIDRegexp    = regexp.MustCompile(`^(?:[a-z][A-Za-z0-9-]*\()?(\d+)\)?$`)
+)
+
+// mibDefinition is the OID of an object relative to its parent.
+type mibDefinition struct {
+	parent string
+	subIDs []string
+}
+
+// mibResolver resolves OIDs to the names of the objects they identify.
+type mibResolver struct {
+	// names maps OIDs, without leading dot, to object names.
+	names map[string]string
+}
+
+// newMIBResolver loads the object definitions of the MIB files at paths, which
+// may also be directories of MIB files.
+func newMIBResolver(paths []string) (*mibResolver, error) {
+	definitions := map[string]mibDefinition{}
+	for _, path := range paths {
+		files, err := mibFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			content, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read MIB file: %w", err)
+			}
+			parseMIB(string(content), definitions)
+		}
+	}
+
+	oids := make(map[string]string, len(wellKnownOIDs)+len(definitions))
+	for name, oid := range wellKnownOIDs {
+		oids[name] = oid
+	}
+	var resolve func(name string, depth int) (string, bool)
+	resolve = func(name string, depth int) (string, bool) {
+		if oid, ok := oids[name]; ok {
+			return oid, true
+		}
+		def, ok := definitions[name]
+		// Guard against definitions referencing each other.
+		if !ok || depth > len(definitions) {
+			return "", false
+		}
+		parent, ok := resolve(def.parent, depth+1)
+		if !ok {
+			return "", false
+		}
+		oid := strings.Join(append([]string{parent}, def.subIDs...), ".")
+		oids[name] = oid
+		return oid, true
+	}
+
+	r := &mibResolver{names: make(map[string]string, len(oids))}
+	for name := range definitions {
+		resolve(name, 0)
+	}
+	for name, oid := range oids {
+		r.names[oid] = name
+	}
+	return r, nil
+}
+
+// mibFiles returns path if it is a file, or the files directly in path if it is a directory.
+func mibFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load MIBs: %w", err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	entries, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load MIBs: %w", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		if entry.Mode().IsRegular() {
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+	return files, nil
+}
+
+// parseMIB adds the OID assignments of the MIB module in content to definitions.
+func parseMIB(content string, definitions map[string]mibDefinition) {
+	content = stripMIB(content)
+
+	for _, match := range mibAssignmentRegexp.FindAllStringSubmatch(content, -1) {
+		fields := strings.Fields(match[2])
+		if len(fields) < 2 {
+			continue
+		}
+		def := mibDefinition{parent: fields[0]}
+		valid := true
+		for _, field := range fields[1:] {
+			subID := mibSubIDRegexp.FindStringSubmatch(field)
+			if subID == nil {
+				valid = false
+				break
+			}
+			def.subIDs = append(def.subIDs, subID[1])
+		}
+		if valid {
+			definitions[match[1]] = def
+		}
+	}
+
+	// SMIv1 traps are identified by the enterprise OID followed by 0 and the
+	// specific trap number, as mapped to SNMPv2 notifications by RFC 3584.
+	for _, match := range mibTrapTypeRegexp.FindAllStringSubmatch(content, -1) {
+		definitions[match[1]] = mibDefinition{parent: match[2], subIDs: []string{"0", match[3]}}
+	}
+}
+
+// resolve returns the name of the object identified by oid, followed by the
+// remaining sub-identifiers of oid that are not part of the object OID, e.g.
+// `ifIndex.3` for 1.3.6.1.2.1.2.2.1.1.3. oid is returned unchanged, without
+// leading dot, when no prefix of it is known.
+func (r *mibResolver) resolve(oid string) string {
+	oid = strings.TrimPrefix(oid, ".")
+	for prefix := oid; prefix != ""; {
+		if name, ok := r.names[prefix]; ok {
+			return name + oid[len(prefix):]
+		}
+		i := strings.LastIndexByte(prefix, '.')
+		if i < 0 {
+			break
+		}
+		prefix = prefix[:i]
+	}
+	return oid
+}
+
+// stripMIB removes the comments and the contents of the quoted strings of a
+// MIB module, so that the text they contain is not mistaken for definitions.
+func stripMIB(content string) string {
+	var sb strings.Builder
+	sb.Grow(len(content))
+	inString, inComment := false, false
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case inString:
+			if c == '"' {
+				inString = false
+				sb.WriteByte(c)
+			}
+		case inComment:
+			// Comments end at the end of the line or at the next "--".
+			if c == '\n' {
+				inComment = false
+				sb.WriteByte(c)
+			} else if c == '-' && i+1 < len(content) && content[i+1] == '-' {
+				inComment = false
+				i++
+			}
+		case c == '"':
+			inString = true
+			sb.WriteByte(c)
+		case c == '-' && i+1 < len(content) && content[i+1] == '-':
+			inComment = true
+			sb.WriteByte(' ')
+			i++
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
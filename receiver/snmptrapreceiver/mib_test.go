@@ -0,0 +1,76 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmptrapreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMIBResolver(t *testing.T) {
+	resolver, err := newMIBResolver([]string{"testdata/mibs"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		oid  string
+		name string
+	}{
+		{oid: ".1.3.6.1.4.1.99999", name: "exampleMIB"},
+		{oid: "1.3.6.1.4.1.99999.0.1", name: "exampleOverheat"},
+		{oid: "1.3.6.1.4.1.99999.0.2", name: "exampleFanFailure"},
+		{oid: ".1.3.6.1.4.1.99999.1.2.0", name: "exampleTemperature.0"},
+		{oid: "1.3.6.1.6.3.1.1.5.3", name: "linkDown"},
+		{oid: "1.3.6.1.2.1.2.2.1.1.12", name: "ifIndex.12"},
+		{oid: "1.3.6.1.4.1.99999.99", name: "exampleMIB.99"},
+		{oid: "1.3.6.1.4.1.12345.1", name: "enterprises.12345.1"},
+		{oid: "2.25.1", name: "2.25.1"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.name, resolver.resolve(tt.oid), tt.oid)
+	}
+}
+
+func TestMIBResolverWithoutMIBs(t *testing.T) {
+	resolver, err := newMIBResolver(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "coldStart", resolver.resolve(".1.3.6.1.6.3.1.1.5.1"))
+	assert.Equal(t, "enterprises.99999.0.1", resolver.resolve("1.3.6.1.4.1.99999.0.1"))
+}
+
+func TestMIBResolverMissingPath(t *testing.T) {
+	_, err := newMIBResolver([]string{"testdata/missing"})
+	assert.Error(t, err)
+}
+
+func TestParseMIB(t *testing.T) {
+	definitions := map[string]mibDefinition{}
+	parseMIB(`
+TEST-MIB DEFINITIONS ::= BEGIN
+-- commented OBJECT IDENTIFIER ::= { test 1 }
+test OBJECT IDENTIFIER ::= { iso org(3) dod(6) 99 }
+described OBJECT-IDENTITY
+    STATUS current
+    DESCRIPTION "Not -- a comment, nor inner OBJECT IDENTIFIER ::= { test 2 }"
+    ::= { test 3 }
+invalid OBJECT IDENTIFIER ::= { test sub }
+END`, definitions)
+
+	assert.Equal(t, map[string]mibDefinition{
+		"test":      {parent: "iso", subIDs: []string{"3", "6", "99"}},
+		"described": {parent: "test", subIDs: []string{"3"}},
+	}, definitions)
+}
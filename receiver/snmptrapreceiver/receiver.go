@@ -0,0 +1,187 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmptrapreceiver
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// maxPacketSize is the size of the largest UDP datagram.
+const maxPacketSize = 65535
+
+type snmpTrapReceiver struct {
+	config   *Config
+	logger   *zap.Logger
+	consumer consumer.Logs
+	resolver *mibResolver
+
+	// params decodes the traps.
+	params *gosnmp.GoSNMP
+	conn   *net.UDPConn
+	// done is closed once the listener stopped.
+	done chan struct{}
+}
+
+var _ component.LogsReceiver = (*snmpTrapReceiver)(nil)
+
+func newSNMPTrapReceiver(logger *zap.Logger, cfg *Config, next consumer.Logs) (*snmpTrapReceiver, error) {
+	resolver, err := newMIBResolver(cfg.MIBPaths)
+	if err != nil {
+		return nil, err
+	}
+	return &snmpTrapReceiver{
+		config:   cfg,
+		logger:   logger,
+		consumer: next,
+		resolver: resolver,
+	}, nil
+}
+
+// Start binds the trap listener and returns once it receives traps.
+func (r *snmpTrapReceiver) Start(context.Context, component.Host) error {
+	r.params = r.config.params()
+	// gosnmp logs the traps it fails to decode or authenticate.
+	stdLogger, err := zap.NewStdLogAt(r.logger, zapcore.DebugLevel)
+	if err != nil {
+		return err
+	}
+	r.params.Logger = gosnmp.NewLogger(stdLogger)
+
+	addr, err := net.ResolveUDPAddr("udp", r.config.Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", r.config.Endpoint, err)
+	}
+	if r.conn, err = net.ListenUDP("udp", addr); err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", r.config.Endpoint, err)
+	}
+	r.done = make(chan struct{})
+	go r.listen()
+	return nil
+}
+
+// Shutdown stops the trap listener.
+func (r *snmpTrapReceiver) Shutdown(context.Context) error {
+	if r.conn == nil {
+		return nil
+	}
+	err := r.conn.Close()
+	<-r.done
+	return err
+}
+
+// listen handles the packets received until the connection is closed.
+func (r *snmpTrapReceiver) listen() {
+	defer close(r.done)
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, peer, err := r.conn.ReadFromUDP(buf)
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			r.logger.Debug("Failed to read trap", zap.Error(err))
+			continue
+		}
+		r.handlePacket(buf[:n], peer)
+	}
+}
+
+// handlePacket decodes a trap and hands it over to the next consumer,
+// acknowledging it if it is an inform. gosnmp panics on some malformed
+// packets, which are dropped rather than take the collector down.
+func (r *snmpTrapReceiver) handlePacket(msg []byte, peer *net.UDPAddr) {
+	defer func() {
+		if p := recover(); p != nil {
+			r.logger.Debug("Dropping malformed trap", zap.Stringer("peer", peer), zap.Any("panic", p))
+		}
+	}()
+
+	// SNMP v3 traps are only decoded with the security settings they are
+	// authenticated with.
+	if version, ok := snmpVersion(msg); !ok || (version == gosnmp.Version3 && r.config.Security == nil) {
+		r.logger.Debug("Dropping trap of an unsupported SNMP version", zap.Stringer("peer", peer))
+		return
+	}
+
+	packet := r.params.UnmarshalTrap(msg, false)
+	if packet == nil {
+		return
+	}
+	if !r.handleTrap(packet, peer) || packet.PDUType != gosnmp.InformRequest {
+		return
+	}
+
+	// The inform is acknowledged with the same variables.
+	packet.PDUType = gosnmp.GetResponse
+	packet.Error = gosnmp.NoError
+	packet.ErrorIndex = 0
+	resp, err := packet.MarshalMsg()
+	if err == nil {
+		_, err = r.conn.WriteToUDP(resp, peer)
+	}
+	if err != nil {
+		r.logger.Debug("Failed to acknowledge inform", zap.Stringer("peer", peer), zap.Error(err))
+	}
+}
+
+// handleTrap hands the trap over to the next consumer, returning whether it
+// was accepted.
+func (r *snmpTrapReceiver) handleTrap(packet *gosnmp.SnmpPacket, peer *net.UDPAddr) bool {
+	if packet.Version != gosnmp.Version3 && r.config.Community != "" &&
+		subtle.ConstantTimeCompare([]byte(packet.Community), []byte(r.config.Community)) != 1 {
+		r.logger.Debug("Dropping trap with an unexpected community", zap.Stringer("peer", peer))
+		return false
+	}
+
+	logs := trapToLogs(packet, peer, r.resolver, time.Now())
+	if err := r.consumer.ConsumeLogs(context.Background(), logs); err != nil {
+		r.logger.Error("Failed to consume trap", zap.Stringer("peer", peer), zap.Error(err))
+	}
+	return true
+}
+
+// snmpVersion reads the version of an SNMP message, the first field of its
+// top-level sequence, without decoding the rest of it.
+func snmpVersion(msg []byte) (gosnmp.SnmpVersion, bool) {
+	if len(msg) < 2 || msg[0] != byte(gosnmp.Sequence) {
+		return 0, false
+	}
+	// The length of the sequence is either a single byte, or a byte holding
+	// the number of bytes of the length that follow.
+	i := 2
+	if msg[1]&0x80 != 0 {
+		i += int(msg[1] & 0x7f)
+	}
+	if len(msg) < i+3 || msg[i] != byte(gosnmp.Integer) || msg[i+1] != 1 {
+		return 0, false
+	}
+	version := gosnmp.SnmpVersion(msg[i+2])
+	switch version {
+	case gosnmp.Version1, gosnmp.Version2c, gosnmp.Version3:
+		return version, true
+	}
+	return 0, false
+}
@@ -0,0 +1,302 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmptrapreceiver
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/testutil"
+	"go.uber.org/zap"
+)
+
+const testEngineID = "80001f8880e9630000d61ff449"
+
+var overheatVariables = []gosnmp.SnmpPDU{
+	{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(4200)},
+	{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.99999.0.1"},
+	{Name: ".1.3.6.1.4.1.99999.1.1.0", Type: gosnmp.OctetString, Value: "device-1"},
+	{Name: ".1.3.6.1.4.1.99999.1.2.0", Type: gosnmp.Integer, Value: 85},
+}
+
+func TestReceiveTraps(t *testing.T) {
+	tests := []struct {
+		name     string
+		sender   gosnmp.GoSNMP
+		trap     gosnmp.SnmpTrap
+		expected map[string]pdata.AttributeValue
+	}{
+		{
+			name:   "v1 trap",
+			sender: gosnmp.GoSNMP{Version: gosnmp.Version1, Community: "public"},
+			trap: gosnmp.SnmpTrap{
+				Enterprise:   ".1.3.6.1.4.1.99999",
+				AgentAddress: "10.0.0.1",
+				GenericTrap:  6,
+				SpecificTrap: 2,
+				Timestamp:    300,
+				Variables: []gosnmp.SnmpPDU{
+					{Name: ".1.3.6.1.4.1.99999.1.1.0", Type: gosnmp.OctetString, Value: "device-1"},
+				},
+			},
+			expected: map[string]pdata.AttributeValue{
+				attributeVersion:      pdata.NewAttributeValueString("v1"),
+				attributePDUType:      pdata.NewAttributeValueString("trap"),
+				attributeTrapOID:      pdata.NewAttributeValueString("1.3.6.1.4.1.99999.0.2"),
+				attributeTrapName:     pdata.NewAttributeValueString("exampleFanFailure"),
+				attributeEnterprise:   pdata.NewAttributeValueString("exampleMIB"),
+				attributeAgentAddress: pdata.NewAttributeValueString("10.0.0.1"),
+				attributeUptime:       pdata.NewAttributeValueInt(300),
+				"exampleDeviceName.0": pdata.NewAttributeValueString("device-1"),
+			},
+		},
+		{
+			name:   "v2c trap",
+			sender: gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"},
+			trap:   gosnmp.SnmpTrap{Variables: overheatVariables},
+			expected: map[string]pdata.AttributeValue{
+				attributeVersion:       pdata.NewAttributeValueString("v2c"),
+				attributePDUType:       pdata.NewAttributeValueString("trap"),
+				attributeTrapOID:       pdata.NewAttributeValueString("1.3.6.1.4.1.99999.0.1"),
+				attributeTrapName:      pdata.NewAttributeValueString("exampleOverheat"),
+				attributeUptime:        pdata.NewAttributeValueInt(4200),
+				"exampleDeviceName.0":  pdata.NewAttributeValueString("device-1"),
+				"exampleTemperature.0": pdata.NewAttributeValueInt(85),
+			},
+		},
+		{
+			name:   "v2c inform",
+			sender: gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"},
+			trap:   gosnmp.SnmpTrap{Variables: overheatVariables, IsInform: true},
+			expected: map[string]pdata.AttributeValue{
+				attributeVersion:       pdata.NewAttributeValueString("v2c"),
+				attributePDUType:       pdata.NewAttributeValueString("inform"),
+				attributeTrapOID:       pdata.NewAttributeValueString("1.3.6.1.4.1.99999.0.1"),
+				attributeTrapName:      pdata.NewAttributeValueString("exampleOverheat"),
+				attributeUptime:        pdata.NewAttributeValueInt(4200),
+				"exampleDeviceName.0":  pdata.NewAttributeValueString("device-1"),
+				"exampleTemperature.0": pdata.NewAttributeValueInt(85),
+			},
+		},
+		{
+			name: "v3 trap",
+			sender: gosnmp.GoSNMP{
+				Version:       gosnmp.Version3,
+				SecurityModel: gosnmp.UserSecurityModel,
+				MsgFlags:      gosnmp.AuthPriv,
+				SecurityParameters: &gosnmp.UsmSecurityParameters{
+					UserName:                 "collector",
+					AuthoritativeEngineID:    engineID(t),
+					AuthoritativeEngineBoots: 1,
+					AuthoritativeEngineTime:  1,
+					AuthenticationProtocol:   gosnmp.SHA,
+					AuthenticationPassphrase: "authpassword",
+					PrivacyProtocol:          gosnmp.AES,
+					PrivacyPassphrase:        "privacypassword",
+				},
+			},
+			trap: gosnmp.SnmpTrap{Variables: overheatVariables},
+			expected: map[string]pdata.AttributeValue{
+				attributeVersion:       pdata.NewAttributeValueString("v3"),
+				attributePDUType:       pdata.NewAttributeValueString("trap"),
+				attributeTrapOID:       pdata.NewAttributeValueString("1.3.6.1.4.1.99999.0.1"),
+				attributeTrapName:      pdata.NewAttributeValueString("exampleOverheat"),
+				attributeUptime:        pdata.NewAttributeValueInt(4200),
+				"exampleDeviceName.0":  pdata.NewAttributeValueString("device-1"),
+				"exampleTemperature.0": pdata.NewAttributeValueInt(85),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.LogsSink)
+			host, port := startReceiver(t, sink)
+
+			sender := tt.sender
+			sender.Target = host
+			sender.Port = port
+			sender.Timeout = 2 * time.Second
+			require.NoError(t, sender.Connect())
+			defer sender.Conn.Close()
+			_, err := sender.SendTrap(tt.trap)
+			require.NoError(t, err)
+
+			require.Eventually(t, func() bool {
+				return sink.LogRecordCount() == 1
+			}, 5*time.Second, 10*time.Millisecond, "trap not received")
+
+			lr := sink.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+			assert.Equal(t, tt.expected[attributeTrapName].StringVal(), lr.Body().StringVal())
+			attrs := lr.Attributes()
+			for key, expected := range tt.expected {
+				actual, ok := attrs.Get(key)
+				if assert.True(t, ok, key) {
+					assert.True(t, expected.Equal(actual), "%s: expected %v, got %v", key, expected, actual)
+				}
+			}
+			peerIP, _ := attrs.Get("net.peer.ip")
+			assert.Equal(t, "127.0.0.1", peerIP.StringVal())
+		})
+	}
+}
+
+func TestReceiveTrapWrongCommunity(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	host, port := startReceiver(t, sink)
+
+	sender := &gosnmp.GoSNMP{Target: host, Port: port, Version: gosnmp.Version2c, Community: "private", Timeout: 2 * time.Second}
+	require.NoError(t, sender.Connect())
+	defer sender.Conn.Close()
+	_, err := sender.SendTrap(gosnmp.SnmpTrap{Variables: overheatVariables})
+	require.NoError(t, err)
+	sender.Community = "public"
+	_, err = sender.SendTrap(gosnmp.SnmpTrap{Variables: overheatVariables})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() > 0
+	}, 5*time.Second, 10*time.Millisecond, "trap not received")
+	// Only the trap with the configured community is received.
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestReceiveV3TrapWithoutSecurity(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	host, port := startReceiverWithConfig(t, createDefaultConfig().(*Config), sink)
+
+	sender := &gosnmp.GoSNMP{
+		Target:        host,
+		Port:          port,
+		Version:       gosnmp.Version3,
+		SecurityModel: gosnmp.UserSecurityModel,
+		MsgFlags:      gosnmp.AuthNoPriv,
+		SecurityParameters: &gosnmp.UsmSecurityParameters{
+			UserName:                 "collector",
+			AuthoritativeEngineID:    engineID(t),
+			AuthoritativeEngineBoots: 1,
+			AuthoritativeEngineTime:  1,
+			AuthenticationProtocol:   gosnmp.SHA,
+			AuthenticationPassphrase: "authpassword",
+		},
+		Timeout: 2 * time.Second,
+	}
+	require.NoError(t, sender.Connect())
+	defer sender.Conn.Close()
+	_, err := sender.SendTrap(gosnmp.SnmpTrap{Variables: overheatVariables})
+	require.NoError(t, err)
+
+	// The v3 trap is dropped, and the receiver keeps receiving traps.
+	v2cSender := &gosnmp.GoSNMP{Target: host, Port: port, Version: gosnmp.Version2c, Community: "public", Timeout: 2 * time.Second}
+	require.NoError(t, v2cSender.Connect())
+	defer v2cSender.Conn.Close()
+	_, err = v2cSender.SendTrap(gosnmp.SnmpTrap{Variables: overheatVariables})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() > 0
+	}, 5*time.Second, 10*time.Millisecond, "trap not received")
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, 1, sink.LogRecordCount())
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	version, _ := lr.Attributes().Get(attributeVersion)
+	assert.Equal(t, "v2c", version.StringVal())
+}
+
+func TestHandleMalformedPacket(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Security = &SecurityConfig{Username: "collector", EngineID: testEngineID, AuthProtocol: "MD5", AuthPassword: "authpassword"}
+	r, err := newSNMPTrapReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+	r.params = cfg.params()
+	r.params.Logger = gosnmp.NewLogger(nil)
+
+	for _, msg := range [][]byte{
+		nil,
+		{0x30},
+		{0x30, 0x03, 0x02, 0x01, 0x02},
+		{0x30, 0x82, 0x00, 0x10, 0x02, 0x01, 0x03, 0x30},
+	} {
+		assert.NotPanics(t, func() { r.handlePacket(msg, &net.UDPAddr{}) })
+	}
+	assert.Equal(t, 0, sink.LogRecordCount())
+}
+
+func TestStartListenError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "invalid:address:1162"
+	r, err := newSNMPTrapReceiver(zap.NewNop(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestOctetString(t *testing.T) {
+	assert.Equal(t, "device 1", octetString([]byte("device 1")))
+	assert.Equal(t, "00c0ffee", octetString([]byte{0x00, 0xc0, 0xff, 0xee}))
+}
+
+// startReceiver starts a receiver with the test MIBs on an available local
+// address and returns the host and port it listens on.
+func startReceiver(t *testing.T, sink *consumertest.LogsSink) (string, uint16) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Community = "public"
+	cfg.MIBPaths = []string{"testdata/mibs"}
+	cfg.Security = &SecurityConfig{
+		Username:        "collector",
+		EngineID:        testEngineID,
+		AuthProtocol:    "SHA",
+		AuthPassword:    "authpassword",
+		PrivacyProtocol: "AES",
+		PrivacyPassword: "privacypassword",
+	}
+	return startReceiverWithConfig(t, cfg, sink)
+}
+
+// startReceiverWithConfig starts a receiver with the given config on an
+// available local address and returns the host and port it listens on.
+func startReceiverWithConfig(t *testing.T, cfg *Config, sink *consumertest.LogsSink) (string, uint16) {
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	r, err := newSNMPTrapReceiver(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	})
+
+	host, portStr, err := net.SplitHostPort(cfg.Endpoint)
+	require.NoError(t, err)
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	require.NoError(t, err)
+	return host, uint16(port)
+}
+
+func engineID(t *testing.T) string {
+	id, err := hex.DecodeString(testEngineID)
+	require.NoError(t, err)
+	return string(id)
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
 
 	"github.com/gobwas/glob"
 	"go.opentelemetry.io/collector/config"
@@ -35,6 +36,26 @@ type Config struct {
 	// Path we will listen on, defaults to `*` (anything matches)
 	Path     string `mapstructure:"path"`
 	pathGlob glob.Glob
+	// Tokens are the HEC tokens accepted by the receiver. When empty, requests
+	// are accepted regardless of the token they carry.
+	Tokens []TokenConfig `mapstructure:"tokens"`
+	tokens map[string]*TokenConfig
+	// AllowedOrigins are the origins allowed in the responses to CORS preflight
+	// requests sent by browser based clients. An origin may contain "*" wildcards.
+	// No origin is allowed by default.
+	AllowedOrigins []string `mapstructure:"allowed_origins"`
+	allowedOrigins []glob.Glob
+}
+
+// TokenConfig defines an accepted HEC token and the defaults applied to the
+// events sent with it.
+type TokenConfig struct {
+	// Token is the HEC token value.
+	Token string `mapstructure:"token"`
+	// Index is set on events sent with the token that don't specify an index.
+	Index string `mapstructure:"index"`
+	// SourceType is set on events sent with the token that don't specify a sourcetype.
+	SourceType string `mapstructure:"sourcetype"`
 }
 
 // initialize and initialize the configuration
@@ -48,10 +69,61 @@ func (c *Config) initialize() error {
 		return err
 	}
 	c.pathGlob = glob
+	if err = c.initializeTokens(); err != nil {
+		return err
+	}
+	if err = c.initializeAllowedOrigins(); err != nil {
+		return err
+	}
 	_, err = extractPortFromEndpoint(c.Endpoint)
 	return err
 }
 
+// initializeTokens indexes the accepted tokens by their value.
+func (c *Config) initializeTokens() error {
+	if len(c.Tokens) == 0 {
+		c.tokens = nil
+		return nil
+	}
+	c.tokens = make(map[string]*TokenConfig, len(c.Tokens))
+	for i := range c.Tokens {
+		token := &c.Tokens[i]
+		if token.Token == "" {
+			return fmt.Errorf("tokens[%d]: token must not be empty", i)
+		}
+		if _, ok := c.tokens[token.Token]; ok {
+			return fmt.Errorf("tokens[%d]: duplicate token", i)
+		}
+		c.tokens[token.Token] = token
+	}
+	return nil
+}
+
+// initializeAllowedOrigins compiles the allowed origins, which are matched
+// case insensitively.
+func (c *Config) initializeAllowedOrigins() error {
+	c.allowedOrigins = nil
+	for i, origin := range c.AllowedOrigins {
+		originGlob, err := glob.Compile(strings.ToLower(origin))
+		if err != nil {
+			return fmt.Errorf("allowed_origins[%d]: %w", i, err)
+		}
+		c.allowedOrigins = append(c.allowedOrigins, originGlob)
+	}
+	return nil
+}
+
+// isAllowedOrigin returns whether the origin matches one of the allowed origins.
+func (c *Config) isAllowedOrigin(origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, allowed := range c.allowedOrigins {
+		if allowed.Match(origin) {
+			return true
+		}
+	}
+	return false
+}
+
 // extract the port number from string in "address:port" format. If the
 // port number cannot be extracted returns an error.
 func extractPortFromEndpoint(endpoint string) (int, error) {
@@ -77,6 +77,29 @@ func TestInvalidPathSpaces(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestTokens(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.Tokens = []TokenConfig{
+		{Token: "token1", Index: "index1"},
+		{Token: "token2", SourceType: "sourcetype2"},
+	}
+	err := c.initialize()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*TokenConfig{
+		"token1": {Token: "token1", Index: "index1"},
+		"token2": {Token: "token2", SourceType: "sourcetype2"},
+	}, c.tokens)
+}
+
+func TestInvalidTokens(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.Tokens = []TokenConfig{{Index: "index1"}}
+	assert.EqualError(t, c.initialize(), "tokens[0]: token must not be empty")
+
+	c.Tokens = []TokenConfig{{Token: "token1"}, {Token: "token1"}}
+	assert.EqualError(t, c.initialize(), "tokens[1]: duplicate token")
+}
+
 func TestCreateValidEndpoint(t *testing.T) {
 	endpoint, err := extractPortFromEndpoint("localhost:123")
 	assert.NoError(t, err)
@@ -128,6 +151,17 @@ func TestLoadConfig(t *testing.T) {
 				AccessTokenPassthrough: true,
 			},
 			Path: "/foo",
+			Tokens: []TokenConfig{
+				{
+					Token:      "00000000-0000-0000-0000-000000000000",
+					Index:      "main",
+					SourceType: "otel",
+				},
+				{
+					Token: "11111111-1111-1111-1111-111111111111",
+				},
+			},
+			AllowedOrigins: []string{"https://*.example.com"},
 		})
 
 	r2 := cfg.Receivers[config.NewIDWithName(typeStr, "tls")].(*Config)
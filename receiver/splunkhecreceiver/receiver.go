@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -48,10 +49,20 @@ const (
 	responseErrInternalServerError    = "Internal Server Error"
 	responseErrUnsupportedMetricEvent = "Unsupported metric event"
 	responseErrUnsupportedLogEvent    = "Unsupported log event"
+	responseErrTokenRequired          = "Token is required"
+	responseErrInvalidToken           = "Invalid token"
+
+	// healthPath is the path of the HEC health endpoint, see
+	// https://docs.splunk.com/Documentation/Splunk/latest/RESTREF/RESTinput#services.2Fcollector.2Fhealth
+	healthPath = "/services/collector/health"
 
 	// Centralizing some HTTP and related string constants.
 	gzipEncoding              = "gzip"
 	httpContentEncodingHeader = "Content-Encoding"
+	httpAuthorizationHeader   = "Authorization"
+	splunkAuthorizationPrefix = "Splunk "
+	corsAllowedMethods        = "POST, OPTIONS"
+	corsAllowedHeaders        = "Authorization, Content-Type, Content-Encoding, X-Splunk-Request-Channel"
 )
 
 var (
@@ -68,6 +79,11 @@ var (
 	errInternalServerError    = initJSONResponse(responseErrInternalServerError)
 	errUnsupportedMetricEvent = initJSONResponse(responseErrUnsupportedMetricEvent)
 	errUnsupportedLogEvent    = initJSONResponse(responseErrUnsupportedLogEvent)
+	errTokenRequired          = initJSONResponse(responseErrTokenRequired)
+	errInvalidToken           = initJSONResponse(responseErrInvalidToken)
+
+	// healthyRespBody mirrors the body returned by the Splunk health endpoint.
+	healthyRespBody = []byte(`{"text":"HEC is healthy","code":17}`)
 )
 
 // splunkReceiver implements the component.MetricsReceiver for Splunk HEC metric protocol.
@@ -160,6 +176,7 @@ func (r *splunkReceiver) Start(_ context.Context, host component.Host) error {
 	}
 
 	mx := mux.NewRouter()
+	mx.PathPrefix(healthPath).Methods(http.MethodGet, http.MethodHead).HandlerFunc(r.handleHealth)
 	mx.NewRoute().HandlerFunc(r.handleReq)
 
 	r.server = r.config.HTTPServerSettings.ToServer(mx)
@@ -195,11 +212,21 @@ func (r *splunkReceiver) handleReq(resp http.ResponseWriter, req *http.Request)
 		return
 	}
 
+	if req.Method == http.MethodOptions {
+		r.handlePreflight(resp, req)
+		return
+	}
+
 	if req.Method != http.MethodPost {
 		r.failRequest(ctx, resp, http.StatusBadRequest, invalidMethodRespBody, nil)
 		return
 	}
 
+	tokenConfig, ok := r.authorize(ctx, resp, req)
+	if !ok {
+		return
+	}
+
 	encoding := req.Header.Get(httpContentEncodingHeader)
 	if encoding != "" && encoding != gzipEncoding {
 		r.failRequest(ctx, resp, http.StatusUnsupportedMediaType, invalidEncodingRespBody, nil)
@@ -241,6 +268,14 @@ func (r *splunkReceiver) handleReq(resp http.ResponseWriter, req *http.Request)
 			r.failRequest(ctx, resp, http.StatusBadRequest, errUnsupportedLogEvent, err)
 			return
 		}
+		if tokenConfig != nil {
+			if msg.Index == "" {
+				msg.Index = tokenConfig.Index
+			}
+			if msg.SourceType == "" {
+				msg.SourceType = tokenConfig.SourceType
+			}
+		}
 
 		events = append(events, &msg)
 	}
@@ -251,9 +286,58 @@ func (r *splunkReceiver) handleReq(resp http.ResponseWriter, req *http.Request)
 	}
 }
 
+// handleHealth responds to the HEC health check, so that load balancers and
+// Splunk clients probing the endpoint see the receiver as healthy.
+func (r *splunkReceiver) handleHealth(resp http.ResponseWriter, _ *http.Request) {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+	resp.Write(healthyRespBody)
+}
+
+// handlePreflight responds to CORS preflight requests sent by browser based clients.
+// Only the allowed origins are reflected, the browser rejects the other ones.
+func (r *splunkReceiver) handlePreflight(resp http.ResponseWriter, req *http.Request) {
+	resp.Header().Add("Vary", "Origin")
+	if origin := req.Header.Get("Origin"); origin != "" && r.config.isAllowedOrigin(origin) {
+		resp.Header().Set("Access-Control-Allow-Origin", origin)
+		resp.Header().Set("Access-Control-Allow-Methods", corsAllowedMethods)
+		resp.Header().Set("Access-Control-Allow-Headers", corsAllowedHeaders)
+	}
+	resp.WriteHeader(http.StatusOK)
+}
+
+// authorize checks the request token against the configured tokens. It returns
+// the matching token configuration, or nil when no tokens are configured.
+// When the request isn't authorized, it is failed and false is returned.
+func (r *splunkReceiver) authorize(ctx context.Context, resp http.ResponseWriter, req *http.Request) (*TokenConfig, bool) {
+	if len(r.config.tokens) == 0 {
+		return nil, true
+	}
+	token := hecToken(req)
+	if token == "" {
+		r.failRequest(ctx, resp, http.StatusUnauthorized, errTokenRequired, nil)
+		return nil, false
+	}
+	tokenConfig, ok := r.config.tokens[token]
+	if !ok {
+		r.failRequest(ctx, resp, http.StatusForbidden, errInvalidToken, nil)
+		return nil, false
+	}
+	return tokenConfig, true
+}
+
+// hecToken returns the HEC token of the request, read from the
+// "Authorization: Splunk <token>" header or the "Splunk" header.
+func hecToken(req *http.Request) string {
+	if auth := req.Header.Get(httpAuthorizationHeader); strings.HasPrefix(auth, splunkAuthorizationPrefix) {
+		return strings.TrimPrefix(auth, splunkAuthorizationPrefix)
+	}
+	return req.Header.Get(splunk.HECTokenHeader)
+}
+
 func (r *splunkReceiver) createResourceCustomizer(req *http.Request) func(pdata.Resource) {
 	if r.config.AccessTokenPassthrough {
-		if accessToken := req.Header.Get(splunk.HECTokenHeader); accessToken != "" {
+		if accessToken := hecToken(req); accessToken != "" {
 			return func(resource pdata.Resource) {
 				resource.Attributes().InsertString(splunk.HecTokenLabel, accessToken)
 			}
@@ -538,6 +538,177 @@ func Test_splunkhecReceiver_AccessTokenPassthrough(t *testing.T) {
 	}
 }
 
+func Test_splunkhecReceiver_Tokens(t *testing.T) {
+	tests := []struct {
+		name               string
+		header             string
+		value              string
+		index              string
+		sourcetype         string
+		expectedStatus     int
+		expectedBody       string
+		expectedIndex      string
+		expectedSourcetype string
+	}{
+		{
+			name:           "no token",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   responseErrTokenRequired,
+		},
+		{
+			name:           "invalid token",
+			header:         "Authorization",
+			value:          "Splunk invalid",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   responseErrInvalidToken,
+		},
+		{
+			name:               "token defaults applied",
+			header:             "Authorization",
+			value:              "Splunk token1",
+			expectedStatus:     http.StatusAccepted,
+			expectedBody:       responseOK,
+			expectedIndex:      "index1",
+			expectedSourcetype: "sourcetype1",
+		},
+		{
+			name:               "event values take precedence",
+			header:             "Splunk",
+			value:              "token1",
+			index:              "myindex",
+			sourcetype:         "custom:sourcetype",
+			expectedStatus:     http.StatusAccepted,
+			expectedBody:       responseOK,
+			expectedIndex:      "myindex",
+			expectedSourcetype: "custom:sourcetype",
+		},
+		{
+			name:           "token without defaults",
+			header:         "Authorization",
+			value:          "Splunk token2",
+			expectedStatus: http.StatusAccepted,
+			expectedBody:   responseOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createDefaultConfig().(*Config)
+			config.Endpoint = "localhost:0"
+			config.Tokens = []TokenConfig{
+				{Token: "token1", Index: "index1", SourceType: "sourcetype1"},
+				{Token: "token2"},
+			}
+			require.NoError(t, config.initialize())
+
+			sink := new(consumertest.LogsSink)
+			rcv, err := newLogsReceiver(zap.NewNop(), *config, sink)
+			require.NoError(t, err)
+
+			currentTime := float64(time.Now().UnixNano()) / 1e6
+			splunkhecMsg := buildSplunkHecMsg(currentTime, 3)
+			splunkhecMsg.Index = tt.index
+			splunkhecMsg.SourceType = tt.sourcetype
+			msgBytes, _ := json.Marshal(splunkhecMsg)
+			req := httptest.NewRequest("POST", "http://localhost", bytes.NewReader(msgBytes))
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+
+			r := rcv.(*splunkReceiver)
+			w := httptest.NewRecorder()
+			r.handleReq(w, req)
+
+			resp := w.Result()
+			respBytes, err := ioutil.ReadAll(resp.Body)
+			assert.NoError(t, err)
+
+			var bodyStr string
+			assert.NoError(t, json.Unmarshal(respBytes, &bodyStr))
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectedBody, bodyStr)
+
+			if tt.expectedStatus != http.StatusAccepted {
+				assert.Empty(t, sink.AllLogs())
+				return
+			}
+			require.Len(t, sink.AllLogs(), 1)
+			attrs := sink.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes()
+			if index, ok := attrs.Get(splunk.IndexLabel); ok {
+				assert.Equal(t, tt.expectedIndex, index.StringVal())
+			} else {
+				assert.Empty(t, tt.expectedIndex)
+			}
+			if sourcetype, ok := attrs.Get(splunk.SourcetypeLabel); ok {
+				assert.Equal(t, tt.expectedSourcetype, sourcetype.StringVal())
+			} else {
+				assert.Empty(t, tt.expectedSourcetype)
+			}
+		})
+	}
+}
+
+func Test_splunkhecReceiver_Health(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = testutil.GetAvailableLocalAddress(t)
+	config.Path = "/foo"
+	config.Tokens = []TokenConfig{{Token: "token1"}}
+	require.NoError(t, config.initialize())
+
+	sink := new(consumertest.LogsSink)
+	rcv, err := newLogsReceiver(zap.NewNop(), *config, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	defer rcv.Shutdown(context.Background())
+
+	for _, path := range []string{"/services/collector/health", "/services/collector/health/1.0"} {
+		resp, err := http.Get("http://" + config.Endpoint + path)
+		require.NoError(t, err)
+		respBytes, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.JSONEq(t, `{"text":"HEC is healthy","code":17}`, string(respBytes))
+	}
+}
+
+func Test_splunkhecReceiver_Preflight(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0"
+	config.Tokens = []TokenConfig{{Token: "token1"}}
+	config.AllowedOrigins = []string{"http://example.com", "https://*.example.org"}
+	require.NoError(t, config.initialize())
+
+	sink := new(consumertest.LogsSink)
+	rcv, err := newLogsReceiver(zap.NewNop(), *config, sink)
+	require.NoError(t, err)
+	r := rcv.(*splunkReceiver)
+
+	preflight := func(origin string) *http.Response {
+		req := httptest.NewRequest("OPTIONS", "http://localhost/services/collector", nil)
+		req.Header.Set("Origin", origin)
+		w := httptest.NewRecorder()
+		r.handleReq(w, req)
+		return w.Result()
+	}
+
+	for _, origin := range []string{"http://example.com", "https://app.example.org", "HTTPS://APP.EXAMPLE.ORG"} {
+		resp := preflight(origin)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, origin, resp.Header.Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, corsAllowedMethods, resp.Header.Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, corsAllowedHeaders, resp.Header.Get("Access-Control-Allow-Headers"))
+	}
+
+	for _, origin := range []string{"http://evil.com", "https://example.org", ""} {
+		resp := preflight(origin)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, resp.Header.Get("Access-Control-Allow-Methods"))
+	}
+	assert.Empty(t, sink.AllLogs())
+}
+
 func Test_Logs_splunkhecReceiver_IndexSourceTypePassthrough(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -27,24 +27,41 @@ import (
 
 // Config defines configuration for StatsD receiver.
 type Config struct {
-	config.ReceiverSettings `mapstructure:",squash"`
-	NetAddr                 confignet.NetAddr                `mapstructure:",squash"`
-	AggregationInterval     time.Duration                    `mapstructure:"aggregation_interval"`
-	EnableMetricType        bool                             `mapstructure:"enable_metric_type"`
-	IsMonotonicCounter      bool                             `mapstructure:"is_monotonic_counter"`
-	TimerHistogramMapping   []protocol.TimerHistogramMapping `mapstructure:"timer_histogram_mapping"`
+	config.ReceiverSettings  `mapstructure:",squash"`
+	NetAddr                  confignet.NetAddr                `mapstructure:",squash"`
+	AggregationInterval      time.Duration                    `mapstructure:"aggregation_interval"`
+	EnableMetricType         bool                             `mapstructure:"enable_metric_type"`
+	IsMonotonicCounter       bool                             `mapstructure:"is_monotonic_counter"`
+	TimerHistogramMapping    []protocol.TimerHistogramMapping `mapstructure:"timer_histogram_mapping"`
+	AlignAggregationInterval bool                             `mapstructure:"align_aggregation_interval"`
+	ClientAddress            ClientAddressConfig              `mapstructure:"client_address"`
+}
+
+// ClientAddressConfig defines how metrics are attributed to the client that sent them.
+type ClientAddressConfig struct {
+	// Enabled aggregates the metrics of every client separately and adds the client's
+	// IP address as a resource attribute.
+	Enabled bool `mapstructure:"enabled"`
+	// AttributeName is the name of the resource attribute holding the client address.
+	AttributeName string `mapstructure:"attribute_name"`
+	// Names maps client IP addresses to names that are used as the attribute value instead.
+	Names map[string]string `mapstructure:"names"`
 }
 
 func (c *Config) validate() error {
 
 	var errors []error
-	supportedStatsdType := []string{"timing", "timer", "histogram"}
-	supportedObserverType := []string{"gauge", "summary"}
+	supportedStatsdType := []string{"timing", "timer", "histogram", "distribution"}
+	supportedObserverType := []string{"gauge", "summary", "histogram"}
 
 	if c.AggregationInterval <= 0 {
 		errors = append(errors, fmt.Errorf("aggregation_interval must be a positive duration"))
 	}
 
+	if c.ClientAddress.Enabled && c.ClientAddress.AttributeName == "" {
+		errors = append(errors, fmt.Errorf("client_address.attribute_name must not be empty"))
+	}
+
 	var TimerHistogramMappingMissingObjectName bool
 	for _, eachMap := range c.TimerHistogramMapping {
 
@@ -65,6 +82,14 @@ func (c *Config) validate() error {
 		if !protocol.Contains(supportedObserverType, eachMap.ObserverType) {
 			errors = append(errors, fmt.Errorf("observer_type is not supported: %s", eachMap.ObserverType))
 		}
+
+		bounds := eachMap.Histogram.ExplicitBounds
+		for i := 1; i < len(bounds); i++ {
+			if bounds[i] <= bounds[i-1] {
+				errors = append(errors, fmt.Errorf("histogram explicit_bounds must be increasing: %v", bounds))
+				break
+			}
+		}
 	}
 
 	if TimerHistogramMappingMissingObjectName {
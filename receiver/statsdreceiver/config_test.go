@@ -53,8 +53,19 @@ func TestLoadConfig(t *testing.T) {
 			Endpoint:  "localhost:12345",
 			Transport: "custom_transport",
 		},
-		AggregationInterval:   70 * time.Second,
-		TimerHistogramMapping: []protocol.TimerHistogramMapping{{StatsdType: "histogram", ObserverType: "gauge"}, {StatsdType: "timing", ObserverType: "gauge"}},
+		AggregationInterval: 70 * time.Second,
+		TimerHistogramMapping: []protocol.TimerHistogramMapping{
+			{StatsdType: "histogram", ObserverType: "gauge"},
+			{StatsdType: "timing", ObserverType: "histogram", Histogram: protocol.HistogramConfig{ExplicitBounds: []float64{10, 100, 1000}}},
+		},
+		AlignAggregationInterval: true,
+		ClientAddress: ClientAddressConfig{
+			Enabled:       true,
+			AttributeName: "host.name",
+			Names: map[string]string{
+				"10.0.0.1": "web-1",
+			},
+		},
 	}, r1)
 }
 
@@ -70,6 +81,8 @@ func TestValidate(t *testing.T) {
 		noObjectNameErr                = "must specify object id for all TimerHistogramMappings"
 		statsdTypeNotSupportErr        = "statsd_type is not supported: %s"
 		observerTypeNotSupportErr      = "observer_type is not supported: %s"
+
+		emptyClientAddressAttributeNameErr = "client_address.attribute_name must not be empty"
 	)
 
 	tests := []test{
@@ -123,6 +136,26 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: fmt.Sprintf(observerTypeNotSupportErr, "gauge1"),
 		},
+		{
+			name: "histogramBoundsNotIncreasing",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "timer", ObserverType: "histogram", Histogram: protocol.HistogramConfig{ExplicitBounds: []float64{10, 5}}},
+				},
+			},
+			expectedErr: "histogram explicit_bounds must be increasing: [10 5]",
+		},
+		{
+			name: "emptyClientAddressAttributeName",
+			cfg: &Config{
+				AggregationInterval: 10,
+				ClientAddress: ClientAddressConfig{
+					Enabled: true,
+				},
+			},
+			expectedErr: emptyClientAddressAttributeNameErr,
+		},
 	}
 
 	for _, test := range tests {
@@ -16,13 +16,17 @@ package statsdreceiver
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
@@ -39,6 +43,11 @@ const (
 
 var (
 	defaultTimerHistogramMapping = []protocol.TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}}
+
+	// receivers holds the receiver created for every config, so that the metrics and logs
+	// pipelines of a receiver share the same transport server.
+	receivers     = map[*Config]*statsdReceiver{}
+	receiversLock sync.Mutex
 )
 
 // NewFactory creates a factory for the StatsD receiver.
@@ -47,6 +56,7 @@ func NewFactory() component.ReceiverFactory {
 		typeStr,
 		createDefaultConfig,
 		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithLogs(createLogsReceiver),
 	)
 }
 
@@ -61,6 +71,9 @@ func createDefaultConfig() config.Receiver {
 		EnableMetricType:      defaultEnableMetricType,
 		IsMonotonicCounter:    defaultIsMonotonicCounter,
 		TimerHistogramMapping: defaultTimerHistogramMapping,
+		ClientAddress: ClientAddressConfig{
+			AttributeName: conventions.AttributeNetPeerIP,
+		},
 	}
 }
 
@@ -70,10 +83,60 @@ func createMetricsReceiver(
 	cfg config.Receiver,
 	consumer consumer.Metrics,
 ) (component.MetricsReceiver, error) {
-	c := cfg.(*Config)
+	if consumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	r, err := getOrCreateReceiver(params.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	r.nextConsumer = consumer
+	return r, nil
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	consumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	if consumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	r, err := getOrCreateReceiver(params.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	r.logsConsumer = consumer
+	return r, nil
+}
+
+// getOrCreateReceiver returns the receiver for the given config, creating it if this is the
+// first pipeline it is used in.
+func getOrCreateReceiver(logger *zap.Logger, c *Config) (*statsdReceiver, error) {
+	receiversLock.Lock()
+	defer receiversLock.Unlock()
+
+	if r, ok := receivers[c]; ok {
+		return r, nil
+	}
+
 	err := c.validate()
 	if err != nil {
 		return nil, err
 	}
-	return New(params.Logger, *c, consumer)
+	r, err := newReceiver(logger, *c)
+	if err != nil {
+		return nil, err
+	}
+	r.sharedConfig = c
+	receivers[c] = r
+	return r, nil
+}
+
+// removeReceiver forgets the receiver created for the given config once it is shut down.
+func removeReceiver(c *Config) {
+	receiversLock.Lock()
+	defer receiversLock.Unlock()
+	delete(receivers, c)
 }
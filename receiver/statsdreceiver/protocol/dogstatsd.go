@@ -0,0 +1,261 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DogStatsD extensions of the StatsD protocol, see
+// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell.
+const (
+	dogStatsDEventPrefix        = "_e{"
+	dogStatsDServiceCheckPrefix = "_sc|"
+	dogStatsDContainerIDPrefix  = "c:"
+
+	statsdServiceCheck = "_sc"
+
+	eventTitleAttribute          = "event.title"
+	eventPriorityAttribute       = "event.priority"
+	eventAlertTypeAttribute      = "event.alert_type"
+	eventAggregationKeyAttribute = "event.aggregation_key"
+	eventSourceTypeAttribute     = "event.source_type_name"
+)
+
+var errEmptyEventTitle = errors.New("empty event title")
+
+// aggregateEvent parses a DogStatsD event and keeps it as a log record until the next flush.
+func (p *StatsDParser) aggregateEvent(line string) error {
+	lr, err := parseEvent(line, timeNowFunc())
+	if err != nil {
+		return err
+	}
+	lr.CopyTo(p.events.AppendEmpty())
+	return nil
+}
+
+// aggregateServiceCheck parses a DogStatsD service check and records its status as a gauge.
+func (p *StatsDParser) aggregateServiceCheck(line string) error {
+	parsedMetric, timestamp, err := parseServiceCheck(line, p.enableMetricType, timeNowFunc())
+	if err != nil {
+		return err
+	}
+	p.gauges[parsedMetric.description] = buildGaugeMetric(parsedMetric, timestamp)
+	return nil
+}
+
+// parseEvent parses a DogStatsD event with the format
+// _e{<title length>,<text length>}:<title>|<text>|d:<timestamp>|h:<hostname>|p:<priority>|t:<alert type>|#<tags>
+// into a log record.
+func parseEvent(line string, timeNow time.Time) (pdata.LogRecord, error) {
+	lr := pdata.NewLogRecord()
+
+	end := strings.Index(line, "}:")
+	if end < 0 {
+		return lr, fmt.Errorf("invalid event format: %s", line)
+	}
+	lengths := strings.Split(line[len(dogStatsDEventPrefix):end], ",")
+	if len(lengths) != 2 {
+		return lr, fmt.Errorf("invalid event lengths: %s", line[:end+1])
+	}
+	titleLen, err := strconv.Atoi(lengths[0])
+	if err != nil || titleLen < 0 {
+		return lr, fmt.Errorf("invalid event title length: %s", lengths[0])
+	}
+	textLen, err := strconv.Atoi(lengths[1])
+	if err != nil || textLen < 0 {
+		return lr, fmt.Errorf("invalid event text length: %s", lengths[1])
+	}
+
+	rest := line[end+2:]
+	if len(rest) < titleLen+1+textLen || rest[titleLen] != '|' {
+		return lr, fmt.Errorf("event title and text don't match their lengths: %s", line)
+	}
+	title := rest[:titleLen]
+	if title == "" {
+		return lr, errEmptyEventTitle
+	}
+	text := rest[titleLen+1 : titleLen+1+textLen]
+
+	rest = rest[titleLen+1+textLen:]
+	var parts []string
+	if rest != "" {
+		if rest[0] != '|' {
+			return lr, fmt.Errorf("event title and text don't match their lengths: %s", line)
+		}
+		parts = strings.Split(rest[1:], "|")
+	}
+
+	lr.SetTimestamp(pdata.TimestampFromTime(timeNow))
+	lr.Body().SetStringVal(strings.ReplaceAll(text, `\n`, "\n"))
+	attrs := lr.Attributes()
+	attrs.InsertString(eventTitleAttribute, title)
+
+	alertType := "info"
+	for _, part := range parts {
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			timestamp, err := parseTimestamp(strings.TrimPrefix(part, "d:"))
+			if err != nil {
+				return lr, err
+			}
+			lr.SetTimestamp(pdata.TimestampFromTime(timestamp))
+		case strings.HasPrefix(part, "h:"):
+			attrs.UpsertString(conventions.AttributeHostName, strings.TrimPrefix(part, "h:"))
+		case strings.HasPrefix(part, "p:"):
+			attrs.UpsertString(eventPriorityAttribute, strings.TrimPrefix(part, "p:"))
+		case strings.HasPrefix(part, "t:"):
+			alertType = strings.TrimPrefix(part, "t:")
+		case strings.HasPrefix(part, "k:"):
+			attrs.UpsertString(eventAggregationKeyAttribute, strings.TrimPrefix(part, "k:"))
+		case strings.HasPrefix(part, "s:"):
+			attrs.UpsertString(eventSourceTypeAttribute, strings.TrimPrefix(part, "s:"))
+		case strings.HasPrefix(part, dogStatsDContainerIDPrefix):
+			attrs.UpsertString(conventions.AttributeContainerID, strings.TrimPrefix(part, dogStatsDContainerIDPrefix))
+		case strings.HasPrefix(part, "#"):
+			keys, values, err := parseTags(strings.TrimPrefix(part, "#"))
+			if err != nil {
+				return lr, err
+			}
+			for i, key := range keys {
+				attrs.UpsertString(key, values[i])
+			}
+		default:
+			return lr, fmt.Errorf("unrecognized event part: %s", part)
+		}
+	}
+
+	attrs.UpsertString(eventAlertTypeAttribute, alertType)
+	lr.SetSeverityText(alertType)
+	switch alertType {
+	case "error":
+		lr.SetSeverityNumber(pdata.SeverityNumberERROR)
+	case "warning":
+		lr.SetSeverityNumber(pdata.SeverityNumberWARN)
+	default:
+		lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+	}
+
+	return lr, nil
+}
+
+// parseServiceCheck parses a DogStatsD service check with the format
+// _sc|<name>|<status>|d:<timestamp>|h:<hostname>|#<tags>|m:<message>
+// into a metric whose value is the status: 0 for OK, 1 for WARNING, 2 for CRITICAL and 3 for UNKNOWN.
+// The message is dropped, as it doesn't fit on a metric.
+func parseServiceCheck(line string, enableMetricType bool, timeNow time.Time) (statsDMetric, time.Time, error) {
+	result := statsDMetric{}
+	timestamp := timeNow
+
+	parts := strings.Split(line, "|")
+	if len(parts) < 3 {
+		return result, timestamp, fmt.Errorf("invalid service check format: %s", line)
+	}
+
+	result.description.name = parts[1]
+	if result.description.name == "" {
+		return result, timestamp, errEmptyMetricName
+	}
+	result.description.statsdMetricType = statsdServiceCheck
+	result.value = parts[2]
+	status, err := strconv.Atoi(result.value)
+	if err != nil || status < 0 || status > 3 {
+		return result, timestamp, fmt.Errorf("service check: invalid status: %s", result.value)
+	}
+	result.floatvalue = float64(status)
+
+	var kvs []attribute.KeyValue
+	var sortable attribute.Sortable
+	addLabel := func(key, value string) {
+		result.labelKeys = append(result.labelKeys, key)
+		result.labelValues = append(result.labelValues, value)
+		kvs = append(kvs, attribute.String(key, value))
+	}
+
+	for _, part := range parts[3:] {
+		if strings.HasPrefix(part, "m:") {
+			// The message is always the last part and may contain the separator itself.
+			break
+		}
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			timestamp, err = parseTimestamp(strings.TrimPrefix(part, "d:"))
+			if err != nil {
+				return result, timestamp, err
+			}
+		case strings.HasPrefix(part, "h:"):
+			addLabel(conventions.AttributeHostName, strings.TrimPrefix(part, "h:"))
+		case strings.HasPrefix(part, dogStatsDContainerIDPrefix):
+			addLabel(conventions.AttributeContainerID, strings.TrimPrefix(part, dogStatsDContainerIDPrefix))
+		case strings.HasPrefix(part, "#"):
+			keys, values, err := parseTags(strings.TrimPrefix(part, "#"))
+			if err != nil {
+				return result, timestamp, err
+			}
+			for i, key := range keys {
+				addLabel(key, values[i])
+			}
+		default:
+			return result, timestamp, fmt.Errorf("unrecognized service check part: %s", part)
+		}
+	}
+
+	if enableMetricType {
+		addLabel(tagMetricType, "service_check")
+	}
+
+	if len(kvs) != 0 {
+		set := attribute.NewSetWithSortable(kvs, &sortable)
+		result.description.labels = set.Equivalent()
+	}
+
+	return result, timestamp, nil
+}
+
+// parseTags parses comma separated <key>:<value> tags. The value is everything after the
+// first colon, and bare <key> tags have an empty value.
+func parseTags(tagsStr string) ([]string, []string, error) {
+	var keys, values []string
+	for _, tagSet := range strings.Split(tagsStr, ",") {
+		tagParts := strings.SplitN(tagSet, ":", 2)
+		if tagParts[0] == "" {
+			return nil, nil, fmt.Errorf("invalid tag format: %s", tagSet)
+		}
+		keys = append(keys, tagParts[0])
+		if len(tagParts) == 2 {
+			values = append(values, tagParts[1])
+		} else {
+			values = append(values, "")
+		}
+	}
+	return keys, values, nil
+}
+
+// parseTimestamp parses a Unix timestamp in seconds.
+func parseTimestamp(s string) (time.Time, error) {
+	seconds, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp: %s", s)
+	}
+	return time.Unix(seconds, 0), nil
+}
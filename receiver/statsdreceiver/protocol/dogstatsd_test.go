@@ -0,0 +1,234 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func Test_ParseEvent(t *testing.T) {
+	now := time.Unix(711, 0)
+
+	tests := []struct {
+		name          string
+		input         string
+		wantBody      string
+		wantTime      time.Time
+		wantSeverity  pdata.SeverityNumber
+		wantAttribute map[string]string
+		err           error
+	}{
+		{
+			name:          "title and text",
+			input:         "_e{5,4}:title|text",
+			wantBody:      "text",
+			wantTime:      now,
+			wantSeverity:  pdata.SeverityNumberINFO,
+			wantAttribute: map[string]string{"event.title": "title", "event.alert_type": "info"},
+		},
+		{
+			name:     "all fields",
+			input:    `_e{5,11}:title|line\nline2|d:1000|h:host1|p:low|t:error|k:key|s:src|c:abc123|#k1:v1,k2:v2`,
+			wantBody: "line\nline2",
+			wantTime: time.Unix(1000, 0),
+			wantAttribute: map[string]string{
+				"event.title":            "title",
+				"event.alert_type":       "error",
+				"event.priority":         "low",
+				"event.aggregation_key":  "key",
+				"event.source_type_name": "src",
+				"host.name":              "host1",
+				"container.id":           "abc123",
+				"k1":                     "v1",
+				"k2":                     "v2",
+			},
+			wantSeverity: pdata.SeverityNumberERROR,
+		},
+		{
+			name:          "text containing the separator",
+			input:         "_e{5,3}:title|a|b|t:warning",
+			wantBody:      "a|b",
+			wantTime:      now,
+			wantSeverity:  pdata.SeverityNumberWARN,
+			wantAttribute: map[string]string{"event.title": "title", "event.alert_type": "warning"},
+		},
+		{
+			name:  "missing lengths",
+			input: "_e{title|text",
+			err:   errors.New("invalid event format: _e{title|text"),
+		},
+		{
+			name:  "invalid title length",
+			input: "_e{a,4}:title|text",
+			err:   errors.New("invalid event title length: a"),
+		},
+		{
+			name:  "lengths don't match",
+			input: "_e{5,10}:title|text",
+			err:   errors.New("event title and text don't match their lengths: _e{5,10}:title|text"),
+		},
+		{
+			name:  "empty title",
+			input: "_e{0,4}:|text",
+			err:   errors.New("empty event title"),
+		},
+		{
+			name:  "invalid timestamp",
+			input: "_e{5,4}:title|text|d:abc",
+			err:   errors.New("invalid timestamp: abc"),
+		},
+		{
+			name:  "unrecognized part",
+			input: "_e{5,4}:title|text|x:abc",
+			err:   errors.New("unrecognized event part: x:abc"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr, err := parseEvent(tt.input, now)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBody, lr.Body().StringVal())
+			assert.Equal(t, pdata.TimestampFromTime(tt.wantTime), lr.Timestamp())
+			assert.Equal(t, tt.wantSeverity, lr.SeverityNumber())
+			attrs := map[string]string{}
+			lr.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+				attrs[k] = v.StringVal()
+				return true
+			})
+			assert.Equal(t, tt.wantAttribute, attrs)
+		})
+	}
+}
+
+func Test_ParseServiceCheck(t *testing.T) {
+	now := time.Unix(711, 0)
+
+	tests := []struct {
+		name       string
+		input      string
+		wantMetric statsDMetric
+		wantTime   time.Time
+		err        error
+	}{
+		{
+			name:       "status only",
+			input:      "_sc|app.is_ok|0",
+			wantMetric: testStatsDMetric("app.is_ok", "0", 0, 0, false, "_sc", 0, nil, nil),
+			wantTime:   now,
+		},
+		{
+			name:  "all fields",
+			input: "_sc|app.is_ok|2|d:1000|h:host1|c:abc123|#env:prod|m:failed | with separator",
+			wantMetric: testStatsDMetric("app.is_ok", "2", 0, 2, false, "_sc", 0,
+				[]string{"host.name", "container.id", "env"}, []string{"host1", "abc123", "prod"}),
+			wantTime: time.Unix(1000, 0),
+		},
+		{
+			name:  "bare and colon tags",
+			input: "_sc|app.is_ok|0|#canary,url:http://example.com:8080",
+			wantMetric: testStatsDMetric("app.is_ok", "0", 0, 0, false, "_sc", 0,
+				[]string{"canary", "url"}, []string{"", "http://example.com:8080"}),
+			wantTime: now,
+		},
+		{
+			name:  "empty tag key",
+			input: "_sc|app.is_ok|0|#:prod",
+			err:   errors.New("invalid tag format: :prod"),
+		},
+		{
+			name:  "missing status",
+			input: "_sc|app.is_ok",
+			err:   errors.New("invalid service check format: _sc|app.is_ok"),
+		},
+		{
+			name:  "empty name",
+			input: "_sc||0",
+			err:   errors.New("empty metric name"),
+		},
+		{
+			name:  "invalid status",
+			input: "_sc|app.is_ok|4",
+			err:   errors.New("service check: invalid status: 4"),
+		},
+		{
+			name:  "unrecognized part",
+			input: "_sc|app.is_ok|0|x:abc",
+			err:   errors.New("unrecognized service check part: x:abc"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, timestamp, err := parseServiceCheck(tt.input, false, now)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMetric, got)
+			assert.Equal(t, tt.wantTime, timestamp)
+		})
+	}
+}
+
+func TestStatsDParser_AggregateDogStatsD(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	p.Initialize(false, false, []TimerHistogramMapping{{StatsdType: "distribution", ObserverType: "summary"}})
+	for _, line := range []string{
+		"test.distribution:1|d|#key:value",
+		"test.distribution:2|d|#key:value",
+		"_sc|app.is_ok|0",
+		"_sc|app.is_ok|1",
+		"_e{5,4}:title|text",
+	} {
+		require.NoError(t, p.Aggregate(line))
+	}
+
+	metrics := p.GetMetrics()
+	ilms := metrics.ResourceMetrics().At(0).InstrumentationLibraryMetrics()
+	require.Equal(t, 2, ilms.Len())
+	byName := map[string]pdata.Metric{}
+	for i := 0; i < ilms.Len(); i++ {
+		m := ilms.At(i).Metrics().At(0)
+		byName[m.Name()] = m
+	}
+	require.Contains(t, byName, "test.distribution")
+	assert.Equal(t, pdata.MetricDataTypeSummary, byName["test.distribution"].DataType())
+	assert.Equal(t, uint64(2), byName["test.distribution"].Summary().DataPoints().At(0).Count())
+	require.Contains(t, byName, "app.is_ok")
+	assert.Equal(t, pdata.MetricDataTypeGauge, byName["app.is_ok"].DataType())
+	assert.Equal(t, float64(1), byName["app.is_ok"].Gauge().DataPoints().At(0).DoubleVal())
+
+	logs := p.GetLogs()
+	require.Equal(t, 1, logs.LogRecordCount())
+	lr := logs.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, "text", lr.Body().StringVal())
+	assert.Equal(t, 0, p.GetLogs().LogRecordCount())
+}
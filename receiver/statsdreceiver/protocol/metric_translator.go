@@ -91,3 +91,23 @@ func buildSummaryMetric(summaryMetric summaryMetric) pdata.InstrumentationLibrar
 	return ilm
 
 }
+
+func buildHistogramMetric(histogramMetric histogramMetric) pdata.InstrumentationLibraryMetrics {
+	ilm := pdata.NewInstrumentationLibraryMetrics()
+	nm := ilm.Metrics().AppendEmpty()
+	nm.SetName(histogramMetric.name)
+	nm.SetDataType(pdata.MetricDataTypeHistogram)
+	nm.Histogram().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+
+	dp := nm.Histogram().DataPoints().AppendEmpty()
+	dp.SetCount(histogramMetric.count)
+	dp.SetSum(histogramMetric.sum)
+	dp.SetExplicitBounds(histogramMetric.bounds)
+	dp.SetBucketCounts(histogramMetric.bucketCounts)
+	dp.SetTimestamp(pdata.TimestampFromTime(histogramMetric.timeNow))
+	for i, key := range histogramMetric.labelKeys {
+		dp.Attributes().InsertString(key, histogramMetric.labelValues[i])
+	}
+
+	return ilm
+}
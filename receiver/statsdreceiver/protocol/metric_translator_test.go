@@ -111,3 +111,31 @@ func TestBuildSummaryMetric(t *testing.T) {
 	assert.Equal(t, metric, expectedMetric)
 
 }
+
+func TestBuildHistogramMetric(t *testing.T) {
+	timeNow := time.Now()
+	histogramMetric := histogramMetric{
+		name:         "testHistogram",
+		bounds:       []float64{10, 100},
+		bucketCounts: []uint64{1, 2, 0},
+		count:        3,
+		sum:          105,
+		labelKeys:    []string{"mykey"},
+		labelValues:  []string{"myvalue"},
+		timeNow:      timeNow,
+	}
+	metric := buildHistogramMetric(histogramMetric)
+	expectedMetrics := pdata.NewInstrumentationLibraryMetrics()
+	expectedMetric := expectedMetrics.Metrics().AppendEmpty()
+	expectedMetric.SetName("testHistogram")
+	expectedMetric.SetDataType(pdata.MetricDataTypeHistogram)
+	expectedMetric.Histogram().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+	dp := expectedMetric.Histogram().DataPoints().AppendEmpty()
+	dp.SetCount(3)
+	dp.SetSum(105)
+	dp.SetExplicitBounds([]float64{10, 100})
+	dp.SetBucketCounts([]uint64{1, 2, 0})
+	dp.SetTimestamp(pdata.TimestampFromTime(timeNow))
+	dp.Attributes().InsertString("mykey", "myvalue")
+	assert.Equal(t, metric, expectedMetrics)
+}
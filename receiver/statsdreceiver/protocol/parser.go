@@ -22,5 +22,6 @@ import (
 type Parser interface {
 	Initialize(enableMetricType bool, isMonotonicCounter bool, sendTimerHistogram []TimerHistogramMapping) error
 	GetMetrics() pdata.Metrics
+	GetLogs() pdata.Logs
 	Aggregate(line string) error
 }
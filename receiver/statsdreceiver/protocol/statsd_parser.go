@@ -17,11 +17,13 @@ package protocol
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
 	"go.opentelemetry.io/otel/attribute"
 )
 
@@ -31,20 +33,34 @@ var (
 )
 
 func getSupportedTypes() []string {
-	return []string{"c", "g", "h", "ms"}
+	return []string{"c", "g", "h", "ms", "d"}
 }
 
 const (
-	tagMetricType   = "metric_type"
-	statsdCounter   = "c"
-	statsdGauge     = "g"
-	statsdHistogram = "h"
-	statsdTiming    = "ms"
+	tagMetricType      = "metric_type"
+	statsdCounter      = "c"
+	statsdGauge        = "g"
+	statsdHistogram    = "h"
+	statsdTiming       = "ms"
+	statsdDistribution = "d"
+)
+
+var (
+	// defaultHistogramBounds are the default bucket boundaries of the "histogram" observer type.
+	defaultHistogramBounds = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 1000}
 )
 
 type TimerHistogramMapping struct {
-	StatsdType   string `mapstructure:"statsd_type"`
-	ObserverType string `mapstructure:"observer_type"`
+	StatsdType   string          `mapstructure:"statsd_type"`
+	ObserverType string          `mapstructure:"observer_type"`
+	Histogram    HistogramConfig `mapstructure:"histogram"`
+}
+
+// HistogramConfig configures the histograms of the "histogram" observer type.
+type HistogramConfig struct {
+	// ExplicitBounds are the increasing upper bounds of the histogram buckets, in the unit of the
+	// received values. Defaults to [0, 5, 10, 25, 50, 75, 100, 250, 500, 1000].
+	ExplicitBounds []float64 `mapstructure:"explicit_bounds"`
 }
 
 // StatsDParser supports the Parse method for parsing StatsD messages with Tags.
@@ -52,11 +68,17 @@ type StatsDParser struct {
 	gauges                 map[statsDMetricdescription]pdata.InstrumentationLibraryMetrics
 	counters               map[statsDMetricdescription]pdata.InstrumentationLibraryMetrics
 	summaries              map[statsDMetricdescription]summaryMetric
+	histograms             map[statsDMetricdescription]histogramMetric
 	timersAndDistributions []pdata.InstrumentationLibraryMetrics
 	enableMetricType       bool
 	isMonotonicCounter     bool
 	observeTimer           string
 	observeHistogram       string
+	observeDistribution    string
+	timerBounds            []float64
+	histogramBounds        []float64
+	distributionBounds     []float64
+	events                 pdata.LogSlice
 }
 
 type summaryMetric struct {
@@ -67,6 +89,17 @@ type summaryMetric struct {
 	timeNow       time.Time
 }
 
+type histogramMetric struct {
+	name         string
+	bounds       []float64
+	bucketCounts []uint64
+	count        uint64
+	sum          float64
+	labelKeys    []string
+	labelValues  []string
+	timeNow      time.Time
+}
+
 type statsDMetric struct {
 	description statsDMetricdescription
 	value       string
@@ -90,20 +123,37 @@ func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool
 	p.counters = make(map[statsDMetricdescription]pdata.InstrumentationLibraryMetrics)
 	p.timersAndDistributions = make([]pdata.InstrumentationLibraryMetrics, 0)
 	p.summaries = make(map[statsDMetricdescription]summaryMetric)
+	p.histograms = make(map[statsDMetricdescription]histogramMetric)
+	p.events = pdata.NewLogSlice()
 
 	p.enableMetricType = enableMetricType
 	p.isMonotonicCounter = isMonotonicCounter
+	// Distributions are reported as gauges unless they are mapped explicitly.
+	p.observeDistribution = "gauge"
 	for _, eachMap := range sendTimerHistogram {
 		switch eachMap.StatsdType {
 		case "histogram":
 			p.observeHistogram = eachMap.ObserverType
+			p.histogramBounds = histogramBounds(eachMap.Histogram)
 		case "timer", "timing":
 			p.observeTimer = eachMap.ObserverType
+			p.timerBounds = histogramBounds(eachMap.Histogram)
+		case "distribution":
+			p.observeDistribution = eachMap.ObserverType
+			p.distributionBounds = histogramBounds(eachMap.Histogram)
 		}
 	}
 	return nil
 }
 
+// histogramBounds returns the configured bucket boundaries, or the default ones.
+func histogramBounds(cfg HistogramConfig) []float64 {
+	if len(cfg.ExplicitBounds) == 0 {
+		return defaultHistogramBounds
+	}
+	return cfg.ExplicitBounds
+}
+
 // GetMetrics gets the metrics preparing for flushing and reset the state.
 func (p *StatsDParser) GetMetrics() pdata.Metrics {
 	metrics := pdata.NewMetrics()
@@ -126,19 +176,44 @@ func (p *StatsDParser) GetMetrics() pdata.Metrics {
 		buildSummaryMetric(summaryMetric).CopyTo(tgt)
 	}
 
+	for _, histogramMetric := range p.histograms {
+		tgt := metrics.ResourceMetrics().At(0).InstrumentationLibraryMetrics().AppendEmpty()
+		buildHistogramMetric(histogramMetric).CopyTo(tgt)
+	}
+
 	p.gauges = make(map[statsDMetricdescription]pdata.InstrumentationLibraryMetrics)
 	p.counters = make(map[statsDMetricdescription]pdata.InstrumentationLibraryMetrics)
 	p.timersAndDistributions = make([]pdata.InstrumentationLibraryMetrics, 0)
 	p.summaries = make(map[statsDMetricdescription]summaryMetric)
+	p.histograms = make(map[statsDMetricdescription]histogramMetric)
 	return metrics
 }
 
+// GetLogs gets the DogStatsD events received since the last call as log records and resets them.
+func (p *StatsDParser) GetLogs() pdata.Logs {
+	logs := pdata.NewLogs()
+	if p.events.Len() == 0 {
+		return logs
+	}
+
+	ill := logs.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty()
+	p.events.MoveAndAppendTo(ill.Logs())
+	return logs
+}
+
 var timeNowFunc = func() time.Time {
 	return time.Now()
 }
 
 // Aggregate for each metric line.
 func (p *StatsDParser) Aggregate(line string) error {
+	switch {
+	case strings.HasPrefix(line, dogStatsDEventPrefix):
+		return p.aggregateEvent(line)
+	case strings.HasPrefix(line, dogStatsDServiceCheckPrefix):
+		return p.aggregateServiceCheck(line)
+	}
+
 	parsedMetric, err := parseMessageToMetric(line, p.enableMetricType)
 	if err != nil {
 		return err
@@ -169,61 +244,64 @@ func (p *StatsDParser) Aggregate(line string) error {
 		}
 
 	case statsdHistogram:
-		switch p.observeHistogram {
-		case "gauge":
-			p.timersAndDistributions = append(p.timersAndDistributions, buildGaugeMetric(parsedMetric, timeNowFunc()))
-		case "summary":
-			eachSummaryMetric, ok := p.summaries[parsedMetric.description]
-			if !ok {
-				p.summaries[parsedMetric.description] = summaryMetric{
-					name:          parsedMetric.description.name,
-					summaryPoints: []float64{parsedMetric.floatvalue},
-					labelKeys:     parsedMetric.labelKeys,
-					labelValues:   parsedMetric.labelValues,
-					timeNow:       timeNowFunc(),
-				}
-			} else {
-				points := eachSummaryMetric.summaryPoints
-				p.summaries[parsedMetric.description] = summaryMetric{
-					name:          parsedMetric.description.name,
-					summaryPoints: append(points, parsedMetric.floatvalue),
-					labelKeys:     parsedMetric.labelKeys,
-					labelValues:   parsedMetric.labelValues,
-					timeNow:       timeNowFunc(),
-				}
-			}
-		}
+		p.observe(parsedMetric, p.observeHistogram, p.histogramBounds)
 
 	case statsdTiming:
-		switch p.observeTimer {
-		case "gauge":
-			p.timersAndDistributions = append(p.timersAndDistributions, buildGaugeMetric(parsedMetric, timeNowFunc()))
-		case "summary":
-			eachSummaryMetric, ok := p.summaries[parsedMetric.description]
-			if !ok {
-				p.summaries[parsedMetric.description] = summaryMetric{
-					name:          parsedMetric.description.name,
-					summaryPoints: []float64{parsedMetric.floatvalue},
-					labelKeys:     parsedMetric.labelKeys,
-					labelValues:   parsedMetric.labelValues,
-					timeNow:       timeNowFunc(),
-				}
-			} else {
-				points := eachSummaryMetric.summaryPoints
-				p.summaries[parsedMetric.description] = summaryMetric{
-					name:          parsedMetric.description.name,
-					summaryPoints: append(points, parsedMetric.floatvalue),
-					labelKeys:     parsedMetric.labelKeys,
-					labelValues:   parsedMetric.labelValues,
-					timeNow:       timeNowFunc(),
-				}
-			}
-		}
+		p.observe(parsedMetric, p.observeTimer, p.timerBounds)
+
+	case statsdDistribution:
+		p.observe(parsedMetric, p.observeDistribution, p.distributionBounds)
 	}
 
 	return nil
 }
 
+// observe records a timing, histogram or distribution value as the given observer type.
+// The bounds are the bucket boundaries used by the "histogram" observer type.
+func (p *StatsDParser) observe(parsedMetric statsDMetric, observerType string, bounds []float64) {
+	switch observerType {
+	case "gauge":
+		p.timersAndDistributions = append(p.timersAndDistributions, buildGaugeMetric(parsedMetric, timeNowFunc()))
+	case "summary":
+		eachSummaryMetric, ok := p.summaries[parsedMetric.description]
+		if !ok {
+			p.summaries[parsedMetric.description] = summaryMetric{
+				name:          parsedMetric.description.name,
+				summaryPoints: []float64{parsedMetric.floatvalue},
+				labelKeys:     parsedMetric.labelKeys,
+				labelValues:   parsedMetric.labelValues,
+				timeNow:       timeNowFunc(),
+			}
+		} else {
+			points := eachSummaryMetric.summaryPoints
+			p.summaries[parsedMetric.description] = summaryMetric{
+				name:          parsedMetric.description.name,
+				summaryPoints: append(points, parsedMetric.floatvalue),
+				labelKeys:     parsedMetric.labelKeys,
+				labelValues:   parsedMetric.labelValues,
+				timeNow:       timeNowFunc(),
+			}
+		}
+	case "histogram":
+		eachHistogramMetric, ok := p.histograms[parsedMetric.description]
+		if !ok {
+			eachHistogramMetric = histogramMetric{
+				name:         parsedMetric.description.name,
+				bounds:       bounds,
+				bucketCounts: make([]uint64, len(bounds)+1),
+				labelKeys:    parsedMetric.labelKeys,
+				labelValues:  parsedMetric.labelValues,
+			}
+		}
+		// Buckets include their upper bound, the last bucket counts the values above all bounds.
+		eachHistogramMetric.bucketCounts[sort.SearchFloat64s(bounds, parsedMetric.floatvalue)]++
+		eachHistogramMetric.count++
+		eachHistogramMetric.sum += parsedMetric.floatvalue
+		eachHistogramMetric.timeNow = timeNowFunc()
+		p.histograms[parsedMetric.description] = eachHistogramMetric
+	}
+}
+
 func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, error) {
 	result := statsDMetric{}
 
@@ -273,18 +351,21 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 		} else if strings.HasPrefix(part, "#") {
 			tagsStr := strings.TrimPrefix(part, "#")
 
-			tagSets := strings.Split(tagsStr, ",")
-
-			for _, tagSet := range tagSets {
-				tagParts := strings.Split(tagSet, ":")
-				if len(tagParts) != 2 {
-					return result, fmt.Errorf("invalid tag format: %s", tagParts)
-				}
-				result.labelKeys = append(result.labelKeys, tagParts[0])
-				result.labelValues = append(result.labelValues, tagParts[1])
-				kvs = append(kvs, attribute.String(tagParts[0], tagParts[1]))
+			keys, values, err := parseTags(tagsStr)
+			if err != nil {
+				return result, err
+			}
+			for i, key := range keys {
+				result.labelKeys = append(result.labelKeys, key)
+				result.labelValues = append(result.labelValues, values[i])
+				kvs = append(kvs, attribute.String(key, values[i]))
 			}
 
+		} else if strings.HasPrefix(part, dogStatsDContainerIDPrefix) {
+			containerID := strings.TrimPrefix(part, dogStatsDContainerIDPrefix)
+			result.labelKeys = append(result.labelKeys, conventions.AttributeContainerID)
+			result.labelValues = append(result.labelValues, containerID)
+			kvs = append(kvs, attribute.String(conventions.AttributeContainerID, containerID))
 		} else {
 			return result, fmt.Errorf("unrecognized message part: %s", part)
 		}
@@ -306,7 +387,7 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 			i = int64(f / result.sampleRate)
 		}
 		result.intvalue = i
-	case statsdHistogram, statsdTiming:
+	case statsdHistogram, statsdTiming, statsdDistribution:
 		f, err := strconv.ParseFloat(result.value, 64)
 		if err != nil {
 			return result, fmt.Errorf("timing/histogram: parse metric value string: %s", result.value)
@@ -330,6 +411,8 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 			metricType = "timing"
 		case statsdHistogram:
 			metricType = "histogram"
+		case statsdDistribution:
+			metricType = "distribution"
 		}
 		result.labelKeys = append(result.labelKeys, tagMetricType)
 		result.labelValues = append(result.labelValues, metricType)
@@ -59,8 +59,50 @@ func Test_ParseMessageToMetric(t *testing.T) {
 		},
 		{
 			name:  "invalid tag format",
-			input: "test.metric:42|c|#key1",
-			err:   errors.New("invalid tag format: [key1]"),
+			input: "test.metric:42|c|#:value",
+			err:   errors.New("invalid tag format: :value"),
+		},
+		{
+			name:  "counter metric with bare tag",
+			input: "test.metric:42|c|#env",
+			wantMetric: testStatsDMetric(
+				"test.metric",
+				"42",
+				42,
+				0,
+				false,
+				"c",
+				0,
+				[]string{"env"},
+				[]string{""}),
+		},
+		{
+			name:  "gauge metric with colon in tag value",
+			input: "test.metric:42|g|#url:http://x",
+			wantMetric: testStatsDMetric(
+				"test.metric",
+				"42",
+				0,
+				42,
+				false,
+				"g",
+				0,
+				[]string{"url"},
+				[]string{"http://x"}),
+		},
+		{
+			name:  "distribution metric with bare and colon-valued tags",
+			input: "test.metric:42|d|#env,url:http://x",
+			wantMetric: testStatsDMetric(
+				"test.metric",
+				"42",
+				0,
+				42,
+				false,
+				"d",
+				0,
+				[]string{"env", "url"},
+				[]string{"", "http://x"}),
 		},
 		{
 			name:  "unrecognized message part",
@@ -78,6 +120,20 @@ func Test_ParseMessageToMetric(t *testing.T) {
 				false,
 				"c", 0, nil, nil),
 		},
+		{
+			name:  "distribution metric with tag and container id",
+			input: "test.metric:42|d|#key:value|c:abc123",
+			wantMetric: testStatsDMetric(
+				"test.metric",
+				"42",
+				0,
+				42,
+				false,
+				"d",
+				0,
+				[]string{"key", "container.id"},
+				[]string{"value", "abc123"}),
+		},
 		{
 			name:  "invalid  counter metric value",
 			input: "test.metric:42.abc|c",
@@ -951,6 +1007,53 @@ func TestStatsDParser_AggregateTimmerWithSummary(t *testing.T) {
 	}
 }
 
+func TestStatsDParser_AggregateTimerWithHistogram(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	p.Initialize(false, false, []TimerHistogramMapping{
+		{StatsdType: "timer", ObserverType: "histogram", Histogram: HistogramConfig{ExplicitBounds: []float64{10, 100}}},
+		{StatsdType: "histogram", ObserverType: "histogram"},
+	})
+	for _, line := range []string{
+		"statsdTestMetric1:1|ms|#mykey:myvalue",
+		"statsdTestMetric1:10|ms|#mykey:myvalue",
+		"statsdTestMetric1:50|ms|#mykey:myvalue",
+		"statsdTestMetric1:500|ms|#mykey:myvalue",
+		"statsdTestMetric2:7|h",
+	} {
+		assert.NoError(t, p.Aggregate(line))
+	}
+
+	assert.Equal(t, map[statsDMetricdescription]histogramMetric{
+		testDescription("statsdTestMetric1", "ms",
+			[]string{"mykey"}, []string{"myvalue"}): {
+			name:         "statsdTestMetric1",
+			bounds:       []float64{10, 100},
+			bucketCounts: []uint64{2, 1, 1},
+			count:        4,
+			sum:          561,
+			labelKeys:    []string{"mykey"},
+			labelValues:  []string{"myvalue"},
+			timeNow:      time.Unix(711, 0),
+		},
+		{name: "statsdTestMetric2", statsdMetricType: "h"}: {
+			name:         "statsdTestMetric2",
+			bounds:       defaultHistogramBounds,
+			bucketCounts: []uint64{0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0},
+			count:        1,
+			sum:          7,
+			timeNow:      time.Unix(711, 0),
+		},
+	}, p.histograms)
+
+	metrics := p.GetMetrics()
+	assert.Equal(t, 2, metrics.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Len())
+	assert.Empty(t, p.histograms)
+}
+
 func TestStatsDParser_Initialize(t *testing.T) {
 	p := &StatsDParser{}
 	p.Initialize(true, false, []TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}})
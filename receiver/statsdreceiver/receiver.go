@@ -17,7 +17,9 @@ package statsdreceiver
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -31,17 +33,28 @@ import (
 )
 
 var _ component.MetricsReceiver = (*statsdReceiver)(nil)
+var _ component.LogsReceiver = (*statsdReceiver)(nil)
 
-// statsdReceiver implements the component.MetricsReceiver for StatsD protocol.
+// statsdReceiver implements the component.MetricsReceiver and component.LogsReceiver for
+// StatsD protocol. DogStatsD events are sent to the logs pipelines.
 type statsdReceiver struct {
 	logger *zap.Logger
 	config *Config
+	// sharedConfig is the config the receiver is registered under in receivers, if any.
+	sharedConfig *Config
 
 	server       transport.Server
 	reporter     transport.Reporter
 	parser       protocol.Parser
 	nextConsumer consumer.Metrics
+	logsConsumer consumer.Logs
 	cancel       context.CancelFunc
+
+	startOnce    sync.Once
+	shutdownOnce sync.Once
+
+	// clientParsers holds a parser per client when metrics are aggregated per client address.
+	clientParsers map[string]protocol.Parser
 }
 
 // New creates the StatsD receiver with the given parameters.
@@ -54,6 +67,15 @@ func New(
 		return nil, componenterror.ErrNilNextConsumer
 	}
 
+	r, err := newReceiver(logger, config)
+	if err != nil {
+		return nil, err
+	}
+	r.nextConsumer = nextConsumer
+	return r, nil
+}
+
+func newReceiver(logger *zap.Logger, config Config) (*statsdReceiver, error) {
 	if config.NetAddr.Endpoint == "" {
 		config.NetAddr.Endpoint = "localhost:8125"
 	}
@@ -64,12 +86,13 @@ func New(
 	}
 
 	r := &statsdReceiver{
-		logger:       logger,
-		config:       &config,
-		nextConsumer: nextConsumer,
-		server:       server,
-		reporter:     newReporter(config.ID(), logger),
-		parser:       &protocol.StatsDParser{},
+		logger:   logger,
+		config:   &config,
+		server:   server,
+		reporter: newReporter(config.ID(), logger),
+		parser:   &protocol.StatsDParser{},
+
+		clientParsers: map[string]protocol.Parser{},
 	}
 	return r, nil
 }
@@ -84,11 +107,19 @@ func buildTransportServer(config Config) (transport.Server, error) {
 	return nil, fmt.Errorf("unsupported transport %q for receiver %v", config.NetAddr.Transport, config.ID())
 }
 
-// Start starts a UDP server that can process StatsD messages.
+// Start starts a UDP server that can process StatsD messages. The server is only started once
+// when the receiver is used in both metrics and logs pipelines.
 func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
+	r.startOnce.Do(func() {
+		r.start(ctx, host)
+	})
+	return nil
+}
+
+func (r *statsdReceiver) start(ctx context.Context, host component.Host) {
 	ctx, r.cancel = context.WithCancel(ctx)
-	var transferChan = make(chan string, 10)
-	ticker := time.NewTicker(r.config.AggregationInterval)
+	var transferChan = make(chan transport.Metric, 10)
+	flushTimer := time.NewTimer(r.nextFlushDelay(time.Now()))
 	r.parser.Initialize(r.config.EnableMetricType, r.config.IsMonotonicCounter, r.config.TimerHistogramMapping)
 	go func() {
 		if err := r.server.ListenAndServe(r.parser, r.nextConsumer, r.reporter, transferChan); err != nil {
@@ -98,27 +129,128 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 	go func() {
 		for {
 			select {
-			case <-ticker.C:
-				metrics := r.parser.GetMetrics()
-				if metrics.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Len() > 0 {
+			case <-flushTimer.C:
+				// Logs are collected first, as collecting the metrics forgets idle clients.
+				logs := r.getLogs()
+				if r.logsConsumer != nil && logs.LogRecordCount() > 0 {
+					r.logsConsumer.ConsumeLogs(ctx, logs)
+				}
+				metrics := r.getMetrics()
+				if r.nextConsumer != nil && metrics.MetricCount() > 0 {
 					r.Flush(ctx, metrics, r.nextConsumer)
 				}
+				flushTimer.Reset(r.nextFlushDelay(time.Now()))
 			case rawMetric := <-transferChan:
-				r.parser.Aggregate(rawMetric)
+				r.parserFor(rawMetric.Addr).Aggregate(rawMetric.Raw)
 			case <-ctx.Done():
-				ticker.Stop()
+				flushTimer.Stop()
 				return
 			}
 		}
 	}()
+}
 
-	return nil
+// nextFlushDelay returns the time to wait before the next aggregation flush.
+func (r *statsdReceiver) nextFlushDelay(now time.Time) time.Duration {
+	interval := r.config.AggregationInterval
+	if !r.config.AlignAggregationInterval {
+		return interval
+	}
+	return now.Truncate(interval).Add(interval).Sub(now)
+}
+
+// parserFor returns the parser aggregating the metrics sent from addr.
+func (r *statsdReceiver) parserFor(addr net.Addr) protocol.Parser {
+	if !r.config.ClientAddress.Enabled {
+		return r.parser
+	}
+
+	client := r.clientName(addr)
+	parser, ok := r.clientParsers[client]
+	if !ok {
+		parser = &protocol.StatsDParser{}
+		parser.Initialize(r.config.EnableMetricType, r.config.IsMonotonicCounter, r.config.TimerHistogramMapping)
+		r.clientParsers[client] = parser
+	}
+	return parser
+}
+
+// clientName returns the IP address of the client at addr, or the name it is mapped to.
+func (r *statsdReceiver) clientName(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+
+	var ip string
+	if udpAddr, ok := addr.(*net.UDPAddr); ok {
+		ip = udpAddr.IP.String()
+	} else if host, _, err := net.SplitHostPort(addr.String()); err == nil {
+		ip = host
+	} else {
+		ip = addr.String()
+	}
+
+	if name, ok := r.config.ClientAddress.Names[ip]; ok {
+		return name
+	}
+	return ip
+}
+
+// getMetrics gets the metrics aggregated since the last flush. When metrics are aggregated
+// per client, every client gets its own resource, and clients that didn't send any metrics
+// are forgotten.
+func (r *statsdReceiver) getMetrics() pdata.Metrics {
+	if !r.config.ClientAddress.Enabled {
+		return r.parser.GetMetrics()
+	}
+
+	metrics := pdata.NewMetrics()
+	for client, parser := range r.clientParsers {
+		clientMetrics := parser.GetMetrics()
+		if clientMetrics.MetricCount() == 0 {
+			delete(r.clientParsers, client)
+			continue
+		}
+		rms := clientMetrics.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			rms.At(i).Resource().Attributes().UpsertString(r.config.ClientAddress.AttributeName, client)
+		}
+		rms.MoveAndAppendTo(metrics.ResourceMetrics())
+	}
+	return metrics
+}
+
+// getLogs gets the DogStatsD events received since the last flush. When metrics are aggregated
+// per client, the events of every client get their own resource.
+func (r *statsdReceiver) getLogs() pdata.Logs {
+	if !r.config.ClientAddress.Enabled {
+		return r.parser.GetLogs()
+	}
+
+	logs := pdata.NewLogs()
+	for client, parser := range r.clientParsers {
+		clientLogs := parser.GetLogs()
+		rls := clientLogs.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			rls.At(i).Resource().Attributes().UpsertString(r.config.ClientAddress.AttributeName, client)
+		}
+		rls.MoveAndAppendTo(logs.ResourceLogs())
+	}
+	return logs
 }
 
 // Shutdown stops the StatsD receiver.
 func (r *statsdReceiver) Shutdown(context.Context) error {
-	err := r.server.Close()
-	r.cancel()
+	var err error
+	r.shutdownOnce.Do(func() {
+		err = r.server.Close()
+		if r.cancel != nil {
+			r.cancel()
+		}
+		if r.sharedConfig != nil {
+			removeReceiver(r.sharedConfig)
+		}
+	})
 	return err
 }
 
@@ -92,6 +92,83 @@ func TestStatsdReceiver_Flush(t *testing.T) {
 	r.Shutdown(ctx)
 }
 
+func TestStatsdReceiver_nextFlushDelay(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:0"
+	cfg.AggregationInterval = time.Minute
+	rcv, err := New(zap.NewNop(), *cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	r := rcv.(*statsdReceiver)
+	defer r.Shutdown(context.Background())
+
+	now := time.Date(2021, 8, 1, 12, 0, 15, 0, time.UTC)
+	assert.Equal(t, time.Minute, r.nextFlushDelay(now))
+
+	r.config.AlignAggregationInterval = true
+	assert.Equal(t, 45*time.Second, r.nextFlushDelay(now))
+	assert.Equal(t, time.Minute, r.nextFlushDelay(now.Add(45*time.Second)))
+}
+
+func TestStatsdReceiver_clientAddress(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:0"
+	cfg.ClientAddress.Enabled = true
+	cfg.ClientAddress.Names = map[string]string{"10.0.0.2": "web-2"}
+	rcv, err := New(zap.NewNop(), *cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	r := rcv.(*statsdReceiver)
+	defer r.Shutdown(context.Background())
+
+	client1 := &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 50000}
+	client2 := &net.UDPAddr{IP: net.ParseIP("10.0.0.2"), Port: 50001}
+	require.NoError(t, r.parserFor(client1).Aggregate("test.metric:1|c"))
+	require.NoError(t, r.parserFor(&net.UDPAddr{IP: client1.IP, Port: 50002}).Aggregate("test.metric:2|c"))
+	require.NoError(t, r.parserFor(client2).Aggregate("test.metric:3|c"))
+
+	metrics := r.getMetrics()
+	require.Equal(t, 2, metrics.ResourceMetrics().Len())
+	values := map[string]int64{}
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		client, ok := rm.Resource().Attributes().Get("net.peer.ip")
+		require.True(t, ok)
+		require.Equal(t, 1, rm.InstrumentationLibraryMetrics().Len())
+		metric := rm.InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+		values[client.StringVal()] = metric.Sum().DataPoints().At(0).IntVal()
+	}
+	assert.Equal(t, map[string]int64{"10.0.0.1": 3, "web-2": 3}, values)
+
+	// Clients that didn't send anything since the last flush are forgotten.
+	assert.Equal(t, 0, r.getMetrics().MetricCount())
+	assert.Empty(t, r.clientParsers)
+}
+
+func TestStatsdReceiver_clientAddressEvents(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:0"
+	cfg.ClientAddress.Enabled = true
+	rcv, err := New(zap.NewNop(), *cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	r := rcv.(*statsdReceiver)
+	defer r.Shutdown(context.Background())
+
+	client := &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 50000}
+	require.NoError(t, r.parserFor(client).Aggregate("_e{5,4}:title|text"))
+
+	logs := r.getLogs()
+	require.Equal(t, 1, logs.ResourceLogs().Len())
+	rl := logs.ResourceLogs().At(0)
+	clientAttr, ok := rl.Resource().Attributes().Get("net.peer.ip")
+	require.True(t, ok)
+	assert.Equal(t, "10.0.0.1", clientAttr.StringVal())
+	assert.Equal(t, 1, logs.LogRecordCount())
+
+	// Clients that only sent events are forgotten once the events were flushed.
+	assert.Equal(t, 0, r.getLogs().LogRecordCount())
+	assert.Equal(t, 0, r.getMetrics().MetricCount())
+	assert.Empty(t, r.clientParsers)
+}
+
 func Test_statsdreceiver_EndToEnd(t *testing.T) {
 	addr := testutil.GetAvailableLocalAddress(t)
 	host, portStr, err := net.SplitHostPort(addr)
@@ -17,6 +17,7 @@ package transport
 import (
 	"context"
 	"errors"
+	"net"
 
 	"go.opentelemetry.io/collector/consumer"
 
@@ -24,7 +25,7 @@ import (
 )
 
 var (
-	errNilListenAndServeParameters = errors.New("no parameter of ListenAndServe can be nil")
+	errNilListenAndServeParameters = errors.New("parser and reporter parameters of ListenAndServe can't be nil")
 )
 
 // Server abstracts the type of transport being used and offer an
@@ -37,7 +38,7 @@ type Server interface {
 		p protocol.Parser,
 		mc consumer.Metrics,
 		r Reporter,
-		transferChan chan<- string,
+		transferChan chan<- Metric,
 	) error
 
 	// Close stops any running ListenAndServe, however, it waits for any
@@ -45,6 +46,12 @@ type Server interface {
 	Close() error
 }
 
+// Metric is a raw StatsD message line along with the address of the client that sent it.
+type Metric struct {
+	Raw  string
+	Addr net.Addr
+}
+
 // Reporter is used to report (via zPages, logs, metrics, etc) the events
 // happening when the Server is receiving and processing data.
 type Reporter interface {
@@ -49,9 +49,10 @@ func (u *udpServer) ListenAndServe(
 	parser protocol.Parser,
 	nextConsumer consumer.Metrics,
 	reporter Reporter,
-	transferChan chan<- string,
+	transferChan chan<- Metric,
 ) error {
-	if parser == nil || nextConsumer == nil || reporter == nil {
+	// The metrics consumer may be nil when the receiver is only used in logs pipelines.
+	if parser == nil || reporter == nil {
 		return errNilListenAndServeParameters
 	}
 
@@ -59,11 +60,11 @@ func (u *udpServer) ListenAndServe(
 
 	buf := make([]byte, 65527) // max size for udp packet body (assuming ipv6)
 	for {
-		n, _, err := u.packetConn.ReadFrom(buf)
+		n, addr, err := u.packetConn.ReadFrom(buf)
 		if n > 0 {
 			bufCopy := make([]byte, n)
 			copy(bufCopy, buf)
-			u.handlePacket(bufCopy, transferChan)
+			u.handlePacket(bufCopy, addr, transferChan)
 		}
 		if err != nil {
 			u.reporter.OnDebugf("UDP Transport (%s) - ReadFrom error: %v",
@@ -85,7 +86,8 @@ func (u *udpServer) Close() error {
 
 func (u *udpServer) handlePacket(
 	data []byte,
-	transferChan chan<- string,
+	addr net.Addr,
+	transferChan chan<- Metric,
 ) {
 	buf := bytes.NewBuffer(data)
 	for {
@@ -98,7 +100,7 @@ func (u *udpServer) handlePacket(
 		}
 		line := strings.TrimSpace(string(bytes))
 		if line != "" {
-			transferChan <- line
+			transferChan <- Metric{Raw: line, Addr: addr}
 		}
 	}
 }
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
@@ -27,4 +28,12 @@ type Config struct {
 
 	// Timeout within which requests should be completed.
 	Timeout time.Duration `mapstructure:"timeout"`
+
+	// TLS configures the connection to a secure client port. The commands are
+	// sent in plain text when not set.
+	TLS *configtls.TLSClientSetting `mapstructure:"tls"`
+
+	// ClientMetrics enables the per-client connection metrics, collected with
+	// the `cons` command.
+	ClientMetrics bool `mapstructure:"client_metrics"`
 }
@@ -55,66 +55,96 @@ func (m *metricImpl) Init(metric pdata.Metric) {
 }
 
 type metricStruct struct {
-	ZookeeperApproximateDateSize   MetricIntf
-	ZookeeperConnectionsAlive      MetricIntf
-	ZookeeperEphemeralNodes        MetricIntf
-	ZookeeperFollowers             MetricIntf
-	ZookeeperFsyncThresholdExceeds MetricIntf
-	ZookeeperLatencyAvg            MetricIntf
-	ZookeeperLatencyMax            MetricIntf
-	ZookeeperLatencyMin            MetricIntf
-	ZookeeperMaxFileDescriptors    MetricIntf
-	ZookeeperOpenFileDescriptors   MetricIntf
-	ZookeeperOutstandingRequests   MetricIntf
-	ZookeeperPacketsReceived       MetricIntf
-	ZookeeperPacketsSent           MetricIntf
-	ZookeeperPendingSyncs          MetricIntf
-	ZookeeperSyncedFollowers       MetricIntf
-	ZookeeperWatches               MetricIntf
-	ZookeeperZnodes                MetricIntf
+	ZookeeperApproximateDateSize       MetricIntf
+	ZookeeperClientConnections         MetricIntf
+	ZookeeperClientLatencyMax          MetricIntf
+	ZookeeperClientRequestsOutstanding MetricIntf
+	ZookeeperConnectionsAlive          MetricIntf
+	ZookeeperElectionTimeAvg           MetricIntf
+	ZookeeperElectionTimeMax           MetricIntf
+	ZookeeperElectionTimeMin           MetricIntf
+	ZookeeperElections                 MetricIntf
+	ZookeeperEphemeralNodes            MetricIntf
+	ZookeeperFollowers                 MetricIntf
+	ZookeeperFsyncThresholdExceeds     MetricIntf
+	ZookeeperLatencyAvg                MetricIntf
+	ZookeeperLatencyMax                MetricIntf
+	ZookeeperLatencyMin                MetricIntf
+	ZookeeperLearners                  MetricIntf
+	ZookeeperMaxFileDescriptors        MetricIntf
+	ZookeeperOpenFileDescriptors       MetricIntf
+	ZookeeperOutstandingRequests       MetricIntf
+	ZookeeperPacketsReceived           MetricIntf
+	ZookeeperPacketsSent               MetricIntf
+	ZookeeperPendingSyncs              MetricIntf
+	ZookeeperQuorumSize                MetricIntf
+	ZookeeperSyncedFollowers           MetricIntf
+	ZookeeperSyncedObservers           MetricIntf
+	ZookeeperWatches                   MetricIntf
+	ZookeeperZnodes                    MetricIntf
 }
 
 // Names returns a list of all the metric name strings.
 func (m *metricStruct) Names() []string {
 	return []string{
 		"zookeeper.approximate_date_size",
+		"zookeeper.client.connections",
+		"zookeeper.client.latency.max",
+		"zookeeper.client.requests.outstanding",
 		"zookeeper.connections_alive",
+		"zookeeper.election_time.avg",
+		"zookeeper.election_time.max",
+		"zookeeper.election_time.min",
+		"zookeeper.elections",
 		"zookeeper.ephemeral_nodes",
 		"zookeeper.followers",
 		"zookeeper.fsync_threshold_exceeds",
 		"zookeeper.latency.avg",
 		"zookeeper.latency.max",
 		"zookeeper.latency.min",
+		"zookeeper.learners",
 		"zookeeper.max_file_descriptors",
 		"zookeeper.open_file_descriptors",
 		"zookeeper.outstanding_requests",
 		"zookeeper.packets.received",
 		"zookeeper.packets.sent",
 		"zookeeper.pending_syncs",
+		"zookeeper.quorum.size",
 		"zookeeper.synced_followers",
+		"zookeeper.synced_observers",
 		"zookeeper.watches",
 		"zookeeper.znodes",
 	}
 }
 
 var metricsByName = map[string]MetricIntf{
-	"zookeeper.approximate_date_size":   Metrics.ZookeeperApproximateDateSize,
-	"zookeeper.connections_alive":       Metrics.ZookeeperConnectionsAlive,
-	"zookeeper.ephemeral_nodes":         Metrics.ZookeeperEphemeralNodes,
-	"zookeeper.followers":               Metrics.ZookeeperFollowers,
-	"zookeeper.fsync_threshold_exceeds": Metrics.ZookeeperFsyncThresholdExceeds,
-	"zookeeper.latency.avg":             Metrics.ZookeeperLatencyAvg,
-	"zookeeper.latency.max":             Metrics.ZookeeperLatencyMax,
-	"zookeeper.latency.min":             Metrics.ZookeeperLatencyMin,
-	"zookeeper.max_file_descriptors":    Metrics.ZookeeperMaxFileDescriptors,
-	"zookeeper.open_file_descriptors":   Metrics.ZookeeperOpenFileDescriptors,
-	"zookeeper.outstanding_requests":    Metrics.ZookeeperOutstandingRequests,
-	"zookeeper.packets.received":        Metrics.ZookeeperPacketsReceived,
-	"zookeeper.packets.sent":            Metrics.ZookeeperPacketsSent,
-	"zookeeper.pending_syncs":           Metrics.ZookeeperPendingSyncs,
-	"zookeeper.synced_followers":        Metrics.ZookeeperSyncedFollowers,
-	"zookeeper.watches":                 Metrics.ZookeeperWatches,
-	"zookeeper.znodes":                  Metrics.ZookeeperZnodes,
+	"zookeeper.approximate_date_size":       Metrics.ZookeeperApproximateDateSize,
+	"zookeeper.client.connections":          Metrics.ZookeeperClientConnections,
+	"zookeeper.client.latency.max":          Metrics.ZookeeperClientLatencyMax,
+	"zookeeper.client.requests.outstanding": Metrics.ZookeeperClientRequestsOutstanding,
+	"zookeeper.connections_alive":           Metrics.ZookeeperConnectionsAlive,
+	"zookeeper.election_time.avg":           Metrics.ZookeeperElectionTimeAvg,
+	"zookeeper.election_time.max":           Metrics.ZookeeperElectionTimeMax,
+	"zookeeper.election_time.min":           Metrics.ZookeeperElectionTimeMin,
+	"zookeeper.elections":                   Metrics.ZookeeperElections,
+	"zookeeper.ephemeral_nodes":             Metrics.ZookeeperEphemeralNodes,
+	"zookeeper.followers":                   Metrics.ZookeeperFollowers,
+	"zookeeper.fsync_threshold_exceeds":     Metrics.ZookeeperFsyncThresholdExceeds,
+	"zookeeper.latency.avg":                 Metrics.ZookeeperLatencyAvg,
+	"zookeeper.latency.max":                 Metrics.ZookeeperLatencyMax,
+	"zookeeper.latency.min":                 Metrics.ZookeeperLatencyMin,
+	"zookeeper.learners":                    Metrics.ZookeeperLearners,
+	"zookeeper.max_file_descriptors":        Metrics.ZookeeperMaxFileDescriptors,
+	"zookeeper.open_file_descriptors":       Metrics.ZookeeperOpenFileDescriptors,
+	"zookeeper.outstanding_requests":        Metrics.ZookeeperOutstandingRequests,
+	"zookeeper.packets.received":            Metrics.ZookeeperPacketsReceived,
+	"zookeeper.packets.sent":                Metrics.ZookeeperPacketsSent,
+	"zookeeper.pending_syncs":               Metrics.ZookeeperPendingSyncs,
+	"zookeeper.quorum.size":                 Metrics.ZookeeperQuorumSize,
+	"zookeeper.synced_followers":            Metrics.ZookeeperSyncedFollowers,
+	"zookeeper.synced_observers":            Metrics.ZookeeperSyncedObservers,
+	"zookeeper.watches":                     Metrics.ZookeeperWatches,
+	"zookeeper.znodes":                      Metrics.ZookeeperZnodes,
 }
 
 func (m *metricStruct) ByName(n string) MetricIntf {
@@ -123,23 +153,33 @@ func (m *metricStruct) ByName(n string) MetricIntf {
 
 func (m *metricStruct) FactoriesByName() map[string]func(pdata.Metric) {
 	return map[string]func(pdata.Metric){
-		Metrics.ZookeeperApproximateDateSize.Name():   Metrics.ZookeeperApproximateDateSize.Init,
-		Metrics.ZookeeperConnectionsAlive.Name():      Metrics.ZookeeperConnectionsAlive.Init,
-		Metrics.ZookeeperEphemeralNodes.Name():        Metrics.ZookeeperEphemeralNodes.Init,
-		Metrics.ZookeeperFollowers.Name():             Metrics.ZookeeperFollowers.Init,
-		Metrics.ZookeeperFsyncThresholdExceeds.Name(): Metrics.ZookeeperFsyncThresholdExceeds.Init,
-		Metrics.ZookeeperLatencyAvg.Name():            Metrics.ZookeeperLatencyAvg.Init,
-		Metrics.ZookeeperLatencyMax.Name():            Metrics.ZookeeperLatencyMax.Init,
-		Metrics.ZookeeperLatencyMin.Name():            Metrics.ZookeeperLatencyMin.Init,
-		Metrics.ZookeeperMaxFileDescriptors.Name():    Metrics.ZookeeperMaxFileDescriptors.Init,
-		Metrics.ZookeeperOpenFileDescriptors.Name():   Metrics.ZookeeperOpenFileDescriptors.Init,
-		Metrics.ZookeeperOutstandingRequests.Name():   Metrics.ZookeeperOutstandingRequests.Init,
-		Metrics.ZookeeperPacketsReceived.Name():       Metrics.ZookeeperPacketsReceived.Init,
-		Metrics.ZookeeperPacketsSent.Name():           Metrics.ZookeeperPacketsSent.Init,
-		Metrics.ZookeeperPendingSyncs.Name():          Metrics.ZookeeperPendingSyncs.Init,
-		Metrics.ZookeeperSyncedFollowers.Name():       Metrics.ZookeeperSyncedFollowers.Init,
-		Metrics.ZookeeperWatches.Name():               Metrics.ZookeeperWatches.Init,
-		Metrics.ZookeeperZnodes.Name():                Metrics.ZookeeperZnodes.Init,
+		Metrics.ZookeeperApproximateDateSize.Name():       Metrics.ZookeeperApproximateDateSize.Init,
+		Metrics.ZookeeperClientConnections.Name():         Metrics.ZookeeperClientConnections.Init,
+		Metrics.ZookeeperClientLatencyMax.Name():          Metrics.ZookeeperClientLatencyMax.Init,
+		Metrics.ZookeeperClientRequestsOutstanding.Name(): Metrics.ZookeeperClientRequestsOutstanding.Init,
+		Metrics.ZookeeperConnectionsAlive.Name():          Metrics.ZookeeperConnectionsAlive.Init,
+		Metrics.ZookeeperElectionTimeAvg.Name():           Metrics.ZookeeperElectionTimeAvg.Init,
+		Metrics.ZookeeperElectionTimeMax.Name():           Metrics.ZookeeperElectionTimeMax.Init,
+		Metrics.ZookeeperElectionTimeMin.Name():           Metrics.ZookeeperElectionTimeMin.Init,
+		Metrics.ZookeeperElections.Name():                 Metrics.ZookeeperElections.Init,
+		Metrics.ZookeeperEphemeralNodes.Name():            Metrics.ZookeeperEphemeralNodes.Init,
+		Metrics.ZookeeperFollowers.Name():                 Metrics.ZookeeperFollowers.Init,
+		Metrics.ZookeeperFsyncThresholdExceeds.Name():     Metrics.ZookeeperFsyncThresholdExceeds.Init,
+		Metrics.ZookeeperLatencyAvg.Name():                Metrics.ZookeeperLatencyAvg.Init,
+		Metrics.ZookeeperLatencyMax.Name():                Metrics.ZookeeperLatencyMax.Init,
+		Metrics.ZookeeperLatencyMin.Name():                Metrics.ZookeeperLatencyMin.Init,
+		Metrics.ZookeeperLearners.Name():                  Metrics.ZookeeperLearners.Init,
+		Metrics.ZookeeperMaxFileDescriptors.Name():        Metrics.ZookeeperMaxFileDescriptors.Init,
+		Metrics.ZookeeperOpenFileDescriptors.Name():       Metrics.ZookeeperOpenFileDescriptors.Init,
+		Metrics.ZookeeperOutstandingRequests.Name():       Metrics.ZookeeperOutstandingRequests.Init,
+		Metrics.ZookeeperPacketsReceived.Name():           Metrics.ZookeeperPacketsReceived.Init,
+		Metrics.ZookeeperPacketsSent.Name():               Metrics.ZookeeperPacketsSent.Init,
+		Metrics.ZookeeperPendingSyncs.Name():              Metrics.ZookeeperPendingSyncs.Init,
+		Metrics.ZookeeperQuorumSize.Name():                Metrics.ZookeeperQuorumSize.Init,
+		Metrics.ZookeeperSyncedFollowers.Name():           Metrics.ZookeeperSyncedFollowers.Init,
+		Metrics.ZookeeperSyncedObservers.Name():           Metrics.ZookeeperSyncedObservers.Init,
+		Metrics.ZookeeperWatches.Name():                   Metrics.ZookeeperWatches.Init,
+		Metrics.ZookeeperZnodes.Name():                    Metrics.ZookeeperZnodes.Init,
 	}
 }
 
@@ -155,6 +195,33 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"zookeeper.client.connections",
+		func(metric pdata.Metric) {
+			metric.SetName("zookeeper.client.connections")
+			metric.SetDescription("Number of connections from a client.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"zookeeper.client.latency.max",
+		func(metric pdata.Metric) {
+			metric.SetName("zookeeper.client.latency.max")
+			metric.SetDescription("Maximum time in milliseconds for the requests of a client to be processed.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"zookeeper.client.requests.outstanding",
+		func(metric pdata.Metric) {
+			metric.SetName("zookeeper.client.requests.outstanding")
+			metric.SetDescription("Number of requests queued on the connections from a client.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"zookeeper.connections_alive",
 		func(metric pdata.Metric) {
@@ -164,6 +231,44 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"zookeeper.election_time.avg",
+		func(metric pdata.Metric) {
+			metric.SetName("zookeeper.election_time.avg")
+			metric.SetDescription("Average time in milliseconds taken by leader elections.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"zookeeper.election_time.max",
+		func(metric pdata.Metric) {
+			metric.SetName("zookeeper.election_time.max")
+			metric.SetDescription("Maximum time in milliseconds taken by leader elections.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"zookeeper.election_time.min",
+		func(metric pdata.Metric) {
+			metric.SetName("zookeeper.election_time.min")
+			metric.SetDescription("Minimum time in milliseconds taken by leader elections.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"zookeeper.elections",
+		func(metric pdata.Metric) {
+			metric.SetName("zookeeper.elections")
+			metric.SetDescription("Number of leader elections the server took part in.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
 	&metricImpl{
 		"zookeeper.ephemeral_nodes",
 		func(metric pdata.Metric) {
@@ -220,6 +325,15 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"zookeeper.learners",
+		func(metric pdata.Metric) {
+			metric.SetName("zookeeper.learners")
+			metric.SetDescription("Number of learners (followers and observers) connected to the leader. Only exposed by the leader.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"zookeeper.max_file_descriptors",
 		func(metric pdata.Metric) {
@@ -278,6 +392,15 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"zookeeper.quorum.size",
+		func(metric pdata.Metric) {
+			metric.SetName("zookeeper.quorum.size")
+			metric.SetDescription("Number of voting servers in the ensemble.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"zookeeper.synced_followers",
 		func(metric pdata.Metric) {
@@ -287,6 +410,15 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"zookeeper.synced_observers",
+		func(metric pdata.Metric) {
+			metric.SetName("zookeeper.synced_observers")
+			metric.SetDescription("The number of observers in sync with the leader. Only exposed by the leader.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"zookeeper.watches",
 		func(metric pdata.Metric) {
@@ -313,11 +445,14 @@ var M = Metrics
 
 // Labels contains the possible metric labels that can be used.
 var Labels = struct {
+	// ClientAddress (Address of the client the connections are from.)
+	ClientAddress string
 	// ServerState (State of the Zookeeper server (leader, standalone or follower).)
 	ServerState string
 	// ZkVersion (Zookeeper version of the instance.)
 	ZkVersion string
 }{
+	"client.address",
 	"server.state",
 	"zk.version",
 }
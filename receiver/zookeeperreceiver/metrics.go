@@ -42,6 +42,15 @@ const (
 	syncedFollowersMetricKey = "zk_synced_followers"
 	pendingSyncsMetricKey    = "zk_pending_syncs"
 
+	// Only exposed by ZooKeeper 3.6 and later.
+	quorumSizeMetricKey        = "zk_quorum_size"
+	learnersMetricKey          = "zk_learners"
+	syncedObserversMetricKey   = "zk_synced_observers"
+	avgElectionTimeMetricKey   = "zk_avg_election_time"
+	maxElectionTimeMetricKey   = "zk_max_election_time"
+	minElectionTimeMetricKey   = "zk_min_election_time"
+	countElectionTimeMetricKey = "zk_cnt_election_time"
+
 	serverStateKey = "zk_server_state"
 	zkVersionKey   = "zk_version"
 )
@@ -54,6 +63,20 @@ func getOTLPInitFunc(metric string) func(metric pdata.Metric) {
 		return metadata.Metrics.ZookeeperSyncedFollowers.Init
 	case pendingSyncsMetricKey:
 		return metadata.Metrics.ZookeeperPendingSyncs.Init
+	case quorumSizeMetricKey:
+		return metadata.Metrics.ZookeeperQuorumSize.Init
+	case learnersMetricKey:
+		return metadata.Metrics.ZookeeperLearners.Init
+	case syncedObserversMetricKey:
+		return metadata.Metrics.ZookeeperSyncedObservers.Init
+	case avgElectionTimeMetricKey:
+		return metadata.Metrics.ZookeeperElectionTimeAvg.Init
+	case maxElectionTimeMetricKey:
+		return metadata.Metrics.ZookeeperElectionTimeMax.Init
+	case minElectionTimeMetricKey:
+		return metadata.Metrics.ZookeeperElectionTimeMin.Init
+	case countElectionTimeMetricKey:
+		return metadata.Metrics.ZookeeperElections.Init
 	case avgLatencyMetricKey:
 		return metadata.Metrics.ZookeeperLatencyAvg.Init
 	case maxLatencyMetricKey:
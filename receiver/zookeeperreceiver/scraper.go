@@ -17,14 +17,18 @@ package zookeeperreceiver
 import (
 	"bufio"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"math"
 	"net"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/zookeeperreceiver/internal/metadata"
@@ -32,14 +36,23 @@ import (
 
 var zookeeperFormatRE = regexp.MustCompile(`(^zk_\w+)\s+([\w\.\-]+)`)
 
+// zookeeperConsRE matches the connections listed by the "cons" command, e.g.
+// " /127.0.0.1:54312[1](queued=0,recved=3,sent=3,...,maxlat=2)".
+var zookeeperConsRE = regexp.MustCompile(`^\s*/(.+):\d+\[\d+\]\((.*)\)\s*$`)
+
 const (
 	mntrCommand = "mntr"
+	consCommand = "cons"
+
+	// Number of metrics reported from the "cons" command.
+	numClientMetrics = 3
 )
 
 type zookeeperMetricsScraper struct {
-	logger *zap.Logger
-	config *Config
-	cancel context.CancelFunc
+	logger    *zap.Logger
+	config    *Config
+	tlsConfig *tls.Config
+	cancel    context.CancelFunc
 
 	// For mocking.
 	closeConnection       func(net.Conn) error
@@ -61,9 +74,18 @@ func newZookeeperMetricsScraper(logger *zap.Logger, config *Config) (*zookeeperM
 		return nil, errors.New("timeout must be a positive duration")
 	}
 
+	var tlsConfig *tls.Config
+	if config.TLS != nil {
+		tlsConfig, err = config.TLS.LoadTLSConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+	}
+
 	return &zookeeperMetricsScraper{
 		logger:                logger,
 		config:                config,
+		tlsConfig:             tlsConfig,
 		closeConnection:       closeConnection,
 		setConnectionDeadline: setConnectionDeadline,
 		sendCmd:               sendCmd,
@@ -79,13 +101,39 @@ func (z *zookeeperMetricsScraper) scrape(ctx context.Context) (pdata.ResourceMet
 	var ctxWithTimeout context.Context
 	ctxWithTimeout, z.cancel = context.WithTimeout(ctx, z.config.Timeout)
 
-	conn, err := z.config.Dial()
+	md := pdata.NewMetrics()
+	err := z.runCommand(ctxWithTimeout, mntrCommand, func(scanner *bufio.Scanner) {
+		z.appendMetrics(scanner, md.ResourceMetrics())
+	})
+	if err != nil {
+		return pdata.NewResourceMetricsSlice(), err
+	}
+
+	// The per-client metrics are added to the resource of the server, which
+	// is only known from the "mntr" output.
+	if !z.config.ClientMetrics || md.ResourceMetrics().Len() == 0 {
+		return md.ResourceMetrics(), nil
+	}
+	err = z.runCommand(ctxWithTimeout, consCommand, func(scanner *bufio.Scanner) {
+		ilm := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0)
+		z.appendClientMetrics(scanner, ilm.Metrics())
+	})
+	if err != nil {
+		return md.ResourceMetrics(), scrapererror.NewPartialScrapeError(err, numClientMetrics)
+	}
+	return md.ResourceMetrics(), nil
+}
+
+// runCommand sends the command on a new connection, as the server closes
+// the connection after responding, and passes the response to handle.
+func (z *zookeeperMetricsScraper) runCommand(ctx context.Context, cmd string, handle func(*bufio.Scanner)) error {
+	conn, err := z.dial()
 	if err != nil {
 		z.logger.Error("failed to establish connection",
 			zap.String("endpoint", z.config.Endpoint),
 			zap.Error(err),
 		)
-		return pdata.NewResourceMetricsSlice(), err
+		return err
 	}
 	defer func() {
 		if closeErr := z.closeConnection(conn); closeErr != nil {
@@ -93,29 +141,32 @@ func (z *zookeeperMetricsScraper) scrape(ctx context.Context) (pdata.ResourceMet
 		}
 	}()
 
-	deadline, ok := ctxWithTimeout.Deadline()
+	deadline, ok := ctx.Deadline()
 	if ok {
 		if err := z.setConnectionDeadline(conn, deadline); err != nil {
 			z.logger.Warn("failed to set deadline on connection", zap.Error(err))
 		}
 	}
 
-	return z.getResourceMetrics(conn)
-}
-
-func (z *zookeeperMetricsScraper) getResourceMetrics(conn net.Conn) (pdata.ResourceMetricsSlice, error) {
-	scanner, err := z.sendCmd(conn, mntrCommand)
+	scanner, err := z.sendCmd(conn, cmd)
 	if err != nil {
 		z.logger.Error("failed to send command",
 			zap.Error(err),
-			zap.String("command", mntrCommand),
+			zap.String("command", cmd),
 		)
-		return pdata.NewResourceMetricsSlice(), err
+		return err
 	}
 
-	md := pdata.NewMetrics()
-	z.appendMetrics(scanner, md.ResourceMetrics())
-	return md.ResourceMetrics(), nil
+	handle(scanner)
+	return nil
+}
+
+func (z *zookeeperMetricsScraper) dial() (net.Conn, error) {
+	if z.tlsConfig == nil {
+		return z.config.Dial()
+	}
+	dialer := &net.Dialer{Timeout: z.config.Timeout}
+	return tls.DialWithDialer(dialer, "tcp", z.config.Endpoint, z.tlsConfig)
 }
 
 func (z *zookeeperMetricsScraper) appendMetrics(scanner *bufio.Scanner, rms pdata.ResourceMetricsSlice) {
@@ -151,7 +202,7 @@ func (z *zookeeperMetricsScraper) appendMetrics(scanner *bufio.Scanner, rms pdat
 				// Unexported metric, just move to the next line.
 				continue
 			}
-			int64Val, err := strconv.ParseInt(metricValue, 10, 64)
+			int64Val, err := parseInt(metricValue)
 			if err != nil {
 				z.logger.Debug(
 					fmt.Sprintf("non-integer value from %s", mntrCommand),
@@ -179,6 +230,97 @@ func (z *zookeeperMetricsScraper) appendMetrics(scanner *bufio.Scanner, rms pdat
 	}
 }
 
+// clientStats aggregates the connections of a client listed by the "cons" command.
+type clientStats struct {
+	connections int64
+	queued      int64
+	maxLatency  int64
+}
+
+func (z *zookeeperMetricsScraper) appendClientMetrics(scanner *bufio.Scanner, metrics pdata.MetricSlice) {
+	now := pdata.TimestampFromTime(time.Now())
+	var addresses []string
+	clients := map[string]*clientStats{}
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		parts := zookeeperConsRE.FindStringSubmatch(line)
+		if len(parts) != 3 {
+			z.logger.Warn("unexpected line in response",
+				zap.String("command", consCommand),
+				zap.String("line", line),
+			)
+			continue
+		}
+
+		address := parts[1]
+		stats, ok := clients[address]
+		if !ok {
+			stats = &clientStats{}
+			clients[address] = stats
+			addresses = append(addresses, address)
+		}
+		stats.connections++
+		for _, field := range strings.Split(parts[2], ",") {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			// Fields missing from connections without requests yet are left unset.
+			val, err := strconv.ParseInt(kv[1], 10, 64)
+			if err != nil {
+				continue
+			}
+			switch kv[0] {
+			case "queued":
+				stats.queued += val
+			case "maxlat":
+				if val > stats.maxLatency {
+					stats.maxLatency = val
+				}
+			}
+		}
+	}
+
+	if len(addresses) == 0 {
+		return
+	}
+	for _, m := range []struct {
+		init  func(pdata.Metric)
+		value func(*clientStats) int64
+	}{
+		{metadata.Metrics.ZookeeperClientConnections.Init, func(s *clientStats) int64 { return s.connections }},
+		{metadata.Metrics.ZookeeperClientRequestsOutstanding.Init, func(s *clientStats) int64 { return s.queued }},
+		{metadata.Metrics.ZookeeperClientLatencyMax.Init, func(s *clientStats) int64 { return s.maxLatency }},
+	} {
+		metric := metrics.AppendEmpty()
+		m.init(metric)
+		dps := metric.Gauge().DataPoints()
+		for _, address := range addresses {
+			dp := dps.AppendEmpty()
+			dp.SetTimestamp(now)
+			dp.LabelsMap().Upsert(metadata.Labels.ClientAddress, address)
+			dp.SetIntVal(m.value(clients[address]))
+		}
+	}
+}
+
+// parseInt parses the value of a "mntr" entry. Averages are reported as
+// decimals by ZooKeeper 3.6 and later, in which case they are rounded.
+func parseInt(value string) (int64, error) {
+	int64Val, err := strconv.ParseInt(value, 10, 64)
+	if err == nil {
+		return int64Val, nil
+	}
+	float64Val, floatErr := strconv.ParseFloat(value, 64)
+	if floatErr != nil {
+		return 0, err
+	}
+	return int64(math.Round(float64Val)), nil
+}
+
 func closeConnection(conn net.Conn) error {
 	return conn.Close()
 }
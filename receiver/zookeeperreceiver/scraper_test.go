@@ -16,16 +16,20 @@ package zookeeperreceiver
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"crypto/tls"
 	"errors"
 	"io/ioutil"
 	"net"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/testutil"
 	"go.uber.org/zap"
@@ -66,6 +70,7 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 		expectedMetrics              []pdata.Metric
 		expectedResourceAttributes   map[string]string
 		mockedZKOutputSourceFilename string
+		mockedConsOutputFilename     string
 		mockZKConnectionErr          bool
 		expectedLogs                 []logMsg
 		expectedNumResourceMetrics   int
@@ -104,6 +109,43 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 			},
 			expectedNumResourceMetrics: 1,
 		},
+		{
+			name:                         "Test correctness with v3.6.2 and client metrics",
+			mockedZKOutputSourceFilename: "mntr-3.6.2",
+			mockedConsOutputFilename:     "cons-3.6.2",
+			expectedMetrics: []pdata.Metric{
+				metadata.Metrics.ZookeeperEphemeralNodes.New(),
+				metadata.Metrics.ZookeeperLatencyMin.New(),
+				metadata.Metrics.ZookeeperLatencyAvg.New(),
+				metadata.Metrics.ZookeeperLatencyMax.New(),
+				metadata.Metrics.ZookeeperPacketsReceived.New(),
+				metadata.Metrics.ZookeeperPacketsSent.New(),
+				metadata.Metrics.ZookeeperConnectionsAlive.New(),
+				metadata.Metrics.ZookeeperOutstandingRequests.New(),
+				metadata.Metrics.ZookeeperZnodes.New(),
+				metadata.Metrics.ZookeeperWatches.New(),
+				metadata.Metrics.ZookeeperApproximateDateSize.New(),
+				metadata.Metrics.ZookeeperOpenFileDescriptors.New(),
+				metadata.Metrics.ZookeeperMaxFileDescriptors.New(),
+				metadata.Metrics.ZookeeperLearners.New(),
+				metadata.Metrics.ZookeeperSyncedFollowers.New(),
+				metadata.Metrics.ZookeeperSyncedObservers.New(),
+				metadata.Metrics.ZookeeperPendingSyncs.New(),
+				metadata.Metrics.ZookeeperQuorumSize.New(),
+				metadata.Metrics.ZookeeperElectionTimeAvg.New(),
+				metadata.Metrics.ZookeeperElectionTimeMin.New(),
+				metadata.Metrics.ZookeeperElectionTimeMax.New(),
+				metadata.Metrics.ZookeeperElections.New(),
+				metadata.Metrics.ZookeeperClientConnections.New(),
+				metadata.Metrics.ZookeeperClientRequestsOutstanding.New(),
+				metadata.Metrics.ZookeeperClientLatencyMax.New(),
+			},
+			expectedResourceAttributes: map[string]string{
+				"server.state": "leader",
+				"zk.version":   "3.6.2--803c7f1a12f85978cb049af5e4ef23bd8b688715",
+			},
+			expectedNumResourceMetrics: 1,
+		},
 		{
 			name:                "Arbitrary connection error",
 			mockZKConnectionErr: true,
@@ -193,8 +235,12 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			localAddr := testutil.GetAvailableLocalAddress(t)
 			if !tt.mockZKConnectionErr {
+				responses := map[string]string{mntrCommand: tt.mockedZKOutputSourceFilename}
+				if tt.mockedConsOutputFilename != "" {
+					responses[consCommand] = tt.mockedConsOutputFilename
+				}
 				ms := mockedServer{ready: make(chan bool, 1)}
-				go ms.mockZKServer(t, localAddr, tt.mockedZKOutputSourceFilename)
+				go ms.mockZKServer(t, net.Listen, localAddr, responses)
 				<-ms.ready
 			}
 
@@ -202,7 +248,8 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 				TCPAddr: confignet.TCPAddr{
 					Endpoint: localAddr,
 				},
-				Timeout: defaultTimeout,
+				Timeout:       defaultTimeout,
+				ClientMetrics: tt.mockedConsOutputFilename != "",
 			}
 
 			core, observedLogs := observer.New(zap.DebugLevel)
@@ -286,22 +333,117 @@ type mockedServer struct {
 	ready chan bool
 }
 
-func (ms *mockedServer) mockZKServer(t *testing.T, endpoint string, filename string) {
-	listener, err := net.Listen("tcp", endpoint)
+// mockZKServer serves one connection per command, responding with the content
+// of the testdata file mapped to the command received.
+func (ms *mockedServer) mockZKServer(
+	t *testing.T, listen func(network, address string) (net.Listener, error),
+	endpoint string, responses map[string]string) {
+	listener, err := listen("tcp", endpoint)
 	require.NoError(t, err)
 	defer listener.Close()
 
 	ms.ready <- true
 
-	conn, err := listener.Accept()
-	require.NoError(t, err)
+	for range responses {
+		conn, err := listener.Accept()
+		require.NoError(t, err)
 
-	for {
-		out, err := ioutil.ReadFile(path.Join(".", "testdata", filename))
+		cmd, err := bufio.NewReader(conn).ReadString('\n')
+		if err != nil {
+			// The command was not sent.
+			conn.Close()
+			return
+		}
+
+		out, err := ioutil.ReadFile(path.Join(".", "testdata", responses[strings.TrimSpace(cmd)]))
 		require.NoError(t, err)
 
 		conn.Write(out)
 		conn.Close()
-		return
+	}
+}
+
+func TestZookeeperMetricsScraperScrapeTLS(t *testing.T) {
+	cert, err := tls.LoadX509KeyPair(path.Join(".", "testdata", "testcert.crt"), path.Join(".", "testdata", "testkey.key"))
+	require.NoError(t, err)
+	listenTLS := func(network, address string) (net.Listener, error) {
+		return tls.Listen(network, address, &tls.Config{Certificates: []tls.Certificate{cert}})
+	}
+
+	localAddr := testutil.GetAvailableLocalAddress(t)
+	ms := mockedServer{ready: make(chan bool, 1)}
+	go ms.mockZKServer(t, listenTLS, localAddr, map[string]string{mntrCommand: "mntr-3.5.5"})
+	<-ms.ready
+
+	cfg := &Config{
+		TCPAddr: confignet.TCPAddr{
+			Endpoint: localAddr,
+		},
+		Timeout: defaultTimeout,
+		TLS: &configtls.TLSClientSetting{
+			InsecureSkipVerify: true,
+		},
+	}
+	z, err := newZookeeperMetricsScraper(zap.NewNop(), cfg)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	got, err := z.scrape(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, got.Len())
+	require.Equal(t, 16, got.At(0).InstrumentationLibraryMetrics().At(0).Metrics().Len())
+	require.NoError(t, z.shutdown(ctx))
+}
+
+func TestNewZookeeperMetricsScraperInvalidTLS(t *testing.T) {
+	cfg := &Config{
+		TCPAddr: confignet.TCPAddr{
+			Endpoint: ":2281",
+		},
+		Timeout: defaultTimeout,
+		TLS: &configtls.TLSClientSetting{
+			TLSSetting: configtls.TLSSetting{
+				CAFile: path.Join(".", "testdata", "missing.crt"),
+			},
+		},
+	}
+	_, err := newZookeeperMetricsScraper(zap.NewNop(), cfg)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to load TLS config")
+}
+
+func TestAppendClientMetrics(t *testing.T) {
+	out, err := ioutil.ReadFile(path.Join(".", "testdata", "cons-3.6.2"))
+	require.NoError(t, err)
+
+	z := &zookeeperMetricsScraper{logger: zap.NewNop()}
+	metrics := pdata.NewMetricSlice()
+	z.appendClientMetrics(bufio.NewScanner(bytes.NewReader(out)), metrics)
+
+	expected := map[string]map[string]int64{
+		"zookeeper.client.connections": {
+			"10.0.0.12":       2,
+			"0:0:0:0:0:0:0:1": 1,
+		},
+		"zookeeper.client.requests.outstanding": {
+			"10.0.0.12":       2,
+			"0:0:0:0:0:0:0:1": 0,
+		},
+		"zookeeper.client.latency.max": {
+			"10.0.0.12":       9,
+			"0:0:0:0:0:0:0:1": 0,
+		},
+	}
+	require.Equal(t, len(expected), metrics.Len())
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		dps := metric.Gauge().DataPoints()
+		got := map[string]int64{}
+		for j := 0; j < dps.Len(); j++ {
+			address, ok := dps.At(j).LabelsMap().Get(metadata.Labels.ClientAddress)
+			require.True(t, ok)
+			got[address] = dps.At(j).IntVal()
+		}
+		require.Equal(t, expected[metric.Name()], got, metric.Name())
 	}
 }